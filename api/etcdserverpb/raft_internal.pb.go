@@ -105,6 +105,8 @@ type InternalRaftRequest struct {
 	ClusterVersionSet        *membershippb.ClusterVersionSetRequest    `protobuf:"bytes,1300,opt,name=cluster_version_set,json=clusterVersionSet,proto3" json:"cluster_version_set,omitempty"`
 	ClusterMemberAttrSet     *membershippb.ClusterMemberAttrSetRequest `protobuf:"bytes,1301,opt,name=cluster_member_attr_set,json=clusterMemberAttrSet,proto3" json:"cluster_member_attr_set,omitempty"`
 	DowngradeInfoSet         *membershippb.DowngradeInfoSetRequest     `protobuf:"bytes,1302,opt,name=downgrade_info_set,json=downgradeInfoSet,proto3" json:"downgrade_info_set,omitempty"`
+	ClusterMetadataSet       *membershippb.ClusterMetadataSetRequest   `protobuf:"bytes,1303,opt,name=cluster_metadata_set,json=clusterMetadataSet,proto3" json:"cluster_metadata_set,omitempty"`
+	ValuePolicy              *ValuePolicyRequest                       `protobuf:"bytes,1400,opt,name=value_policy,json=valuePolicy,proto3" json:"value_policy,omitempty"`
 	DowngradeVersionTest     *DowngradeVersionTestRequest              `protobuf:"bytes,9900,opt,name=downgrade_version_test,json=downgradeVersionTest,proto3" json:"downgrade_version_test,omitempty"`
 	XXX_NoUnkeyedLiteral     struct{}                                  `json:"-"`
 	XXX_unrecognized         []byte                                    `json:"-"`
@@ -239,76 +241,80 @@ func init() {
 func init() { proto.RegisterFile("raft_internal.proto", fileDescriptor_b4c9a9be0cfca103) }
 
 var fileDescriptor_b4c9a9be0cfca103 = []byte{
-	// 1101 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xcb, 0x72, 0x1b, 0x45,
-	0x14, 0x8d, 0x6c, 0xc7, 0xb6, 0x5a, 0xb6, 0xe3, 0xb4, 0x9d, 0xa4, 0xb1, 0xab, 0x8c, 0xe3, 0x90,
-	0x60, 0x20, 0xc8, 0xc1, 0x06, 0xaa, 0x60, 0x03, 0x8a, 0xe5, 0x72, 0x4c, 0x25, 0x29, 0xd7, 0xc4,
-	0x50, 0x29, 0x28, 0x6a, 0x68, 0xcd, 0x5c, 0x4b, 0x13, 0x8f, 0x66, 0x86, 0xee, 0x96, 0xe2, 0x6c,
-	0x59, 0xb2, 0x06, 0x8a, 0x8f, 0x60, 0xc1, 0x2b, 0xff, 0x90, 0x05, 0x8f, 0x00, 0x3f, 0x00, 0x66,
-	0xc3, 0x1e, 0xd8, 0xa7, 0xfa, 0x31, 0x2f, 0xa9, 0xe5, 0xdd, 0xe8, 0xde, 0x73, 0xcf, 0x39, 0xdd,
-	0x7d, 0xbb, 0x75, 0xd1, 0x02, 0xa3, 0x87, 0xc2, 0x0d, 0x22, 0x01, 0x2c, 0xa2, 0x61, 0x3d, 0x61,
-	0xb1, 0x88, 0xf1, 0x0c, 0x08, 0xcf, 0xe7, 0xc0, 0xfa, 0xc0, 0x92, 0xd6, 0xd2, 0x62, 0x3b, 0x6e,
-	0xc7, 0x2a, 0xb1, 0x21, 0xbf, 0x34, 0x66, 0x69, 0x3e, 0xc7, 0x98, 0x48, 0x95, 0x25, 0x9e, 0xf9,
-	0x5c, 0x95, 0xc9, 0x0d, 0x9a, 0x04, 0x1b, 0x7d, 0x60, 0x3c, 0x88, 0xa3, 0xa4, 0x95, 0x7e, 0x19,
-	0xc4, 0xb5, 0x0c, 0xd1, 0x85, 0x6e, 0x0b, 0x18, 0xef, 0x04, 0x49, 0xd2, 0x2a, 0xfc, 0xd0, 0xb8,
-	0x35, 0x86, 0x66, 0x1d, 0xf8, 0xb4, 0x07, 0x5c, 0xdc, 0x02, 0xea, 0x03, 0xc3, 0x73, 0x68, 0x6c,
-	0xaf, 0x49, 0x2a, 0xab, 0x95, 0xf5, 0x09, 0x67, 0x6c, 0xaf, 0x89, 0x97, 0xd0, 0x74, 0x8f, 0x4b,
-	0xf3, 0x5d, 0x20, 0x63, 0xab, 0x95, 0xf5, 0xaa, 0x93, 0xfd, 0xc6, 0xd7, 0xd1, 0x2c, 0xed, 0x89,
-	0x8e, 0xcb, 0xa0, 0x1f, 0x48, 0x6d, 0x32, 0x2e, 0xcb, 0x6e, 0x4e, 0x7d, 0xfe, 0x98, 0x8c, 0x6f,
-	0xd5, 0x5f, 0x73, 0x66, 0x64, 0xd6, 0x31, 0xc9, 0xb7, 0xa7, 0x3e, 0x53, 0xe1, 0x1b, 0x6b, 0x8f,
-	0x17, 0xd0, 0xc2, 0x9e, 0xd9, 0x11, 0x87, 0x1e, 0x0a, 0x63, 0x00, 0x6f, 0xa1, 0xc9, 0x8e, 0x32,
-	0x41, 0xfc, 0xd5, 0xca, 0x7a, 0x6d, 0x73, 0xb9, 0x5e, 0xdc, 0xa7, 0x7a, 0xc9, 0xa7, 0x63, 0xa0,
-	0x43, 0x7e, 0xaf, 0xa2, 0xb1, 0xfe, 0xa6, 0x72, 0x5a, 0xdb, 0xbc, 0x60, 0x25, 0x70, 0xc6, 0xfa,
-	0x9b, 0xf8, 0x06, 0x3a, 0xcb, 0x68, 0xd4, 0x06, 0x65, 0xb9, 0xb6, 0xb9, 0x34, 0x80, 0x94, 0xa9,
-	0x14, 0xae, 0x81, 0xf8, 0x65, 0x34, 0x9e, 0xf4, 0x04, 0x99, 0x50, 0x78, 0x52, 0xc6, 0xef, 0xf7,
-	0xd2, 0x45, 0x38, 0x12, 0x84, 0xb7, 0xd1, 0x8c, 0x0f, 0x21, 0x08, 0x70, 0xb5, 0xc8, 0x59, 0x55,
-	0xb4, 0x5a, 0x2e, 0x6a, 0x2a, 0x44, 0x49, 0xaa, 0xe6, 0xe7, 0x31, 0x29, 0x28, 0x8e, 0x23, 0x32,
-	0x69, 0x13, 0x3c, 0x38, 0x8e, 0x32, 0x41, 0x71, 0x1c, 0xe1, 0x77, 0x10, 0xf2, 0xe2, 0x6e, 0x42,
-	0x3d, 0x21, 0x8f, 0x61, 0x4a, 0x95, 0x3c, 0x5f, 0x2e, 0xd9, 0xce, 0xf2, 0x69, 0x65, 0xa1, 0x04,
-	0xbf, 0x8b, 0x6a, 0x21, 0x50, 0x0e, 0x6e, 0x9b, 0xd1, 0x48, 0x90, 0x69, 0x1b, 0xc3, 0x6d, 0x09,
-	0xd8, 0x95, 0xf9, 0x8c, 0x21, 0xcc, 0x42, 0x72, 0xcd, 0x9a, 0x81, 0x41, 0x3f, 0x3e, 0x02, 0x52,
-	0xb5, 0xad, 0x59, 0x51, 0x38, 0x0a, 0x90, 0xad, 0x39, 0xcc, 0x63, 0xf2, 0x58, 0x68, 0x48, 0x59,
-	0x97, 0x20, 0xdb, 0xb1, 0x34, 0x64, 0x2a, 0x3b, 0x16, 0x05, 0xc4, 0xf7, 0xd1, 0xbc, 0x96, 0xf5,
-	0x3a, 0xe0, 0x1d, 0x25, 0x71, 0x10, 0x09, 0x52, 0x53, 0xc5, 0x2f, 0x58, 0xa4, 0xb7, 0x33, 0x90,
-	0xa1, 0x49, 0x9b, 0xf5, 0x75, 0xe7, 0x5c, 0x58, 0x06, 0xe0, 0x06, 0xaa, 0xa9, 0xee, 0x86, 0x88,
-	0xb6, 0x42, 0x20, 0xff, 0x58, 0x77, 0xb5, 0xd1, 0x13, 0x9d, 0x1d, 0x05, 0xc8, 0xf6, 0x84, 0x66,
-	0x21, 0xdc, 0x44, 0xea, 0x0a, 0xb8, 0x7e, 0xc0, 0x15, 0xc7, 0xbf, 0x53, 0xb6, 0x4d, 0x91, 0x1c,
-	0x4d, 0x8d, 0xc8, 0x36, 0x85, 0xe6, 0x31, 0xfc, 0x9e, 0x31, 0xc2, 0x05, 0x15, 0x3d, 0x4e, 0xfe,
-	0x1f, 0x69, 0xe4, 0x9e, 0x02, 0x0c, 0xac, 0xec, 0x0d, 0xed, 0x48, 0xe7, 0xf0, 0x5d, 0xed, 0x08,
-	0x22, 0x11, 0x78, 0x54, 0x00, 0xf9, 0x4f, 0x93, 0xbd, 0x54, 0x26, 0x4b, 0x6f, 0x67, 0xa3, 0x00,
-	0x4d, 0xad, 0x95, 0xea, 0xf1, 0x8e, 0x79, 0x02, 0xe4, 0x9b, 0xe0, 0x52, 0xdf, 0x27, 0x3f, 0x4d,
-	0x8f, 0x5a, 0xe2, 0xfb, 0x1c, 0x58, 0xc3, 0xf7, 0x4b, 0x4b, 0x34, 0x31, 0x7c, 0x17, 0xcd, 0xe7,
-	0x34, 0xfa, 0x12, 0x90, 0x9f, 0x35, 0xd3, 0x15, 0x3b, 0x93, 0xb9, 0x3d, 0x86, 0x6c, 0x8e, 0x96,
-	0xc2, 0x65, 0x5b, 0x6d, 0x10, 0xe4, 0x97, 0x53, 0x6d, 0xed, 0x82, 0x18, 0xb2, 0xb5, 0x0b, 0x02,
-	0xb7, 0xd1, 0x73, 0x39, 0x8d, 0xd7, 0x91, 0xd7, 0xd2, 0x4d, 0x28, 0xe7, 0x0f, 0x63, 0xe6, 0x93,
-	0x5f, 0x35, 0xe5, 0x2b, 0x76, 0xca, 0x6d, 0x85, 0xde, 0x37, 0xe0, 0x94, 0xfd, 0x22, 0xb5, 0xa6,
-	0xf1, 0x7d, 0xb4, 0x58, 0xf0, 0x2b, 0xef, 0x93, 0xcb, 0xe2, 0x10, 0xc8, 0x53, 0xad, 0x71, 0x6d,
-	0x84, 0x6d, 0x75, 0x17, 0xe3, 0xbc, 0x6d, 0xce, 0xd3, 0xc1, 0x0c, 0xfe, 0x08, 0x5d, 0xc8, 0x99,
-	0xf5, 0xd5, 0xd4, 0xd4, 0xbf, 0x69, 0xea, 0x17, 0xed, 0xd4, 0xe6, 0x8e, 0x16, 0xb8, 0x31, 0x1d,
-	0x4a, 0xe1, 0x5b, 0x68, 0x2e, 0x27, 0x0f, 0x03, 0x2e, 0xc8, 0xef, 0x9a, 0xf5, 0xb2, 0x9d, 0xf5,
-	0x76, 0xc0, 0x45, 0xa9, 0x8f, 0xd2, 0x60, 0xc6, 0x24, 0xad, 0x69, 0xa6, 0x3f, 0x46, 0x32, 0x49,
-	0xe9, 0x21, 0xa6, 0x34, 0x98, 0x1d, 0xbd, 0x62, 0x92, 0x1d, 0xf9, 0x6d, 0x75, 0xd4, 0xd1, 0xcb,
-	0x9a, 0xc1, 0x8e, 0x34, 0xb1, 0xac, 0x23, 0x15, 0x8d, 0xe9, 0xc8, 0xef, 0xaa, 0xa3, 0x3a, 0x52,
-	0x56, 0x59, 0x3a, 0x32, 0x0f, 0x97, 0x6d, 0xc9, 0x8e, 0xfc, 0xfe, 0x54, 0x5b, 0x83, 0x1d, 0x69,
-	0x62, 0xf8, 0x01, 0x5a, 0x2a, 0xd0, 0xa8, 0x46, 0x49, 0x80, 0x75, 0x03, 0xae, 0xfe, 0x7f, 0x7f,
-	0xd0, 0x9c, 0xd7, 0x47, 0x70, 0x4a, 0xf8, 0x7e, 0x86, 0x4e, 0xf9, 0x2f, 0x51, 0x7b, 0x1e, 0x77,
-	0xd1, 0x72, 0xae, 0x65, 0x5a, 0xa7, 0x20, 0xf6, 0xa3, 0x16, 0x7b, 0xd5, 0x2e, 0xa6, 0xbb, 0x64,
-	0x58, 0x8d, 0xd0, 0x11, 0x00, 0xfc, 0x09, 0x5a, 0xf0, 0xc2, 0x1e, 0x17, 0xc0, 0x5c, 0x33, 0xcb,
-	0xb8, 0x1c, 0x04, 0xf9, 0x02, 0x99, 0x2b, 0x50, 0x1c, 0x64, 0xea, 0xdb, 0x1a, 0xf9, 0x81, 0x06,
-	0xde, 0x03, 0x31, 0xf4, 0xea, 0x9d, 0xf7, 0x06, 0x21, 0xf8, 0x01, 0xba, 0x94, 0x2a, 0x68, 0x32,
-	0x97, 0x0a, 0xc1, 0x94, 0xca, 0x97, 0xc8, 0xbc, 0x83, 0x36, 0x95, 0x3b, 0x2a, 0xd6, 0x10, 0x82,
-	0xd9, 0x84, 0x16, 0x3d, 0x0b, 0x0a, 0x7f, 0x8c, 0xb0, 0x1f, 0x3f, 0x8c, 0xda, 0x8c, 0xfa, 0xe0,
-	0x06, 0xd1, 0x61, 0xac, 0x64, 0xbe, 0xd2, 0x32, 0x57, 0xcb, 0x32, 0xcd, 0x14, 0xb8, 0x17, 0x1d,
-	0xc6, 0x36, 0x89, 0x79, 0x7f, 0x00, 0x81, 0x03, 0x74, 0x31, 0xa7, 0x4f, 0xb7, 0x4b, 0x00, 0x17,
-	0xe4, 0x9b, 0x3b, 0xb6, 0x17, 0x3d, 0x93, 0x30, 0xdb, 0x71, 0x00, 0x7c, 0x50, 0xe6, 0x4d, 0x67,
-	0xd1, 0xb7, 0xa0, 0xf2, 0xb9, 0xed, 0x1c, 0x9a, 0xdd, 0xe9, 0x26, 0xe2, 0x91, 0x03, 0x3c, 0x89,
-	0x23, 0x0e, 0x6b, 0x8f, 0xd0, 0xf2, 0x29, 0xff, 0x14, 0x18, 0xa3, 0x09, 0x35, 0x36, 0x56, 0xd4,
-	0xd8, 0xa8, 0xbe, 0xe5, 0x38, 0x99, 0x3d, 0xa0, 0x66, 0x9c, 0x4c, 0x7f, 0xe3, 0xcb, 0x68, 0x86,
-	0x07, 0xdd, 0x24, 0x04, 0x57, 0xc4, 0x47, 0xa0, 0xa7, 0xc9, 0xaa, 0x53, 0xd3, 0xb1, 0x03, 0x19,
-	0xca, 0xbc, 0xdc, 0x7c, 0xeb, 0xc9, 0x5f, 0x2b, 0x67, 0x9e, 0x9c, 0xac, 0x54, 0x9e, 0x9e, 0xac,
-	0x54, 0xfe, 0x3c, 0x59, 0xa9, 0x7c, 0xfd, 0xf7, 0xca, 0x99, 0x0f, 0xaf, 0xb4, 0x63, 0xb5, 0xec,
-	0x7a, 0x10, 0x6f, 0xe4, 0x23, 0xf2, 0xd6, 0x46, 0x71, 0x2b, 0x5a, 0x93, 0x6a, 0xf2, 0xdd, 0x7a,
-	0x16, 0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x15, 0x1f, 0x9b, 0x0b, 0x00, 0x00,
+	// 1157 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x4d, 0x73, 0xdb, 0x44,
+	0x18, 0xae, 0xd3, 0x34, 0x89, 0xd7, 0x49, 0x9a, 0x6e, 0xdc, 0x76, 0x49, 0x66, 0x42, 0x9a, 0xd2,
+	0x12, 0xa0, 0x38, 0x25, 0x01, 0x66, 0xe0, 0x02, 0x6e, 0x9c, 0x49, 0xc3, 0x34, 0x9d, 0x8c, 0x1a,
+	0x3a, 0x1d, 0x18, 0x46, 0xac, 0xa5, 0x37, 0xb6, 0x1a, 0x59, 0x12, 0xbb, 0x6b, 0x37, 0xb9, 0x72,
+	0xe4, 0xcc, 0xd7, 0x8f, 0xe0, 0xc0, 0xe7, 0x7f, 0xe8, 0x81, 0x8f, 0x02, 0x7f, 0x00, 0xc2, 0x05,
+	0xce, 0xc0, 0x0c, 0x47, 0x66, 0x3f, 0x24, 0x59, 0xf6, 0x3a, 0x37, 0xe9, 0x7d, 0x9f, 0xf7, 0x79,
+	0x9e, 0xdd, 0x7d, 0x5f, 0x69, 0xd1, 0x3c, 0xa3, 0x07, 0xc2, 0x0d, 0x22, 0x01, 0x2c, 0xa2, 0x61,
+	0x2d, 0x61, 0xb1, 0x88, 0xf1, 0x34, 0x08, 0xcf, 0xe7, 0xc0, 0x7a, 0xc0, 0x92, 0xe6, 0x42, 0xb5,
+	0x15, 0xb7, 0x62, 0x95, 0x58, 0x93, 0x4f, 0x1a, 0xb3, 0x30, 0x97, 0x63, 0x4c, 0xa4, 0xcc, 0x12,
+	0xcf, 0x3c, 0x2e, 0xcb, 0xe4, 0x1a, 0x4d, 0x82, 0xb5, 0x1e, 0x30, 0x1e, 0xc4, 0x51, 0xd2, 0x4c,
+	0x9f, 0x0c, 0xe2, 0x7a, 0x86, 0xe8, 0x40, 0xa7, 0x09, 0x8c, 0xb7, 0x83, 0x24, 0x69, 0xf6, 0xbd,
+	0x68, 0xdc, 0x0a, 0x43, 0x33, 0x0e, 0x7c, 0xd0, 0x05, 0x2e, 0x6e, 0x03, 0xf5, 0x81, 0xe1, 0x59,
+	0x34, 0xb6, 0xd3, 0x20, 0xa5, 0xe5, 0xd2, 0xea, 0xb8, 0x33, 0xb6, 0xd3, 0xc0, 0x0b, 0x68, 0xaa,
+	0xcb, 0xa5, 0xf9, 0x0e, 0x90, 0xb1, 0xe5, 0xd2, 0x6a, 0xd9, 0xc9, 0xde, 0xf1, 0x0d, 0x34, 0x43,
+	0xbb, 0xa2, 0xed, 0x32, 0xe8, 0x05, 0x52, 0x9b, 0x9c, 0x95, 0x65, 0xb7, 0x26, 0x3f, 0xfa, 0x8e,
+	0x9c, 0xdd, 0xa8, 0xbd, 0xe4, 0x4c, 0xcb, 0xac, 0x63, 0x92, 0xaf, 0x4f, 0x7e, 0xa8, 0xc2, 0x37,
+	0x57, 0xfe, 0xaa, 0xa2, 0xf9, 0x1d, 0xb3, 0x23, 0x0e, 0x3d, 0x10, 0xc6, 0x00, 0xde, 0x40, 0x13,
+	0x6d, 0x65, 0x82, 0xf8, 0xcb, 0xa5, 0xd5, 0xca, 0xfa, 0x62, 0xad, 0x7f, 0x9f, 0x6a, 0x05, 0x9f,
+	0x8e, 0x81, 0x0e, 0xf9, 0xbd, 0x86, 0xc6, 0x7a, 0xeb, 0xca, 0x69, 0x65, 0xfd, 0xa2, 0x95, 0xc0,
+	0x19, 0xeb, 0xad, 0xe3, 0x9b, 0xe8, 0x1c, 0xa3, 0x51, 0x0b, 0x94, 0xe5, 0xca, 0xfa, 0xc2, 0x00,
+	0x52, 0xa6, 0x52, 0xb8, 0x06, 0xe2, 0xe7, 0xd1, 0xd9, 0xa4, 0x2b, 0xc8, 0xb8, 0xc2, 0x93, 0x22,
+	0x7e, 0xaf, 0x9b, 0x2e, 0xc2, 0x91, 0x20, 0xbc, 0x89, 0xa6, 0x7d, 0x08, 0x41, 0x80, 0xab, 0x45,
+	0xce, 0xa9, 0xa2, 0xe5, 0x62, 0x51, 0x43, 0x21, 0x0a, 0x52, 0x15, 0x3f, 0x8f, 0x49, 0x41, 0x71,
+	0x14, 0x91, 0x09, 0x9b, 0xe0, 0xfe, 0x51, 0x94, 0x09, 0x8a, 0xa3, 0x08, 0xbf, 0x81, 0x90, 0x17,
+	0x77, 0x12, 0xea, 0x09, 0x79, 0x0c, 0x93, 0xaa, 0xe4, 0xe9, 0x62, 0xc9, 0x66, 0x96, 0x4f, 0x2b,
+	0xfb, 0x4a, 0xf0, 0x9b, 0xa8, 0x12, 0x02, 0xe5, 0xe0, 0xb6, 0x18, 0x8d, 0x04, 0x99, 0xb2, 0x31,
+	0xdc, 0x91, 0x80, 0x6d, 0x99, 0xcf, 0x18, 0xc2, 0x2c, 0x24, 0xd7, 0xac, 0x19, 0x18, 0xf4, 0xe2,
+	0x43, 0x20, 0x65, 0xdb, 0x9a, 0x15, 0x85, 0xa3, 0x00, 0xd9, 0x9a, 0xc3, 0x3c, 0x26, 0x8f, 0x85,
+	0x86, 0x94, 0x75, 0x08, 0xb2, 0x1d, 0x4b, 0x5d, 0xa6, 0xb2, 0x63, 0x51, 0x40, 0xfc, 0x00, 0xcd,
+	0x69, 0x59, 0xaf, 0x0d, 0xde, 0x61, 0x12, 0x07, 0x91, 0x20, 0x15, 0x55, 0xfc, 0x8c, 0x45, 0x7a,
+	0x33, 0x03, 0x19, 0x9a, 0xb4, 0x59, 0x5f, 0x76, 0xce, 0x87, 0x45, 0x00, 0xae, 0xa3, 0x8a, 0xea,
+	0x6e, 0x88, 0x68, 0x33, 0x04, 0xf2, 0xa7, 0x75, 0x57, 0xeb, 0x5d, 0xd1, 0xde, 0x52, 0x80, 0x6c,
+	0x4f, 0x68, 0x16, 0xc2, 0x0d, 0xa4, 0x46, 0xc0, 0xf5, 0x03, 0xae, 0x38, 0xfe, 0x9e, 0xb4, 0x6d,
+	0x8a, 0xe4, 0x68, 0x68, 0x44, 0xb6, 0x29, 0x34, 0x8f, 0xe1, 0xb7, 0x8c, 0x11, 0x2e, 0xa8, 0xe8,
+	0x72, 0xf2, 0xef, 0x48, 0x23, 0xf7, 0x14, 0x60, 0x60, 0x65, 0xaf, 0x68, 0x47, 0x3a, 0x87, 0xef,
+	0x6a, 0x47, 0x10, 0x89, 0xc0, 0xa3, 0x02, 0xc8, 0x3f, 0x9a, 0xec, 0xb9, 0x22, 0x59, 0x3a, 0x9d,
+	0xf5, 0x3e, 0x68, 0x6a, 0xad, 0x50, 0x8f, 0xb7, 0xcc, 0x27, 0x40, 0x7e, 0x13, 0x5c, 0xea, 0xfb,
+	0xe4, 0xfb, 0xa9, 0x51, 0x4b, 0x7c, 0x9b, 0x03, 0xab, 0xfb, 0x7e, 0x61, 0x89, 0x26, 0x86, 0xef,
+	0xa2, 0xb9, 0x9c, 0x46, 0x0f, 0x01, 0xf9, 0x41, 0x33, 0x5d, 0xb5, 0x33, 0x99, 0xe9, 0x31, 0x64,
+	0xb3, 0xb4, 0x10, 0x2e, 0xda, 0x6a, 0x81, 0x20, 0x3f, 0x9e, 0x6a, 0x6b, 0x1b, 0xc4, 0x90, 0xad,
+	0x6d, 0x10, 0xb8, 0x85, 0x9e, 0xca, 0x69, 0xbc, 0xb6, 0x1c, 0x4b, 0x37, 0xa1, 0x9c, 0x3f, 0x8a,
+	0x99, 0x4f, 0x7e, 0xd2, 0x94, 0x2f, 0xd8, 0x29, 0x37, 0x15, 0x7a, 0xcf, 0x80, 0x53, 0xf6, 0x4b,
+	0xd4, 0x9a, 0xc6, 0x0f, 0x50, 0xb5, 0xcf, 0xaf, 0x9c, 0x27, 0x97, 0xc5, 0x21, 0x90, 0x27, 0x5a,
+	0xe3, 0xfa, 0x08, 0xdb, 0x6a, 0x16, 0xe3, 0xbc, 0x6d, 0x2e, 0xd0, 0xc1, 0x0c, 0x7e, 0x17, 0x5d,
+	0xcc, 0x99, 0xf5, 0x68, 0x6a, 0xea, 0x9f, 0x35, 0xf5, 0xb3, 0x76, 0x6a, 0x33, 0xa3, 0x7d, 0xdc,
+	0x98, 0x0e, 0xa5, 0xf0, 0x6d, 0x34, 0x9b, 0x93, 0x87, 0x01, 0x17, 0xe4, 0x17, 0xcd, 0x7a, 0xc5,
+	0xce, 0x7a, 0x27, 0xe0, 0xa2, 0xd0, 0x47, 0x69, 0x30, 0x63, 0x92, 0xd6, 0x34, 0xd3, 0xaf, 0x23,
+	0x99, 0xa4, 0xf4, 0x10, 0x53, 0x1a, 0xcc, 0x8e, 0x5e, 0x31, 0xc9, 0x8e, 0xfc, 0xb2, 0x3c, 0xea,
+	0xe8, 0x65, 0xcd, 0x60, 0x47, 0x9a, 0x58, 0xd6, 0x91, 0x8a, 0xc6, 0x74, 0xe4, 0x57, 0xe5, 0x51,
+	0x1d, 0x29, 0xab, 0x2c, 0x1d, 0x99, 0x87, 0x8b, 0xb6, 0x64, 0x47, 0x7e, 0x7d, 0xaa, 0xad, 0xc1,
+	0x8e, 0x34, 0x31, 0xfc, 0x10, 0x2d, 0xf4, 0xd1, 0xa8, 0x46, 0x49, 0x80, 0x75, 0x02, 0xae, 0xfe,
+	0xbf, 0xdf, 0x68, 0xce, 0x1b, 0x23, 0x38, 0x25, 0x7c, 0x2f, 0x43, 0xa7, 0xfc, 0x97, 0xa9, 0x3d,
+	0x8f, 0x3b, 0x68, 0x31, 0xd7, 0x32, 0xad, 0xd3, 0x27, 0xf6, 0xad, 0x16, 0x7b, 0xd1, 0x2e, 0xa6,
+	0xbb, 0x64, 0x58, 0x8d, 0xd0, 0x11, 0x00, 0xfc, 0x3e, 0x9a, 0xf7, 0xc2, 0x2e, 0x17, 0xc0, 0x5c,
+	0x73, 0x97, 0x71, 0x39, 0x08, 0xf2, 0x31, 0x32, 0x23, 0xd0, 0x7f, 0x91, 0xa9, 0x6d, 0x6a, 0xe4,
+	0x7d, 0x0d, 0xbc, 0x07, 0x62, 0xe8, 0xab, 0x77, 0xc1, 0x1b, 0x84, 0xe0, 0x87, 0xe8, 0x72, 0xaa,
+	0xa0, 0xc9, 0x5c, 0x2a, 0x04, 0x53, 0x2a, 0x9f, 0x20, 0xf3, 0x1d, 0xb4, 0xa9, 0xec, 0xaa, 0x58,
+	0x5d, 0x08, 0x66, 0x13, 0xaa, 0x7a, 0x16, 0x14, 0x7e, 0x0f, 0x61, 0x3f, 0x7e, 0x14, 0xb5, 0x18,
+	0xf5, 0xc1, 0x0d, 0xa2, 0x83, 0x58, 0xc9, 0x7c, 0xaa, 0x65, 0xae, 0x15, 0x65, 0x1a, 0x29, 0x70,
+	0x27, 0x3a, 0x88, 0x6d, 0x12, 0x73, 0xfe, 0x00, 0x02, 0x7b, 0xa8, 0x9a, 0x2f, 0x45, 0x50, 0x9f,
+	0x0a, 0xaa, 0x04, 0x3e, 0x43, 0x66, 0xaa, 0xed, 0xeb, 0xd0, 0xc8, 0x61, 0x89, 0x57, 0x1d, 0xec,
+	0x0d, 0x61, 0xf0, 0x2e, 0x9a, 0xee, 0xd1, 0xb0, 0x0b, 0x6e, 0x12, 0x87, 0x81, 0x77, 0x4c, 0xfe,
+	0x43, 0xb6, 0x96, 0xbd, 0x2f, 0x21, 0x7b, 0x0a, 0x31, 0xc4, 0x5a, 0xe9, 0xe5, 0x49, 0x1c, 0xa0,
+	0x4b, 0xf9, 0x96, 0xa4, 0x47, 0x2c, 0x80, 0x0b, 0xf2, 0xc5, 0xae, 0xed, 0x2f, 0x94, 0x6d, 0x8b,
+	0x39, 0xc2, 0x7d, 0xe0, 0xc3, 0xbe, 0xab, 0xbe, 0x05, 0x95, 0xdf, 0x35, 0xcf, 0xa3, 0x99, 0xad,
+	0x4e, 0x22, 0x8e, 0x1d, 0xe0, 0x49, 0x1c, 0x71, 0x58, 0x39, 0x46, 0x8b, 0xa7, 0xfc, 0xdd, 0x30,
+	0x46, 0xe3, 0xea, 0xaa, 0x5b, 0x52, 0x57, 0x5d, 0xf5, 0x2c, 0xaf, 0xc0, 0xd9, 0x47, 0xdf, 0x5c,
+	0x81, 0xd3, 0x77, 0x7c, 0x05, 0x4d, 0xf3, 0xa0, 0x93, 0x84, 0xe0, 0x8a, 0xf8, 0x10, 0xf4, 0x0d,
+	0xb8, 0xec, 0x54, 0x74, 0x6c, 0x5f, 0x86, 0x32, 0x2f, 0xb7, 0x5e, 0x7b, 0xfc, 0xfb, 0xd2, 0x99,
+	0xc7, 0x27, 0x4b, 0xa5, 0x27, 0x27, 0x4b, 0xa5, 0xdf, 0x4e, 0x96, 0x4a, 0x9f, 0xff, 0xb1, 0x74,
+	0xe6, 0x9d, 0xab, 0xad, 0x58, 0x2d, 0xbb, 0x16, 0xc4, 0x6b, 0xf9, 0xb5, 0x7e, 0x63, 0xad, 0x7f,
+	0x2b, 0x9a, 0x13, 0xea, 0xb6, 0xbe, 0xf1, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xfe, 0xec, 0x67,
+	0x5a, 0x4f, 0x0c, 0x00, 0x00,
 }
 
 func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
@@ -395,6 +401,34 @@ func (m *InternalRaftRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xe2
 	}
+	if m.ValuePolicy != nil {
+		{
+			size, err := m.ValuePolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x57
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.ClusterMetadataSet != nil {
+		{
+			size, err := m.ClusterMetadataSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x51
+		i--
+		dAtA[i] = 0xba
+	}
 	if m.DowngradeInfoSet != nil {
 		{
 			size, err := m.DowngradeInfoSet.MarshalToSizedBuffer(dAtA[:i])
@@ -1058,6 +1092,14 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.DowngradeInfoSet.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
 	}
+	if m.ClusterMetadataSet != nil {
+		l = m.ClusterMetadataSet.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
+	if m.ValuePolicy != nil {
+		l = m.ValuePolicy.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
 	if m.DowngradeVersionTest != nil {
 		l = m.DowngradeVersionTest.Size()
 		n += 3 + l + sovRaftInternal(uint64(l))
@@ -2395,6 +2437,78 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 1303:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterMetadataSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ClusterMetadataSet == nil {
+				m.ClusterMetadataSet = &membershippb.ClusterMetadataSetRequest{}
+			}
+			if err := m.ClusterMetadataSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1400:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuePolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValuePolicy == nil {
+				m.ValuePolicy = &ValuePolicyRequest{}
+			}
+			if err := m.ValuePolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 9900:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DowngradeVersionTest", wireType)
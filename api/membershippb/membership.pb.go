@@ -71,8 +71,14 @@ var xxx_messageInfo_RaftAttributes proto.InternalMessageInfo
 
 // Attributes represents all the non-raft related attributes of an etcd member.
 type Attributes struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ClientUrls           []string `protobuf:"bytes,2,rep,name=client_urls,json=clientUrls,proto3" json:"client_urls,omitempty"`
+	Name       string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClientUrls []string `protobuf:"bytes,2,rep,name=client_urls,json=clientUrls,proto3" json:"client_urls,omitempty"`
+	// leadership_priority is the preference of the member to be elected as leader.
+	// Members with higher priority are preferred when leadership is rebalanced.
+	LeadershipPriority int32 `protobuf:"varint,3,opt,name=leadership_priority,json=leadershipPriority,proto3" json:"leadership_priority,omitempty"`
+	// read_replica marks a learner that is never promoted to a voting member
+	// and serves serializable reads within a bounded staleness.
+	ReadReplica          bool     `protobuf:"varint,4,opt,name=read_replica,json=readReplica,proto3" json:"read_replica,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -275,6 +281,49 @@ func (m *DowngradeInfoSetRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DowngradeInfoSetRequest proto.InternalMessageInfo
 
+type ClusterMetadataSetRequest struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// delete deletes the entry of the key instead of setting it.
+	Delete               bool     `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterMetadataSetRequest) Reset()         { *m = ClusterMetadataSetRequest{} }
+func (m *ClusterMetadataSetRequest) String() string { return proto.CompactTextString(m) }
+func (*ClusterMetadataSetRequest) ProtoMessage()    {}
+func (*ClusterMetadataSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_949fe0d019050ef5, []int{6}
+}
+func (m *ClusterMetadataSetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterMetadataSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClusterMetadataSetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ClusterMetadataSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterMetadataSetRequest.Merge(m, src)
+}
+func (m *ClusterMetadataSetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterMetadataSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterMetadataSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterMetadataSetRequest proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*RaftAttributes)(nil), "membershippb.RaftAttributes")
 	proto.RegisterType((*Attributes)(nil), "membershippb.Attributes")
@@ -282,39 +331,46 @@ func init() {
 	proto.RegisterType((*ClusterVersionSetRequest)(nil), "membershippb.ClusterVersionSetRequest")
 	proto.RegisterType((*ClusterMemberAttrSetRequest)(nil), "membershippb.ClusterMemberAttrSetRequest")
 	proto.RegisterType((*DowngradeInfoSetRequest)(nil), "membershippb.DowngradeInfoSetRequest")
+	proto.RegisterType((*ClusterMetadataSetRequest)(nil), "membershippb.ClusterMetadataSetRequest")
 }
 
 func init() { proto.RegisterFile("membership.proto", fileDescriptor_949fe0d019050ef5) }
 
 var fileDescriptor_949fe0d019050ef5 = []byte{
-	// 422 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0xed, 0x3a, 0x55, 0x6b, 0x4f, 0x51, 0x28, 0x2b, 0x24, 0xac, 0x06, 0x8c, 0x55, 0x2e, 0x39,
-	0xd9, 0x12, 0x51, 0x0f, 0x70, 0x03, 0xd2, 0x43, 0x10, 0xe5, 0xb0, 0xa8, 0x1c, 0xb8, 0x44, 0xeb,
-	0x66, 0x62, 0x56, 0x72, 0xbc, 0x66, 0x77, 0x5d, 0xee, 0x1c, 0xf9, 0x02, 0xfe, 0x82, 0x13, 0xff,
-	0xd0, 0x23, 0x9f, 0x00, 0xe1, 0x47, 0x90, 0x77, 0x9d, 0xd8, 0x11, 0x9c, 0x7a, 0x1b, 0x3f, 0xcf,
-	0xbc, 0x79, 0xef, 0xed, 0xc0, 0xf1, 0x0a, 0x57, 0x19, 0x2a, 0xfd, 0x51, 0x54, 0x49, 0xa5, 0xa4,
-	0x91, 0xf4, 0x4e, 0x87, 0x54, 0xd9, 0xc9, 0xfd, 0x5c, 0xe6, 0xd2, 0xfe, 0x48, 0x9b, 0xca, 0xf5,
-	0x9c, 0xc4, 0x68, 0xae, 0x16, 0x29, 0xaf, 0x44, 0x7a, 0x8d, 0x4a, 0x0b, 0x59, 0x56, 0xd9, 0xa6,
-	0x72, 0x1d, 0xa7, 0x97, 0x30, 0x64, 0x7c, 0x69, 0x5e, 0x18, 0xa3, 0x44, 0x56, 0x1b, 0xd4, 0x74,
-	0x04, 0x41, 0x85, 0xa8, 0xe6, 0xb5, 0x2a, 0x74, 0x48, 0xe2, 0xc1, 0x38, 0x60, 0x7e, 0x03, 0x5c,
-	0xaa, 0x42, 0xd3, 0x47, 0x00, 0x42, 0xcf, 0x0b, 0xe4, 0xaa, 0x44, 0x15, 0x7a, 0x31, 0x19, 0xfb,
-	0x2c, 0x10, 0xfa, 0x8d, 0x03, 0x9e, 0x1f, 0x7e, 0xf9, 0x11, 0x0e, 0x26, 0xc9, 0xd9, 0xe9, 0x6b,
-	0x80, 0x1e, 0x25, 0x85, 0xfd, 0x92, 0xaf, 0x30, 0x24, 0x31, 0x19, 0x07, 0xcc, 0xd6, 0xf4, 0x31,
-	0x1c, 0x5d, 0x15, 0x02, 0x4b, 0xe3, 0x16, 0x79, 0x76, 0x11, 0x38, 0xa8, 0x59, 0xd5, 0x71, 0x7d,
-	0x27, 0x70, 0x70, 0x61, 0xbd, 0xd2, 0x21, 0x78, 0xb3, 0xa9, 0xa5, 0xd9, 0x67, 0xde, 0x6c, 0x4a,
-	0xcf, 0xe1, 0xae, 0xe2, 0x4b, 0x33, 0xe7, 0xdb, 0x5d, 0x56, 0xd3, 0xd1, 0xd3, 0x87, 0x49, 0x3f,
-	0x9d, 0x64, 0xd7, 0x22, 0x1b, 0xaa, 0x5d, 0xcb, 0xe7, 0x70, 0xcf, 0xb5, 0xf7, 0x89, 0x06, 0x96,
-	0x28, 0xdc, 0x25, 0xea, 0x91, 0xb4, 0x2f, 0xd2, 0x21, 0x9d, 0xe2, 0x33, 0x08, 0x5f, 0x15, 0xb5,
-	0x36, 0xa8, 0xde, 0xbb, 0xb0, 0xdf, 0xa1, 0x61, 0xf8, 0xa9, 0x46, 0x6d, 0xe8, 0x31, 0x0c, 0xae,
-	0x51, 0xb5, 0x51, 0x34, 0x65, 0x37, 0xf6, 0x95, 0xc0, 0xa8, 0x9d, 0xbb, 0xd8, 0x72, 0xf7, 0x46,
-	0x47, 0x10, 0xb4, 0x32, 0xb7, 0x21, 0xf8, 0x0e, 0xb0, 0x51, 0xfc, 0xc7, 0x83, 0x77, 0x7b, 0x0f,
-	0x6f, 0xe1, 0xc1, 0x54, 0x7e, 0x2e, 0x73, 0xc5, 0x17, 0x38, 0x2b, 0x97, 0xb2, 0xa7, 0x23, 0x84,
-	0x43, 0x2c, 0x79, 0x56, 0xe0, 0xc2, 0xaa, 0xf0, 0xd9, 0xe6, 0x73, 0x63, 0xce, 0xfb, 0xd7, 0xdc,
-	0xcb, 0x67, 0x37, 0xbf, 0xa3, 0xbd, 0x9b, 0x75, 0x44, 0x7e, 0xae, 0x23, 0xf2, 0x6b, 0x1d, 0x91,
-	0x6f, 0x7f, 0xa2, 0xbd, 0x0f, 0x4f, 0x72, 0x99, 0x34, 0x37, 0x9a, 0x08, 0x99, 0x76, 0xb7, 0x3a,
-	0x49, 0xfb, 0x82, 0xb3, 0x03, 0x7b, 0xaa, 0x93, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x56,
-	0x21, 0x97, 0x04, 0x03, 0x00, 0x00,
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xee, 0x3a, 0x69, 0x7e, 0x26, 0x55, 0x28, 0x4b, 0x05, 0xa6, 0x81, 0x10, 0x95, 0x4b, 0xc4,
+	0xc1, 0x91, 0x88, 0x5a, 0x01, 0x37, 0x4a, 0x7a, 0x88, 0x44, 0x11, 0x5a, 0x54, 0x0e, 0x5c, 0xa2,
+	0x75, 0x3c, 0x09, 0xab, 0x3a, 0x5e, 0xb3, 0xde, 0x04, 0xe5, 0xca, 0xb1, 0x4f, 0xc0, 0x5b, 0xc0,
+	0x85, 0x77, 0xe8, 0x91, 0x47, 0x80, 0xf0, 0x22, 0xc8, 0xbb, 0x4e, 0xec, 0x48, 0x3d, 0xf5, 0x36,
+	0x3b, 0x3b, 0xf3, 0xcd, 0xf7, 0x7d, 0xa3, 0x81, 0xfd, 0x19, 0xce, 0x7c, 0x54, 0xc9, 0x67, 0x11,
+	0x7b, 0xb1, 0x92, 0x5a, 0xd2, 0xbd, 0x3c, 0x13, 0xfb, 0x87, 0x07, 0x53, 0x39, 0x95, 0xe6, 0xa3,
+	0x97, 0x46, 0xb6, 0xe6, 0xb0, 0x83, 0x7a, 0x1c, 0xf4, 0x78, 0x2c, 0x7a, 0x0b, 0x54, 0x89, 0x90,
+	0x51, 0xec, 0xaf, 0x23, 0x5b, 0x71, 0x74, 0x01, 0x4d, 0xc6, 0x27, 0xfa, 0xb5, 0xd6, 0x4a, 0xf8,
+	0x73, 0x8d, 0x09, 0x6d, 0x41, 0x3d, 0x46, 0x54, 0xa3, 0xb9, 0x0a, 0x13, 0x97, 0x74, 0x4a, 0xdd,
+	0x3a, 0xab, 0xa5, 0x89, 0x0b, 0x15, 0x26, 0xf4, 0x31, 0x80, 0x48, 0x46, 0x21, 0x72, 0x15, 0xa1,
+	0x72, 0x9d, 0x0e, 0xe9, 0xd6, 0x58, 0x5d, 0x24, 0x6f, 0x6d, 0xe2, 0x55, 0xf5, 0xdb, 0x2f, 0xb7,
+	0xd4, 0xf7, 0x8e, 0x8f, 0x7e, 0x12, 0x80, 0x02, 0x26, 0x85, 0x72, 0xc4, 0x67, 0xe8, 0x92, 0x0e,
+	0xe9, 0xd6, 0x99, 0x89, 0xe9, 0x13, 0x68, 0x8c, 0x43, 0x81, 0x91, 0xb6, 0x93, 0x1c, 0x33, 0x09,
+	0x6c, 0xca, 0xcc, 0x7a, 0x01, 0xf7, 0x42, 0xe4, 0x81, 0x95, 0x38, 0x8a, 0x95, 0x90, 0x4a, 0xe8,
+	0xa5, 0x5b, 0xea, 0x90, 0xee, 0xee, 0x69, 0xf5, 0xca, 0x4c, 0x3a, 0x61, 0x34, 0xaf, 0x79, 0x9f,
+	0x95, 0xd0, 0x67, 0xb0, 0xa7, 0x90, 0x07, 0x23, 0x85, 0x71, 0x28, 0xc6, 0xdc, 0x2d, 0xa7, 0x3c,
+	0xf3, 0x96, 0x46, 0xfa, 0xc9, 0xec, 0x5f, 0x4e, 0xf9, 0x07, 0x81, 0xca, 0xb9, 0xb1, 0x94, 0x36,
+	0xc1, 0x19, 0x0e, 0x0c, 0xd9, 0x32, 0x73, 0x86, 0x03, 0x7a, 0x06, 0x77, 0x14, 0x9f, 0xe8, 0x11,
+	0xdf, 0x28, 0x32, 0xd2, 0x1b, 0xcf, 0x1f, 0x79, 0xc5, 0x25, 0x78, 0xdb, 0x4e, 0xb2, 0xa6, 0xda,
+	0x76, 0xf6, 0x0c, 0xee, 0xda, 0xf2, 0x22, 0x50, 0xc9, 0x00, 0xb9, 0xdb, 0x40, 0x05, 0x90, 0x6c,
+	0xf1, 0x79, 0x26, 0x67, 0x7c, 0x0c, 0xee, 0x9b, 0x70, 0x9e, 0x68, 0x54, 0x1f, 0xed, 0x4e, 0x3f,
+	0xa0, 0x66, 0xf8, 0x65, 0x8e, 0x89, 0xa6, 0xfb, 0x50, 0x5a, 0xa0, 0xca, 0x0c, 0x4f, 0xc3, 0xbc,
+	0xed, 0x8a, 0x40, 0x2b, 0xeb, 0x3b, 0xdf, 0x60, 0x17, 0x5a, 0x5b, 0x50, 0xcf, 0x68, 0x6e, 0x4c,
+	0xa8, 0xd9, 0x84, 0xb1, 0xe2, 0x06, 0x0d, 0xce, 0xed, 0x35, 0xbc, 0x83, 0x07, 0x03, 0xf9, 0x35,
+	0x9a, 0x2a, 0x1e, 0xe0, 0x30, 0x9a, 0xc8, 0x02, 0x0f, 0x17, 0xaa, 0x18, 0x71, 0x3f, 0xc4, 0xc0,
+	0xb0, 0xa8, 0xb1, 0xf5, 0x73, 0x2d, 0xce, 0xb9, 0x41, 0x5c, 0x00, 0x0f, 0x37, 0xda, 0x34, 0x0f,
+	0xb8, 0xe6, 0xdb, 0xa6, 0x5c, 0xe2, 0x72, 0x6d, 0xca, 0x25, 0x2e, 0xe9, 0x01, 0xec, 0x2e, 0x78,
+	0x38, 0xc7, 0x0c, 0xcb, 0x3e, 0xe8, 0x7d, 0xa8, 0x04, 0x18, 0xa2, 0x46, 0xb3, 0x9d, 0x1a, 0xcb,
+	0x5e, 0xeb, 0x29, 0x27, 0xa7, 0x2f, 0xaf, 0xff, 0xb6, 0x77, 0xae, 0x57, 0x6d, 0xf2, 0x7b, 0xd5,
+	0x26, 0x7f, 0x56, 0x6d, 0xf2, 0xfd, 0x5f, 0x7b, 0xe7, 0xd3, 0xd3, 0xa9, 0xf4, 0xd2, 0x83, 0xf3,
+	0x84, 0xec, 0xe5, 0x87, 0xd7, 0xef, 0x15, 0x6d, 0xf1, 0x2b, 0xe6, 0xee, 0xfa, 0xff, 0x03, 0x00,
+	0x00, 0xff, 0xff, 0x26, 0x4d, 0xde, 0x05, 0xd1, 0x03, 0x00, 0x00,
 }
 
 func (m *RaftAttributes) Marshal() (dAtA []byte, err error) {
@@ -387,6 +443,21 @@ func (m *Attributes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ReadReplica {
+		i--
+		if m.ReadReplica {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LeadershipPriority != 0 {
+		i = encodeVarintMembership(dAtA, i, uint64(m.LeadershipPriority))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.ClientUrls) > 0 {
 		for iNdEx := len(m.ClientUrls) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.ClientUrls[iNdEx])
@@ -584,6 +655,57 @@ func (m *DowngradeInfoSetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *ClusterMetadataSetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterMetadataSetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterMetadataSetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Delete {
+		i--
+		if m.Delete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintMembership(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintMembership(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMembership(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMembership(v)
 	base := offset
@@ -632,6 +754,12 @@ func (m *Attributes) Size() (n int) {
 			n += 1 + l + sovMembership(uint64(l))
 		}
 	}
+	if m.LeadershipPriority != 0 {
+		n += 1 + sovMembership(uint64(m.LeadershipPriority))
+	}
+	if m.ReadReplica {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -715,6 +843,29 @@ func (m *DowngradeInfoSetRequest) Size() (n int) {
 	return n
 }
 
+func (m *ClusterMetadataSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovMembership(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovMembership(uint64(l))
+	}
+	if m.Delete {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovMembership(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -917,6 +1068,45 @@ func (m *Attributes) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeadershipPriority", wireType)
+			}
+			m.LeadershipPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeadershipPriority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadReplica", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadReplica = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMembership(dAtA[iNdEx:])
@@ -1373,6 +1563,141 @@ func (m *DowngradeInfoSetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ClusterMetadataSetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMembership
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterMetadataSetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterMetadataSetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMembership
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMembership
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMembership
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMembership
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Delete = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMembership(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMembership
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMembership(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
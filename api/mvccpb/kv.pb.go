@@ -116,10 +116,16 @@ type Event struct {
 	// its modification revision set to the revision of deletion.
 	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
 	// prev_kv holds the key-value pair before the event happens.
-	PrevKv               *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// range_end is set on the DELETE event of a lazy range delete, which deletes
+	// all keys in the range [kv.key, range_end) at once. If range_end is '\0', the
+	// range is all keys greater than or equal to kv.key. The event is sent to the
+	// watchers of any key in the range, whether the key existed or not. prev_kv is
+	// not set on such events.
+	RangeEnd             []byte   `protobuf:"bytes,4,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
@@ -164,28 +170,29 @@ func init() {
 func init() { proto.RegisterFile("kv.proto", fileDescriptor_2216fe83c9c12408) }
 
 var fileDescriptor_2216fe83c9c12408 = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xc1, 0x6a, 0xfa, 0x40,
-	0x10, 0xc6, 0xb3, 0x46, 0xa3, 0xff, 0x51, 0xfc, 0x87, 0x45, 0x68, 0x28, 0x34, 0xa4, 0x5e, 0x6a,
-	0x29, 0x24, 0xa0, 0x87, 0xde, 0x4b, 0x73, 0xb2, 0x87, 0x12, 0x6c, 0x0f, 0xbd, 0x48, 0x8c, 0x83,
-	0x84, 0xa8, 0x1b, 0x62, 0xba, 0x90, 0x37, 0xe9, 0xbd, 0xf7, 0x3e, 0x87, 0x47, 0x1f, 0xa1, 0xda,
-	0x17, 0x29, 0x3b, 0x5b, 0xed, 0xa5, 0x97, 0xdd, 0x99, 0xef, 0xfb, 0xb1, 0xf3, 0x0d, 0x0b, 0xad,
-	0x4c, 0xfa, 0x79, 0x21, 0x4a, 0xc1, 0xad, 0x95, 0x4c, 0x92, 0x7c, 0x76, 0xde, 0x5b, 0x88, 0x85,
-	0x20, 0x29, 0x50, 0x95, 0x76, 0xfb, 0x1f, 0x0c, 0x5a, 0x63, 0xac, 0x9e, 0xe3, 0xe5, 0x2b, 0x72,
-	0x1b, 0xcc, 0x0c, 0x2b, 0x87, 0x79, 0x6c, 0xd0, 0x89, 0x54, 0xc9, 0xaf, 0xe0, 0x7f, 0x52, 0x60,
-	0x5c, 0xe2, 0xb4, 0x40, 0x99, 0x6e, 0x52, 0xb1, 0x76, 0x6a, 0x1e, 0x1b, 0x98, 0x51, 0x57, 0xcb,
-	0xd1, 0x8f, 0xca, 0x2f, 0xa1, 0xb3, 0x12, 0xf3, 0x5f, 0xca, 0x24, 0xaa, 0xbd, 0x12, 0xf3, 0x13,
-	0xe2, 0x40, 0x53, 0x62, 0x41, 0x6e, 0x9d, 0xdc, 0x63, 0xcb, 0x7b, 0xd0, 0x90, 0x2a, 0x80, 0xd3,
-	0xa0, 0xc9, 0xba, 0x51, 0xea, 0x12, 0xe3, 0x0d, 0x3a, 0x16, 0xd1, 0xba, 0xe9, 0xbf, 0x33, 0x68,
-	0x84, 0x12, 0xd7, 0x25, 0xbf, 0x81, 0x7a, 0x59, 0xe5, 0x48, 0x71, 0xbb, 0xc3, 0x33, 0x5f, 0xef,
-	0xe9, 0x93, 0xa9, 0xcf, 0x49, 0x95, 0x63, 0x44, 0x10, 0xf7, 0xa0, 0x96, 0x49, 0xca, 0xde, 0x1e,
-	0xda, 0x47, 0xf4, 0xb8, 0x78, 0x54, 0xcb, 0x24, 0xbf, 0x86, 0x66, 0x5e, 0xa0, 0x9c, 0x66, 0x92,
-	0xc2, 0xff, 0x85, 0x59, 0x0a, 0x18, 0xcb, 0xbe, 0x07, 0xff, 0x4e, 0xef, 0xf3, 0x26, 0x98, 0x8f,
-	0x4f, 0x13, 0xdb, 0xe0, 0x00, 0xd6, 0x7d, 0xf8, 0x10, 0x4e, 0x42, 0x9b, 0xdd, 0xdd, 0x6e, 0xf7,
-	0xae, 0xb1, 0xdb, 0xbb, 0xc6, 0xf6, 0xe0, 0xb2, 0xdd, 0xc1, 0x65, 0x9f, 0x07, 0x97, 0xbd, 0x7d,
-	0xb9, 0xc6, 0xcb, 0xc5, 0x42, 0xf8, 0x58, 0x26, 0x73, 0x3f, 0x15, 0x81, 0xba, 0x83, 0x38, 0x4f,
-	0x03, 0x39, 0x0a, 0xf4, 0xac, 0x99, 0x45, 0xdf, 0x32, 0xfa, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x78,
-	0x06, 0x46, 0xf5, 0xc0, 0x01, 0x00, 0x00,
+	// 345 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xc1, 0x6a, 0xf2, 0x50,
+	0x10, 0x85, 0x73, 0x8d, 0x46, 0x1d, 0xc5, 0x3f, 0x5c, 0x84, 0x3f, 0xb4, 0x34, 0xa4, 0x6e, 0x6a,
+	0x29, 0x24, 0xa0, 0x8b, 0xee, 0x4b, 0xb3, 0xb2, 0x8b, 0x12, 0x6c, 0x17, 0xdd, 0x48, 0x4c, 0x86,
+	0x10, 0xa2, 0xb9, 0x21, 0xa6, 0x17, 0xf2, 0x26, 0x7d, 0x8a, 0x3e, 0x43, 0x97, 0x2e, 0x7d, 0x84,
+	0x6a, 0x5f, 0xa4, 0x64, 0x52, 0xed, 0xa6, 0x9b, 0x64, 0xe6, 0x9c, 0x0f, 0xe6, 0x1c, 0x2e, 0x74,
+	0x12, 0x69, 0x67, 0xb9, 0x28, 0x04, 0xd7, 0xd6, 0x32, 0x08, 0xb2, 0xe5, 0xd9, 0x30, 0x12, 0x91,
+	0x20, 0xc9, 0xa9, 0xa6, 0xda, 0x1d, 0xbd, 0x33, 0xe8, 0xcc, 0xb0, 0x7c, 0xf6, 0x57, 0xaf, 0xc8,
+	0x75, 0x50, 0x13, 0x2c, 0x0d, 0x66, 0xb1, 0x71, 0xdf, 0xab, 0x46, 0x7e, 0x05, 0xff, 0x82, 0x1c,
+	0xfd, 0x02, 0x17, 0x39, 0xca, 0x78, 0x13, 0x8b, 0xd4, 0x68, 0x58, 0x6c, 0xac, 0x7a, 0x83, 0x5a,
+	0xf6, 0x7e, 0x54, 0x7e, 0x09, 0xfd, 0xb5, 0x08, 0x7f, 0x29, 0x95, 0xa8, 0xde, 0x5a, 0x84, 0x27,
+	0xc4, 0x80, 0xb6, 0xc4, 0x9c, 0xdc, 0x26, 0xb9, 0xc7, 0x95, 0x0f, 0xa1, 0x25, 0xab, 0x00, 0x46,
+	0x8b, 0x2e, 0xd7, 0x4b, 0xa5, 0xae, 0xd0, 0xdf, 0xa0, 0xa1, 0x11, 0x5d, 0x2f, 0xa3, 0x0f, 0x06,
+	0x2d, 0x57, 0x62, 0x5a, 0xf0, 0x1b, 0x68, 0x16, 0x65, 0x86, 0x14, 0x77, 0x30, 0xf9, 0x6f, 0xd7,
+	0x3d, 0x6d, 0x32, 0xeb, 0xef, 0xbc, 0xcc, 0xd0, 0x23, 0x88, 0x5b, 0xd0, 0x48, 0x24, 0x65, 0xef,
+	0x4d, 0xf4, 0x23, 0x7a, 0x2c, 0xee, 0x35, 0x12, 0xc9, 0xaf, 0xa1, 0x9d, 0xe5, 0x28, 0x17, 0x89,
+	0xa4, 0xf0, 0x7f, 0x61, 0x5a, 0x05, 0xcc, 0x24, 0x3f, 0x87, 0x6e, 0xee, 0xa7, 0x11, 0x2e, 0x30,
+	0x0d, 0xa9, 0x4b, 0xdf, 0xeb, 0x90, 0xe0, 0xa6, 0xe1, 0xc8, 0x82, 0xee, 0xe9, 0x38, 0x6f, 0x83,
+	0xfa, 0xf8, 0x34, 0xd7, 0x15, 0x0e, 0xa0, 0xdd, 0xbb, 0x0f, 0xee, 0xdc, 0xd5, 0xd9, 0xdd, 0xed,
+	0x76, 0x6f, 0x2a, 0xbb, 0xbd, 0xa9, 0x6c, 0x0f, 0x26, 0xdb, 0x1d, 0x4c, 0xf6, 0x79, 0x30, 0xd9,
+	0xdb, 0x97, 0xa9, 0xbc, 0x5c, 0x44, 0xc2, 0xc6, 0x22, 0x08, 0xed, 0x58, 0x38, 0xd5, 0xdf, 0xf1,
+	0xb3, 0xd8, 0x91, 0x53, 0xa7, 0x0e, 0xb2, 0xd4, 0xe8, 0xcd, 0xa6, 0xdf, 0x01, 0x00, 0x00, 0xff,
+	0xff, 0x8e, 0x29, 0x78, 0x40, 0xdd, 0x01, 0x00, 0x00,
 }
 
 func (m *KeyValue) Marshal() (dAtA []byte, err error) {
@@ -273,6 +280,13 @@ func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.RangeEnd) > 0 {
+		i -= len(m.RangeEnd)
+		copy(dAtA[i:], m.RangeEnd)
+		i = encodeVarintKv(dAtA, i, uint64(len(m.RangeEnd)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.PrevKv != nil {
 		{
 			size, err := m.PrevKv.MarshalToSizedBuffer(dAtA[:i])
@@ -365,6 +379,10 @@ func (m *Event) Size() (n int) {
 		l = m.PrevKv.Size()
 		n += 1 + l + sovKv(uint64(l))
 	}
+	l = len(m.RangeEnd)
+	if l > 0 {
+		n += 1 + l + sovKv(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -692,6 +710,40 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeEnd", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKv
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RangeEnd = append(m.RangeEnd[:0], dAtA[iNdEx:postIndex]...)
+			if m.RangeEnd == nil {
+				m.RangeEnd = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
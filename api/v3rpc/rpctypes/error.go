@@ -29,13 +29,16 @@ var (
 	ErrGRPCDuplicateKey            = status.Error(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
 	ErrGRPCInvalidClientAPIVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid client api version")
 	ErrGRPCInvalidSortOption       = status.Error(codes.InvalidArgument, "etcdserver: invalid sort option")
+	ErrGRPCLazyDeletePrevKV        = status.Error(codes.InvalidArgument, "etcdserver: lazy delete cannot return previous key-values")
+	ErrGRPCLazyDeleteDisabled      = status.Error(codes.FailedPrecondition, "etcdserver: lazy delete is not enabled")
 	ErrGRPCCompacted               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
 	ErrGRPCFutureRev               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace                 = status.Error(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
 
-	ErrGRPCLeaseNotFound    = status.Error(codes.NotFound, "etcdserver: requested lease not found")
-	ErrGRPCLeaseExist       = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
-	ErrGRPCLeaseTTLTooLarge = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
+	ErrGRPCLeaseNotFound     = status.Error(codes.NotFound, "etcdserver: requested lease not found")
+	ErrGRPCLeaseExist        = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
+	ErrGRPCLeaseTTLTooLarge  = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
+	ErrGRPCLeaseLabelInvalid = status.Error(codes.InvalidArgument, "etcdserver: invalid lease label")
 
 	ErrGRPCWatchCanceled = status.Error(codes.Canceled, "etcdserver: watch canceled")
 
@@ -47,12 +50,23 @@ var (
 	ErrGRPCMemberNotLearner       = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member")
 	ErrGRPCLearnerNotReady        = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member which is in sync with leader")
 	ErrGRPCTooManyLearners        = status.Error(codes.FailedPrecondition, "etcdserver: too many learner members in cluster")
+	ErrGRPCMemberIsReadReplica    = status.Error(codes.FailedPrecondition, "etcdserver: can not promote a read replica member")
 	ErrGRPCClusterIDMismatch      = status.Error(codes.FailedPrecondition, "etcdserver: cluster ID mismatch")
 	//revive:disable:var-naming
 	// Deprecated: Please use ErrGRPCClusterIDMismatch.
 	ErrGRPCClusterIdMismatch = ErrGRPCClusterIDMismatch
 	//revive:enable:var-naming
 
+	ErrGRPCClusterMetadataKeyEmpty = status.Error(codes.InvalidArgument, "etcdserver: cluster metadata key is not provided")
+	ErrGRPCClusterMetadataTooLarge = status.Error(codes.InvalidArgument, "etcdserver: cluster metadata exceeds the size limit")
+
+	ErrGRPCSnapshotRevisionApplied = status.Error(codes.FailedPrecondition, "etcdserver: snapshot revision is already applied")
+
+	ErrGRPCInvalidValuePolicy       = status.Error(codes.InvalidArgument, "etcdserver: invalid value policy")
+	ErrGRPCValuePolicyValueTooLarge = status.Error(codes.InvalidArgument, "etcdserver: value exceeds the size limit of the value policy")
+	ErrGRPCValuePolicyBadContent    = status.Error(codes.InvalidArgument, "etcdserver: value does not match the content type of the value policy")
+	ErrGRPCValuePolicyKeyMismatch   = status.Error(codes.InvalidArgument, "etcdserver: key does not match the key pattern of the value policy")
+
 	ErrGRPCRequestTooLarge        = status.Error(codes.InvalidArgument, "etcdserver: request is too large")
 	ErrGRPCRequestTooManyRequests = status.Error(codes.ResourceExhausted, "etcdserver: too many requests")
 
@@ -87,6 +101,11 @@ var (
 	ErrGRPCCorrupt                    = status.Error(codes.DataLoss, "etcdserver: corrupt cluster")
 	ErrGRPCNotSupportedForLearner     = status.Error(codes.FailedPrecondition, "etcdserver: rpc not supported for learner")
 	ErrGRPCBadLeaderTransferee        = status.Error(codes.FailedPrecondition, "etcdserver: bad leader transferee")
+	ErrGRPCReadReplicaTooStale        = status.Error(codes.Unavailable, "etcdserver: read replica exceeds max staleness")
+	ErrGRPCDraining                   = status.Error(codes.Unavailable, "etcdserver: member is draining")
+	ErrGRPCTooManyStreams             = status.Error(codes.ResourceExhausted, "etcdserver: too many concurrent streams")
+	ErrGRPCTooManyWatchers            = status.Error(codes.ResourceExhausted, "etcdserver: too many watchers")
+	ErrGRPCWatcherTooSlow             = status.Error(codes.ResourceExhausted, "etcdserver: watcher exceeds its bandwidth limit")
 
 	ErrGRPCWrongDowngradeVersionFormat   = status.Error(codes.InvalidArgument, "etcdserver: wrong downgrade target version format")
 	ErrGRPCInvalidDowngradeTargetVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid downgrade target version")
@@ -103,16 +122,19 @@ var (
 		ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
 		ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
 
-		ErrorDesc(ErrGRPCTooManyOps):        ErrGRPCTooManyOps,
-		ErrorDesc(ErrGRPCDuplicateKey):      ErrGRPCDuplicateKey,
-		ErrorDesc(ErrGRPCInvalidSortOption): ErrGRPCInvalidSortOption,
-		ErrorDesc(ErrGRPCCompacted):         ErrGRPCCompacted,
-		ErrorDesc(ErrGRPCFutureRev):         ErrGRPCFutureRev,
-		ErrorDesc(ErrGRPCNoSpace):           ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCTooManyOps):         ErrGRPCTooManyOps,
+		ErrorDesc(ErrGRPCDuplicateKey):       ErrGRPCDuplicateKey,
+		ErrorDesc(ErrGRPCInvalidSortOption):  ErrGRPCInvalidSortOption,
+		ErrorDesc(ErrGRPCLazyDeletePrevKV):   ErrGRPCLazyDeletePrevKV,
+		ErrorDesc(ErrGRPCLazyDeleteDisabled): ErrGRPCLazyDeleteDisabled,
+		ErrorDesc(ErrGRPCCompacted):          ErrGRPCCompacted,
+		ErrorDesc(ErrGRPCFutureRev):          ErrGRPCFutureRev,
+		ErrorDesc(ErrGRPCNoSpace):            ErrGRPCNoSpace,
 
-		ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
-		ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
-		ErrorDesc(ErrGRPCLeaseTTLTooLarge): ErrGRPCLeaseTTLTooLarge,
+		ErrorDesc(ErrGRPCLeaseNotFound):     ErrGRPCLeaseNotFound,
+		ErrorDesc(ErrGRPCLeaseExist):        ErrGRPCLeaseExist,
+		ErrorDesc(ErrGRPCLeaseTTLTooLarge):  ErrGRPCLeaseTTLTooLarge,
+		ErrorDesc(ErrGRPCLeaseLabelInvalid): ErrGRPCLeaseLabelInvalid,
 
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
@@ -122,8 +144,19 @@ var (
 		ErrorDesc(ErrGRPCMemberNotLearner):       ErrGRPCMemberNotLearner,
 		ErrorDesc(ErrGRPCLearnerNotReady):        ErrGRPCLearnerNotReady,
 		ErrorDesc(ErrGRPCTooManyLearners):        ErrGRPCTooManyLearners,
+		ErrorDesc(ErrGRPCMemberIsReadReplica):    ErrGRPCMemberIsReadReplica,
 		ErrorDesc(ErrGRPCClusterIDMismatch):      ErrGRPCClusterIDMismatch,
 
+		ErrorDesc(ErrGRPCClusterMetadataKeyEmpty): ErrGRPCClusterMetadataKeyEmpty,
+		ErrorDesc(ErrGRPCClusterMetadataTooLarge): ErrGRPCClusterMetadataTooLarge,
+
+		ErrorDesc(ErrGRPCSnapshotRevisionApplied): ErrGRPCSnapshotRevisionApplied,
+
+		ErrorDesc(ErrGRPCInvalidValuePolicy):       ErrGRPCInvalidValuePolicy,
+		ErrorDesc(ErrGRPCValuePolicyValueTooLarge): ErrGRPCValuePolicyValueTooLarge,
+		ErrorDesc(ErrGRPCValuePolicyBadContent):    ErrGRPCValuePolicyBadContent,
+		ErrorDesc(ErrGRPCValuePolicyKeyMismatch):   ErrGRPCValuePolicyKeyMismatch,
+
 		ErrorDesc(ErrGRPCRequestTooLarge):        ErrGRPCRequestTooLarge,
 		ErrorDesc(ErrGRPCRequestTooManyRequests): ErrGRPCRequestTooManyRequests,
 
@@ -156,6 +189,11 @@ var (
 		ErrorDesc(ErrGRPCCorrupt):                    ErrGRPCCorrupt,
 		ErrorDesc(ErrGRPCNotSupportedForLearner):     ErrGRPCNotSupportedForLearner,
 		ErrorDesc(ErrGRPCBadLeaderTransferee):        ErrGRPCBadLeaderTransferee,
+		ErrorDesc(ErrGRPCReadReplicaTooStale):        ErrGRPCReadReplicaTooStale,
+		ErrorDesc(ErrGRPCDraining):                   ErrGRPCDraining,
+		ErrorDesc(ErrGRPCTooManyStreams):             ErrGRPCTooManyStreams,
+		ErrorDesc(ErrGRPCTooManyWatchers):            ErrGRPCTooManyWatchers,
+		ErrorDesc(ErrGRPCWatcherTooSlow):             ErrGRPCWatcherTooSlow,
 
 		ErrorDesc(ErrGRPCClusterVersionUnavailable):     ErrGRPCClusterVersionUnavailable,
 		ErrorDesc(ErrGRPCWrongDowngradeVersionFormat):   ErrGRPCWrongDowngradeVersionFormat,
@@ -167,20 +205,23 @@ var (
 
 // client-side error
 var (
-	ErrEmptyKey          = Error(ErrGRPCEmptyKey)
-	ErrKeyNotFound       = Error(ErrGRPCKeyNotFound)
-	ErrValueProvided     = Error(ErrGRPCValueProvided)
-	ErrLeaseProvided     = Error(ErrGRPCLeaseProvided)
-	ErrTooManyOps        = Error(ErrGRPCTooManyOps)
-	ErrDuplicateKey      = Error(ErrGRPCDuplicateKey)
-	ErrInvalidSortOption = Error(ErrGRPCInvalidSortOption)
-	ErrCompacted         = Error(ErrGRPCCompacted)
-	ErrFutureRev         = Error(ErrGRPCFutureRev)
-	ErrNoSpace           = Error(ErrGRPCNoSpace)
-
-	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
-	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
-	ErrLeaseTTLTooLarge = Error(ErrGRPCLeaseTTLTooLarge)
+	ErrEmptyKey           = Error(ErrGRPCEmptyKey)
+	ErrKeyNotFound        = Error(ErrGRPCKeyNotFound)
+	ErrValueProvided      = Error(ErrGRPCValueProvided)
+	ErrLeaseProvided      = Error(ErrGRPCLeaseProvided)
+	ErrTooManyOps         = Error(ErrGRPCTooManyOps)
+	ErrDuplicateKey       = Error(ErrGRPCDuplicateKey)
+	ErrInvalidSortOption  = Error(ErrGRPCInvalidSortOption)
+	ErrLazyDeletePrevKV   = Error(ErrGRPCLazyDeletePrevKV)
+	ErrLazyDeleteDisabled = Error(ErrGRPCLazyDeleteDisabled)
+	ErrCompacted          = Error(ErrGRPCCompacted)
+	ErrFutureRev          = Error(ErrGRPCFutureRev)
+	ErrNoSpace            = Error(ErrGRPCNoSpace)
+
+	ErrLeaseNotFound     = Error(ErrGRPCLeaseNotFound)
+	ErrLeaseExist        = Error(ErrGRPCLeaseExist)
+	ErrLeaseTTLTooLarge  = Error(ErrGRPCLeaseTTLTooLarge)
+	ErrLeaseLabelInvalid = Error(ErrGRPCLeaseLabelInvalid)
 
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
@@ -190,6 +231,17 @@ var (
 	ErrMemberNotLearner       = Error(ErrGRPCMemberNotLearner)
 	ErrMemberLearnerNotReady  = Error(ErrGRPCLearnerNotReady)
 	ErrTooManyLearners        = Error(ErrGRPCTooManyLearners)
+	ErrMemberIsReadReplica    = Error(ErrGRPCMemberIsReadReplica)
+
+	ErrClusterMetadataKeyEmpty = Error(ErrGRPCClusterMetadataKeyEmpty)
+	ErrClusterMetadataTooLarge = Error(ErrGRPCClusterMetadataTooLarge)
+
+	ErrSnapshotRevisionApplied = Error(ErrGRPCSnapshotRevisionApplied)
+
+	ErrInvalidValuePolicy       = Error(ErrGRPCInvalidValuePolicy)
+	ErrValuePolicyValueTooLarge = Error(ErrGRPCValuePolicyValueTooLarge)
+	ErrValuePolicyBadContent    = Error(ErrGRPCValuePolicyBadContent)
+	ErrValuePolicyKeyMismatch   = Error(ErrGRPCValuePolicyKeyMismatch)
 
 	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
 	ErrTooManyRequests = Error(ErrGRPCRequestTooManyRequests)
@@ -228,6 +280,11 @@ var (
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
 	ErrCorrupt                    = Error(ErrGRPCCorrupt)
 	ErrBadLeaderTransferee        = Error(ErrGRPCBadLeaderTransferee)
+	ErrReadReplicaTooStale        = Error(ErrGRPCReadReplicaTooStale)
+	ErrDraining                   = Error(ErrGRPCDraining)
+	ErrTooManyStreams             = Error(ErrGRPCTooManyStreams)
+	ErrTooManyWatchers            = Error(ErrGRPCTooManyWatchers)
+	ErrWatcherTooSlow             = Error(ErrGRPCWatcherTooSlow)
 
 	ErrClusterVersionUnavailable     = Error(ErrGRPCClusterVersionUnavailable)
 	ErrWrongDowngradeVersionFormat   = Error(ErrGRPCWrongDowngradeVersionFormat)
@@ -19,4 +19,8 @@ var (
 	MetadataHasLeader        = "true"
 
 	MetadataClientAPIVersionKey = "client-api-version"
+
+	// MetadataReadReplicaStalenessKey is the response header key carrying how
+	// far behind the leader a read replica is, in milliseconds.
+	MetadataReadReplicaStalenessKey = "read-replica-staleness-ms"
 )
@@ -0,0 +1,169 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SubsystemLevels holds log levels which override the level of the loggers
+// of individual subsystems at runtime. A nil *SubsystemLevels overrides
+// nothing.
+type SubsystemLevels struct {
+	defaultLevel zapcore.Level
+
+	mu     sync.RWMutex
+	levels map[string]*subsystemLevel
+}
+
+type subsystemLevel struct {
+	// level is nil if the subsystem logs at the level of the logger it wraps.
+	level *zapcore.Level
+	// expiry is the time the level reverts to the default, if set.
+	expiry time.Time
+	timer  *time.Timer
+}
+
+// SubsystemLevel is the current log level of a subsystem.
+type SubsystemLevel struct {
+	Subsystem string
+	Level     zapcore.Level
+	// TTL is the remaining time until the level reverts to the default, or
+	// zero if the level does not expire.
+	TTL time.Duration
+}
+
+// NewSubsystemLevels creates the log levels of the given subsystems, which
+// default to the given level.
+func NewSubsystemLevels(defaultLevel zapcore.Level, subsystems ...string) *SubsystemLevels {
+	l := &SubsystemLevels{defaultLevel: defaultLevel, levels: make(map[string]*subsystemLevel, len(subsystems))}
+	for _, s := range subsystems {
+		l.levels[s] = &subsystemLevel{}
+	}
+	return l
+}
+
+// Logger returns a logger writing to the core of lg whose level is
+// overridden by the level of the given subsystem.
+func (l *SubsystemLevels) Logger(lg *zap.Logger, subsystem string) *zap.Logger {
+	if l == nil || lg == nil {
+		return lg
+	}
+	return lg.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return &subsystemCore{Core: c, levels: l, subsystem: subsystem}
+	}))
+}
+
+// Set overrides the log level of the subsystem. The level reverts to the
+// default after ttl, unless ttl is zero. An empty level reverts it now.
+func (l *SubsystemLevels) Set(subsystem, level string, ttl time.Duration) error {
+	var lvl *zapcore.Level
+	if level != "" {
+		var parsed zapcore.Level
+		if err := parsed.Set(level); err != nil {
+			return err
+		}
+		lvl = &parsed
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sl, ok := l.levels[subsystem]
+	if !ok {
+		return fmt.Errorf("unknown log subsystem %q", subsystem)
+	}
+	if sl.timer != nil {
+		sl.timer.Stop()
+	}
+	next := &subsystemLevel{level: lvl}
+	if lvl != nil && ttl > 0 {
+		next.expiry = time.Now().Add(ttl)
+		next.timer = time.AfterFunc(ttl, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			// the level may have been set again since.
+			if l.levels[subsystem] == next {
+				l.levels[subsystem] = &subsystemLevel{}
+			}
+		})
+	}
+	l.levels[subsystem] = next
+	return nil
+}
+
+// Levels returns the current log level of all subsystems, sorted by name.
+func (l *SubsystemLevels) Levels() []SubsystemLevel {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := make([]SubsystemLevel, 0, len(l.levels))
+	for name, sl := range l.levels {
+		current := SubsystemLevel{Subsystem: name, Level: l.defaultLevel}
+		if sl.level != nil {
+			current.Level = *sl.level
+		}
+		if !sl.expiry.IsZero() {
+			current.TTL = max(time.Until(sl.expiry), 0)
+		}
+		levels = append(levels, current)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Subsystem < levels[j].Subsystem })
+	return levels
+}
+
+func (l *SubsystemLevels) level(subsystem string) *zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if sl, ok := l.levels[subsystem]; ok {
+		return sl.level
+	}
+	return nil
+}
+
+// subsystemCore filters entries by the level of its subsystem, if set. An
+// overridden level may be lower than the level of the wrapped core, so
+// enabled entries are written to the wrapped core without checking it.
+type subsystemCore struct {
+	zapcore.Core
+	levels    *SubsystemLevels
+	subsystem string
+}
+
+func (c *subsystemCore) Enabled(lvl zapcore.Level) bool {
+	if l := c.levels.level(c.subsystem); l != nil {
+		return l.Enabled(lvl)
+	}
+	return c.Core.Enabled(lvl)
+}
+
+func (c *subsystemCore) With(fields []zapcore.Field) zapcore.Core {
+	return &subsystemCore{Core: c.Core.With(fields), levels: c.levels, subsystem: c.subsystem}
+}
+
+func (c *subsystemCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	l := c.levels.level(c.subsystem)
+	if l == nil {
+		return c.Core.Check(ent, ce)
+	}
+	if l.Enabled(ent.Level) {
+		return ce.AddCore(ent, c.Core)
+	}
+	return ce
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSubsystemLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	lg := zap.New(core)
+	levels := NewSubsystemLevels(zapcore.InfoLevel, "mvcc", "raft")
+	mvcc := levels.Logger(lg, "mvcc").With(zap.String("k", "v"))
+	raft := levels.Logger(lg, "raft")
+
+	mvcc.Debug("hidden")
+	require.NoError(t, levels.Set("mvcc", "debug", 0))
+	mvcc.Debug("shown")
+	raft.Debug("hidden")
+	require.NoError(t, levels.Set("raft", "error", 0))
+	raft.Warn("hidden")
+	require.Equal(t, []SubsystemLevel{
+		{Subsystem: "mvcc", Level: zapcore.DebugLevel},
+		{Subsystem: "raft", Level: zapcore.ErrorLevel},
+	}, levels.Levels())
+
+	require.NoError(t, levels.Set("mvcc", "", 0))
+	mvcc.Debug("hidden")
+	mvcc.Info("shown")
+
+	var msgs []string
+	for _, e := range logs.All() {
+		msgs = append(msgs, e.Message)
+	}
+	require.Equal(t, []string{"shown", "shown"}, msgs)
+
+	require.Error(t, levels.Set("unknown", "debug", 0))
+	require.Error(t, levels.Set("mvcc", "verbose", 0))
+}
+
+func TestSubsystemLevelsTTL(t *testing.T) {
+	levels := NewSubsystemLevels(zapcore.InfoLevel, "mvcc")
+	require.NoError(t, levels.Set("mvcc", "debug", 50*time.Millisecond))
+	current := levels.Levels()[0]
+	require.Equal(t, zapcore.DebugLevel, current.Level)
+	require.Positive(t, current.TTL)
+
+	require.Eventually(t, func() bool {
+		return levels.Levels()[0].Level == zapcore.InfoLevel
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, levels.Levels()[0].TTL)
+}
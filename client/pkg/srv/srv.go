@@ -16,10 +16,13 @@
 package srv
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"go.etcd.io/etcd/client/pkg/v3/types"
 )
@@ -133,6 +136,33 @@ func GetClient(service, domain string, serviceName string) (*SRVClients, error)
 	return &SRVClients{Endpoints: endpoints, SRVs: srvs}, nil
 }
 
+// WatchClient looks up the client endpoints for a service and domain every
+// interval, and calls update with the endpoints whenever they differ from the
+// previous ones, starting with the given endpoints. Failed lookups and lookups
+// without endpoints keep the previous endpoints. It returns when ctx is done.
+func WatchClient(ctx context.Context, service, domain, serviceName string, interval time.Duration, endpoints []string, update func(endpoints []string)) {
+	current := slices.Sorted(slices.Values(endpoints))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		srvs, err := GetClient(service, domain, serviceName)
+		if err != nil || len(srvs.Endpoints) == 0 {
+			continue
+		}
+		eps := slices.Sorted(slices.Values(srvs.Endpoints))
+		if slices.Equal(eps, current) {
+			continue
+		}
+		current = eps
+		update(slices.Clone(eps))
+	}
+}
+
 // GetSRVService generates a SRV service including an optional suffix.
 func GetSRVService(service, serviceName string, scheme string) (SRVService string) {
 	if scheme == "https" {
@@ -15,12 +15,15 @@
 package srv
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -302,3 +305,50 @@ func TestGetSRVService(t *testing.T) {
 		}
 	}
 }
+
+func TestSRVWatchClient(t *testing.T) {
+	defer func() { lookupSRV = net.LookupSRV }()
+
+	var (
+		mu      sync.Mutex
+		targets = []string{"10.0.0.1"}
+		updates = make(chan []string, 10)
+	)
+	lookupSRV = func(service string, proto string, domain string) (string, []*net.SRV, error) {
+		if service != "etcd-client-ssl" {
+			return "", nil, notFoundErr(service, proto, domain)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		var srvs []*net.SRV
+		for _, target := range targets {
+			srvs = append(srvs, &net.SRV{Target: target, Port: 2379})
+		}
+		return "", srvs, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		WatchClient(ctx, "etcd-client", "example.com", "", time.Millisecond, []string{"https://10.0.0.1:2379"}, func(eps []string) { updates <- eps })
+		close(done)
+	}()
+
+	mu.Lock()
+	targets = []string{"10.0.0.3", "10.0.0.2"}
+	mu.Unlock()
+	require.Equal(t, []string{"https://10.0.0.2:2379", "https://10.0.0.3:2379"}, <-updates)
+
+	// lookups without endpoints keep the previous endpoints.
+	mu.Lock()
+	targets = nil
+	mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	targets = []string{"10.0.0.2", "10.0.0.3"}
+	mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-done
+	require.Empty(t, updates)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TargetTLS configures how the TLS connections to the targets discovered
+// through SRV records are verified. By default, all targets are verified
+// against the same CA bundle and the SRV domain.
+type TargetTLS struct {
+	// TrustedCAFiles maps SRV target host names to the CA bundle used to
+	// verify them. Targets without an entry use the default CA bundle.
+	TrustedCAFiles map[string]string
+	// VerifyTargetName verifies the certificate of each target against the
+	// target host name instead of the SRV domain.
+	VerifyTargetName bool
+}
+
+// ParseTargetCAFiles parses a list of "target=ca-file" pairs.
+func ParseTargetCAFiles(pairs []string) (map[string]string, error) {
+	cas := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		target, file, ok := strings.Cut(p, "=")
+		if !ok || target == "" || file == "" {
+			return nil, fmt.Errorf("invalid SRV target CA %q, expected \"target=ca-file\"", p)
+		}
+		cas[normalizeTarget(target)] = file
+	}
+	return cas, nil
+}
+
+// Enabled returns true if any target specific TLS setting is configured.
+func (t TargetTLS) Enabled() bool {
+	return len(t.TrustedCAFiles) > 0 || t.VerifyTargetName
+}
+
+// ClientConfig returns a copy of the given client TLS configuration which
+// verifies each target discovered in the given SRV domain according to the
+// target specific settings. The returned configuration sends the target host
+// name as the TLS server name, so that it can tell targets apart.
+func (t TargetTLS) ClientConfig(base *tls.Config, domain string) (*tls.Config, error) {
+	if base == nil {
+		base = &tls.Config{}
+	}
+	pools := make(map[string]*x509.CertPool, len(t.TrustedCAFiles))
+	for target, file := range t.TrustedCAFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", file)
+		}
+		pools[target] = pool
+	}
+
+	cfg := base.Clone()
+	if base.InsecureSkipVerify {
+		return cfg, nil
+	}
+	defaultRoots := base.RootCAs
+	verifyTargetName := t.VerifyTargetName
+	// certificates are verified in VerifyConnection, which knows the target.
+	cfg.InsecureSkipVerify = true
+	cfg.ServerName = ""
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("srv: no server certificate")
+		}
+		target := normalizeTarget(cs.ServerName)
+		roots, ok := pools[target]
+		if !ok {
+			roots = defaultRoots
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			DNSName:       domain,
+			Intermediates: x509.NewCertPool(),
+		}
+		if verifyTargetName {
+			opts.DNSName = target
+		}
+		for _, cert := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+			return fmt.Errorf("srv: failed to verify target %q: %w", target, err)
+		}
+		return nil
+	}
+	return cfg, nil
+}
+
+// normalizeTarget strips the trailing dot of SRV targets.
+func normalizeTarget(target string) string {
+	return strings.ToLower(strings.TrimSuffix(target, "."))
+}
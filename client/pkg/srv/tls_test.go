@@ -0,0 +1,76 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+func TestParseTargetCAFiles(t *testing.T) {
+	cas, err := ParseTargetCAFiles([]string{"a.example.com.=a.crt", "B.example.com=b.crt"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a.example.com": "a.crt", "b.example.com": "b.crt"}, cas)
+
+	_, err = ParseTargetCAFiles([]string{"a.example.com"})
+	require.Error(t, err)
+}
+
+func TestTargetTLSClientConfig(t *testing.T) {
+	selfCert := func(host string) transport.TLSInfo {
+		info, err := transport.SelfCert(zaptest.NewLogger(t), t.TempDir(), []string{host + ":2379"}, 1)
+		require.NoError(t, err)
+		return info
+	}
+	a, b := selfCert("a.example.com"), selfCert("b.example.com")
+
+	handshake := func(cfg *tls.Config, target string, server transport.TLSInfo) error {
+		scfg, err := server.ServerConfig()
+		require.NoError(t, err)
+		cc, sc := net.Pipe()
+		defer cc.Close()
+		defer sc.Close()
+		go tls.Server(sc, scfg).Handshake()
+		ccfg := cfg.Clone()
+		// gRPC sends the target host name when no server name is configured.
+		if ccfg.ServerName == "" {
+			ccfg.ServerName = target
+		}
+		return tls.Client(cc, ccfg).Handshake()
+	}
+
+	tt := TargetTLS{
+		TrustedCAFiles:   map[string]string{"a.example.com": a.CertFile, "b.example.com": b.CertFile},
+		VerifyTargetName: true,
+	}
+	cfg, err := tt.ClientConfig(&tls.Config{ServerName: "example.com"}, "example.com")
+	require.NoError(t, err)
+	require.NoError(t, handshake(cfg, "a.example.com", a))
+	require.NoError(t, handshake(cfg, "b.example.com", b))
+	// the certificate of a target must be signed by the CA of the target.
+	require.ErrorContains(t, handshake(cfg, "a.example.com", b), "failed to verify target")
+
+	// without target name verification, targets are verified against the domain.
+	tt.VerifyTargetName = false
+	cfg, err = tt.ClientConfig(nil, "example.com")
+	require.NoError(t, err)
+	require.ErrorContains(t, handshake(cfg, "a.example.com", a), "example.com")
+}
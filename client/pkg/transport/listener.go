@@ -50,14 +50,21 @@ func NewListenerWithOpts(addr, scheme string, opts ...ListenerOption) (net.Liste
 }
 
 func newListener(addr, scheme string, opts ...ListenerOption) (net.Listener, error) {
-	if scheme == "unix" || scheme == "unixs" {
-		// unix sockets via unix://laddr
-		return NewUnixListener(addr)
-	}
-
 	lnOpts := newListenOpts(opts...)
 
 	switch {
+	case lnOpts.Listener != nil:
+		// listener provided by the caller.
+		if lnOpts.IsTimeout() {
+			lnOpts.Listener = &rwTimeoutListener{
+				Listener:     lnOpts.Listener,
+				readTimeout:  lnOpts.readTimeout,
+				writeTimeout: lnOpts.writeTimeout,
+			}
+		}
+	case scheme == "unix" || scheme == "unixs":
+		// unix sockets via unix://laddr
+		return NewUnixListener(addr)
 	case lnOpts.IsSocketOpts():
 		// new ListenConfig with socket options.
 		lnOpts.ListenConfig = newListenConfig(lnOpts.socketOpts)
@@ -207,6 +214,10 @@ type TLSInfo struct {
 
 	// LocalAddr is the local IP address to use when communicating with a peer.
 	LocalAddr string
+
+	// ClientConfigHook optionally adjusts the configuration returned by
+	// ClientConfig, e.g. to verify each server with its own settings.
+	ClientConfigHook func(*tls.Config) (*tls.Config, error)
 }
 
 func (info TLSInfo) String() string {
@@ -609,6 +620,9 @@ func (info TLSInfo) ClientConfig() (*tls.Config, error) {
 		}
 	}
 
+	if info.ClientConfigHook != nil {
+		return info.ClientConfigHook(cfg)
+	}
 	return cfg, nil
 }
 
@@ -82,6 +82,12 @@ func WithTLSInfo(t *TLSInfo) ListenerOption {
 	return func(lo *ListenerOptions) { lo.tlsInfo = t }
 }
 
+// WithListener uses the given listener, e.g. inherited through socket
+// activation, instead of creating a new one.
+func WithListener(l net.Listener) ListenerOption {
+	return func(lo *ListenerOptions) { lo.Listener = l }
+}
+
 // WithSkipTLSInfoCheck when true a transport can be created with an https scheme
 // without passing TLSInfo, circumventing not presented error. Skipping this check
 // also requires that TLSInfo is not passed.
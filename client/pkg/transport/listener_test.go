@@ -498,6 +498,24 @@ func TestNewListenerUnixSocket(t *testing.T) {
 	l.Close()
 }
 
+func TestNewListenerWithListener(t *testing.T) {
+	tlsInfo, err := createSelfCert(t)
+	require.NoErrorf(t, err, "unable to create cert")
+
+	inherited, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ln, err := NewListenerWithOpts(inherited.Addr().String(), "https",
+		WithListener(inherited),
+		WithTLSInfo(tlsInfo),
+		WithTimeout(time.Second, time.Second),
+	)
+	require.NoError(t, err)
+	defer ln.Close()
+	require.Equal(t, inherited.Addr(), ln.Addr())
+	_, ok := ln.(*tlsListener)
+	require.Truef(t, ok, "expected TLS listener, got %T", ln)
+}
+
 // TestNewListenerTLSInfoSelfCert tests that a new certificate accepts connections.
 func TestNewListenerTLSInfoSelfCert(t *testing.T) {
 	tmpdir := t.TempDir()
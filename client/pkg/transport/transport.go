@@ -16,8 +16,11 @@ package transport
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 )
@@ -57,6 +60,9 @@ func NewTransport(info TLSInfo, dialtimeoutd time.Duration) (*http.Transport, er
 	}
 
 	dialContext := func(ctx context.Context, net, addr string) (net.Conn, error) {
+		if path, ok := decodeUnixSocketHost(addr); ok {
+			addr = path
+		}
 		return dialer.DialContext(ctx, "unix", addr)
 	}
 	tu := &http.Transport{
@@ -82,5 +88,62 @@ func (urt *unixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	url := *req.URL
 	req.URL = &url
 	req.URL.Scheme = strings.Replace(req.URL.Scheme, "unix", "http", 1)
+	if req.URL.Host == "" {
+		// unix:///path/to/socket/request/path addresses the socket by its
+		// absolute path, which is followed by the request path.
+		sock, rest, err := splitUnixSocketPath(req.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		req.URL.Host = encodeUnixSocketHost(sock)
+		req.URL.Path, req.URL.RawPath = rest, ""
+		if req.Host == "" {
+			req.Host = "localhost"
+		}
+	}
 	return urt.Transport.RoundTrip(req)
 }
+
+// unixSocketHostPrefix marks hosts encoding the absolute path of a unix socket.
+const unixSocketHostPrefix = "unix-socket-"
+
+func encodeUnixSocketHost(path string) string {
+	return unixSocketHostPrefix + hex.EncodeToString([]byte(path))
+}
+
+func decodeUnixSocketHost(addr string) (string, bool) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if !strings.HasPrefix(host, unixSocketHostPrefix) {
+		return "", false
+	}
+	path, err := hex.DecodeString(strings.TrimPrefix(host, unixSocketHostPrefix))
+	if err != nil {
+		return "", false
+	}
+	return string(path), true
+}
+
+// splitUnixSocketPath splits an absolute URL path into the path of the
+// unix socket it starts with and the remaining request path.
+func splitUnixSocketPath(p string) (sock, rest string, err error) {
+	for i := 1; i <= len(p); i++ {
+		if i < len(p) && p[i] != '/' {
+			continue
+		}
+		fi, serr := os.Stat(p[:i])
+		if serr != nil {
+			break
+		}
+		if fi.Mode()&os.ModeSocket != 0 {
+			rest = p[i:]
+			if rest == "" {
+				rest = "/"
+			}
+			return p[:i], rest, nil
+		}
+	}
+	return "", "", fmt.Errorf("transport: no unix socket found in path %q", p)
+}
@@ -16,7 +16,10 @@ package transport
 
 import (
 	"crypto/tls"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +27,39 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// TestNewTransportUnixSocketAbsolutePath expects unix URLs addressing the
+// socket by its absolute path to reach the socket with the remaining path.
+func TestNewTransportUnixSocketAbsolutePath(t *testing.T) {
+	// keep the socket path short enough for sockaddr_un.
+	dir, err := os.MkdirTemp("", "etcd-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "peer.sock")
+
+	ln, err := NewListener(sock, "unix", nil)
+	require.NoError(t, err)
+	defer ln.Close()
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	tr, err := NewTransport(TLSInfo{}, time.Second)
+	require.NoError(t, err)
+	for path, want := range map[string]string{"/raft/stream": "/raft/stream", "": "/"} {
+		resp, err := (&http.Client{Transport: tr}).Get("unix://" + sock + path)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, want, string(body))
+	}
+
+	_, err = (&http.Client{Transport: tr}).Get("unix://" + dir + "/missing.sock/health")
+	require.ErrorContains(t, err, "no unix socket found")
+}
+
 // TestNewTransportTLSInvalidCipherSuitesTLS12 expects a client with invalid
 // cipher suites fail to handshake with the server.
 func TestNewTransportTLSInvalidCipherSuitesTLS12(t *testing.T) {
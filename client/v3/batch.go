@@ -0,0 +1,65 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// DoBatch applies the independent operations in a single round trip and
+// returns their responses, in the order of the operations. It is equivalent
+// to calling Do on every operation, except that the operations are packed
+// into a transaction without comparisons, so they are applied at once at the
+// same revision and a later operation sees the changes of the earlier ones.
+//
+// As for any transaction, the number of operations is bounded by the
+// "--max-txn-ops" flag of the server and a key must not be written by more
+// than one operation; otherwise the whole batch fails and no operation is
+// applied.
+func DoBatch(ctx context.Context, kv KV, ops ...Op) ([]OpResponse, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	resp, err := kv.Txn(ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	return opResponsesFromTxn(resp), nil
+}
+
+// opResponsesFromTxn unpacks the responses of the operations of a
+// transaction. The responses get the header of the transaction.
+func opResponsesFromTxn(resp *TxnResponse) []OpResponse {
+	resps := make([]OpResponse, len(resp.Responses))
+	for i, r := range resp.Responses {
+		switch tr := r.Response.(type) {
+		case *pb.ResponseOp_ResponseRange:
+			tr.ResponseRange.Header = resp.Header
+			resps[i] = (*GetResponse)(tr.ResponseRange).OpResponse()
+		case *pb.ResponseOp_ResponsePut:
+			tr.ResponsePut.Header = resp.Header
+			resps[i] = (*PutResponse)(tr.ResponsePut).OpResponse()
+		case *pb.ResponseOp_ResponseDeleteRange:
+			tr.ResponseDeleteRange.Header = resp.Header
+			resps[i] = (*DeleteResponse)(tr.ResponseDeleteRange).OpResponse()
+		case *pb.ResponseOp_ResponseTxn:
+			tr.ResponseTxn.Header = resp.Header
+			resps[i] = (*TxnResponse)(tr.ResponseTxn).OpResponse()
+		}
+	}
+	return resps
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestOpResponsesFromTxn(t *testing.T) {
+	header := &pb.ResponseHeader{Revision: 5}
+	resp := &TxnResponse{
+		Header:    header,
+		Succeeded: true,
+		Responses: []*pb.ResponseOp{
+			{Response: &pb.ResponseOp_ResponsePut{ResponsePut: &pb.PutResponse{}}},
+			{Response: &pb.ResponseOp_ResponseRange{ResponseRange: &pb.RangeResponse{
+				Kvs:   []*mvccpb.KeyValue{{Key: []byte("a"), Value: []byte("1")}},
+				Count: 1,
+			}}},
+			{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &pb.DeleteRangeResponse{Deleted: 2}}},
+			{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: &pb.TxnResponse{Succeeded: true}}},
+		},
+	}
+
+	resps := opResponsesFromTxn(resp)
+	require.Len(t, resps, 4)
+	require.NotNil(t, resps[0].Put())
+	assert.Equal(t, header, resps[0].Put().Header)
+	require.NotNil(t, resps[1].Get())
+	assert.Equal(t, int64(1), resps[1].Get().Count)
+	assert.Equal(t, header, resps[1].Get().Header)
+	require.NotNil(t, resps[2].Del())
+	assert.Equal(t, int64(2), resps[2].Del().Deleted)
+	require.NotNil(t, resps[3].Txn())
+	assert.True(t, resps[3].Txn().Succeeded)
+}
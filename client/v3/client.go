@@ -35,6 +35,7 @@ import (
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
+	"go.etcd.io/etcd/client/v3/compression"
 	"go.etcd.io/etcd/client/v3/credentials"
 	"go.etcd.io/etcd/client/v3/internal/endpoint"
 	"go.etcd.io/etcd/client/v3/internal/resolver"
@@ -431,6 +432,13 @@ func newClient(cfg *Config) (*Client, error) {
 		}
 		client.callOpts = callOpts
 	}
+	if cfg.CompressionAlgorithm != "" {
+		if err := compression.Validate(cfg.CompressionAlgorithm); err != nil {
+			client.cancel()
+			return nil, err
+		}
+		client.callOpts = append(append([]grpc.CallOption{}, client.callOpts...), grpc.UseCompressor(cfg.CompressionAlgorithm))
+	}
 
 	client.resolver = resolver.New(cfg.Endpoints...)
 
@@ -452,6 +452,18 @@ func (mm mockMaintenance) AlarmDisarm(ctx context.Context, m *AlarmMember) (*Ala
 	return nil, nil
 }
 
+func (mm mockMaintenance) ValuePolicyList(ctx context.Context) (*ValuePolicyResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ValuePolicyPut(ctx context.Context, p *ValuePolicy) (*ValuePolicyResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ValuePolicyDelete(ctx context.Context, prefix string) (*ValuePolicyResponse, error) {
+	return nil, nil
+}
+
 func (mm mockMaintenance) Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error) {
 	return nil, nil
 }
@@ -464,6 +476,10 @@ func (mm mockMaintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotRes
 	return nil, nil
 }
 
+func (mm mockMaintenance) SnapshotAtRevision(ctx context.Context, endpoint string, rev int64) (*SnapshotResponse, error) {
+	return nil, nil
+}
+
 func (mm mockMaintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	return nil, nil
 }
@@ -476,6 +492,34 @@ func (mm mockMaintenance) Downgrade(ctx context.Context, action DowngradeAction,
 	return nil, nil
 }
 
+func (mm mockMaintenance) Drain(ctx context.Context, endpoint string) (*DrainResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ConfigSet(ctx context.Context, endpoint string, settings map[string]string) (*ConfigSetResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) LogLevelSet(ctx context.Context, endpoint string, levels map[string]string, ttl time.Duration) (*LogLevelSetResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ScrubStart(ctx context.Context, endpoint string, rateBytes int64, checkPages bool) (*ScrubResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ScrubStatus(ctx context.Context, endpoint string) (*ScrubResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) ScrubCancel(ctx context.Context, endpoint string) (*ScrubResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) FeatureGates(ctx context.Context, endpoint string) (*FeatureGatesResponse, error) {
+	return nil, nil
+}
+
 type mockAuthServer struct {
 	*etcdserverpb.UnimplementedAuthServer
 }
@@ -511,3 +555,15 @@ func (mc *mockCluster) MemberUpdate(ctx context.Context, id uint64, peerAddrs []
 func (mc *mockCluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error) {
 	return nil, nil
 }
+
+func (mc *mockCluster) ClusterMetadataPut(ctx context.Context, key, value string) (*ClusterMetadataPutResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) ClusterMetadataDelete(ctx context.Context, key string) (*ClusterMetadataDeleteResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) ClusterMetadataList(ctx context.Context, opts ...OpOption) (*ClusterMetadataListResponse, error) {
+	return nil, nil
+}
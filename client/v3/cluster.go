@@ -30,6 +30,10 @@ type (
 	MemberRemoveResponse  pb.MemberRemoveResponse
 	MemberUpdateResponse  pb.MemberUpdateResponse
 	MemberPromoteResponse pb.MemberPromoteResponse
+
+	ClusterMetadataPutResponse    pb.ClusterMetadataPutResponse
+	ClusterMetadataDeleteResponse pb.ClusterMetadataDeleteResponse
+	ClusterMetadataListResponse   pb.ClusterMetadataListResponse
 )
 
 type Cluster interface {
@@ -50,6 +54,19 @@ type Cluster interface {
 
 	// MemberPromote promotes a member from raft learner (non-voting) to raft voting member.
 	MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error)
+
+	// ClusterMetadataPut sets an entry of the cluster metadata, the
+	// operational annotations of the cluster, e.g. maintenance windows or
+	// ownership labels. The cluster metadata is not subject to compaction.
+	ClusterMetadataPut(ctx context.Context, key, value string) (*ClusterMetadataPutResponse, error)
+
+	// ClusterMetadataDelete deletes an entry of the cluster metadata.
+	ClusterMetadataDelete(ctx context.Context, key string) (*ClusterMetadataDeleteResponse, error)
+
+	// ClusterMetadataList lists the entries of the cluster metadata.
+	// WithSerializable lists the entries known to the member serving the
+	// request.
+	ClusterMetadataList(ctx context.Context, opts ...OpOption) (*ClusterMetadataListResponse, error)
 }
 
 type cluster struct {
@@ -139,3 +156,30 @@ func (c *cluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteR
 	}
 	return (*MemberPromoteResponse)(resp), nil
 }
+
+func (c *cluster) ClusterMetadataPut(ctx context.Context, key, value string) (*ClusterMetadataPutResponse, error) {
+	r := &pb.ClusterMetadataPutRequest{Key: key, Value: value}
+	resp, err := c.remote.ClusterMetadataPut(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ClusterMetadataPutResponse)(resp), nil
+}
+
+func (c *cluster) ClusterMetadataDelete(ctx context.Context, key string) (*ClusterMetadataDeleteResponse, error) {
+	r := &pb.ClusterMetadataDeleteRequest{Key: key}
+	resp, err := c.remote.ClusterMetadataDelete(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ClusterMetadataDeleteResponse)(resp), nil
+}
+
+func (c *cluster) ClusterMetadataList(ctx context.Context, opts ...OpOption) (*ClusterMetadataListResponse, error) {
+	opt := OpGet("", opts...)
+	resp, err := c.remote.ClusterMetadataList(ctx, &pb.ClusterMetadataListRequest{Linearizable: !opt.serializable}, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ClusterMetadataListResponse)(resp), nil
+}
@@ -20,8 +20,9 @@ import (
 
 // CompactOp represents a compact operation.
 type CompactOp struct {
-	revision int64
-	physical bool
+	revision   int64
+	physical   bool
+	retentions []*pb.CompactionRetention
 }
 
 // CompactOption configures compact operation.
@@ -41,7 +42,7 @@ func OpCompact(rev int64, opts ...CompactOption) CompactOp {
 }
 
 func (op CompactOp) toRequest() *pb.CompactionRequest {
-	return &pb.CompactionRequest{Revision: op.revision, Physical: op.physical}
+	return &pb.CompactionRequest{Revision: op.revision, Physical: op.physical, Retentions: op.retentions}
 }
 
 // WithCompactPhysical makes Compact wait until all compacted entries are
@@ -49,3 +50,13 @@ func (op CompactOp) toRequest() *pb.CompactionRequest {
 func WithCompactPhysical() CompactOption {
 	return func(op *CompactOp) { op.physical = true }
 }
+
+// WithCompactRetention keeps the history of the keys with the prefix from rev
+// on, instead of compacting it at the compaction revision. The retention with
+// the longest prefix of a key applies to it, and a retention later than the
+// compaction revision compacts the history of its keys further.
+func WithCompactRetention(prefix string, rev int64) CompactOption {
+	return func(op *CompactOp) {
+		op.retentions = append(op.retentions, &pb.CompactionRetention{Prefix: []byte(prefix), Revision: rev})
+	}
+}
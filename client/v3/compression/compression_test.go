@@ -0,0 +1,58 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressorsRoundTrip(t *testing.T) {
+	msg := bytes.Repeat([]byte("etcd compression "), 4096)
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run(name, func(t *testing.T) {
+			c := encoding.GetCompressor(name)
+			require.NotNil(t, c)
+			// the second round reuses the pooled encoder and decoder.
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				w, err := c.Compress(&buf)
+				require.NoError(t, err)
+				_, err = w.Write(msg)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				assert.Less(t, buf.Len(), len(msg)/10)
+
+				r, err := c.Decompress(&buf)
+				require.NoError(t, err)
+				got, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, msg, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, name := range []string{"", Gzip, Zstd} {
+		assert.NoError(t, Validate(name))
+	}
+	assert.Error(t, Validate("snappy"))
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// historyProgressInterval is the interval at which ElectionHistory requests
+// the progress of its watch, to learn it caught up with the current revision.
+var historyProgressInterval = 100 * time.Millisecond
+
+const (
+	// historyInitialWindow is the number of the last revisions ElectionHistory
+	// replays first, doubled until enough leadership changes are found.
+	historyInitialWindow = 1000
+	// HistoryMaxWindow is the maximum number of the last revisions
+	// ElectionHistory replays, which bounds its cost on long histories.
+	HistoryMaxWindow = 128000
+)
+
+// LeaderChange is a key becoming the leader of an election.
+type LeaderChange struct {
+	// Kv is the leader key as of Revision.
+	Kv *mvccpb.KeyValue
+	// Revision is the revision the key became the leader at. The leader of
+	// the oldest revision replayed, after a compaction or beyond
+	// HistoryMaxWindow revisions, is reported as becoming the leader at that
+	// revision.
+	Revision int64
+}
+
+// Epoch returns the leadership epoch of the change. Every new leader has a
+// greater epoch than the previous ones, so the epoch can be used as a fencing
+// token. It is the create revision of the leader key.
+func (c LeaderChange) Epoch() int64 { return c.Kv.CreateRevision }
+
+// ElectionHistory returns up to the last n leadership changes of the election
+// of the given name, as used by NewElection, oldest first, and the header of
+// the revision they are replayed up to. If the election has a leader, the
+// last change is the one of the current leader. The changes are replayed from
+// the history of the keys of the election, so the changes before the
+// compacted revision, or before the last HistoryMaxWindow revisions, are not
+// returned.
+func ElectionHistory(ctx context.Context, client *v3.Client, name string, n int) ([]LeaderChange, *pb.ResponseHeader, error) {
+	pfx := name + "/"
+	resp, err := client.Get(ctx, pfx, v3.WithPrefix(), v3.WithCountOnly())
+	if err != nil {
+		return nil, nil, err
+	}
+	end := resp.Header.Revision
+
+	for window := int64(historyInitialWindow); ; window = min(2*window, HistoryMaxWindow) {
+		start := max(1, end-window+1)
+		changes, replayed, err := replayLeaderChanges(ctx, client, pfx, start, end)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the first change of a replay starting after the first revision may
+		// be the one of a leader elected before, so more changes than needed
+		// are replayed unless no earlier revision is available.
+		if len(changes) > n || start == 1 || replayed != start || window == HistoryMaxWindow {
+			if len(changes) > n {
+				changes = changes[len(changes)-n:]
+			}
+			return changes, resp.Header, nil
+		}
+	}
+}
+
+// replayLeaderChanges replays the leadership changes of the keys under the
+// prefix from start, or from the compacted revision if start is compacted, to
+// end. It returns the revision the changes are replayed from.
+func replayLeaderChanges(ctx context.Context, client *v3.Client, pfx string, start, end int64) ([]LeaderChange, int64, error) {
+	for {
+		changes, from, err := leaderChanges(ctx, client, pfx, start, end)
+		if !errors.Is(err, rpctypes.ErrCompacted) {
+			return changes, start, err
+		}
+		start = from
+	}
+}
+
+// leaderChanges replays the leadership changes of the keys under the prefix
+// from start to end. If start is compacted, it returns ErrCompacted and the
+// revision to retry from.
+func leaderChanges(ctx context.Context, client *v3.Client, pfx string, start, end int64) ([]LeaderChange, int64, error) {
+	r := leaderReplay{kvs: make(map[string]*mvccpb.KeyValue)}
+	if start > 1 {
+		// the keys at the compacted revision are still available.
+		resp, err := client.Get(ctx, pfx, v3.WithPrefix(), v3.WithRev(start))
+		if errors.Is(err, rpctypes.ErrCompacted) {
+			// the compacted revision is only learnt from a watch, which
+			// fails again from the first revision.
+			return nil, 1, err
+		}
+		if err != nil {
+			return nil, start, err
+		}
+		for _, kv := range resp.Kvs {
+			r.kvs[string(kv.Key)] = kv
+		}
+		r.elect(start)
+		if start == end {
+			return r.changes, start, nil
+		}
+		start++
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch := client.Watch(wctx, pfx, v3.WithPrefix(), v3.WithRev(start))
+	ticker := time.NewTicker(historyProgressInterval)
+	defer ticker.Stop()
+	rev := start - 1
+	for {
+		select {
+		case <-ticker.C:
+			// the progress is only notified once the watch is synced.
+			client.RequestProgress(wctx)
+			continue
+		case wr, ok := <-wch:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return nil, start, err
+				}
+				return nil, start, errors.New("lost watcher replaying election history")
+			}
+			if wr.CompactRevision != 0 {
+				return nil, wr.CompactRevision, rpctypes.ErrCompacted
+			}
+			if err := wr.Err(); err != nil {
+				return nil, start, err
+			}
+			done := wr.IsProgressNotify() && wr.Header.Revision >= end
+			for _, ev := range wr.Events {
+				if ev.Kv.ModRevision > end {
+					done = true
+					break
+				}
+				if ev.Kv.ModRevision != rev {
+					r.elect(rev)
+					rev = ev.Kv.ModRevision
+				}
+				r.apply(ev)
+			}
+			if done || rev == end {
+				r.elect(rev)
+				return r.changes, start, nil
+			}
+		}
+	}
+}
+
+// leaderReplay tracks the keys of an election to find its leadership changes.
+type leaderReplay struct {
+	kvs map[string]*mvccpb.KeyValue
+	// epoch is the create revision of the current leader key.
+	epoch   int64
+	changes []LeaderChange
+}
+
+func (r *leaderReplay) apply(ev *v3.Event) {
+	if ev.IsRangeDelete() {
+		// a range end of "\x00" deletes all keys from the key on.
+		end := string(ev.RangeEnd)
+		for k := range r.kvs {
+			if k >= string(ev.Kv.Key) && (end == "\x00" || k < end) {
+				delete(r.kvs, k)
+			}
+		}
+		return
+	}
+	if ev.Type == mvccpb.DELETE {
+		delete(r.kvs, string(ev.Kv.Key))
+		return
+	}
+	r.kvs[string(ev.Kv.Key)] = ev.Kv
+}
+
+// elect records a leadership change at rev if the oldest key changed.
+func (r *leaderReplay) elect(rev int64) {
+	var leader *mvccpb.KeyValue
+	for _, kv := range r.kvs {
+		if leader == nil || kv.CreateRevision < leader.CreateRevision {
+			leader = kv
+		}
+	}
+	if leader == nil {
+		r.epoch = 0
+		return
+	}
+	if leader.CreateRevision != r.epoch {
+		r.epoch = leader.CreateRevision
+		r.changes = append(r.changes, LeaderChange{Kv: leader, Revision: rev})
+	}
+}
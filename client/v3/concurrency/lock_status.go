@@ -0,0 +1,99 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+var (
+	ErrLockNotHeld       = errors.New("mutex: lock is not held")
+	ErrLockHolderChanged = errors.New("mutex: lock holder changed")
+)
+
+// LockContender is a key of a session holding or waiting for a lock.
+type LockContender struct {
+	Key            string
+	Lease          v3.LeaseID
+	CreateRevision int64
+	// TTL is the remaining TTL of the lease in seconds, or -1 if the lease
+	// expired.
+	TTL int64
+}
+
+// LockState is the holder and the waiters of a lock.
+type LockState struct {
+	Header *pb.ResponseHeader
+	// Holder is nil if the lock is not held.
+	Holder *LockContender
+	// Waiters are in the order they acquire the lock.
+	Waiters []LockContender
+}
+
+// GetLockState returns the state of the lock of the given name, as used by
+// NewMutex, without contending for it.
+func GetLockState(ctx context.Context, client *v3.Client, name string) (*LockState, error) {
+	resp, err := client.Get(ctx, name+"/", v3.WithPrefix(), v3.WithSort(v3.SortByCreateRevision, v3.SortAscend))
+	if err != nil {
+		return nil, err
+	}
+	state := &LockState{Header: resp.Header}
+	for i, kv := range resp.Kvs {
+		c := LockContender{Key: string(kv.Key), Lease: v3.LeaseID(kv.Lease), CreateRevision: kv.CreateRevision}
+		if kv.Lease != 0 {
+			lresp, err := client.TimeToLive(ctx, c.Lease)
+			if err != nil {
+				return nil, err
+			}
+			c.TTL = lresp.TTL
+		}
+		if i == 0 {
+			state.Holder = &c
+			continue
+		}
+		state.Waiters = append(state.Waiters, c)
+	}
+	return state, nil
+}
+
+// ForceUnlock releases the lock of the given name on behalf of its holder,
+// e.g. a crashed client whose lease has not expired yet, and returns the
+// released holder. The next waiter then acquires the lock. The lease of the
+// holder is not revoked.
+func ForceUnlock(ctx context.Context, client *v3.Client, name string) (*LockContender, *pb.ResponseHeader, error) {
+	state, err := GetLockState(ctx, client, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state.Holder == nil {
+		return nil, nil, ErrLockNotHeld
+	}
+	holder := state.Holder
+	resp, err := client.Txn(ctx).
+		If(v3.Compare(v3.CreateRevision(holder.Key), "=", holder.CreateRevision)).
+		Then(v3.OpDelete(holder.Key)).
+		Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !resp.Succeeded {
+		return nil, nil, ErrLockHolderChanged
+	}
+	return holder, resp.Header, nil
+}
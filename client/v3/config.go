@@ -57,6 +57,12 @@ type Config struct {
 	// ("--max-recv-bytes" flag to etcd).
 	MaxCallRecvMsgSize int
 
+	// CompressionAlgorithm is the algorithm compressing the requests, either
+	// "gzip" or "zstd". The server compresses its responses with the same
+	// algorithm, which mostly benefits large Range responses and snapshots.
+	// If empty, messages are not compressed.
+	CompressionAlgorithm string `json:"compression-algorithm"`
+
 	// TLS holds the client secure credentials, if any.
 	TLS *tls.Config
 
@@ -115,6 +121,8 @@ type ConfigSpec struct {
 	MaxCallRecvMsgSize int           `json:"max-recv-bytes"`
 	Secure             *SecureConfig `json:"secure"`
 	Auth               *AuthConfig   `json:"auth"`
+
+	CompressionAlgorithm string `json:"compression"`
 }
 
 type SecureConfig struct {
@@ -174,6 +182,7 @@ func NewClientConfig(confSpec *ConfigSpec, lg *zap.Logger) (*Config, error) {
 		DialKeepAliveTimeout: confSpec.KeepAliveTimeout,
 		MaxCallSendMsgSize:   confSpec.MaxCallSendMsgSize,
 		MaxCallRecvMsgSize:   confSpec.MaxCallRecvMsgSize,
+		CompressionAlgorithm: confSpec.CompressionAlgorithm,
 		TLS:                  tlsCfg,
 	}
 
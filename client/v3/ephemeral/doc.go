@@ -0,0 +1,39 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ephemeral puts keys that live only as long as a context and the
+// process that put them, such as service discovery registrations.
+//
+// Put a key from a clientv3.Client 'cli' for the lifetime of 'ctx':
+//
+//	k, err := ephemeral.Put(ctx, cli, "services/foo/10.0.0.1", "10.0.0.1:2379",
+//	    ephemeral.WithTTL(10),
+//	    ephemeral.WithOnLost(func(key string, err error) {
+//	        // re-register, or exit
+//	    }),
+//	)
+//	if err != nil {
+//	    // handle error
+//	}
+//
+// The keys put with the same client and TTL share a lease, which is kept alive
+// with jittered renewals. Once ctx is canceled, the key is deleted and Done of
+// 'k' closes; once no key uses the lease anymore, it is revoked. If the process
+// dies, the lease expires after its TTL and removes its keys.
+//
+// If the lease cannot be kept alive, e.g. it expired while the client was
+// partitioned from the cluster or it was revoked, its keys are lost: the loss
+// callbacks run, Done closes and Err returns ErrLeaseLost. Later puts use a
+// new lease.
+package ephemeral
@@ -0,0 +1,152 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeral
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+const defaultTTL = 10
+
+// ErrLeaseLost is returned by Key.Err when the lease of the key could not be
+// kept alive, so that the key may have been removed.
+var ErrLeaseLost = errors.New("ephemeral: lease lost")
+
+// Key is a key put for the lifetime of a context.
+type Key struct {
+	key    string
+	lease  *managedLease
+	id     v3.LeaseID
+	onLost []func(key string, err error)
+
+	donec chan struct{}
+	err   error
+}
+
+// Put puts the key with the value, attached to the lease managed for the
+// client and TTL, until ctx is canceled or the lease is lost. Once ctx is
+// canceled, the key is deleted unless it has been overwritten by another
+// lease, and Done of the returned Key closes.
+func Put(ctx context.Context, client *v3.Client, key, val string, opts ...Option) (*Key, error) {
+	ops := &options{ttl: defaultTTL}
+	for _, opt := range opts {
+		opt(ops, client.GetLogger())
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ml := attach(client, ops.ttl)
+	id, err := ml.grant(ctx)
+	if err == nil {
+		_, err = client.Put(ctx, key, val, v3.WithLease(id))
+	}
+	if err != nil {
+		ml.detach()
+		return nil, err
+	}
+
+	k := &Key{key: key, lease: ml, id: id, onLost: ops.onLost, donec: make(chan struct{})}
+	go k.run(ctx)
+	return k, nil
+}
+
+// Key returns the key.
+func (k *Key) Key() string { return k.key }
+
+// Lease is the lease ID the key is attached to.
+func (k *Key) Lease() v3.LeaseID { return k.id }
+
+// Done returns a channel that closes when the key is deleted after its
+// context was canceled, or when its lease is lost.
+func (k *Key) Done() <-chan struct{} { return k.donec }
+
+// Err returns nil while Done is not closed, the error of the context if the
+// key was deleted, or ErrLeaseLost if its lease was lost.
+func (k *Key) Err() error {
+	select {
+	case <-k.donec:
+		return k.err
+	default:
+		return nil
+	}
+}
+
+func (k *Key) run(ctx context.Context) {
+	defer close(k.donec)
+	select {
+	case <-ctx.Done():
+		k.err = ctx.Err()
+		k.delete()
+		k.lease.detach()
+	case <-k.lease.lostc:
+		k.err = k.lease.err
+		k.lease.detach()
+		for _, f := range k.onLost {
+			f(k.key, k.err)
+		}
+	}
+}
+
+// delete deletes the key if it is still attached to its lease. If the delete
+// fails, the key is removed once the lease is revoked or expires.
+func (k *Key) delete() {
+	client := k.lease.client
+	// if delete takes longer than the ttl, lease is expired anyway
+	ctx, cancel := context.WithTimeout(client.Ctx(), time.Duration(k.lease.ttl)*time.Second)
+	defer cancel()
+	_, err := client.Txn(ctx).
+		If(v3.Compare(v3.LeaseValue(k.key), "=", k.id)).
+		Then(v3.OpDelete(k.key)).
+		Commit()
+	if err != nil {
+		client.GetLogger().Warn("failed to delete ephemeral key", zap.String("key", k.key), zap.Error(err))
+	}
+}
+
+type options struct {
+	ttl    int
+	onLost []func(key string, err error)
+}
+
+// Option configures Put.
+type Option func(*options, *zap.Logger)
+
+// WithTTL configures the TTL in seconds of the lease of the key, which is how
+// long the key outlives a process that dies without deleting it.
+// If TTL is <= 0, the default 10 seconds TTL will be used.
+func WithTTL(ttl int) Option {
+	return func(o *options, lg *zap.Logger) {
+		if ttl > 0 {
+			o.ttl = ttl
+		} else {
+			lg.Warn("WithTTL(): TTL should be > 0, preserving current TTL", zap.Int64("current-ttl", int64(o.ttl)))
+		}
+	}
+}
+
+// WithOnLost adds a callback called with the key and ErrLeaseLost when the
+// lease of the key is lost, so that the caller can put the key again.
+func WithOnLost(f func(key string, err error)) Option {
+	return func(o *options, _ *zap.Logger) {
+		o.onLost = append(o.onLost, f)
+	}
+}
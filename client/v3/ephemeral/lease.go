@@ -0,0 +1,213 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeral
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// renewJitter is the fraction of the renewal interval added or
+	// subtracted at random, so that the clients started together do not
+	// renew their leases together.
+	renewJitter = 0.2
+	// retryInterval is the interval of renewal retries after a failure.
+	retryInterval = 500 * time.Millisecond
+)
+
+type leaseKey struct {
+	client *v3.Client
+	ttl    int
+}
+
+var (
+	leasesMu sync.Mutex
+	// leases are the managed leases that keys can attach to.
+	leases = make(map[leaseKey]*managedLease)
+)
+
+// managedLease is a lease shared by the keys put with the same client and
+// TTL, kept alive while keys are attached to it.
+type managedLease struct {
+	client *v3.Client
+	ttl    int
+
+	// keys is the number of keys attached to the lease, guarded by leasesMu.
+	keys int
+
+	mu    sync.Mutex
+	id    v3.LeaseID
+	stopc chan struct{}
+	donec chan struct{}
+
+	// lostc is closed once the lease is lost, after err is set.
+	lostc chan struct{}
+	err   error
+}
+
+// attach attaches a key to the managed lease of the client and TTL, creating
+// the lease if there is none.
+func attach(client *v3.Client, ttl int) *managedLease {
+	leasesMu.Lock()
+	defer leasesMu.Unlock()
+	lk := leaseKey{client: client, ttl: ttl}
+	ml, ok := leases[lk]
+	if !ok {
+		ml = &managedLease{
+			client: client,
+			ttl:    ttl,
+			stopc:  make(chan struct{}),
+			donec:  make(chan struct{}),
+			lostc:  make(chan struct{}),
+		}
+		leases[lk] = ml
+	}
+	ml.keys++
+	return ml
+}
+
+// detach detaches a key from the lease, and closes the lease once no key is
+// attached to it.
+func (ml *managedLease) detach() {
+	leasesMu.Lock()
+	ml.keys--
+	last := ml.keys == 0
+	if last {
+		ml.unregister()
+	}
+	leasesMu.Unlock()
+	if last {
+		ml.close()
+	}
+}
+
+// unregister removes the lease from the managed leases, so that the keys put
+// afterwards use a new lease. leasesMu must be held.
+func (ml *managedLease) unregister() {
+	lk := leaseKey{client: ml.client, ttl: ml.ttl}
+	if leases[lk] == ml {
+		delete(leases, lk)
+	}
+}
+
+// grant grants the lease if it is not granted yet, and returns its ID.
+func (ml *managedLease) grant(ctx context.Context) (v3.LeaseID, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	if ml.id != v3.NoLease {
+		return ml.id, nil
+	}
+	resp, err := ml.client.Grant(ctx, int64(ml.ttl))
+	if err != nil {
+		return v3.NoLease, err
+	}
+	ml.id = resp.ID
+	go ml.keepAlive(resp.ID, time.Now().Add(time.Duration(resp.TTL)*time.Second))
+	return ml.id, nil
+}
+
+// keepAlive renews the lease every third of its TTL with jitter, until the
+// lease is closed or lost. Failed renewals are retried until the lease is
+// expected to have expired.
+func (ml *managedLease) keepAlive(id v3.LeaseID, deadline time.Time) {
+	defer close(ml.donec)
+	ttl := time.Duration(ml.ttl) * time.Second
+	interval := ttl / 3
+	for {
+		wait := jitterUp(interval, renewJitter)
+		if remaining := time.Until(deadline); wait > remaining {
+			wait = remaining
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ml.stopc:
+			timer.Stop()
+			return
+		case <-ml.client.Ctx().Done():
+			timer.Stop()
+			ml.lose(id, ml.client.Ctx().Err())
+			return
+		}
+
+		start := time.Now()
+		ctx, cancel := context.WithDeadline(ml.client.Ctx(), deadline)
+		resp, err := ml.client.KeepAliveOnce(ctx, id)
+		cancel()
+		switch {
+		case err == nil:
+			deadline = start.Add(time.Duration(resp.TTL) * time.Second)
+			interval = ttl / 3
+		case errors.Is(err, rpctypes.ErrLeaseNotFound) || !time.Now().Before(deadline):
+			ml.lose(id, err)
+			return
+		default:
+			ml.client.GetLogger().Warn("failed to renew ephemeral lease, retrying",
+				zap.Int64("lease-id", int64(id)), zap.Duration("remaining", time.Until(deadline)), zap.Error(err))
+			interval = retryInterval
+		}
+	}
+}
+
+// lose marks the lease as lost, notifying the keys attached to it.
+func (ml *managedLease) lose(id v3.LeaseID, err error) {
+	ml.client.GetLogger().Warn("lost ephemeral lease", zap.Int64("lease-id", int64(id)), zap.Error(err))
+	leasesMu.Lock()
+	ml.unregister()
+	leasesMu.Unlock()
+	ml.err = ErrLeaseLost
+	close(ml.lostc)
+}
+
+// close stops renewing the lease and revokes it, unless it was lost.
+func (ml *managedLease) close() {
+	ml.mu.Lock()
+	id := ml.id
+	ml.mu.Unlock()
+	if id == v3.NoLease {
+		return
+	}
+	close(ml.stopc)
+	<-ml.donec
+	select {
+	case <-ml.lostc:
+		return
+	default:
+	}
+	// if revoke takes longer than the ttl, lease is expired anyway
+	ctx, cancel := context.WithTimeout(ml.client.Ctx(), time.Duration(ml.ttl)*time.Second)
+	defer cancel()
+	if _, err := ml.client.Revoke(ctx, id); err != nil {
+		ml.client.GetLogger().Warn("failed to revoke ephemeral lease", zap.Int64("lease-id", int64(id)), zap.Error(err))
+	}
+}
+
+// jitterUp adds random jitter to the duration.
+//
+// This adds or subtracts time from the duration within a given jitter fraction.
+// For example for 10s and jitter 0.1, it will return a time within [9s, 11s])
+func jitterUp(duration time.Duration, jitter float64) time.Duration {
+	multiplier := jitter * (rand.Float64()*2 - 1)
+	return time.Duration(float64(duration) * (1 + multiplier))
+}
@@ -22,7 +22,9 @@ import (
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
@@ -63,10 +65,22 @@ type LeaseTimeToLiveResponse struct {
 	Keys [][]byte `json:"keys"`
 }
 
-// LeaseStatus represents a lease status.
+// LeaseStatus represents a lease status. The fields other than ID are only
+// set if the details are requested with WithLeaseDetails.
 type LeaseStatus struct {
 	ID LeaseID `json:"id"`
-	// TODO: TTL int64
+
+	// TTL is the remaining TTL in seconds for the lease.
+	TTL int64 `json:"ttl,omitempty"`
+
+	// GrantedTTL is the initial granted time in seconds upon lease creation/renewal.
+	GrantedTTL int64 `json:"granted-ttl,omitempty"`
+
+	// Keys is the number of keys attached to the lease.
+	Keys int64 `json:"keys,omitempty"`
+
+	// Labels are the labels the lease was granted with.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // LeaseLeasesResponse wraps the protobuf message LeaseLeasesResponse.
@@ -84,6 +98,14 @@ const (
 
 	// retryConnWait is how long to wait before retrying request due to an error
 	retryConnWait = 500 * time.Millisecond
+
+	// keepAliveStreamFailures is the number of consecutive keep alive streams
+	// interrupted before any response after which the leases are renewed with
+	// unary requests, for proxies which do not let long-lived streams through.
+	keepAliveStreamFailures = 3
+	// unaryKeepAliveDuration is how long the leases are renewed with unary
+	// requests before trying a keep alive stream again.
+	unaryKeepAliveDuration = time.Minute
 )
 
 // LeaseResponseChSize is the size of buffer to store unsent lease responses.
@@ -108,7 +130,7 @@ func (e ErrKeepAliveHalted) Error() string {
 
 type Lease interface {
 	// Grant creates a new lease.
-	Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error)
+	Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error)
 
 	// Revoke revokes the given lease.
 	Revoke(ctx context.Context, id LeaseID) (*LeaseRevokeResponse, error)
@@ -117,7 +139,7 @@ type Lease interface {
 	TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption) (*LeaseTimeToLiveResponse, error)
 
 	// Leases retrieves all leases.
-	Leases(ctx context.Context) (*LeaseLeasesResponse, error)
+	Leases(ctx context.Context, opts ...LeaseOption) (*LeaseLeasesResponse, error)
 
 	// KeepAlive attempts to keep the given lease alive forever. If the keepalive responses posted
 	// to the channel are not consumed promptly the channel may become full. When full, the lease
@@ -132,6 +154,11 @@ type Lease interface {
 	// alive stream is interrupted in some way the client cannot handle itself;
 	// given context "ctx" is canceled or timed out.
 	//
+	// If the keep alive streams are repeatedly interrupted before any response,
+	// as by proxies which do not let long-lived streams through, the client
+	// renews the leases with unary LeaseRenew requests for a while before
+	// trying a stream again.
+	//
 	// TODO(v4.0): post errors to last keep alive message before closing
 	// (see https://github.com/etcd-io/etcd/pull/7866)
 	KeepAlive(ctx context.Context, id LeaseID) (<-chan *LeaseKeepAliveResponse, error)
@@ -172,6 +199,10 @@ type lessor struct {
 	// firstKeepAliveOnce ensures stream starts after first KeepAlive call.
 	firstKeepAliveOnce sync.Once
 
+	// unaryUnsupported is set by recvKeepAliveLoop if the server does not
+	// support unary lease renewal.
+	unaryUnsupported bool
+
 	callOpts []grpc.CallOption
 
 	lg *zap.Logger
@@ -212,8 +243,8 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, c *Client, keepAliveTimeout
 	return l
 }
 
-func (l *lessor) Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error) {
-	r := &pb.LeaseGrantRequest{TTL: ttl}
+func (l *lessor) Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error) {
+	r := toLeaseGrantRequest(ttl, opts...)
 	resp, err := l.remote.LeaseGrant(ctx, r, l.callOpts...)
 	if err == nil {
 		gresp := &LeaseGrantResponse{
@@ -252,12 +283,18 @@ func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption
 	return gresp, nil
 }
 
-func (l *lessor) Leases(ctx context.Context) (*LeaseLeasesResponse, error) {
-	resp, err := l.remote.LeaseLeases(ctx, &pb.LeaseLeasesRequest{}, l.callOpts...)
+func (l *lessor) Leases(ctx context.Context, opts ...LeaseOption) (*LeaseLeasesResponse, error) {
+	resp, err := l.remote.LeaseLeases(ctx, toLeaseLeasesRequest(opts...), l.callOpts...)
 	if err == nil {
 		leases := make([]LeaseStatus, len(resp.Leases))
-		for i := range resp.Leases {
-			leases[i] = LeaseStatus{ID: LeaseID(resp.Leases[i].ID)}
+		for i, ls := range resp.Leases {
+			leases[i] = LeaseStatus{
+				ID:         LeaseID(ls.ID),
+				TTL:        ls.TTL,
+				GrantedTTL: ls.GrantedTTL,
+				Keys:       ls.Keys,
+				Labels:     fromLeaseLabels(ls.Labels),
+			}
 		}
 		return &LeaseLeasesResponse{ResponseHeader: resp.GetHeader(), Leases: leases}, nil
 	}
@@ -458,6 +495,7 @@ func (l *lessor) recvKeepAliveLoop() (gerr error) {
 		l.mu.Unlock()
 	}()
 
+	failures := 0
 	for {
 		stream, err := l.resetRecv()
 		if err != nil {
@@ -467,7 +505,9 @@ func (l *lessor) recvKeepAliveLoop() (gerr error) {
 			if canceledByCaller(l.stopCtx, err) {
 				return err
 			}
+			failures++
 		} else {
+			received := false
 			for {
 				resp, err := stream.Recv()
 				if err != nil {
@@ -481,8 +521,22 @@ func (l *lessor) recvKeepAliveLoop() (gerr error) {
 					break
 				}
 
+				received = true
 				l.recvKeepAlive(resp)
 			}
+			if received {
+				failures = 0
+			} else {
+				failures++
+			}
+		}
+
+		if failures >= keepAliveStreamFailures && !l.unaryUnsupported {
+			failures = 0
+			if err := l.renewKeepAliveLoop(unaryKeepAliveDuration); err != nil {
+				return err
+			}
+			continue
 		}
 
 		select {
@@ -582,21 +636,66 @@ func (l *lessor) deadlineLoop() {
 	}
 }
 
-// sendKeepAliveLoop sends keep alive requests for the lifetime of the given stream.
-func (l *lessor) sendKeepAliveLoop(stream pb.Lease_LeaseKeepAliveClient) {
-	for {
-		var tosend []LeaseID
-
-		now := time.Now()
-		l.mu.Lock()
-		for id, ka := range l.keepAlives {
-			if ka.nextKeepAlive.Before(now) {
-				tosend = append(tosend, id)
+// renewKeepAliveLoop renews the leases with unary LeaseRenew requests for the
+// given duration, or until it finds that the server does not support them. It
+// returns an error only if the lessor is closed.
+func (l *lessor) renewKeepAliveLoop(d time.Duration) error {
+	l.lg.Warn("lease keep alive streams are interrupted; renewing leases with unary requests",
+		zap.Duration("duration", d),
+	)
+	end := time.Now().Add(d)
+	for time.Now().Before(end) {
+		for _, id := range l.dueKeepAlives() {
+			ctx, cancel := context.WithTimeout(l.stopCtx, l.firstKeepAliveTimeout)
+			resp, err := l.remote.LeaseRenew(ctx, &pb.LeaseRenewRequest{ID: int64(id)}, l.callOpts...)
+			cancel()
+			if err != nil {
+				if canceledByCaller(l.stopCtx, err) {
+					return err
+				}
+				if status.Code(err) == codes.Unimplemented {
+					l.lg.Warn("server does not support unary lease renewal; renewing leases with keep alive streams only")
+					l.unaryUnsupported = true
+					return nil
+				}
+				if errors.Is(ContextError(l.stopCtx, err), rpctypes.ErrNoLeader) {
+					l.closeRequireLeader()
+				}
+				l.lg.Warn("error occurred during lease renewal",
+					zap.Error(err),
+				)
+				break
 			}
+			l.recvKeepAlive(&pb.LeaseKeepAliveResponse{Header: resp.Header, ID: resp.ID, TTL: resp.TTL})
 		}
-		l.mu.Unlock()
 
-		for _, id := range tosend {
+		select {
+		case <-time.After(retryConnWait):
+		case <-l.stopCtx.Done():
+			return l.stopCtx.Err()
+		}
+	}
+	return nil
+}
+
+// dueKeepAlives returns the leases whose next keep alive is due.
+func (l *lessor) dueKeepAlives() []LeaseID {
+	var due []LeaseID
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, ka := range l.keepAlives {
+		if ka.nextKeepAlive.Before(now) {
+			due = append(due, id)
+		}
+	}
+	return due
+}
+
+// sendKeepAliveLoop sends keep alive requests for the lifetime of the given stream.
+func (l *lessor) sendKeepAliveLoop(stream pb.Lease_LeaseKeepAliveClient) {
+	for {
+		for _, id := range l.dueKeepAlives() {
 			r := &pb.LeaseKeepAliveRequest{ID: int64(id)}
 			if err := stream.Send(r); err != nil {
 				l.lg.Warn("error occurred during lease keep alive request sending",
@@ -0,0 +1,83 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// streamlessLeaseClient fails all keep alive streams, as a proxy which does not
+// let long-lived streams through.
+type streamlessLeaseClient struct {
+	pb.LeaseClient
+	renewErr error
+	renews   atomic.Int64
+}
+
+func (c *streamlessLeaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (pb.Lease_LeaseKeepAliveClient, error) {
+	return nil, status.Error(codes.Unavailable, "stream reset by proxy")
+}
+
+func (c *streamlessLeaseClient) LeaseRenew(ctx context.Context, in *pb.LeaseRenewRequest, opts ...grpc.CallOption) (*pb.LeaseRenewResponse, error) {
+	c.renews.Add(1)
+	if c.renewErr != nil {
+		return nil, c.renewErr
+	}
+	return &pb.LeaseRenewResponse{Header: &pb.ResponseHeader{}, ID: in.ID, TTL: 30}, nil
+}
+
+func TestLeaseKeepAliveUnaryFallback(t *testing.T) {
+	remote := &streamlessLeaseClient{}
+	l := NewLeaseFromLeaseClient(remote, &Client{lg: zaptest.NewLogger(t)}, 5*time.Second)
+	defer l.Close()
+
+	ch, err := l.KeepAlive(context.Background(), 1)
+	require.NoError(t, err)
+	select {
+	case resp := <-ch:
+		require.NotNil(t, resp)
+		assert.Equal(t, LeaseID(1), resp.ID)
+		assert.Equal(t, int64(30), resp.TTL)
+	case <-time.After(5 * time.Second):
+		t.Fatal("lease was not renewed after the keep alive streams failed")
+	}
+	assert.Equal(t, int64(1), remote.renews.Load())
+}
+
+func TestLeaseKeepAliveUnaryUnsupported(t *testing.T) {
+	remote := &streamlessLeaseClient{renewErr: status.Error(codes.Unimplemented, "unknown method LeaseRenew")}
+	l := NewLeaseFromLeaseClient(remote, &Client{lg: zaptest.NewLogger(t)}, 10*time.Second)
+	defer l.Close()
+
+	_, err := l.KeepAlive(context.Background(), 1)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return remote.renews.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	// the lessor keeps retrying streams only, instead of falling back again
+	// after keepAliveStreamFailures more stream failures.
+	time.Sleep((keepAliveStreamFailures + 1) * retryConnWait)
+	assert.Equal(t, int64(1), remote.renews.Load())
+}
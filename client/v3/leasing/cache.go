@@ -164,6 +164,20 @@ func (lc *leaseCache) Delete(key string, hdr *v3pb.ResponseHeader) {
 	lc.delete(key, hdr)
 }
 
+func (lc *leaseCache) DeleteRange(key, end string, hdr *v3pb.ResponseHeader) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.deleteRange(key, end, hdr)
+}
+
+func (lc *leaseCache) deleteRange(key, end string, hdr *v3pb.ResponseHeader) {
+	for k := range lc.entries {
+		if inRange(k, key, end) {
+			lc.delete(k, hdr)
+		}
+	}
+}
+
 func (lc *leaseCache) delete(key string, hdr *v3pb.ResponseHeader) {
 	if li := lc.entries[key]; li != nil && hdr.Revision >= li.response.Header.Revision {
 		li.response.Kvs = nil
@@ -187,8 +201,8 @@ func (lc *leaseCache) EvictRange(key, end string) {
 	defer lc.mu.Unlock()
 	for k := range lc.entries {
 		if inRange(k, key, end) {
-			delete(lc.entries, key)
-			lc.revokes[key] = time.Now()
+			delete(lc.entries, k)
+			lc.revokes[k] = time.Now()
 		}
 	}
 }
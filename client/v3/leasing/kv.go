@@ -333,14 +333,12 @@ func (lkv *leasingKV) get(ctx context.Context, op v3.Op) (*v3.GetResponse, error
 	return getResp, nil
 }
 
-func (lkv *leasingKV) deleteRangeRPC(ctx context.Context, maxLeaseRev int64, key, end string) (*v3.DeleteResponse, error) {
+func (lkv *leasingKV) deleteRangeRPC(ctx context.Context, maxLeaseRev int64, op v3.Op) (*v3.DeleteResponse, error) {
+	key, end := string(op.KeyBytes()), string(op.RangeBytes())
 	lkey, lend := lkv.pfx+key, lkv.pfx+end
 	resp, err := lkv.kv.Txn(ctx).If(
 		v3.Compare(v3.CreateRevision(lkey).WithRange(lend), "<", maxLeaseRev+1),
-	).Then(
-		v3.OpGet(key, v3.WithRange(end), v3.WithKeysOnly()),
-		v3.OpDelete(key, v3.WithRange(end)),
-	).Commit()
+	).Then(op).Commit()
 	if err != nil {
 		lkv.leases.EvictRange(key, end)
 		return nil, err
@@ -348,10 +346,10 @@ func (lkv *leasingKV) deleteRangeRPC(ctx context.Context, maxLeaseRev int64, key
 	if !resp.Succeeded {
 		return nil, nil
 	}
-	for _, kv := range resp.Responses[0].GetResponseRange().Kvs {
-		lkv.leases.Delete(string(kv.Key), resp.Header)
-	}
-	delResp := (*v3.DeleteResponse)(resp.Responses[1].GetResponseDeleteRange())
+	// the whole range is invalidated, as a lazy delete does not tell the
+	// deleted keys.
+	lkv.leases.DeleteRange(key, end, resp.Header)
+	delResp := (*v3.DeleteResponse)(resp.Responses[0].GetResponseDeleteRange())
 	delResp.Header = resp.Header
 	return delResp, nil
 }
@@ -364,7 +362,7 @@ func (lkv *leasingKV) deleteRange(ctx context.Context, op v3.Op) (*v3.DeleteResp
 			return nil, err
 		}
 		wcs := lkv.leases.LockRange(key, end)
-		delResp, err := lkv.deleteRangeRPC(ctx, maxLeaseRev, key, end)
+		delResp, err := lkv.deleteRangeRPC(ctx, maxLeaseRev, op)
 		closeAll(wcs)
 		if err != nil || delResp != nil {
 			return delResp, err
@@ -162,12 +162,7 @@ func (txn *txnLeasing) commitToCache(txnResp *v3pb.TxnResponse, userTxn v3.Op) {
 	for _, op := range ops {
 		key := string(op.KeyBytes())
 		if op.IsDelete() && len(op.RangeBytes()) > 0 {
-			end := string(op.RangeBytes())
-			for k := range txn.lkv.leases.entries {
-				if inRange(k, key, end) {
-					txn.lkv.leases.delete(k, txnResp.Header)
-				}
-			}
+			txn.lkv.leases.deleteRange(key, string(op.RangeBytes()), txnResp.Header)
 		} else if op.IsDelete() {
 			txn.lkv.leases.delete(key, txnResp.Header)
 		}
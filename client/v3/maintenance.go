@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -27,13 +29,21 @@ import (
 )
 
 type (
-	DefragmentResponse pb.DefragmentResponse
-	AlarmResponse      pb.AlarmResponse
-	AlarmMember        pb.AlarmMember
-	StatusResponse     pb.StatusResponse
-	HashKVResponse     pb.HashKVResponse
-	MoveLeaderResponse pb.MoveLeaderResponse
-	DowngradeResponse  pb.DowngradeResponse
+	DefragmentResponse  pb.DefragmentResponse
+	AlarmResponse       pb.AlarmResponse
+	AlarmMember         pb.AlarmMember
+	StatusResponse      pb.StatusResponse
+	HashKVResponse      pb.HashKVResponse
+	MoveLeaderResponse  pb.MoveLeaderResponse
+	DowngradeResponse   pb.DowngradeResponse
+	DrainResponse       pb.DrainResponse
+	ConfigSetResponse   pb.ConfigSetResponse
+	LogLevelSetResponse pb.LogLevelSetResponse
+	ValuePolicyResponse pb.ValuePolicyResponse
+	ValuePolicy         pb.ValuePolicy
+	ScrubResponse       pb.ScrubResponse
+
+	FeatureGatesResponse pb.FeatureGatesResponse
 
 	DowngradeAction pb.DowngradeRequest_DowngradeAction
 )
@@ -51,6 +61,16 @@ type Maintenance interface {
 	// AlarmDisarm disarms a given alarm.
 	AlarmDisarm(ctx context.Context, m *AlarmMember) (*AlarmResponse, error)
 
+	// ValuePolicyList gets all value policies.
+	ValuePolicyList(ctx context.Context) (*ValuePolicyResponse, error)
+
+	// ValuePolicyPut sets the value policy of a key prefix, replacing the policy of the
+	// same prefix. Puts violating the policy of their keys fail.
+	ValuePolicyPut(ctx context.Context, p *ValuePolicy) (*ValuePolicyResponse, error)
+
+	// ValuePolicyDelete deletes the value policy of a key prefix.
+	ValuePolicyDelete(ctx context.Context, prefix string) (*ValuePolicyResponse, error)
+
 	// Defragment releases wasted space from internal fragmentation on a given etcd member.
 	// Defragment is only needed when deleting a large number of keys and want to reclaim
 	// the resources.
@@ -73,6 +93,14 @@ type Maintenance interface {
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
 	SnapshotWithVersion(ctx context.Context) (*SnapshotResponse, error)
 
+	// SnapshotAtRevision returns a reader for a snapshot of the given endpoint taken right
+	// after it applies the given revision, so the snapshots of all members at the same
+	// revision hold the same key-value store, leases, authentication and membership. The
+	// revision must not be applied by the endpoint yet; the endpoint waits until it applies
+	// the revision, or returns rpctypes.ErrSnapshotRevisionApplied if it already did.
+	// Supported since etcd 3.6.
+	SnapshotAtRevision(ctx context.Context, endpoint string, rev int64) (*SnapshotResponse, error)
+
 	// Snapshot provides a reader for a point-in-time snapshot of etcd.
 	// If the context "ctx" is canceled or timed out, reading from returned
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
@@ -87,6 +115,45 @@ type Maintenance interface {
 	// on the cluster version.
 	// Supported since etcd 3.5.
 	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
+
+	// Drain puts the given endpoint into maintenance mode before shutdown. The member stops
+	// accepting new client connections, transfers its leadership if it is the leader, and closes
+	// its watch and lease keepalive streams so that clients fail over to other members.
+	// Supported since etcd 3.6.
+	Drain(ctx context.Context, endpoint string) (*DrainResponse, error)
+
+	// ConfigSet changes the given runtime adjustable settings, keyed by the name of their server
+	// flag, of the given endpoint. The changes are not persisted across restarts. The response
+	// holds the current value of all runtime adjustable settings.
+	// Supported since etcd 3.6.
+	ConfigSet(ctx context.Context, endpoint string, settings map[string]string) (*ConfigSetResponse, error)
+
+	// LogLevelSet overrides the log level of the given subsystems, such as raft or mvcc, of the
+	// given endpoint. The levels revert to the server log level after ttl, unless ttl is zero;
+	// an empty level reverts a subsystem immediately. The response holds the current log level
+	// of all subsystems.
+	// Supported since etcd 3.6.
+	LogLevelSet(ctx context.Context, endpoint string, levels map[string]string, ttl time.Duration) (*LogLevelSetResponse, error)
+
+	// ScrubStart starts a scrub of the backend of the given endpoint, which checks the stored
+	// revisions reading at most rateBytes bytes per second, or the server default if rateBytes
+	// is zero. If checkPages is set, the scrub also checks the pages of the database file, at
+	// a rate which is not bounded. If a scrub is already running, it is left running.
+	// Supported since etcd 3.6.
+	ScrubStart(ctx context.Context, endpoint string, rateBytes int64, checkPages bool) (*ScrubResponse, error)
+
+	// ScrubStatus gets the status of the last scrub of the backend of the given endpoint.
+	// Supported since etcd 3.6.
+	ScrubStatus(ctx context.Context, endpoint string) (*ScrubResponse, error)
+
+	// ScrubCancel cancels the running scrub of the backend of the given endpoint.
+	// Supported since etcd 3.6.
+	ScrubCancel(ctx context.Context, endpoint string) (*ScrubResponse, error)
+
+	// FeatureGates lists the feature gates of the given endpoint, with their maturity
+	// level, whether they are enabled, and whether they are safe to toggle at runtime.
+	// Supported since etcd 3.6.
+	FeatureGates(ctx context.Context, endpoint string) (*FeatureGatesResponse, error)
 }
 
 // SnapshotResponse is aggregated response from the snapshot stream.
@@ -188,6 +255,30 @@ func (m *maintenance) AlarmDisarm(ctx context.Context, am *AlarmMember) (*AlarmR
 	return nil, ContextError(ctx, err)
 }
 
+func (m *maintenance) ValuePolicyList(ctx context.Context) (*ValuePolicyResponse, error) {
+	return m.valuePolicy(ctx, &pb.ValuePolicyRequest{Action: pb.ValuePolicyRequest_GET})
+}
+
+func (m *maintenance) ValuePolicyPut(ctx context.Context, p *ValuePolicy) (*ValuePolicyResponse, error) {
+	return m.valuePolicy(ctx, &pb.ValuePolicyRequest{Action: pb.ValuePolicyRequest_PUT, Policy: (*pb.ValuePolicy)(p)})
+}
+
+func (m *maintenance) ValuePolicyDelete(ctx context.Context, prefix string) (*ValuePolicyResponse, error) {
+	req := &pb.ValuePolicyRequest{
+		Action: pb.ValuePolicyRequest_DELETE,
+		Policy: &pb.ValuePolicy{Prefix: []byte(prefix)},
+	}
+	return m.valuePolicy(ctx, req)
+}
+
+func (m *maintenance) valuePolicy(ctx context.Context, req *pb.ValuePolicyRequest) (*ValuePolicyResponse, error) {
+	resp, err := m.remote.ValuePolicy(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ValuePolicyResponse)(resp), nil
+}
+
 func (m *maintenance) Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error) {
 	remote, cancel, err := m.dial(endpoint)
 	if err != nil {
@@ -214,6 +305,93 @@ func (m *maintenance) Status(ctx context.Context, endpoint string) (*StatusRespo
 	return (*StatusResponse)(resp), nil
 }
 
+func (m *maintenance) Drain(ctx context.Context, endpoint string) (*DrainResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Drain(ctx, &pb.DrainRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*DrainResponse)(resp), nil
+}
+
+func (m *maintenance) ConfigSet(ctx context.Context, endpoint string, settings map[string]string) (*ConfigSetResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	req := &pb.ConfigSetRequest{}
+	for name, value := range settings {
+		req.Settings = append(req.Settings, &pb.ConfigSetting{Name: name, Value: value})
+	}
+	sort.Slice(req.Settings, func(i, j int) bool { return req.Settings[i].Name < req.Settings[j].Name })
+	resp, err := remote.ConfigSet(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ConfigSetResponse)(resp), nil
+}
+
+func (m *maintenance) LogLevelSet(ctx context.Context, endpoint string, levels map[string]string, ttl time.Duration) (*LogLevelSetResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	req := &pb.LogLevelSetRequest{}
+	for subsystem, level := range levels {
+		req.Settings = append(req.Settings, &pb.LogLevelSetting{Subsystem: subsystem, Level: level, Ttl: int64(ttl / time.Second)})
+	}
+	sort.Slice(req.Settings, func(i, j int) bool { return req.Settings[i].Subsystem < req.Settings[j].Subsystem })
+	resp, err := remote.LogLevelSet(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*LogLevelSetResponse)(resp), nil
+}
+
+func (m *maintenance) ScrubStart(ctx context.Context, endpoint string, rateBytes int64, checkPages bool) (*ScrubResponse, error) {
+	return m.scrub(ctx, endpoint, &pb.ScrubRequest{Action: pb.ScrubRequest_START, RateBytes: rateBytes, CheckPages: checkPages})
+}
+
+func (m *maintenance) ScrubStatus(ctx context.Context, endpoint string) (*ScrubResponse, error) {
+	return m.scrub(ctx, endpoint, &pb.ScrubRequest{Action: pb.ScrubRequest_STATUS})
+}
+
+func (m *maintenance) ScrubCancel(ctx context.Context, endpoint string) (*ScrubResponse, error) {
+	return m.scrub(ctx, endpoint, &pb.ScrubRequest{Action: pb.ScrubRequest_CANCEL})
+}
+
+func (m *maintenance) scrub(ctx context.Context, endpoint string, req *pb.ScrubRequest) (*ScrubResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Scrub(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ScrubResponse)(resp), nil
+}
+
+func (m *maintenance) FeatureGates(ctx context.Context, endpoint string) (*FeatureGatesResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.FeatureGates(ctx, &pb.FeatureGatesRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*FeatureGatesResponse)(resp), nil
+}
+
 func (m *maintenance) HashKV(ctx context.Context, endpoint string, rev int64) (*HashKVResponse, error) {
 	remote, cancel, err := m.dial(endpoint)
 	if err != nil {
@@ -228,8 +406,36 @@ func (m *maintenance) HashKV(ctx context.Context, endpoint string, rev int64) (*
 }
 
 func (m *maintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotResponse, error) {
-	ss, err := m.remote.Snapshot(ctx, &pb.SnapshotRequest{}, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
+	return m.snapshotWithVersion(ctx, m.remote, &pb.SnapshotRequest{}, func() {})
+}
+
+func (m *maintenance) SnapshotAtRevision(ctx context.Context, endpoint string, rev int64) (*SnapshotResponse, error) {
+	if rev <= 0 {
+		return nil, errors.New("etcdclient: snapshot revision must be positive")
+	}
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	resp, err := m.snapshotWithVersion(ctx, remote, &pb.SnapshotRequest{Revision: rev}, cancel)
+	if err != nil {
+		return nil, err
+	}
+	// servers that do not support snapshots at a revision send a snapshot of
+	// their current state without a header.
+	if resp.Header == nil || resp.Header.Revision != rev {
+		resp.Snapshot.Close()
+		return nil, errors.New("etcdclient: server does not support snapshots at a revision")
+	}
+	return resp, nil
+}
+
+// snapshotWithVersion opens a snapshot stream on remote, calling done once the
+// stream is finished.
+func (m *maintenance) snapshotWithVersion(ctx context.Context, remote pb.MaintenanceClient, req *pb.SnapshotRequest, done func()) (*SnapshotResponse, error) {
+	ss, err := remote.Snapshot(ctx, req, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
 	if err != nil {
+		done()
 		return nil, ContextError(ctx, err)
 	}
 
@@ -239,9 +445,11 @@ func (m *maintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotRespons
 	resp, err := ss.Recv()
 	if err != nil {
 		m.logAndCloseWithError(err, pw)
+		done()
 		return nil, err
 	}
 	go func() {
+		defer done()
 		// Saving response is blocking
 		err := m.save(resp, pw)
 		if err != nil {
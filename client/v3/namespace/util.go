@@ -14,6 +14,8 @@
 
 package namespace
 
+import "bytes"
+
 func prefixInterval(pfx string, key, end []byte) (pfxKey []byte, pfxEnd []byte) {
 	pfxKey = make([]byte, len(pfx)+len(key))
 	copy(pfxKey[copy(pfxKey, pfx):], key)
@@ -40,3 +42,21 @@ func prefixInterval(pfx string, key, end []byte) (pfxKey []byte, pfxEnd []byte)
 
 	return pfxKey, pfxEnd
 }
+
+// unprefixInterval translates the interval [key, end) of the prefixed keyspace
+// into the unprefixed interval of its keys under pfx, clamping it to the keys
+// under pfx. An end of "\x00" stands for the edge of the keyspace. The interval
+// must overlap the keys under pfx.
+func unprefixInterval(pfx string, key, end []byte) (unpfxKey []byte, unpfxEnd []byte) {
+	_, pfxEnd := prefixInterval(pfx, nil, []byte{0})
+	if bytes.Compare(key, []byte(pfx)) < 0 {
+		key = []byte(pfx)
+	}
+	unpfxKey = key[len(pfx):]
+
+	atEdge := func(end []byte) bool { return len(end) == 1 && end[0] == 0 }
+	if atEdge(end) || (!atEdge(pfxEnd) && bytes.Compare(end, pfxEnd) >= 0) {
+		return unpfxKey, []byte{0}
+	}
+	return unpfxKey, end[len(pfx):]
+}
@@ -73,3 +73,69 @@ func TestPrefixInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestUnprefixInterval(t *testing.T) {
+	tests := []struct {
+		pfx string
+		key []byte
+		end []byte
+
+		wKey []byte
+		wEnd []byte
+	}{
+		// range under the prefix
+		{
+			pfx: "pfx/",
+			key: []byte("pfx/abc"),
+			end: []byte("pfx/def"),
+
+			wKey: []byte("abc"),
+			wEnd: []byte("def"),
+		},
+		// range starting before the prefix
+		{
+			pfx: "pfx/",
+			key: []byte("a"),
+			end: []byte("pfx/def"),
+
+			wKey: []byte(""),
+			wEnd: []byte("def"),
+		},
+		// range ending after the prefix
+		{
+			pfx: "pfx/",
+			key: []byte("pfx/abc"),
+			end: []byte("q"),
+
+			wKey: []byte("abc"),
+			wEnd: []byte{0},
+		},
+		// one-sided range
+		{
+			pfx: "pfx/",
+			key: []byte("a"),
+			end: []byte{0},
+
+			wKey: []byte(""),
+			wEnd: []byte{0},
+		},
+		// range ending at the end of keyspace
+		{
+			pfx: "\xff\xff",
+			key: []byte("\xff\xffabc"),
+			end: []byte("\xff\xffdef"),
+
+			wKey: []byte("abc"),
+			wEnd: []byte("def"),
+		},
+	}
+	for i, tt := range tests {
+		unpfxKey, unpfxEnd := unprefixInterval(tt.pfx, tt.key, tt.end)
+		if !bytes.Equal(unpfxKey, tt.wKey) {
+			t.Errorf("#%d: expected key=%q, got key=%q", i, tt.wKey, unpfxKey)
+		}
+		if !bytes.Equal(unpfxEnd, tt.wEnd) {
+			t.Errorf("#%d: expected end=%q, got end=%q", i, tt.wEnd, unpfxEnd)
+		}
+	}
+}
@@ -58,6 +58,11 @@ func (w *watcherPrefix) Watch(ctx context.Context, key string, opts ...clientv3.
 		}()
 		for wr := range wch {
 			for i := range wr.Events {
+				if wr.Events[i].IsRangeDelete() {
+					// the range of a lazy delete may extend beyond the prefix.
+					wr.Events[i].Kv.Key, wr.Events[i].RangeEnd = unprefixInterval(w.pfx, wr.Events[i].Kv.Key, wr.Events[i].RangeEnd)
+					continue
+				}
 				wr.Events[i].Kv.Key = wr.Events[i].Kv.Key[len(w.pfx):]
 				if wr.Events[i].PrevKv != nil {
 					wr.Events[i].PrevKv.Key = wr.Events[i].Kv.Key
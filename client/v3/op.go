@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+import (
+	"sort"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
 
 type opType int
 
@@ -56,11 +60,17 @@ type Op struct {
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// for watch, limits of the events of each watch response
+	maxEvents int64
+	maxBytes  int64
 
 	// for put
 	ignoreValue bool
 	ignoreLease bool
 
+	// for delete
+	lazy bool
+
 	// progressNotify is for progress updates.
 	progressNotify bool
 	// createdNotify is for created event
@@ -200,7 +210,7 @@ func (op Op) toRequestOp() *pb.RequestOp {
 		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: r}}
 	case tDeleteRange:
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, Lazy: op.lazy}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: r}}
 	case tTxn:
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: op.toTxnRequest()}}
@@ -270,6 +280,8 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected filter in delete")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in delete")
+	case ret.lazy && ret.prevKV:
+		panic("unexpected prevKV in lazy delete")
 	}
 	return ret
 }
@@ -312,8 +324,6 @@ func opWatch(key string, opts ...OpOption) Op {
 	ret := Op{t: tRange, key: []byte(key)}
 	ret.applyOpts(opts)
 	switch {
-	case ret.leaseID != 0:
-		panic("unexpected lease in watch")
 	case ret.limit != 0:
 		panic("unexpected limit in watch")
 	case ret.sort != nil:
@@ -340,6 +350,9 @@ func (op *Op) applyOpts(opts []OpOption) {
 type OpOption func(*Op)
 
 // WithLease attaches a lease ID to a key in 'Put' request.
+// In 'Watch' request, it restricts the watch to the keys attached to the
+// lease, e.g. Watch(ctx, "", WithPrefix(), WithLease(id)) watches all the keys
+// of the lease, including their deletion when the lease expires.
 func WithLease(leaseID LeaseID) OpOption {
 	return func(op *Op) { op.leaseID = leaseID }
 }
@@ -518,6 +531,17 @@ func WithPrevKV() OpOption {
 	}
 }
 
+// WithLazyDelete deletes a range lazily: etcd marks the whole range deleted
+// at once instead of deleting every key, and the keys are physically removed
+// by the compactions. Watchers get a single DELETE event for the range, with
+// the range end in the RangeEnd of the event. This option can not be combined
+// with WithPrevKV, and has no effect on the delete of a single key. The
+// server rejects lazy deletes with rpctypes.ErrLazyDeleteDisabled unless its
+// LazyDeleteRange feature gate is enabled.
+func WithLazyDelete() OpOption {
+	return func(op *Op) { op.lazy = true }
+}
+
 // WithFragment to receive raw watch response with fragmentation.
 // Fragmentation is disabled by default. If fragmentation is enabled,
 // etcd watch server will split watch response before sending to clients
@@ -529,6 +553,23 @@ func WithFragment() OpOption {
 	return func(op *Op) { op.fragment = true }
 }
 
+// WithMaxEvents limits the number of events in each watch response to n.
+// Events of a revision split across responses are merged back by the client,
+// so a watch channel still receives whole revisions.
+// Supported since etcd 3.6.
+func WithMaxEvents(n int64) OpOption {
+	return func(op *Op) { op.maxEvents = n }
+}
+
+// WithMaxBytes limits the size of the events in each watch response to n
+// bytes. A single event larger than n is sent on its own. Events of a
+// revision split across responses are merged back by the client, so a watch
+// channel still receives whole revisions.
+// Supported since etcd 3.6.
+func WithMaxBytes(n int64) OpOption {
+	return func(op *Op) { op.maxBytes = n }
+}
+
 // WithIgnoreValue updates the key using its current value.
 // This option can not be combined with non-empty values.
 // Returns an error if the key does not exist.
@@ -553,6 +594,12 @@ type LeaseOp struct {
 
 	// for TimeToLive
 	attachedKeys bool
+
+	// for Grant
+	labels map[string]string
+
+	// for Leases
+	details bool
 }
 
 // LeaseOption configures lease operations.
@@ -569,12 +616,60 @@ func WithAttachedKeys() LeaseOption {
 	return func(op *LeaseOp) { op.attachedKeys = true }
 }
 
+// WithLeaseLabels makes Grant label the lease with the given labels, e.g. the
+// host and the process owning the lease. Keys must not be empty.
+func WithLeaseLabels(labels map[string]string) LeaseOption {
+	return func(op *LeaseOp) { op.labels = labels }
+}
+
+// WithLeaseDetails makes Leases return the remaining TTL, the granted TTL,
+// the number of attached keys and the labels of the leases.
+func WithLeaseDetails() LeaseOption {
+	return func(op *LeaseOp) { op.details = true }
+}
+
 func toLeaseTimeToLiveRequest(id LeaseID, opts ...LeaseOption) *pb.LeaseTimeToLiveRequest {
 	ret := &LeaseOp{id: id}
 	ret.applyOpts(opts)
 	return &pb.LeaseTimeToLiveRequest{ID: int64(id), Keys: ret.attachedKeys}
 }
 
+func toLeaseGrantRequest(ttl int64, opts ...LeaseOption) *pb.LeaseGrantRequest {
+	ret := &LeaseOp{}
+	ret.applyOpts(opts)
+	return &pb.LeaseGrantRequest{TTL: ttl, Labels: toLeaseLabels(ret.labels)}
+}
+
+func toLeaseLeasesRequest(opts ...LeaseOption) *pb.LeaseLeasesRequest {
+	ret := &LeaseOp{}
+	ret.applyOpts(opts)
+	return &pb.LeaseLeasesRequest{Details: ret.details}
+}
+
+// toLeaseLabels converts the labels to protobuf, sorted by key.
+func toLeaseLabels(labels map[string]string) []*pb.LeaseLabel {
+	if len(labels) == 0 {
+		return nil
+	}
+	ret := make([]*pb.LeaseLabel, 0, len(labels))
+	for k, v := range labels {
+		ret = append(ret, &pb.LeaseLabel{Key: k, Value: v})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
+
+func fromLeaseLabels(labels []*pb.LeaseLabel) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	ret := make(map[string]string, len(labels))
+	for _, l := range labels {
+		ret[l.Key] = l.Value
+	}
+	return ret
+}
+
 // IsOptsWithPrefix returns true if WithPrefix option is called in the given opts.
 func IsOptsWithPrefix(opts []OpOption) bool {
 	ret := NewOp()
@@ -155,6 +155,10 @@ func (rlc *retryLeaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.Ca
 	return rlc.lc.LeaseKeepAlive(ctx, append(opts, withRepeatablePolicy())...)
 }
 
+func (rlc *retryLeaseClient) LeaseRenew(ctx context.Context, in *pb.LeaseRenewRequest, opts ...grpc.CallOption) (resp *pb.LeaseRenewResponse, err error) {
+	return rlc.lc.LeaseRenew(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryClusterClient struct {
 	cc pb.ClusterClient
 }
@@ -186,6 +190,18 @@ func (rcc *retryClusterClient) MemberPromote(ctx context.Context, in *pb.MemberP
 	return rcc.cc.MemberPromote(ctx, in, opts...)
 }
 
+func (rcc *retryClusterClient) ClusterMetadataPut(ctx context.Context, in *pb.ClusterMetadataPutRequest, opts ...grpc.CallOption) (resp *pb.ClusterMetadataPutResponse, err error) {
+	return rcc.cc.ClusterMetadataPut(ctx, in, opts...)
+}
+
+func (rcc *retryClusterClient) ClusterMetadataDelete(ctx context.Context, in *pb.ClusterMetadataDeleteRequest, opts ...grpc.CallOption) (resp *pb.ClusterMetadataDeleteResponse, err error) {
+	return rcc.cc.ClusterMetadataDelete(ctx, in, opts...)
+}
+
+func (rcc *retryClusterClient) ClusterMetadataList(ctx context.Context, in *pb.ClusterMetadataListRequest, opts ...grpc.CallOption) (resp *pb.ClusterMetadataListResponse, err error) {
+	return rcc.cc.ClusterMetadataList(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryMaintenanceClient struct {
 	mc pb.MaintenanceClient
 }
@@ -201,6 +217,10 @@ func (rmc *retryMaintenanceClient) Alarm(ctx context.Context, in *pb.AlarmReques
 	return rmc.mc.Alarm(ctx, in, append(opts, withRepeatablePolicy())...)
 }
 
+func (rmc *retryMaintenanceClient) ValuePolicy(ctx context.Context, in *pb.ValuePolicyRequest, opts ...grpc.CallOption) (resp *pb.ValuePolicyResponse, err error) {
+	return rmc.mc.ValuePolicy(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 func (rmc *retryMaintenanceClient) Status(ctx context.Context, in *pb.StatusRequest, opts ...grpc.CallOption) (resp *pb.StatusResponse, err error) {
 	return rmc.mc.Status(ctx, in, append(opts, withRepeatablePolicy())...)
 }
@@ -225,10 +245,30 @@ func (rmc *retryMaintenanceClient) Defragment(ctx context.Context, in *pb.Defrag
 	return rmc.mc.Defragment(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) Scrub(ctx context.Context, in *pb.ScrubRequest, opts ...grpc.CallOption) (resp *pb.ScrubResponse, err error) {
+	return rmc.mc.Scrub(ctx, in, opts...)
+}
+
 func (rmc *retryMaintenanceClient) Downgrade(ctx context.Context, in *pb.DowngradeRequest, opts ...grpc.CallOption) (resp *pb.DowngradeResponse, err error) {
 	return rmc.mc.Downgrade(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) Drain(ctx context.Context, in *pb.DrainRequest, opts ...grpc.CallOption) (resp *pb.DrainResponse, err error) {
+	return rmc.mc.Drain(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) ConfigSet(ctx context.Context, in *pb.ConfigSetRequest, opts ...grpc.CallOption) (resp *pb.ConfigSetResponse, err error) {
+	return rmc.mc.ConfigSet(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) LogLevelSet(ctx context.Context, in *pb.LogLevelSetRequest, opts ...grpc.CallOption) (resp *pb.LogLevelSetResponse, err error) {
+	return rmc.mc.LogLevelSet(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) FeatureGates(ctx context.Context, in *pb.FeatureGatesRequest, opts ...grpc.CallOption) (resp *pb.FeatureGatesResponse, err error) {
+	return rmc.mc.FeatureGates(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryAuthClient struct {
 	ac pb.AuthClient
 }
@@ -0,0 +1,221 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// MarkerKey is the key SaveCluster writes to bring the cluster to the revision
+// the snapshots are taken at. The user saving the snapshots must be permitted
+// to write it when authentication is enabled.
+const MarkerKey = "__etcd_snapshot_marker"
+
+// ClusterSnapshot is the result of saving the snapshots of all members of a
+// cluster at one revision.
+type ClusterSnapshot struct {
+	// Revision is the revision of the key-value store all snapshots hold.
+	Revision int64
+	// Members holds the snapshot of each member, in the order of the member list.
+	Members []MemberSnapshot
+}
+
+// MemberSnapshot is the snapshot of one member of a cluster.
+type MemberSnapshot struct {
+	MemberID uint64
+	Name     string
+	// Endpoint is the client URL the snapshot was requested from.
+	Endpoint string
+	// Path is the file the snapshot was saved to.
+	Path string
+	// Size is the number of bytes saved, including the sha256 checksum.
+	Size int64
+	// Version is the local version of the server that created the snapshot.
+	Version string
+	// Err is the error that failed the snapshot of the member, if any.
+	Err error
+}
+
+// SaveCluster saves a snapshot of every member of the cluster taken at one
+// revision into dir. Each member takes its snapshot right after applying the
+// revision, which SaveCluster brings about by writing MarkerKey. The snapshot
+// of a member is saved as "<member ID>.db". Unlike snapshots saved one member
+// at a time, these snapshots are consistent with each other. SaveCluster
+// returns the snapshot of each member and, if any of them failed, an error
+// joining the errors of the members.
+// Supported since etcd 3.6.
+func SaveCluster(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, dir string) (*ClusterSnapshot, error) {
+	cfg.Logger = lg.Named("client")
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = cli.Close()
+		if err != nil {
+			lg.Error("Failed to close client", zap.Error(err))
+		}
+	}()
+
+	if err = fileutil.TouchDirAll(lg, dir); err != nil {
+		return nil, fmt.Errorf("could not create %s (%w)", dir, err)
+	}
+	members, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cs := &ClusterSnapshot{Members: make([]MemberSnapshot, len(members.Members))}
+	for i, m := range members.Members {
+		ms := &cs.Members[i]
+		ms.MemberID = m.ID
+		ms.Name = m.Name
+		ms.Path = filepath.Join(dir, fmt.Sprintf("%x.db", m.ID))
+		if len(m.ClientURLs) == 0 {
+			ms.Err = errors.New("member has not published its client URLs")
+			continue
+		}
+		ms.Endpoint = m.ClientURLs[0]
+	}
+
+	// the current revision of the cluster, read linearizably, is applied by
+	// some members already, so the snapshots are taken at a later one. A
+	// member applying it before the snapshot is requested fails the attempt,
+	// which is retried further ahead of the current revision, leaving the
+	// requests more writes to reach the members.
+	var resps []*clientv3.SnapshotResponse
+	var cancel context.CancelFunc
+	for ahead := int64(1); ; ahead *= 2 {
+		getResp, err := cli.Get(ctx, "\x00", clientv3.WithCountOnly())
+		if err != nil {
+			return nil, err
+		}
+		cs.Revision = getResp.Header.Revision + ahead
+		lg.Info("saving snapshots of cluster members", zap.Int64("revision", cs.Revision), zap.Int("members", len(members.Members)))
+
+		var actx context.Context
+		actx, cancel = context.WithCancel(ctx)
+		var applied bool
+		resps, applied, err = openCluster(actx, cancel, cli, cs)
+		if err != nil {
+			cancel()
+			closeAll(resps)
+			return nil, err
+		}
+		if !applied {
+			break
+		}
+		cancel()
+		closeAll(resps)
+		lg.Info("revision applied before the snapshots were requested; retrying", zap.Int64("revision", cs.Revision))
+	}
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range cs.Members {
+		ms := &cs.Members[i]
+		if resps[i] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, size, err := save(lg, ms.Endpoint, ms.Path, func() (*clientv3.SnapshotResponse, error) {
+				return resps[i], nil
+			})
+			ms.Size, ms.Err = size, err
+			if resp != nil {
+				ms.Version = resp.Version
+			}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, ms := range cs.Members {
+		if ms.Err != nil {
+			errs = append(errs, fmt.Errorf("member %x: %w", ms.MemberID, ms.Err))
+		}
+	}
+	return cs, errors.Join(errs...)
+}
+
+// openCluster requests the snapshots of the members at the revision of cs,
+// recording the errors in cs, and writes MarkerKey until the cluster reaches
+// the revision. It returns whether a member had applied the revision already,
+// calling cancel to cancel the other requests, and the error of the writes.
+func openCluster(ctx context.Context, cancel context.CancelFunc, cli *clientv3.Client, cs *ClusterSnapshot) ([]*clientv3.SnapshotResponse, bool, error) {
+	resps := make([]*clientv3.SnapshotResponse, len(cs.Members))
+	errs := make([]error, len(cs.Members))
+	var wg sync.WaitGroup
+	for i := range cs.Members {
+		if cs.Members[i].Endpoint == "" {
+			continue
+		}
+		cs.Members[i].Err = nil
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resps[i], errs[i] = cli.SnapshotAtRevision(ctx, cs.Members[i].Endpoint, cs.Revision)
+			if errors.Is(rpctypes.Error(errs[i]), rpctypes.ErrSnapshotRevisionApplied) {
+				cancel()
+			}
+		}()
+	}
+
+	var perr error
+	for {
+		resp, err := cli.Put(ctx, MarkerKey, "")
+		if err != nil {
+			if ctx.Err() == nil {
+				perr = err
+				cancel()
+			}
+			break
+		}
+		if resp.Header.Revision >= cs.Revision {
+			break
+		}
+	}
+	wg.Wait()
+
+	var applied bool
+	for i := range errs {
+		if errors.Is(rpctypes.Error(errs[i]), rpctypes.ErrSnapshotRevisionApplied) {
+			applied = true
+		}
+		if errs[i] != nil {
+			cs.Members[i].Err = errs[i]
+		}
+	}
+	return resps, applied, perr
+}
+
+func closeAll(resps []*clientv3.SnapshotResponse) {
+	for i := range resps {
+		if resps[i] != nil {
+			resps[i].Snapshot.Close()
+		}
+	}
+}
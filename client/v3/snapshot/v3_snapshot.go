@@ -62,9 +62,21 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 		}
 	}()
 
+	resp, _, err := save(lg, cfg.Endpoints[0], dbPath, func() (*clientv3.SnapshotResponse, error) {
+		return cli.SnapshotWithVersion(ctx)
+	})
+	if resp == nil {
+		return "", err
+	}
+	return resp.Version, err
+}
+
+// save saves the snapshot stream opened by open to dbPath, and returns the
+// snapshot response and the number of bytes saved.
+func save(lg *zap.Logger, endpoint, dbPath string, open func() (*clientv3.SnapshotResponse, error)) (*clientv3.SnapshotResponse, int64, error) {
 	partpath := dbPath + ".part"
 	defer func() {
-		err = os.RemoveAll(partpath)
+		err := os.RemoveAll(partpath)
 		if err != nil {
 			lg.Error("Failed to cleanup .part file", zap.Error(err))
 		}
@@ -72,7 +84,7 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 
 	f, err := os.OpenFile(partpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
 	if err != nil {
-		return "", fmt.Errorf("could not open %s (%w)", partpath, err)
+		return nil, 0, fmt.Errorf("could not open %s (%w)", partpath, err)
 	}
 	defer func() {
 		err = f.Close()
@@ -83,9 +95,9 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 	lg.Info("created temporary db file", zap.String("path", partpath))
 
 	start := time.Now()
-	resp, err := cli.SnapshotWithVersion(ctx)
+	resp, err := open()
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 	defer func() {
 		err = resp.Snapshot.Close()
@@ -93,31 +105,31 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 			lg.Error("Could not close snapshot stream", zap.Error(err))
 		}
 	}()
-	lg.Info("fetching snapshot", zap.String("endpoint", cfg.Endpoints[0]))
+	lg.Info("fetching snapshot", zap.String("endpoint", endpoint))
 	var size int64
 	size, err = io.Copy(f, resp.Snapshot)
 	if err != nil {
-		return resp.Version, fmt.Errorf("could not write snapshot: %w", err)
+		return resp, size, fmt.Errorf("could not write snapshot: %w", err)
 	}
 	if !hasChecksum(size) {
-		return resp.Version, fmt.Errorf("sha256 checksum not found [bytes: %d]", size)
+		return resp, size, fmt.Errorf("sha256 checksum not found [bytes: %d]", size)
 	}
 	if err = fileutil.Fsync(f); err != nil {
-		return resp.Version, fmt.Errorf("could not fsync snapshot: %w", err)
+		return resp, size, fmt.Errorf("could not fsync snapshot: %w", err)
 	}
 	if err = f.Close(); err != nil {
-		return resp.Version, fmt.Errorf("could not close file descriptor: %w", err)
+		return resp, size, fmt.Errorf("could not close file descriptor: %w", err)
 	}
 	lg.Info("fetched snapshot",
-		zap.String("endpoint", cfg.Endpoints[0]),
+		zap.String("endpoint", endpoint),
 		zap.String("size", humanize.Bytes(uint64(size))),
 		zap.Duration("took", time.Since(start)),
 		zap.String("etcd-version", resp.Version),
 	)
 
 	if err = os.Rename(partpath, dbPath); err != nil {
-		return resp.Version, fmt.Errorf("could not rename %s to %s (%w)", partpath, dbPath, err)
+		return resp, size, fmt.Errorf("could not rename %s to %s (%w)", partpath, dbPath, err)
 	}
 	lg.Info("saved", zap.String("path", dbPath))
-	return resp.Version, nil
+	return resp, size, nil
 }
@@ -119,6 +119,12 @@ func (e *Event) IsModify() bool {
 	return e.Type == EventTypePut && e.Kv.CreateRevision != e.Kv.ModRevision
 }
 
+// IsRangeDelete returns true if the event tells that all keys in the range
+// [Kv.Key, RangeEnd) were deleted at once by a lazy delete.
+func (e *Event) IsRangeDelete() bool {
+	return e.Type == EventTypeDelete && e.RangeEnd != nil
+}
+
 // Err is the error value if this WatchResponse holds an error.
 func (wr *WatchResponse) Err() error {
 	switch {
@@ -211,11 +217,16 @@ type watchRequest struct {
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// maxEvents and maxBytes limit the events of each watch response
+	maxEvents int64
+	maxBytes  int64
 
 	// filters is the list of events to filter out
 	filters []pb.WatchCreateRequest_FilterType
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// lease restricts the watch to the keys attached to the lease
+	lease LeaseID
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
@@ -314,8 +325,11 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		rev:            ow.rev,
 		progressNotify: ow.progressNotify,
 		fragment:       ow.fragment,
+		maxEvents:      ow.maxEvents,
+		maxBytes:       ow.maxBytes,
 		filters:        filters,
 		prevKV:         ow.prevKV,
+		lease:          ow.leaseID,
 		retc:           make(chan chan WatchResponse, 1),
 	}
 
@@ -607,7 +621,9 @@ func (w *watchGRPCStream) run() {
 				// reset for next iteration
 				cur = nil
 
-			case pbresp.Canceled && pbresp.CompactRevision == 0:
+			// a watcher canceled by the server with a reason is dispatched
+			// like a compacted one, so the reason reaches the watch channel
+			case pbresp.Canceled && pbresp.CompactRevision == 0 && pbresp.CancelReason == "":
 				delete(cancelSet, pbresp.WatchId)
 				if ws, ok := w.substreams[pbresp.WatchId]; ok {
 					// signal to stream goroutine to update closingc
@@ -1023,6 +1039,9 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 		Filters:        wr.filters,
 		PrevKv:         wr.prevKV,
 		Fragment:       wr.fragment,
+		Lease:          int64(wr.lease),
+		MaxEvents:      wr.maxEvents,
+		MaxBytes:       wr.maxBytes,
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
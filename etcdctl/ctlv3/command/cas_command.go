@@ -0,0 +1,100 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	casExpectValue  string
+	casExpectModRev int64
+	casNewValue     string
+	casLease        string
+)
+
+// NewCASCommand returns the cobra command for "cas".
+func NewCASCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cas <key> (--expect-value <value> | --expect-mod-rev <revision>) --new-value <value>",
+		Short: "Puts a new value into a key if it holds the expected value or revision",
+		Long: `Compares the key with the expected value or modification revision, and puts the new
+value into the key if they match, in a single transaction.
+
+An expected modification revision of 0 expects the key not to exist.
+
+When --write-out is set to simple, this command prints out OK or FAILED, followed by the
+value and the modification revision of the key after the transaction. The command exits
+with an error if the comparison fails.
+`,
+		Run:               casCommandFunc,
+		ValidArgsFunction: completeArgs(completeKeys),
+	}
+	cmd.Flags().StringVar(&casExpectValue, "expect-value", "", "value the key is expected to hold")
+	cmd.Flags().Int64Var(&casExpectModRev, "expect-mod-rev", 0, "modification revision the key is expected to have (0 for a key that does not exist)")
+	cmd.Flags().StringVar(&casNewValue, "new-value", "", "value to put into the key")
+	cmd.Flags().StringVar(&casLease, "lease", "0", "lease ID (in hexadecimal) to attach to the key")
+	cmd.MarkFlagsMutuallyExclusive("expect-value", "expect-mod-rev")
+	cmd.MarkFlagsOneRequired("expect-value", "expect-mod-rev")
+	cmd.MarkFlagRequired("new-value")
+	return cmd
+}
+
+// casCommandFunc executes the "cas" command.
+func casCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cas command needs 1 argument"))
+	}
+	key := args[0]
+
+	var cmp clientv3.Cmp
+	if cmd.Flags().Changed("expect-value") {
+		cmp = clientv3.Compare(clientv3.Value(key), "=", casExpectValue)
+	} else {
+		cmp = clientv3.Compare(clientv3.ModRevision(key), "=", casExpectModRev)
+	}
+
+	id, err := strconv.ParseInt(casLease, 16, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err))
+	}
+	var opts []clientv3.OpOption
+	if id != 0 {
+		opts = append(opts, clientv3.WithLease(clientv3.LeaseID(id)))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).Txn(ctx).
+		If(cmp).
+		Then(clientv3.OpPut(key, casNewValue, opts...), clientv3.OpGet(key)).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.CAS(*resp)
+	if !resp.Succeeded {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("key %q does not hold the expected value", key))
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"os"
@@ -42,6 +43,20 @@ var (
 	checkDatascalePrefix string
 	autoCompact          bool
 	autoDefrag           bool
+
+	checkPerfClients           int
+	checkPerfRate              int
+	checkPerfDuration          time.Duration
+	checkPerfMinThroughput     float64
+	checkPerfMaxLatency        time.Duration
+	checkPerfMaxStddev         time.Duration
+	checkDatascaleKVs          int
+	checkDatascaleClients      int
+	checkDatascaleMaxMemoryMiB float64
+
+	// checkOut receives the progress messages of the checks, so that they
+	// don't mix with JSON reports.
+	checkOut io.Writer = os.Stdout
 )
 
 type checkPerfCfg struct {
@@ -117,6 +132,85 @@ func NewCheckCommand() *cobra.Command {
 	return cc
 }
 
+// checkCriterion is a pass or fail criterion of a check.
+type checkCriterion struct {
+	Name      string  `json:"name"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Pass      bool    `json:"pass"`
+}
+
+// Names of the criteria of the checks.
+const (
+	checkCriterionErrors     = "errors"
+	checkCriterionThroughput = "throughput"
+	checkCriterionSlowest    = "slowest_latency_seconds"
+	checkCriterionStddev     = "latency_stddev_seconds"
+	checkCriterionMemory     = "memory_mib"
+)
+
+// checkPerfResult is the report of "check perf".
+type checkPerfResult struct {
+	Load       string  `json:"load"`
+	Clients    int     `json:"clients"`
+	Rate       int     `json:"rate"`
+	Duration   float64 `json:"duration_seconds"`
+	Throughput float64 `json:"throughput"`
+
+	AverageLatency     float64            `json:"average_latency_seconds"`
+	SlowestLatency     float64            `json:"slowest_latency_seconds"`
+	LatencyStddev      float64            `json:"latency_stddev_seconds"`
+	LatencyPercentiles map[string]float64 `json:"latency_percentiles_seconds"`
+
+	Errors   map[string]int   `json:"errors,omitempty"`
+	Criteria []checkCriterion `json:"criteria"`
+	Pass     bool             `json:"pass"`
+}
+
+// checkDatascaleResult is the report of "check datascale".
+type checkDatascaleResult struct {
+	Load      string  `json:"load"`
+	Clients   int     `json:"clients"`
+	KVs       int     `json:"kvs"`
+	KVSize    int     `json:"kv_size"`
+	MemoryMiB float64 `json:"memory_mib"`
+
+	Errors   map[string]int   `json:"errors,omitempty"`
+	Criteria []checkCriterion `json:"criteria"`
+	Pass     bool             `json:"pass"`
+}
+
+// addCriterion records whether value passes the criterion of not exceeding
+// the threshold.
+func addCriterion(criteria []checkCriterion, name string, value, threshold float64) []checkCriterion {
+	return append(criteria, checkCriterion{Name: name, Value: value, Threshold: threshold, Pass: value <= threshold})
+}
+
+func criteriaPass(criteria []checkCriterion) bool {
+	for _, c := range criteria {
+		if !c.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+func latencyPercentiles(lats []float64) map[string]float64 {
+	pcs, data := report.Percentiles(lats)
+	m := make(map[string]float64, len(pcs))
+	for i, pc := range pcs {
+		m[strconv.FormatFloat(pc, 'f', -1, 64)] = data[i]
+	}
+	return m
+}
+
+func initCheckDisplay(cmd *cobra.Command) {
+	initDisplayFromCmd(cmd)
+	if _, ok := display.(*jsonPrinter); ok {
+		checkOut = os.Stderr
+	}
+}
+
 // NewCheckPerfCommand returns the cobra command for "check perf".
 func NewCheckPerfCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -125,8 +219,13 @@ func NewCheckPerfCommand() *cobra.Command {
 		Run:   newCheckPerfCommand,
 	}
 
-	// TODO: support customized configuration
 	cmd.Flags().StringVar(&checkPerfLoad, "load", "s", "The performance check's workload model. Accepted workloads: s(small), m(medium), l(large), xl(xLarge). Different workload models use different configurations in terms of number of clients and expected throughput.")
+	cmd.Flags().IntVar(&checkPerfClients, "clients", 0, "The number of clients, overriding the workload model's when positive.")
+	cmd.Flags().IntVar(&checkPerfRate, "rate", 0, "The number of put requests per second, overriding the workload model's when positive.")
+	cmd.Flags().DurationVar(&checkPerfDuration, "duration", 0, "The duration of the check, overriding the workload model's when positive.")
+	cmd.Flags().Float64Var(&checkPerfMinThroughput, "min-throughput-ratio", 0.9, "The minimum ratio of the throughput to the rate of put requests.")
+	cmd.Flags().DurationVar(&checkPerfMaxLatency, "max-latency", 500*time.Millisecond, "The maximum latency of the slowest request.")
+	cmd.Flags().DurationVar(&checkPerfMaxStddev, "max-stddev", 100*time.Millisecond, "The maximum standard deviation of the request latency.")
 	cmd.Flags().StringVar(&checkPerfPrefix, "prefix", "/etcdctl-check-perf/", "The prefix for writing the performance check's keys.")
 	cmd.Flags().BoolVar(&autoCompact, "auto-compact", false, "Compact storage with last revision after test is finished.")
 	cmd.Flags().BoolVar(&autoDefrag, "auto-defrag", false, "Defragment storage after test is finished.")
@@ -151,11 +250,21 @@ func newCheckPerfCommand(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkPerfLoad))
 	}
 	cfg := checkPerfCfgMap[model]
+	if checkPerfClients > 0 {
+		cfg.clients = checkPerfClients
+	}
+	if checkPerfRate > 0 {
+		cfg.limit = checkPerfRate
+	}
+	if checkPerfDuration > 0 {
+		cfg.duration = int(math.Ceil(checkPerfDuration.Seconds()))
+	}
 
 	requests := make(chan v3.Op, cfg.clients)
 	limit := rate.NewLimiter(rate.Limit(cfg.limit), 1)
 
 	cc := clientConfigFromCmd(cmd)
+	initCheckDisplay(cmd)
 	clients := make([]*v3.Client, cfg.clients)
 	for i := 0; i < cfg.clients; i++ {
 		clients[i] = mustClient(cc)
@@ -229,39 +338,36 @@ func newCheckPerfCommand(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	ok = true
-	if len(s.ErrorDist) != 0 {
-		fmt.Println("FAIL: too many errors")
-		for k, v := range s.ErrorDist {
-			fmt.Printf("FAIL: ERROR(%v) -> %d\n", k, v)
-		}
-		ok = false
-	}
-
-	if s.RPS/float64(cfg.limit) <= 0.9 {
-		fmt.Printf("FAIL: Throughput too low: %d writes/s\n", int(s.RPS)+1)
-		ok = false
-	} else {
-		fmt.Printf("PASS: Throughput is %d writes/s\n", int(s.RPS)+1)
+	res := checkPerfResult{
+		Load:               model,
+		Clients:            cfg.clients,
+		Rate:               cfg.limit,
+		Duration:           s.Total.Seconds(),
+		Throughput:         s.RPS,
+		AverageLatency:     s.Average,
+		SlowestLatency:     s.Slowest,
+		LatencyStddev:      s.Stddev,
+		LatencyPercentiles: latencyPercentiles(s.Lats),
+		Errors:             s.ErrorDist,
+		Criteria:           []checkCriterion{},
 	}
-	if s.Slowest > 0.5 { // slowest request > 500ms
-		fmt.Printf("Slowest request took too long: %fs\n", s.Slowest)
-		ok = false
-	} else {
-		fmt.Printf("PASS: Slowest request took %fs\n", s.Slowest)
-	}
-	if s.Stddev > 0.1 { // stddev > 100ms
-		fmt.Printf("Stddev too high: %fs\n", s.Stddev)
-		ok = false
-	} else {
-		fmt.Printf("PASS: Stddev is %fs\n", s.Stddev)
+	if len(s.ErrorDist) != 0 {
+		res.Criteria = addCriterion(res.Criteria, checkCriterionErrors, float64(len(s.ErrorDist)), 0)
 	}
+	res.Criteria = append(res.Criteria, checkCriterion{
+		Name:      checkCriterionThroughput,
+		Value:     s.RPS,
+		Threshold: checkPerfMinThroughput * float64(cfg.limit),
+		Pass:      s.RPS/float64(cfg.limit) > checkPerfMinThroughput,
+	})
+	res.Criteria = addCriterion(res.Criteria, checkCriterionSlowest, s.Slowest, checkPerfMaxLatency.Seconds())
+	res.Criteria = addCriterion(res.Criteria, checkCriterionStddev, s.Stddev, checkPerfMaxStddev.Seconds())
+	res.Pass = criteriaPass(res.Criteria)
 
-	if !ok {
-		fmt.Println("FAIL")
-		os.Exit(cobrautl.ExitError)
+	display.CheckPerf(res)
+	if !res.Pass {
+		cobrautl.Exit(cobrautl.ExitError)
 	}
-	fmt.Println("PASS")
 }
 
 func attemptCleanup(client *v3.Client, autoCompact bool) {
@@ -269,7 +375,7 @@ func attemptCleanup(client *v3.Client, autoCompact bool) {
 	defer dcancel()
 	dresp, err := client.Delete(dctx, checkPerfPrefix, v3.WithPrefix())
 	if err != nil {
-		fmt.Printf("FAIL: Cleanup failed during key deletion: ERROR(%v)\n", err)
+		fmt.Fprintf(checkOut, "FAIL: Cleanup failed during key deletion: ERROR(%v)\n", err)
 		return
 	}
 	if autoCompact {
@@ -303,6 +409,9 @@ func NewCheckDatascaleCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&checkDatascaleLoad, "load", "s", "The datascale check's workload model. Accepted workloads: s(small), m(medium), l(large), xl(xLarge)")
 	cmd.Flags().StringVar(&checkDatascalePrefix, "prefix", "/etcdctl-check-datascale/", "The prefix for writing the datascale check's keys.")
+	cmd.Flags().IntVar(&checkDatascaleKVs, "kvs", 0, "The number of key-value pairs to write, overriding the workload model's when positive.")
+	cmd.Flags().IntVar(&checkDatascaleClients, "clients", 0, "The number of clients, overriding the workload model's when positive.")
+	cmd.Flags().Float64Var(&checkDatascaleMaxMemoryMiB, "max-memory", 0, "The maximum memory used in MiB. No maximum if 0.")
 	cmd.Flags().BoolVar(&autoCompact, "auto-compact", false, "Compact storage with last revision after test is finished.")
 	cmd.Flags().BoolVar(&autoDefrag, "auto-defrag", false, "Defragment storage after test is finished.")
 
@@ -323,10 +432,17 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkDatascaleLoad))
 	}
 	cfg := checkDatascaleCfgMap[model]
+	if checkDatascaleKVs > 0 {
+		cfg.limit = checkDatascaleKVs
+	}
+	if checkDatascaleClients > 0 {
+		cfg.clients = checkDatascaleClients
+	}
 
 	requests := make(chan v3.Op, cfg.clients)
 
 	cc := clientConfigFromCmd(cmd)
+	initCheckDisplay(cmd)
 	clients := make([]*v3.Client, cfg.clients)
 	for i := 0; i < cfg.clients; i++ {
 		clients[i] = mustClient(cc)
@@ -360,11 +476,11 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	// get the process_resident_memory_bytes and process_virtual_memory_bytes before the put operations
 	bytesBefore := endpointMemoryMetrics(eps[0], sec)
 	if bytesBefore == 0 {
-		fmt.Println("FAIL: Could not read process_resident_memory_bytes before the put operations.")
-		os.Exit(cobrautl.ExitError)
+		fmt.Fprintln(checkOut, "FAIL: Could not read process_resident_memory_bytes before the put operations.")
+		cobrautl.Exit(cobrautl.ExitError)
 	}
 
-	fmt.Printf("Start data scale check for work load [%v key-value pairs, %v bytes per key-value, %v concurrent clients].\n", cfg.limit, cfg.kvSize, cfg.clients)
+	fmt.Fprintf(checkOut, "Start data scale check for work load [%v key-value pairs, %v bytes per key-value, %v concurrent clients].\n", cfg.limit, cfg.kvSize, cfg.clients)
 	bar := pb.New(cfg.limit)
 	bar.Start()
 
@@ -397,8 +513,8 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	// get the process_resident_memory_bytes after the put operations
 	bytesAfter := endpointMemoryMetrics(eps[0], sec)
 	if bytesAfter == 0 {
-		fmt.Println("FAIL: Could not read process_resident_memory_bytes after the put operations.")
-		os.Exit(cobrautl.ExitError)
+		fmt.Fprintln(checkOut, "FAIL: Could not read process_resident_memory_bytes after the put operations.")
+		cobrautl.Exit(cobrautl.ExitError)
 	}
 
 	// delete the created kv pairs
@@ -420,19 +536,32 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	}
 
 	if bytesAfter == 0 {
-		fmt.Println("FAIL: Could not read process_resident_memory_bytes after the put operations.")
-		os.Exit(cobrautl.ExitError)
+		fmt.Fprintln(checkOut, "FAIL: Could not read process_resident_memory_bytes after the put operations.")
+		cobrautl.Exit(cobrautl.ExitError)
 	}
 
 	bytesUsed := bytesAfter - bytesBefore
 	mbUsed := bytesUsed / (1024 * 1024)
 
+	res := checkDatascaleResult{
+		Load:      model,
+		Clients:   cfg.clients,
+		KVs:       cfg.limit,
+		KVSize:    cfg.kvSize,
+		MemoryMiB: mbUsed,
+		Errors:    s.ErrorDist,
+		Criteria:  []checkCriterion{},
+	}
 	if len(s.ErrorDist) != 0 {
-		fmt.Println("FAIL: too many errors")
-		for k, v := range s.ErrorDist {
-			fmt.Printf("FAIL: ERROR(%v) -> %d\n", k, v)
-		}
-		os.Exit(cobrautl.ExitError)
+		res.Criteria = addCriterion(res.Criteria, checkCriterionErrors, float64(len(s.ErrorDist)), 0)
+	}
+	if checkDatascaleMaxMemoryMiB > 0 {
+		res.Criteria = addCriterion(res.Criteria, checkCriterionMemory, mbUsed, checkDatascaleMaxMemoryMiB)
+	}
+	res.Pass = criteriaPass(res.Criteria)
+
+	display.CheckDatascale(res)
+	if !res.Pass {
+		cobrautl.Exit(cobrautl.ExitError)
 	}
-	fmt.Printf("PASS: Approximate system memory used : %v MB.\n", strconv.FormatFloat(mbUsed, 'f', 2, 64))
 }
@@ -0,0 +1,57 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCriteria(t *testing.T) {
+	var criteria []checkCriterion
+	criteria = addCriterion(criteria, checkCriterionSlowest, 0.2, 0.5)
+	criteria = addCriterion(criteria, checkCriterionStddev, 0.1, 0.1)
+	assert.True(t, criteriaPass(criteria))
+
+	criteria = addCriterion(criteria, checkCriterionMemory, 120, 100)
+	assert.False(t, criteria[2].Pass)
+	assert.False(t, criteriaPass(criteria))
+}
+
+func TestCheckPerfResultJSON(t *testing.T) {
+	res := checkPerfResult{
+		Load:               "s",
+		Throughput:         140,
+		LatencyPercentiles: latencyPercentiles([]float64{0.1, 0.2, 0.3, 0.4}),
+		Criteria:           addCriterion(nil, checkCriterionSlowest, 0.4, 0.5),
+		Pass:               true,
+	}
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, "s", got["load"])
+	assert.InDelta(t, 140, got["throughput"], 0)
+	assert.Equal(t, true, got["pass"])
+	assert.NotContains(t, got, "errors")
+	assert.Contains(t, got["latency_percentiles_seconds"], "99.9")
+	criteria := got["criteria"].([]any)
+	require.Len(t, criteria, 1)
+	assert.Equal(t, map[string]any{"name": checkCriterionSlowest, "value": 0.4, "threshold": 0.5, "pass": true}, criteria[0])
+}
@@ -0,0 +1,330 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/spf13/cobra"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	rolloutTargetVersion  string
+	rolloutRestartCommand string
+	rolloutDrain          bool
+	rolloutMemberTimeout  time.Duration
+)
+
+// rolloutInterval is the interval at which "cluster rollout" polls the status
+// of the restarted member.
+var rolloutInterval = time.Second
+
+// NewClusterCommand returns the cobra command for "cluster".
+func NewClusterCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "cluster <subcommand>",
+		Short: "Cluster wide operations",
+	}
+
+	cc.AddCommand(NewClusterRolloutCommand())
+	cc.AddCommand(NewClusterMetadataCommand())
+
+	return cc
+}
+
+// NewClusterRolloutCommand returns the cobra command for "cluster rollout".
+func NewClusterRolloutCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "rollout --target-version <version> [options]",
+		Short: "Upgrades or downgrades the cluster one member at a time",
+		Long: `Upgrades or downgrades the cluster to the target minor version one member at a time.
+
+Downgrades are validated and enabled before any member is restarted. Members are
+then restarted one by one, followers first and the leader last. Each member is
+restarted by running the restart command, or by the operator if no restart command
+is given. The rollout waits until the member is healthy and runs the target version
+before moving on to the next member.
+
+The restart command runs through "sh -c" with the following environment variables:
+  ETCDCTL_ROLLOUT_MEMBER_ID        hex ID of the member to restart
+  ETCDCTL_ROLLOUT_MEMBER_NAME      name of the member to restart
+  ETCDCTL_ROLLOUT_MEMBER_ENDPOINT  client URL of the member to restart
+  ETCDCTL_ROLLOUT_TARGET_VERSION   target version of the rollout
+`,
+
+		Run: clusterRolloutCommandFunc,
+	}
+
+	cc.Flags().StringVar(&rolloutTargetVersion, "target-version", "", "target version (major.minor) of the cluster")
+	cc.Flags().StringVar(&rolloutRestartCommand, "restart-command", "", "command restarting a member with the target version. If not set, waits for the operator to restart each member")
+	cc.Flags().BoolVar(&rolloutDrain, "drain", false, "drain each member before restarting it")
+	cc.Flags().DurationVar(&rolloutMemberTimeout, "member-timeout", 10*time.Minute, "timeout for restarting a single member and waiting for it to become healthy")
+	cc.MarkFlagRequired("target-version")
+
+	return cc
+}
+
+// clusterRolloutCommandFunc executes the "cluster rollout" command.
+func clusterRolloutCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments"))
+	}
+	target, err := parseRolloutVersion(rolloutTargetVersion)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad target version %q: %w", rolloutTargetVersion, err))
+	}
+
+	r := &rollout{
+		c:             mustClientFromCmd(cmd),
+		target:        target,
+		restart:       manualRestart,
+		drain:         rolloutDrain,
+		interval:      rolloutInterval,
+		memberTimeout: rolloutMemberTimeout,
+		out:           os.Stdout,
+	}
+	if rolloutRestartCommand != "" {
+		r.restart = commandRestart(rolloutRestartCommand)
+	}
+	if err := r.run(context.Background()); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// rolloutClient is the subset of the client used by "cluster rollout".
+type rolloutClient interface {
+	MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error)
+	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+	Downgrade(ctx context.Context, action clientv3.DowngradeAction, version string) (*clientv3.DowngradeResponse, error)
+	Drain(ctx context.Context, endpoint string) (*clientv3.DrainResponse, error)
+}
+
+// rolloutRestartFunc restarts the given member with the target version.
+type rolloutRestartFunc func(ctx context.Context, out io.Writer, m *pb.Member, target *semver.Version) error
+
+// manualRestart asks the operator to restart the member.
+func manualRestart(ctx context.Context, out io.Writer, m *pb.Member, target *semver.Version) error {
+	fmt.Fprintf(out, "Restart member %x (%s) with etcd %s, waiting for it to rejoin the cluster\n", m.ID, m.Name, target)
+	return nil
+}
+
+// commandRestart restarts the member by running the given shell command.
+func commandRestart(command string) rolloutRestartFunc {
+	return func(ctx context.Context, out io.Writer, m *pb.Member, target *semver.Version) error {
+		c := exec.CommandContext(ctx, "sh", "-c", command)
+		c.Env = append(os.Environ(),
+			fmt.Sprintf("ETCDCTL_ROLLOUT_MEMBER_ID=%x", m.ID),
+			"ETCDCTL_ROLLOUT_MEMBER_NAME="+m.Name,
+			"ETCDCTL_ROLLOUT_MEMBER_ENDPOINT="+m.ClientURLs[0],
+			"ETCDCTL_ROLLOUT_TARGET_VERSION="+target.String(),
+		)
+		c.Stdout = os.Stderr
+		c.Stderr = os.Stderr
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("restart command failed for member %x: %w", m.ID, err)
+		}
+		return nil
+	}
+}
+
+// rollout changes the version of a cluster one member at a time.
+type rollout struct {
+	c             rolloutClient
+	target        *semver.Version
+	restart       rolloutRestartFunc
+	drain         bool
+	interval      time.Duration
+	memberTimeout time.Duration
+	out           io.Writer
+}
+
+func (r *rollout) run(ctx context.Context) error {
+	resp, err := r.c.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	members := resp.Members
+
+	var (
+		leader                       uint64
+		downgradeInfo                *pb.DowngradeInfo
+		needsUpgrade, needsDowngrade bool
+	)
+	versions := make(map[uint64]*semver.Version, len(members))
+	for _, m := range members {
+		if len(m.Name) == 0 || len(m.ClientURLs) == 0 {
+			return fmt.Errorf("member %x has not started", m.ID)
+		}
+		sresp, err := r.c.Status(ctx, m.ClientURLs[0])
+		if err != nil {
+			return fmt.Errorf("member %x is unhealthy: %w", m.ID, err)
+		}
+		v, err := parseRolloutVersion(sresp.Version)
+		if err != nil {
+			return fmt.Errorf("member %x reported bad version %q: %w", m.ID, sresp.Version, err)
+		}
+		versions[m.ID] = v
+		leader = sresp.Leader
+		downgradeInfo = sresp.DowngradeInfo
+		switch {
+		case v.LessThan(*r.target):
+			needsUpgrade = true
+		case r.target.LessThan(*v):
+			needsDowngrade = true
+		}
+	}
+	if needsUpgrade && needsDowngrade {
+		return fmt.Errorf("members run versions both below and above the target version %s", r.target)
+	}
+	if !needsUpgrade && !needsDowngrade {
+		fmt.Fprintf(r.out, "All members already run version %s\n", r.target)
+		return nil
+	}
+	if needsDowngrade {
+		if err := r.enableDowngrade(ctx, downgradeInfo); err != nil {
+			return err
+		}
+	}
+
+	// restart followers first so that leadership changes only once.
+	sort.Slice(members, func(i, j int) bool {
+		if (members[i].ID == leader) != (members[j].ID == leader) {
+			return members[j].ID == leader
+		}
+		return members[i].ID < members[j].ID
+	})
+	for i, m := range members {
+		fmt.Fprintf(r.out, "[%d/%d] ", i+1, len(members))
+		if versions[m.ID].Equal(*r.target) {
+			fmt.Fprintf(r.out, "Member %x (%s) already runs version %s\n", m.ID, m.Name, r.target)
+			continue
+		}
+		fmt.Fprintf(r.out, "Rolling member %x (%s) from version %s to %s\n", m.ID, m.Name, versions[m.ID], r.target)
+		if err := r.rollMember(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	if needsDowngrade {
+		if err := r.waitDowngradeFinished(ctx, members); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(r.out, "Rolled out version %s to all members\n", r.target)
+	return nil
+}
+
+// enableDowngrade validates and enables the downgrade to the target version,
+// unless it is already enabled, e.g. by an interrupted rollout.
+func (r *rollout) enableDowngrade(ctx context.Context, info *pb.DowngradeInfo) error {
+	if info.GetEnabled() {
+		v, err := parseRolloutVersion(info.TargetVersion)
+		if err == nil && v.Equal(*r.target) {
+			fmt.Fprintf(r.out, "Downgrade to %s is already enabled\n", r.target)
+			return nil
+		}
+		return fmt.Errorf("a downgrade to %s is in progress", info.TargetVersion)
+	}
+	if _, err := r.c.Downgrade(ctx, clientv3.DowngradeValidate, r.target.String()); err != nil {
+		return fmt.Errorf("failed to validate downgrade to %s: %w", r.target, err)
+	}
+	if _, err := r.c.Downgrade(ctx, clientv3.DowngradeEnable, r.target.String()); err != nil {
+		return fmt.Errorf("failed to enable downgrade to %s: %w", r.target, err)
+	}
+	fmt.Fprintf(r.out, "Enabled downgrade to %s\n", r.target)
+	return nil
+}
+
+// rollMember restarts the given member and waits until it is healthy and
+// runs the target version.
+func (r *rollout) rollMember(ctx context.Context, m *pb.Member) error {
+	ctx, cancel := context.WithTimeout(ctx, r.memberTimeout)
+	defer cancel()
+
+	if r.drain {
+		dresp, err := r.c.Drain(ctx, m.ClientURLs[0])
+		if err != nil {
+			return fmt.Errorf("failed to drain member %x: %w", m.ID, err)
+		}
+		if dresp.LeaderTransferred {
+			fmt.Fprintf(r.out, "Member %x transferred its leadership\n", m.ID)
+		}
+		fmt.Fprintf(r.out, "Drained member %x\n", m.ID)
+	}
+	if err := r.restart(ctx, r.out, m, r.target); err != nil {
+		return err
+	}
+
+	for {
+		sresp, err := r.c.Status(ctx, m.ClientURLs[0])
+		if err == nil {
+			if v, verr := parseRolloutVersion(sresp.Version); verr == nil && v.Equal(*r.target) && sresp.Leader != 0 && len(sresp.Errors) == 0 {
+				fmt.Fprintf(r.out, "Member %x (%s) is healthy and runs version %s\n", m.ID, m.Name, sresp.Version)
+				return nil
+			}
+		}
+		select {
+		case <-time.After(r.interval):
+		case <-ctx.Done():
+			return fmt.Errorf("member %x did not become healthy with version %s: %w", m.ID, r.target, ctx.Err())
+		}
+	}
+}
+
+// waitDowngradeFinished waits until the cluster has completed the downgrade,
+// which happens once all members run the target version.
+func (r *rollout) waitDowngradeFinished(ctx context.Context, members []*pb.Member) error {
+	ctx, cancel := context.WithTimeout(ctx, r.memberTimeout)
+	defer cancel()
+	for {
+		for _, m := range members {
+			sresp, err := r.c.Status(ctx, m.ClientURLs[0])
+			if err == nil && !sresp.DowngradeInfo.GetEnabled() {
+				fmt.Fprintf(r.out, "Downgrade to %s completed\n", r.target)
+				return nil
+			}
+		}
+		select {
+		case <-time.After(r.interval):
+		case <-ctx.Done():
+			return fmt.Errorf("downgrade to %s did not complete: %w", r.target, ctx.Err())
+		}
+	}
+}
+
+// parseRolloutVersion parses a version in major.minor or major.minor.patch
+// format, and returns its major.minor part.
+func parseRolloutVersion(s string) (*semver.Version, error) {
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		var perr error
+		if v, perr = semver.NewVersion(s + ".0"); perr != nil {
+			return nil, err
+		}
+	}
+	return &semver.Version{Major: v.Major, Minor: v.Minor}, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeRolloutClient simulates a cluster whose members report the versions
+// in the versions map. The downgrade completes once all members run the
+// downgrade target version.
+type fakeRolloutClient struct {
+	leader    uint64
+	versions  map[uint64]string
+	downgrade string
+
+	actions []string
+}
+
+func (c *fakeRolloutClient) MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
+	resp := &clientv3.MemberListResponse{}
+	for id := range c.versions {
+		resp.Members = append(resp.Members, &pb.Member{ID: id, Name: fmt.Sprintf("m%d", id), ClientURLs: []string{fmt.Sprintf("http://m%d:2379", id)}})
+	}
+	return resp, nil
+}
+
+func (c *fakeRolloutClient) Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error) {
+	var id uint64
+	fmt.Sscanf(endpoint, "http://m%d:2379", &id)
+	if c.downgrade != "" {
+		done := true
+		for _, v := range c.versions {
+			done = done && v == c.downgrade
+		}
+		if done {
+			c.downgrade = ""
+		}
+	}
+	return &clientv3.StatusResponse{
+		Header:        &pb.ResponseHeader{MemberId: id},
+		Version:       c.versions[id],
+		Leader:        c.leader,
+		DowngradeInfo: &pb.DowngradeInfo{Enabled: c.downgrade != "", TargetVersion: c.downgrade},
+	}, nil
+}
+
+func (c *fakeRolloutClient) Downgrade(ctx context.Context, action clientv3.DowngradeAction, version string) (*clientv3.DowngradeResponse, error) {
+	switch action {
+	case clientv3.DowngradeValidate:
+		c.actions = append(c.actions, "validate "+version)
+	case clientv3.DowngradeEnable:
+		c.actions = append(c.actions, "enable "+version)
+		c.downgrade = version
+	}
+	return &clientv3.DowngradeResponse{}, nil
+}
+
+func (c *fakeRolloutClient) Drain(ctx context.Context, endpoint string) (*clientv3.DrainResponse, error) {
+	c.actions = append(c.actions, "drain "+endpoint)
+	return &clientv3.DrainResponse{}, nil
+}
+
+func (c *fakeRolloutClient) restart(ctx context.Context, out io.Writer, m *pb.Member, target *semver.Version) error {
+	c.actions = append(c.actions, fmt.Sprintf("restart %d", m.ID))
+	c.versions[m.ID] = target.String()
+	return nil
+}
+
+func newTestRollout(c *fakeRolloutClient, target string) *rollout {
+	return &rollout{
+		c:             c,
+		target:        semver.New(target),
+		restart:       c.restart,
+		interval:      time.Millisecond,
+		memberTimeout: time.Second,
+		out:           io.Discard,
+	}
+}
+
+func TestRolloutUpgrade(t *testing.T) {
+	c := &fakeRolloutClient{leader: 1, versions: map[uint64]string{1: "3.5.17", 2: "3.6.0", 3: "3.5.17"}}
+	r := newTestRollout(c, "3.6.0")
+	r.drain = true
+	require.NoError(t, r.run(context.Background()))
+	// the leader is restarted last, and members at the target version are skipped.
+	require.Equal(t, []string{"drain http://m3:2379", "restart 3", "drain http://m1:2379", "restart 1"}, c.actions)
+}
+
+func TestRolloutDowngrade(t *testing.T) {
+	c := &fakeRolloutClient{leader: 2, versions: map[uint64]string{1: "3.6.0", 2: "3.6.0", 3: "3.6.0"}}
+	require.NoError(t, newTestRollout(c, "3.5.0").run(context.Background()))
+	require.Equal(t, []string{"validate 3.5.0", "enable 3.5.0", "restart 1", "restart 3", "restart 2"}, c.actions)
+	require.Empty(t, c.downgrade)
+
+	// resuming an interrupted downgrade doesn't enable it again.
+	c = &fakeRolloutClient{leader: 1, versions: map[uint64]string{1: "3.6.0", 2: "3.5.0"}, downgrade: "3.5.0"}
+	require.NoError(t, newTestRollout(c, "3.5.0").run(context.Background()))
+	require.Equal(t, []string{"restart 1"}, c.actions)
+}
+
+func TestRolloutNoop(t *testing.T) {
+	c := &fakeRolloutClient{leader: 1, versions: map[uint64]string{1: "3.6.1", 2: "3.6.0"}}
+	require.NoError(t, newTestRollout(c, "3.6.0").run(context.Background()))
+	require.Empty(t, c.actions)
+
+	c = &fakeRolloutClient{leader: 1, versions: map[uint64]string{1: "3.7.0", 2: "3.5.0"}}
+	require.ErrorContains(t, newTestRollout(c, "3.6.0").run(context.Background()), "both below and above")
+}
+
+func TestParseRolloutVersion(t *testing.T) {
+	for in, want := range map[string]string{"3.5": "3.5.0", "3.5.17": "3.5.0", "3.6.0-alpha.0": "3.6.0"} {
+		v, err := parseRolloutVersion(in)
+		require.NoError(t, err)
+		require.Equal(t, want, v.String())
+	}
+	_, err := parseRolloutVersion("three")
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var clusterMetadataConsistency string
+
+// NewClusterMetadataCommand returns the cobra command for "cluster metadata".
+func NewClusterMetadataCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "metadata <subcommand>",
+		Short: "Cluster metadata related commands",
+		Long: `Manages the cluster metadata, small operational annotations of the cluster such as
+its environment or owner. The metadata is replicated to all members, but kept apart
+from the keyspace: it is not affected by compaction, defragmentation or snapshot restore.
+`,
+	}
+
+	cc.AddCommand(NewClusterMetadataPutCommand())
+	cc.AddCommand(NewClusterMetadataDeleteCommand())
+	cc.AddCommand(NewClusterMetadataListCommand())
+
+	return cc
+}
+
+// NewClusterMetadataPutCommand returns the cobra command for "cluster metadata put".
+func NewClusterMetadataPutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "put <key> <value>",
+		Short: "Sets an entry of the cluster metadata",
+		Run:   clusterMetadataPutCommandFunc,
+	}
+}
+
+// NewClusterMetadataDeleteCommand returns the cobra command for "cluster metadata del".
+func NewClusterMetadataDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "del <key>",
+		Short: "Deletes an entry of the cluster metadata",
+		Run:   clusterMetadataDeleteCommandFunc,
+	}
+}
+
+// NewClusterMetadataListCommand returns the cobra command for "cluster metadata list".
+func NewClusterMetadataListCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the entries of the cluster metadata",
+		Long: `When --write-out is set to simple, this command prints out comma-separated entries.
+The items in the entries are Key, Value.
+`,
+		Run: clusterMetadataListCommandFunc,
+	}
+
+	cc.Flags().StringVar(&clusterMetadataConsistency, "consistency", "l", "Linearizable(l) or Serializable(s)")
+
+	return cc
+}
+
+// clusterMetadataPutCommandFunc executes the "cluster metadata put" command.
+func clusterMetadataPutCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster metadata put command needs key and value as arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ClusterMetadataPut(ctx, args[0], args[1])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ClusterMetadataPut(args[0], *resp)
+}
+
+// clusterMetadataDeleteCommandFunc executes the "cluster metadata del" command.
+func clusterMetadataDeleteCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster metadata del command needs key as argument"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ClusterMetadataDelete(ctx, args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ClusterMetadataDelete(args[0], *resp)
+}
+
+// clusterMetadataListCommandFunc executes the "cluster metadata list" command.
+func clusterMetadataListCommandFunc(cmd *cobra.Command, args []string) {
+	var opts []clientv3.OpOption
+	if IsSerializable(clusterMetadataConsistency) {
+		opts = append(opts, clientv3.WithSerializable())
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ClusterMetadataList(ctx, opts...)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ClusterMetadataList(*resp)
+}
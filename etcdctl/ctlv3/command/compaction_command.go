@@ -17,6 +17,7 @@ package command
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -24,7 +25,10 @@ import (
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
-var compactPhysical bool
+var (
+	compactPhysical bool
+	compactRetains  []string
+)
 
 // NewCompactionCommand returns the cobra command for "compaction".
 func NewCompactionCommand() *cobra.Command {
@@ -34,6 +38,7 @@ func NewCompactionCommand() *cobra.Command {
 		Run:   compactionCommandFunc,
 	}
 	cmd.Flags().BoolVar(&compactPhysical, "physical", false, "'true' to wait for compaction to physically remove all old revisions")
+	cmd.Flags().StringArrayVar(&compactRetains, "retain", nil, "'prefix=revision' to keep the history of the keys under the prefix from the revision on")
 	return cmd
 }
 
@@ -52,6 +57,17 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	if compactPhysical {
 		opts = append(opts, clientv3.WithCompactPhysical())
 	}
+	for _, r := range compactRetains {
+		i := strings.LastIndex(r, "=")
+		if i < 0 {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid retention %q, expected 'prefix=revision'", r))
+		}
+		rrev, err := strconv.ParseInt(r[i+1:], 10, 64)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		opts = append(opts, clientv3.WithCompactRetention(r[:i], rrev))
+	}
 
 	c := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
@@ -0,0 +1,147 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// completionKeysLimit is the maximum number of keys looked up to complete a
+// key argument.
+const completionKeysLimit = 100
+
+// completionFunc completes a single argument by looking up the candidates in
+// the cluster.
+type completionFunc func(ctx context.Context, c *clientv3.Client, toComplete string) []string
+
+// completeArgs returns a cobra completion function completing the argument at
+// each position with the function at the same position. Arguments without a
+// function, and all arguments if the cluster cannot be reached within the
+// completion timeout, are not completed.
+func completeArgs(fns ...completionFunc) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(fns) || fns[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		timeout, err := cmd.Flags().GetDuration("completion-timeout")
+		if err != nil || timeout <= 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		c, err := completionClient(cmd, timeout)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("failed to connect: %v", err), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		defer c.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return fns[len(args)](ctx, c, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionClient creates the client of the live lookups. The lookups never
+// ask for a password, since the shell is waiting for the completion.
+func completionClient(cmd *cobra.Command, timeout time.Duration) (*clientv3.Client, error) {
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	if user != "" && password == "" && !strings.Contains(user, ":") {
+		return nil, fmt.Errorf("no password given for user %q", user)
+	}
+	cc := clientConfigFromCmd(cmd)
+	if cc.DialTimeout <= 0 || cc.DialTimeout > timeout {
+		cc.DialTimeout = timeout
+	}
+	cfg, err := newClientConfig(cc, zap.NewNop())
+	if err != nil {
+		return nil, err
+	}
+	cfg.Logger = zap.NewNop()
+	c, err := clientv3.New(*cfg)
+	if err != nil {
+		return nil, err
+	}
+	namespaceClientFromCmd(cmd, c)
+	return c, nil
+}
+
+// completeKeys completes a key with the keys starting with it.
+func completeKeys(ctx context.Context, c *clientv3.Client, toComplete string) []string {
+	resp, err := c.Get(ctx, toComplete, clientv3.WithPrefix(), clientv3.WithKeysOnly(),
+		clientv3.WithSerializable(), clientv3.WithLimit(completionKeysLimit))
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to get keys: %v", err), true)
+		return nil
+	}
+	keys := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys = append(keys, string(kv.Key))
+	}
+	return keys
+}
+
+// completeMemberIDs completes a member ID with the IDs of the members, described
+// by their names.
+func completeMemberIDs(ctx context.Context, c *clientv3.Client, toComplete string) []string {
+	resp, err := c.MemberList(ctx, clientv3.WithSerializable())
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list members: %v", err), true)
+		return nil
+	}
+	var ids []string
+	for _, m := range resp.Members {
+		if id := fmt.Sprintf("%x", m.ID); strings.HasPrefix(id, toComplete) {
+			ids = append(ids, id+"\t"+m.Name)
+		}
+	}
+	return ids
+}
+
+// completeUsers completes a user name with the names of the users.
+func completeUsers(ctx context.Context, c *clientv3.Client, toComplete string) []string {
+	resp, err := c.UserList(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list users: %v", err), true)
+		return nil
+	}
+	return filterPrefix(resp.Users, toComplete)
+}
+
+// completeRoles completes a role name with the names of the roles.
+func completeRoles(ctx context.Context, c *clientv3.Client, toComplete string) []string {
+	resp, err := c.RoleList(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list roles: %v", err), true)
+		return nil
+	}
+	return filterPrefix(resp.Roles, toComplete)
+}
+
+func filterPrefix(names []string, prefix string) []string {
+	var filtered []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
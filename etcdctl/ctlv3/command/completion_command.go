@@ -62,6 +62,11 @@ PowerShell:
   # To load completions for every new session, run:
   PS> etcdctl completion powershell > etcdctl.ps1
   # and source this file from your PowerShell profile.
+
+Keys, member IDs, users and roles are completed by looking them up in the cluster,
+using the endpoints and credentials of the command line being completed. The lookups
+are bounded by --completion-timeout and skipped if it is 0 or if the password of
+--user would have to be prompted.
 `,
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
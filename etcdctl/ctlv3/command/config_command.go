@@ -0,0 +1,116 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// NewConfigCommand returns the cobra command for "config".
+func NewConfigCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "config <subcommand>",
+		Short: "Runtime configuration related commands",
+	}
+	cc.PersistentFlags().BoolVar(&epClusterEndpoints, "cluster", false, "use all endpoints from the cluster member list")
+
+	cc.AddCommand(NewConfigSetCommand())
+	cc.AddCommand(NewConfigGetCommand())
+
+	return cc
+}
+
+// NewConfigSetCommand returns the cobra command for "config set".
+func NewConfigSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name>=<value> [<name>=<value>...]",
+		Short: "Changes runtime adjustable settings of the etcd members with given endpoints",
+		Long: `Changes settings of the etcd members with given endpoints, which are safe to adjust at runtime:
+compaction-batch-limit, compaction-sleep-interval, warning-apply-duration, max-concurrent-streams,
+watch-progress-notify-interval, max-request-bytes, backend-batch-interval, backend-batch-limit and
+backend-batch-limit-max. max-concurrent-streams and max-request-bytes can only be lowered below the values the members started with.
+The changes are not persisted; restarted members use their configured values again.
+`,
+		Run: configSetCommandFunc,
+	}
+}
+
+// NewConfigGetCommand returns the cobra command for "config get".
+func NewConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Lists the runtime adjustable settings of the etcd members with given endpoints",
+		Run:   configGetCommandFunc,
+	}
+}
+
+func configSetCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("config set command needs at least one <name>=<value> argument"))
+	}
+	settings, err := parseConfigSettings(args)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	configSet(cmd, settings)
+}
+
+func configGetCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("config get command does not accept arguments"))
+	}
+	configSet(cmd, nil)
+}
+
+func configSet(cmd *cobra.Command, settings map[string]string) {
+	failures := 0
+	cfg := clientConfigFromCmd(cmd)
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.ConfigSet(ctx, ep, settings)
+		cancel()
+		c.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set config of etcd member[%s] (%v)\n", ep, err)
+			failures++
+			continue
+		}
+		display.Config(ep, *resp)
+	}
+
+	if failures != 0 {
+		cobrautl.Exit(cobrautl.ExitError)
+	}
+}
+
+func parseConfigSettings(args []string) (map[string]string, error) {
+	settings := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("invalid setting %q, expected <name>=<value>", arg)
+		}
+		settings[name] = value
+	}
+	return settings, nil
+}
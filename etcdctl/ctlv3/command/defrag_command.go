@@ -56,6 +56,6 @@ func defragCommandFunc(cmd *cobra.Command, args []string) {
 	}
 
 	if failures != 0 {
-		os.Exit(cobrautl.ExitError)
+		cobrautl.Exit(cobrautl.ExitError)
 	}
 }
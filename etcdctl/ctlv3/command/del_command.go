@@ -30,20 +30,23 @@ var (
 	delPrevKV  bool
 	delFromKey bool
 	delRange   bool
+	delLazy    bool
 )
 
 // NewDelCommand returns the cobra command for "del".
 func NewDelCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "del [options] <key> [range_end]",
-		Short: "Removes the specified key or range of keys [key, range_end)",
-		Run:   delCommandFunc,
+		Use:               "del [options] <key> [range_end]",
+		Short:             "Removes the specified key or range of keys [key, range_end)",
+		Run:               delCommandFunc,
+		ValidArgsFunction: completeArgs(completeKeys, completeKeys),
 	}
 
 	cmd.Flags().BoolVar(&delPrefix, "prefix", false, "delete keys with matching prefix")
 	cmd.Flags().BoolVar(&delPrevKV, "prev-kv", false, "return deleted key-value pairs")
 	cmd.Flags().BoolVar(&delFromKey, "from-key", false, "delete keys that are greater than or equal to the given key using byte compare")
 	cmd.Flags().BoolVar(&delRange, "range", false, "delete range of keys")
+	cmd.Flags().BoolVar(&delLazy, "lazy", false, "mark the range deleted at once and leave removing its keys to the compactions")
 	return cmd
 }
 
@@ -67,6 +70,9 @@ func getDelOp(args []string) (string, []clientv3.OpOption) {
 	if delPrefix && delFromKey {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
 	}
+	if delLazy && delPrevKV {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--lazy` and `--prev-kv` cannot be set at the same time"))
+	}
 
 	var opts []clientv3.OpOption
 	key := args[0]
@@ -93,6 +99,9 @@ func getDelOp(args []string) (string, []clientv3.OpOption) {
 	if delPrevKV {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if delLazy {
+		opts = append(opts, clientv3.WithLazyDelete())
+	}
 
 	if delFromKey {
 		if len(key) == 0 {
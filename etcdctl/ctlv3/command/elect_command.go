@@ -22,6 +22,7 @@ import (
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
@@ -38,9 +39,41 @@ func NewElectCommand() *cobra.Command {
 		Run:   electCommandFunc,
 	}
 	cmd.Flags().BoolVarP(&electListen, "listen", "l", false, "observation mode")
+	cmd.AddCommand(newElectHistoryCommand())
 	return cmd
 }
 
+var electHistoryLimit int
+
+func newElectHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <election-name>",
+		Short: "Shows the last leaders of an election",
+		Long: `Shows the last leaders of an election, oldest first, with their leadership
+epoch and the revision they were elected at. The epoch increases with every
+new leader, so it can be used as a fencing token.`,
+		Run: electHistoryCommandFunc,
+	}
+	cmd.Flags().IntVar(&electHistoryLimit, "limit", 10, "maximum number of leaders to show")
+	return cmd
+}
+
+func electHistoryCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("elect history takes one election name argument"))
+	}
+	if electHistoryLimit <= 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--limit must be positive"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	changes, _, err := concurrency.ElectionHistory(ctx, mustClientFromCmd(cmd), args[0], electHistoryLimit)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.ElectHistory(args[0], changes)
+}
+
 func electCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 && len(args) != 2 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("elect takes one election name argument and an optional proposal argument"))
@@ -64,13 +97,16 @@ func electCommandFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
-func observe(c *clientv3.Client, election string) error {
-	s, err := concurrency.NewSession(c)
+func observe(c *clientv3.Client, election string) (err error) {
+	sctx, sspan := startSpan(traceCtx(), "election.session", attribute.String("etcd.election.name", election))
+	defer func() { endSpan(sspan, err) }()
+	s, err := concurrency.NewSession(c, concurrency.WithContext(sctx))
 	if err != nil {
 		return err
 	}
+	sspan.SetAttributes(attribute.Int64("etcd.lease.id", int64(s.Lease())))
 	e := concurrency.NewElection(s, election)
-	ctx, cancel := context.WithCancel(context.TODO())
+	ctx, cancel := context.WithCancel(sctx)
 
 	donec := make(chan struct{})
 	sigc := make(chan os.Signal, 1)
@@ -80,8 +116,11 @@ func observe(c *clientv3.Client, election string) error {
 		cancel()
 	}()
 
+	octx, ospan := startSpan(ctx, "election.observe")
+	defer ospan.End()
 	go func() {
-		for resp := range e.Observe(ctx) {
+		for resp := range e.Observe(octx) {
+			ospan.AddEvent("leader changed")
 			display.Get(resp)
 		}
 		close(donec)
@@ -98,13 +137,16 @@ func observe(c *clientv3.Client, election string) error {
 	return nil
 }
 
-func campaign(c *clientv3.Client, election string, prop string) error {
-	s, err := concurrency.NewSession(c)
+func campaign(c *clientv3.Client, election string, prop string) (err error) {
+	sctx, sspan := startSpan(traceCtx(), "election.session", attribute.String("etcd.election.name", election))
+	defer func() { endSpan(sspan, err) }()
+	s, err := concurrency.NewSession(c, concurrency.WithContext(sctx))
 	if err != nil {
 		return err
 	}
+	sspan.SetAttributes(attribute.Int64("etcd.lease.id", int64(s.Lease())))
 	e := concurrency.NewElection(s, election)
-	ctx, cancel := context.WithCancel(context.TODO())
+	ctx, cancel := context.WithCancel(sctx)
 
 	donec := make(chan struct{})
 	sigc := make(chan os.Signal, 1)
@@ -115,9 +157,14 @@ func campaign(c *clientv3.Client, election string, prop string) error {
 		close(donec)
 	}()
 
-	if err = e.Campaign(ctx, prop); err != nil {
+	cctx, cspan := startSpan(ctx, "election.campaign")
+	err = e.Campaign(cctx, prop)
+	endSpan(cspan, err)
+	if err != nil {
 		return err
 	}
+	_, lspan := startSpan(sctx, "election.leader", attribute.String("etcd.election.key", e.Key()))
+	defer lspan.End()
 
 	// print key since elected
 	resp, err := c.Get(ctx, e.Key())
@@ -132,5 +179,9 @@ func campaign(c *clientv3.Client, election string, prop string) error {
 		return errors.New("elect: session expired")
 	}
 
-	return e.Resign(context.TODO())
+	lspan.End()
+	rctx, rspan := startSpan(sctx, "election.resign")
+	err = e.Resign(rctx)
+	endSpan(rspan, err)
+	return err
 }
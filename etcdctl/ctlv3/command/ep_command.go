@@ -35,6 +35,7 @@ import (
 var (
 	epClusterEndpoints bool
 	epHashKVRev        int64
+	epConsistencyCheck bool
 )
 
 // NewEndpointCommand returns the cobra command for "endpoint".
@@ -63,14 +64,19 @@ func newEpHealthCommand() *cobra.Command {
 }
 
 func newEpStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	sc := &cobra.Command{
 		Use:   "status",
 		Short: "Prints out the status of endpoints specified in `--endpoints` flag",
 		Long: `When --write-out is set to simple, this command prints out comma-separated status lists for each endpoint.
 The items in the lists are endpoint, ID, version, db size, is leader, is learner, raft term, raft index, raft applied index, errors.
+
+With --consistency-check, this command compares the raft status and the KV hash of the endpoints at the
+lowest revision all of them applied, and prints out the divergences found between the endpoints.
 `,
 		Run: epStatusCommandFunc,
 	}
+	sc.Flags().BoolVar(&epConsistencyCheck, "consistency-check", false, "compare the raft status and the KV hash of the endpoints and report their divergences")
+	return sc
 }
 
 func newEpHashKVCommand() *cobra.Command {
@@ -205,11 +211,108 @@ func epStatusCommandFunc(cmd *cobra.Command, args []string) {
 		statusList = append(statusList, epStatus{Ep: ep, Resp: resp})
 	}
 
+	if epConsistencyCheck {
+		r := checkEndpointConsistency(cmd, cfg, statusList)
+		display.EndpointConsistency(r)
+		if err != nil || len(r.Divergences) > 0 {
+			cobrautl.Exit(cobrautl.ExitError)
+		}
+		return
+	}
+
 	display.EndpointStatus(statusList)
 
 	if err != nil {
-		os.Exit(cobrautl.ExitError)
+		cobrautl.Exit(cobrautl.ExitError)
+	}
+}
+
+// epConsistency is the report of the consistency check of the endpoints.
+type epConsistency struct {
+	// Revision is the revision the KV hashes are compared at, the lowest
+	// revision of the endpoints.
+	Revision  int64             `json:"revision"`
+	Endpoints []epConsistencyEp `json:"endpoints"`
+	// Divergences describe the disagreements between the endpoints.
+	Divergences []string `json:"divergences,omitempty"`
+}
+
+type epConsistencyEp struct {
+	Ep               string `json:"endpoint"`
+	MemberID         uint64 `json:"member_id"`
+	Leader           uint64 `json:"leader"`
+	Revision         int64  `json:"revision"`
+	RaftTerm         uint64 `json:"raft_term"`
+	RaftIndex        uint64 `json:"raft_index"`
+	RaftAppliedIndex uint64 `json:"raft_applied_index"`
+	Hash             uint32 `json:"hash"`
+	CompactRevision  int64  `json:"compact_revision"`
+	Error            string `json:"error,omitempty"`
+}
+
+// checkEndpointConsistency gets the KV hash of the endpoints at the lowest
+// revision of their status, and compares their raft status and hashes.
+func checkEndpointConsistency(cmd *cobra.Command, cfg *clientv3.ConfigSpec, statusList []epStatus) epConsistency {
+	var r epConsistency
+	for _, st := range statusList {
+		if r.Revision == 0 || st.Resp.Header.Revision < r.Revision {
+			r.Revision = st.Resp.Header.Revision
+		}
+	}
+	for _, st := range statusList {
+		e := epConsistencyEp{
+			Ep:               st.Ep,
+			MemberID:         st.Resp.Header.MemberId,
+			Leader:           st.Resp.Leader,
+			Revision:         st.Resp.Header.Revision,
+			RaftTerm:         st.Resp.RaftTerm,
+			RaftIndex:        st.Resp.RaftIndex,
+			RaftAppliedIndex: st.Resp.RaftAppliedIndex,
+		}
+		cfg.Endpoints = []string{st.Ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.HashKV(ctx, st.Ep, r.Revision)
+		cancel()
+		c.Close()
+		if err != nil {
+			e.Error = err.Error()
+			r.Divergences = append(r.Divergences, fmt.Sprintf("failed to get the hash of endpoint %s at revision %d (%v)", st.Ep, r.Revision, err))
+		} else {
+			e.Hash, e.CompactRevision = resp.Hash, resp.CompactRevision
+		}
+		r.Endpoints = append(r.Endpoints, e)
+	}
+	r.Divergences = append(r.Divergences, endpointDivergences(r.Endpoints)...)
+	return r
+}
+
+// endpointDivergences compares the leader, the raft term and the KV hash of
+// the endpoints with the ones of the first endpoint. The hashes are only
+// compared between endpoints at the same compact revision.
+func endpointDivergences(eps []epConsistencyEp) (divergences []string) {
+	if len(eps) == 0 {
+		return nil
+	}
+	ref := eps[0]
+	hashes := make(map[int64]epConsistencyEp)
+	for _, e := range eps {
+		if e.Leader != ref.Leader {
+			divergences = append(divergences, fmt.Sprintf("endpoint %s sees leader %x, endpoint %s sees leader %x", e.Ep, e.Leader, ref.Ep, ref.Leader))
+		}
+		if e.RaftTerm != ref.RaftTerm {
+			divergences = append(divergences, fmt.Sprintf("endpoint %s is at raft term %d, endpoint %s at raft term %d", e.Ep, e.RaftTerm, ref.Ep, ref.RaftTerm))
+		}
+		if e.Error != "" {
+			continue
+		}
+		if h, ok := hashes[e.CompactRevision]; !ok {
+			hashes[e.CompactRevision] = e
+		} else if h.Hash != e.Hash {
+			divergences = append(divergences, fmt.Sprintf("endpoint %s has hash %d, endpoint %s has hash %d at compact revision %d", e.Ep, e.Hash, h.Ep, h.Hash, e.CompactRevision))
+		}
 	}
+	return divergences
 }
 
 type epHashKV struct {
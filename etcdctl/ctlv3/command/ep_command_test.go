@@ -0,0 +1,64 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointDivergences(t *testing.T) {
+	ep := func(name string, term uint64, hash uint32, compactRev int64) epConsistencyEp {
+		return epConsistencyEp{Ep: name, Leader: 1, RaftTerm: term, Hash: hash, CompactRevision: compactRev}
+	}
+	tests := []struct {
+		name string
+		eps  []epConsistencyEp
+		want []string
+	}{
+		{
+			name: "consistent",
+			eps:  []epConsistencyEp{ep("a", 2, 10, 5), ep("b", 2, 10, 5), ep("c", 2, 10, 5)},
+		},
+		{
+			name: "hash mismatch",
+			eps:  []epConsistencyEp{ep("a", 2, 10, 5), ep("b", 2, 10, 5), ep("c", 2, 11, 5)},
+			want: []string{"endpoint c has hash 11, endpoint a has hash 10 at compact revision 5"},
+		},
+		{
+			name: "hashes at different compact revisions are not compared",
+			eps:  []epConsistencyEp{ep("a", 2, 10, 5), ep("b", 2, 11, 7), ep("c", 2, 11, 7)},
+		},
+		{
+			name: "raft term mismatch",
+			eps:  []epConsistencyEp{ep("a", 2, 10, 5), ep("b", 3, 10, 5)},
+			want: []string{"endpoint b is at raft term 3, endpoint a at raft term 2"},
+		},
+		{
+			name: "leader mismatch and failed hash",
+			eps: []epConsistencyEp{
+				ep("a", 2, 10, 5),
+				{Ep: "b", Leader: 2, RaftTerm: 2, Error: "unavailable"},
+			},
+			want: []string{"endpoint b sees leader 2, endpoint a sees leader 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointDivergences(tt.eps))
+		})
+	}
+}
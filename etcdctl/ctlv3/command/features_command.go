@@ -0,0 +1,78 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// NewFeaturesCommand returns the cobra command for "features".
+func NewFeaturesCommand() *cobra.Command {
+	fc := &cobra.Command{
+		Use:   "features <subcommand>",
+		Short: "Feature gate related commands",
+	}
+	fc.PersistentFlags().BoolVar(&epClusterEndpoints, "cluster", false, "use all endpoints from the cluster member list")
+
+	fc.AddCommand(NewFeaturesListCommand())
+
+	return fc
+}
+
+// NewFeaturesListCommand returns the cobra command for "features list".
+func NewFeaturesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the feature gates of the etcd members with given endpoints",
+		Long: `Lists the feature gates of the etcd members with given endpoints.
+For each feature gate, prints whether it is enabled, its maturity level and default,
+whether it is locked to its default, whether it is safe to toggle at runtime, and the
+deprecated experimental flags which set it.
+`,
+		Run: featuresListCommandFunc,
+	}
+}
+
+func featuresListCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("features list command does not accept arguments"))
+	}
+
+	failures := 0
+	cfg := clientConfigFromCmd(cmd)
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.FeatureGates(ctx, ep)
+		cancel()
+		c.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list feature gates of etcd member[%s] (%v)\n", ep, err)
+			failures++
+			continue
+		}
+		display.FeatureGates(ep, *resp)
+	}
+
+	if failures != 0 {
+		cobrautl.Exit(cobrautl.ExitError)
+	}
+}
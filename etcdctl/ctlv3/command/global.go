@@ -32,6 +32,7 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/srv"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/namespace"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 	"go.etcd.io/etcd/pkg/v3/flags"
 )
@@ -45,21 +46,31 @@ type GlobalFlags struct {
 	Endpoints             []string
 	DialTimeout           time.Duration
 	CommandTimeOut        time.Duration
+	CompletionTimeout     time.Duration
 	KeepAliveTime         time.Duration
 	KeepAliveTimeout      time.Duration
 	MaxCallSendMsgSize    int
 	MaxCallRecvMsgSize    int
 	DNSClusterServiceName string
+	CompressionAlgorithm  string
+
+	DiscoverySRVTargetCAs        []string
+	DiscoverySRVVerifyTargetName bool
 
 	TLS transport.TLSInfo
 
 	OutputFormat string
 	IsHex        bool
 
+	Namespace string
+
 	User     string
 	Password string
 
 	Debug bool
+
+	TracingEndpoint    string
+	TracingServiceName string
 }
 
 type discoveryCfg struct {
@@ -70,6 +81,10 @@ type discoveryCfg struct {
 
 var display printer = &simplePrinter{}
 
+// discoveryTargetTLS holds the target specific TLS settings of the endpoints
+// discovered through SRV records.
+var discoveryTargetTLS srv.TargetTLS
+
 func initDisplayFromCmd(cmd *cobra.Command) {
 	isHex, err := cmd.Flags().GetBool("hex")
 	if err != nil {
@@ -132,18 +147,20 @@ func clientConfigFromCmd(cmd *cobra.Command) *clientv3.ConfigSpec {
 	cfg.KeepAliveTimeout = keepAliveTimeoutFromCmd(cmd)
 	cfg.MaxCallSendMsgSize = maxCallSendMsgSizeFromCmd(cmd)
 	cfg.MaxCallRecvMsgSize = maxCallRecvMsgSizeFromCmd(cmd)
+	cfg.CompressionAlgorithm = compressionFromCmd(cmd)
 
 	cfg.Secure = secureCfgFromCmd(cmd)
 	cfg.Auth = authCfgFromCmd(cmd)
 
 	initDisplayFromCmd(cmd)
+	initDiscoveryTargetTLSFromCmd(cmd)
 	return cfg
 }
 
 func mustClientCfgFromCmd(cmd *cobra.Command) *clientv3.Config {
 	cc := clientConfigFromCmd(cmd)
 	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
-	cfg, err := clientv3.NewClientConfig(cc, lg)
+	cfg, err := newClientConfig(cc, lg)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
@@ -152,12 +169,30 @@ func mustClientCfgFromCmd(cmd *cobra.Command) *clientv3.Config {
 
 func mustClientFromCmd(cmd *cobra.Command) *clientv3.Client {
 	cfg := clientConfigFromCmd(cmd)
-	return mustClient(cfg)
+	client := mustClient(cfg)
+	namespaceClientFromCmd(cmd, client)
+	return client
+}
+
+// namespaceClientFromCmd prefixes the keys of the client with --namespace, like
+// the namespace of a grpc proxy does, so the keys given to and printed by the
+// commands are relative to the namespace.
+func namespaceClientFromCmd(cmd *cobra.Command, client *clientv3.Client) {
+	ns, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if ns == "" {
+		return
+	}
+	client.KV = namespace.NewKV(client.KV, ns)
+	client.Watcher = namespace.NewWatcher(client.Watcher, ns)
+	client.Lease = namespace.NewLease(client.Lease, ns)
 }
 
 func mustClient(cc *clientv3.ConfigSpec) *clientv3.Client {
 	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
-	cfg, err := clientv3.NewClientConfig(cc, lg)
+	cfg, err := newClientConfig(cc, lg)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
@@ -170,6 +205,39 @@ func mustClient(cc *clientv3.ConfigSpec) *clientv3.Client {
 	return client
 }
 
+// newClientConfig creates the client configuration, verifying the endpoints
+// discovered through SRV records with their target specific TLS settings.
+func newClientConfig(cc *clientv3.ConfigSpec, lg *zap.Logger) (*clientv3.Config, error) {
+	cfg, err := clientv3.NewClientConfig(cc, lg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DialOptions = append(cfg.DialOptions, traceDialOptions()...)
+	domain := cc.Secure.ServerName
+	if domain == "" || cfg.TLS == nil || !discoveryTargetTLS.Enabled() {
+		return cfg, nil
+	}
+	if cfg.TLS, err = discoveryTargetTLS.ClientConfig(cfg.TLS, domain); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func initDiscoveryTargetTLSFromCmd(cmd *cobra.Command) {
+	targetCAs, err := cmd.Flags().GetStringSlice("discovery-srv-target-cacert")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	discoveryTargetTLS.TrustedCAFiles, err = srv.ParseTargetCAFiles(targetCAs)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	discoveryTargetTLS.VerifyTargetName, err = cmd.Flags().GetBool("discovery-srv-verify-target-name")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
 func argOrStdin(args []string, stdin io.Reader, i int) (string, error) {
 	if i < len(args) {
 		return args[i], nil
@@ -221,6 +289,14 @@ func maxCallRecvMsgSizeFromCmd(cmd *cobra.Command) int {
 	return maxReceiveBytes
 }
 
+func compressionFromCmd(cmd *cobra.Command) string {
+	algorithm, err := cmd.Flags().GetString("compression")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	return algorithm
+}
+
 func secureCfgFromCmd(cmd *cobra.Command) *clientv3.SecureConfig {
 	cert, key, cacert := keyAndCertFromCmd(cmd)
 	insecureTr := insecureTransportFromCmd(cmd)
@@ -0,0 +1,117 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var incrBy int64
+
+// NewIncrCommand returns the cobra command for "incr".
+func NewIncrCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "incr <key> [options]",
+		Short: "Increments the integer value of a key",
+		Long: `Increments the decimal integer value of a key, a key that does not exist counting as 0.
+
+The key is read and updated in a transaction conditioned on its modification revision,
+which is retried until no concurrent update of the key interleaves. The lease of the
+key is kept.
+
+When --write-out is set to simple, this command prints out the value and the
+modification revision of the key after the increment.
+`,
+		Run:               incrCommandFunc,
+		ValidArgsFunction: completeArgs(completeKeys),
+	}
+	cmd.Flags().Int64Var(&incrBy, "by", 1, "amount to increment the value by (may be negative)")
+	return cmd
+}
+
+// incrCommandFunc executes the "incr" command.
+func incrCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("incr command needs 1 argument"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := incr(ctx, mustClientFromCmd(cmd), args[0], incrBy)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.Incr(*resp)
+}
+
+// incr adds n to the integer value of the key, retrying on concurrent updates
+// of the key until the context is done.
+func incr(ctx context.Context, c *clientv3.Client, key string, n int64) (*clientv3.TxnResponse, error) {
+	getResp, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	kvs := getResp.Kvs
+	for {
+		var value, modRev int64
+		var opts []clientv3.OpOption
+		if len(kvs) != 0 {
+			kv := kvs[0]
+			value, err = strconv.ParseInt(string(kv.Value), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("value of key %q is not an integer: %w", key, err)
+			}
+			modRev = kv.ModRevision
+			if kv.Lease != 0 {
+				opts = append(opts, clientv3.WithLease(clientv3.LeaseID(kv.Lease)))
+			}
+		}
+
+		resp, err := c.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRev)).
+			Then(clientv3.OpPut(key, strconv.FormatInt(value+n, 10), opts...), clientv3.OpGet(key)).
+			Else(clientv3.OpGet(key)).
+			Commit()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Succeeded {
+			return resp, nil
+		}
+		// the key was updated concurrently, retry from its current value
+		kvs = txnRangeKvs(resp)
+	}
+}
+
+// txnRangeKvs returns the key-values read by the last range of a transaction.
+func txnRangeKvs(resp *clientv3.TxnResponse) []*mvccpb.KeyValue {
+	for i := len(resp.Responses) - 1; i >= 0; i-- {
+		if r, ok := resp.Responses[i].Response.(*pb.ResponseOp_ResponseRange); ok {
+			return r.ResponseRange.Kvs
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -41,14 +42,17 @@ func NewLeaseCommand() *cobra.Command {
 	return lc
 }
 
+var leaseGrantLabels []string
+
 // NewLeaseGrantCommand returns the cobra command for "lease grant".
 func NewLeaseGrantCommand() *cobra.Command {
 	lc := &cobra.Command{
-		Use:   "grant <ttl>",
+		Use:   "grant <ttl> [options]",
 		Short: "Creates leases",
 
 		Run: leaseGrantCommandFunc,
 	}
+	lc.Flags().StringArrayVar(&leaseGrantLabels, "label", nil, "Label describing the owner of the lease, as key=value (can be repeated)")
 
 	return lc
 }
@@ -64,8 +68,21 @@ func leaseGrantCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad TTL (%w)", err))
 	}
 
+	var opts []v3.LeaseOption
+	if len(leaseGrantLabels) > 0 {
+		labels := make(map[string]string, len(leaseGrantLabels))
+		for _, l := range leaseGrantLabels {
+			k, v, ok := strings.Cut(l, "=")
+			if !ok || k == "" {
+				cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad label %q, expected key=value", l))
+			}
+			labels[k] = v
+		}
+		opts = append(opts, v3.WithLeaseLabels(labels))
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl)
+	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl, opts...)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease (%w)", err))
@@ -132,19 +149,26 @@ func leaseTimeToLiveCommandFunc(cmd *cobra.Command, args []string) {
 	display.TimeToLive(*resp, timeToLiveKeys)
 }
 
+var leaseListDetails bool
+
 // NewLeaseListCommand returns the cobra command for "lease list".
 func NewLeaseListCommand() *cobra.Command {
 	lc := &cobra.Command{
-		Use:   "list",
+		Use:   "list [options]",
 		Short: "List all active leases",
 		Run:   leaseListCommandFunc,
 	}
+	lc.Flags().BoolVar(&leaseListDetails, "details", true, "Get the remaining TTL, the number of attached keys and the labels of the leases")
 	return lc
 }
 
 // leaseListCommandFunc executes the "lease list" command.
 func leaseListCommandFunc(cmd *cobra.Command, args []string) {
-	resp, rerr := mustClientFromCmd(cmd).Leases(context.TODO())
+	var opts []v3.LeaseOption
+	if leaseListDetails {
+		opts = append(opts, v3.WithLeaseDetails())
+	}
+	resp, rerr := mustClientFromCmd(cmd).Leases(context.TODO(), opts...)
 	if rerr != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadConnection, rerr)
 	}
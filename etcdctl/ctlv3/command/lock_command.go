@@ -24,6 +24,7 @@ import (
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
@@ -40,9 +41,56 @@ func NewLockCommand() *cobra.Command {
 		Run:   lockCommandFunc,
 	}
 	c.Flags().IntVarP(&lockTTL, "ttl", "", lockTTL, "timeout for session")
+	c.AddCommand(newLockStatusCommand())
+	c.AddCommand(newLockBreakCommand())
 	return c
 }
 
+func newLockStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <lockname>",
+		Short: "Shows the holder of a named lock and the sessions waiting for it",
+		Run:   lockStatusCommandFunc,
+	}
+}
+
+func newLockBreakCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "break <lockname>",
+		Short: "Releases a named lock on behalf of its holder",
+		Long: `Releases a named lock on behalf of its holder, e.g. a crashed client
+whose session did not expire yet. The next waiting session acquires the lock.
+The holder is not notified and its lease is not revoked.`,
+		Run: lockBreakCommandFunc,
+	}
+}
+
+func lockStatusCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("lock status takes a lock name argument"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	st, err := concurrency.GetLockState(ctx, mustClientFromCmd(cmd), args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.LockStatus(args[0], *st)
+}
+
+func lockBreakCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("lock break takes a lock name argument"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	holder, _, err := concurrency.ForceUnlock(ctx, mustClientFromCmd(cmd), args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.LockBreak(args[0], *holder)
+}
+
 func lockCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) == 0 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("lock takes a lock name argument and an optional command to execute"))
@@ -69,14 +117,17 @@ func getExitCodeFromError(err error) int {
 	return cobrautl.ExitError
 }
 
-func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) error {
-	s, err := concurrency.NewSession(c, concurrency.WithTTL(lockTTL))
+func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) (err error) {
+	sctx, sspan := startSpan(traceCtx(), "lock.session", attribute.String("etcd.lock.name", lockname))
+	defer func() { endSpan(sspan, err) }()
+	s, err := concurrency.NewSession(c, concurrency.WithTTL(lockTTL), concurrency.WithContext(sctx))
 	if err != nil {
 		return err
 	}
+	sspan.SetAttributes(attribute.Int64("etcd.lease.id", int64(s.Lease())))
 
 	m := concurrency.NewMutex(s, lockname)
-	ctx, cancel := context.WithCancel(context.TODO())
+	ctx, cancel := context.WithCancel(sctx)
 
 	// unlock in case of ordinary shutdown
 	donec := make(chan struct{})
@@ -88,16 +139,30 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 		close(donec)
 	}()
 
-	if err := m.Lock(ctx); err != nil {
+	actx, aspan := startSpan(ctx, "lock.acquire")
+	err = m.Lock(actx)
+	endSpan(aspan, err)
+	if err != nil {
+		return err
+	}
+	hctx, hspan := startSpan(sctx, "lock.hold", attribute.String("etcd.lock.key", m.Key()))
+	defer hspan.End()
+	unlock := func() error {
+		hspan.End()
+		rctx, rspan := startSpan(sctx, "lock.release")
+		err := m.Unlock(rctx)
+		endSpan(rspan, err)
 		return err
 	}
 
 	if len(cmdArgs) > 0 {
 		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
 		cmd.Env = append(environLockResponse(m), os.Environ()...)
+		// the command joins the trace of the lock.
+		cmd.Env = append(cmd.Env, traceEnviron(hctx)...)
 		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
 		err := cmd.Run()
-		unlockErr := m.Unlock(context.TODO())
+		unlockErr := unlock()
 		if err != nil {
 			return err
 		}
@@ -115,7 +180,7 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 
 	select {
 	case <-donec:
-		return m.Unlock(context.TODO())
+		return unlock()
 	case <-s.Done():
 	}
 
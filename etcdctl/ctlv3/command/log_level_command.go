@@ -0,0 +1,118 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var logLevelTTL time.Duration
+
+// NewLogLevelCommand returns the cobra command for "log-level".
+func NewLogLevelCommand() *cobra.Command {
+	lc := &cobra.Command{
+		Use:   "log-level <subcommand>",
+		Short: "Log level related commands",
+	}
+	lc.PersistentFlags().BoolVar(&epClusterEndpoints, "cluster", false, "use all endpoints from the cluster member list")
+
+	lc.AddCommand(NewLogLevelSetCommand())
+	lc.AddCommand(NewLogLevelGetCommand())
+
+	return lc
+}
+
+// NewLogLevelSetCommand returns the cobra command for "log-level set".
+func NewLogLevelSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <subsystem>=<level> [<subsystem>=<level>...]",
+		Short: "Sets the log level of subsystems of the etcd members with given endpoints",
+		Long: `Sets the log level of subsystems of the etcd members with given endpoints.
+Subsystems are raft, mvcc, auth and grpc; levels are debug, info, warn and error.
+The level "default" reverts a subsystem to the log level of the member.
+Levels revert automatically after --ttl, unless it is 0.
+`,
+		Run: logLevelSetCommandFunc,
+	}
+	cmd.Flags().DurationVar(&logLevelTTL, "ttl", 10*time.Minute, "time after which the levels revert to the log level of the member, 0 to never revert")
+	return cmd
+}
+
+// NewLogLevelGetCommand returns the cobra command for "log-level get".
+func NewLogLevelGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Lists the log level of subsystems of the etcd members with given endpoints",
+		Run:   logLevelGetCommandFunc,
+	}
+}
+
+func logLevelSetCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("log-level set command needs at least one <subsystem>=<level> argument"))
+	}
+	if logLevelTTL < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--ttl must not be negative"))
+	}
+	levels := make(map[string]string, len(args))
+	for _, arg := range args {
+		subsystem, level, ok := strings.Cut(arg, "=")
+		if !ok || subsystem == "" || level == "" {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid log level %q, expected <subsystem>=<level>", arg))
+		}
+		if level == "default" {
+			level = ""
+		}
+		levels[subsystem] = level
+	}
+	logLevelSet(cmd, levels, logLevelTTL)
+}
+
+func logLevelGetCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("log-level get command does not accept arguments"))
+	}
+	logLevelSet(cmd, nil, 0)
+}
+
+func logLevelSet(cmd *cobra.Command, levels map[string]string, ttl time.Duration) {
+	failures := 0
+	cfg := clientConfigFromCmd(cmd)
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.LogLevelSet(ctx, ep, levels, ttl)
+		cancel()
+		c.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set log level of etcd member[%s] (%v)\n", ep, err)
+			failures++
+			continue
+		}
+		display.LogLevel(ep, *resp)
+	}
+
+	if failures != 0 {
+		cobrautl.Exit(cobrautl.ExitError)
+	}
+}
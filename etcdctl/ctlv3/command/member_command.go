@@ -15,13 +15,18 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
@@ -30,8 +35,16 @@ var (
 	memberPeerURLs    string
 	isLearner         bool
 	memberConsistency string
+
+	memberAutoPromote bool
+	memberWait        bool
+	memberWaitTimeout time.Duration
 )
 
+// memberWaitInterval is the interval at which "member add --wait" polls the
+// progress of the new member.
+var memberWaitInterval = time.Second
+
 // NewMemberCommand returns the cobra command for "member".
 func NewMemberCommand() *cobra.Command {
 	mc := &cobra.Command{
@@ -44,6 +57,7 @@ func NewMemberCommand() *cobra.Command {
 	mc.AddCommand(NewMemberUpdateCommand())
 	mc.AddCommand(NewMemberListCommand())
 	mc.AddCommand(NewMemberPromoteCommand())
+	mc.AddCommand(NewMemberDrainCommand())
 
 	return mc
 }
@@ -59,6 +73,10 @@ func NewMemberAddCommand() *cobra.Command {
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
 	cc.Flags().BoolVar(&isLearner, "learner", false, "indicates if the new member is raft learner")
+	cc.Flags().BoolVar(&isLearner, "as-learner", false, "alias of --learner")
+	cc.Flags().BoolVar(&memberAutoPromote, "auto-promote", false, "promote the new learner member once it has caught up with the leader. Requires --learner and --wait")
+	cc.Flags().BoolVar(&memberWait, "wait", false, "wait until the new member has started, and has been promoted with --auto-promote, before returning")
+	cc.Flags().DurationVar(&memberWaitTimeout, "wait-timeout", 10*time.Minute, "timeout for --wait")
 
 	return cc
 }
@@ -69,7 +87,8 @@ func NewMemberRemoveCommand() *cobra.Command {
 		Use:   "remove <memberID>",
 		Short: "Removes a member from the cluster",
 
-		Run: memberRemoveCommandFunc,
+		Run:               memberRemoveCommandFunc,
+		ValidArgsFunction: completeArgs(completeMemberIDs),
 	}
 
 	return cc
@@ -81,7 +100,8 @@ func NewMemberUpdateCommand() *cobra.Command {
 		Use:   "update <memberID> [options]",
 		Short: "Updates a member in the cluster",
 
-		Run: memberUpdateCommandFunc,
+		Run:               memberUpdateCommandFunc,
+		ValidArgsFunction: completeArgs(completeMemberIDs),
 	}
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the updated member.")
@@ -114,7 +134,26 @@ func NewMemberPromoteCommand() *cobra.Command {
 		Long: `Promotes a non-voting learner member to a voting one in the cluster.
 `,
 
-		Run: memberPromoteCommandFunc,
+		Run:               memberPromoteCommandFunc,
+		ValidArgsFunction: completeArgs(completeMemberIDs),
+	}
+
+	return cc
+}
+
+// NewMemberDrainCommand returns the cobra command for "member drain".
+func NewMemberDrainCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "drain <memberID>",
+		Short: "Drains a member in the cluster for maintenance",
+		Long: `Drains a member before it is shut down for maintenance. The member stops accepting
+new client connections, transfers its leadership if it is the leader, and closes its watch
+and lease keepalive streams so that clients fail over to other members.
+The member is ready for shutdown once the command returns.
+`,
+
+		Run:               memberDrainCommandFunc,
+		ValidArgsFunction: completeArgs(completeMemberIDs),
 	}
 
 	return cc
@@ -139,6 +178,9 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(memberPeerURLs) == 0 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("member peer urls not provided"))
 	}
+	if memberAutoPromote && (!isLearner || !memberWait) {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--auto-promote requires --learner and --wait"))
+	}
 
 	urls := strings.Split(memberPeerURLs, ",")
 	ctx, cancel := commandCtx(cmd)
@@ -178,6 +220,131 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 		fmt.Printf("ETCD_INITIAL_ADVERTISE_PEER_URLS=%q\n", memberPeerURLs)
 		fmt.Print("ETCD_INITIAL_CLUSTER_STATE=\"existing\"\n")
 	}
+
+	if !memberWait {
+		return
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), memberWaitTimeout)
+	promoteResp, err := waitMemberReady(ctx, cli, newID, memberAutoPromote, memberWaitInterval)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if promoteResp != nil {
+		display.MemberPromote(newID, *promoteResp)
+	}
+}
+
+// memberDrainCommandFunc executes the "member drain" command.
+func memberDrainCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+	}
+
+	id, err := strconv.ParseUint(args[0], 16, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+	}
+
+	cli := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	resp, err := cli.MemberList(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	m := findMember(resp.Members, id)
+	if m == nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member %x not found", id))
+	}
+	if len(m.ClientURLs) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("member %x has not started", id))
+	}
+
+	ctx, cancel = commandCtx(cmd)
+	dresp, err := cli.Drain(ctx, m.ClientURLs[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.MemberDrain(id, *dresp)
+}
+
+// memberWaitClient is the subset of the client used to wait for a new member.
+type memberWaitClient interface {
+	MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error)
+	MemberPromote(ctx context.Context, id uint64) (*clientv3.MemberPromoteResponse, error)
+	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+}
+
+// waitMemberReady polls the cluster until the member with the given ID has
+// started. If promote is set, it then keeps trying to promote the member until
+// the learner has caught up with the leader, and returns the promote response.
+func waitMemberReady(ctx context.Context, c memberWaitClient, id uint64, promote bool, interval time.Duration) (*clientv3.MemberPromoteResponse, error) {
+	for {
+		resp, err := c.MemberList(ctx)
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "failed to list members: %v\n", err)
+		}
+		if err == nil {
+			m := findMember(resp.Members, id)
+			if m == nil {
+				return nil, fmt.Errorf("member %x is no longer part of the cluster", id)
+			}
+			switch {
+			case len(m.Name) == 0:
+				fmt.Fprintf(os.Stderr, "waiting for member %x to start\n", id)
+			case !promote:
+				return nil, nil
+			case !m.IsLearner:
+				return nil, fmt.Errorf("member %x is not a learner", id)
+			default:
+				reportLearnerProgress(ctx, c, resp.Members, m)
+				presp, perr := c.MemberPromote(ctx, id)
+				if perr == nil {
+					return presp, nil
+				}
+				if !errors.Is(perr, rpctypes.ErrMemberLearnerNotReady) && ctx.Err() == nil {
+					return nil, perr
+				}
+			}
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for member %x: %w", id, ctx.Err())
+		}
+	}
+}
+
+// reportLearnerProgress prints how far the learner lags behind its leader.
+func reportLearnerProgress(ctx context.Context, c memberWaitClient, members []*pb.Member, learner *pb.Member) {
+	if len(learner.ClientURLs) == 0 {
+		return
+	}
+	lresp, err := c.Status(ctx, learner.ClientURLs[0])
+	if err != nil {
+		return
+	}
+	leader := findMember(members, lresp.Leader)
+	if leader == nil || len(leader.ClientURLs) == 0 {
+		return
+	}
+	resp, err := c.Status(ctx, leader.ClientURLs[0])
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "waiting for learner %x to catch up with leader %x (raft index %d/%d)\n", learner.ID, leader.ID, lresp.RaftIndex, resp.RaftIndex)
+}
+
+func findMember(members []*pb.Member, id uint64) *pb.Member {
+	for _, m := range members {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
 }
 
 // memberRemoveCommandFunc executes the "member remove" command.
@@ -0,0 +1,84 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeMemberWaitClient starts the learner after startAfter member lists and
+// lets it be promoted after readyAfter promote attempts.
+type fakeMemberWaitClient struct {
+	startAfter, readyAfter int
+	lists, promotes        int
+}
+
+func (c *fakeMemberWaitClient) MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
+	c.lists++
+	learner := &pb.Member{ID: 2, IsLearner: true}
+	if c.lists > c.startAfter {
+		learner.Name = "learner"
+		learner.ClientURLs = []string{"http://learner:2379"}
+	}
+	return &clientv3.MemberListResponse{Members: []*pb.Member{
+		{ID: 1, Name: "leader", ClientURLs: []string{"http://leader:2379"}},
+		learner,
+	}}, nil
+}
+
+func (c *fakeMemberWaitClient) MemberPromote(ctx context.Context, id uint64) (*clientv3.MemberPromoteResponse, error) {
+	c.promotes++
+	if c.promotes <= c.readyAfter {
+		return nil, rpctypes.ErrMemberLearnerNotReady
+	}
+	return &clientv3.MemberPromoteResponse{Header: &pb.ResponseHeader{}}, nil
+}
+
+func (c *fakeMemberWaitClient) Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error) {
+	return &clientv3.StatusResponse{Leader: 1, RaftIndex: 10}, nil
+}
+
+func TestWaitMemberReady(t *testing.T) {
+	ctx := context.Background()
+
+	c := &fakeMemberWaitClient{startAfter: 2}
+	resp, err := waitMemberReady(ctx, c, 2, false, time.Millisecond)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Equal(t, 3, c.lists)
+	require.Zero(t, c.promotes)
+
+	c = &fakeMemberWaitClient{startAfter: 1, readyAfter: 3}
+	resp, err = waitMemberReady(ctx, c, 2, true, time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, 4, c.promotes)
+
+	_, err = waitMemberReady(ctx, &fakeMemberWaitClient{}, 3, true, time.Millisecond)
+	require.ErrorContains(t, err, "no longer part of the cluster")
+
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = waitMemberReady(tctx, &fakeMemberWaitClient{readyAfter: 1 << 30}, 2, true, time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -17,6 +17,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,6 +25,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
@@ -33,6 +35,8 @@ type printer interface {
 	Put(v3.PutResponse)
 	Txn(v3.TxnResponse)
 	Watch(v3.WatchResponse)
+	CAS(v3.TxnResponse)
+	Incr(v3.TxnResponse)
 
 	Grant(r v3.LeaseGrantResponse)
 	Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)
@@ -44,12 +48,26 @@ type printer interface {
 	MemberRemove(id uint64, r v3.MemberRemoveResponse)
 	MemberUpdate(id uint64, r v3.MemberUpdateResponse)
 	MemberPromote(id uint64, r v3.MemberPromoteResponse)
+	MemberDrain(id uint64, r v3.DrainResponse)
 	MemberList(v3.MemberListResponse)
 
+	ClusterMetadataPut(key string, r v3.ClusterMetadataPutResponse)
+	ClusterMetadataDelete(key string, r v3.ClusterMetadataDeleteResponse)
+	ClusterMetadataList(r v3.ClusterMetadataListResponse)
+
+	ValuePolicyPut(prefix string, r v3.ValuePolicyResponse)
+	ValuePolicyDelete(prefix string, r v3.ValuePolicyResponse)
+	ValuePolicyList(r v3.ValuePolicyResponse)
+
 	EndpointHealth([]epHealth)
 	EndpointStatus([]epStatus)
 	EndpointHashKV([]epHashKV)
+	EndpointConsistency(epConsistency)
 	MoveLeader(leader, target uint64, r v3.MoveLeaderResponse)
+	Config(endpoint string, r v3.ConfigSetResponse)
+	LogLevel(endpoint string, r v3.LogLevelSetResponse)
+	Scrub(endpoint string, r v3.ScrubResponse)
+	FeatureGates(endpoint string, r v3.FeatureGatesResponse)
 
 	DowngradeValidate(r v3.DowngradeResponse)
 	DowngradeEnable(r v3.DowngradeResponse)
@@ -73,6 +91,13 @@ type printer interface {
 	UserDelete(user string, r v3.AuthUserDeleteResponse)
 
 	AuthStatus(r v3.AuthStatusResponse)
+
+	CheckPerf(r checkPerfResult)
+	CheckDatascale(r checkDatascaleResult)
+
+	LockStatus(name string, s concurrency.LockState)
+	LockBreak(name string, holder concurrency.LockContender)
+	ElectHistory(name string, changes []concurrency.LeaderChange)
 }
 
 func NewPrinter(printerType string, isHex bool) printer {
@@ -101,6 +126,8 @@ func (p *printerRPC) Get(r v3.GetResponse)     { p.p((*pb.RangeResponse)(&r)) }
 func (p *printerRPC) Put(r v3.PutResponse)     { p.p((*pb.PutResponse)(&r)) }
 func (p *printerRPC) Txn(r v3.TxnResponse)     { p.p((*pb.TxnResponse)(&r)) }
 func (p *printerRPC) Watch(r v3.WatchResponse) { p.p(&r) }
+func (p *printerRPC) CAS(r v3.TxnResponse)     { p.p((*pb.TxnResponse)(&r)) }
+func (p *printerRPC) Incr(r v3.TxnResponse)    { p.p((*pb.TxnResponse)(&r)) }
 
 func (p *printerRPC) Grant(r v3.LeaseGrantResponse)                      { p.p(r) }
 func (p *printerRPC) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)     { p.p(r) }
@@ -120,8 +147,50 @@ func (p *printerRPC) MemberUpdate(id uint64, r v3.MemberUpdateResponse) {
 func (p *printerRPC) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	p.p((*pb.MemberPromoteResponse)(&r))
 }
+
+func (p *printerRPC) MemberDrain(id uint64, r v3.DrainResponse) {
+	p.p((*pb.DrainResponse)(&r))
+}
 func (p *printerRPC) MemberList(r v3.MemberListResponse) { p.p((*pb.MemberListResponse)(&r)) }
-func (p *printerRPC) Alarm(r v3.AlarmResponse)           { p.p((*pb.AlarmResponse)(&r)) }
+func (p *printerRPC) ClusterMetadataPut(_ string, r v3.ClusterMetadataPutResponse) {
+	p.p((*pb.ClusterMetadataPutResponse)(&r))
+}
+
+func (p *printerRPC) ClusterMetadataDelete(_ string, r v3.ClusterMetadataDeleteResponse) {
+	p.p((*pb.ClusterMetadataDeleteResponse)(&r))
+}
+
+func (p *printerRPC) ClusterMetadataList(r v3.ClusterMetadataListResponse) {
+	p.p((*pb.ClusterMetadataListResponse)(&r))
+}
+
+func (p *printerRPC) ValuePolicyPut(_ string, r v3.ValuePolicyResponse) {
+	p.p((*pb.ValuePolicyResponse)(&r))
+}
+
+func (p *printerRPC) ValuePolicyDelete(_ string, r v3.ValuePolicyResponse) {
+	p.p((*pb.ValuePolicyResponse)(&r))
+}
+
+func (p *printerRPC) ValuePolicyList(r v3.ValuePolicyResponse) {
+	p.p((*pb.ValuePolicyResponse)(&r))
+}
+func (p *printerRPC) Alarm(r v3.AlarmResponse) { p.p((*pb.AlarmResponse)(&r)) }
+func (p *printerRPC) Config(endpoint string, r v3.ConfigSetResponse) {
+	p.p((*pb.ConfigSetResponse)(&r))
+}
+func (p *printerRPC) LogLevel(endpoint string, r v3.LogLevelSetResponse) {
+	p.p((*pb.LogLevelSetResponse)(&r))
+}
+
+func (p *printerRPC) Scrub(endpoint string, r v3.ScrubResponse) {
+	p.p((*pb.ScrubResponse)(&r))
+}
+
+func (p *printerRPC) FeatureGates(endpoint string, r v3.FeatureGatesResponse) {
+	p.p((*pb.FeatureGatesResponse)(&r))
+}
+
 func (p *printerRPC) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	p.p((*pb.MoveLeaderResponse)(&r))
 }
@@ -177,6 +246,15 @@ func (p *printerUnsupported) EndpointHealth([]epHealth) { p.p(nil) }
 func (p *printerUnsupported) EndpointStatus([]epStatus) { p.p(nil) }
 func (p *printerUnsupported) EndpointHashKV([]epHashKV) { p.p(nil) }
 
+func (p *printerUnsupported) EndpointConsistency(epConsistency) { p.p(nil) }
+
+func (p *printerUnsupported) CheckPerf(checkPerfResult)           { p.p(nil) }
+func (p *printerUnsupported) CheckDatascale(checkDatascaleResult) { p.p(nil) }
+
+func (p *printerUnsupported) LockStatus(string, concurrency.LockState)        { p.p(nil) }
+func (p *printerUnsupported) LockBreak(string, concurrency.LockContender)     { p.p(nil) }
+func (p *printerUnsupported) ElectHistory(string, []concurrency.LeaderChange) { p.p(nil) }
+
 func (p *printerUnsupported) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) { p.p(nil) }
 func (p *printerUnsupported) DowngradeValidate(r v3.DowngradeResponse)                  { p.p(nil) }
 func (p *printerUnsupported) DowngradeEnable(r v3.DowngradeResponse)                    { p.p(nil) }
@@ -205,6 +283,64 @@ func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string
 	return hdr, rows
 }
 
+func makeClusterMetadataTable(r v3.ClusterMetadataListResponse) (hdr []string, rows [][]string) {
+	hdr = []string{"key", "value"}
+	for _, e := range r.Entries {
+		rows = append(rows, []string{e.Key, e.Value})
+	}
+	return hdr, rows
+}
+
+func makeValuePolicyTable(r v3.ValuePolicyResponse) (hdr []string, rows [][]string) {
+	hdr = []string{"prefix", "max value size", "content type", "key pattern"}
+	for _, p := range r.Policies {
+		rows = append(rows, []string{
+			string(p.Prefix),
+			strconv.FormatInt(p.MaxValueSize, 10),
+			p.ContentType.String(),
+			p.KeyPattern,
+		})
+	}
+	return hdr, rows
+}
+
+// formatClusterMetadata formats the entries as key=value pairs.
+func formatClusterMetadata(entries []*pb.ClusterMetadataEntry) string {
+	kvs := make([]string, len(entries))
+	for i, e := range entries {
+		kvs[i] = e.Key + "=" + e.Value
+	}
+	return strings.Join(kvs, ",")
+}
+
+func makeLeasesTable(r v3.LeaseLeasesResponse) (hdr []string, rows [][]string) {
+	hdr = []string{"ID", "TTL", "granted TTL", "keys", "labels"}
+	for _, l := range r.Leases {
+		rows = append(rows, []string{
+			fmt.Sprintf("%016x", l.ID),
+			fmt.Sprint(l.TTL),
+			fmt.Sprint(l.GrantedTTL),
+			fmt.Sprint(l.Keys),
+			formatLeaseLabels(l.Labels),
+		})
+	}
+	return hdr, rows
+}
+
+// formatLeaseLabels formats the labels as key=value pairs sorted by key.
+func formatLeaseLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]string, len(keys))
+	for i, k := range keys {
+		kvs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(kvs, ",")
+}
+
 func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "health", "took", "error"}
 	for _, h := range healthList {
@@ -221,7 +357,7 @@ func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]stri
 func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]string) {
 	hdr = []string{
 		"endpoint", "ID", "version", "storage version", "db size", "in use", "percentage not in use", "quota", "is leader", "is learner", "raft term",
-		"raft index", "raft applied index", "errors", "downgrade target version", "downgrade enabled",
+		"raft index", "raft applied index", "errors", "downgrade target version", "downgrade enabled", "cluster metadata",
 	}
 	for _, status := range statusList {
 		rows = append(rows, []string{
@@ -241,11 +377,40 @@ func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]stri
 			fmt.Sprint(strings.Join(status.Resp.Errors, ", ")),
 			status.Resp.DowngradeInfo.GetTargetVersion(),
 			strconv.FormatBool(status.Resp.DowngradeInfo.GetEnabled()),
+			formatClusterMetadata(status.Resp.ClusterMetadata),
 		})
 	}
 	return hdr, rows
 }
 
+func makeEndpointConsistencyTable(r epConsistency) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "ID", "revision", "raft term", "raft index", "raft applied index", "hash", "compact revision", "errors"}
+	for _, e := range r.Endpoints {
+		rows = append(rows, []string{
+			e.Ep,
+			fmt.Sprintf("%x", e.MemberID),
+			fmt.Sprint(e.Revision),
+			fmt.Sprint(e.RaftTerm),
+			fmt.Sprint(e.RaftIndex),
+			fmt.Sprint(e.RaftAppliedIndex),
+			fmt.Sprint(e.Hash),
+			fmt.Sprint(e.CompactRevision),
+			e.Error,
+		})
+	}
+	return hdr, rows
+}
+
+func printEndpointDivergences(r epConsistency) {
+	if len(r.Divergences) == 0 {
+		fmt.Printf("all endpoints are consistent at revision %d\n", r.Revision)
+		return
+	}
+	for _, d := range r.Divergences {
+		fmt.Printf("divergence: %s\n", d)
+	}
+}
+
 func makeEndpointHashKVTable(hashList []epHashKV) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "hash", "hash_revision"}
 	for _, h := range hashList {
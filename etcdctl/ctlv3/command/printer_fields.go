@@ -82,6 +82,9 @@ func (p *fieldsPrinter) Put(r v3.PutResponse) {
 	}
 }
 
+func (p *fieldsPrinter) CAS(r v3.TxnResponse)  { p.Txn(r) }
+func (p *fieldsPrinter) Incr(r v3.TxnResponse) { p.Txn(r) }
+
 func (p *fieldsPrinter) Txn(r v3.TxnResponse) {
 	p.hdr(r.Header)
 	fmt.Println(`"Succeeded" :`, r.Succeeded)
@@ -156,6 +159,15 @@ func (p *fieldsPrinter) Leases(r v3.LeaseLeasesResponse) {
 		} else {
 			fmt.Println(`"ID" :`, item.ID)
 		}
+		if item.GrantedTTL == 0 {
+			continue
+		}
+		fmt.Println(`"TTL" :`, item.TTL)
+		fmt.Println(`"GrantedTTL" :`, item.GrantedTTL)
+		fmt.Println(`"Keys" :`, item.Keys)
+		if len(item.Labels) > 0 {
+			fmt.Printf("\"Labels\" : %q\n", formatLeaseLabels(item.Labels))
+		}
 	}
 }
 
@@ -205,6 +217,7 @@ func (p *fieldsPrinter) EndpointStatus(eps []epStatus) {
 		fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
 		fmt.Printf("\"DowngradeTargetVersion\" : %q\n", ep.Resp.DowngradeInfo.GetTargetVersion())
 		fmt.Println(`"DowngradeEnabled" :`, ep.Resp.DowngradeInfo.GetEnabled())
+		fmt.Printf("\"ClusterMetadata\" : %q\n", formatClusterMetadata(ep.Resp.ClusterMetadata))
 		fmt.Println()
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
 type jsonPrinter struct {
@@ -40,6 +41,17 @@ func (p *jsonPrinter) EndpointHealth(r []epHealth) { printJSON(r) }
 func (p *jsonPrinter) EndpointStatus(r []epStatus) { printJSON(r) }
 func (p *jsonPrinter) EndpointHashKV(r []epHashKV) { printJSON(r) }
 
+func (p *jsonPrinter) EndpointConsistency(r epConsistency) { printJSON(r) }
+
+func (p *jsonPrinter) CheckPerf(r checkPerfResult)           { printJSON(r) }
+func (p *jsonPrinter) CheckDatascale(r checkDatascaleResult) { printJSON(r) }
+
+func (p *jsonPrinter) LockStatus(name string, s concurrency.LockState)         { printJSON(s) }
+func (p *jsonPrinter) LockBreak(name string, holder concurrency.LockContender) { printJSON(holder) }
+func (p *jsonPrinter) ElectHistory(name string, changes []concurrency.LeaderChange) {
+	printJSON(changes)
+}
+
 func (p *jsonPrinter) MemberList(r clientv3.MemberListResponse) {
 	if p.isHex {
 		printMemberListWithHexJSON(r)
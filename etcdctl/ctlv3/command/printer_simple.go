@@ -17,11 +17,14 @@ package command
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
 const rootRole = "root"
@@ -51,6 +54,28 @@ func (s *simplePrinter) Put(r v3.PutResponse) {
 	}
 }
 
+func (s *simplePrinter) CAS(resp v3.TxnResponse) {
+	if resp.Succeeded {
+		fmt.Println("OK")
+	} else {
+		fmt.Println("FAILED")
+	}
+	s.txnKeyRevision(resp)
+}
+
+func (s *simplePrinter) Incr(resp v3.TxnResponse) {
+	s.txnKeyRevision(resp)
+}
+
+// txnKeyRevision prints the value and the modification revision of the key
+// read last by the transaction, if it exists.
+func (s *simplePrinter) txnKeyRevision(resp v3.TxnResponse) {
+	for _, kv := range txnRangeKvs(&resp) {
+		printKV(s.isHex, true, kv)
+		fmt.Println(kv.ModRevision)
+	}
+}
+
 func (s *simplePrinter) Txn(resp v3.TxnResponse) {
 	if resp.Succeeded {
 		fmt.Println("SUCCESS")
@@ -115,7 +140,16 @@ func (s *simplePrinter) TimeToLive(resp v3.LeaseTimeToLiveResponse, keys bool) {
 func (s *simplePrinter) Leases(resp v3.LeaseLeasesResponse) {
 	fmt.Printf("found %d leases\n", len(resp.Leases))
 	for _, item := range resp.Leases {
-		fmt.Printf("%016x\n", item.ID)
+		// servers not returning details always grant a positive TTL.
+		if item.GrantedTTL == 0 {
+			fmt.Printf("%016x\n", item.ID)
+			continue
+		}
+		txt := fmt.Sprintf("%016x granted with TTL(%ds), remaining(%ds), attached keys(%d)", item.ID, item.GrantedTTL, item.TTL, item.Keys)
+		if len(item.Labels) > 0 {
+			txt += fmt.Sprintf(", labels(%s)", formatLeaseLabels(item.Labels))
+		}
+		fmt.Println(txt)
 	}
 }
 
@@ -145,6 +179,86 @@ func (s *simplePrinter) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	fmt.Printf("Member %16x promoted in cluster %16x\n", id, r.Header.ClusterId)
 }
 
+func (s *simplePrinter) MemberDrain(id uint64, r v3.DrainResponse) {
+	if r.LeaderTransferred {
+		fmt.Printf("Member %16x transferred its leadership\n", id)
+	}
+	fmt.Printf("Member %16x drained in cluster %16x and is ready for shutdown\n", id, r.Header.ClusterId)
+}
+
+func (s *simplePrinter) Config(endpoint string, r v3.ConfigSetResponse) {
+	for _, setting := range r.Settings {
+		fmt.Printf("%s, %s=%s\n", endpoint, setting.Name, setting.Value)
+	}
+}
+
+func (s *simplePrinter) LogLevel(endpoint string, r v3.LogLevelSetResponse) {
+	for _, l := range r.Levels {
+		if l.Ttl > 0 {
+			fmt.Printf("%s, %s=%s (reverts in %v)\n", endpoint, l.Subsystem, l.Level, time.Duration(l.Ttl)*time.Second)
+			continue
+		}
+		fmt.Printf("%s, %s=%s\n", endpoint, l.Subsystem, l.Level)
+	}
+}
+
+func (s *simplePrinter) Scrub(endpoint string, r v3.ScrubResponse) {
+	fmt.Printf("%s, %s, %d bytes read, %d revisions checked, %d problems found\n",
+		endpoint, r.State, r.BytesRead, r.RevisionsChecked, r.FindingsCount)
+	for _, f := range r.Findings {
+		fmt.Printf("%s, %s\n", endpoint, f)
+	}
+	if r.Error != "" {
+		fmt.Printf("%s, error: %s\n", endpoint, r.Error)
+	}
+}
+
+func (s *simplePrinter) FeatureGates(endpoint string, r v3.FeatureGatesResponse) {
+	for _, f := range r.Features {
+		attrs := []string{f.Stage.String(), fmt.Sprintf("default=%t", f.DefaultEnabled)}
+		if f.Locked {
+			attrs = append(attrs, "locked")
+		}
+		if f.Runtime {
+			attrs = append(attrs, "runtime")
+		}
+		for _, flag := range f.ExperimentalFlags {
+			attrs = append(attrs, "--"+flag)
+		}
+		fmt.Printf("%s, %s=%t (%s)\n", endpoint, f.Name, f.Enabled, strings.Join(attrs, ", "))
+	}
+}
+
+func (s *simplePrinter) ClusterMetadataPut(key string, r v3.ClusterMetadataPutResponse) {
+	fmt.Printf("Cluster metadata %q set in cluster %16x\n", key, r.Header.ClusterId)
+}
+
+func (s *simplePrinter) ClusterMetadataDelete(key string, r v3.ClusterMetadataDeleteResponse) {
+	fmt.Printf("Cluster metadata %q deleted from cluster %16x\n", key, r.Header.ClusterId)
+}
+
+func (s *simplePrinter) ClusterMetadataList(r v3.ClusterMetadataListResponse) {
+	_, rows := makeClusterMetadataTable(r)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) ValuePolicyPut(prefix string, r v3.ValuePolicyResponse) {
+	fmt.Printf("Value policy of prefix %q set\n", prefix)
+}
+
+func (s *simplePrinter) ValuePolicyDelete(prefix string, r v3.ValuePolicyResponse) {
+	fmt.Printf("Value policy of prefix %q deleted\n", prefix)
+}
+
+func (s *simplePrinter) ValuePolicyList(r v3.ValuePolicyResponse) {
+	_, rows := makeValuePolicyTable(r)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) MemberList(resp v3.MemberListResponse) {
 	_, rows := makeMemberListTable(resp)
 	for _, row := range rows {
@@ -176,6 +290,14 @@ func (s *simplePrinter) EndpointHashKV(hashList []epHashKV) {
 	}
 }
 
+func (s *simplePrinter) EndpointConsistency(r epConsistency) {
+	_, rows := makeEndpointConsistencyTable(r)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+	printEndpointDivergences(r)
+}
+
 func (s *simplePrinter) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	fmt.Printf("Leadership transferred from %s to %s\n", types.ID(leader), types.ID(target))
 }
@@ -308,3 +430,92 @@ func (s *simplePrinter) AuthStatus(r v3.AuthStatusResponse) {
 	fmt.Println("Authentication Status:", r.Enabled)
 	fmt.Println("AuthRevision:", r.AuthRevision)
 }
+
+func (s *simplePrinter) CheckPerf(r checkPerfResult) {
+	printCheckErrors(r.Errors)
+	for _, c := range r.Criteria {
+		switch c.Name {
+		case checkCriterionThroughput:
+			if c.Pass {
+				fmt.Printf("PASS: Throughput is %d writes/s\n", int(c.Value)+1)
+			} else {
+				fmt.Printf("FAIL: Throughput too low: %d writes/s\n", int(c.Value)+1)
+			}
+		case checkCriterionSlowest:
+			if c.Pass {
+				fmt.Printf("PASS: Slowest request took %fs\n", c.Value)
+			} else {
+				fmt.Printf("FAIL: Slowest request took too long: %fs\n", c.Value)
+			}
+		case checkCriterionStddev:
+			if c.Pass {
+				fmt.Printf("PASS: Stddev is %fs\n", c.Value)
+			} else {
+				fmt.Printf("FAIL: Stddev too high: %fs\n", c.Value)
+			}
+		}
+	}
+	if r.Pass {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+	}
+}
+
+func (s *simplePrinter) CheckDatascale(r checkDatascaleResult) {
+	printCheckErrors(r.Errors)
+	mb := strconv.FormatFloat(r.MemoryMiB, 'f', 2, 64)
+	for _, c := range r.Criteria {
+		if c.Name == checkCriterionMemory && !c.Pass {
+			fmt.Printf("FAIL: Approximate system memory used : %v MB exceeds %v MB.\n", mb, c.Threshold)
+			return
+		}
+	}
+	if r.Pass {
+		fmt.Printf("PASS: Approximate system memory used : %v MB.\n", mb)
+	}
+}
+
+func printCheckErrors(errs map[string]int) {
+	if len(errs) == 0 {
+		return
+	}
+	fmt.Println("FAIL: too many errors")
+	for k, v := range errs {
+		fmt.Printf("FAIL: ERROR(%v) -> %d\n", k, v)
+	}
+}
+
+func (s *simplePrinter) LockStatus(name string, st concurrency.LockState) {
+	if st.Holder == nil {
+		fmt.Printf("lock %q is not held\n", name)
+		return
+	}
+	fmt.Printf("lock %q held by %s\n", name, describeLockContender(*st.Holder))
+	for i, w := range st.Waiters {
+		fmt.Printf("waiter %d: %s\n", i+1, describeLockContender(w))
+	}
+}
+
+func (s *simplePrinter) LockBreak(name string, holder concurrency.LockContender) {
+	fmt.Printf("lock %q released from %s\n", name, describeLockContender(holder))
+}
+
+func (s *simplePrinter) ElectHistory(name string, changes []concurrency.LeaderChange) {
+	if len(changes) == 0 {
+		fmt.Printf("election %q has no leader\n", name)
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("epoch %d, elected at revision %d: %s\n", c.Epoch(), c.Revision, string(c.Kv.Key))
+		fmt.Println(string(c.Kv.Value))
+	}
+}
+
+func describeLockContender(c concurrency.LockContender) string {
+	ttl := fmt.Sprintf("remaining(%ds)", c.TTL)
+	if c.TTL == -1 {
+		ttl = "expired"
+	}
+	return fmt.Sprintf("%s (lease %016x %s, since revision %d)", c.Key, c.Lease, ttl, c.CreateRevision)
+}
@@ -35,6 +35,39 @@ func (tp *tablePrinter) MemberList(r v3.MemberListResponse) {
 	table.Render()
 }
 
+func (tp *tablePrinter) ClusterMetadataList(r v3.ClusterMetadataListResponse) {
+	hdr, rows := makeClusterMetadataTable(r)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.Render()
+}
+
+func (tp *tablePrinter) ValuePolicyList(r v3.ValuePolicyResponse) {
+	hdr, rows := makeValuePolicyTable(r)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.Render()
+}
+
+func (tp *tablePrinter) Leases(r v3.LeaseLeasesResponse) {
+	hdr, rows := makeLeasesTable(r)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.Render()
+}
+
 func (tp *tablePrinter) EndpointHealth(r []epHealth) {
 	hdr, rows := makeEndpointHealthTable(r)
 	table := tablewriter.NewWriter(os.Stdout)
@@ -67,3 +100,15 @@ func (tp *tablePrinter) EndpointHashKV(r []epHashKV) {
 	table.SetAlignment(tablewriter.ALIGN_RIGHT)
 	table.Render()
 }
+
+func (tp *tablePrinter) EndpointConsistency(r epConsistency) {
+	hdr, rows := makeEndpointConsistencyTable(r)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.Render()
+	printEndpointDivergences(r)
+}
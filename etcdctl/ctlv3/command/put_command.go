@@ -56,7 +56,8 @@ For example,
 $ cat file | put <key>
 will store the content of the file to <key>.
 `,
-		Run: putCommandFunc,
+		Run:               putCommandFunc,
+		ValidArgsFunction: completeArgs(completeKeys),
 	}
 	cmd.Flags().StringVar(&leaseStr, "lease", "0", "lease ID (in hexadecimal) to attach to the key")
 	cmd.Flags().BoolVar(&putPrevKV, "prev-kv", false, "return the previous key-value pair before modification")
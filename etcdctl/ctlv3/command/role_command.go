@@ -56,17 +56,19 @@ func newRoleAddCommand() *cobra.Command {
 
 func newRoleDeleteCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "delete <role name>",
-		Short: "Deletes a role",
-		Run:   roleDeleteCommandFunc,
+		Use:               "delete <role name>",
+		Short:             "Deletes a role",
+		Run:               roleDeleteCommandFunc,
+		ValidArgsFunction: completeArgs(completeRoles),
 	}
 }
 
 func newRoleGetCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "get <role name>",
-		Short: "Gets detailed information of a role",
-		Run:   roleGetCommandFunc,
+		Use:               "get <role name>",
+		Short:             "Gets detailed information of a role",
+		Run:               roleGetCommandFunc,
+		ValidArgsFunction: completeArgs(completeRoles),
 	}
 }
 
@@ -80,9 +82,10 @@ func newRoleListCommand() *cobra.Command {
 
 func newRoleGrantPermissionCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "grant-permission [options] <role name> <permission type> <key> [endkey]",
-		Short: "Grants a key to a role",
-		Run:   roleGrantPermissionCommandFunc,
+		Use:               "grant-permission [options] <role name> <permission type> <key> [endkey]",
+		Short:             "Grants a key to a role",
+		Run:               roleGrantPermissionCommandFunc,
+		ValidArgsFunction: completeArgs(completeRoles, nil, completeKeys, completeKeys),
 	}
 
 	cmd.Flags().BoolVar(&rolePermPrefix, "prefix", false, "grant a prefix permission")
@@ -93,9 +96,10 @@ func newRoleGrantPermissionCommand() *cobra.Command {
 
 func newRoleRevokePermissionCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "revoke-permission <role name> <key> [endkey]",
-		Short: "Revokes a key from a role",
-		Run:   roleRevokePermissionCommandFunc,
+		Use:               "revoke-permission <role name> <key> [endkey]",
+		Short:             "Revokes a key from a role",
+		Run:               roleRevokePermissionCommandFunc,
+		ValidArgsFunction: completeArgs(completeRoles, completeKeys, completeKeys),
 	}
 
 	cmd.Flags().BoolVar(&rolePermPrefix, "prefix", false, "revoke a prefix permission")
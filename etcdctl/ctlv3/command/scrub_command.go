@@ -0,0 +1,134 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	scrubRateBytes  int64
+	scrubCheckPages bool
+)
+
+// NewScrubCommand returns the cobra command for "scrub".
+func NewScrubCommand() *cobra.Command {
+	sc := &cobra.Command{
+		Use:   "scrub <subcommand>",
+		Short: "Backend scrub related commands",
+	}
+	sc.PersistentFlags().BoolVar(&epClusterEndpoints, "cluster", false, "use all endpoints from the cluster member list")
+
+	sc.AddCommand(NewScrubStartCommand())
+	sc.AddCommand(NewScrubStatusCommand())
+	sc.AddCommand(NewScrubCancelCommand())
+
+	return sc
+}
+
+// NewScrubStartCommand returns the cobra command for "scrub start".
+func NewScrubStartCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Starts a scrub of the backend of the etcd members with given endpoints",
+		Long: `Starts a scrub of the backend of the etcd members with given endpoints.
+The scrub checks in the background that the revisions stored in the backend are
+well formed and agree with the key index of the member, reading at most
+--rate-bytes per second. Use "scrub status" to get the problems found.
+`,
+		Run: scrubStartCommandFunc,
+	}
+	cmd.Flags().Int64Var(&scrubRateBytes, "rate-bytes", 0, "maximum number of bytes per second the scrub reads, 0 to use the default of the member")
+	cmd.Flags().BoolVar(&scrubCheckPages, "check-pages", false, "also check the pages of the database file, a subtree of pages per read transaction at the scrub rate")
+	return cmd
+}
+
+// NewScrubStatusCommand returns the cobra command for "scrub status".
+func NewScrubStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Prints the status of the last scrub of the etcd members with given endpoints",
+		Run:   scrubStatusCommandFunc,
+	}
+}
+
+// NewScrubCancelCommand returns the cobra command for "scrub cancel".
+func NewScrubCancelCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancels the running scrub of the etcd members with given endpoints",
+		Run:   scrubCancelCommandFunc,
+	}
+}
+
+func scrubStartCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("scrub start command does not accept arguments"))
+	}
+	if scrubRateBytes < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--rate-bytes must not be negative"))
+	}
+	scrub(cmd, "start", func(ctx context.Context, c *v3.Client, ep string) (*v3.ScrubResponse, error) {
+		return c.ScrubStart(ctx, ep, scrubRateBytes, scrubCheckPages)
+	})
+}
+
+func scrubStatusCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("scrub status command does not accept arguments"))
+	}
+	scrub(cmd, "get status of", func(ctx context.Context, c *v3.Client, ep string) (*v3.ScrubResponse, error) {
+		return c.ScrubStatus(ctx, ep)
+	})
+}
+
+func scrubCancelCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("scrub cancel command does not accept arguments"))
+	}
+	scrub(cmd, "cancel", func(ctx context.Context, c *v3.Client, ep string) (*v3.ScrubResponse, error) {
+		return c.ScrubCancel(ctx, ep)
+	})
+}
+
+func scrub(cmd *cobra.Command, verb string, f func(ctx context.Context, c *v3.Client, ep string) (*v3.ScrubResponse, error)) {
+	failures := 0
+	cfg := clientConfigFromCmd(cmd)
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, err := f(ctx, c, ep)
+		cancel()
+		c.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to %s scrub of etcd member[%s] (%v)\n", verb, ep, err)
+			failures++
+			continue
+		}
+		display.Scrub(ep, *resp)
+	}
+
+	if failures != 0 {
+		cobrautl.Exit(cobrautl.ExitError)
+	}
+}
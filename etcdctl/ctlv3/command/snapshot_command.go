@@ -17,6 +17,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -44,7 +45,12 @@ var snapshotExample = util.Normalize(`
 	etcdctl --endpoints=https://127.0.0.1:2379 --dial-timeout=20s snapshot save /backup/etcd-snapshot.db
 
 	# Save snapshot with desirable time format
-	etcdctl snapshot save /mnt/backup/etcd/backup_$(date +%Y%m%d_%H%M%S).db`)
+	etcdctl snapshot save /mnt/backup/etcd/backup_$(date +%Y%m%d_%H%M%S).db
+
+	# Save snapshots of all cluster members at one revision to a given directory
+	etcdctl snapshot save --cluster /backup/etcd-snapshots`)
+
+var snapshotCluster bool
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
 func NewSnapshotCommand() *cobra.Command {
@@ -58,12 +64,23 @@ func NewSnapshotCommand() *cobra.Command {
 }
 
 func NewSnapshotSaveCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:     "save <filename>",
-		Short:   "Stores an etcd node backend snapshot to a given file",
+	cmd := &cobra.Command{
+		Use:   "save <filename>",
+		Short: "Stores an etcd node backend snapshot to a given file",
+		Long: `Stores an etcd node backend snapshot to a given file.
+
+With --cluster, the argument is a directory, and a snapshot of every member of the
+cluster is stored into it as <member ID>.db. Every member takes its snapshot right
+after applying the same revision, a revision after the current revision of the
+cluster, so the snapshots hold the same key-value store, leases, authentication and
+membership. The command brings the cluster to that revision by writing the key
+__etcd_snapshot_marker.
+`,
 		Run:     snapshotSaveCommandFunc,
 		Example: snapshotExample,
 	}
+	cmd.Flags().BoolVar(&snapshotCluster, "cluster", false, "save snapshots of all cluster members at one revision to the given directory")
+	return cmd
 }
 
 func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
@@ -71,6 +88,10 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 		err := fmt.Errorf("snapshot save expects one argument <filename>")
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
+	if snapshotCluster {
+		snapshotSaveClusterCommandFunc(cmd, args[0])
+		return
+	}
 
 	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
 	if err != nil {
@@ -95,3 +116,35 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 		fmt.Printf("Server version %s\n", version)
 	}
 }
+
+func snapshotSaveClusterCommandFunc(cmd *cobra.Command, dir string) {
+	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	cfg := mustClientCfgFromCmd(cmd)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if isCommandTimeoutFlagSet(cmd) {
+		ctx, cancel = commandCtx(cmd)
+	}
+	defer cancel()
+
+	cs, err := snapshot.SaveCluster(ctx, lg, *cfg, dir)
+	if cs == nil {
+		cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
+	}
+	for _, ms := range cs.Members {
+		if ms.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save snapshot of member %x (%s): %v\n", ms.MemberID, ms.Endpoint, ms.Err)
+			continue
+		}
+		fmt.Printf("Snapshot of member %x (%s) at revision %d saved at %s\n", ms.MemberID, ms.Endpoint, cs.Revision, ms.Path)
+		if ms.Version != "" {
+			fmt.Printf("Server version %s\n", ms.Version)
+		}
+	}
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
+	}
+}
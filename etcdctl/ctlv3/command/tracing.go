@@ -0,0 +1,203 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/pkg/v3/flags"
+)
+
+// traceParentEnv is the environment variable holding the W3C trace context
+// of the caller, as set by CI systems and other tracing aware tools.
+const traceParentEnv = "TRACEPARENT"
+
+var tracePropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// cmdTracing traces the running command. Without a tracing endpoint spans
+// are not recorded, but the trace context of the caller is still propagated
+// to the server.
+var cmdTracing = &commandTracing{
+	tracer: noop.NewTracerProvider().Tracer(cliTracerName),
+	ctx:    context.Background(),
+}
+
+const cliTracerName = "go.etcd.io/etcd/etcdctl"
+
+type commandTracing struct {
+	provider *tracesdk.TracerProvider
+	tracer   trace.Tracer
+	// ctx holds the span of the running command.
+	ctx  context.Context
+	span trace.Span
+}
+
+// InitTracing starts the span of the given command, exporting it to the
+// tracing endpoint if one is configured. FinishTracing must be called once
+// the command completes; it is called before the command exits through
+// cobrautl otherwise.
+func InitTracing(cmd *cobra.Command) {
+	cobrautl.RegisterExitHook(finishTracingOnExit)
+
+	endpoint := tracingFlagFromCmd(cmd, "tracing-endpoint")
+	serviceName := tracingFlagFromCmd(cmd, "tracing-service-name")
+
+	ctx := tracePropagator.Extract(context.Background(), traceParentCarrier{})
+	var provider trace.TracerProvider = noop.NewTracerProvider()
+	if endpoint != "" {
+		exporter, err := otlptracegrpc.New(ctx,
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithEndpoint(endpoint),
+		)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		cmdTracing.provider = tracesdk.NewTracerProvider(
+			// export spans as they end, as the process may exit at any time.
+			tracesdk.WithSyncer(exporter),
+			tracesdk.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(serviceName))),
+			tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.AlwaysSample())),
+		)
+		provider = cmdTracing.provider
+	}
+
+	cmdTracing.tracer = provider.Tracer(cliTracerName)
+	cmdTracing.ctx, cmdTracing.span = cmdTracing.tracer.Start(ctx, cmd.CommandPath(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.StringSlice("etcdctl.args", cmd.Flags().Args())),
+	)
+}
+
+// tracingFlagFromCmd returns the value of the flag, falling back to its
+// environment variable, as tracing starts before the flags are set from
+// the environment.
+func tracingFlagFromCmd(cmd *cobra.Command, name string) string {
+	v, err := cmd.Flags().GetString(name)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if !cmd.Flags().Changed(name) {
+		if env, ok := os.LookupEnv(flags.FlagToEnv("ETCDCTL", name)); ok {
+			return env
+		}
+	}
+	return v
+}
+
+// FinishTracing ends the span of the command and flushes the spans.
+func FinishTracing() {
+	if cmdTracing.span != nil {
+		cmdTracing.span.End()
+		cmdTracing.span = nil
+	}
+	if cmdTracing.provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		cmdTracing.provider.Shutdown(ctx)
+		cmdTracing.provider = nil
+	}
+}
+
+// finishTracingOnExit records the failure of the command on its span, and
+// finishes tracing before the command exits.
+func finishTracingOnExit(code int, err error) {
+	if cmdTracing.span != nil && code != cobrautl.ExitSuccess {
+		if err == nil {
+			err = fmt.Errorf("exit status %d", code)
+		}
+		cmdTracing.span.RecordError(err)
+		cmdTracing.span.SetStatus(codes.Error, err.Error())
+	}
+	FinishTracing()
+}
+
+// traceCtx returns a context holding the span of the running command.
+func traceCtx() context.Context {
+	return cmdTracing.ctx
+}
+
+// startSpan starts a child span of the span held by ctx.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return cmdTracing.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends the span, recording the error if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceDialOptions propagates the trace context of the command to the
+// server, and records the RPCs as spans if tracing is enabled.
+func traceDialOptions() []grpc.DialOption {
+	var provider trace.TracerProvider = noop.NewTracerProvider()
+	if cmdTracing.provider != nil {
+		provider = cmdTracing.provider
+	}
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithPropagators(tracePropagator),
+			otelgrpc.WithTracerProvider(provider),
+		)),
+	}
+}
+
+// traceEnviron returns the environment variable propagating the trace
+// context of ctx to a child process.
+func traceEnviron(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if tp := carrier.Get("traceparent"); tp != "" {
+		return []string{traceParentEnv + "=" + tp}
+	}
+	return nil
+}
+
+// traceParentCarrier reads the trace context from the environment.
+type traceParentCarrier struct{}
+
+func (traceParentCarrier) Get(key string) string {
+	return os.Getenv(strings.ToUpper(key))
+}
+
+func (traceParentCarrier) Set(string, string) {}
+
+func (traceParentCarrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+func TestTracingPropagatesTraceParent(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	t.Setenv(traceParentEnv, "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	cmd := &cobra.Command{Use: "get"}
+	cmd.Flags().String("tracing-endpoint", "", "")
+	cmd.Flags().String("tracing-service-name", "etcdctl", "")
+	InitTracing(cmd)
+	defer FinishTracing()
+
+	sc := trace.SpanContextFromContext(traceCtx())
+	require.Equal(t, traceID, sc.TraceID().String())
+	require.True(t, sc.IsSampled())
+
+	// child processes join the trace.
+	ctx, span := startSpan(traceCtx(), "lock.hold")
+	defer span.End()
+	env := traceEnviron(ctx)
+	require.Len(t, env, 1)
+	require.True(t, strings.HasPrefix(env[0], traceParentEnv+"=00-"+traceID+"-"), env[0])
+}
+
+func TestTracingFinishedOnExit(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	cmdTracing.provider = tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))
+	cmdTracing.ctx, cmdTracing.span = cmdTracing.provider.Tracer(cliTracerName).Start(context.Background(), "etcdctl get")
+
+	finishTracingOnExit(cobrautl.ExitBadConnection, errors.New("context deadline exceeded"))
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "context deadline exceeded", spans[0].Status().Description)
+	require.Nil(t, cmdTracing.provider)
+
+	// finishing again after the command exits is a no-op.
+	FinishTracing()
+	require.Len(t, recorder.Ended(), 1)
+}
@@ -68,17 +68,19 @@ func newUserAddCommand() *cobra.Command {
 
 func newUserDeleteCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "delete <user name>",
-		Short: "Deletes a user",
-		Run:   userDeleteCommandFunc,
+		Use:               "delete <user name>",
+		Short:             "Deletes a user",
+		Run:               userDeleteCommandFunc,
+		ValidArgsFunction: completeArgs(completeUsers),
 	}
 }
 
 func newUserGetCommand() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "get <user name> [options]",
-		Short: "Gets detailed information of a user",
-		Run:   userGetCommandFunc,
+		Use:               "get <user name> [options]",
+		Short:             "Gets detailed information of a user",
+		Run:               userGetCommandFunc,
+		ValidArgsFunction: completeArgs(completeUsers),
 	}
 
 	cmd.Flags().BoolVar(&userShowDetail, "detail", false, "Show permissions of roles granted to the user")
@@ -96,9 +98,10 @@ func newUserListCommand() *cobra.Command {
 
 func newUserChangePasswordCommand() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "passwd <user name> [options]",
-		Short: "Changes password of user",
-		Run:   userChangePasswordCommandFunc,
+		Use:               "passwd <user name> [options]",
+		Short:             "Changes password of user",
+		Run:               userChangePasswordCommandFunc,
+		ValidArgsFunction: completeArgs(completeUsers),
 	}
 
 	cmd.Flags().BoolVar(&passwordInteractive, "interactive", true, "If true, read password from stdin instead of interactive terminal")
@@ -108,17 +111,19 @@ func newUserChangePasswordCommand() *cobra.Command {
 
 func newUserGrantRoleCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "grant-role <user name> <role name>",
-		Short: "Grants a role to a user",
-		Run:   userGrantRoleCommandFunc,
+		Use:               "grant-role <user name> <role name>",
+		Short:             "Grants a role to a user",
+		Run:               userGrantRoleCommandFunc,
+		ValidArgsFunction: completeArgs(completeUsers, completeRoles),
 	}
 }
 
 func newUserRevokeRoleCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "revoke-role <user name> <role name>",
-		Short: "Revokes a role from a user",
-		Run:   userRevokeRoleCommandFunc,
+		Use:               "revoke-role <user name> <role name>",
+		Short:             "Revokes a role from a user",
+		Run:               userRevokeRoleCommandFunc,
+		ValidArgsFunction: completeArgs(completeUsers, completeRoles),
 	}
 }
 
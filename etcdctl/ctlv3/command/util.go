@@ -82,7 +82,7 @@ func commandCtx(cmd *cobra.Command) (context.Context, context.CancelFunc) {
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
-	return context.WithTimeout(context.Background(), timeOut)
+	return context.WithTimeout(traceCtx(), timeOut)
 }
 
 func isCommandTimeoutFlagSet(cmd *cobra.Command) bool {
@@ -146,26 +146,26 @@ func endpointMemoryMetrics(host string, scfg *clientv3.SecureConfig) float64 {
 
 // compact keyspace history to a provided revision
 func compact(c *clientv3.Client, rev int64) {
-	fmt.Printf("Compacting with revision %d\n", rev)
+	fmt.Fprintf(checkOut, "Compacting with revision %d\n", rev)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	_, err := c.Compact(ctx, rev, clientv3.WithCompactPhysical())
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
-	fmt.Printf("Compacted with revision %d\n", rev)
+	fmt.Fprintf(checkOut, "Compacted with revision %d\n", rev)
 }
 
 // defrag a given endpoint
 func defrag(c *clientv3.Client, ep string) {
-	fmt.Printf("Defragmenting %q\n", ep)
+	fmt.Fprintf(checkOut, "Defragmenting %q\n", ep)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	_, err := c.Defragment(ctx, ep)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
-	fmt.Printf("Defragmented %q\n", ep)
+	fmt.Fprintf(checkOut, "Defragmented %q\n", ep)
 }
 
 func IsSerializable(option string) bool {
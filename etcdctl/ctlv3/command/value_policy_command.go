@@ -0,0 +1,149 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	valuePolicyMaxValueSize int64
+	valuePolicyContentType  string
+	valuePolicyKeyPattern   string
+)
+
+// NewValuePolicyCommand returns the cobra command for "value-policy".
+func NewValuePolicyCommand() *cobra.Command {
+	vc := &cobra.Command{
+		Use:   "value-policy <subcommand>",
+		Short: "Value policy related commands",
+		Long: `Manages the value policies of key prefixes. Puts of keys under the prefix of a policy,
+including the puts of transactions, fail if the key or value violates the policy. A key
+is governed by the policy with the longest prefix of the key. The puts of both branches
+of a transaction are checked.
+`,
+	}
+
+	vc.AddCommand(NewValuePolicyPutCommand())
+	vc.AddCommand(NewValuePolicyDeleteCommand())
+	vc.AddCommand(NewValuePolicyListCommand())
+
+	return vc
+}
+
+// NewValuePolicyPutCommand returns the cobra command for "value-policy put".
+func NewValuePolicyPutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "put <prefix> [options]",
+		Short: "Sets the value policy of a key prefix",
+		Run:   valuePolicyPutCommandFunc,
+	}
+
+	cmd.Flags().Int64Var(&valuePolicyMaxValueSize, "max-value-size", 0, "maximum size of values in bytes (unlimited if 0)")
+	cmd.Flags().StringVar(&valuePolicyContentType, "content-type", "any", "required content type of values (any, json, protobuf)")
+	cmd.Flags().StringVar(&valuePolicyKeyPattern, "key-pattern", "", "regular expression which keys must match")
+	cmd.RegisterFlagCompletionFunc("content-type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"any", "json", "protobuf"}, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
+
+// NewValuePolicyDeleteCommand returns the cobra command for "value-policy del".
+func NewValuePolicyDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "del <prefix>",
+		Short: "Deletes the value policy of a key prefix",
+		Run:   valuePolicyDeleteCommandFunc,
+	}
+}
+
+// NewValuePolicyListCommand returns the cobra command for "value-policy list".
+func NewValuePolicyListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists all value policies",
+		Long: `When --write-out is set to simple, this command prints out comma-separated policies.
+The items in the policies are Prefix, Max Value Size, Content Type, Key Pattern.
+`,
+		Run: valuePolicyListCommandFunc,
+	}
+}
+
+// valuePolicyPutCommandFunc executes the "value-policy put" command.
+func valuePolicyPutCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("value-policy put command needs prefix as argument"))
+	}
+	ct, ok := pb.ValuePolicy_ContentType_value[strings.ToUpper(valuePolicyContentType)]
+	if !ok {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("unknown content type %q", valuePolicyContentType))
+	}
+	p := &v3.ValuePolicy{
+		Prefix:       []byte(args[0]),
+		MaxValueSize: valuePolicyMaxValueSize,
+		ContentType:  pb.ValuePolicy_ContentType(ct),
+		KeyPattern:   valuePolicyKeyPattern,
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ValuePolicyPut(ctx, p)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ValuePolicyPut(args[0], *resp)
+}
+
+// valuePolicyDeleteCommandFunc executes the "value-policy del" command.
+func valuePolicyDeleteCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("value-policy del command needs prefix as argument"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ValuePolicyDelete(ctx, args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ValuePolicyDelete(args[0], *resp)
+}
+
+// valuePolicyListCommandFunc executes the "value-policy list" command.
+func valuePolicyListCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("value-policy list command accepts no arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ValuePolicyList(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.ValuePolicyList(*resp)
+}
@@ -20,8 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -42,14 +43,16 @@ var (
 	watchInteractive bool
 	watchPrevKey     bool
 	progressNotify   bool
+	watchLease       string
 )
 
 // NewWatchCommand returns the cobra command for "watch".
 func NewWatchCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "watch [options] [key or prefix] [range_end] [--] [exec-command arg1 arg2 ...]",
-		Short: "Watches events stream on keys or prefixes",
-		Run:   watchCommandFunc,
+		Use:               "watch [options] [key or prefix] [range_end] [--] [exec-command arg1 arg2 ...]",
+		Short:             "Watches events stream on keys or prefixes",
+		Run:               watchCommandFunc,
+		ValidArgsFunction: completeArgs(completeKeys, completeKeys),
 	}
 
 	cmd.Flags().BoolVarP(&watchInteractive, "interactive", "i", false, "Interactive mode")
@@ -57,6 +60,12 @@ func NewWatchCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&watchRev, "rev", 0, "Revision to start watching")
 	cmd.Flags().BoolVar(&watchPrevKey, "prev-kv", false, "get the previous key-value pair before the event happens")
 	cmd.Flags().BoolVar(&progressNotify, "progress-notify", false, "get periodic watch progress notification from server")
+	cmd.Flags().StringVar(&watchLease, "lease", "", "watch only the keys attached to the lease ID (in hexadecimal)")
+	cmd.Flags().StringVar(&watchExec, "exec", "", "command to execute for each event, whose arguments are templates of the event such as {{.Key}}, {{.Value}} and {{.Type}}")
+	cmd.Flags().IntVar(&watchExecConcurrency, "exec-concurrency", 1, "maximum number of commands executed at the same time")
+	cmd.Flags().IntVar(&watchExecRetries, "exec-retries", 0, "number of times a failed command is retried before exiting")
+	cmd.Flags().DurationVar(&watchExecRetryInterval, "exec-retry-interval", time.Second, "time to wait before retrying a failed command")
+	cmd.Flags().StringVar(&watchExecResumeFile, "exec-resume-file", "", "file saving the revision up to which the commands of all events succeeded, to resume watching after it")
 
 	return cmd
 }
@@ -78,13 +87,18 @@ func watchCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
 
+	we, err := newWatchExecutor(execArgs)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+
 	c := mustClientFromCmd(cmd)
 	wc, err := getWatchChan(c, watchArgs)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
 
-	printWatchCh(c, wc, execArgs)
+	printWatchCh(c, wc, we)
 	if err = c.Close(); err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadConnection, err)
 	}
@@ -118,13 +132,18 @@ func watchInteractiveFunc(cmd *cobra.Command, osArgs []string, envKey, envRange
 			if perr != nil {
 				cobrautl.ExitWithError(cobrautl.ExitBadArgs, perr)
 			}
+			we, werr := newWatchExecutor(execArgs)
+			if werr != nil {
+				fmt.Fprintf(os.Stderr, "Invalid command %s (%v)\n", l, werr)
+				continue
+			}
 
 			ch, err := getWatchChan(c, watchArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Invalid command %s (%v)\n", l, err)
 				continue
 			}
-			go printWatchCh(c, ch, execArgs)
+			go printWatchCh(c, ch, we)
 		case "progress":
 			err := c.RequestProgress(clientv3.WithRequireLeader(context.Background()))
 			if err != nil {
@@ -159,10 +178,17 @@ func getWatchChan(c *clientv3.Client, args []string) (clientv3.WatchChan, error)
 	if progressNotify {
 		opts = append(opts, clientv3.WithProgressNotify())
 	}
+	if watchLease != "" {
+		id, err := strconv.ParseInt(watchLease, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err)
+		}
+		opts = append(opts, clientv3.WithLease(clientv3.LeaseID(id)))
+	}
 	return c.Watch(clientv3.WithRequireLeader(context.Background()), key, opts...), nil
 }
 
-func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string) {
+func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, we *watchExecutor) {
 	for resp := range ch {
 		if resp.Canceled {
 			fmt.Fprintf(os.Stderr, "watch was canceled (%v)\n", resp.Err())
@@ -172,22 +198,13 @@ func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string)
 		}
 		display.Watch(resp)
 
-		if len(execArgs) > 0 {
-			for _, ev := range resp.Events {
-				cmd := exec.CommandContext(c.Ctx(), execArgs[0], execArgs[1:]...)
-				cmd.Env = os.Environ()
-				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_REVISION=%d", resp.Header.Revision))
-				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_EVENT_TYPE=%q", ev.Type))
-				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_KEY=%q", ev.Kv.Key))
-				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_VALUE=%q", ev.Kv.Value))
-				cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-				if err := cmd.Run(); err != nil {
-					fmt.Fprintf(os.Stderr, "command %q error (%v)\n", execArgs, err)
-					os.Exit(1)
-				}
-			}
+		if we != nil {
+			we.handle(c.Ctx(), resp)
 		}
 	}
+	if we != nil {
+		we.wait()
+	}
 }
 
 // "commandArgs" is the command arguments after "spf13/cobra" parses
@@ -333,6 +350,30 @@ func parseWatchArgs(osArgs, commandArgs []string, envKey, envRange string, inter
 		if err != nil {
 			return nil, nil, err
 		}
+		watchLease, err = flagset.GetString("lease")
+		if err != nil {
+			return nil, nil, err
+		}
+		watchExec, err = flagset.GetString("exec")
+		if err != nil {
+			return nil, nil, err
+		}
+		watchExecConcurrency, err = flagset.GetInt("exec-concurrency")
+		if err != nil {
+			return nil, nil, err
+		}
+		watchExecRetries, err = flagset.GetInt("exec-retries")
+		if err != nil {
+			return nil, nil, err
+		}
+		watchExecRetryInterval, err = flagset.GetDuration("exec-retry-interval")
+		if err != nil {
+			return nil, nil, err
+		}
+		watchExecResumeFile, err = flagset.GetString("exec-resume-file")
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// "ETCDCTL_WATCH_KEY=foo watch -- echo hello"
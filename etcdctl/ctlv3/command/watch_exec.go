@@ -0,0 +1,370 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	watchExec              string
+	watchExecConcurrency   int
+	watchExecRetries       int
+	watchExecRetryInterval time.Duration
+	watchExecResumeFile    string
+)
+
+// watchExecEvent is the data of the templates of the --exec command for an event.
+type watchExecEvent struct {
+	// Type is PUT or DELETE.
+	Type           string
+	Key            string
+	Value          string
+	PrevValue      string
+	Revision       int64
+	CreateRevision int64
+	Version        int64
+	Lease          int64
+}
+
+// watchExecutor runs a command for each watch event.
+type watchExecutor struct {
+	// args returns the command line of an event.
+	args          func(ev *clientv3.Event) ([]string, error)
+	retries       int
+	retryInterval time.Duration
+	sem           chan struct{}
+	wg            sync.WaitGroup
+	tracker       *watchResumeTracker
+}
+
+// newWatchExecutor returns the executor of the command given after "--", or
+// of the --exec template, or nil if neither is given. With --exec-resume-file,
+// it sets the watch to start after the revision saved in the file.
+func newWatchExecutor(execArgs []string) (*watchExecutor, error) {
+	if len(execArgs) > 0 && watchExec != "" {
+		return nil, errors.New("--exec and a command after -- are mutually exclusive")
+	}
+	if watchExecConcurrency < 1 {
+		return nil, errors.New("--exec-concurrency must be at least 1")
+	}
+	if watchExecRetries < 0 {
+		return nil, errors.New("--exec-retries must not be negative")
+	}
+	we := &watchExecutor{
+		retries:       watchExecRetries,
+		retryInterval: watchExecRetryInterval,
+		sem:           make(chan struct{}, watchExecConcurrency),
+	}
+	switch {
+	case len(execArgs) > 0:
+		we.args = func(*clientv3.Event) ([]string, error) { return execArgs, nil }
+	case watchExec != "":
+		tmpls, err := parseWatchExecTemplate(watchExec)
+		if err != nil {
+			return nil, err
+		}
+		we.args = func(ev *clientv3.Event) ([]string, error) { return executeWatchExecTemplate(tmpls, ev) }
+	default:
+		if watchExecResumeFile != "" {
+			return nil, errors.New("--exec-resume-file needs a command to execute")
+		}
+		return nil, nil
+	}
+	if watchExecResumeFile != "" {
+		rev, err := readWatchResumeRevision(watchExecResumeFile)
+		if err != nil {
+			return nil, err
+		}
+		// the watch resumes after the saved revision.
+		if rev != 0 {
+			watchRev = rev + 1
+		}
+		we.tracker = &watchResumeTracker{path: watchExecResumeFile}
+	}
+	return we, nil
+}
+
+// parseWatchExecTemplate splits the --exec command line into arguments, and
+// parses each argument as a template. The arguments are separated by spaces
+// outside of quotes and actions, so the values of an event are passed as is.
+func parseWatchExecTemplate(s string) ([]*template.Template, error) {
+	args, err := splitWatchExecArgs(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("--exec command is empty")
+	}
+	tmpls := make([]*template.Template, len(args))
+	for i, arg := range args {
+		tmpls[i], err = template.New("exec").Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --exec template %q (%w)", arg, err)
+		}
+	}
+	return tmpls, nil
+}
+
+func splitWatchExecArgs(s string) ([]string, error) {
+	var (
+		args  []string
+		arg   strings.Builder
+		inArg bool
+		quote byte
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == 0 && strings.HasPrefix(s[i:], "{{"):
+			end := strings.Index(s[i:], "}}")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed action in --exec command %q", s)
+			}
+			arg.WriteString(s[i : i+end+2])
+			i += end + 1
+			inArg = true
+		case quote == 0 && (c == ' ' || c == '\t' || c == '\n'):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote, inArg = c, true
+		case c == quote:
+			quote = 0
+		case quote == '"' && c == '\\' && i+1 < len(s):
+			i++
+			arg.WriteByte(s[i])
+		default:
+			arg.WriteByte(c)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unclosed quote in --exec command %q", s)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func executeWatchExecTemplate(tmpls []*template.Template, ev *clientv3.Event) ([]string, error) {
+	data := watchExecEvent{
+		Type:           ev.Type.String(),
+		Key:            string(ev.Kv.Key),
+		Value:          string(ev.Kv.Value),
+		Revision:       ev.Kv.ModRevision,
+		CreateRevision: ev.Kv.CreateRevision,
+		Version:        ev.Kv.Version,
+		Lease:          ev.Kv.Lease,
+	}
+	if ev.PrevKv != nil {
+		data.PrevValue = string(ev.PrevKv.Value)
+	}
+	args := make([]string, len(tmpls))
+	for i, tmpl := range tmpls {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, err
+		}
+		args[i] = sb.String()
+	}
+	return args, nil
+}
+
+// handle runs the command of each event of the response. It blocks while
+// --exec-concurrency commands are running, or until the command returns if
+// commands run one at a time, and exits etcdctl if a command fails after its
+// retries.
+func (we *watchExecutor) handle(ctx context.Context, resp clientv3.WatchResponse) {
+	if we.tracker != nil {
+		if resp.IsProgressNotify() {
+			we.tracker.progress(resp.Header.Revision)
+		}
+		we.tracker.add(resp.Events)
+	}
+	for _, ev := range resp.Events {
+		args, err := we.args(ev)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "command template error (%v)\n", err)
+			cobrautl.Exit(1)
+		}
+		we.sem <- struct{}{}
+		we.wg.Add(1)
+		f := func() {
+			defer we.wg.Done()
+			defer func() { <-we.sem }()
+			if err := we.run(ctx, resp.Header.Revision, ev, args); err != nil {
+				fmt.Fprintf(os.Stderr, "command %q error (%v)\n", args, err)
+				cobrautl.Exit(1)
+			}
+			if we.tracker != nil {
+				we.tracker.done(ev.Kv.ModRevision)
+			}
+		}
+		if cap(we.sem) == 1 {
+			f()
+		} else {
+			go f()
+		}
+	}
+}
+
+func (we *watchExecutor) run(ctx context.Context, rev int64, ev *clientv3.Event, args []string) error {
+	for i := 0; ; i++ {
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Env = os.Environ()
+		cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_REVISION=%d", rev))
+		cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_EVENT_TYPE=%q", ev.Type))
+		cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_KEY=%q", ev.Kv.Key))
+		cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_VALUE=%q", ev.Kv.Value))
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		err := cmd.Run()
+		if err == nil || i == we.retries {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "command %q error (%v), retrying\n", args, err)
+		select {
+		case <-time.After(we.retryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// wait waits for the running commands to finish.
+func (we *watchExecutor) wait() {
+	we.wg.Wait()
+}
+
+// watchResumeTracker saves in a file the revision up to which the commands
+// of all events have succeeded, to resume the watch after it.
+type watchResumeTracker struct {
+	path string
+
+	mu sync.Mutex
+	// pending are the revisions of the events whose commands are running,
+	// in order, with the number of their events left.
+	pending []watchPendingRev
+	// progressRev is the revision of a progress notification received
+	// while commands were running.
+	progressRev int64
+}
+
+type watchPendingRev struct {
+	rev  int64
+	left int
+}
+
+// readWatchResumeRevision returns the revision saved in the resume file, or 0
+// if the file does not exist.
+func readWatchResumeRevision(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	rev, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resume file %q (%w)", path, err)
+	}
+	return rev, nil
+}
+
+func (t *watchResumeTracker) add(evs []*clientv3.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ev := range evs {
+		rev := ev.Kv.ModRevision
+		if n := len(t.pending); n > 0 && t.pending[n-1].rev == rev {
+			t.pending[n-1].left++
+			continue
+		}
+		t.pending = append(t.pending, watchPendingRev{rev: rev, left: 1})
+	}
+}
+
+// progress saves the revision of a progress notification once the commands
+// running have succeeded.
+func (t *watchResumeTracker) progress(rev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) > 0 {
+		t.progressRev = rev
+		return
+	}
+	t.save(rev)
+}
+
+func (t *watchResumeTracker) done(rev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.pending {
+		if t.pending[i].rev == rev {
+			t.pending[i].left--
+			break
+		}
+	}
+	var saved int64
+	for len(t.pending) > 0 && t.pending[0].left == 0 {
+		saved = t.pending[0].rev
+		t.pending = t.pending[1:]
+	}
+	if len(t.pending) == 0 && t.progressRev > saved {
+		saved, t.progressRev = t.progressRev, 0
+	}
+	if saved != 0 {
+		t.save(saved)
+	}
+}
+
+// save writes the revision to the resume file, replacing it atomically.
+func (t *watchResumeTracker) save(rev int64) {
+	tmp, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".tmp")
+	if err == nil {
+		_, err = fmt.Fprintf(tmp, "%d\n", rev)
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), t.path)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to save resume revision %d to %q (%v)\n", rev, t.path, err)
+		cobrautl.Exit(1)
+	}
+}
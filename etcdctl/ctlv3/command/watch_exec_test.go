@@ -0,0 +1,105 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestWatchExecTemplate(t *testing.T) {
+	tests := []struct {
+		exec string
+		ev   clientv3.Event
+		want []string
+		err  bool
+	}{
+		{
+			exec: `handler {{.Key}} {{.Type}}`,
+			ev:   clientv3.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/a b"), ModRevision: 3}},
+			want: []string{"handler", "/a b", "PUT"},
+		},
+		{
+			exec: `sh -c 'echo "$0"' "{{.Key}}={{.Value}}" {{printf "%d/%d" .Revision .Version}} key:{{.Key}}`,
+			ev:   clientv3.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("k"), Value: []byte("v"), ModRevision: 5, Version: 2}},
+			want: []string{"sh", "-c", `echo "$0"`, "k=v", "5/2", "key:k"},
+		},
+		{
+			exec: `handler {{.PrevValue}}`,
+			ev: clientv3.Event{
+				Type:   mvccpb.DELETE,
+				Kv:     &mvccpb.KeyValue{Key: []byte("k")},
+				PrevKv: &mvccpb.KeyValue{Key: []byte("k"), Value: []byte("old")},
+			},
+			want: []string{"handler", "old"},
+		},
+		{exec: `handler {{.Key`, err: true},
+		{exec: `handler "{{.Key}}`, err: true},
+		{exec: `handler {{.Unknown}}`, ev: clientv3.Event{Kv: &mvccpb.KeyValue{}}, err: true},
+		{exec: ` `, err: true},
+	}
+	for _, tt := range tests {
+		tmpls, err := parseWatchExecTemplate(tt.exec)
+		if err == nil {
+			var args []string
+			args, err = executeWatchExecTemplate(tmpls, &tt.ev)
+			if err == nil {
+				assert.Equalf(t, tt.want, args, "exec %q", tt.exec)
+			}
+		}
+		assert.Equalf(t, tt.err, err != nil, "exec %q: error %v", tt.exec, err)
+	}
+}
+
+func TestWatchResumeTracker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume")
+	rev, err := readWatchResumeRevision(path)
+	require.NoError(t, err)
+	require.Zero(t, rev)
+
+	saved := func() int64 {
+		rev, err := readWatchResumeRevision(path)
+		require.NoError(t, err)
+		return rev
+	}
+	ev := func(rev int64) *clientv3.Event {
+		return &clientv3.Event{Kv: &mvccpb.KeyValue{ModRevision: rev}}
+	}
+
+	tr := &watchResumeTracker{path: path}
+	tr.progress(3)
+	assert.Equal(t, int64(3), saved())
+
+	// two events of a txn at 5, and an event at 6.
+	tr.add([]*clientv3.Event{ev(5), ev(5)})
+	tr.add([]*clientv3.Event{ev(6)})
+	tr.done(6)
+	tr.done(5)
+	assert.Equal(t, int64(3), saved(), "revision 5 has an event left")
+	tr.progress(8)
+	assert.Equal(t, int64(3), saved())
+	tr.done(5)
+	assert.Equal(t, int64(8), saved())
+
+	tr.add([]*clientv3.Event{ev(9)})
+	tr.done(9)
+	assert.Equal(t, int64(9), saved())
+}
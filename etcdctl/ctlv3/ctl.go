@@ -16,7 +16,6 @@
 package ctlv3
 
 import (
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -30,10 +29,11 @@ const (
 	cliName        = "etcdctl"
 	cliDescription = "A simple command line client for etcd3."
 
-	defaultDialTimeout      = 2 * time.Second
-	defaultCommandTimeOut   = 5 * time.Second
-	defaultKeepAliveTime    = 2 * time.Second
-	defaultKeepAliveTimeOut = 6 * time.Second
+	defaultDialTimeout       = 2 * time.Second
+	defaultCommandTimeOut    = 5 * time.Second
+	defaultKeepAliveTime     = 2 * time.Second
+	defaultKeepAliveTimeOut  = 6 * time.Second
+	defaultCompletionTimeout = time.Second
 )
 
 var (
@@ -42,25 +42,36 @@ var (
 		Use:        cliName,
 		Short:      cliDescription,
 		SuggestFor: []string{"etcdctl"},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			command.InitTracing(cmd)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			command.FinishTracing()
+		},
 	}
 )
 
 func init() {
 	rootCmd.PersistentFlags().StringSliceVar(&globalFlags.Endpoints, "endpoints", []string{"127.0.0.1:2379"}, "gRPC endpoints")
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.Debug, "debug", false, "enable client-side debug logging")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.TracingEndpoint, "tracing-endpoint", "", "OpenTelemetry collector address to export command traces to over OTLP gRPC (disabled if empty)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.TracingServiceName, "tracing-service-name", cliName, "service name of the exported command traces")
 
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.OutputFormat, "write-out", "w", "simple", "set the output format (fields, json, protobuf, simple, table)")
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.IsHex, "hex", false, "print byte strings as hex encoded strings")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Namespace, "namespace", "", "prefix of the keys given to and printed by the commands, as in the namespace of a grpc proxy")
 	rootCmd.RegisterFlagCompletionFunc("write-out", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"fields", "json", "protobuf", "simple", "table"}, cobra.ShellCompDirectiveDefault
 	})
 
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.DialTimeout, "dial-timeout", defaultDialTimeout, "dial timeout for client connections")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.CommandTimeOut, "command-timeout", defaultCommandTimeOut, "timeout for short running command (excluding dial timeout)")
+	rootCmd.PersistentFlags().DurationVar(&globalFlags.CompletionTimeout, "completion-timeout", defaultCompletionTimeout, "timeout for the lookups of keys, members, users and roles by shell completion (disabled if 0)")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.KeepAliveTime, "keepalive-time", defaultKeepAliveTime, "keepalive time for client connections")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.KeepAliveTimeout, "keepalive-timeout", defaultKeepAliveTimeOut, "keepalive timeout for client connections")
 	rootCmd.PersistentFlags().IntVar(&globalFlags.MaxCallSendMsgSize, "max-request-bytes", 0, "client-side request send limit in bytes (if 0, it defaults to 2.0 MiB (2 * 1024 * 1024).)")
 	rootCmd.PersistentFlags().IntVar(&globalFlags.MaxCallRecvMsgSize, "max-recv-bytes", 0, "client-side response receive limit in bytes (if 0, it defaults to \"math.MaxInt32\")")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.CompressionAlgorithm, "compression", "", "compress requests and responses with the given algorithm (gzip, zstd)")
 
 	// TODO: secure by default when etcd enables secure gRPC by default.
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.Insecure, "insecure-transport", true, "disable transport security for client connections")
@@ -73,14 +84,19 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&globalFlags.Password, "password", "", "password for authentication (if this option is used, --user option shouldn't include password)")
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.TLS.ServerName, "discovery-srv", "d", "", "domain name to query for SRV records describing cluster endpoints")
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.DNSClusterServiceName, "discovery-srv-name", "", "", "service name to query when using DNS discovery")
+	rootCmd.PersistentFlags().StringSliceVar(&globalFlags.DiscoverySRVTargetCAs, "discovery-srv-target-cacert", nil, "comma separated 'target=ca-file' pairs verifying the SRV targets with their own CA bundle")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.DiscoverySRVVerifyTargetName, "discovery-srv-verify-target-name", false, "verify the certificate of each SRV target against the target host name instead of the SRV domain")
 
 	rootCmd.AddCommand(
 		command.NewGetCommand(),
 		command.NewPutCommand(),
 		command.NewDelCommand(),
 		command.NewTxnCommand(),
+		command.NewCASCommand(),
+		command.NewIncrCommand(),
 		command.NewCompactionCommand(),
 		command.NewAlarmCommand(),
+		command.NewValuePolicyCommand(),
 		command.NewDefragCommand(),
 		command.NewEndpointCommand(),
 		command.NewMoveLeaderCommand(),
@@ -98,6 +114,11 @@ func init() {
 		command.NewCheckCommand(),
 		command.NewCompletionCommand(),
 		command.NewDowngradeCommand(),
+		command.NewClusterCommand(),
+		command.NewConfigCommand(),
+		command.NewLogLevelCommand(),
+		command.NewScrubCommand(),
+		command.NewFeaturesCommand(),
 	)
 }
 
@@ -117,7 +138,7 @@ func MustStart() {
 		if rootCmd.SilenceErrors {
 			cobrautl.ExitWithError(cobrautl.ExitError, err)
 		}
-		os.Exit(cobrautl.ExitError)
+		cobrautl.Exit(cobrautl.ExitError)
 	}
 }
 
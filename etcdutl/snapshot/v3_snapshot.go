@@ -178,9 +178,18 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 					}
 					key := string(kv.Key)
 					// refer to https://etcd.io/docs/v3.5/learning/data_model/
-					if !mvcc.IsTombstone(k) {
+					switch {
+					case mvcc.IsRangeTombstone(k):
+						// the value of a range tombstone holds the end of the range.
+						end := string(kv.Value)
+						for seen := range seenKeys {
+							if seen >= key && (end == "\x00" || seen < end) {
+								delete(seenKeys, seen)
+							}
+						}
+					case !mvcc.IsTombstone(k):
 						seenKeys[key] = struct{}{}
-					} else {
+					default:
 						delete(seenKeys, key)
 					}
 				}
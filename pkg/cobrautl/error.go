@@ -34,7 +34,29 @@ const (
 	ExitClusterNotHealthy = 5
 )
 
+// exitHooks are run before the process exits through ExitWithError or Exit.
+var exitHooks []func(code int, err error)
+
+// RegisterExitHook registers a function run before the process exits through
+// ExitWithError or Exit, e.g. to flush telemetry. err is nil if the process
+// exits through Exit.
+func RegisterExitHook(hook func(code int, err error)) {
+	exitHooks = append(exitHooks, hook)
+}
+
 func ExitWithError(code int, err error) {
 	fmt.Fprintln(os.Stderr, "Error:", err)
+	exit(code, err)
+}
+
+// Exit runs the exit hooks and exits the process with the given code.
+func Exit(code int) {
+	exit(code, nil)
+}
+
+func exit(code int, err error) {
+	for _, hook := range exitHooks {
+		hook(code, err)
+	}
 	os.Exit(code)
 }
@@ -0,0 +1,258 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Types of the profiles captured by the continuous profiler.
+const (
+	ProfileCPU  = "cpu"
+	ProfileHeap = "heap"
+)
+
+const profileFileSuffix = ".pb.gz"
+
+// ContinuousProfilerConfig configures a ContinuousProfiler.
+type ContinuousProfilerConfig struct {
+	// Interval is the time between the start of two captures.
+	Interval time.Duration
+	// CPUDuration is the duration of each CPU profile. It must not exceed
+	// Interval.
+	CPUDuration time.Duration
+
+	// Dir is the directory retaining the last MaxFiles profiles of each type.
+	Dir      string
+	MaxFiles int
+
+	// PushURL is the URL of a pprof-compatible ingestion endpoint, as the
+	// /ingest endpoint of Pyroscope, which the profiles are pushed to instead
+	// of being retained in Dir.
+	PushURL string
+	// AppName is the application name the profiles are pushed under.
+	AppName string
+}
+
+// ContinuousProfiler periodically captures CPU and heap profiles, so that
+// the profiles of transient spikes are available after the fact.
+type ContinuousProfiler struct {
+	lg     *zap.Logger
+	cfg    ContinuousProfilerConfig
+	client *http.Client
+
+	cancel context.CancelFunc
+	donec  chan struct{}
+	once   sync.Once
+}
+
+// NewContinuousProfiler creates a profiler capturing profiles with the given
+// configuration. Start must be called to start capturing.
+func NewContinuousProfiler(lg *zap.Logger, cfg ContinuousProfilerConfig) (*ContinuousProfiler, error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	if cfg.Interval <= 0 || cfg.CPUDuration <= 0 || cfg.CPUDuration > cfg.Interval {
+		return nil, fmt.Errorf("invalid continuous profiling interval %v and CPU duration %v", cfg.Interval, cfg.CPUDuration)
+	}
+	if cfg.PushURL == "" {
+		if cfg.Dir == "" || cfg.MaxFiles <= 0 {
+			return nil, fmt.Errorf("continuous profiling requires a directory retaining a positive number of files, or a push URL")
+		}
+		if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+			return nil, err
+		}
+	} else if _, err := url.Parse(cfg.PushURL); err != nil {
+		return nil, err
+	}
+	return &ContinuousProfiler{
+		lg:     lg,
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Interval},
+		donec:  make(chan struct{}),
+	}, nil
+}
+
+// Start starts capturing profiles in the background until Stop is called.
+func (p *ContinuousProfiler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.run(ctx)
+}
+
+// Stop stops capturing profiles, and waits for the capture in progress.
+func (p *ContinuousProfiler) Stop() {
+	p.once.Do(func() {
+		if p.cancel == nil {
+			close(p.donec)
+			return
+		}
+		p.cancel()
+	})
+	<-p.donec
+}
+
+func (p *ContinuousProfiler) run(ctx context.Context) {
+	defer close(p.donec)
+	p.lg.Info(
+		"started continuous profiling",
+		zap.Duration("interval", p.cfg.Interval),
+		zap.Duration("cpu-duration", p.cfg.CPUDuration),
+		zap.String("dir", p.cfg.Dir),
+		zap.String("push-url", p.cfg.PushURL),
+	)
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		p.capture(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// capture captures a CPU and a heap profile.
+func (p *ContinuousProfiler) capture(ctx context.Context) {
+	start := time.Now()
+	var cpu bytes.Buffer
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		// another CPU profile, as one requested through /debug/pprof, is
+		// in progress.
+		p.lg.Warn("skipped continuous CPU profile", zap.Error(err))
+	} else {
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.cfg.CPUDuration):
+		}
+		pprof.StopCPUProfile()
+		p.save(ProfileCPU, start, time.Now(), cpu.Bytes())
+	}
+
+	var heap bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		p.lg.Warn("failed to capture continuous heap profile", zap.Error(err))
+		return
+	}
+	now := time.Now()
+	p.save(ProfileHeap, now, now, heap.Bytes())
+}
+
+func (p *ContinuousProfiler) save(typ string, from, until time.Time, profile []byte) {
+	var err error
+	if p.cfg.PushURL != "" {
+		err = p.push(typ, from, until, profile)
+	} else {
+		err = p.retain(typ, until, profile)
+	}
+	if err != nil {
+		p.lg.Warn("failed to save continuous profile", zap.String("type", typ), zap.Error(err))
+	}
+}
+
+// retain writes the profile to the profile directory, and removes the oldest
+// profiles of its type beyond the maximum number of files.
+func (p *ContinuousProfiler) retain(typ string, at time.Time, profile []byte) error {
+	name := fmt.Sprintf("%s-%s%s", typ, at.UTC().Format("20060102T150405.000Z"), profileFileSuffix)
+	if err := os.WriteFile(filepath.Join(p.cfg.Dir, name), profile, 0o600); err != nil {
+		return err
+	}
+	profiles, err := ProfileFiles(p.cfg.Dir, typ)
+	if err != nil {
+		return err
+	}
+	for len(profiles) > p.cfg.MaxFiles {
+		if err := os.Remove(profiles[0]); err != nil {
+			return err
+		}
+		profiles = profiles[1:]
+	}
+	return nil
+}
+
+// ProfileFiles returns the paths of the profiles of the given type retained
+// in dir, from the oldest to the newest.
+func ProfileFiles(dir, typ string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), typ+"-") && strings.HasSuffix(e.Name(), profileFileSuffix) {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	// the names hold the capture time, so that they sort by age.
+	sort.Strings(files)
+	return files, nil
+}
+
+// push sends the profile to the ingestion endpoint, following the Pyroscope
+// ingestion protocol.
+func (p *ContinuousProfiler) push(typ string, from, until time.Time, profile []byte) error {
+	u, err := url.Parse(p.cfg.PushURL)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("name", p.cfg.AppName)
+	q.Set("from", strconv.FormatInt(from.Unix(), 10))
+	q.Set("until", strconv.FormatInt(until.Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+	u.RawQuery = q.Encode()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("profile", typ+profileFileSuffix)
+	if err != nil {
+		return err
+	}
+	if _, err = fw.Write(profile); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	resp, err := p.client.Post(u.String(), w.FormDataContentType(), &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushing profile to %s: unexpected status %s", u.Redacted(), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugutil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestContinuousProfilerRetain(t *testing.T) {
+	dir := t.TempDir()
+	p, err := NewContinuousProfiler(zaptest.NewLogger(t), ContinuousProfilerConfig{
+		Interval:    20 * time.Millisecond,
+		CPUDuration: 10 * time.Millisecond,
+		Dir:         dir,
+		MaxFiles:    2,
+	})
+	require.NoError(t, err)
+	p.Start()
+
+	require.Eventually(t, func() bool {
+		files, err := ProfileFiles(dir, ProfileHeap)
+		require.NoError(t, err)
+		return len(files) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// wait for the oldest profiles to be rotated out.
+	time.Sleep(100 * time.Millisecond)
+	p.Stop()
+
+	for _, typ := range []string{ProfileCPU, ProfileHeap} {
+		files, err := ProfileFiles(dir, typ)
+		require.NoError(t, err)
+		assert.Len(t, files, 2, typ)
+	}
+}
+
+func TestContinuousProfilerPush(t *testing.T) {
+	type push struct {
+		name, format, file string
+		size               int
+	}
+	pushc := make(chan push, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, h, err := r.FormFile("profile")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b, _ := io.ReadAll(f)
+		select {
+		case pushc <- push{r.URL.Query().Get("name"), r.URL.Query().Get("format"), h.Filename, len(b)}:
+		default:
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewContinuousProfiler(zaptest.NewLogger(t), ContinuousProfilerConfig{
+		Interval:    time.Hour,
+		CPUDuration: 10 * time.Millisecond,
+		PushURL:     srv.URL + "/ingest",
+		AppName:     "etcd{member=m1}",
+	})
+	require.NoError(t, err)
+	p.Start()
+	defer p.Stop()
+
+	for _, file := range []string{"cpu.pb.gz", "heap.pb.gz"} {
+		select {
+		case got := <-pushc:
+			assert.Equal(t, "etcd{member=m1}", got.name)
+			assert.Equal(t, "pprof", got.format)
+			assert.Equal(t, file, got.file)
+			assert.Positive(t, got.size)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", file)
+		}
+	}
+}
+
+func TestNewContinuousProfilerValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ContinuousProfilerConfig
+	}{
+		{"no interval", ContinuousProfilerConfig{CPUDuration: time.Second, Dir: t.TempDir(), MaxFiles: 1}},
+		{"cpu duration exceeds interval", ContinuousProfilerConfig{Interval: time.Second, CPUDuration: time.Minute, Dir: t.TempDir(), MaxFiles: 1}},
+		{"no destination", ContinuousProfilerConfig{Interval: time.Minute, CPUDuration: time.Second}},
+		{"no retained files", ContinuousProfilerConfig{Interval: time.Minute, CPUDuration: time.Second, Dir: t.TempDir()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewContinuousProfiler(zaptest.NewLogger(t), tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
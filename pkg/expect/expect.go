@@ -39,6 +39,9 @@ var ErrProcessRunning = fmt.Errorf("process is still running")
 type ExpectedResponse struct {
 	Value         string
 	IsRegularExpr bool
+	// Timeout bounds the time waiting for the response, in addition to the
+	// deadline of the context. No timeout if 0.
+	Timeout time.Duration
 }
 
 type ExpectProcess struct {
@@ -48,16 +51,37 @@ type ExpectProcess struct {
 	fpty *os.File
 	wg   sync.WaitGroup
 
-	readCloseCh chan struct{} // close it if async read goroutine exits
-
-	mu       sync.Mutex // protects lines, count, cur, exitErr and exitCode
-	lines    []string
-	count    int   // increment whenever new line gets added
-	cur      int   // current read position
-	exitErr  error // process exit error
+	mu       sync.Mutex // protects stdout, stderr, count, cur, exitErr and exitCode
+	stdout   output
+	stderr   *output // nil unless stderr is captured separately
+	count    int     // increment whenever new line gets added to stdout
+	cur      int     // current read position
+	exitErr  error   // process exit error
 	exitCode int
 }
 
+// output holds the lines read from an output of the process.
+type output struct {
+	name  string
+	lines []string
+	// closeCh is closed when the goroutine reading the output exits.
+	closeCh chan struct{}
+}
+
+func newOutput(name string) *output {
+	return &output{name: name, closeCh: make(chan struct{})}
+}
+
+// Option configures the process of an ExpectProcess.
+type Option func(*expectConfig)
+
+// WithSeparateStderr captures the standard error of the process apart from
+// its standard output, so that it is matched by ExpectStderr instead of
+// Expect. By default, both are captured together.
+func WithSeparateStderr() Option {
+	return func(cfg *expectConfig) { cfg.separateStderr = true }
+}
+
 // NewExpect creates a new process for expect testing.
 func NewExpect(name string, arg ...string) (ep *ExpectProcess, err error) {
 	// if env[] is nil, use current system env and the default command as name
@@ -65,7 +89,7 @@ func NewExpect(name string, arg ...string) (ep *ExpectProcess, err error) {
 }
 
 // NewExpectWithEnv creates a new process with user defined env variables for expect testing.
-func NewExpectWithEnv(name string, args []string, env []string, serverProcessConfigName string) (ep *ExpectProcess, err error) {
+func NewExpectWithEnv(name string, args []string, env []string, serverProcessConfigName string, opts ...Option) (ep *ExpectProcess, err error) {
 	ep = &ExpectProcess{
 		cfg: expectConfig{
 			name: serverProcessConfigName,
@@ -73,10 +97,21 @@ func NewExpectWithEnv(name string, args []string, env []string, serverProcessCon
 			args: args,
 			env:  env,
 		},
-		readCloseCh: make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(&ep.cfg)
+	}
+	ep.stdout = *newOutput("stdout")
 	ep.cmd = commandFromConfig(ep.cfg)
 
+	var stderr io.ReadCloser
+	if ep.cfg.separateStderr {
+		if stderr, err = ep.cmd.StderrPipe(); err != nil {
+			return nil, err
+		}
+		ep.stderr = newOutput("stderr")
+	}
+
 	if ep.fpty, err = pty.Start(ep.cmd); err != nil {
 		return nil, err
 	}
@@ -84,14 +119,19 @@ func NewExpectWithEnv(name string, args []string, env []string, serverProcessCon
 	ep.wg.Add(2)
 	go ep.read()
 	go ep.waitSaveExitErr()
+	if stderr != nil {
+		ep.wg.Add(1)
+		go ep.readStderr(stderr)
+	}
 	return ep, nil
 }
 
 type expectConfig struct {
-	name string
-	cmd  string
-	args []string
-	env  []string
+	name           string
+	cmd            string
+	args           []string
+	env            []string
+	separateStderr bool
 }
 
 func commandFromConfig(config expectConfig) *exec.Cmd {
@@ -109,7 +149,7 @@ func (ep *ExpectProcess) Pid() int {
 func (ep *ExpectProcess) read() {
 	defer func() {
 		ep.wg.Done()
-		close(ep.readCloseCh)
+		close(ep.stdout.closeCh)
 	}()
 	defer func(fpty *os.File) {
 		err := fpty.Close()
@@ -121,14 +161,30 @@ func (ep *ExpectProcess) read() {
 
 	r := bufio.NewReader(ep.fpty)
 	for {
-		err := ep.tryReadNextLine(r)
+		err := ep.tryReadNextLine(r, &ep.stdout)
 		if err != nil {
 			break
 		}
 	}
 }
 
-func (ep *ExpectProcess) tryReadNextLine(r *bufio.Reader) error {
+func (ep *ExpectProcess) readStderr(stderr io.ReadCloser) {
+	defer func() {
+		ep.wg.Done()
+		close(ep.stderr.closeCh)
+	}()
+	defer stderr.Close()
+
+	r := bufio.NewReader(stderr)
+	for {
+		err := ep.tryReadNextLine(r, ep.stderr)
+		if err != nil {
+			break
+		}
+	}
+}
+
+func (ep *ExpectProcess) tryReadNextLine(r *bufio.Reader, out *output) error {
 	printDebugLines := os.Getenv("EXPECT_DEBUG") != ""
 	l, err := r.ReadString('\n')
 
@@ -137,10 +193,12 @@ func (ep *ExpectProcess) tryReadNextLine(r *bufio.Reader) error {
 
 	if l != "" {
 		if printDebugLines {
-			fmt.Printf("%s (%s) (%d): %s", ep.cmd.Path, ep.cfg.name, ep.cmd.Process.Pid, l)
+			fmt.Printf("%s (%s) (%d) (%s): %s", ep.cmd.Path, ep.cfg.name, ep.cmd.Process.Pid, out.name, l)
+		}
+		out.lines = append(out.lines, l)
+		if out == &ep.stdout {
+			ep.count++
 		}
-		ep.lines = append(ep.lines, l)
-		ep.count++
 	}
 
 	// we're checking the error here at the bottom to ensure any leftover reads are still taken into account
@@ -160,6 +218,19 @@ func (ep *ExpectProcess) waitSaveExitErr() {
 
 // ExpectFunc returns the first line satisfying the function f.
 func (ep *ExpectProcess) ExpectFunc(ctx context.Context, f func(string) bool) (string, error) {
+	return ep.expectFunc(ctx, &ep.stdout, f)
+}
+
+// ExpectStderrFunc returns the first line of the standard error satisfying
+// the function f. The process must have been created with WithSeparateStderr.
+func (ep *ExpectProcess) ExpectStderrFunc(ctx context.Context, f func(string) bool) (string, error) {
+	if ep.stderr == nil {
+		return "", errors.New("stderr is not captured separately")
+	}
+	return ep.expectFunc(ctx, ep.stderr, f)
+}
+
+func (ep *ExpectProcess) expectFunc(ctx context.Context, out *output, f func(string) bool) (string, error) {
 	i := 0
 	for {
 		line, errsFound := func() (string, bool) {
@@ -171,8 +242,8 @@ func (ep *ExpectProcess) ExpectFunc(ctx context.Context, f func(string) bool) (s
 				return "", true
 			}
 
-			for i < len(ep.lines) {
-				line := ep.lines[i]
+			for i < len(out.lines) {
+				line := out.lines[i]
 				i++
 				if f(line) {
 					return line, false
@@ -198,8 +269,8 @@ func (ep *ExpectProcess) ExpectFunc(ctx context.Context, f func(string) bool) (s
 	}
 
 	select {
-	// NOTE: we wait readCloseCh for ep.read() to complete draining the log before acquring the lock.
-	case <-ep.readCloseCh:
+	// NOTE: we wait closeCh for the reader to complete draining the log before acquring the lock.
+	case <-out.closeCh:
 	case <-ctx.Done():
 		return "", fmt.Errorf("context done before to found matching log")
 	}
@@ -208,26 +279,39 @@ func (ep *ExpectProcess) ExpectFunc(ctx context.Context, f func(string) bool) (s
 	defer ep.mu.Unlock()
 
 	// retry it since we get all the log data
-	for i < len(ep.lines) {
-		line := ep.lines[i]
+	for i < len(out.lines) {
+		line := out.lines[i]
 		i++
 		if f(line) {
 			return line, nil
 		}
 	}
 
-	lastLinesIndex := len(ep.lines) - debugLinesTail
+	lastLinesIndex := len(out.lines) - debugLinesTail
 	if lastLinesIndex < 0 {
 		lastLinesIndex = 0
 	}
-	lastLines := strings.Join(ep.lines[lastLinesIndex:], "")
+	lastLines := strings.Join(out.lines[lastLinesIndex:], "")
 	return "", fmt.Errorf("match not found. "+
-		" Set EXPECT_DEBUG for more info Errs: [%v], last lines:\n%s",
-		ep.exitErr, lastLines)
+		" Set EXPECT_DEBUG for more info Errs: [%v], last lines of %s:\n%s",
+		ep.exitErr, out.name, lastLines)
 }
 
 // ExpectWithContext returns the first line containing the given string.
 func (ep *ExpectProcess) ExpectWithContext(ctx context.Context, s ExpectedResponse) (string, error) {
+	return ep.expectResponse(ctx, &ep.stdout, s)
+}
+
+// ExpectStderr returns the first line of the standard error containing the
+// given string. The process must have been created with WithSeparateStderr.
+func (ep *ExpectProcess) ExpectStderr(ctx context.Context, s ExpectedResponse) (string, error) {
+	if ep.stderr == nil {
+		return "", errors.New("stderr is not captured separately")
+	}
+	return ep.expectResponse(ctx, ep.stderr, s)
+}
+
+func (ep *ExpectProcess) expectResponse(ctx context.Context, out *output, s ExpectedResponse) (string, error) {
 	var (
 		expr *regexp.Regexp
 		err  error
@@ -238,12 +322,35 @@ func (ep *ExpectProcess) ExpectWithContext(ctx context.Context, s ExpectedRespon
 			return "", err
 		}
 	}
-	return ep.ExpectFunc(ctx, func(txt string) bool {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	line, err := ep.expectFunc(ctx, out, func(txt string) bool {
 		if expr != nil {
 			return expr.MatchString(txt)
 		}
 		return strings.Contains(txt, s.Value)
 	})
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && s.Timeout > 0 {
+		return "", fmt.Errorf("%q not found within %v: %w", s.Value, s.Timeout, err)
+	}
+	return line, err
+}
+
+// ExpectRegexp returns the submatches of the regular expression in the first
+// line matching it, the first submatch being the whole match.
+func (ep *ExpectProcess) ExpectRegexp(ctx context.Context, expr string) ([]string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	line, err := ep.ExpectFunc(ctx, re.MatchString)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindStringSubmatch(line), nil
 }
 
 // Expect returns the first line containing the given string.
@@ -375,7 +482,18 @@ func (ep *ExpectProcess) Send(command string) error {
 func (ep *ExpectProcess) Lines() []string {
 	ep.mu.Lock()
 	defer ep.mu.Unlock()
-	return ep.lines
+	return ep.stdout.lines
+}
+
+// StderrLines returns the lines of the standard error, when captured
+// separately with WithSeparateStderr.
+func (ep *ExpectProcess) StderrLines() []string {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.stderr == nil {
+		return nil
+	}
+	return ep.stderr.lines
 }
 
 // ReadLine returns line by line.
@@ -383,7 +501,7 @@ func (ep *ExpectProcess) ReadLine() string {
 	ep.mu.Lock()
 	defer ep.mu.Unlock()
 	if ep.count > ep.cur {
-		line := ep.lines[ep.cur]
+		line := ep.stdout.lines[ep.cur]
 		ep.cur++
 		return line
 	}
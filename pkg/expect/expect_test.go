@@ -222,3 +222,69 @@ func TestResponseMatchRegularExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestExpectRegexp(t *testing.T) {
+	ep, err := NewExpect("echo", "revision: 42, member: 8e9e05c52164694d")
+	require.NoError(t, err)
+	m, err := ep.ExpectRegexp(context.Background(), `revision: (\d+), member: ([0-9a-f]+)`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"revision: 42, member: 8e9e05c52164694d", "42", "8e9e05c52164694d"}, m)
+	require.NoError(t, ep.Close())
+
+	ep, err = NewExpect("echo", "hello world")
+	require.NoError(t, err)
+	_, err = ep.ExpectRegexp(context.Background(), `(`)
+	require.Error(t, err)
+	require.NoError(t, ep.Close())
+}
+
+func TestExpectResponseTimeout(t *testing.T) {
+	ep, err := NewExpect("sleep", "100")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, ep.Stop())
+		ep.Close()
+	}()
+
+	start := time.Now()
+	// the timeout of the expectation is shorter than the context deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = ep.ExpectWithContext(ctx, ExpectedResponse{Value: "never", Timeout: 200 * time.Millisecond})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, `"never" not found within 200ms`)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestExpectSeparateStderr(t *testing.T) {
+	ep, err := NewExpectWithEnv("sh", []string{"-c", "echo out; echo err >&2; exit 1"}, nil, "sh", WithSeparateStderr())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	l, err := ep.ExpectStderr(ctx, ExpectedResponse{Value: "err"})
+	require.NoError(t, err)
+	require.Equal(t, "err\n", l)
+	_, err = ep.ExpectWithContext(ctx, ExpectedResponse{Value: "out"})
+	require.NoError(t, err)
+
+	// stdout and stderr don't mix.
+	_, err = ep.ExpectWithContext(ctx, ExpectedResponse{Value: "err"})
+	require.ErrorContains(t, err, "match not found")
+	_, err = ep.ExpectStderr(ctx, ExpectedResponse{Value: "out"})
+	require.ErrorContains(t, err, "match not found")
+
+	require.ErrorContains(t, ep.Close(), "unexpected exit code [1]")
+	require.Equal(t, []string{"out\r\n"}, ep.Lines())
+	require.Equal(t, []string{"err\n"}, ep.StderrLines())
+}
+
+func TestExpectStderrNotSeparate(t *testing.T) {
+	ep, err := NewExpect("sh", "-c", "echo err >&2")
+	require.NoError(t, err)
+	_, err = ep.ExpectWithContext(context.Background(), ExpectedResponse{Value: "err"})
+	require.NoError(t, err)
+	_, err = ep.ExpectStderr(context.Background(), ExpectedResponse{Value: "err"})
+	require.Error(t, err)
+	require.NoError(t, ep.Close())
+}
@@ -68,6 +68,9 @@ type FeatureSpec struct {
 	LockToDefault bool
 	// PreRelease indicates the maturity level of the feature
 	PreRelease prerelease
+	// Runtime indicates that the feature is checked each time it is used, so it
+	// is safe to toggle while the component runs
+	Runtime bool
 }
 
 type prerelease string
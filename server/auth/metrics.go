@@ -37,8 +37,18 @@ var (
 	// overridden by auth store initialization
 	reportCurrentAuthRevMu sync.RWMutex
 	reportCurrentAuthRev   = func() float64 { return 0 }
+
+	rangePermCacheRebuilds = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "auth",
+			Name:      "range_perm_cache_rebuilds_total",
+			Help:      "The total number of users whose cached permissions were rebuilt.",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(currentAuthRevision)
+	prometheus.MustRegister(rangePermCacheRebuilds)
 }
@@ -15,14 +15,36 @@
 package auth
 
 import (
+	"slices"
+
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/api/v3/authpb"
 	"go.etcd.io/etcd/pkg/v3/adt"
 )
 
-func getMergedPerms(tx UnsafeAuthReader, userName string) *unifiedRangePermissions {
-	user := tx.UnsafeGetUser(userName)
+// roleReader reads roles from the backend, reading each role once across the
+// users whose permissions are merged together.
+type roleReader struct {
+	tx    UnsafeAuthReader
+	roles map[string]*authpb.Role
+}
+
+func newRoleReader(tx UnsafeAuthReader) *roleReader {
+	return &roleReader{tx: tx, roles: make(map[string]*authpb.Role)}
+}
+
+func (rr *roleReader) role(roleName string) *authpb.Role {
+	role, ok := rr.roles[roleName]
+	if !ok {
+		role = rr.tx.UnsafeGetRole(roleName)
+		rr.roles[roleName] = role
+	}
+	return role
+}
+
+func getMergedPerms(rr *roleReader, userName string) *unifiedRangePermissions {
+	user := rr.tx.UnsafeGetUser(userName)
 	if user == nil {
 		return nil
 	}
@@ -31,7 +53,7 @@ func getMergedPerms(tx UnsafeAuthReader, userName string) *unifiedRangePermissio
 	writePerms := adt.NewIntervalTree()
 
 	for _, roleName := range user.Roles {
-		role := tx.UnsafeGetRole(roleName)
+		role := rr.role(roleName)
 		if role == nil {
 			continue
 		}
@@ -67,6 +89,7 @@ func getMergedPerms(tx UnsafeAuthReader, userName string) *unifiedRangePermissio
 	return &unifiedRangePermissions{
 		readPerms:  readPerms,
 		writePerms: writePerms,
+		roles:      user.Roles,
 	}
 }
 
@@ -124,14 +147,13 @@ func (as *authStore) isRangeOpPermitted(userName string, key, rangeEnd []byte, p
 	if len(rangeEnd) == 0 {
 		return checkKeyPoint(as.lg, rangePerm, key, permtyp)
 	}
-
 	return checkKeyInterval(as.lg, rangePerm, key, rangeEnd, permtyp)
 }
 
 func (as *authStore) refreshRangePermCache(tx UnsafeAuthReader) {
-	// Note that every authentication configuration update calls this method and it invalidates the entire
-	// rangePermCache and reconstruct it based on information of users and roles stored in the backend.
-	// This can be a costly operation.
+	// Note that this method reconstructs the entire rangePermCache based on information of users and roles
+	// stored in the backend. This can be a costly operation, so configuration updates only refresh the
+	// users they affect with refreshUserRangePermCache() and refreshRoleRangePermCache().
 	as.rangePermCacheMu.Lock()
 	defer as.rangePermCacheMu.Unlock()
 
@@ -139,10 +161,11 @@ func (as *authStore) refreshRangePermCache(tx UnsafeAuthReader) {
 
 	as.rangePermCache = make(map[string]*unifiedRangePermissions)
 
+	rr := newRoleReader(tx)
 	users := tx.UnsafeGetAllUsers()
 	for _, user := range users {
 		userName := string(user.Name)
-		perms := getMergedPerms(tx, userName)
+		perms := getMergedPerms(rr, userName)
 		if perms == nil {
 			as.lg.Error(
 				"failed to create a merged permission",
@@ -152,11 +175,55 @@ func (as *authStore) refreshRangePermCache(tx UnsafeAuthReader) {
 		}
 		as.rangePermCache[userName] = perms
 	}
+	rangePermCacheRebuilds.Add(float64(len(as.rangePermCache)))
+}
+
+// refreshUserRangePermCache rebuilds the cached permissions of the given
+// users, dropping the users that do not exist anymore.
+func (as *authStore) refreshUserRangePermCache(tx UnsafeAuthReader, userNames ...string) {
+	as.rangePermCacheMu.Lock()
+	defer as.rangePermCacheMu.Unlock()
+
+	as.refreshUsersLocked(newRoleReader(tx), userNames)
+}
+
+// refreshRoleRangePermCache rebuilds the cached permissions of the users
+// granted the given role.
+func (as *authStore) refreshRoleRangePermCache(tx UnsafeAuthReader, roleName string) {
+	as.rangePermCacheMu.Lock()
+	defer as.rangePermCacheMu.Unlock()
+
+	var userNames []string
+	for userName, perms := range as.rangePermCache {
+		if slices.Contains(perms.roles, roleName) {
+			userNames = append(userNames, userName)
+		}
+	}
+	as.refreshUsersLocked(newRoleReader(tx), userNames)
+}
+
+func (as *authStore) refreshUsersLocked(rr *roleReader, userNames []string) {
+	as.lg.Debug("Refreshing rangePermCache of users", zap.Strings("user-names", userNames))
+
+	for _, userName := range userNames {
+		perms := getMergedPerms(rr, userName)
+		if perms == nil {
+			delete(as.rangePermCache, userName)
+			continue
+		}
+		as.rangePermCache[userName] = perms
+		rangePermCacheRebuilds.Inc()
+	}
 }
 
+// unifiedRangePermissions are the permissions of a user merged from all the
+// roles of the user into interval trees. They are immutable once built and
+// replaced when the user or one of its roles is updated.
 type unifiedRangePermissions struct {
 	readPerms  adt.IntervalTree
 	writePerms adt.IntervalTree
+	// roles are the roles of the user the permissions are merged from
+	roles []string
 }
 
 // Constraints related to key range
@@ -17,9 +17,11 @@ package auth
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"go.etcd.io/etcd/api/v3/authpb"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/pkg/v3/adt"
 )
 
@@ -219,3 +221,36 @@ func TestRangeCheck(t *testing.T) {
 		})
 	}
 }
+
+// TestRangePermCacheIncrementalRefresh ensures that updating a role only
+// rebuilds the cached permissions of the users granted the role.
+func TestRangePermCacheIncrementalRefresh(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	_, err := as.UserGrantRole(&pb.AuthUserGrantRoleRequest{User: "foo", Role: "role-test"})
+	require.NoError(t, err)
+
+	as.rangePermCacheMu.RLock()
+	foo := as.rangePermCache["foo"]
+	other := as.rangePermCache["foo-no-user-options"]
+	as.rangePermCacheMu.RUnlock()
+
+	require.False(t, as.isRangeOpPermitted("foo", []byte("k"), nil, authpb.READ))
+
+	_, err = as.RoleGrantPermission(&pb.AuthRoleGrantPermissionRequest{
+		Name: "role-test",
+		Perm: &authpb.Permission{PermType: authpb.READ, Key: []byte("k")},
+	})
+	require.NoError(t, err)
+
+	as.rangePermCacheMu.RLock()
+	require.NotSame(t, foo, as.rangePermCache["foo"], "expected the permissions of a user of the role to be rebuilt")
+	require.Same(t, other, as.rangePermCache["foo-no-user-options"], "expected the permissions of other users to be kept")
+	as.rangePermCacheMu.RUnlock()
+	require.True(t, as.isRangeOpPermitted("foo", []byte("k"), nil, authpb.READ))
+
+	_, err = as.RoleDelete(&pb.AuthRoleDeleteRequest{Role: "role-test"})
+	require.NoError(t, err)
+	require.False(t, as.isRangeOpPermitted("foo", []byte("k"), nil, authpb.READ))
+}
@@ -458,7 +458,7 @@ func (as *authStore) UserAdd(r *pb.AuthUserAddRequest) (*pb.AuthUserAddResponse,
 	tx.UnsafePutUser(newUser)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshUserRangePermCache(tx, r.Name)
 
 	as.lg.Info("added a user", zap.String("user-name", r.Name))
 	return &pb.AuthUserAddResponse{}, nil
@@ -481,7 +481,7 @@ func (as *authStore) UserDelete(r *pb.AuthUserDeleteRequest) (*pb.AuthUserDelete
 	tx.UnsafeDeleteUser(r.Name)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshUserRangePermCache(tx, r.Name)
 
 	as.tokenProvider.invalidateUser(r.Name)
 
@@ -523,7 +523,6 @@ func (as *authStore) UserChangePassword(r *pb.AuthUserChangePasswordRequest) (*p
 	tx.UnsafePutUser(updatedUser)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
 
 	as.tokenProvider.invalidateUser(r.Name)
 
@@ -569,7 +568,7 @@ func (as *authStore) UserGrantRole(r *pb.AuthUserGrantRoleRequest) (*pb.AuthUser
 	tx.UnsafePutUser(user)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshUserRangePermCache(tx, r.User)
 
 	as.lg.Info(
 		"granted a role to a user",
@@ -640,7 +639,7 @@ func (as *authStore) UserRevokeRole(r *pb.AuthUserRevokeRoleRequest) (*pb.AuthUs
 	tx.UnsafePutUser(updatedUser)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshUserRangePermCache(tx, r.Name)
 
 	as.lg.Info(
 		"revoked a role from a user",
@@ -704,7 +703,7 @@ func (as *authStore) RoleRevokePermission(r *pb.AuthRoleRevokePermissionRequest)
 	tx.UnsafePutRole(updatedRole)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshRoleRangePermCache(tx, r.Role)
 
 	as.lg.Info(
 		"revoked a permission on range",
@@ -754,7 +753,7 @@ func (as *authStore) RoleDelete(r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDelete
 	}
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshRoleRangePermCache(tx, r.Role)
 
 	as.lg.Info("deleted a role", zap.String("role-name", r.Role))
 	return &pb.AuthRoleDeleteResponse{}, nil
@@ -843,7 +842,7 @@ func (as *authStore) RoleGrantPermission(r *pb.AuthRoleGrantPermissionRequest) (
 	tx.UnsafePutRole(role)
 
 	as.commitRevision(tx)
-	as.refreshRangePermCache(tx)
+	as.refreshRoleRangePermCache(tx, r.Name)
 
 	as.lg.Info(
 		"granted/updated a permission to a user",
@@ -22,10 +22,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/jonboulle/clockwork"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 
 	bolt "go.etcd.io/bbolt"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/pkg/v3/featuregate"
@@ -38,6 +42,10 @@ const (
 	grpcOverheadBytes = 512 * 1024
 )
 
+// PostApplyHook is called with the raft index, request, response and error
+// of each applied request. It runs on the apply loop, so it must not block.
+type PostApplyHook func(index uint64, req *pb.InternalRaftRequest, resp proto.Message, err error)
+
 // ServerConfig holds the configuration of etcd as taken from the command line or discovery.
 type ServerConfig struct {
 	Name string
@@ -69,6 +77,18 @@ type ServerConfig struct {
 	BackendBatchInterval time.Duration
 	// BackendBatchLimit is the maximum operations before commit the backend transaction.
 	BackendBatchLimit int
+	// BackendBatchLimitMax is the maximum the backend batch limit grows to
+	// under load. The batch limit is fixed if it is not greater than
+	// BackendBatchLimit.
+	BackendBatchLimitMax int
+
+	// WALGroupCommitDelay is the maximum time the leader holds back the WAL
+	// save after saving entries to group the entries proposed meanwhile into
+	// a single fsync. Zero disables group commit.
+	WALGroupCommitDelay time.Duration
+	// WALGroupCommitEntries is the number of proposals after which the leader
+	// stops holding back the WAL save before WALGroupCommitDelay expires.
+	WALGroupCommitEntries int
 
 	// BackendFreelistType is the type of the backend boltdb freelist.
 	BackendFreelistType bolt.FreelistType
@@ -78,6 +98,14 @@ type ServerConfig struct {
 	NewCluster          bool
 	PeerTLSInfo         transport.TLSInfo
 
+	// BootstrapFromPeer bootstraps a member joining an existing cluster from
+	// a snapshot of the backend and the raft log of a running member, instead
+	// of waiting for the leader to send a raft snapshot.
+	BootstrapFromPeer bool
+	// BootstrapFromPeerBytesPerSecond is the maximum rate at which the
+	// snapshot is fetched, zero meaning no limit.
+	BootstrapFromPeerBytesPerSecond uint
+
 	CORS map[string]struct{}
 
 	// HostWhitelist lists acceptable hostnames from client requests.
@@ -126,6 +154,10 @@ type ServerConfig struct {
 	QuotaBackendBytes       int64
 	MaxTxnOps               uint
 
+	// AutoCompactionPrefixRetentions override AutoCompactionRetention for
+	// the keys under the prefixes.
+	AutoCompactionPrefixRetentions map[string]time.Duration
+
 	// MaxRequestBytes is the maximum request size to send over raft.
 	MaxRequestBytes uint
 
@@ -133,6 +165,21 @@ type ServerConfig struct {
 	// streams that each client can open at a time.
 	MaxConcurrentStreams uint32
 
+	// MaxStreamsPerConnection is the maximum number of concurrent streams of
+	// a client connection, zero meaning no limit. Unlike MaxConcurrentStreams,
+	// which clients wait on, exceeding it fails the stream with a typed error.
+	MaxStreamsPerConnection uint
+
+	// MaxWatchersPerStream is the maximum number of watchers of a watch
+	// stream, zero meaning no limit.
+	MaxWatchersPerStream uint
+	// MaxWatchersPerUser is the maximum number of watchers of an
+	// authenticated user across all watch streams, zero meaning no limit.
+	MaxWatchersPerUser uint
+	// MaxWatcherBytesPerSecond is the maximum rate at which the events of a
+	// watcher are sent, zero meaning no limit.
+	MaxWatcherBytesPerSecond uint
+
 	WarningApplyDuration        time.Duration
 	WarningUnaryRequestDuration time.Duration
 
@@ -159,6 +206,8 @@ type ServerConfig struct {
 
 	// Logger logs server-side operations.
 	Logger *zap.Logger
+	// LogLevels overrides the log level of subsystems at runtime, if set.
+	LogLevels *logutil.SubsystemLevels
 
 	ForceNewCluster bool
 
@@ -172,6 +221,9 @@ type ServerConfig struct {
 	// TracerOptions are options for OpenTelemetry gRPC interceptor.
 	TracerOptions []otelgrpc.Option
 
+	// PostApplyHooks are called, in order, after each request is applied.
+	PostApplyHooks []PostApplyHook
+
 	WatchProgressNotifyInterval time.Duration
 
 	// UnsafeNoFsync disables all uses of fsync.
@@ -207,11 +259,57 @@ type ServerConfig struct {
 	// ExperimentalLocalAddress is the local IP address to use when communicating with a peer.
 	ExperimentalLocalAddress string `json:"experimental-local-address"`
 
+	// LearnerAutoPromoteMaxLag is the maximum number of raft entries a learner may
+	// lag behind the leader to be considered caught up for automatic promotion.
+	LearnerAutoPromoteMaxLag uint64 `json:"learner-auto-promote-max-lag"`
+	// LearnerAutoPromoteDuration is how long a learner needs to stay caught up
+	// with the leader before it is automatically promoted.
+	LearnerAutoPromoteDuration time.Duration `json:"learner-auto-promote-duration"`
+
+	// LeadershipPriority is the preference of this member to become the leader.
+	// It is published to the cluster as part of the member attributes.
+	LeadershipPriority int32 `json:"leadership-priority"`
+
+	// ReadReplica marks this member as a read replica. A read replica is a
+	// learner that is never promoted and serves serializable reads only.
+	ReadReplica bool `json:"read-replica"`
+	// ReadReplicaMaxStaleness is the maximum staleness of the data served by
+	// a read replica. Serializable reads are rejected beyond this bound.
+	ReadReplicaMaxStaleness time.Duration `json:"read-replica-max-staleness"`
+
+	// LeaderLeaseMaxClockDrift is the maximum clock drift between members
+	// tolerated by lease based reads. The leader lease is shortened by it.
+	LeaderLeaseMaxClockDrift time.Duration `json:"leader-lease-max-clock-drift"`
+
+	// ReadIndexBatchingWindow is how long the server waits to batch
+	// linearizable reads before requesting a read index.
+	ReadIndexBatchingWindow time.Duration `json:"read-index-batching-window"`
+
+	// LeaseGracePeriod is the minimum time leases live after this member
+	// becomes the leader, so that a short unavailability does not expire
+	// them. Zero extends them by the election timeout only.
+	LeaseGracePeriod time.Duration `json:"lease-grace-period"`
+
 	// ServerFeatureGate is a server level feature gate
 	ServerFeatureGate featuregate.FeatureGate
 
+	// Clock drives the raft ticks, the expiry of leases and the auto
+	// compaction. It defaults to the real clock, tests inject a fake clock to
+	// advance time without waiting.
+	Clock clockwork.Clock
+
 	// Metrics types of metrics - should be either 'basic' or 'extensive'
 	Metrics string
+	// MetricsKeyPrefixes are the key prefixes whose requests, watch events
+	// and keys are exported as metrics labeled by prefix.
+	MetricsKeyPrefixes []string
+	// MetricsKeyPrefixSizeBuckets are the buckets of the histogram of the
+	// size of the put kv pairs of the monitored key prefixes.
+	MetricsKeyPrefixSizeBuckets []float64
+
+	// HealthExcludedChecks are the /livez and /readyz checks not run unless
+	// they are queried individually.
+	HealthExcludedChecks []string
 }
 
 // VerifyBootstrap sanity-checks the initial config for bootstrap case
@@ -15,6 +15,7 @@
 package embed
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"flag"
@@ -47,6 +48,7 @@ import (
 	"go.etcd.io/etcd/pkg/v3/netutil"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clouddiscovery"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
@@ -62,6 +64,7 @@ const (
 	DefaultMaxSnapshots                = 5
 	DefaultMaxWALs                     = 5
 	DefaultMaxTxnOps                   = uint(128)
+	DefaultWALGroupCommitEntries       = 128
 	DefaultWarningApplyDuration        = 100 * time.Millisecond
 	DefaultWarningUnaryRequestDuration = 300 * time.Millisecond
 	DefaultMaxRequestBytes             = 1.5 * 1024 * 1024
@@ -74,6 +77,10 @@ const (
 	DefaultAutoCompactionRetention     = "0"
 	DefaultAuthToken                   = "simple"
 	DefaultCompactHashCheckTime        = time.Minute
+	DefaultLearnerAutoPromoteMaxLag    = 1000
+	DefaultLearnerAutoPromoteDuration  = 30 * time.Second
+	DefaultReadReplicaMaxStaleness     = 10 * time.Second
+	DefaultLeaderLeaseMaxClockDrift    = 200 * time.Millisecond
 	DefaultLoggingFormat               = "json"
 
 	DefaultDiscoveryDialTimeout       = 2 * time.Second
@@ -81,9 +88,14 @@ const (
 	DefaultDiscoveryKeepAliveTime     = 2 * time.Second
 	DefaultDiscoveryKeepAliveTimeOut  = 6 * time.Second
 	DefaultDiscoveryInsecureTransport = true
+	DefaultDiscoveryProviderTimeout   = 5 * time.Minute
 	DefaultSelfSignedCertValidity     = 1
 	DefaultTLSMinVersion              = string(tlsutil.TLSVersion12)
 
+	DefaultContinuousProfilingInterval    = time.Minute
+	DefaultContinuousProfilingCPUDuration = 10 * time.Second
+	DefaultContinuousProfilingMaxFiles    = 10
+
 	DefaultListenPeerURLs   = "http://localhost:2380"
 	DefaultListenClientURLs = "http://localhost:2379"
 
@@ -265,6 +277,20 @@ type Config struct {
 	BackendBatchInterval time.Duration `json:"backend-batch-interval"`
 	// BackendBatchLimit is the maximum operations before commit the backend transaction.
 	BackendBatchLimit int `json:"backend-batch-limit"`
+	// BackendBatchLimitMax is the maximum the backend batch limit grows to
+	// while the operations fill the backend transaction before the batch
+	// interval expires, trading a bounded commit latency for throughput. The
+	// batch limit is fixed if it is not greater than the batch limit.
+	BackendBatchLimitMax int `json:"backend-batch-limit-max"`
+	// WALGroupCommitDelay is the maximum time the leader holds back the WAL
+	// save after saving entries, so that the entries proposed meanwhile are
+	// saved with a single fsync. It must be shorter than the heartbeat
+	// interval, since the raft messages are held back too. Zero disables
+	// group commit.
+	WALGroupCommitDelay time.Duration `json:"wal-group-commit-delay"`
+	// WALGroupCommitEntries is the number of proposals after which the leader
+	// stops holding back the WAL save before the group commit delay expires.
+	WALGroupCommitEntries int `json:"wal-group-commit-entries"`
 	// BackendFreelistType specifies the type of freelist that boltdb backend uses (array and map are supported types).
 	BackendFreelistType string `json:"backend-bbolt-freelist-type"`
 	QuotaBackendBytes   int64  `json:"quota-backend-bytes"`
@@ -275,6 +301,22 @@ type Config struct {
 	// streams that each client can open at a time.
 	MaxConcurrentStreams uint32 `json:"max-concurrent-streams"`
 
+	// MaxStreamsPerConnection is the maximum number of concurrent streams of
+	// a client connection. Exceeding it fails the stream with a typed error
+	// instead of waiting as clients do for MaxConcurrentStreams. Zero means
+	// no limit.
+	MaxStreamsPerConnection uint `json:"max-streams-per-connection"`
+
+	// MaxWatchersPerStream is the maximum number of watchers of a watch
+	// stream. Zero means no limit.
+	MaxWatchersPerStream uint `json:"max-watchers-per-stream"`
+	// MaxWatchersPerUser is the maximum number of watchers of an
+	// authenticated user across all watch streams. Zero means no limit.
+	MaxWatchersPerUser uint `json:"max-watchers-per-user"`
+	// MaxWatcherBytesPerSecond is the maximum rate at which the events of a
+	// watcher are sent. Zero means no limit.
+	MaxWatcherBytesPerSecond uint `json:"max-watcher-bytes-per-second"`
+
 	//revive:disable:var-naming
 	ListenPeerUrls, ListenClientUrls, ListenClientHttpUrls []url.URL
 	AdvertisePeerUrls, AdvertiseClientUrls                 []url.URL
@@ -308,13 +350,36 @@ type Config struct {
 	DNSClusterServiceName string `json:"discovery-srv-name"`
 	Dproxy                string `json:"discovery-proxy"`
 
+	// DNSClusterTargetCAs are "target=ca-file" pairs verifying the peers
+	// discovered through SRV records with their own CA bundle.
+	DNSClusterTargetCAs []string `json:"discovery-srv-target-cacert"`
+	// DNSClusterVerifyTargetName verifies the certificate of each peer
+	// discovered through SRV records against its target host name instead of
+	// the SRV domain.
+	DNSClusterVerifyTargetName bool `json:"discovery-srv-verify-target-name"`
+	// DNSClusterRefreshInterval is the interval at which the SRV records are
+	// re-resolved while bootstrapping until they list the local member. The
+	// bootstrap fails right away if it is 0.
+	DNSClusterRefreshInterval time.Duration `json:"discovery-srv-refresh-interval"`
+
 	Durl         string                      `json:"discovery"`
 	DiscoveryCfg v3discovery.DiscoveryConfig `json:"discovery-config"`
 
+	// CloudDiscoveryCfg configures discovering the initial cluster from the
+	// instances of a cloud provider or the endpoints of a Kubernetes Service.
+	CloudDiscoveryCfg clouddiscovery.Config `json:"discovery-provider-config"`
+
 	InitialCluster      string `json:"initial-cluster"`
 	InitialClusterToken string `json:"initial-cluster-token"`
 	StrictReconfigCheck bool   `json:"strict-reconfig-check"`
 
+	// BootstrapFromPeer bootstraps a member joining an existing cluster with
+	// an empty data dir from a snapshot streamed from a running member.
+	BootstrapFromPeer bool `json:"bootstrap-from-peer"`
+	// BootstrapFromPeerBytesPerSecond is the maximum rate at which the
+	// snapshot is fetched. Zero means no limit.
+	BootstrapFromPeerBytesPerSecond uint `json:"bootstrap-from-peer-bytes-per-second"`
+
 	// AutoCompactionMode is either 'periodic' or 'revision'.
 	AutoCompactionMode string `json:"auto-compaction-mode"`
 	// AutoCompactionRetention is either duration string with time unit
@@ -322,6 +387,10 @@ type Config struct {
 	// If no time unit is provided and compaction mode is 'periodic',
 	// the unit defaults to hour. For example, '5' translates into 5-hour.
 	AutoCompactionRetention string `json:"auto-compaction-retention"`
+	// AutoCompactionPrefixRetentions override AutoCompactionRetention for
+	// the keys under the prefixes, as 'prefix=retention' in the same unit
+	// (e.g. '/audit/=24h' or '/cache/=0' to keep only the latest revision).
+	AutoCompactionPrefixRetentions []string `json:"auto-compaction-prefix-retentions"`
 
 	// GRPCKeepAliveMinTime is the minimum interval that a client should
 	// wait before pinging server. When client pings "too fast", server
@@ -345,9 +414,24 @@ type Config struct {
 	// https://github.com/etcd-io/etcd/pull/14066#issuecomment-1248682996
 	GRPCAdditionalServerOptions []grpc.ServerOption `json:"grpc-additional-server-options"`
 
+	// GRPCUnaryInterceptors are additional unary interceptors of the client
+	// gRPC server. They run in order, after the built-in interceptors.
+	GRPCUnaryInterceptors []grpc.UnaryServerInterceptor `json:"-"`
+	// GRPCStreamInterceptors are additional stream interceptors of the client
+	// gRPC server. They run in order, after the built-in interceptors.
+	GRPCStreamInterceptors []grpc.StreamServerInterceptor `json:"-"`
+
+	// PostApplyHooks are called, in order, after each request is applied to
+	// the local member. See config.PostApplyHook.
+	PostApplyHooks []config.PostApplyHook `json:"-"`
+
 	// SocketOpts are socket options passed to listener config.
 	SocketOpts transport.SocketOpts `json:"socket-options"`
 
+	// SocketActivation uses the listeners passed by systemd socket activation
+	// (LISTEN_FDS) for the peer, client and metrics URLs they are bound to.
+	SocketActivation bool `json:"socket-activation"`
+
 	// PreVote is true to enable Raft Pre-Vote.
 	// If enabled, Raft runs an additional election phase
 	// to check whether it would get enough votes to win
@@ -395,6 +479,9 @@ type Config struct {
 	//	}
 	//	embed.StartEtcd(cfg)
 	ServiceRegister func(*grpc.Server) `json:"-"`
+	// Plugins are registered on the client gRPC servers like ServiceRegister,
+	// with read access to the key-value store.
+	Plugins []Plugin `json:"-"`
 
 	AuthToken  string `json:"auth-token"`
 	BcryptCost uint   `json:"bcrypt-cost"`
@@ -476,6 +563,46 @@ type Config struct {
 	// MaxLearners sets a limit to the number of learner members that can exist in the cluster membership.
 	MaxLearners int `json:"max-learners"`
 
+	// LearnerAutoPromoteMaxLag is the maximum number of raft entries a learner may
+	// lag behind the leader to be considered caught up for automatic promotion.
+	// Requires the LearnerAutoPromote feature gate to be enabled.
+	LearnerAutoPromoteMaxLag uint64 `json:"learner-auto-promote-max-lag"`
+	// LearnerAutoPromoteDuration is how long a learner needs to stay caught up
+	// with the leader before it is automatically promoted.
+	// Requires the LearnerAutoPromote feature gate to be enabled.
+	LearnerAutoPromoteDuration time.Duration `json:"learner-auto-promote-duration"`
+
+	// LeadershipPriority is the preference of this member to become the leader.
+	// When the LeadershipPriority feature gate is enabled, the leader transfers
+	// leadership to a healthy voting member with a higher priority.
+	LeadershipPriority int `json:"leadership-priority"`
+
+	// ReadReplica marks this member as a read replica. A read replica must be
+	// added to the cluster as a learner; it is never promoted to a voting
+	// member and serves serializable reads only.
+	ReadReplica bool `json:"read-replica"`
+	// ReadReplicaMaxStaleness is the maximum time a read replica may lag behind
+	// the leader. Serializable reads are rejected once the bound is exceeded.
+	ReadReplicaMaxStaleness time.Duration `json:"read-replica-max-staleness"`
+
+	// LeaderLeaseMaxClockDrift is the maximum clock drift between members
+	// tolerated by lease based reads. The leader lease, bounded by the election
+	// timeout, is shortened by it. Requires the LeaseBasedReads feature gate.
+	LeaderLeaseMaxClockDrift time.Duration `json:"leader-lease-max-clock-drift"`
+
+	// ReadIndexBatchingWindow is how long the server waits to batch linearizable
+	// reads before requesting a read index. A longer window adds up to the window
+	// to the latency of linearizable reads but needs fewer read index requests.
+	// By default, linearizable reads are batched while a read index request is
+	// in flight only.
+	ReadIndexBatchingWindow time.Duration `json:"read-index-batching-window"`
+
+	// LeaseGracePeriod is the minimum time leases live after a leader
+	// election or a restart of the leader, so that a short unavailability of
+	// the cluster does not expire the leases of clients that could not renew
+	// them. By default, leases are only extended by the election timeout.
+	LeaseGracePeriod time.Duration `json:"lease-grace-period"`
+
 	// ForceNewCluster starts a new cluster even if previously started; unsafe.
 	ForceNewCluster bool `json:"force-new-cluster"`
 
@@ -483,6 +610,34 @@ type Config struct {
 	Metrics               string `json:"metrics"`
 	ListenMetricsUrls     []url.URL
 	ListenMetricsUrlsJSON string `json:"listen-metrics-urls"`
+	// MetricsKeyPrefixes are the key prefixes whose requests, watch events
+	// and keys are exported as metrics labeled by prefix. Each prefix adds
+	// a label value to the metrics, so the list should be kept short.
+	MetricsKeyPrefixes []string `json:"metrics-key-prefixes"`
+	// MetricsKeyPrefixSizeBuckets are the upper bounds in bytes of the
+	// buckets of the histogram of the size of the put kv pairs of the
+	// monitored key prefixes. Defaults to exponential buckets from 64B to 1MiB.
+	MetricsKeyPrefixSizeBuckets []float64 `json:"metrics-key-prefix-size-buckets"`
+	// HealthExcludedChecks are the names of the checks that /livez and
+	// /readyz do not run, e.g. "lessor". The excluded checks can still be
+	// queried individually, e.g. on /readyz/lessor.
+	HealthExcludedChecks []string `json:"health-excluded-checks"`
+
+	// EnableContinuousProfiling periodically captures CPU and heap profiles.
+	// The profiles are pushed to ContinuousProfilingPushURL if set, and
+	// otherwise retained in ContinuousProfilingDir.
+	EnableContinuousProfiling bool `json:"enable-continuous-profiling"`
+	// ContinuousProfilingInterval is the time between two captures.
+	ContinuousProfilingInterval time.Duration `json:"continuous-profiling-interval"`
+	// ContinuousProfilingCPUDuration is the duration of each CPU profile.
+	ContinuousProfilingCPUDuration time.Duration `json:"continuous-profiling-cpu-duration"`
+	// ContinuousProfilingDir is the directory retaining the last
+	// ContinuousProfilingMaxFiles profiles of each type.
+	ContinuousProfilingDir      string `json:"continuous-profiling-dir"`
+	ContinuousProfilingMaxFiles int    `json:"continuous-profiling-max-files"`
+	// ContinuousProfilingPushURL is the URL of a pprof-compatible ingestion
+	// endpoint, as the /ingest endpoint of Pyroscope.
+	ContinuousProfilingPushURL string `json:"continuous-profiling-push-url"`
 
 	// ExperimentalEnableDistributedTracing indicates if experimental tracing using OpenTelemetry is enabled.
 	// TODO: delete in v3.7
@@ -559,6 +714,12 @@ type Config struct {
 	// Do not set logger directly.
 	loggerMu *sync.RWMutex
 	logger   *zap.Logger
+
+	// logLevels overrides the log level of subsystems at runtime.
+	logLevels *logutil.SubsystemLevels
+
+	// activatedListeners are the listeners passed by socket activation.
+	activatedListeners *activatedListeners
 	// EnableGRPCGateway enables grpc gateway.
 	// The gateway translates a RESTful HTTP API into gRPC.
 	EnableGRPCGateway bool `json:"enable-grpc-gateway"`
@@ -666,6 +827,8 @@ func NewConfig() *Config {
 		MaxConcurrentStreams: DefaultMaxConcurrentStreams,
 		WarningApplyDuration: DefaultWarningApplyDuration,
 
+		WALGroupCommitEntries: DefaultWALGroupCommitEntries,
+
 		GRPCKeepAliveMinTime:  DefaultGRPCKeepAliveMinTime,
 		GRPCKeepAliveInterval: DefaultGRPCKeepAliveInterval,
 		GRPCKeepAliveTimeout:  DefaultGRPCKeepAliveTimeout,
@@ -690,6 +853,10 @@ func NewConfig() *Config {
 		StrictReconfigCheck: DefaultStrictReconfigCheck,
 		Metrics:             "basic",
 
+		ContinuousProfilingInterval:    DefaultContinuousProfilingInterval,
+		ContinuousProfilingCPUDuration: DefaultContinuousProfilingCPUDuration,
+		ContinuousProfilingMaxFiles:    DefaultContinuousProfilingMaxFiles,
+
 		CORS:          map[string]struct{}{"*": {}},
 		HostWhitelist: map[string]struct{}{"*": {}},
 
@@ -721,6 +888,13 @@ func NewConfig() *Config {
 		// TODO: delete in v3.7
 		ExperimentalMaxLearners: membership.DefaultMaxLearners,
 
+		LearnerAutoPromoteMaxLag:   DefaultLearnerAutoPromoteMaxLag,
+		LearnerAutoPromoteDuration: DefaultLearnerAutoPromoteDuration,
+
+		ReadReplicaMaxStaleness: DefaultReadReplicaMaxStaleness,
+
+		LeaderLeaseMaxClockDrift: DefaultLeaderLeaseMaxClockDrift,
+
 		ExperimentalTxnModeWriteWithSharedBuffer:  DefaultExperimentalTxnModeWriteWithSharedBuffer,
 		ExperimentalDistributedTracingAddress:     DefaultDistributedTracingAddress,
 		DistributedTracingAddress:                 DefaultDistributedTracingAddress,
@@ -747,6 +921,10 @@ func NewConfig() *Config {
 			},
 		},
 
+		CloudDiscoveryCfg: clouddiscovery.Config{
+			Timeout: DefaultDiscoveryProviderTimeout,
+		},
+
 		AutoCompactionMode:      DefaultAutoCompactionMode,
 		AutoCompactionRetention: DefaultAutoCompactionRetention,
 		ServerFeatureGate:       features.NewDefaultServerFeatureGate(DefaultName, nil),
@@ -789,6 +967,9 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.BackendFreelistType, "backend-bbolt-freelist-type", cfg.BackendFreelistType, "BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types)")
 	fs.DurationVar(&cfg.BackendBatchInterval, "backend-batch-interval", cfg.BackendBatchInterval, "BackendBatchInterval is the maximum time before commit the backend transaction.")
 	fs.IntVar(&cfg.BackendBatchLimit, "backend-batch-limit", cfg.BackendBatchLimit, "BackendBatchLimit is the maximum operations before commit the backend transaction.")
+	fs.IntVar(&cfg.BackendBatchLimitMax, "backend-batch-limit-max", cfg.BackendBatchLimitMax, "BackendBatchLimitMax is the maximum the backend batch limit grows to under load. The batch limit is fixed if it is not greater than the batch limit.")
+	fs.DurationVar(&cfg.WALGroupCommitDelay, "wal-group-commit-delay", cfg.WALGroupCommitDelay, "Maximum time the leader holds back the WAL save after saving entries to group the entries proposed meanwhile into one fsync (0 to disable).")
+	fs.IntVar(&cfg.WALGroupCommitEntries, "wal-group-commit-entries", cfg.WALGroupCommitEntries, "Number of proposals after which the leader stops holding back the WAL save before the group commit delay expires.")
 	fs.UintVar(&cfg.MaxTxnOps, "max-txn-ops", cfg.MaxTxnOps, "Maximum number of operations permitted in a transaction.")
 	fs.UintVar(&cfg.MaxRequestBytes, "max-request-bytes", cfg.MaxRequestBytes, "Maximum client request size in bytes the server will accept.")
 	fs.DurationVar(&cfg.GRPCKeepAliveMinTime, "grpc-keepalive-min-time", cfg.GRPCKeepAliveMinTime, "Minimum interval duration that a client should wait before pinging server.")
@@ -796,8 +977,13 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&cfg.GRPCKeepAliveTimeout, "grpc-keepalive-timeout", cfg.GRPCKeepAliveTimeout, "Additional duration of wait before closing a non-responsive connection (0 to disable).")
 	fs.BoolVar(&cfg.SocketOpts.ReusePort, "socket-reuse-port", cfg.SocketOpts.ReusePort, "Enable to set socket option SO_REUSEPORT on listeners allowing rebinding of a port already in use.")
 	fs.BoolVar(&cfg.SocketOpts.ReuseAddress, "socket-reuse-address", cfg.SocketOpts.ReuseAddress, "Enable to set socket option SO_REUSEADDR on listeners allowing binding to an address in `TIME_WAIT` state.")
+	fs.BoolVar(&cfg.SocketActivation, "socket-activation", cfg.SocketActivation, "Use the listeners passed by systemd socket activation (LISTEN_FDS) for the listen URLs they are bound to.")
 
 	fs.Var(flags.NewUint32Value(cfg.MaxConcurrentStreams), "max-concurrent-streams", "Maximum concurrent streams that each client can open at a time.")
+	fs.UintVar(&cfg.MaxStreamsPerConnection, "max-streams-per-connection", cfg.MaxStreamsPerConnection, "Maximum number of concurrent streams of a client connection, failing the streams exceeding it (0 means no limit).")
+	fs.UintVar(&cfg.MaxWatchersPerStream, "max-watchers-per-stream", cfg.MaxWatchersPerStream, "Maximum number of watchers of a watch stream (0 means no limit).")
+	fs.UintVar(&cfg.MaxWatchersPerUser, "max-watchers-per-user", cfg.MaxWatchersPerUser, "Maximum number of watchers of an authenticated user across all watch streams (0 means no limit).")
+	fs.UintVar(&cfg.MaxWatcherBytesPerSecond, "max-watcher-bytes-per-second", cfg.MaxWatcherBytesPerSecond, "Maximum rate in bytes per second at which the events of a watcher are sent (0 means no limit).")
 
 	// raft connection timeouts
 	fs.DurationVar(&rafthttp.ConnReadTimeout, "raft-read-timeout", rafthttp.DefaultConnReadTimeout, "Read timeout set on each rafthttp connection")
@@ -839,9 +1025,18 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.Dproxy, "discovery-proxy", cfg.Dproxy, "HTTP proxy to use for traffic to discovery service. Will be deprecated in v3.7, and be decommissioned in v3.8.")
 	fs.StringVar(&cfg.DNSCluster, "discovery-srv", cfg.DNSCluster, "DNS domain used to bootstrap initial cluster.")
 	fs.StringVar(&cfg.DNSClusterServiceName, "discovery-srv-name", cfg.DNSClusterServiceName, "Service name to query when using DNS discovery.")
+	fs.Var(flags.NewStringsValue(""), "discovery-srv-target-cacert", "Comma-separated 'target=ca-file' pairs verifying the peers discovered through SRV records with their own CA bundle.")
+	fs.BoolVar(&cfg.DNSClusterVerifyTargetName, "discovery-srv-verify-target-name", cfg.DNSClusterVerifyTargetName, "Verify the certificate of each peer discovered through SRV records against its target host name instead of the SRV domain.")
+	fs.DurationVar(&cfg.DNSClusterRefreshInterval, "discovery-srv-refresh-interval", cfg.DNSClusterRefreshInterval, "Interval at which SRV records are re-resolved while bootstrapping until they list the local member (disabled by default).")
+	fs.StringVar(&cfg.CloudDiscoveryCfg.Provider, "discovery-provider", cfg.CloudDiscoveryCfg.Provider, "Provider used to discover the initial cluster. Valid values include 'kubernetes', 'gce' and 'ec2'.")
+	fs.StringVar(&cfg.CloudDiscoveryCfg.Selector, "discovery-provider-selector", cfg.CloudDiscoveryCfg.Selector, "Selects the peers of the discovery provider: '[namespace/]service' of a headless Service for 'kubernetes', 'key=value' instance label or tag for 'gce' and 'ec2'.")
+	fs.IntVar(&cfg.CloudDiscoveryCfg.Size, "discovery-provider-size", cfg.CloudDiscoveryCfg.Size, "Expected size of the initial cluster discovered by the discovery provider.")
+	fs.DurationVar(&cfg.CloudDiscoveryCfg.Timeout, "discovery-provider-timeout", cfg.CloudDiscoveryCfg.Timeout, "Timeout for discovering all peers of the initial cluster with the discovery provider.")
 	fs.StringVar(&cfg.InitialCluster, "initial-cluster", cfg.InitialCluster, "Initial cluster configuration for bootstrapping.")
 	fs.StringVar(&cfg.InitialClusterToken, "initial-cluster-token", cfg.InitialClusterToken, "Initial cluster token for the etcd cluster during bootstrap.")
 	fs.BoolVar(&cfg.StrictReconfigCheck, "strict-reconfig-check", cfg.StrictReconfigCheck, "Reject reconfiguration requests that would cause quorum loss.")
+	fs.BoolVar(&cfg.BootstrapFromPeer, "bootstrap-from-peer", cfg.BootstrapFromPeer, "Bootstrap a member joining an existing cluster from a snapshot streamed from a running member.")
+	fs.UintVar(&cfg.BootstrapFromPeerBytesPerSecond, "bootstrap-from-peer-bytes-per-second", cfg.BootstrapFromPeerBytesPerSecond, "Maximum rate in bytes per second at which the snapshot of --bootstrap-from-peer is fetched (0 means no limit).")
 
 	fs.BoolVar(&cfg.PreVote, "pre-vote", cfg.PreVote, "Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.")
 
@@ -888,6 +1083,7 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.LogRotationConfigJSON, "log-rotation-config-json", DefaultLogRotationConfig, "Configures log rotation if enabled with a JSON logger config. Default: MaxSize=100(MB), MaxAge=0(days,no limit), MaxBackups=0(no limit), LocalTime=false(UTC), Compress=false(gzip)")
 
 	fs.StringVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", "0", "Auto compaction retention for mvcc key value store. 0 means disable auto compaction.")
+	fs.Var(flags.NewStringsValue(""), "auto-compaction-prefix-retentions", "Comma-separated list of 'prefix=retention' overriding 'auto-compaction-retention' for the keys under the prefixes, in the same unit (e.g. '/audit/=24h,/cache/=0'). 0 keeps only the latest revision of the keys.")
 	fs.StringVar(&cfg.AutoCompactionMode, "auto-compaction-mode", "periodic", "interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.")
 
 	// pprof profiler via HTTP
@@ -895,6 +1091,15 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 
 	// additional metrics
 	fs.StringVar(&cfg.Metrics, "metrics", cfg.Metrics, "Set level of detail for exported metrics, specify 'extensive' to include server side grpc histogram metrics")
+	fs.BoolVar(&cfg.EnableContinuousProfiling, "enable-continuous-profiling", false, "Enable periodic capture of CPU and heap profiles, retained in --continuous-profiling-dir or pushed to --continuous-profiling-push-url.")
+	fs.DurationVar(&cfg.ContinuousProfilingInterval, "continuous-profiling-interval", cfg.ContinuousProfilingInterval, "Time between two continuous profile captures.")
+	fs.DurationVar(&cfg.ContinuousProfilingCPUDuration, "continuous-profiling-cpu-duration", cfg.ContinuousProfilingCPUDuration, "Duration of each continuous CPU profile.")
+	fs.StringVar(&cfg.ContinuousProfilingDir, "continuous-profiling-dir", cfg.ContinuousProfilingDir, "Directory retaining the continuous profiles.")
+	fs.IntVar(&cfg.ContinuousProfilingMaxFiles, "continuous-profiling-max-files", cfg.ContinuousProfilingMaxFiles, "Maximum number of continuous profiles of each type to retain in --continuous-profiling-dir.")
+	fs.StringVar(&cfg.ContinuousProfilingPushURL, "continuous-profiling-push-url", cfg.ContinuousProfilingPushURL, "URL of a pprof-compatible endpoint (e.g. Pyroscope /ingest) to push the continuous profiles to, instead of retaining them.")
+	fs.Var(flags.NewStringsValue(""), "metrics-key-prefixes", "Comma-separated list of key prefixes whose requests, bytes written, watch events and keys are exported as metrics labeled by prefix.")
+	fs.Var(flags.NewStringsValue(""), "metrics-key-prefix-size-buckets", "Comma-separated list of increasing upper bounds in bytes of the buckets of the put size histogram of the monitored key prefixes.")
+	fs.Var(flags.NewStringsValue(""), "health-excluded-checks", "Comma-separated list of checks not run by /livez and /readyz unless queried individually, e.g. 'lessor,auth_store'.")
 
 	// experimental distributed tracing
 	fs.BoolVar(&cfg.ExperimentalEnableDistributedTracing, "experimental-enable-distributed-tracing", false, "Enable experimental distributed tracing using OpenTelemetry Tracing. Deprecated in v3.6 and will be decommissioned in v3.7. Use --enable-distributed-tracing instead.")
@@ -961,6 +1166,14 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	// TODO: delete in v3.7
 	fs.IntVar(&cfg.ExperimentalMaxLearners, "experimental-max-learners", membership.DefaultMaxLearners, "Sets the maximum number of learners that can be available in the cluster membership. Deprecated in v3.6 and will be decommissioned in v3.7. Use --max-learners instead.")
 	fs.IntVar(&cfg.MaxLearners, "max-learners", membership.DefaultMaxLearners, "Sets the maximum number of learners that can be available in the cluster membership.")
+	fs.Uint64Var(&cfg.LearnerAutoPromoteMaxLag, "learner-auto-promote-max-lag", cfg.LearnerAutoPromoteMaxLag, "Maximum number of raft entries a learner may lag behind the leader to be considered caught up for automatic promotion. Requires the LearnerAutoPromote feature gate.")
+	fs.DurationVar(&cfg.LearnerAutoPromoteDuration, "learner-auto-promote-duration", cfg.LearnerAutoPromoteDuration, "Duration a learner needs to stay caught up with the leader before it is automatically promoted. Requires the LearnerAutoPromote feature gate.")
+	fs.BoolVar(&cfg.ReadReplica, "read-replica", cfg.ReadReplica, "Run this learner member as a read replica that is never promoted and serves serializable reads only.")
+	fs.DurationVar(&cfg.ReadReplicaMaxStaleness, "read-replica-max-staleness", cfg.ReadReplicaMaxStaleness, "Maximum time a read replica may lag behind the leader before it rejects serializable reads.")
+	fs.DurationVar(&cfg.LeaderLeaseMaxClockDrift, "leader-lease-max-clock-drift", cfg.LeaderLeaseMaxClockDrift, "Maximum clock drift between members tolerated by lease based reads. Requires the LeaseBasedReads feature gate.")
+	fs.DurationVar(&cfg.ReadIndexBatchingWindow, "read-index-batching-window", cfg.ReadIndexBatchingWindow, "Time to wait for more linearizable reads to batch before requesting a read index.")
+	fs.DurationVar(&cfg.LeaseGracePeriod, "lease-grace-period", cfg.LeaseGracePeriod, "Minimum time leases live after a leader election, so that a short unavailability does not expire them.")
+	fs.IntVar(&cfg.LeadershipPriority, "leadership-priority", cfg.LeadershipPriority, "Preference of this member to become the leader. Requires the LeadershipPriority feature gate to take effect.")
 	fs.Uint64Var(&cfg.ExperimentalSnapshotCatchUpEntries, "experimental-snapshot-catchup-entries", cfg.ExperimentalSnapshotCatchUpEntries, "Number of entries for a slow follower to catch up after compacting the raft storage entries. Deprecated in v3.6 and will be decommissioned in v3.7. Use --snapshot-catchup-entries instead.")
 	fs.Uint64Var(&cfg.SnapshotCatchUpEntries, "snapshot-catchup-entries", cfg.SnapshotCatchUpEntries, "Number of entries for a slow follower to catch up after compacting the raft storage entries.")
 
@@ -1108,7 +1321,7 @@ func (cfg *configYAML) configFromFile(path string) error {
 	}
 
 	// If a discovery or discovery-endpoints flag is set, clear default initial cluster set by InitialClusterFromName
-	if (cfg.Durl != "" || cfg.DNSCluster != "" || len(cfg.DiscoveryCfg.Endpoints) > 0) && cfg.InitialCluster == defaultInitialCluster {
+	if (cfg.Durl != "" || cfg.DNSCluster != "" || len(cfg.DiscoveryCfg.Endpoints) > 0 || cfg.CloudDiscoveryCfg.Provider != "") && cfg.InitialCluster == defaultInitialCluster {
 		cfg.InitialCluster = ""
 	}
 	if cfg.ClusterState == "" {
@@ -1229,7 +1442,7 @@ func (cfg *Config) Validate() error {
 	}
 	// Check if conflicting flags are passed.
 	nSet := 0
-	for _, v := range []bool{cfg.Durl != "", cfg.InitialCluster != "", cfg.DNSCluster != "", len(cfg.DiscoveryCfg.Endpoints) > 0} {
+	for _, v := range []bool{cfg.Durl != "", cfg.InitialCluster != "", cfg.DNSCluster != "", len(cfg.DiscoveryCfg.Endpoints) > 0, cfg.CloudDiscoveryCfg.Provider != ""} {
 		if v {
 			nSet++
 		}
@@ -1265,6 +1478,12 @@ func (cfg *Config) Validate() error {
 		return errors.New("both --discovery-token and --discovery-endpoints must be set")
 	}
 
+	if cfg.CloudDiscoveryCfg.Provider != "" {
+		if err := cfg.CloudDiscoveryCfg.Validate(); err != nil {
+			return fmt.Errorf("invalid --discovery-provider configuration: %w", err)
+		}
+	}
+
 	if cfg.TickMs == 0 {
 		return fmt.Errorf("--heartbeat-interval must be >0 (set to %dms)", cfg.TickMs)
 	}
@@ -1274,6 +1493,12 @@ func (cfg *Config) Validate() error {
 	if 5*cfg.TickMs > cfg.ElectionMs {
 		return fmt.Errorf("--election-timeout[%vms] should be at least as 5 times as --heartbeat-interval[%vms]", cfg.ElectionMs, cfg.TickMs)
 	}
+	if cfg.WALGroupCommitDelay < 0 || cfg.WALGroupCommitDelay >= time.Duration(cfg.TickMs)*time.Millisecond {
+		return fmt.Errorf("--wal-group-commit-delay[%v] must be >=0 and shorter than --heartbeat-interval[%vms]", cfg.WALGroupCommitDelay, cfg.TickMs)
+	}
+	if cfg.WALGroupCommitEntries < 0 {
+		return fmt.Errorf("--wal-group-commit-entries must be >=0 (set to %d)", cfg.WALGroupCommitEntries)
+	}
 	if cfg.ElectionMs > maxElectionMs {
 		return fmt.Errorf("--election-timeout[%vms] is too long, and should be set less than %vms", cfg.ElectionMs, maxElectionMs)
 	}
@@ -1298,6 +1523,12 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.EnableContinuousProfiling {
+		if err := cfg.validateContinuousProfilingConfig(); err != nil {
+			return err
+		}
+	}
+
 	if !cfg.ServerFeatureGate.Enabled(features.LeaseCheckpointPersist) && cfg.ServerFeatureGate.Enabled(features.LeaseCheckpoint) {
 		cfg.logger.Warn("Detected that checkpointing is enabled without persistence. Consider enabling feature gate LeaseCheckpointPersist")
 	}
@@ -1305,6 +1536,9 @@ func (cfg *Config) Validate() error {
 	if cfg.ServerFeatureGate.Enabled(features.LeaseCheckpointPersist) && !cfg.ServerFeatureGate.Enabled(features.LeaseCheckpoint) {
 		return fmt.Errorf("enabling feature gate LeaseCheckpointPersist requires enabling feature gate LeaseCheckpoint")
 	}
+	if cfg.ServerFeatureGate.Enabled(features.LeaseBasedReads) && cfg.LeaderLeaseMaxClockDrift >= time.Duration(cfg.ElectionMs)*time.Millisecond {
+		return fmt.Errorf("--leader-lease-max-clock-drift must be less than --election-timeout when LeaseBasedReads is enabled (set to %v)", cfg.LeaderLeaseMaxClockDrift)
+	}
 	// TODO: delete in v3.7
 	if cfg.ExperimentalCompactHashCheckTime <= 0 {
 		return fmt.Errorf("--experimental-compact-hash-check-time must be >0 (set to %v)", cfg.ExperimentalCompactHashCheckTime)
@@ -1312,6 +1546,29 @@ func (cfg *Config) Validate() error {
 	if cfg.CompactHashCheckTime <= 0 {
 		return fmt.Errorf("--compact-hash-check-time must be >0 (set to %v)", cfg.CompactHashCheckTime)
 	}
+	if cfg.LearnerAutoPromoteDuration <= 0 {
+		return fmt.Errorf("--learner-auto-promote-duration must be >0 (set to %v)", cfg.LearnerAutoPromoteDuration)
+	}
+	if cfg.LeadershipPriority < math.MinInt32 || cfg.LeadershipPriority > math.MaxInt32 {
+		return fmt.Errorf("--leadership-priority must be within [%d, %d] (set to %d)", math.MinInt32, math.MaxInt32, cfg.LeadershipPriority)
+	}
+	if cfg.ReadReplicaMaxStaleness <= 0 {
+		return fmt.Errorf("--read-replica-max-staleness must be >0 (set to %v)", cfg.ReadReplicaMaxStaleness)
+	}
+	if cfg.ReadIndexBatchingWindow < 0 {
+		return fmt.Errorf("--read-index-batching-window must be >=0 (set to %v)", cfg.ReadIndexBatchingWindow)
+	}
+	if cfg.LeaderLeaseMaxClockDrift < 0 {
+		return fmt.Errorf("--leader-lease-max-clock-drift must be >=0 (set to %v)", cfg.LeaderLeaseMaxClockDrift)
+	}
+	if cfg.LeaseGracePeriod < 0 {
+		return fmt.Errorf("--lease-grace-period must be >=0 (set to %v)", cfg.LeaseGracePeriod)
+	}
+	for i := 1; i < len(cfg.MetricsKeyPrefixSizeBuckets); i++ {
+		if cfg.MetricsKeyPrefixSizeBuckets[i] <= cfg.MetricsKeyPrefixSizeBuckets[i-1] {
+			return fmt.Errorf("--metrics-key-prefix-size-buckets must be in increasing order (set to %v)", cfg.MetricsKeyPrefixSizeBuckets)
+		}
+	}
 
 	// If `--name` isn't configured, then multiple members may have the same "default" name.
 	// When adding a new member with the "default" name as well, etcd may regards its peerURL
@@ -1347,6 +1604,28 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+func (cfg *Config) validateContinuousProfilingConfig() error {
+	if cfg.ContinuousProfilingInterval <= 0 {
+		return fmt.Errorf("--continuous-profiling-interval must be >0 (set to %v)", cfg.ContinuousProfilingInterval)
+	}
+	if cfg.ContinuousProfilingCPUDuration <= 0 || cfg.ContinuousProfilingCPUDuration > cfg.ContinuousProfilingInterval {
+		return fmt.Errorf("--continuous-profiling-cpu-duration must be >0 and not exceed --continuous-profiling-interval (set to %v)", cfg.ContinuousProfilingCPUDuration)
+	}
+	if cfg.ContinuousProfilingPushURL != "" {
+		if _, err := url.Parse(cfg.ContinuousProfilingPushURL); err != nil {
+			return fmt.Errorf("invalid --continuous-profiling-push-url: %w", err)
+		}
+		return nil
+	}
+	if cfg.ContinuousProfilingDir == "" {
+		return errors.New("--enable-continuous-profiling requires --continuous-profiling-dir or --continuous-profiling-push-url")
+	}
+	if cfg.ContinuousProfilingMaxFiles <= 0 {
+		return fmt.Errorf("--continuous-profiling-max-files must be >0 (set to %d)", cfg.ContinuousProfilingMaxFiles)
+	}
+	return nil
+}
+
 // PeerURLsMapAndToken sets up an initial peer URLsMap and cluster token for bootstrap or discovery.
 func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, token string, err error) {
 	token = cfg.InitialClusterToken
@@ -1366,29 +1645,35 @@ func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, tok
 		token = cfg.DiscoveryCfg.Token
 
 	case cfg.DNSCluster != "":
-		clusterStrs, cerr := cfg.GetDNSClusterNames()
-		lg := cfg.logger
-		if cerr != nil {
-			lg.Warn("failed to resolve during SRV discovery", zap.Error(cerr))
-		}
-		if len(clusterStrs) == 0 {
-			return nil, "", cerr
+		urlsmap, err = cfg.dnsClusterURLsMap(which)
+		for err != nil && cfg.DNSClusterRefreshInterval > 0 {
+			cfg.logger.Warn(
+				"failed to bootstrap from SRV records, re-resolving",
+				zap.String("discovery-srv", cfg.DNSCluster),
+				zap.Duration("discovery-srv-refresh-interval", cfg.DNSClusterRefreshInterval),
+				zap.Error(err),
+			)
+			time.Sleep(cfg.DNSClusterRefreshInterval)
+			urlsmap, err = cfg.dnsClusterURLsMap(which)
 		}
-		for _, s := range clusterStrs {
-			lg.Info("got bootstrap from DNS for etcd-server", zap.String("node", s))
+
+	case cfg.CloudDiscoveryCfg.Provider != "":
+		var p clouddiscovery.Provider
+		p, err = clouddiscovery.NewProvider(cfg.CloudDiscoveryCfg.Provider, cfg.CloudDiscoveryCfg.Selector)
+		if err != nil {
+			return nil, "", err
 		}
-		clusterStr := strings.Join(clusterStrs, ",")
-		if strings.Contains(clusterStr, "https://") && cfg.PeerTLSInfo.TrustedCAFile == "" {
-			cfg.PeerTLSInfo.ServerName = cfg.DNSCluster
+		var clusterStr string
+		clusterStr, err = clouddiscovery.GetCluster(context.TODO(), cfg.GetLogger(), p, cfg.Name, cfg.CloudDiscoveryCfg.Size, cfg.CloudDiscoveryCfg.Timeout, cfg.AdvertisePeerUrls)
+		if err != nil {
+			return nil, "", err
 		}
+		cfg.GetLogger().Info(
+			"discovered initial cluster",
+			zap.String("discovery-provider", cfg.CloudDiscoveryCfg.Provider),
+			zap.String("initial-cluster", clusterStr),
+		)
 		urlsmap, err = types.NewURLsMap(clusterStr)
-		// only etcd member must belong to the discovered cluster.
-		// proxy does not need to belong to the discovered cluster.
-		if which == "etcd" {
-			if _, ok := urlsmap[cfg.Name]; !ok {
-				return nil, "", fmt.Errorf("cannot find local etcd member %q in SRV records", cfg.Name)
-			}
-		}
 
 	default:
 		// We're statically configured, and cluster has appropriately been set.
@@ -1397,6 +1682,58 @@ func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, tok
 	return urlsmap, token, err
 }
 
+// dnsClusterURLsMap resolves the initial peer URLsMap from DNS SRV records.
+func (cfg *Config) dnsClusterURLsMap(which string) (types.URLsMap, error) {
+	clusterStrs, cerr := cfg.GetDNSClusterNames()
+	lg := cfg.logger
+	if cerr != nil {
+		lg.Warn("failed to resolve during SRV discovery", zap.Error(cerr))
+	}
+	if len(clusterStrs) == 0 {
+		return nil, cerr
+	}
+	for _, s := range clusterStrs {
+		lg.Info("got bootstrap from DNS for etcd-server", zap.String("node", s))
+	}
+	clusterStr := strings.Join(clusterStrs, ",")
+	if strings.Contains(clusterStr, "https://") && cfg.PeerTLSInfo.TrustedCAFile == "" {
+		cfg.PeerTLSInfo.ServerName = cfg.DNSCluster
+	}
+	urlsmap, err := types.NewURLsMap(clusterStr)
+	if err != nil {
+		return nil, err
+	}
+	// only etcd member must belong to the discovered cluster.
+	// proxy does not need to belong to the discovered cluster.
+	if which == "etcd" {
+		if _, ok := urlsmap[cfg.Name]; !ok {
+			return nil, fmt.Errorf("cannot find local etcd member %q in SRV records", cfg.Name)
+		}
+	}
+	return urlsmap, nil
+}
+
+// setupDNSClusterTargetTLS verifies the peers discovered through DNS SRV
+// records with their target specific TLS settings, if any.
+func (cfg *Config) setupDNSClusterTargetTLS() error {
+	if cfg.DNSCluster == "" {
+		return nil
+	}
+	targetCAs, err := srv.ParseTargetCAFiles(cfg.DNSClusterTargetCAs)
+	if err != nil {
+		return err
+	}
+	targetTLS := srv.TargetTLS{TrustedCAFiles: targetCAs, VerifyTargetName: cfg.DNSClusterVerifyTargetName}
+	if !targetTLS.Enabled() {
+		return nil
+	}
+	domain := cfg.DNSCluster
+	cfg.PeerTLSInfo.ClientConfigHook = func(base *tls.Config) (*tls.Config, error) {
+		return targetTLS.ClientConfig(base, domain)
+	}
+	return nil
+}
+
 // GetDNSClusterNames uses DNS SRV records to get a list of initial nodes for cluster bootstrapping.
 // This function will return a list of one or more nodes, as well as any errors encountered while
 // performing service discovery.
@@ -1620,6 +1957,12 @@ func checkBindURLs(urls []url.URL) error {
 
 func checkHostURLs(urls []url.URL) error {
 	for _, url := range urls {
+		if url.Scheme == "unix" || url.Scheme == "unixs" {
+			if url.Host == "" && url.Path == "" {
+				return fmt.Errorf("unexpected empty socket path (%s)", url.String())
+			}
+			continue
+		}
 		host, _, err := net.SplitHostPort(url.Host)
 		if err != nil {
 			return err
@@ -31,6 +31,7 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
+	"go.etcd.io/etcd/server/v3/etcdserver"
 )
 
 // GetLogger returns the logger.
@@ -164,6 +165,7 @@ func (cfg *Config) setupLogging() error {
 		if err != nil {
 			return err
 		}
+		cfg.logLevels = logutil.NewSubsystemLevels(logutil.ConvertToZapLevel(cfg.LogLevel), etcdserver.LogSubsystems...)
 
 		logTLSHandshakeFailureFunc := func(msg string) func(conn *tls.Conn, err error) {
 			return func(conn *tls.Conn, err error) {
@@ -226,7 +228,12 @@ func (cfg *Config) SetupGlobalLoggers() {
 	if lg != nil {
 		if cfg.LogLevel == "debug" {
 			grpc.EnableTracing = true
-			grpclog.SetLoggerV2(zapgrpc.NewLogger(lg))
+			grpclog.SetLoggerV2(zapgrpc.NewLogger(cfg.logLevels.Logger(lg, etcdserver.LogSubsystemGRPC)))
+		} else if cfg.logLevels != nil {
+			// grpc logs warnings and errors only, unless its log level is
+			// lowered at runtime.
+			grpcLogger := lg.WithOptions(zap.IncreaseLevel(zapcore.WarnLevel))
+			grpclog.SetLoggerV2(zapgrpc.NewLogger(cfg.logLevels.Logger(grpcLogger, etcdserver.LogSubsystemGRPC)))
 		} else {
 			grpclog.SetLoggerV2(grpclog.NewLoggerV2(io.Discard, os.Stderr, os.Stderr))
 		}
@@ -639,6 +639,30 @@ func TestAutoCompactionModeInvalid(t *testing.T) {
 	}
 }
 
+func TestMetricsKeyPrefixSizeBucketsValidate(t *testing.T) {
+	tcs := []struct {
+		buckets []float64
+		wantErr bool
+	}{
+		{buckets: nil},
+		{buckets: []float64{100, 1000, 10000}},
+		{buckets: []float64{100, 100}, wantErr: true},
+		{buckets: []float64{1000, 100}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		cfg := NewConfig()
+		cfg.Logger = "zap"
+		cfg.LogOutputs = []string{"/dev/null"}
+		cfg.MetricsKeyPrefixSizeBuckets = tc.buckets
+		err := cfg.Validate()
+		if tc.wantErr {
+			require.Errorf(t, err, "buckets %v", tc.buckets)
+		} else {
+			require.NoErrorf(t, err, "buckets %v", tc.buckets)
+		}
+	}
+}
+
 func TestAutoCompactionModeParse(t *testing.T) {
 	tests := []struct {
 		mode      string
@@ -680,6 +704,75 @@ func TestAutoCompactionModeParse(t *testing.T) {
 	}
 }
 
+func TestAutoCompactionPrefixRetentionsParse(t *testing.T) {
+	rs, err := parseCompactionPrefixRetentions("periodic", []string{"/audit/=24", "/cache/=0", "/a=b/=5m"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"/audit/": 24 * time.Hour, "/cache/": 0, "/a=b/": 5 * time.Minute}, rs)
+
+	rs, err = parseCompactionPrefixRetentions("revision", []string{"/audit/=1000"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"/audit/": 1000}, rs)
+
+	_, err = parseCompactionPrefixRetentions("periodic", []string{"/audit/"})
+	require.Error(t, err)
+	_, err = parseCompactionPrefixRetentions("periodic", []string{"/audit/=a"})
+	require.Error(t, err)
+}
+
+func TestPeerURLsMapAndTokenFromSRVRefresh(t *testing.T) {
+	defer func() { getCluster = srv.GetCluster }()
+
+	lookups := 0
+	getCluster = func(serviceScheme string, service string, name string, dns string, apurls types.URLs) ([]string, error) {
+		if serviceScheme != "https" {
+			return nil, notFoundErr(service, dns)
+		}
+		lookups++
+		// the record of the local member shows up on the third lookup.
+		if lookups < 3 {
+			return []string{"0=https://2.example.com:2380"}, nil
+		}
+		return []string{"0=https://2.example.com:2380", "1.example.com=https://1.example.com:2380"}, nil
+	}
+
+	cfg := NewConfig()
+	cfg.Name = "1.example.com"
+	cfg.InitialCluster = ""
+	cfg.InitialClusterToken = ""
+	cfg.DNSCluster = "example.com"
+	cfg.AdvertisePeerUrls = types.MustNewURLs([]string{"https://1.example.com:2380"})
+	require.NoError(t, cfg.Validate())
+
+	_, _, err := cfg.PeerURLsMapAndToken("etcd")
+	require.ErrorContains(t, err, "cannot find local etcd member")
+
+	lookups = 0
+	cfg.DNSClusterRefreshInterval = time.Millisecond
+	urlsmap, _, err := cfg.PeerURLsMapAndToken("etcd")
+	require.NoError(t, err)
+	require.Equal(t, "0=https://2.example.com:2380,1.example.com=https://1.example.com:2380", urlsmap.String())
+	require.Equal(t, 3, lookups)
+}
+
+func TestSetupDNSClusterTargetTLS(t *testing.T) {
+	cfg := NewConfig()
+	cfg.DNSClusterTargetCAs = []string{"1.example.com"}
+	require.NoError(t, cfg.setupDNSClusterTargetTLS(), "target TLS is only set up with SRV discovery")
+
+	cfg.DNSCluster = "example.com"
+	require.Error(t, cfg.setupDNSClusterTargetTLS())
+
+	cfg.DNSClusterTargetCAs = nil
+	require.NoError(t, cfg.setupDNSClusterTargetTLS())
+	require.Nil(t, cfg.PeerTLSInfo.ClientConfigHook)
+
+	cfg.DNSClusterVerifyTargetName = true
+	require.NoError(t, cfg.setupDNSClusterTargetTLS())
+	tlsCfg, err := cfg.PeerTLSInfo.ClientConfig()
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg.VerifyConnection, "peers should be verified per target")
+}
+
 func TestPeerURLsMapAndTokenFromSRV(t *testing.T) {
 	defer func() { getCluster = srv.GetCluster }()
 
@@ -795,6 +888,102 @@ func TestLeaseCheckpointValidate(t *testing.T) {
 	}
 }
 
+func TestDiscoveryProviderValidate(t *testing.T) {
+	tcs := []struct {
+		name           string
+		provider       string
+		selector       string
+		size           int
+		initialCluster string
+		expectError    bool
+	}{
+		{
+			name:     "Kubernetes provider should pass",
+			provider: "kubernetes",
+			selector: "etcd",
+			size:     3,
+		},
+		{
+			name:        "Unknown provider should fail",
+			provider:    "azure",
+			selector:    "cluster=etcd",
+			size:        3,
+			expectError: true,
+		},
+		{
+			name:        "Provider without size should fail",
+			provider:    "ec2",
+			selector:    "cluster=etcd",
+			expectError: true,
+		},
+		{
+			name:           "Provider with initial cluster should fail",
+			provider:       "gce",
+			selector:       "cluster=etcd",
+			size:           3,
+			initialCluster: "default=http://localhost:2380",
+			expectError:    true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := *NewConfig()
+			cfg.InitialCluster = tc.initialCluster
+			cfg.CloudDiscoveryCfg.Provider = tc.provider
+			cfg.CloudDiscoveryCfg.Selector = tc.selector
+			cfg.CloudDiscoveryCfg.Size = tc.size
+			err := cfg.Validate()
+			if (err != nil) != tc.expectError {
+				t.Errorf("config.Validate() = %q, expected error: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
+func TestContinuousProfilingValidate(t *testing.T) {
+	tcs := []struct {
+		name        string
+		dir         string
+		pushURL     string
+		cpuDuration time.Duration
+		expectError bool
+	}{
+		{
+			name: "Retaining profiles in a directory should pass",
+			dir:  "profiles",
+		},
+		{
+			name:    "Pushing profiles should pass",
+			pushURL: "http://localhost:4040/ingest",
+		},
+		{
+			name:        "No directory nor push URL should fail",
+			expectError: true,
+		},
+		{
+			name:        "CPU duration exceeding the interval should fail",
+			dir:         "profiles",
+			cpuDuration: time.Hour,
+			expectError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := *NewConfig()
+			cfg.EnableContinuousProfiling = true
+			cfg.ContinuousProfilingDir = tc.dir
+			cfg.ContinuousProfilingPushURL = tc.pushURL
+			if tc.cpuDuration != 0 {
+				cfg.ContinuousProfilingCPUDuration = tc.cpuDuration
+			}
+			err := cfg.Validate()
+			if (err != nil) != tc.expectError {
+				t.Errorf("config.Validate() = %q, expected error: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
 func TestLogRotation(t *testing.T) {
 	tests := []struct {
 		name              string
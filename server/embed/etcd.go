@@ -76,6 +76,8 @@ type Etcd struct {
 
 	tracingExporterShutdown func()
 
+	profiler *debugutil.ContinuousProfiler
+
 	Server *etcdserver.EtcdServer
 
 	cfg Config
@@ -124,6 +126,7 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 				sctx.close()
 			}
 		}
+		cfg.activatedListeners.closeUnused()
 		e.Close()
 		e = nil
 	}()
@@ -135,6 +138,11 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 			zap.Bool("reuse-port", cfg.SocketOpts.ReusePort),
 		)
 	}
+	if cfg.SocketActivation {
+		if cfg.activatedListeners, err = loadActivatedListeners(cfg.logger); err != nil {
+			return e, err
+		}
+	}
 	e.cfg.logger.Info(
 		"configuring peer listeners",
 		zap.Strings("listen-peer-urls", e.cfg.getListenPeerURLs()),
@@ -159,6 +167,9 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		urlsmap types.URLsMap
 		token   string
 	)
+	if err = cfg.setupDNSClusterTargetTLS(); err != nil {
+		return e, fmt.Errorf("error setting up SRV target TLS: %w", err)
+	}
 	memberInitialized := true
 	if !isMemberInitialized(cfg) {
 		memberInitialized = false
@@ -176,6 +187,13 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	if err != nil {
 		return e, err
 	}
+	autoCompactionPrefixRetentions, err := parseCompactionPrefixRetentions(cfg.AutoCompactionMode, cfg.AutoCompactionPrefixRetentions)
+	if err != nil {
+		return e, err
+	}
+	if len(autoCompactionPrefixRetentions) > 0 && autoCompactionRetention == 0 {
+		return e, errors.New("--auto-compaction-prefix-retentions requires --auto-compaction-retention")
+	}
 
 	backendFreelistType := parseBackendFreelistType(cfg.BackendFreelistType)
 
@@ -191,6 +209,8 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		MaxWALFiles:                       cfg.MaxWalFiles,
 		InitialPeerURLsMap:                urlsmap,
 		InitialClusterToken:               token,
+		BootstrapFromPeer:                 cfg.BootstrapFromPeer,
+		BootstrapFromPeerBytesPerSecond:   cfg.BootstrapFromPeerBytesPerSecond,
 		DiscoveryURL:                      cfg.Durl,
 		DiscoveryProxy:                    cfg.Dproxy,
 		DiscoveryCfg:                      cfg.DiscoveryCfg,
@@ -201,13 +221,21 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		InitialElectionTickAdvance:        cfg.InitialElectionTickAdvance,
 		AutoCompactionRetention:           autoCompactionRetention,
 		AutoCompactionMode:                cfg.AutoCompactionMode,
+		AutoCompactionPrefixRetentions:    autoCompactionPrefixRetentions,
 		QuotaBackendBytes:                 cfg.QuotaBackendBytes,
 		BackendBatchLimit:                 cfg.BackendBatchLimit,
 		BackendFreelistType:               backendFreelistType,
 		BackendBatchInterval:              cfg.BackendBatchInterval,
+		BackendBatchLimitMax:              cfg.BackendBatchLimitMax,
+		WALGroupCommitDelay:               cfg.WALGroupCommitDelay,
+		WALGroupCommitEntries:             cfg.WALGroupCommitEntries,
 		MaxTxnOps:                         cfg.MaxTxnOps,
 		MaxRequestBytes:                   cfg.MaxRequestBytes,
 		MaxConcurrentStreams:              cfg.MaxConcurrentStreams,
+		MaxStreamsPerConnection:           cfg.MaxStreamsPerConnection,
+		MaxWatchersPerStream:              cfg.MaxWatchersPerStream,
+		MaxWatchersPerUser:                cfg.MaxWatchersPerUser,
+		MaxWatcherBytesPerSecond:          cfg.MaxWatcherBytesPerSecond,
 		SocketOpts:                        cfg.SocketOpts,
 		StrictReconfigCheck:               cfg.StrictReconfigCheck,
 		ClientCertAuthEnabled:             cfg.ClientTLSInfo.ClientCertAuth,
@@ -220,6 +248,7 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		CompactHashCheckTime:              cfg.CompactHashCheckTime,
 		PreVote:                           cfg.PreVote,
 		Logger:                            cfg.logger,
+		LogLevels:                         cfg.logLevels,
 		ForceNewCluster:                   cfg.ForceNewCluster,
 		EnableGRPCGateway:                 cfg.EnableGRPCGateway,
 		EnableDistributedTracing:          cfg.EnableDistributedTracing,
@@ -233,10 +262,22 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		MemoryMlock:                       cfg.MemoryMlock,
 		BootstrapDefragThresholdMegabytes: cfg.BootstrapDefragThresholdMegabytes,
 		MaxLearners:                       cfg.MaxLearners,
+		LearnerAutoPromoteMaxLag:          cfg.LearnerAutoPromoteMaxLag,
+		LearnerAutoPromoteDuration:        cfg.LearnerAutoPromoteDuration,
+		LeadershipPriority:                int32(cfg.LeadershipPriority),
+		ReadReplica:                       cfg.ReadReplica,
+		ReadReplicaMaxStaleness:           cfg.ReadReplicaMaxStaleness,
+		LeaderLeaseMaxClockDrift:          cfg.LeaderLeaseMaxClockDrift,
+		ReadIndexBatchingWindow:           cfg.ReadIndexBatchingWindow,
+		LeaseGracePeriod:                  cfg.LeaseGracePeriod,
 		V2Deprecation:                     cfg.V2DeprecationEffective(),
 		ExperimentalLocalAddress:          cfg.InferLocalAddr(),
 		ServerFeatureGate:                 cfg.ServerFeatureGate,
 		Metrics:                           cfg.Metrics,
+		MetricsKeyPrefixes:                cfg.MetricsKeyPrefixes,
+		MetricsKeyPrefixSizeBuckets:       cfg.MetricsKeyPrefixSizeBuckets,
+		HealthExcludedChecks:              cfg.HealthExcludedChecks,
+		PostApplyHooks:                    cfg.PostApplyHooks,
 	}
 
 	if srvcfg.EnableDistributedTracing {
@@ -288,6 +329,21 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	if err = e.serveMetrics(); err != nil {
 		return e, err
 	}
+	cfg.activatedListeners.closeUnused()
+
+	if cfg.EnableContinuousProfiling {
+		if e.profiler, err = debugutil.NewContinuousProfiler(e.cfg.logger, debugutil.ContinuousProfilerConfig{
+			Interval:    cfg.ContinuousProfilingInterval,
+			CPUDuration: cfg.ContinuousProfilingCPUDuration,
+			Dir:         cfg.ContinuousProfilingDir,
+			MaxFiles:    cfg.ContinuousProfilingMaxFiles,
+			PushURL:     cfg.ContinuousProfilingPushURL,
+			AppName:     fmt.Sprintf("etcd{name=%s}", cfg.Name),
+		}); err != nil {
+			return e, err
+		}
+		e.profiler.Start()
+	}
 
 	e.cfg.logger.Info(
 		"now serving peer/client/metrics",
@@ -381,9 +437,20 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.String("discovery-key", sc.DiscoveryCfg.Secure.Key),
 		zap.String("discovery-cacert", sc.DiscoveryCfg.Secure.Cacert),
 		zap.String("discovery-user", sc.DiscoveryCfg.Auth.Username),
+		zap.String("discovery-provider", ec.CloudDiscoveryCfg.Provider),
+		zap.String("discovery-provider-selector", ec.CloudDiscoveryCfg.Selector),
+		zap.Int("discovery-provider-size", ec.CloudDiscoveryCfg.Size),
 
 		zap.String("downgrade-check-interval", sc.DowngradeCheckTime.String()),
 		zap.Int("max-learners", sc.MaxLearners),
+		zap.Uint64("learner-auto-promote-max-lag", sc.LearnerAutoPromoteMaxLag),
+		zap.Duration("learner-auto-promote-duration", sc.LearnerAutoPromoteDuration),
+		zap.Int32("leadership-priority", sc.LeadershipPriority),
+		zap.Bool("read-replica", sc.ReadReplica),
+		zap.Duration("read-replica-max-staleness", sc.ReadReplicaMaxStaleness),
+		zap.Duration("leader-lease-max-clock-drift", sc.LeaderLeaseMaxClockDrift),
+		zap.Duration("read-index-batching-window", sc.ReadIndexBatchingWindow),
+		zap.Duration("lease-grace-period", sc.LeaseGracePeriod),
 
 		zap.String("v2-deprecation", string(ec.V2Deprecation)),
 	)
@@ -465,6 +532,10 @@ func (e *Etcd) Close() {
 		e.metricsListeners[i].Close()
 	}
 
+	if e.profiler != nil {
+		e.profiler.Stop()
+	}
+
 	// shutdown tracing exporter
 	if e.tracingExporterShutdown != nil {
 		e.tracingExporterShutdown()
@@ -576,8 +647,10 @@ func configurePeerListeners(cfg *Config) (peers []*peerListener, err error) {
 				cfg.logger.Warn("scheme is HTTP while --peer-client-cert-auth is enabled; ignoring client cert auth for this URL", zap.String("peer-url", u.String()))
 			}
 		}
+		addr, _, network := resolveURL(u)
 		peers[i] = &peerListener{close: func(context.Context) error { return nil }}
-		peers[i].Listener, err = transport.NewListenerWithOpts(u.Host, u.Scheme,
+		peers[i].Listener, err = transport.NewListenerWithOpts(addr, u.Scheme,
+			transport.WithListener(cfg.activatedListeners.take(network, addr)),
 			transport.WithTLSInfo(&cfg.PeerTLSInfo),
 			transport.WithSocketOpts(&cfg.SocketOpts),
 			transport.WithTimeout(rafthttp.ConnReadTimeout, rafthttp.ConnWriteTimeout),
@@ -706,6 +779,7 @@ func configureClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err erro
 
 	for _, sctx := range sctxs {
 		if sctx.l, err = transport.NewListenerWithOpts(sctx.addr, sctx.scheme,
+			transport.WithListener(cfg.activatedListeners.take(sctx.network, sctx.addr)),
 			transport.WithSocketOpts(&cfg.SocketOpts),
 			transport.WithSkipTLSInfoCheck(true),
 		); err != nil {
@@ -740,6 +814,7 @@ func configureClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err erro
 			sctx.userHandlers[k] = cfg.UserHandlers[k]
 		}
 		sctx.serviceRegister = cfg.ServiceRegister
+		sctx.plugins = cfg.Plugins
 		if cfg.EnablePprof || cfg.LogLevel == "debug" {
 			sctx.registerPprof()
 		}
@@ -791,6 +866,12 @@ func (e *Etcd) serveClients() {
 			Timeout: e.cfg.GRPCKeepAliveTimeout,
 		}))
 	}
+	if len(e.cfg.GRPCUnaryInterceptors) > 0 {
+		gopts = append(gopts, grpc.ChainUnaryInterceptor(e.cfg.GRPCUnaryInterceptors...))
+	}
+	if len(e.cfg.GRPCStreamInterceptors) > 0 {
+		gopts = append(gopts, grpc.ChainStreamInterceptor(e.cfg.GRPCStreamInterceptors...))
+	}
 	gopts = append(gopts, e.cfg.GRPCAdditionalServerOptions...)
 
 	splitHTTP := false
@@ -866,7 +947,9 @@ func (e *Etcd) createMetricsListener(murl url.URL) (net.Listener, error) {
 			return nil, ErrMissingClientTLSInfoForMetricsURL
 		}
 	}
-	return transport.NewListenerWithOpts(murl.Host, murl.Scheme,
+	addr, _, network := resolveURL(murl)
+	return transport.NewListenerWithOpts(addr, murl.Scheme,
+		transport.WithListener(e.cfg.activatedListeners.take(network, addr)),
 		transport.WithTLSInfo(tlsInfo),
 		transport.WithSocketOpts(&e.cfg.SocketOpts),
 	)
@@ -930,6 +1013,25 @@ func (e *Etcd) GetLogger() *zap.Logger {
 	return l
 }
 
+func parseCompactionPrefixRetentions(mode string, retentions []string) (map[string]time.Duration, error) {
+	if len(retentions) == 0 {
+		return nil, nil
+	}
+	ret := make(map[string]time.Duration, len(retentions))
+	for _, r := range retentions {
+		i := strings.LastIndex(r, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid compaction prefix retention %q, want 'prefix=retention'", r)
+		}
+		d, err := parseCompactionRetention(mode, r[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		ret[r[:i]] = d
+	}
+	return ret, nil
+}
+
 func parseCompactionRetention(mode, retention string) (ret time.Duration, err error) {
 	h, err := strconv.Atoi(retention)
 	if err == nil && h >= 0 {
@@ -0,0 +1,88 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// Plugin provides additional gRPC services served on the client listeners
+// of the embedded server, next to the data they query.
+type Plugin interface {
+	// Register registers the gRPC services of the plugin. It is called for
+	// each gRPC server of the client listeners.
+	Register(gs *grpc.Server, store PluginStore)
+}
+
+// PluginStore gives plugins read-only access to the key-value store of the
+// local member. Reads bypass authentication; plugins must authorize their
+// callers themselves.
+type PluginStore interface {
+	// Rev returns the current revision of the key-value store.
+	Rev() int64
+	// Range returns the keys in the range at revision ro.Rev, or at the
+	// current revision if ro.Rev <= 0. See mvcc.ReadView.
+	Range(ctx context.Context, key, end []byte, ro mvcc.RangeOptions) (*mvcc.RangeResult, error)
+	// ReadTxn opens a read transaction, whose reads observe the same
+	// snapshot of the store. The caller must call End on the transaction.
+	ReadTxn() mvcc.TxnRead
+	// LinearizableRead waits until the local member applied all entries
+	// committed before the call, so that subsequent reads observe them.
+	LinearizableRead(ctx context.Context) error
+}
+
+type pluginStore struct {
+	s *etcdserver.EtcdServer
+}
+
+func newPluginStore(s *etcdserver.EtcdServer) PluginStore {
+	return &pluginStore{s: s}
+}
+
+func (ps *pluginStore) Rev() int64 {
+	txn := ps.ReadTxn()
+	defer txn.End()
+	return txn.Rev()
+}
+
+func (ps *pluginStore) Range(ctx context.Context, key, end []byte, ro mvcc.RangeOptions) (*mvcc.RangeResult, error) {
+	txn := ps.ReadTxn()
+	defer txn.End()
+	return txn.Range(ctx, key, end, ro)
+}
+
+func (ps *pluginStore) ReadTxn() mvcc.TxnRead {
+	return ps.s.KV().Read(mvcc.ConcurrentReadTxMode, traceutil.TODO())
+}
+
+func (ps *pluginStore) LinearizableRead(ctx context.Context) error {
+	return ps.s.LinearizableReadNotify(ctx)
+}
+
+func (sctx *serveCtx) registerPlugins(gs *grpc.Server, s *etcdserver.EtcdServer) {
+	if len(sctx.plugins) == 0 {
+		return
+	}
+	store := newPluginStore(s)
+	for _, p := range sctx.plugins {
+		p.Register(gs, store)
+	}
+}
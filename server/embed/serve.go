@@ -68,6 +68,7 @@ type serveCtx struct {
 
 	userHandlers    map[string]http.Handler
 	serviceRegister func(*grpc.Server)
+	plugins         []Plugin
 
 	// serversC is used to receive the http and grpc server objects (created
 	// in `serve`), both of which will be closed when shutting down the etcd.
@@ -137,6 +138,7 @@ func (sctx *serveCtx) serve(
 
 	sctx.lg.Info("ready to serve client requests")
 
+	sctx.l = v3rpc.NewDrainListener(sctx.l, s)
 	m := cmux.New(sctx.l)
 	var server func() error
 	onlyGRPC := splitHTTP && !sctx.httpOnly
@@ -188,6 +190,7 @@ func (sctx *serveCtx) serve(
 			if sctx.serviceRegister != nil {
 				sctx.serviceRegister(gs)
 			}
+			sctx.registerPlugins(gs, s)
 			defer func(gs *grpc.Server) {
 				if err != nil {
 					sctx.lg.Warn("stopping insecure grpc server due to error", zap.Error(err))
@@ -240,6 +243,7 @@ func (sctx *serveCtx) serve(
 			if sctx.serviceRegister != nil {
 				sctx.serviceRegister(gs)
 			}
+			sctx.registerPlugins(gs, s)
 			defer func(gs *grpc.Server) {
 				if err != nil {
 					sctx.lg.Warn("stopping secure grpc server due to error", zap.Error(err))
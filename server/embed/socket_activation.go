@@ -0,0 +1,108 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"net"
+	"sync"
+
+	"github.com/coreos/go-systemd/v22/activation"
+	"go.uber.org/zap"
+)
+
+// activatedListeners holds the listeners passed by systemd socket activation
+// (LISTEN_FDS) until they are claimed by the listen URL they are bound to.
+type activatedListeners struct {
+	lg *zap.Logger
+
+	mu sync.Mutex
+	ls []net.Listener
+}
+
+func loadActivatedListeners(lg *zap.Logger) (*activatedListeners, error) {
+	ls, err := activation.Listeners()
+	if err != nil {
+		return nil, err
+	}
+	a := &activatedListeners{lg: lg}
+	for _, l := range ls {
+		// file descriptors which are not stream sockets are returned as nil.
+		if l != nil {
+			a.ls = append(a.ls, l)
+		}
+	}
+	lg.Info("loaded socket activation listeners", zap.Int("listeners", len(a.ls)))
+	return a, nil
+}
+
+// take returns and removes the activated listener bound to the given
+// address, or nil if there is none.
+func (a *activatedListeners) take(network, addr string) net.Listener {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, l := range a.ls {
+		if listenerBoundTo(l, network, addr) {
+			a.ls = append(a.ls[:i], a.ls[i+1:]...)
+			a.lg.Info(
+				"using socket activation listener",
+				zap.String("network", network),
+				zap.String("address", addr),
+			)
+			return l
+		}
+	}
+	return nil
+}
+
+// closeUnused closes the activated listeners no listen URL is bound to.
+func (a *activatedListeners) closeUnused() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, l := range a.ls {
+		a.lg.Warn(
+			"closing socket activation listener not matching any listen URL",
+			zap.String("network", l.Addr().Network()),
+			zap.String("address", l.Addr().String()),
+		)
+		l.Close()
+	}
+	a.ls = nil
+}
+
+func listenerBoundTo(l net.Listener, network, addr string) bool {
+	switch la := l.Addr().(type) {
+	case *net.UnixAddr:
+		return network == "unix" && la.Name == addr
+	case *net.TCPAddr:
+		if network != "tcp" {
+			return false
+		}
+		ta, err := net.ResolveTCPAddr("tcp", addr)
+		if err != nil || ta.Port != la.Port {
+			return false
+		}
+		if ta.IP == nil || ta.IP.IsUnspecified() {
+			return la.IP == nil || la.IP.IsUnspecified()
+		}
+		return ta.IP.Equal(la.IP)
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestActivatedListeners(t *testing.T) {
+	// keep the socket path short enough for sockaddr_un.
+	dir, err := os.MkdirTemp("", "etcd-activation")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "client.sock")
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unix, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	wildcard, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	anyPort := strconv.Itoa(wildcard.Addr().(*net.TCPAddr).Port)
+	unused, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	a := &activatedListeners{lg: zaptest.NewLogger(t), ls: []net.Listener{tcp, unix, wildcard, unused}}
+	require.Nil(t, a.take("tcp", "127.0.0.2:"+anyPort))
+	require.Nil(t, a.take("unix", filepath.Join(dir, "peer.sock")))
+	require.Equal(t, tcp, a.take("tcp", tcp.Addr().String()))
+	require.Nil(t, a.take("tcp", tcp.Addr().String()), "listener must only be taken once")
+	require.Equal(t, unix, a.take("unix", sock))
+	require.Equal(t, wildcard, a.take("tcp", "0.0.0.0:"+anyPort))
+
+	a.closeUnused()
+	_, err = unused.Accept()
+	require.Error(t, err)
+	require.Empty(t, a.ls)
+
+	var none *activatedListeners
+	require.Nil(t, none.take("tcp", "127.0.0.1:2379"))
+	none.closeUnused()
+}
+
+func TestCheckHostURLsUnix(t *testing.T) {
+	require.NoError(t, checkHostURLs([]url.URL{{Scheme: "unix", Path: "/run/etcd/peer.sock"}}))
+	require.NoError(t, checkHostURLs([]url.URL{{Scheme: "unixs", Host: "localhost:2380"}}))
+	require.Error(t, checkHostURLs([]url.URL{{Scheme: "unix"}}))
+	require.Error(t, checkHostURLs([]url.URL{{Scheme: "http", Host: ":2380"}}))
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -320,6 +321,7 @@ func (cfg *config) configFromCmdLine() error {
 	cfg.ec.ListenMetricsUrls = flags.UniqueURLsFromFlag(cfg.cf.flagSet, "listen-metrics-urls")
 
 	cfg.ec.DiscoveryCfg.Endpoints = flags.UniqueStringsFromFlag(cfg.cf.flagSet, "discovery-endpoints")
+	cfg.ec.DNSClusterTargetCAs = flags.StringsFromFlag(cfg.cf.flagSet, "discovery-srv-target-cacert")
 
 	cfg.ec.CORS = flags.UniqueURLsMapFromFlag(cfg.cf.flagSet, "cors")
 	cfg.ec.HostWhitelist = flags.UniqueStringsMapFromFlag(cfg.cf.flagSet, "host-whitelist")
@@ -330,6 +332,17 @@ func (cfg *config) configFromCmdLine() error {
 
 	cfg.ec.CipherSuites = flags.StringsFromFlag(cfg.cf.flagSet, "cipher-suites")
 
+	cfg.ec.MetricsKeyPrefixes = flags.StringsFromFlag(cfg.cf.flagSet, "metrics-key-prefixes")
+	for _, b := range flags.StringsFromFlag(cfg.cf.flagSet, "metrics-key-prefix-size-buckets") {
+		bucket, perr := strconv.ParseFloat(b, 64)
+		if perr != nil {
+			return fmt.Errorf("invalid --metrics-key-prefix-size-buckets %q: %w", b, perr)
+		}
+		cfg.ec.MetricsKeyPrefixSizeBuckets = append(cfg.ec.MetricsKeyPrefixSizeBuckets, bucket)
+	}
+	cfg.ec.AutoCompactionPrefixRetentions = flags.StringsFromFlag(cfg.cf.flagSet, "auto-compaction-prefix-retentions")
+	cfg.ec.HealthExcludedChecks = flags.StringsFromFlag(cfg.cf.flagSet, "health-excluded-checks")
+
 	cfg.ec.MaxConcurrentStreams = flags.Uint32FromFlag(cfg.cf.flagSet, "max-concurrent-streams")
 
 	cfg.ec.LogOutputs = flags.UniqueStringsFromFlag(cfg.cf.flagSet, "log-outputs")
@@ -345,7 +358,7 @@ func (cfg *config) configFromCmdLine() error {
 	}
 
 	// disable default initial-cluster if discovery is set
-	if (cfg.ec.Durl != "" || cfg.ec.DNSCluster != "" || cfg.ec.DNSClusterServiceName != "" || len(cfg.ec.DiscoveryCfg.Endpoints) > 0) && !flags.IsSet(cfg.cf.flagSet, "initial-cluster") {
+	if (cfg.ec.Durl != "" || cfg.ec.DNSCluster != "" || cfg.ec.DNSClusterServiceName != "" || len(cfg.ec.DiscoveryCfg.Endpoints) > 0 || cfg.ec.CloudDiscoveryCfg.Provider != "") && !flags.IsSet(cfg.cf.flagSet, "initial-cluster") {
 		cfg.ec.InitialCluster = ""
 	}
 
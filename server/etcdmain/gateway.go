@@ -19,12 +19,14 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/server/v3/proxy/tcpproxy"
 )
 
@@ -66,13 +68,13 @@ func newGatewayStartCommand() *cobra.Command {
 		Run:   startGateway,
 	}
 
-	cmd.Flags().StringVar(&gatewayListenAddr, "listen-addr", "127.0.0.1:23790", "listen address")
+	cmd.Flags().StringVar(&gatewayListenAddr, "listen-addr", "127.0.0.1:23790", "listen address, or unix:///path/to/socket to listen on a unix socket")
 	cmd.Flags().StringVar(&gatewayDNSCluster, "discovery-srv", "", "DNS domain used to bootstrap initial cluster")
 	cmd.Flags().StringVar(&gatewayDNSClusterServiceName, "discovery-srv-name", "", "service name to query when using DNS discovery")
 	cmd.Flags().BoolVar(&gatewayInsecureDiscovery, "insecure-discovery", false, "accept insecure SRV records")
 	cmd.Flags().StringVar(&gatewayCA, "trusted-ca-file", "", "path to the client server TLS CA file for verifying the discovered endpoints when discovery-srv is provided.")
 
-	cmd.Flags().StringSliceVar(&gatewayEndpoints, "endpoints", []string{"127.0.0.1:2379"}, "comma separated etcd cluster endpoints")
+	cmd.Flags().StringSliceVar(&gatewayEndpoints, "endpoints", []string{"127.0.0.1:2379"}, "comma separated etcd cluster endpoints, unix:///path/to/socket for unix socket endpoints")
 
 	cmd.Flags().DurationVar(&gatewayRetryDelay, "retry-delay", time.Minute, "duration of delay before retrying failed endpoints")
 
@@ -82,8 +84,14 @@ func newGatewayStartCommand() *cobra.Command {
 func stripSchema(eps []string) []string {
 	var endpoints []string
 	for _, ep := range eps {
-		if u, err := url.Parse(ep); err == nil && u.Host != "" {
-			ep = u.Host
+		if u, err := url.Parse(ep); err == nil {
+			switch {
+			case u.Host != "":
+				ep = u.Host
+			case u.Scheme == "unix" || u.Scheme == "unixs":
+				// unix:///path/to/socket
+				ep = u.Path
+			}
 		}
 		endpoints = append(endpoints, ep)
 	}
@@ -109,6 +117,11 @@ func startGateway(cmd *cobra.Command, args []string) {
 	srvs.Endpoints = stripSchema(srvs.Endpoints)
 	if len(srvs.SRVs) == 0 {
 		for _, ep := range srvs.Endpoints {
+			if strings.HasPrefix(ep, "/") {
+				// unix socket endpoint, see tcpproxy.TCPProxy.Endpoints.
+				srvs.SRVs = append(srvs.SRVs, &net.SRV{Target: ep})
+				continue
+			}
 			h, p, serr := net.SplitHostPort(ep)
 			if serr != nil {
 				fmt.Printf("error parsing endpoint %q", ep)
@@ -120,6 +133,39 @@ func startGateway(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if len(srvs.Endpoints) == 0 {
+		fmt.Println("no endpoints found")
+		os.Exit(1)
+	}
+
+	var l net.Listener
+	if sock, ok := strings.CutPrefix(gatewayListenAddr, "unix://"); ok {
+		l, err = transport.NewUnixListener(sock)
+	} else {
+		checkGatewayListenAddr(srvs.SRVs)
+		l, err = net.Listen("tcp", gatewayListenAddr)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	tp := tcpproxy.TCPProxy{
+		Logger:          lg,
+		Listener:        l,
+		Endpoints:       srvs.SRVs,
+		MonitorInterval: gatewayRetryDelay,
+	}
+
+	// At this point, etcd gateway listener is initialized
+	notifySystemd(lg)
+
+	tp.Run()
+}
+
+// checkGatewayListenAddr exits if an endpoint resolves to the TCP listen
+// address of the gateway.
+func checkGatewayListenAddr(srvs []*net.SRV) {
 	lhost, lport, err := net.SplitHostPort(gatewayListenAddr)
 	if err != nil {
 		fmt.Println("failed to validate listen address:", gatewayListenAddr)
@@ -136,7 +182,10 @@ func startGateway(cmd *cobra.Command, args []string) {
 		laddrsMap[addr] = true
 	}
 
-	for _, srv := range srvs.SRVs {
+	for _, srv := range srvs {
+		if srv.Port == 0 && strings.HasPrefix(srv.Target, "/") {
+			continue
+		}
 		var eaddrs []string
 		eaddrs, err = net.LookupHost(srv.Target)
 		if err != nil {
@@ -154,28 +203,4 @@ func startGateway(cmd *cobra.Command, args []string) {
 			}
 		}
 	}
-
-	if len(srvs.Endpoints) == 0 {
-		fmt.Println("no endpoints found")
-		os.Exit(1)
-	}
-
-	var l net.Listener
-	l, err = net.Listen("tcp", gatewayListenAddr)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	tp := tcpproxy.TCPProxy{
-		Logger:          lg,
-		Listener:        l,
-		Endpoints:       srvs.SRVs,
-		MonitorInterval: gatewayRetryDelay,
-	}
-
-	// At this point, etcd gateway listener is initialized
-	notifySystemd(lg)
-
-	tp.Run()
 }
@@ -27,6 +27,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -44,6 +46,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
+	"go.etcd.io/etcd/client/pkg/v3/srv"
 	"go.etcd.io/etcd/client/pkg/v3/tlsutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -68,6 +71,9 @@ var (
 	grpcProxyDNSCluster                string
 	grpcProxyDNSClusterServiceName     string
 	grpcProxyInsecureDiscovery         bool
+	grpcProxyDNSRefreshInterval        time.Duration
+	grpcProxyDNSTargetCAs              []string
+	grpcProxyDNSVerifyTargetName       bool
 	grpcProxyDataDir                   string
 	grpcMaxCallSendMsgSize             int
 	grpcMaxCallRecvMsgSize             int
@@ -142,6 +148,9 @@ func newGRPCProxyStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&grpcProxyDNSClusterServiceName, "discovery-srv-name", "", "service name to query when using DNS discovery")
 	cmd.Flags().StringVar(&grpcProxyMetricsListenAddr, "metrics-addr", "", "listen for endpoint /metrics requests on an additional interface")
 	cmd.Flags().BoolVar(&grpcProxyInsecureDiscovery, "insecure-discovery", false, "accept insecure SRV records")
+	cmd.Flags().DurationVar(&grpcProxyDNSRefreshInterval, "discovery-srv-refresh-interval", 0, "interval at which SRV records are re-resolved to update the etcd cluster endpoints (disabled by default)")
+	cmd.Flags().StringSliceVar(&grpcProxyDNSTargetCAs, "discovery-srv-target-cacert", nil, "comma separated 'target=ca-file' pairs verifying the SRV targets with their own CA bundle")
+	cmd.Flags().BoolVar(&grpcProxyDNSVerifyTargetName, "discovery-srv-verify-target-name", false, "verify the certificate of each SRV target against the target host name instead of the SRV domain")
 	cmd.Flags().StringSliceVar(&grpcProxyEndpoints, "endpoints", []string{"127.0.0.1:2379"}, "comma separated etcd cluster endpoints")
 	cmd.Flags().DurationVar(&grpcProxyEndpointsAutoSyncInterval, "endpoints-auto-sync-interval", 0, "etcd endpoints auto sync interval (disabled by default)")
 	cmd.Flags().DurationVar(&grpcProxyDialKeepAliveTime, "dial-keepalive-time", 0, "keepalive time for client(grpc-proxy) connections (default 0, disable).")
@@ -361,6 +370,18 @@ func mustNewClient(lg *zap.Logger) *clientv3.Client {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	if grpcProxyDNSCluster != "" && grpcProxyDNSRefreshInterval > 0 {
+		go srv.WatchClient(client.Ctx(), "etcd-client", grpcProxyDNSCluster, grpcProxyDNSClusterServiceName, grpcProxyDNSRefreshInterval, eps, func(endpoints []string) {
+			if !grpcProxyInsecureDiscovery {
+				endpoints = slices.DeleteFunc(endpoints, func(ep string) bool { return strings.HasPrefix(ep, "http://") })
+			}
+			if len(endpoints) == 0 {
+				return
+			}
+			lg.Info("updating endpoints from SRV", zap.String("srv-server", grpcProxyDNSCluster), zap.Strings("endpoints", endpoints))
+			client.SetEndpoints(endpoints...)
+		})
+	}
 	return client
 }
 
@@ -434,6 +455,18 @@ func newClientCfg(lg *zap.Logger, eps []string) (*clientv3.Config, error) {
 		cfg.TLS = clientTLS
 		lg.Info("gRPC proxy client TLS", zap.String("tls-info", fmt.Sprintf("%+v", tls)))
 	}
+	if grpcProxyDNSCluster != "" {
+		targetCAs, err := srv.ParseTargetCAFiles(grpcProxyDNSTargetCAs)
+		if err != nil {
+			return nil, err
+		}
+		targetTLS := srv.TargetTLS{TrustedCAFiles: targetCAs, VerifyTargetName: grpcProxyDNSVerifyTargetName}
+		if targetTLS.Enabled() {
+			if cfg.TLS, err = targetTLS.ClientConfig(cfg.TLS, grpcProxyDNSCluster); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return &cfg, nil
 }
 
@@ -85,12 +85,26 @@ Member:
     BackendBatchInterval is the maximum time before commit the backend transaction.
   --backend-batch-limit '0'
     BackendBatchLimit is the maximum operations before commit the backend transaction.
+  --backend-batch-limit-max '0'
+    BackendBatchLimitMax is the maximum the backend batch limit grows to under load. The batch limit is fixed if it is not greater than the batch limit.
+  --wal-group-commit-delay '0s'
+    Maximum time the leader holds back the WAL save after saving entries to group the entries proposed meanwhile into one fsync (0 to disable).
+  --wal-group-commit-entries '128'
+    Number of proposals after which the leader stops holding back the WAL save before the group commit delay expires.
   --max-txn-ops '128'
     Maximum number of operations permitted in a transaction.
   --max-request-bytes '1572864'
     Maximum client request size in bytes the server will accept.
   --max-concurrent-streams 'math.MaxUint32'
     Maximum concurrent streams that each client can open at a time.
+  --max-streams-per-connection '0'
+    Maximum number of concurrent streams of a client connection, failing the streams exceeding it (0 means no limit).
+  --max-watchers-per-stream '0'
+    Maximum number of watchers of a watch stream (0 means no limit).
+  --max-watchers-per-user '0'
+    Maximum number of watchers of an authenticated user across all watch streams (0 means no limit).
+  --max-watcher-bytes-per-second '0'
+    Maximum rate in bytes per second at which the events of a watcher are sent (0 means no limit).
   --grpc-keepalive-min-time '5s'
     Minimum duration interval that a client should wait before pinging server.
   --grpc-keepalive-interval '2h'
@@ -101,6 +115,8 @@ Member:
     Enable to set socket option SO_REUSEPORT on listeners allowing rebinding of a port already in use.
   --socket-reuse-address 'false'
     Enable to set socket option SO_REUSEADDR on listeners allowing binding to an address in TIME_WAIT state.
+  --socket-activation 'false'
+    Use the listeners passed by systemd socket activation (LISTEN_FDS) for the listen URLs they are bound to.
   --enable-grpc-gateway
     Enable GRPC gateway.
   --raft-read-timeout '` + rafthttp.DefaultConnReadTimeout.String() + `'
@@ -121,6 +137,11 @@ Clustering:
   --initial-cluster-token 'etcd-cluster'
     Initial cluster token for the etcd cluster during bootstrap.
     Specifying this can protect you from unintended cross-cluster interaction when running multiple clusters.
+  --bootstrap-from-peer 'false'
+    Bootstrap a member joining an existing cluster from a snapshot streamed from a running member.
+    The backend is fetched resumably over the peer URLs, along with the raft log after its last snapshot. Ignored on restarts.
+  --bootstrap-from-peer-bytes-per-second '0'
+    Maximum rate in bytes per second at which the snapshot of --bootstrap-from-peer is fetched (0 means no limit).
   --advertise-client-urls 'http://localhost:2379'
     List of this member's client URLs to advertise to the public.
     The client URLs advertised should be accessible to machines that talk to etcd cluster. etcd client libraries parse these URLs to connect to the cluster.
@@ -160,12 +181,40 @@ Clustering:
     DNS srv domain used to bootstrap the cluster.
   --discovery-srv-name ''
     Suffix to the dns srv name queried when bootstrapping.
+  --discovery-srv-target-cacert ''
+    Comma-separated 'target=ca-file' pairs verifying the peers discovered through SRV records with their own CA bundle.
+  --discovery-srv-verify-target-name 'false'
+    Verify the certificate of each peer discovered through SRV records against its target host name instead of the SRV domain.
+  --discovery-srv-refresh-interval '0s'
+    Interval at which SRV records are re-resolved while bootstrapping until they list the local member (disabled by default).
+  --discovery-provider ''
+    Provider used to discover the initial cluster. Valid values include 'kubernetes', 'gce' and 'ec2'.
+  --discovery-provider-selector ''
+    Selects the peers of the discovery provider: '[namespace/]service' of a headless Service for 'kubernetes', 'key=value' instance label or tag for 'gce' and 'ec2'.
+  --discovery-provider-size '0'
+    Expected size of the initial cluster discovered by the discovery provider.
+  --discovery-provider-timeout '` + embed.DefaultDiscoveryProviderTimeout.String() + `'
+    Timeout for discovering all peers of the initial cluster with the discovery provider.
   --strict-reconfig-check '` + strconv.FormatBool(embed.DefaultStrictReconfigCheck) + `'
     Reject reconfiguration requests that would cause quorum loss.
   --pre-vote 'true'
     Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.
+  --leadership-priority '0'
+    Preference of this member to become the leader. Requires the LeadershipPriority feature gate to take effect.
+  --read-replica 'false'
+    Run this learner member as a read replica that is never promoted and serves serializable reads only.
+  --read-replica-max-staleness '10s'
+    Maximum time a read replica may lag behind the leader before it rejects serializable reads.
+  --leader-lease-max-clock-drift '200ms'
+    Maximum clock drift between members tolerated by lease based reads. Requires the LeaseBasedReads feature gate.
+  --read-index-batching-window '0s'
+    Time to wait for more linearizable reads to batch before requesting a read index.
+  --lease-grace-period '0s'
+    Minimum time leases live after a leader election, so that a short unavailability does not expire them.
   --auto-compaction-retention '0'
     Auto compaction retention length. 0 means disable auto compaction.
+  --auto-compaction-prefix-retentions ''
+    Comma-separated list of 'prefix=retention' overriding 'auto-compaction-retention' for the keys under the prefixes, in the same unit (e.g. '/audit/=24h,/cache/=0'). 0 keeps only the latest revision of the keys.
   --auto-compaction-mode 'periodic'
     Interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.
   --v2-deprecation '` + string(cconfig.V2DeprDefault) + `'
@@ -241,6 +290,24 @@ Profiling and Monitoring:
     Enable runtime profiling data via HTTP server. Address is at client URL + "/debug/pprof/"
   --metrics 'basic'
     Set level of detail for exported metrics, specify 'extensive' to include server side grpc histogram metrics.
+  --enable-continuous-profiling 'false'
+    Enable periodic capture of CPU and heap profiles, retained in --continuous-profiling-dir or pushed to --continuous-profiling-push-url.
+  --continuous-profiling-interval '1m'
+    Time between two continuous profile captures.
+  --continuous-profiling-cpu-duration '10s'
+    Duration of each continuous CPU profile.
+  --continuous-profiling-dir ''
+    Directory retaining the continuous profiles.
+  --continuous-profiling-max-files '10'
+    Maximum number of continuous profiles of each type to retain in --continuous-profiling-dir.
+  --continuous-profiling-push-url ''
+    URL of a pprof-compatible endpoint (e.g. Pyroscope /ingest) to push the continuous profiles to, instead of retaining them.
+  --metrics-key-prefixes ''
+    Comma-separated list of key prefixes whose requests, bytes written, watch events and keys are exported as metrics labeled by prefix.
+  --metrics-key-prefix-size-buckets ''
+    Comma-separated list of increasing upper bounds in bytes of the buckets of the put size histogram of the monitored key prefixes.
+  --health-excluded-checks ''
+    Comma-separated list of checks not run by /livez and /readyz unless queried individually, e.g. 'lessor,auth_store'.
   --listen-metrics-urls ''
     List of URLs to listen on for the /metrics and /health endpoints. For https, the client URL TLS info is used.
 
@@ -325,6 +392,10 @@ Experimental feature:
     Set the max number of learner members allowed in the cluster membership. Deprecated in v3.6 and will be decommissioned in v3.7. Use '--max-learners' instead.
   --max-learners '1'
     Set the max number of learner members allowed in the cluster membership.
+  --learner-auto-promote-max-lag '1000'
+    Maximum number of raft entries a learner may lag behind the leader to be considered caught up for automatic promotion. Requires the LearnerAutoPromote feature gate.
+  --learner-auto-promote-duration '30s'
+    Duration a learner needs to stay caught up with the leader before it is automatically promoted. Requires the LearnerAutoPromote feature gate.
   --experimental-snapshot-catch-up-entries '5000'
     Number of entries for a slow follower to catch up after compacting the raft storage entries.
   --experimental-compaction-sleep-interval
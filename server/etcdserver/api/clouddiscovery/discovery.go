@@ -0,0 +1,181 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clouddiscovery builds the initial cluster of a new etcd cluster from
+// the instances of a cloud provider or the endpoints of a Kubernetes Service.
+package clouddiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ProviderKubernetes = "kubernetes"
+	ProviderGCE        = "gce"
+	ProviderEC2        = "ec2"
+)
+
+var (
+	ErrUnknownProvider = errors.New("clouddiscovery: unknown discovery provider")
+	ErrBadSelector     = errors.New("clouddiscovery: bad discovery selector")
+	ErrSelfNotFound    = errors.New("clouddiscovery: local member not found")
+)
+
+// discoveryRetryInterval is the interval between two discovery attempts.
+var discoveryRetryInterval = 5 * time.Second
+
+// Peer is a discovered member of the initial cluster.
+type Peer struct {
+	// Name is the name of the member, which is expected to match its --name.
+	Name string
+	// Host is the host name or IP address the member is reachable at.
+	Host string
+}
+
+// Provider discovers the peers of the initial cluster.
+type Provider interface {
+	// Peers returns the currently known peers.
+	Peers(ctx context.Context) ([]Peer, error)
+}
+
+// NewProvider returns the provider with the given name. The selector names
+// the Kubernetes Service as "[namespace/]name", or the cloud instance tag or
+// label as "key=value".
+func NewProvider(name, selector string) (Provider, error) {
+	switch name {
+	case ProviderKubernetes:
+		return newKubernetesProvider(selector)
+	case ProviderGCE:
+		return newGCEProvider(selector)
+	case ProviderEC2:
+		return newEC2Provider(selector)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+}
+
+// Config configures how the initial cluster is discovered.
+type Config struct {
+	// Provider is the name of the discovery provider.
+	Provider string `json:"provider"`
+	// Selector selects the peers, see NewProvider.
+	Selector string `json:"selector"`
+	// Size is the expected size of the initial cluster.
+	Size int `json:"size"`
+	// Timeout is how long to wait for all peers to be discovered.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// Validate checks the configuration without contacting the provider.
+func (c Config) Validate() error {
+	switch c.Provider {
+	case ProviderKubernetes, ProviderGCE, ProviderEC2:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownProvider, c.Provider)
+	}
+	if c.Selector == "" {
+		return fmt.Errorf("%w: selector must be set", ErrBadSelector)
+	}
+	if c.Size <= 0 {
+		return fmt.Errorf("clouddiscovery: size must be >0 (set to %d)", c.Size)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("clouddiscovery: timeout must be >0 (set to %v)", c.Timeout)
+	}
+	return nil
+}
+
+// GetCluster discovers the initial cluster of the local member with the
+// given name. Discovered peers are reachable with the scheme and port of
+// the local member's advertised peer URLs. It retries until exactly size
+// peers, including the local member, are discovered.
+func GetCluster(ctx context.Context, lg *zap.Logger, p Provider, name string, size int, timeout time.Duration, apurls []url.URL) (string, error) {
+	if len(apurls) == 0 {
+		return "", errors.New("clouddiscovery: no advertised peer URLs")
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		peers, err := p.Peers(ctx)
+		if err == nil {
+			var cluster string
+			cluster, err = buildCluster(peers, name, size, apurls)
+			if err == nil {
+				return cluster, nil
+			}
+		}
+		lg.Warn(
+			"failed to discover initial cluster; retrying",
+			zap.String("name", name),
+			zap.Int("expected-size", size),
+			zap.Duration("retry-interval", discoveryRetryInterval),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(discoveryRetryInterval):
+		case <-ctx.Done():
+			return "", fmt.Errorf("clouddiscovery: %w: %w", ctx.Err(), err)
+		}
+	}
+}
+
+func buildCluster(peers []Peer, name string, size int, apurls []url.URL) (string, error) {
+	seen := make(map[string]bool)
+	var uniq []Peer
+	for _, p := range peers {
+		if p.Name == "" || p.Host == "" || seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		uniq = append(uniq, p)
+	}
+	if !seen[name] {
+		return "", fmt.Errorf("%w: %q", ErrSelfNotFound, name)
+	}
+	if len(uniq) != size {
+		return "", fmt.Errorf("clouddiscovery: discovered %d peers, expected %d", len(uniq), size)
+	}
+	sort.Slice(uniq, func(i, j int) bool { return uniq[i].Name < uniq[j].Name })
+
+	var stringParts []string
+	for _, p := range uniq {
+		for _, u := range apurls {
+			_, port, err := net.SplitHostPort(u.Host)
+			if err != nil {
+				return "", err
+			}
+			pu := url.URL{Scheme: u.Scheme, Host: net.JoinHostPort(p.Host, port)}
+			stringParts = append(stringParts, fmt.Sprintf("%s=%s", p.Name, pu.String()))
+		}
+	}
+	return strings.Join(stringParts, ","), nil
+}
+
+// parseTagSelector parses a "key=value" selector.
+func parseTagSelector(selector string) (key, value string, err error) {
+	key, value, ok := strings.Cut(selector, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("%w: %q, expected \"key=value\"", ErrBadSelector, selector)
+	}
+	return key, value, nil
+}
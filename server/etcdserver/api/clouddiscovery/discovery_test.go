@@ -0,0 +1,175 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clouddiscovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeProvider struct {
+	peers [][]Peer
+	calls int
+}
+
+func (p *fakeProvider) Peers(ctx context.Context) ([]Peer, error) {
+	peers := p.peers[min(p.calls, len(p.peers)-1)]
+	p.calls++
+	return peers, nil
+}
+
+func TestGetCluster(t *testing.T) {
+	old := discoveryRetryInterval
+	discoveryRetryInterval = time.Millisecond
+	defer func() { discoveryRetryInterval = old }()
+
+	apurls := []url.URL{{Scheme: "https", Host: "10.0.0.1:2380"}}
+	p := &fakeProvider{peers: [][]Peer{
+		{{Name: "b", Host: "10.0.0.2"}},
+		{{Name: "b", Host: "10.0.0.2"}, {Name: "a", Host: "10.0.0.1"}},
+		{{Name: "c", Host: "10.0.0.3"}, {Name: "b", Host: "10.0.0.2"}, {Name: "a", Host: "10.0.0.1"}, {Name: "a", Host: "10.0.0.1"}},
+	}}
+	cluster, err := GetCluster(context.Background(), zaptest.NewLogger(t), p, "a", 3, time.Second, apurls)
+	require.NoError(t, err)
+	assert.Equal(t, "a=https://10.0.0.1:2380,b=https://10.0.0.2:2380,c=https://10.0.0.3:2380", cluster)
+	assert.Equal(t, 3, p.calls)
+
+	p = &fakeProvider{peers: [][]Peer{{{Name: "b", Host: "10.0.0.2"}}}}
+	_, err = GetCluster(context.Background(), zaptest.NewLogger(t), p, "a", 1, 20*time.Millisecond, apurls)
+	require.ErrorIs(t, err, ErrSelfNotFound)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestKubernetesPeers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/discovery.k8s.io/v1/namespaces/db/endpointslices", r.URL.Path)
+		assert.Equal(t, "kubernetes.io/service-name=etcd", r.URL.Query().Get("labelSelector"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"items":[{"endpoints":[
+			{"addresses":["10.1.0.1"],"hostname":"etcd-0","targetRef":{"kind":"Pod","name":"etcd-0"}},
+			{"addresses":["10.1.0.2"],"targetRef":{"kind":"Pod","name":"etcd-1"}}
+		]}]}`)
+	}))
+	defer srv.Close()
+
+	p := &kubernetesProvider{apiServer: srv.URL, client: srv.Client(), token: "token", namespace: "db", service: "etcd"}
+	peers, err := p.Peers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Peer{{Name: "etcd-0", Host: "etcd-0.etcd.db.svc"}, {Name: "etcd-1", Host: "10.1.0.2"}}, peers)
+}
+
+func TestGCEPeers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/metadata/"):
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		default:
+			assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/metadata/project/project-id":
+			fmt.Fprint(w, "proj")
+		case "/metadata/instance/service-accounts/default/token":
+			fmt.Fprint(w, `{"access_token":"access"}`)
+		case "/compute/projects/proj/aggregated/instances":
+			assert.Equal(t, `(labels.cluster = "etcd") AND (status = RUNNING)`, r.URL.Query().Get("filter"))
+			if r.URL.Query().Get("pageToken") == "" {
+				fmt.Fprint(w, `{"items":{"zones/a":{"instances":[{"name":"etcd-a","status":"RUNNING","networkInterfaces":[{"networkIP":"10.2.0.1"}]}]}},"nextPageToken":"next"}`)
+				return
+			}
+			fmt.Fprint(w, `{"items":{"zones/b":{"instances":[{"name":"etcd-b","status":"RUNNING","networkInterfaces":[{"networkIP":"10.2.0.2"}]}]}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := newGCEProvider("cluster=etcd")
+	require.NoError(t, err)
+	p.metadataURL, p.computeURL, p.client = srv.URL+"/metadata", srv.URL+"/compute", srv.Client()
+	peers, err := p.Peers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Peer{{Name: "etcd-a", Host: "10.2.0.1"}, {Name: "etcd-b", Host: "10.2.0.2"}}, peers)
+}
+
+func TestEC2Peers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			assert.Equal(t, http.MethodPut, r.Method)
+			fmt.Fprint(w, "imds")
+		case "/latest/meta-data/placement/region":
+			assert.Equal(t, "imds", r.Header.Get("X-aws-ec2-metadata-token"))
+			fmt.Fprint(w, "us-east-1")
+		case "/latest/meta-data/iam/security-credentials/":
+			fmt.Fprint(w, "etcd-role")
+		case "/latest/meta-data/iam/security-credentials/etcd-role":
+			fmt.Fprint(w, `{"AccessKeyId":"AKID","SecretAccessKey":"secret","Token":"session"}`)
+		case "/":
+			q := r.URL.Query()
+			assert.Equal(t, "DescribeInstances", q.Get("Action"))
+			assert.Equal(t, "tag:cluster", q.Get("Filter.1.Name"))
+			assert.Equal(t, "etcd", q.Get("Filter.1.Value.1"))
+			assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+			fmt.Fprint(w, `<DescribeInstancesResponse><reservationSet>
+				<item><instancesSet><item><instanceId>i-1</instanceId><privateIpAddress>10.3.0.1</privateIpAddress></item></instancesSet></item>
+				<item><instancesSet><item><instanceId>i-2</instanceId><privateIpAddress>10.3.0.2</privateIpAddress></item></instancesSet></item>
+			</reservationSet></DescribeInstancesResponse>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	p, err := newEC2Provider("cluster=etcd")
+	require.NoError(t, err)
+	p.metadataURL, p.endpoint, p.client = srv.URL+"/latest", srv.URL, srv.Client()
+	peers, err := p.Peers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Peer{{Name: "i-1", Host: "10.3.0.1"}, {Name: "i-2", Host: "10.3.0.2"}}, peers)
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// get-vanilla-query-order-key-case of the AWS signature version 4 test suite.
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/?Param1=value1&Param2=value2", nil)
+	require.NoError(t, err)
+	creds := awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, "us-east-1", "service", creds, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		req.Header.Get("Authorization"))
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider("azure", "a=b")
+	require.ErrorIs(t, err, ErrUnknownProvider)
+	_, err = NewProvider(ProviderEC2, "cluster")
+	require.ErrorIs(t, err, ErrBadSelector)
+	_, err = NewProvider(ProviderKubernetes, "db/")
+	require.ErrorIs(t, err, ErrBadSelector)
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clouddiscovery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const ec2MetadataURL = "http://169.254.169.254/latest"
+
+// ec2Provider discovers peers from the running EC2 instances of the local
+// region with the given tag. Peers are named after their instance ID.
+type ec2Provider struct {
+	metadataURL string
+	// endpoint overrides the regional EC2 API endpoint.
+	endpoint string
+	client   *http.Client
+	now      func() time.Time
+
+	tagKey, tagValue string
+}
+
+func newEC2Provider(selector string) (*ec2Provider, error) {
+	key, value, err := parseTagSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &ec2Provider{
+		metadataURL: ec2MetadataURL,
+		client:      &http.Client{},
+		now:         time.Now,
+		tagKey:      key,
+		tagValue:    value,
+	}, nil
+}
+
+type awsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+type ec2DescribeInstancesResponse struct {
+	Reservations []struct {
+		Instances []struct {
+			InstanceID       string `xml:"instanceId"`
+			PrivateIPAddress string `xml:"privateIpAddress"`
+		} `xml:"instancesSet>item"`
+	} `xml:"reservationSet>item"`
+	NextToken string `xml:"nextToken"`
+}
+
+func (p *ec2Provider) Peers(ctx context.Context) ([]Peer, error) {
+	region, creds, err := p.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := p.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://ec2.%s.amazonaws.com", region)
+	}
+
+	var peers []Peer
+	nextToken := ""
+	for {
+		q := url.Values{}
+		q.Set("Action", "DescribeInstances")
+		q.Set("Version", "2016-11-15")
+		q.Set("Filter.1.Name", "tag:"+p.tagKey)
+		q.Set("Filter.1.Value.1", p.tagValue)
+		q.Set("Filter.2.Name", "instance-state-name")
+		q.Set("Filter.2.Value.1", "running")
+		if nextToken != "" {
+			q.Set("NextToken", nextToken)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/?"+awsQueryEncode(q), nil)
+		if err != nil {
+			return nil, err
+		}
+		signAWSRequest(req, region, "ec2", creds, p.now())
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var out ec2DescribeInstancesResponse
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("clouddiscovery: DescribeInstances: unexpected status %s", resp.Status)
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&out)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range out.Reservations {
+			for _, inst := range r.Instances {
+				peers = append(peers, Peer{Name: inst.InstanceID, Host: inst.PrivateIPAddress})
+			}
+		}
+		if out.NextToken == "" {
+			return peers, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+// credentials returns the region and credentials from the environment, or
+// from the instance metadata service.
+func (p *ec2Provider) credentials(ctx context.Context) (string, awsCredentials, error) {
+	region := os.Getenv("AWS_REGION")
+	creds := awsCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		Token:           os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if region != "" && creds.AccessKeyID != "" {
+		return region, creds, nil
+	}
+
+	// IMDSv2 requires a session token for all metadata requests.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.metadataURL+"/api/token", nil)
+	if err != nil {
+		return "", creds, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+	token, err := doText(p.client, req)
+	if err != nil {
+		return "", creds, err
+	}
+	metadata := func(path string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL+"/meta-data"+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+		return req, nil
+	}
+
+	if region == "" {
+		req, err = metadata("/placement/region")
+		if err != nil {
+			return "", creds, err
+		}
+		if region, err = doText(p.client, req); err != nil {
+			return "", creds, err
+		}
+	}
+	if creds.AccessKeyID == "" {
+		req, err = metadata("/iam/security-credentials/")
+		if err != nil {
+			return "", creds, err
+		}
+		role, err := doText(p.client, req)
+		if err != nil {
+			return "", creds, err
+		}
+		role, _, _ = strings.Cut(role, "\n")
+		req, err = metadata("/iam/security-credentials/" + role)
+		if err != nil {
+			return "", creds, err
+		}
+		if err = doJSON(p.client, req, &creds); err != nil {
+			return "", creds, err
+		}
+	}
+	return region, creds, nil
+}
+
+// awsQueryEncode encodes the query in the canonical form of AWS signature
+// version 4, which escapes spaces as "%20".
+func awsQueryEncode(q url.Values) string {
+	return strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+// signAWSRequest signs a GET request without body with AWS signature version 4.
+func signAWSRequest(req *http.Request, region, service string, creds awsCredentials, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	headers := []string{"host:" + req.URL.Host, "x-amz-date:" + amzDate}
+	signedHeaders := "host;x-amz-date"
+	if creds.Token != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.Token)
+		headers = append(headers, "x-amz-security-token:"+creds.Token)
+		signedHeaders += ";x-amz-security-token"
+	}
+
+	emptyHash := sha256.Sum256(nil)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		req.URL.RawQuery,
+		strings.Join(headers, "\n") + "\n",
+		signedHeaders,
+		hex.EncodeToString(emptyHash[:]),
+	}, "\n")
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(crHash[:])}, "\n")
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, s := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
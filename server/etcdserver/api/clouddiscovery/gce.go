@@ -0,0 +1,149 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clouddiscovery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	gceMetadataURL = "http://metadata.google.internal/computeMetadata/v1"
+	gceComputeURL  = "https://compute.googleapis.com/compute/v1"
+)
+
+// gceProvider discovers peers from the running GCE instances of the local
+// project with the given label. Peers are named after their instance name.
+type gceProvider struct {
+	metadataURL string
+	computeURL  string
+	client      *http.Client
+
+	labelKey, labelValue string
+}
+
+func newGCEProvider(selector string) (*gceProvider, error) {
+	key, value, err := parseTagSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &gceProvider{
+		metadataURL: gceMetadataURL,
+		computeURL:  gceComputeURL,
+		client:      &http.Client{},
+		labelKey:    key,
+		labelValue:  value,
+	}, nil
+}
+
+type gceInstanceList struct {
+	Items map[string]struct {
+		Instances []struct {
+			Name              string `json:"name"`
+			Status            string `json:"status"`
+			NetworkInterfaces []struct {
+				NetworkIP string `json:"networkIP"`
+			} `json:"networkInterfaces"`
+		} `json:"instances"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (p *gceProvider) Peers(ctx context.Context) ([]Peer, error) {
+	project, err := p.metadata(ctx, "/project/project-id")
+	if err != nil {
+		return nil, err
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	req, err := p.metadataRequest(ctx, "/instance/service-accounts/default/token")
+	if err != nil {
+		return nil, err
+	}
+	if err = doJSON(p.client, req, &token); err != nil {
+		return nil, err
+	}
+
+	var peers []Peer
+	pageToken := ""
+	for {
+		q := url.Values{}
+		q.Set("filter", fmt.Sprintf("(labels.%s = %q) AND (status = RUNNING)", p.labelKey, p.labelValue))
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u := fmt.Sprintf("%s/projects/%s/aggregated/instances?%s", p.computeURL, url.PathEscape(project), q.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		var list gceInstanceList
+		if err := doJSON(p.client, req, &list); err != nil {
+			return nil, err
+		}
+		for _, zone := range list.Items {
+			for _, inst := range zone.Instances {
+				if inst.Status != "RUNNING" || len(inst.NetworkInterfaces) == 0 {
+					continue
+				}
+				peers = append(peers, Peer{Name: inst.Name, Host: inst.NetworkInterfaces[0].NetworkIP})
+			}
+		}
+		if list.NextPageToken == "" {
+			return peers, nil
+		}
+		pageToken = list.NextPageToken
+	}
+}
+
+func (p *gceProvider) metadataRequest(ctx context.Context, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	return req, nil
+}
+
+func (p *gceProvider) metadata(ctx context.Context, path string) (string, error) {
+	req, err := p.metadataRequest(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	return doText(p.client, req)
+}
+
+// doText sends the request and returns the response body as a string.
+func doText(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("clouddiscovery: %s %s: unexpected status %s", req.Method, req.URL.Redacted(), resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clouddiscovery
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// serviceAccountDir is where Kubernetes mounts the service account of a pod.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// kubernetesProvider discovers peers from the EndpointSlices of a headless
+// Service. Endpoints which are not ready are included, as members are not
+// ready before the cluster is bootstrapped.
+type kubernetesProvider struct {
+	apiServer string
+	client    *http.Client
+	token     string
+	namespace string
+	service   string
+}
+
+func newKubernetesProvider(selector string) (*kubernetesProvider, error) {
+	namespace, service, ok := strings.Cut(selector, "/")
+	if !ok {
+		namespace, service = "", selector
+	}
+	if service == "" {
+		return nil, fmt.Errorf("%w: %q, expected \"[namespace/]service\"", ErrBadSelector, selector)
+	}
+	if namespace == "" {
+		ns, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("clouddiscovery: cannot determine namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("clouddiscovery: not running in a Kubernetes cluster")
+	}
+	token, err := os.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("clouddiscovery: cannot read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("clouddiscovery: cannot read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("clouddiscovery: no certificates found in service account CA")
+	}
+	return &kubernetesProvider{
+		apiServer: "https://" + net.JoinHostPort(host, port),
+		client: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}},
+		token:     strings.TrimSpace(string(token)),
+		namespace: namespace,
+		service:   service,
+	}, nil
+}
+
+type endpointSliceList struct {
+	Items []struct {
+		Endpoints []struct {
+			Addresses []string `json:"addresses"`
+			Hostname  string   `json:"hostname"`
+			TargetRef *struct {
+				Kind string `json:"kind"`
+				Name string `json:"name"`
+			} `json:"targetRef"`
+		} `json:"endpoints"`
+	} `json:"items"`
+}
+
+func (p *kubernetesProvider) Peers(ctx context.Context) ([]Peer, error) {
+	u := fmt.Sprintf("%s/apis/discovery.k8s.io/v1/namespaces/%s/endpointslices?labelSelector=%s",
+		p.apiServer, url.PathEscape(p.namespace), url.QueryEscape("kubernetes.io/service-name="+p.service))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	var list endpointSliceList
+	if err := doJSON(p.client, req, &list); err != nil {
+		return nil, err
+	}
+
+	var peers []Peer
+	for _, slice := range list.Items {
+		for _, ep := range slice.Endpoints {
+			var peer Peer
+			switch {
+			case ep.TargetRef != nil && ep.TargetRef.Kind == "Pod":
+				peer.Name = ep.TargetRef.Name
+			default:
+				peer.Name = ep.Hostname
+			}
+			switch {
+			case ep.Hostname != "":
+				// pods of a StatefulSet have stable DNS names under a headless Service.
+				peer.Host = fmt.Sprintf("%s.%s.%s.svc", ep.Hostname, p.service, p.namespace)
+			case len(ep.Addresses) > 0:
+				peer.Host = ep.Addresses[0]
+			}
+			peers = append(peers, peer)
+		}
+	}
+	return peers, nil
+}
+
+// doJSON sends the request and decodes the JSON response into v.
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("clouddiscovery: %s %s: unexpected status %s", req.Method, req.URL.Redacted(), resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
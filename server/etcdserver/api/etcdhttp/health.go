@@ -24,7 +24,10 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -33,6 +36,8 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/raft/v3"
 )
 
@@ -53,6 +58,10 @@ type ServerHealth interface {
 	Config() config.ServerConfig
 	AuthStore() auth.AuthStore
 	IsLearner() bool
+	IsDraining() bool
+	Backend() backend.Backend
+	Lessor() lease.Lessor
+	WALSaveInProgress() time.Duration
 }
 
 // HandleHealth registers metrics and health handlers. it checks health by using v3 range request
@@ -158,6 +167,15 @@ type Health struct {
 type HealthStatus struct {
 	Reason string `json:"reason"`
 	Status string `json:"status"`
+	// Checks are the results of the individual checks, in the order they ran.
+	Checks []CheckStatus `json:"checks,omitempty"`
+}
+
+// CheckStatus is the result of an individual /readyz or /livez check.
+type CheckStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
 }
 
 func getQuerySet(r *http.Request, query string) StringSet {
@@ -238,16 +256,20 @@ type HealthCheck func(ctx context.Context) error
 type CheckRegistry struct {
 	checkType string
 	checks    map[string]HealthCheck
+	// excluded are the checks not run by the root path unless they are
+	// queried individually.
+	excluded StringSet
 }
 
 func installLivezEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHealth) {
-	reg := CheckRegistry{checkType: checkTypeLivez, checks: make(map[string]HealthCheck)}
+	reg := CheckRegistry{checkType: checkTypeLivez, checks: make(map[string]HealthCheck), excluded: listToStringSet(server.Config().HealthExcludedChecks)}
 	reg.Register("serializable_read", readCheck(server, true /* serializable */))
+	registerSubsystemChecks(&reg, server)
 	reg.InstallHTTPEndpoints(lg, mux)
 }
 
 func installReadyzEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHealth) {
-	reg := CheckRegistry{checkType: checkTypeReadyz, checks: make(map[string]HealthCheck)}
+	reg := CheckRegistry{checkType: checkTypeReadyz, checks: make(map[string]HealthCheck), excluded: listToStringSet(server.Config().HealthExcludedChecks)}
 	reg.Register("data_corruption", activeAlarmCheck(server, pb.AlarmType_CORRUPT))
 	// serializable_read checks if local read is ok.
 	// linearizable_read checks if there is consensus in the cluster.
@@ -257,9 +279,28 @@ func installReadyzEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHea
 	reg.Register("linearizable_read", readCheck(server, false))
 	// check if local is learner
 	reg.Register("non_learner", learnerCheck(server))
+	// check if local member is being drained for maintenance
+	reg.Register("not_draining", drainingCheck(server))
+	reg.Register("leader_present", leaderCheck(server))
+	registerSubsystemChecks(&reg, server)
 	reg.InstallHTTPEndpoints(lg, mux)
 }
 
+// registerSubsystemChecks registers the checks of the local subsystems, which
+// fail when the subsystem is stuck, so that a restart of the member may help.
+func registerSubsystemChecks(reg *CheckRegistry, server ServerHealth) {
+	reg.Register("wal_fsync", stuckCheck(server, "WAL save", server.WALSaveInProgress))
+	reg.Register("backend_commit", stuckCheck(server, "backend commit", func() time.Duration {
+		return server.Backend().CommitInProgress()
+	}))
+	reg.Register("lessor", respondCheck(server, "lessor", func() {
+		server.Lessor().Lookup(lease.NoLease)
+	}))
+	reg.Register("auth_store", respondCheck(server, "auth store", func() {
+		server.AuthStore().IsAuthEnabled()
+	}))
+}
+
 func (reg *CheckRegistry) Register(name string, check HealthCheck) {
 	reg.checks[name] = check
 }
@@ -282,6 +323,7 @@ func (reg *CheckRegistry) InstallHTTPEndpoints(lg *zap.Logger, mux *http.ServeMu
 	for k := range reg.checks {
 		checkNames = append(checkNames, k)
 	}
+	sort.Strings(checkNames)
 
 	// installs the http handler for the root path.
 	reg.installRootHTTPEndpoint(lg, mux, checkNames...)
@@ -306,9 +348,11 @@ func (reg *CheckRegistry) runHealthChecks(ctx context.Context, checkNames ...str
 		if err := check(ctx); err != nil {
 			fmt.Fprintf(&individualCheckOutput, "[-]%s failed: %v\n", checkName, err)
 			h.Status = HealthStatusError
+			h.Checks = append(h.Checks, CheckStatus{Name: checkName, Status: HealthStatusError, Reason: err.Error()})
 			recordMetrics(reg.checkType, checkName, HealthStatusError)
 		} else {
 			fmt.Fprintf(&individualCheckOutput, "[+]%s ok\n", checkName)
+			h.Checks = append(h.Checks, CheckStatus{Name: checkName, Status: HealthStatusSuccess})
 			recordMetrics(reg.checkType, checkName, HealthStatusSuccess)
 		}
 	}
@@ -318,11 +362,20 @@ func (reg *CheckRegistry) runHealthChecks(ctx context.Context, checkNames ...str
 
 // installRootHTTPEndpoint installs the http handler for the root path.
 func (reg *CheckRegistry) installRootHTTPEndpoint(lg *zap.Logger, mux *http.ServeMux, checks ...string) {
+	// the configured exclusions of checks which are not registered, e.g. the
+	// readyz only checks for livez, are ignored.
+	defaultChecks := make([]string, 0, len(checks))
+	for _, check := range checks {
+		if _, found := reg.excluded[check]; !found {
+			defaultChecks = append(defaultChecks, check)
+		}
+	}
 	hfunc := func(r *http.Request) HealthStatus {
 		// extracts the health check names to be excludeList from the query param
 		excluded := getQuerySet(r, "exclude")
 
-		filteredCheckNames := filterCheckList(lg, listToStringSet(checks), excluded)
+		filteredCheckNames := filterCheckList(lg, listToStringSet(defaultChecks), excluded)
+		sort.Strings(filteredCheckNames)
 		h := reg.runHealthChecks(r.Context(), filteredCheckNames...)
 		return h
 	}
@@ -339,6 +392,10 @@ func newHealthHandler(path string, lg *zap.Logger, hfunc func(*http.Request) Hea
 			return
 		}
 		h := hfunc(r)
+		if r.URL.Query().Get("format") == "json" {
+			writeHealthStatusJSON(w, path, lg, h)
+			return
+		}
 		// Always returns detailed reason for failed checks.
 		if h.Status == HealthStatusError {
 			http.Error(w, h.Reason, http.StatusServiceUnavailable)
@@ -356,6 +413,22 @@ func newHealthHandler(path string, lg *zap.Logger, hfunc func(*http.Request) Hea
 	}
 }
 
+// writeHealthStatusJSON writes the health status with the results of the
+// individual checks as JSON, for machine consumption.
+func writeHealthStatusJSON(w http.ResponseWriter, path string, lg *zap.Logger, h HealthStatus) {
+	d, _ := json.Marshal(h)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if h.Status == HealthStatusError {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write(d)
+		lg.Error("Health check error", zap.String("path", path), zap.String("reason", h.Reason), zap.Int("status-code", http.StatusServiceUnavailable))
+		return
+	}
+	w.Write(d)
+	lg.Debug("Health check OK", zap.String("path", path), zap.String("reason", h.Reason), zap.Int("status-code", http.StatusOK))
+}
+
 func filterCheckList(lg *zap.Logger, checks StringSet, excluded StringSet) []string {
 	filteredList := []string{}
 	for chk := range checks {
@@ -445,3 +518,70 @@ func learnerCheck(srv ServerHealth) func(ctx context.Context) error {
 		return nil
 	}
 }
+
+func drainingCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if srv.IsDraining() {
+			return fmt.Errorf("member is draining")
+		}
+		return nil
+	}
+}
+
+func leaderCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if uint64(srv.Leader()) == raft.None {
+			return fmt.Errorf("no leader")
+		}
+		return nil
+	}
+}
+
+// stuckCheck fails if the operation in progress returned by inProgress has
+// been running for longer than the request timeout.
+func stuckCheck(srv ServerHealth, op string, inProgress func() time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		cfg := srv.Config()
+		if d, timeout := inProgress(), cfg.ReqTimeout(); d > timeout {
+			return fmt.Errorf("%s in progress for %v, longer than %v", op, d.Round(time.Millisecond), timeout)
+		}
+		return nil
+	}
+}
+
+// respondCheck fails if f does not return within the request timeout, e.g.
+// when the subsystem it queries is deadlocked. f keeps running in the
+// background until it returns, and the checks meanwhile wait for it instead
+// of calling f again, so that a stuck subsystem does not pile up goroutines.
+func respondCheck(srv ServerHealth, subsystem string, f func()) func(ctx context.Context) error {
+	var (
+		mu sync.Mutex
+		// inflight is closed when the call of f in progress returns, nil if none.
+		inflight chan struct{}
+	)
+	return func(ctx context.Context) error {
+		cfg := srv.Config()
+		ctx, cancel := context.WithTimeout(ctx, cfg.ReqTimeout())
+		defer cancel()
+		mu.Lock()
+		donec := inflight
+		if donec == nil {
+			donec = make(chan struct{})
+			inflight = donec
+			go func() {
+				f()
+				mu.Lock()
+				inflight = nil
+				mu.Unlock()
+				close(donec)
+			}()
+		}
+		mu.Unlock()
+		select {
+		case <-donec:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("%s not responding: %w", subsystem, ctx.Err())
+		}
+	}
+}
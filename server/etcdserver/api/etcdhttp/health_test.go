@@ -16,12 +16,15 @@ package etcdhttp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap/zaptest"
@@ -33,6 +36,8 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
@@ -44,8 +49,19 @@ type fakeHealthServer struct {
 	missingLeader         bool
 	authStore             auth.AuthStore
 	isLearner             bool
+	isDraining            bool
+	walSaveInProgress     time.Duration
+	commitInProgress      time.Duration
+	excludedChecks        []string
 }
 
+type fakeCommitBackend struct {
+	backend.Backend
+	commitInProgress time.Duration
+}
+
+func (b *fakeCommitBackend) CommitInProgress() time.Duration { return b.commitInProgress }
+
 func (s *fakeHealthServer) Range(_ context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
 	if req.Serializable {
 		return nil, s.serializableReadError
@@ -57,10 +73,22 @@ func (s *fakeHealthServer) IsLearner() bool {
 	return s.isLearner
 }
 
+func (s *fakeHealthServer) IsDraining() bool {
+	return s.isDraining
+}
+
 func (s *fakeHealthServer) Config() config.ServerConfig {
-	return config.ServerConfig{}
+	return config.ServerConfig{HealthExcludedChecks: s.excludedChecks}
+}
+
+func (s *fakeHealthServer) Backend() backend.Backend {
+	return &fakeCommitBackend{commitInProgress: s.commitInProgress}
 }
 
+func (s *fakeHealthServer) Lessor() lease.Lessor { return &lease.FakeLessor{} }
+
+func (s *fakeHealthServer) WALSaveInProgress() time.Duration { return s.walSaveInProgress }
+
 func (s *fakeHealthServer) Leader() types.ID {
 	if !s.missingLeader {
 		return 1
@@ -83,6 +111,7 @@ type healthTestCase struct {
 	apiError      error
 	missingLeader bool
 	isLearner     bool
+	isDraining    bool
 }
 
 func TestHealthHandler(t *testing.T) {
@@ -391,6 +420,207 @@ func TestLearnerReadyCheck(t *testing.T) {
 	}
 }
 
+func TestDrainingReadyCheck(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+	tests := []healthTestCase{
+		{
+			name:             "readyz normal",
+			healthCheckURL:   "/readyz",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:             "not ready because member is draining",
+			healthCheckURL:   "/readyz",
+			expectStatusCode: http.StatusServiceUnavailable,
+			inResult:         []string{"[-]not_draining failed: member is draining"},
+			isDraining:       true,
+		},
+		{
+			name:             "livez ok while member is draining",
+			healthCheckURL:   "/livez",
+			expectStatusCode: http.StatusOK,
+			isDraining:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			logger := zaptest.NewLogger(t)
+			s := &fakeHealthServer{
+				authStore:  auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
+				isDraining: tt.isDraining,
+			}
+			HandleHealth(logger, mux, s)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			checkHTTPResponse(t, ts, tt.healthCheckURL+"?verbose", tt.expectStatusCode, tt.inResult, tt.notInResult)
+		})
+	}
+}
+
+func TestSubsystemChecks(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+	tests := []struct {
+		healthTestCase
+		walSaveInProgress time.Duration
+		commitInProgress  time.Duration
+		excludedChecks    []string
+	}{
+		{
+			healthTestCase: healthTestCase{
+				name:             "livez ok with WAL save in progress",
+				healthCheckURL:   "/livez",
+				expectStatusCode: http.StatusOK,
+				inResult:         []string{"[+]wal_fsync ok", "[+]backend_commit ok", "[+]lessor ok", "[+]auth_store ok"},
+			},
+			walSaveInProgress: time.Second,
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "livez not ok with WAL save stuck",
+				healthCheckURL:   "/livez",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]wal_fsync failed: WAL save in progress for 1m0s"},
+			},
+			walSaveInProgress: time.Minute,
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "readyz/backend_commit not ok with backend commit stuck",
+				healthCheckURL:   "/readyz/backend_commit",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]backend_commit failed: backend commit in progress for 1m0s"},
+			},
+			commitInProgress: time.Minute,
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "readyz not ok without leader",
+				healthCheckURL:   "/readyz",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]leader_present failed: no leader"},
+				missingLeader:    true,
+			},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "livez ok with excluded WAL save stuck",
+				healthCheckURL:   "/livez",
+				expectStatusCode: http.StatusOK,
+				notInResult:      []string{"wal_fsync"},
+			},
+			walSaveInProgress: time.Minute,
+			excludedChecks:    []string{"wal_fsync", "leader_present"},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "excluded livez/wal_fsync can be queried",
+				healthCheckURL:   "/livez/wal_fsync",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]wal_fsync failed"},
+			},
+			walSaveInProgress: time.Minute,
+			excludedChecks:    []string{"wal_fsync"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			logger := zaptest.NewLogger(t)
+			s := &fakeHealthServer{
+				authStore:         auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
+				missingLeader:     tt.missingLeader,
+				walSaveInProgress: tt.walSaveInProgress,
+				commitInProgress:  tt.commitInProgress,
+				excludedChecks:    tt.excludedChecks,
+			}
+			HandleHealth(logger, mux, s)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			checkHTTPResponse(t, ts, tt.healthCheckURL+"?verbose", tt.expectStatusCode, tt.inResult, tt.notInResult)
+		})
+	}
+}
+
+func TestRespondCheckSingleInflight(t *testing.T) {
+	var calls atomic.Int32
+	unblock := make(chan struct{})
+	check := respondCheck(&fakeHealthServer{}, "stuck", func() {
+		calls.Add(1)
+		<-unblock
+	})
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := check(ctx)
+		cancel()
+		if err == nil || !strings.Contains(err.Error(), "stuck not responding") {
+			t.Fatalf("expected stuck check to fail, got %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected a single in-flight call, got %d", n)
+	}
+
+	close(unblock)
+	if err := check(context.Background()); err != nil {
+		t.Fatalf("expected check to succeed once unblocked, got %v", err)
+	}
+	if err := check(context.Background()); err != nil {
+		t.Fatalf("expected check to succeed, got %v", err)
+	}
+	if n := calls.Load(); n < 2 {
+		t.Fatalf("expected a new call once the stuck call returned, got %d calls", n)
+	}
+}
+
+func TestHealthStatusJSON(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+	mux := http.NewServeMux()
+	logger := zaptest.NewLogger(t)
+	HandleHealth(logger, mux, &fakeHealthServer{
+		authStore:     auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
+		missingLeader: true,
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	res, err := ts.Client().Get(ts.URL + "/readyz?format=json&exclude=linearizable_read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("want statusCode %d but got %d", http.StatusServiceUnavailable, res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("want Content-Type application/json but got %q", ct)
+	}
+	var h HealthStatus
+	if err = json.NewDecoder(res.Body).Decode(&h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Status != HealthStatusError {
+		t.Errorf("want status %q but got %q", HealthStatusError, h.Status)
+	}
+	checks := make(map[string]CheckStatus)
+	for _, c := range h.Checks {
+		checks[c.Name] = c
+	}
+	if _, found := checks["linearizable_read"]; found {
+		t.Errorf("excluded check linearizable_read in %+v", h.Checks)
+	}
+	if c := checks["leader_present"]; c.Status != HealthStatusError || c.Reason != "no leader" {
+		t.Errorf("leader_present = %+v, want error with reason %q", c, "no leader")
+	}
+	if c := checks["wal_fsync"]; c.Status != HealthStatusSuccess {
+		t.Errorf("wal_fsync = %+v, want success", c)
+	}
+}
+
 func checkHTTPResponse(t *testing.T, ts *httptest.Server, url string, expectStatusCode int, inResult []string, notInResult []string) {
 	res, err := ts.Client().Do(&http.Request{Method: http.MethodGet, URL: testutil.MustNewURL(t, ts.URL+url)})
 	if err != nil {
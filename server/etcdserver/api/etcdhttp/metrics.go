@@ -17,6 +17,7 @@ package etcdhttp
 import (
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -25,7 +26,12 @@ const (
 	PathProxyMetrics = "/proxy/metrics"
 )
 
-// HandleMetrics registers prometheus handler on '/metrics'.
+// HandleMetrics registers prometheus handler on '/metrics'. Scrapers which
+// accept the OpenMetrics format also receive the exemplars linking metrics to
+// traces.
 func HandleMetrics(mux *http.ServeMux) {
-	mux.Handle(PathMetrics, promhttp.Handler())
+	mux.Handle(PathMetrics, promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 }
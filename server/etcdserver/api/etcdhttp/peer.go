@@ -40,7 +40,7 @@ const (
 
 // NewPeerHandler generates an http.Handler to handle etcd peer requests.
 func NewPeerHandler(lg *zap.Logger, s etcdserver.ServerPeerV2) http.Handler {
-	return newPeerHandler(lg, s, s.RaftHandler(), s.LeaseHandler(), s.HashKVHandler(), s.DowngradeEnabledHandler())
+	return newPeerHandler(lg, s, s.RaftHandler(), s.LeaseHandler(), s.HashKVHandler(), s.DowngradeEnabledHandler(), s.BootstrapSnapshotHandler())
 }
 
 func newPeerHandler(
@@ -50,6 +50,7 @@ func newPeerHandler(
 	leaseHandler http.Handler,
 	hashKVHandler http.Handler,
 	downgradeEnabledHandler http.Handler,
+	bootstrapSnapshotHandler http.Handler,
 ) http.Handler {
 	if lg == nil {
 		lg = zap.NewNop()
@@ -66,6 +67,7 @@ func newPeerHandler(
 	if leaseHandler != nil {
 		mux.Handle(leasehttp.LeasePrefix, leaseHandler)
 		mux.Handle(leasehttp.LeaseInternalPrefix, leaseHandler)
+		mux.Handle(leasehttp.LeaseListInternalPrefix, leaseHandler)
 	}
 	if downgradeEnabledHandler != nil {
 		mux.Handle(etcdserver.DowngradeEnabledPath, downgradeEnabledHandler)
@@ -73,6 +75,10 @@ func newPeerHandler(
 	if hashKVHandler != nil {
 		mux.Handle(etcdserver.PeerHashKVPath, hashKVHandler)
 	}
+	if bootstrapSnapshotHandler != nil {
+		mux.Handle(etcdserver.PeerBootstrapSnapshotPath, bootstrapSnapshotHandler)
+		mux.Handle(etcdserver.PeerBootstrapSnapshotPath+"/", bootstrapSnapshotHandler)
+	}
 	mux.HandleFunc(versionPath, versionHandler(s, serveVersion))
 	return mux
 }
@@ -56,7 +56,10 @@ type RaftCluster struct {
 	// removed id cannot be reused.
 	removed map[types.ID]bool
 
-	downgradeInfo  *serverversion.DowngradeInfo
+	downgradeInfo *serverversion.DowngradeInfo
+	// metadata is the cluster metadata, the operational annotations of
+	// the cluster.
+	metadata       map[string]string
 	maxLearners    int
 	versionChanged *notify.Notifier
 }
@@ -267,6 +270,7 @@ func (c *RaftCluster) UnsafeLoad() {
 
 	if c.be != nil {
 		c.downgradeInfo = c.be.DowngradeInfoFromBackend()
+		c.metadata = c.be.ClusterMetadataFromBackend()
 	}
 }
 
@@ -821,6 +825,56 @@ func (c *RaftCluster) SetDowngradeInfo(d *serverversion.DowngradeInfo, shouldApp
 	c.downgradeInfo = d
 }
 
+// ClusterMetadata returns a copy of the cluster metadata.
+func (c *RaftCluster) ClusterMetadata() map[string]string {
+	c.Lock()
+	defer c.Unlock()
+	md := make(map[string]string, len(c.metadata))
+	for k, v := range c.metadata {
+		md[k] = v
+	}
+	return md
+}
+
+// ClusterMetadataSizeWith returns the total size of the keys and values of
+// the cluster metadata with the entry of key set to value.
+func (c *RaftCluster) ClusterMetadataSizeWith(key, value string) int {
+	c.Lock()
+	defer c.Unlock()
+	size := len(key) + len(value)
+	for k, v := range c.metadata {
+		if k != key {
+			size += len(k) + len(v)
+		}
+	}
+	return size
+}
+
+// SetClusterMetadata sets the entry of the key of the cluster metadata.
+func (c *RaftCluster) SetClusterMetadata(key, value string, shouldApplyV3 ShouldApplyV3) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.be != nil && shouldApplyV3 {
+		c.be.MustSaveClusterMetadataToBackend(key, value)
+	}
+	if c.metadata == nil {
+		c.metadata = make(map[string]string)
+	}
+	c.metadata[key] = value
+}
+
+// DeleteClusterMetadata deletes the entry of the key of the cluster metadata.
+func (c *RaftCluster) DeleteClusterMetadata(key string, shouldApplyV3 ShouldApplyV3) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.be != nil && shouldApplyV3 {
+		c.be.MustDeleteClusterMetadataFromBackend(key)
+	}
+	delete(c.metadata, key)
+}
+
 // IsMemberExist returns if the member with the given id exists in cluster.
 func (c *RaftCluster) IsMemberExist(id types.ID) bool {
 	c.Lock()
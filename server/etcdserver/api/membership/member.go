@@ -38,6 +38,13 @@ type RaftAttributes struct {
 type Attributes struct {
 	Name       string   `json:"name,omitempty"`
 	ClientURLs []string `json:"clientURLs,omitempty"`
+	// LeadershipPriority is the preference of the member to become the leader.
+	// When leadership priority is enabled, the leader transfers leadership to
+	// a healthy voting member with a higher priority.
+	LeadershipPriority int32 `json:"leadershipPriority,omitempty"`
+	// ReadReplica marks a learner that is never promoted to a voting member.
+	// It serves serializable reads as long as its data is fresh enough.
+	ReadReplica bool `json:"readReplica,omitempty"`
 }
 
 type Member struct {
@@ -97,7 +104,9 @@ func (m *Member) Clone() *Member {
 			IsLearner: m.IsLearner,
 		},
 		Attributes: Attributes{
-			Name: m.Name,
+			Name:               m.Name,
+			LeadershipPriority: m.LeadershipPriority,
+			ReadReplica:        m.ReadReplica,
 		},
 	}
 	if m.PeerURLs != nil {
@@ -61,6 +61,11 @@ func TestMemberClone(t *testing.T) {
 		newTestMember(1, []string{"http://a"}, "abc", nil),
 		newTestMember(1, nil, "abc", []string{"http://b"}),
 		newTestMember(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		{
+			ID:             1,
+			RaftAttributes: RaftAttributes{PeerURLs: []string{"http://a"}},
+			Attributes:     Attributes{Name: "abc", LeadershipPriority: 10, ReadReplica: true},
+		},
 	}
 	for i, tt := range tests {
 		nm := tt.Clone()
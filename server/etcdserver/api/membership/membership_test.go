@@ -53,11 +53,31 @@ func TestAddRemoveMember(t *testing.T) {
 	assert.False(t, c2.IsIDRemoved(19))
 }
 
+func TestClusterMetadata(t *testing.T) {
+	c := newTestCluster(t, nil)
+	be := newMembershipBackend()
+	c.SetBackend(be)
+	c.SetClusterMetadata("owner", "team-a", true)
+	c.SetClusterMetadata("maintenance", "sat 02:00-04:00", true)
+	c.SetClusterMetadata("owner", "team-b", true)
+	c.DeleteClusterMetadata("maintenance", true)
+	// not applied to the backend, e.g. replayed from the WAL.
+	c.SetClusterMetadata("restored", "true", false)
+	assert.Equal(t, map[string]string{"owner": "team-b", "restored": "true"}, c.ClusterMetadata())
+
+	// Recover from backend
+	c2 := newTestCluster(t, nil)
+	c2.SetBackend(be)
+	c2.Recover(func(*zap.Logger, *semver.Version) {})
+	assert.Equal(t, map[string]string{"owner": "team-b"}, c2.ClusterMetadata())
+}
+
 type backendMock struct {
 	members       map[types.ID]*Member
 	removed       map[types.ID]bool
 	version       *semver.Version
 	downgradeInfo *serverversion.DowngradeInfo
+	metadata      map[string]string
 }
 
 var _ MembershipBackend = (*backendMock)(nil)
@@ -67,6 +87,7 @@ func newMembershipBackend() MembershipBackend {
 		members:       make(map[types.ID]*Member),
 		removed:       make(map[types.ID]bool),
 		downgradeInfo: &serverversion.DowngradeInfo{Enabled: false},
+		metadata:      make(map[string]string),
 	}
 }
 
@@ -100,3 +121,13 @@ func (b *backendMock) MustSaveDowngradeToBackend(downgradeInfo *serverversion.Do
 	b.downgradeInfo = downgradeInfo
 }
 func (b *backendMock) DowngradeInfoFromBackend() *serverversion.DowngradeInfo { return b.downgradeInfo }
+
+func (b *backendMock) MustSaveClusterMetadataToBackend(key, value string) { b.metadata[key] = value }
+func (b *backendMock) MustDeleteClusterMetadataFromBackend(key string)    { delete(b.metadata, key) }
+func (b *backendMock) ClusterMetadataFromBackend() map[string]string {
+	md := make(map[string]string, len(b.metadata))
+	for k, v := range b.metadata {
+		md[k] = v
+	}
+	return md
+}
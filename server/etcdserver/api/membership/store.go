@@ -28,6 +28,7 @@ type MembershipBackend interface {
 	ClusterVersionBackend
 	MemberBackend
 	DowngradeInfoBackend
+	ClusterMetadataBackend
 	MustCreateBackendBuckets()
 }
 
@@ -48,6 +49,12 @@ type DowngradeInfoBackend interface {
 	DowngradeInfoFromBackend() *version.DowngradeInfo
 }
 
+type ClusterMetadataBackend interface {
+	MustSaveClusterMetadataToBackend(key, value string)
+	MustDeleteClusterMetadataFromBackend(key string)
+	ClusterMetadataFromBackend() map[string]string
+}
+
 func MustParseMemberIDFromKey(lg *zap.Logger, key string) types.ID {
 	id, err := types.IDFromString(path.Base(key))
 	if err != nil {
@@ -17,6 +17,7 @@ package v3compactor
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jonboulle/clockwork"
@@ -51,23 +52,46 @@ type RevGetter interface {
 	Rev() int64
 }
 
-// New returns a new Compactor based on given "mode".
+// New returns a new Compactor based on given "mode". The compactor is driven
+// by clock, or the real clock if nil. prefixRetentions override the retention
+// of the keys under the prefixes, in the same unit as retention.
 func New(
 	lg *zap.Logger,
+	clock clockwork.Clock,
 	mode string,
 	retention time.Duration,
+	prefixRetentions map[string]time.Duration,
 	rg RevGetter,
 	c Compactable,
 ) (Compactor, error) {
 	if lg == nil {
 		lg = zap.NewNop()
 	}
+	if clock == nil {
+		clock = clockwork.NewRealClock()
+	}
 	switch mode {
 	case ModePeriodic:
-		return newPeriodic(lg, clockwork.NewRealClock(), retention, rg, c), nil
+		return newPeriodic(lg, clock, retention, prefixRetentions, rg, c), nil
 	case ModeRevision:
-		return newRevision(lg, clockwork.NewRealClock(), int64(retention), rg, c), nil
+		return newRevision(lg, clock, int64(retention), prefixRetentions, rg, c), nil
 	default:
 		return nil, fmt.Errorf("unsupported compaction mode %s", mode)
 	}
 }
+
+// compactionRequest returns the request compacting at rev, keeping the
+// history of the keys under the prefixes from the revisions of the prefixes
+// on.
+func compactionRequest(rev int64, prefixRevs map[string]int64) *pb.CompactionRequest {
+	req := &pb.CompactionRequest{Revision: rev}
+	prefixes := make([]string, 0, len(prefixRevs))
+	for p := range prefixRevs {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+	for _, p := range prefixes {
+		req.Retentions = append(req.Retentions, &pb.CompactionRetention{Prefix: []byte(p), Revision: prefixRevs[p]})
+	}
+	return req
+}
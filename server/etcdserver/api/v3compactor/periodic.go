@@ -34,10 +34,16 @@ type Periodic struct {
 	clock  clockwork.Clock
 	period time.Duration
 
+	// prefixRetentions are the retention periods of the key prefixes.
+	prefixRetentions map[string]time.Duration
+
 	rg RevGetter
 	c  Compactable
 
-	revs   []int64
+	revs []int64
+	// history records the revisions over the longest of prefixRetentions.
+	history []timedRev
+
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -46,15 +52,22 @@ type Periodic struct {
 	paused bool
 }
 
+type timedRev struct {
+	rev int64
+	t   time.Time
+}
+
 // newPeriodic creates a new instance of Periodic compactor that purges
-// the log older than h Duration.
-func newPeriodic(lg *zap.Logger, clock clockwork.Clock, h time.Duration, rg RevGetter, c Compactable) *Periodic {
+// the log older than h Duration, or than the retention period of the key
+// prefix.
+func newPeriodic(lg *zap.Logger, clock clockwork.Clock, h time.Duration, prefixRetentions map[string]time.Duration, rg RevGetter, c Compactable) *Periodic {
 	pc := &Periodic{
-		lg:     lg,
-		clock:  clock,
-		period: h,
-		rg:     rg,
-		c:      c,
+		lg:               lg,
+		clock:            clock,
+		period:           h,
+		prefixRetentions: prefixRetentions,
+		rg:               rg,
+		c:                c,
 	}
 	// revs won't be longer than the retentions.
 	pc.revs = make([]int64, 0, pc.getRetentions())
@@ -111,6 +124,7 @@ func (pc *Periodic) Run() {
 			if len(pc.revs) > retentions {
 				pc.revs = pc.revs[1:] // pc.revs[0] is always the rev at pc.period ago
 			}
+			pc.record(pc.revs[len(pc.revs)-1])
 
 			select {
 			case <-pc.ctx.Done():
@@ -139,7 +153,7 @@ func (pc *Periodic) Run() {
 				zap.Duration("compact-period", pc.period),
 			)
 			startTime := pc.clock.Now()
-			_, err := pc.c.Compact(pc.ctx, &pb.CompactionRequest{Revision: rev})
+			_, err := pc.c.Compact(pc.ctx, pc.compactionRequest(rev))
 			if err == nil || errors.Is(err, mvcc.ErrCompacted) {
 				pc.lg.Info(
 					"completed auto periodic compaction",
@@ -162,6 +176,53 @@ func (pc *Periodic) Run() {
 	}()
 }
 
+// record adds the revision to the history if it is due, and drops the
+// revisions older than all retention periods of the prefixes.
+func (pc *Periodic) record(rev int64) {
+	if len(pc.prefixRetentions) == 0 {
+		return
+	}
+	var longest, interval time.Duration
+	for _, d := range pc.prefixRetentions {
+		longest = max(longest, d)
+		if itv := min(d, time.Hour) / retryDivisor; itv > 0 && (interval == 0 || itv < interval) {
+			interval = itv
+		}
+	}
+	now := pc.clock.Now()
+	if n := len(pc.history); n == 0 || now.Sub(pc.history[n-1].t) >= interval {
+		pc.history = append(pc.history, timedRev{rev: rev, t: now})
+	}
+	for len(pc.history) > 1 && !pc.history[1].t.After(now.Add(-longest)) {
+		pc.history = pc.history[1:]
+	}
+}
+
+// compactionRequest returns the request compacting at rev, and at the
+// revisions at the retention periods ago for the prefixes. The history of
+// a prefix is kept whole until its retention period has been recorded.
+func (pc *Periodic) compactionRequest(rev int64) *pb.CompactionRequest {
+	if len(pc.prefixRetentions) == 0 {
+		return &pb.CompactionRequest{Revision: rev}
+	}
+	now := pc.clock.Now()
+	prefixRevs := make(map[string]int64, len(pc.prefixRetentions))
+	for p, d := range pc.prefixRetentions {
+		if d == 0 {
+			prefixRevs[p] = pc.rg.Rev()
+			continue
+		}
+		prefixRevs[p] = 0
+		for _, h := range pc.history {
+			if h.t.After(now.Add(-d)) {
+				break
+			}
+			prefixRevs[p] = h.rev
+		}
+	}
+	return compactionRequest(rev, prefixRevs)
+}
+
 // if given compaction period x is <1-hour, compact every x duration.
 // (e.g. --auto-compaction-mode 'periodic' --auto-compaction-retention='10m', then compact every 10-minute)
 // if given compaction period x is >1-hour, compact every hour.
@@ -35,7 +35,7 @@ func TestPeriodicHourly(t *testing.T) {
 	// TODO: Do not depand or real time (Recorder.Wait) in unit tests.
 	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(0), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond)}
-	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, rg, compactable)
+	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, nil, rg, compactable)
 
 	tb.Run()
 	defer tb.Stop()
@@ -86,7 +86,7 @@ func TestPeriodicMinutes(t *testing.T) {
 	fc := clockwork.NewFakeClock()
 	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(0), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond)}
-	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, rg, compactable)
+	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, nil, rg, compactable)
 
 	tb.Run()
 	defer tb.Stop()
@@ -134,7 +134,7 @@ func TestPeriodicPause(t *testing.T) {
 	retentionDuration := time.Hour
 	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(0), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond)}
-	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, rg, compactable)
+	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, nil, rg, compactable)
 
 	tb.Run()
 	tb.Pause()
@@ -181,7 +181,7 @@ func TestPeriodicSkipRevNotChange(t *testing.T) {
 	fc := clockwork.NewFakeClock()
 	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(0), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(20 * time.Millisecond)}
-	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, rg, compactable)
+	tb := newPeriodic(zaptest.NewLogger(t), fc, retentionDuration, nil, rg, compactable)
 
 	tb.Run()
 	defer tb.Stop()
@@ -244,3 +244,37 @@ func waitOneAction(t *testing.T, r testutil.Recorder) {
 		t.Errorf("expect 1 action, got %v instead", len(actions))
 	}
 }
+
+func TestPeriodicPrefixRetentions(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	rg := &fakeRevGetter{&testutil.RecorderBuffered{}, 999} // will be 1000
+	compactable := &fakeCompactable{&testutil.RecorderBuffered{}}
+	tb := newPeriodic(zaptest.NewLogger(t), fc, time.Hour, map[string]time.Duration{"/audit/": 2 * time.Hour, "/cache/": 0}, rg, compactable)
+
+	// the history of /audit/ is kept until 2 hours are recorded.
+	want := &pb.CompactionRequest{Revision: 10, Retentions: []*pb.CompactionRetention{
+		{Prefix: []byte("/audit/"), Revision: 0},
+		{Prefix: []byte("/cache/"), Revision: 1000},
+	}}
+	tb.record(10)
+	if r := tb.compactionRequest(10); !reflect.DeepEqual(r, want) {
+		t.Errorf("compact request = %v, want %v", r, want)
+	}
+
+	// record one revision every 6 minutes for nearly 3 hours.
+	for i := 2; i <= 30; i++ {
+		fc.Advance(6 * time.Minute)
+		tb.record(int64(i * 10))
+	}
+	rg.SetRev(1999) // will be 2000
+	want = &pb.CompactionRequest{Revision: 200, Retentions: []*pb.CompactionRetention{
+		{Prefix: []byte("/audit/"), Revision: 100},
+		{Prefix: []byte("/cache/"), Revision: 2000},
+	}}
+	if r := tb.compactionRequest(200); !reflect.DeepEqual(r, want) {
+		t.Errorf("compact request = %v, want %v", r, want)
+	}
+	if len(tb.history) != 21 {
+		t.Errorf("len(history) = %d, want 21", len(tb.history))
+	}
+}
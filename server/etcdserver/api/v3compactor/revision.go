@@ -23,7 +23,6 @@ import (
 	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
 
-	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -34,6 +33,9 @@ type Revision struct {
 
 	clock     clockwork.Clock
 	retention int64
+	// prefixRetentions are the numbers of revisions kept for the key
+	// prefixes.
+	prefixRetentions map[string]int64
 
 	rg RevGetter
 	c  Compactable
@@ -46,8 +48,9 @@ type Revision struct {
 }
 
 // newRevision creates a new instance of Revisonal compactor that purges
-// the log older than retention revisions from the current revision.
-func newRevision(lg *zap.Logger, clock clockwork.Clock, retention int64, rg RevGetter, c Compactable) *Revision {
+// the log older than retention revisions, or than the retention of the key
+// prefix, from the current revision.
+func newRevision(lg *zap.Logger, clock clockwork.Clock, retention int64, prefixRetentions map[string]time.Duration, rg RevGetter, c Compactable) *Revision {
 	rc := &Revision{
 		lg:        lg,
 		clock:     clock,
@@ -55,6 +58,12 @@ func newRevision(lg *zap.Logger, clock clockwork.Clock, retention int64, rg RevG
 		rg:        rg,
 		c:         c,
 	}
+	if len(prefixRetentions) > 0 {
+		rc.prefixRetentions = make(map[string]int64, len(prefixRetentions))
+		for p, r := range prefixRetentions {
+			rc.prefixRetentions[p] = int64(r)
+		}
+	}
 	rc.ctx, rc.cancel = context.WithCancel(context.Background())
 	return rc
 }
@@ -78,10 +87,18 @@ func (rc *Revision) Run() {
 				}
 			}
 
-			rev := rc.rg.Rev() - rc.retention
+			cur := rc.rg.Rev()
+			rev := cur - rc.retention
 			if rev <= 0 || rev == prev {
 				continue
 			}
+			var prefixRevs map[string]int64
+			if len(rc.prefixRetentions) > 0 {
+				prefixRevs = make(map[string]int64, len(rc.prefixRetentions))
+				for p, r := range rc.prefixRetentions {
+					prefixRevs[p] = max(cur-r, 0)
+				}
+			}
 
 			now := time.Now()
 			rc.lg.Info(
@@ -89,7 +106,7 @@ func (rc *Revision) Run() {
 				zap.Int64("revision", rev),
 				zap.Int64("revision-compaction-retention", rc.retention),
 			)
-			_, err := rc.c.Compact(rc.ctx, &pb.CompactionRequest{Revision: rev})
+			_, err := rc.c.Compact(rc.ctx, compactionRequest(rev, prefixRevs))
 			if err == nil || errors.Is(err, mvcc.ErrCompacted) {
 				prev = rev
 				rc.lg.Info(
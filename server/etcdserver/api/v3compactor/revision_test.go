@@ -30,7 +30,7 @@ func TestRevision(t *testing.T) {
 	fc := clockwork.NewFakeClock()
 	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond)}
-	tb := newRevision(zaptest.NewLogger(t), fc, 10, rg, compactable)
+	tb := newRevision(zaptest.NewLogger(t), fc, 10, nil, rg, compactable)
 
 	tb.Run()
 	defer tb.Stop()
@@ -73,7 +73,7 @@ func TestRevisionPause(t *testing.T) {
 	fc := clockwork.NewFakeClock()
 	rg := &fakeRevGetter{testutil.NewRecorderStream(), 99} // will be 100
 	compactable := &fakeCompactable{testutil.NewRecorderStream()}
-	tb := newRevision(zaptest.NewLogger(t), fc, 10, rg, compactable)
+	tb := newRevision(zaptest.NewLogger(t), fc, 10, nil, rg, compactable)
 
 	tb.Run()
 	tb.Pause()
@@ -106,3 +106,28 @@ func TestRevisionPause(t *testing.T) {
 		t.Errorf("compact request = %v, want %v", a[0].Params[0], wreq.Revision)
 	}
 }
+
+func TestRevisionPrefixRetentions(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	rg := &fakeRevGetter{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond), 99} // will be 100
+	compactable := &fakeCompactable{testutil.NewRecorderStreamWithWaitTimout(10 * time.Millisecond)}
+	tb := newRevision(zaptest.NewLogger(t), fc, 10, map[string]time.Duration{"/audit/": 50, "/cache/": 0}, rg, compactable)
+
+	tb.Run()
+	defer tb.Stop()
+
+	fc.BlockUntil(1)
+	fc.Advance(revInterval)
+	rg.Wait(1)
+	a, err := compactable.Wait(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.CompactionRequest{Revision: 90, Retentions: []*pb.CompactionRetention{
+		{Prefix: []byte("/audit/"), Revision: 50},
+		{Prefix: []byte("/cache/"), Revision: 100},
+	}}
+	if !reflect.DeepEqual(a[0].Params[0], want) {
+		t.Errorf("compact request = %v, want %v", a[0].Params[0], want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	epb "go.etcd.io/etcd/server/v3/etcdserver/api/v3election/v3electionpb"
@@ -27,6 +28,10 @@ import (
 // is missing the "leader" field.
 var ErrMissingLeaderKey = errors.New(`"leader" field must be provided`)
 
+// MaxObserveHistory is the maximum number of the last leadership changes
+// Observe replays; a larger history is capped to it.
+const MaxObserveHistory = 1000
+
 type electionServer struct {
 	c *clientv3.Client
 }
@@ -76,6 +81,22 @@ func (es *electionServer) Observe(req *epb.LeaderRequest, stream epb.Election_Ob
 		return err
 	}
 	e := concurrency.NewElection(s, string(req.Name))
+	// the last replayed update, so that it is not sent again when observed.
+	var last *mvccpb.KeyValue
+	if req.History > 0 {
+		changes, header, err := concurrency.ElectionHistory(stream.Context(), es.c, string(req.Name), int(min(req.History, MaxObserveHistory)))
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			hdr := *header
+			hdr.Revision = c.Revision
+			if err := stream.Send(&epb.LeaderResponse{Header: &hdr, Kv: c.Kv, Epoch: c.Epoch()}); err != nil {
+				return err
+			}
+			last = c.Kv
+		}
+	}
 	ch := e.Observe(stream.Context())
 	for stream.Context().Err() == nil {
 		select {
@@ -84,7 +105,11 @@ func (es *electionServer) Observe(req *epb.LeaderRequest, stream epb.Election_Ob
 			if !ok {
 				return nil
 			}
-			lresp := &epb.LeaderResponse{Header: resp.Header, Kv: resp.Kvs[0]}
+			kv := resp.Kvs[0]
+			if last != nil && kv.CreateRevision == last.CreateRevision && kv.ModRevision <= last.ModRevision {
+				continue
+			}
+			lresp := &epb.LeaderResponse{Header: resp.Header, Kv: kv, Epoch: kv.CreateRevision}
 			if err := stream.Send(lresp); err != nil {
 				return err
 			}
@@ -102,7 +127,7 @@ func (es *electionServer) Leader(ctx context.Context, req *epb.LeaderRequest) (*
 	if lerr != nil {
 		return nil, lerr
 	}
-	return &epb.LeaderResponse{Header: l.Header, Kv: l.Kvs[0]}, nil
+	return &epb.LeaderResponse{Header: l.Header, Kv: l.Kvs[0], Epoch: l.Kvs[0].CreateRevision}, nil
 }
 
 func (es *electionServer) Resign(ctx context.Context, req *epb.ResignRequest) (*epb.ResignResponse, error) {
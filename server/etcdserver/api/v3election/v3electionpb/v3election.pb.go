@@ -236,7 +236,13 @@ func (m *LeaderKey) GetLease() int64 {
 
 type LeaderRequest struct {
 	// name is the election identifier for the leadership information.
-	Name                 []byte   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// history is the number of the last leadership changes Observe replays
+	// before streaming new proclamations, the last one being the change to the
+	// current leader. At most the last 1000 changes are replayed, and the
+	// changes before the compacted revision or beyond the replayed revisions
+	// are not. It is ignored by Leader.
+	History              int64    `protobuf:"varint,2,opt,name=history,proto3" json:"history,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -282,13 +288,24 @@ func (m *LeaderRequest) GetName() []byte {
 	return nil
 }
 
+func (m *LeaderRequest) GetHistory() int64 {
+	if m != nil {
+		return m.History
+	}
+	return 0
+}
+
 type LeaderResponse struct {
 	Header *etcdserverpb.ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	// kv is the key-value pair representing the latest leader update.
-	Kv                   *mvccpb.KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Kv *mvccpb.KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
+	// epoch is the leadership epoch of the leader. Every new leader has a
+	// greater epoch than the previous ones, so it can be used as a fencing
+	// token. It is the creation revision of the leader key.
+	Epoch                int64    `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LeaderResponse) Reset()         { *m = LeaderResponse{} }
@@ -338,6 +355,13 @@ func (m *LeaderResponse) GetKv() *mvccpb.KeyValue {
 	return nil
 }
 
+func (m *LeaderResponse) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 type ResignRequest struct {
 	// leader is the leadership to relinquish by resignation.
 	Leader               *LeaderKey `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
@@ -552,42 +576,44 @@ func init() {
 func init() { proto.RegisterFile("v3election.proto", fileDescriptor_c9b1f26cc432a035) }
 
 var fileDescriptor_c9b1f26cc432a035 = []byte{
-	// 556 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x71, 0x5a, 0xca, 0x78, 0x74, 0x5b, 0x15, 0x8a, 0x28, 0xa5, 0x64, 0x95, 0xb9, 0x4c,
-	0x3d, 0xc4, 0x68, 0xe5, 0xd4, 0xd3, 0x04, 0x02, 0x4d, 0x1a, 0x12, 0xe0, 0x03, 0x02, 0x6e, 0x6e,
-	0xf6, 0x94, 0x55, 0x4d, 0xe3, 0x90, 0x74, 0x91, 0x7a, 0xe5, 0x2b, 0x70, 0x80, 0x8f, 0xc4, 0x11,
-	0x89, 0x2f, 0x80, 0x0a, 0x1f, 0x04, 0xd9, 0x4e, 0x9a, 0x34, 0x6a, 0x11, 0x5a, 0x6f, 0x8e, 0xdf,
-	0xdf, 0xef, 0xf7, 0xfe, 0xcf, 0x2f, 0x86, 0x56, 0x3a, 0xc4, 0x00, 0xbd, 0xf9, 0x44, 0x86, 0x6e,
-	0x14, 0xcb, 0xb9, 0xb4, 0x9b, 0xc5, 0x4e, 0x34, 0xee, 0xb6, 0x7d, 0xe9, 0x4b, 0x1d, 0x60, 0x6a,
-	0x65, 0x34, 0xdd, 0x23, 0x9c, 0x7b, 0x17, 0x4c, 0x44, 0x13, 0xa6, 0x16, 0x09, 0xc6, 0x29, 0xc6,
-	0xd1, 0x98, 0xc5, 0x91, 0x97, 0x09, 0x3a, 0x2b, 0xc1, 0x2c, 0xf5, 0xbc, 0x68, 0xcc, 0xa6, 0x69,
-	0x16, 0xe9, 0xf9, 0x52, 0xfa, 0x01, 0xea, 0x98, 0x08, 0x43, 0x39, 0x17, 0x8a, 0x94, 0x98, 0x28,
-	0x7d, 0x0b, 0x87, 0xcf, 0xc5, 0x2c, 0x12, 0x13, 0x3f, 0xe4, 0xf8, 0xe9, 0x0a, 0x93, 0xb9, 0x6d,
-	0x43, 0x3d, 0x14, 0x33, 0xec, 0x90, 0x3e, 0x39, 0x6e, 0x72, 0xbd, 0xb6, 0xdb, 0x70, 0x33, 0x40,
-	0x91, 0x60, 0xc7, 0xea, 0x93, 0xe3, 0x1a, 0x37, 0x1f, 0x6a, 0x37, 0x15, 0xc1, 0x15, 0x76, 0x6a,
-	0x5a, 0x6a, 0x3e, 0xe8, 0x02, 0x5a, 0x45, 0xca, 0x24, 0x92, 0x61, 0x82, 0xf6, 0x53, 0x68, 0x5c,
-	0xa2, 0xb8, 0xc0, 0x58, 0x67, 0xbd, 0x73, 0xd2, 0x73, 0xcb, 0x3e, 0xdc, 0x5c, 0x77, 0xa6, 0x35,
-	0x3c, 0xd3, 0xda, 0x0c, 0x1a, 0x81, 0x39, 0x65, 0xe9, 0x53, 0xf7, 0xdd, 0x72, 0xab, 0xdc, 0x57,
-	0x3a, 0x76, 0x8e, 0x0b, 0x9e, 0xc9, 0xe8, 0x07, 0xb8, 0xbd, 0xda, 0xdc, 0xe8, 0xa3, 0x05, 0xb5,
-	0x29, 0x2e, 0x74, 0xba, 0x26, 0x57, 0x4b, 0xb5, 0x13, 0x63, 0xaa, 0x1d, 0xd4, 0xb8, 0x5a, 0x16,
-	0x5e, 0xeb, 0x25, 0xaf, 0xf4, 0x31, 0xec, 0x9b, 0xd4, 0xff, 0x68, 0x13, 0xbd, 0x84, 0x83, 0x5c,
-	0xb4, 0x93, 0xf1, 0x3e, 0x58, 0xd3, 0x34, 0x33, 0xdd, 0x72, 0xcd, 0x8d, 0xba, 0xe7, 0xb8, 0x78,
-	0xa7, 0x1a, 0xcc, 0xad, 0x69, 0x4a, 0x4f, 0x61, 0x9f, 0x63, 0x52, 0xba, 0xb5, 0xa2, 0x57, 0xe4,
-	0xff, 0x7a, 0xf5, 0x12, 0x0e, 0xf2, 0x0c, 0xbb, 0xd4, 0x4a, 0xdf, 0xc3, 0xe1, 0x9b, 0x58, 0x7a,
-	0x81, 0x98, 0xcc, 0xae, 0x5b, 0x4b, 0x31, 0x48, 0x56, 0x79, 0x90, 0xce, 0xa0, 0x55, 0x64, 0xde,
-	0xa5, 0xc6, 0x93, 0xaf, 0x75, 0xd8, 0x7b, 0x91, 0x15, 0x60, 0x4f, 0x61, 0x2f, 0x9f, 0x4f, 0xfb,
-	0xd1, 0x7a, 0x65, 0x95, 0x5f, 0xa1, 0xeb, 0x6c, 0x0b, 0x1b, 0x0a, 0xed, 0x7f, 0xfe, 0xf9, 0xe7,
-	0x8b, 0xd5, 0xa5, 0xf7, 0x58, 0x3a, 0x64, 0xb9, 0x90, 0x79, 0x99, 0x6c, 0x44, 0x06, 0x0a, 0x96,
-	0x7b, 0xa8, 0xc2, 0x2a, 0x5d, 0xab, 0xc2, 0xaa, 0xd6, 0xb7, 0xc0, 0xa2, 0x4c, 0xa6, 0x60, 0x1e,
-	0x34, 0x4c, 0x6f, 0xed, 0x87, 0x9b, 0x3a, 0x9e, 0x83, 0x7a, 0x9b, 0x83, 0x19, 0xc6, 0xd1, 0x98,
-	0x0e, 0xbd, 0xbb, 0x86, 0x31, 0x17, 0xa5, 0x20, 0x3e, 0xdc, 0x7a, 0x3d, 0xd6, 0x0d, 0xdf, 0x85,
-	0x72, 0xa4, 0x29, 0x0f, 0x68, 0x7b, 0x8d, 0x22, 0x4d, 0xe2, 0x11, 0x19, 0x3c, 0x21, 0xca, 0x8d,
-	0x19, 0xd0, 0x2a, 0x67, 0x6d, 0xf0, 0xab, 0x9c, 0xf5, 0x99, 0xde, 0xe2, 0x26, 0xd6, 0xa2, 0x11,
-	0x19, 0x3c, 0xe3, 0xdf, 0x97, 0x0e, 0xf9, 0xb1, 0x74, 0xc8, 0xaf, 0xa5, 0x43, 0xbe, 0xfd, 0x76,
-	0x6e, 0x7c, 0x3c, 0xf5, 0xa5, 0x9e, 0x29, 0x77, 0x22, 0xf5, 0x63, 0xcb, 0xcc, 0x70, 0xe9, 0xf3,
-	0xab, 0x51, 0xd3, 0xaf, 0x69, 0xc1, 0x65, 0xe5, 0x12, 0xc6, 0x0d, 0xfd, 0xb4, 0x0e, 0xff, 0x06,
-	0x00, 0x00, 0xff, 0xff, 0xcd, 0x58, 0x82, 0xe2, 0xeb, 0x05, 0x00, 0x00,
+	// 577 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x8b, 0xd3, 0x50,
+	0x14, 0x35, 0x69, 0xed, 0x8c, 0xd7, 0xce, 0x4c, 0x89, 0x15, 0x63, 0xad, 0x99, 0xf2, 0x56, 0x43,
+	0x17, 0x79, 0x32, 0x75, 0x55, 0x10, 0x06, 0x45, 0x19, 0x18, 0x41, 0xcd, 0x42, 0xd4, 0x5d, 0x9a,
+	0xb9, 0xa4, 0xa1, 0x69, 0x5e, 0x4c, 0x32, 0x81, 0x6e, 0x5c, 0xf8, 0x17, 0x5c, 0xe8, 0x4f, 0x72,
+	0x29, 0xf8, 0x07, 0xa4, 0xfa, 0x43, 0xe4, 0x7d, 0xa4, 0x49, 0x43, 0x2b, 0x32, 0xdd, 0xbd, 0xf7,
+	0xee, 0x79, 0xf7, 0xdc, 0x73, 0x72, 0xf2, 0xa0, 0x93, 0x8f, 0x30, 0x44, 0x2f, 0x0b, 0x58, 0x64,
+	0xc7, 0x09, 0xcb, 0x98, 0xd1, 0x2e, 0x4f, 0xe2, 0x49, 0xaf, 0xeb, 0x33, 0x9f, 0x89, 0x02, 0xe5,
+	0x2b, 0x89, 0xe9, 0x1d, 0x63, 0xe6, 0x5d, 0x52, 0x37, 0x0e, 0x28, 0x5f, 0xa4, 0x98, 0xe4, 0x98,
+	0xc4, 0x13, 0x9a, 0xc4, 0x9e, 0x02, 0x98, 0x2b, 0xc0, 0x3c, 0xf7, 0xbc, 0x78, 0x42, 0x67, 0xb9,
+	0xaa, 0xf4, 0x7d, 0xc6, 0xfc, 0x10, 0x45, 0xcd, 0x8d, 0x22, 0x96, 0xb9, 0x9c, 0x29, 0x95, 0x55,
+	0xf2, 0x06, 0x8e, 0x9e, 0xb9, 0xf3, 0xd8, 0x0d, 0xfc, 0xc8, 0xc1, 0x8f, 0x57, 0x98, 0x66, 0x86,
+	0x01, 0xcd, 0xc8, 0x9d, 0xa3, 0xa9, 0x0d, 0xb4, 0x93, 0xb6, 0x23, 0xd6, 0x46, 0x17, 0x6e, 0x86,
+	0xe8, 0xa6, 0x68, 0xea, 0x03, 0xed, 0xa4, 0xe1, 0xc8, 0x0d, 0x3f, 0xcd, 0xdd, 0xf0, 0x0a, 0xcd,
+	0x86, 0x80, 0xca, 0x0d, 0x59, 0x40, 0xa7, 0x6c, 0x99, 0xc6, 0x2c, 0x4a, 0xd1, 0x78, 0x0c, 0xad,
+	0x29, 0xba, 0x97, 0x98, 0x88, 0xae, 0xb7, 0x4f, 0xfb, 0x76, 0x55, 0x87, 0x5d, 0xe0, 0xce, 0x05,
+	0xc6, 0x51, 0x58, 0x83, 0x42, 0x2b, 0x94, 0xb7, 0x74, 0x71, 0xeb, 0x9e, 0x5d, 0xb5, 0xca, 0x7e,
+	0x29, 0x6a, 0x17, 0xb8, 0x70, 0x14, 0x8c, 0xbc, 0x87, 0x5b, 0xab, 0xc3, 0x8d, 0x3a, 0x3a, 0xd0,
+	0x98, 0xe1, 0x42, 0xb4, 0x6b, 0x3b, 0x7c, 0xc9, 0x4f, 0x12, 0xcc, 0x85, 0x82, 0x86, 0xc3, 0x97,
+	0xa5, 0xd6, 0x66, 0x45, 0x2b, 0x79, 0x02, 0x07, 0xb2, 0xf5, 0xbf, 0x6c, 0x32, 0x61, 0x6f, 0x1a,
+	0xa4, 0x19, 0x4b, 0x16, 0xca, 0xa8, 0x62, 0x4b, 0x3e, 0xc1, 0x61, 0x71, 0x7d, 0x27, 0x4b, 0x06,
+	0xa0, 0xcf, 0x72, 0x65, 0x47, 0xc7, 0x96, 0xdf, 0xda, 0xbe, 0xc0, 0xc5, 0x5b, 0x6e, 0xbd, 0xa3,
+	0xcf, 0xc4, 0xf8, 0x18, 0x33, 0x6f, 0xaa, 0x24, 0xc9, 0x0d, 0x39, 0x83, 0x03, 0x07, 0xd3, 0xca,
+	0x57, 0x2e, 0xbd, 0xd5, 0xfe, 0xcf, 0xdb, 0x17, 0x70, 0x58, 0x74, 0xd8, 0x45, 0x01, 0x79, 0x07,
+	0x47, 0xaf, 0x13, 0xe6, 0x85, 0x6e, 0x30, 0xbf, 0xee, 0x2c, 0x65, 0xf0, 0xf4, 0x6a, 0xf0, 0xce,
+	0xa1, 0x53, 0x76, 0xde, 0x65, 0xc6, 0xd3, 0xaf, 0x4d, 0xd8, 0x7f, 0xae, 0x06, 0x30, 0x66, 0xb0,
+	0x5f, 0xe4, 0xd9, 0x78, 0xb8, 0x3e, 0x59, 0xed, 0xd7, 0xe9, 0x59, 0xdb, 0xca, 0x92, 0x85, 0x0c,
+	0x3e, 0xff, 0xfc, 0xf3, 0x45, 0xef, 0x91, 0xbb, 0x34, 0x1f, 0xd1, 0x02, 0x48, 0x3d, 0x05, 0x1b,
+	0x6b, 0x43, 0x4e, 0x56, 0x68, 0xa8, 0x93, 0xd5, 0x5c, 0xab, 0x93, 0xd5, 0xa5, 0x6f, 0x21, 0x8b,
+	0x15, 0x8c, 0x93, 0x79, 0xd0, 0x92, 0xde, 0x1a, 0x0f, 0x36, 0x39, 0x5e, 0x10, 0xf5, 0x37, 0x17,
+	0x15, 0x8d, 0x25, 0x68, 0x4c, 0x72, 0x67, 0x8d, 0x46, 0x7e, 0x28, 0x4e, 0xe2, 0xc3, 0xde, 0xab,
+	0x89, 0x30, 0x7c, 0x17, 0x96, 0x63, 0xc1, 0x72, 0x9f, 0x74, 0xd7, 0x58, 0x98, 0x6c, 0x3c, 0xd6,
+	0x86, 0x8f, 0x34, 0xae, 0x46, 0x06, 0xb4, 0xce, 0xb3, 0x16, 0xfc, 0x3a, 0xcf, 0x7a, 0xa6, 0xb7,
+	0xa8, 0x49, 0x04, 0x68, 0xac, 0x0d, 0x9f, 0x3a, 0xdf, 0x97, 0x96, 0xf6, 0x63, 0x69, 0x69, 0xbf,
+	0x96, 0x96, 0xf6, 0xed, 0xb7, 0x75, 0xe3, 0xc3, 0x99, 0xcf, 0x44, 0xa6, 0xec, 0x80, 0x89, 0xc7,
+	0x99, 0xca, 0x70, 0x89, 0xfb, 0xab, 0xa8, 0x89, 0xd7, 0xb7, 0xe4, 0xa5, 0xd5, 0x11, 0x26, 0x2d,
+	0xf1, 0x14, 0x8f, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff, 0xde, 0xcd, 0xa0, 0x90, 0x1b, 0x06, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -612,7 +638,8 @@ type ElectionClient interface {
 	// Leader returns the current election proclamation, if any.
 	Leader(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (*LeaderResponse, error)
 	// Observe streams election proclamations in-order as made by the election's
-	// elected leaders.
+	// elected leaders. If history is set, the last leadership changes are
+	// streamed first, oldest first.
 	Observe(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (Election_ObserveClient, error)
 	// Resign releases election leadership so other campaigners may acquire
 	// leadership on the election.
@@ -707,7 +734,8 @@ type ElectionServer interface {
 	// Leader returns the current election proclamation, if any.
 	Leader(context.Context, *LeaderRequest) (*LeaderResponse, error)
 	// Observe streams election proclamations in-order as made by the election's
-	// elected leaders.
+	// elected leaders. If history is set, the last leadership changes are
+	// streamed first, oldest first.
 	Observe(*LeaderRequest, Election_ObserveServer) error
 	// Resign releases election leadership so other campaigners may acquire
 	// leadership on the election.
@@ -1034,6 +1062,11 @@ func (m *LeaderRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.History != 0 {
+		i = encodeVarintV3Election(dAtA, i, uint64(m.History))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -1068,6 +1101,11 @@ func (m *LeaderResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Epoch != 0 {
+		i = encodeVarintV3Election(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Kv != nil {
 		{
 			size, err := m.Kv.MarshalToSizedBuffer(dAtA[:i])
@@ -1348,6 +1386,9 @@ func (m *LeaderRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovV3Election(uint64(l))
 	}
+	if m.History != 0 {
+		n += 1 + sovV3Election(uint64(m.History))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1368,6 +1409,9 @@ func (m *LeaderResponse) Size() (n int) {
 		l = m.Kv.Size()
 		n += 1 + l + sovV3Election(uint64(l))
 	}
+	if m.Epoch != 0 {
+		n += 1 + sovV3Election(uint64(m.Epoch))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1929,6 +1973,25 @@ func (m *LeaderRequest) Unmarshal(dAtA []byte) error {
 				m.Name = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			m.History = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.History |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipV3Election(dAtA[iNdEx:])
@@ -2052,6 +2115,25 @@ func (m *LeaderResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			m.Epoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Epoch |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipV3Election(dAtA[iNdEx:])
@@ -31,11 +31,14 @@ func NewLockServer(c *clientv3.Client) v3lockpb.LockServer {
 }
 
 func (ls *lockServer) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3lockpb.LockResponse, error) {
-	s, err := concurrency.NewSession(
-		ls.c,
+	opts := []concurrency.SessionOption{
 		concurrency.WithLease(clientv3.LeaseID(req.Lease)),
 		concurrency.WithContext(ctx),
-	)
+	}
+	if req.Lease == 0 && req.Ttl > 0 {
+		opts = append(opts, concurrency.WithTTL(int(req.Ttl)))
+	}
+	s, err := concurrency.NewSession(ls.c, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +47,7 @@ func (ls *lockServer) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3l
 	if err = m.Lock(ctx); err != nil {
 		return nil, err
 	}
-	return &v3lockpb.LockResponse{Header: m.Header(), Key: []byte(m.Key())}, nil
+	return &v3lockpb.LockResponse{Header: m.Header(), Key: []byte(m.Key()), Lease: int64(s.Lease())}, nil
 }
 
 func (ls *lockServer) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (*v3lockpb.UnlockResponse, error) {
@@ -54,3 +57,35 @@ func (ls *lockServer) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (
 	}
 	return &v3lockpb.UnlockResponse{Header: resp.Header}, nil
 }
+
+func (ls *lockServer) LockStatus(ctx context.Context, req *v3lockpb.LockStatusRequest) (*v3lockpb.LockStatusResponse, error) {
+	state, err := concurrency.GetLockState(ctx, ls.c, string(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	resp := &v3lockpb.LockStatusResponse{Header: state.Header}
+	if state.Holder != nil {
+		resp.Holder = toLockContender(state.Holder)
+	}
+	for i := range state.Waiters {
+		resp.Waiters = append(resp.Waiters, toLockContender(&state.Waiters[i]))
+	}
+	return resp, nil
+}
+
+func (ls *lockServer) ForceUnlock(ctx context.Context, req *v3lockpb.ForceUnlockRequest) (*v3lockpb.ForceUnlockResponse, error) {
+	holder, hdr, err := concurrency.ForceUnlock(ctx, ls.c, string(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &v3lockpb.ForceUnlockResponse{Header: hdr, Holder: toLockContender(holder)}, nil
+}
+
+func toLockContender(c *concurrency.LockContender) *v3lockpb.LockContender {
+	return &v3lockpb.LockContender{
+		Key:            []byte(c.Key),
+		Lease:          int64(c.Lease),
+		CreateRevision: c.CreateRevision,
+		Ttl:            c.TTL,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"net"
+
+	"go.etcd.io/etcd/server/v3/etcdserver"
+)
+
+// NewDrainListener returns a client listener that refuses new connections
+// once the server starts draining, so that new clients connect to other
+// members while the existing connections are still served.
+func NewDrainListener(l net.Listener, s *etcdserver.EtcdServer) net.Listener {
+	return &drainListener{Listener: l, drainc: s.DrainNotify()}
+}
+
+type drainListener struct {
+	net.Listener
+	drainc <-chan struct{}
+}
+
+func (l *drainListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case <-l.drainc:
+			conn.Close()
+		default:
+			return conn, nil
+		}
+	}
+}
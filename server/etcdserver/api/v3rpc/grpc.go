@@ -27,6 +27,9 @@ import (
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	// register the compressors clients may compress requests with, which
+	// the responses are compressed with too.
+	_ "go.etcd.io/etcd/client/v3/compression"
 	"go.etcd.io/etcd/client/v3/credentials"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 )
@@ -55,20 +58,23 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config, interceptor grpc.UnarySer
 	chainUnaryInterceptors := []grpc.UnaryServerInterceptor{
 		newLogUnaryInterceptor(s),
 		newUnaryInterceptor(s),
-		serverMetrics.UnaryServerInterceptor(),
 	}
-	if interceptor != nil {
-		chainUnaryInterceptors = append(chainUnaryInterceptors, interceptor)
-	}
-
 	chainStreamInterceptors := []grpc.StreamServerInterceptor{
 		newStreamInterceptor(s),
-		serverMetrics.StreamServerInterceptor(),
 	}
 
+	var metricsOpts []grpc_prometheus.Option
 	if s.Cfg.EnableDistributedTracing {
+		// the span is started before the metrics are observed, so that the
+		// request duration links to the trace through an exemplar.
 		chainUnaryInterceptors = append(chainUnaryInterceptors, otelgrpc.UnaryServerInterceptor(s.Cfg.TracerOptions...))
 		chainStreamInterceptors = append(chainStreamInterceptors, otelgrpc.StreamServerInterceptor(s.Cfg.TracerOptions...))
+		metricsOpts = append(metricsOpts, grpc_prometheus.WithExemplarFromContext(etcdserver.TraceExemplar))
+	}
+	chainUnaryInterceptors = append(chainUnaryInterceptors, serverMetrics.UnaryServerInterceptor(metricsOpts...))
+	chainStreamInterceptors = append(chainStreamInterceptors, serverMetrics.StreamServerInterceptor(metricsOpts...))
+	if interceptor != nil {
+		chainUnaryInterceptors = append(chainUnaryInterceptors, interceptor)
 	}
 
 	opts = append(opts, grpc.ChainUnaryInterceptor(chainUnaryInterceptors...))
@@ -15,6 +15,8 @@
 package v3rpc
 
 import (
+	"sync/atomic"
+
 	"go.uber.org/zap"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -30,6 +32,7 @@ const (
 type notifier interface {
 	defragStarted()
 	defragFinished()
+	drainStarted()
 }
 
 func newHealthNotifier(hs *health.Server, s *etcdserver.EtcdServer) notifier {
@@ -48,6 +51,9 @@ type healthNotifier struct {
 	lg *zap.Logger
 
 	stopGRPCServiceOnDefrag bool
+	// draining is set once the member starts draining; the gRPC service
+	// never returns to serving afterwards.
+	draining atomic.Bool
 }
 
 func (hc *healthNotifier) defragStarted() {
@@ -57,7 +63,17 @@ func (hc *healthNotifier) defragStarted() {
 	hc.stopServe("defrag is active")
 }
 
-func (hc *healthNotifier) defragFinished() { hc.startServe() }
+func (hc *healthNotifier) defragFinished() {
+	if hc.draining.Load() {
+		return
+	}
+	hc.startServe()
+}
+
+func (hc *healthNotifier) drainStarted() {
+	hc.draining.Store(true)
+	hc.stopServe("member is draining")
+}
 
 func (hc *healthNotifier) startServe() {
 	hc.lg.Info(
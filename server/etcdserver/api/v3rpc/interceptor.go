@@ -16,6 +16,7 @@ package v3rpc
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 	"unicode/utf8"
@@ -43,13 +44,49 @@ type streamsMap struct {
 	streams map[grpc.ServerStream]struct{}
 }
 
+// streamLimiter enforces the maximum number of concurrent streams of a client
+// connection, which is set by --max-streams-per-connection or lowered at
+// runtime below the limit negotiated with HTTP/2 clients. Connections over
+// unix sockets share the same limit.
+type streamLimiter struct {
+	mu sync.Mutex
+	// streams counts the streams by remote address of the connection.
+	streams map[string]uint32
+}
+
+func (l *streamLimiter) acquire(addr string, limit uint32) (release func(), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams[addr] >= limit {
+		return nil, false
+	}
+	l.streams[addr]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.streams[addr]--; l.streams[addr] == 0 {
+			delete(l.streams, addr)
+		}
+	}, true
+}
+
+// streamLimit returns the maximum number of concurrent streams of a client
+// connection enforced by the stream interceptor, zero meaning no limit.
+func streamLimit(s *etcdserver.EtcdServer) uint32 {
+	limit := s.MaxConcurrentStreams()
+	if perConn := min(s.Cfg.MaxStreamsPerConnection, math.MaxUint32); perConn > 0 && (limit == 0 || perConn < uint(limit)) {
+		limit = uint32(perConn)
+	}
+	return limit
+}
+
 func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		if !api.IsCapabilityEnabled(api.V3rpcCapability) {
 			return nil, rpctypes.ErrGRPCNotCapable
 		}
 
-		if s.IsMemberExist(s.MemberID()) && s.IsLearner() && !isRPCSupportedForLearner(req) {
+		if s.IsMemberExist(s.MemberID()) && s.IsLearner() && !isRPCSupportedForLearner(req, s.IsReadReplica()) {
 			return nil, rpctypes.ErrGRPCNotSupportedForLearner
 		}
 
@@ -214,16 +251,42 @@ func logExpensiveRequestStats(lg *zap.Logger, startTime time.Time, duration time
 
 func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor {
 	smap := monitorLeader(s)
+	dmap := monitorDrain(s)
+	limiter := &streamLimiter{streams: make(map[string]uint32)}
 
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if !api.IsCapabilityEnabled(api.V3rpcCapability) {
 			return rpctypes.ErrGRPCNotCapable
 		}
 
+		if p, ok := peer.FromContext(ss.Context()); ok && p.Addr != nil && streamLimit(s) > 0 {
+			release, ok := limiter.acquire(p.Addr.String(), streamLimit(s))
+			if !ok {
+				return rpctypes.ErrGRPCTooManyStreams
+			}
+			defer release()
+		}
+
 		if s.IsMemberExist(s.MemberID()) && s.IsLearner() && info.FullMethod != snapshotMethod { // learner does not support stream RPC except Snapshot
 			return rpctypes.ErrGRPCNotSupportedForLearner
 		}
 
+		if info.FullMethod != snapshotMethod { // snapshot is allowed to complete while draining
+			ctx := newCancellableContext(ss.Context())
+			ss = serverStreamWithCtx{ctx: ctx, ServerStream: ss}
+
+			dmap.mu.Lock()
+			dmap.streams[ss] = struct{}{}
+			dmap.mu.Unlock()
+
+			defer func() {
+				dmap.mu.Lock()
+				delete(dmap.streams, ss)
+				dmap.mu.Unlock()
+				ctx.Cancel(nil)
+			}()
+		}
+
 		md, ok := metadata.FromIncomingContext(ss.Context())
 		if ok {
 			ver, vs := "unknown", md.Get(rpctypes.MetadataClientAPIVersionKey)
@@ -351,3 +414,30 @@ func monitorLeader(s *etcdserver.EtcdServer) *streamsMap {
 
 	return smap
 }
+
+// monitorDrain cancels all registered streams with ErrGRPCDraining once the
+// server starts draining, so that clients fail over to other members.
+func monitorDrain(s *etcdserver.EtcdServer) *streamsMap {
+	smap := &streamsMap{
+		streams: make(map[grpc.ServerStream]struct{}),
+	}
+
+	s.GoAttach(func() {
+		select {
+		case <-s.StoppingNotify():
+			return
+		case <-s.DrainNotify():
+		}
+
+		smap.mu.Lock()
+		defer smap.mu.Unlock()
+		for ss := range smap.streams {
+			if ssWithCtx, ok := ss.(serverStreamWithCtx); ok {
+				ssWithCtx.ctx.Cancel(rpctypes.ErrGRPCDraining)
+			}
+		}
+		smap.streams = make(map[grpc.ServerStream]struct{})
+	})
+
+	return smap
+}
@@ -17,25 +17,45 @@ package v3rpc
 
 import (
 	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/pkg/v3/adt"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/features"
 )
 
+// readReplica reports the staleness of a read replica.
+type readReplica interface {
+	ReadReplicaStaleness() (time.Duration, bool)
+}
+
 type kvServer struct {
-	hdr header
-	kv  etcdserver.RaftKV
+	hdr     header
+	kv      etcdserver.RaftKV
+	replica readReplica
 	// maxTxnOps is the max operations per txn.
 	// e.g suppose maxTxnOps = 128.
 	// Txn.Success can have at most 128 operations,
 	// and Txn.Failure can have at most 128 operations.
 	maxTxnOps uint
+	// lazyDeleteRange tells whether the lazy range deletes are enabled.
+	lazyDeleteRange bool
 }
 
 func NewKVServer(s *etcdserver.EtcdServer) pb.KVServer {
-	return &kvServer{hdr: newHeader(s), kv: s, maxTxnOps: s.Cfg.MaxTxnOps}
+	return &kvServer{
+		hdr:             newHeader(s),
+		kv:              s,
+		replica:         s,
+		maxTxnOps:       s.Cfg.MaxTxnOps,
+		lazyDeleteRange: s.FeatureEnabled(features.LazyDeleteRange),
+	}
 }
 
 func (s *kvServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
@@ -49,9 +69,25 @@ func (s *kvServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResp
 	}
 
 	s.hdr.fill(resp.Header)
+	s.setStalenessHeader(ctx)
 	return resp, nil
 }
 
+// setStalenessHeader reports the staleness of the served data to the client
+// when the local member is a read replica.
+func (s *kvServer) setStalenessHeader(ctx context.Context) {
+	if s.replica == nil {
+		return
+	}
+	d, ok := s.replica.ReadReplicaStaleness()
+	if !ok {
+		return
+	}
+	md := metadata.Pairs(rpctypes.MetadataReadReplicaStalenessKey, strconv.FormatInt(d.Milliseconds(), 10))
+	// SetHeader fails if the context is not a gRPC server stream context.
+	_ = grpc.SetHeader(ctx, md)
+}
+
 func (s *kvServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error) {
 	if err := checkPutRequest(r); err != nil {
 		return nil, err
@@ -70,6 +106,9 @@ func (s *kvServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*
 	if err := checkDeleteRequest(r); err != nil {
 		return nil, err
 	}
+	if r.Lazy && !s.lazyDeleteRange {
+		return nil, rpctypes.ErrGRPCLazyDeleteDisabled
+	}
 
 	resp, err := s.kv.DeleteRange(ctx, r)
 	if err != nil {
@@ -84,6 +123,9 @@ func (s *kvServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse,
 	if err := checkTxnRequest(r, int(s.maxTxnOps)); err != nil {
 		return nil, err
 	}
+	if !s.lazyDeleteRange && (hasLazyDelete(r.Success) || hasLazyDelete(r.Failure)) {
+		return nil, rpctypes.ErrGRPCLazyDeleteDisabled
+	}
 	// check for forbidden put/del overlaps after checking request to avoid quadratic blowup
 	if _, _, err := checkIntervals(r.Success); err != nil {
 		return nil, err
@@ -144,6 +186,9 @@ func checkDeleteRequest(r *pb.DeleteRangeRequest) error {
 	if len(r.Key) == 0 {
 		return rpctypes.ErrGRPCEmptyKey
 	}
+	if r.Lazy && r.PrevKv {
+		return rpctypes.ErrGRPCLazyDeletePrevKV
+	}
 	return nil
 }
 
@@ -262,6 +307,24 @@ func checkIntervals(reqs []*pb.RequestOp) (map[string]struct{}, adt.IntervalTree
 	return puts, dels, nil
 }
 
+// hasLazyDelete returns true if any of the ops, including the ones of nested
+// txns, is a lazy range delete.
+func hasLazyDelete(ops []*pb.RequestOp) bool {
+	for _, op := range ops {
+		switch uv := op.Request.(type) {
+		case *pb.RequestOp_RequestDeleteRange:
+			if uv.RequestDeleteRange.Lazy {
+				return true
+			}
+		case *pb.RequestOp_RequestTxn:
+			if hasLazyDelete(uv.RequestTxn.Success) || hasLazyDelete(uv.RequestTxn.Failure) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func checkRequestOp(u *pb.RequestOp, maxTxnOps int) error {
 	// TODO: ensure only one of the field is set.
 	switch uv := u.Request.(type) {
@@ -90,6 +90,23 @@ func (ls *LeaseServer) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesReques
 	return resp, nil
 }
 
+func (ls *LeaseServer) LeaseRenew(ctx context.Context, rr *pb.LeaseRenewRequest) (*pb.LeaseRenewResponse, error) {
+	// the header is created before the renewal, as in leaseKeepAlive.
+	resp := &pb.LeaseRenewResponse{ID: rr.ID, Header: &pb.ResponseHeader{}}
+	ls.hdr.fill(resp.Header)
+
+	ttl, err := ls.le.LeaseRenew(ctx, lease.LeaseID(rr.ID))
+	if errors.Is(err, lease.ErrLeaseNotFound) {
+		err = nil
+		ttl = 0
+	}
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp.TTL = ttl
+	return resp, nil
+}
+
 func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err error) {
 	errc := make(chan error, 1)
 	go func() {
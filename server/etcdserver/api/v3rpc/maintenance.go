@@ -23,6 +23,8 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
@@ -53,16 +55,46 @@ type Alarmer interface {
 	Alarm(ctx context.Context, ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 }
 
+type RevisionSnapshotter interface {
+	SnapshotAtRevision(ctx context.Context, rev int64) (backend.Snapshot, error)
+}
+
+type ValuePolicier interface {
+	ValuePolicy(ctx context.Context, r *pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error)
+}
+
+type Scrubber interface {
+	Scrub(ctx context.Context, r *pb.ScrubRequest) (*pb.ScrubResponse, error)
+}
+
 type Downgrader interface {
 	Downgrade(ctx context.Context, dr *pb.DowngradeRequest) (*pb.DowngradeResponse, error)
 }
 
+type Drainer interface {
+	Drain(ctx context.Context) (bool, error)
+}
+
+type RuntimeConfigSetter interface {
+	SetRuntimeConfig(settings []*pb.ConfigSetting) ([]*pb.ConfigSetting, error)
+	WatchProgressNotifyInterval() time.Duration
+}
+
+type LogLevelSetter interface {
+	SetLogLevels(settings []*pb.LogLevelSetting) ([]*pb.LogLevelSetting, error)
+}
+
+type FeatureGatesGetter interface {
+	FeatureGates() []*pb.FeatureGate
+}
+
 type LeaderTransferrer interface {
 	MoveLeader(ctx context.Context, lead, target uint64) error
 }
 
 type ClusterStatusGetter interface {
 	IsLearner() bool
+	ClusterMetadata() map[string]string
 }
 
 type ConfigGetter interface {
@@ -74,11 +106,18 @@ type maintenanceServer struct {
 	rg     apply.RaftStatusGetter
 	hasher mvcc.HashStorage
 	bg     BackendGetter
+	rs     RevisionSnapshotter
 	a      Alarmer
+	vp     ValuePolicier
+	sc     Scrubber
 	lt     LeaderTransferrer
 	hdr    header
 	cs     ClusterStatusGetter
 	d      Downgrader
+	dr     Drainer
+	rcs    RuntimeConfigSetter
+	lls    LogLevelSetter
+	fgg    FeatureGatesGetter
 	vs     serverversion.Server
 	cg     ConfigGetter
 
@@ -91,11 +130,18 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		rg:             s,
 		hasher:         s.KV().HashStorage(),
 		bg:             s,
+		rs:             s,
 		a:              s,
+		vp:             s,
+		sc:             s,
 		lt:             s,
 		hdr:            newHeader(s),
 		cs:             s,
 		d:              s,
+		dr:             s,
+		rcs:            s,
+		lls:            s,
+		fgg:            s,
 		vs:             etcdserver.NewServerVersionAdapter(s),
 		healthNotifier: healthNotifier,
 		cg:             s,
@@ -128,7 +174,21 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 	if ver != nil {
 		storageVersion = ver.String()
 	}
-	snap := ms.bg.Backend().Snapshot()
+	var snap backend.Snapshot
+	// the header of the first response holds the revision of a snapshot at a
+	// revision, which tells clients that the server took it at the revision.
+	var hdr *pb.ResponseHeader
+	if sr.Revision > 0 {
+		var err error
+		if snap, err = ms.rs.SnapshotAtRevision(srv.Context(), sr.Revision); err != nil {
+			return togRPCError(err)
+		}
+		hdr = &pb.ResponseHeader{}
+		ms.hdr.fill(hdr)
+		hdr.Revision = sr.Revision
+	} else {
+		snap = ms.bg.Backend().Snapshot()
+	}
 	pr, pw := io.Pipe()
 
 	defer pr.Close()
@@ -154,6 +214,7 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 		zap.Int64("total-bytes", total),
 		zap.String("size", size),
 		zap.String("storage-version", storageVersion),
+		zap.Int64("revision", sr.Revision),
 	)
 	for total-sent > 0 {
 		// buffer just holds read bytes from stream
@@ -178,10 +239,12 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 		// No, the client will still receive non-nil response
 		// until server closes the stream with EOF
 		resp := &pb.SnapshotResponse{
+			Header:         hdr,
 			RemainingBytes: uint64(total - sent),
 			Blob:           buf[:n],
 			Version:        storageVersion,
 		}
+		hdr = nil
 		if err = srv.Send(resp); err != nil {
 			return togRPCError(err)
 		}
@@ -248,6 +311,30 @@ func (ms *maintenanceServer) Alarm(ctx context.Context, ar *pb.AlarmRequest) (*p
 	return resp, nil
 }
 
+func (ms *maintenanceServer) ValuePolicy(ctx context.Context, r *pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error) {
+	resp, err := ms.vp.ValuePolicy(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	if resp.Header == nil {
+		resp.Header = &pb.ResponseHeader{}
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Scrub(ctx context.Context, r *pb.ScrubRequest) (*pb.ScrubResponse, error) {
+	resp, err := ms.sc.Scrub(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	if resp.Header == nil {
+		resp.Header = &pb.ResponseHeader{}
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (*pb.StatusResponse, error) {
 	hdr := &pb.ResponseHeader{}
 	ms.hdr.fill(hdr)
@@ -263,6 +350,7 @@ func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (
 		IsLearner:        ms.cs.IsLearner(),
 		DbSizeQuota:      ms.cg.Config().QuotaBackendBytes,
 		DowngradeInfo:    &pb.DowngradeInfo{Enabled: false},
+		ClusterMetadata:  clusterMetadataToProto(ms.cs.ClusterMetadata()),
 	}
 	if storageVersion := ms.vs.GetStorageVersion(); storageVersion != nil {
 		resp.StorageVersion = storageVersion.String()
@@ -303,6 +391,69 @@ func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeReque
 	return resp, nil
 }
 
+func (ms *maintenanceServer) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	ms.lg.Info("starting drain")
+	ms.healthNotifier.drainStarted()
+	transferred, err := ms.dr.Drain(ctx)
+	if err != nil {
+		ms.lg.Warn("failed to drain", zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.lg.Info("finished drain", zap.Bool("leader-transferred", transferred))
+	resp := &pb.DrainResponse{Header: &pb.ResponseHeader{}, LeaderTransferred: transferred}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) ConfigSet(ctx context.Context, r *pb.ConfigSetRequest) (*pb.ConfigSetResponse, error) {
+	watchIntervalChanged := false
+	for _, setting := range r.Settings {
+		if setting.Name != etcdserver.RuntimeConfigWatchProgressNotifyInterval {
+			continue
+		}
+		if d, err := time.ParseDuration(setting.Value); err == nil && d < minWatchProgressInterval {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %s must be at least %v",
+				errors.ErrInvalidRuntimeConfig, setting.Name, minWatchProgressInterval)
+		}
+		watchIntervalChanged = true
+	}
+
+	settings, err := ms.rcs.SetRuntimeConfig(r.Settings)
+	if err != nil {
+		ms.lg.Warn("failed to set runtime config", zap.Error(err))
+		if errorspkg.Is(err, errors.ErrInvalidRuntimeConfig) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, togRPCError(err)
+	}
+	if watchIntervalChanged {
+		SetProgressReportInterval(ms.rcs.WatchProgressNotifyInterval())
+	}
+	resp := &pb.ConfigSetResponse{Header: &pb.ResponseHeader{}, Settings: settings}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) LogLevelSet(ctx context.Context, r *pb.LogLevelSetRequest) (*pb.LogLevelSetResponse, error) {
+	levels, err := ms.lls.SetLogLevels(r.Settings)
+	if err != nil {
+		ms.lg.Warn("failed to set log levels", zap.Error(err))
+		if errorspkg.Is(err, errors.ErrInvalidLogLevel) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, togRPCError(err)
+	}
+	resp := &pb.LogLevelSetResponse{Header: &pb.ResponseHeader{}, Levels: levels}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) FeatureGates(ctx context.Context, r *pb.FeatureGatesRequest) (*pb.FeatureGatesResponse, error) {
+	resp := &pb.FeatureGatesResponse{Header: &pb.ResponseHeader{}, Features: ms.fgg.FeatureGates()}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 type authMaintenanceServer struct {
 	*maintenanceServer
 	*AuthAdmin
@@ -339,6 +490,14 @@ func (ams *authMaintenanceServer) HashKV(ctx context.Context, r *pb.HashKVReques
 	return ams.maintenanceServer.HashKV(ctx, r)
 }
 
+func (ams *authMaintenanceServer) Scrub(ctx context.Context, r *pb.ScrubRequest) (*pb.ScrubResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.Scrub(ctx, r)
+}
+
 func (ams *authMaintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (*pb.StatusResponse, error) {
 	if err := ams.isPermitted(ctx); err != nil {
 		return nil, togRPCError(err)
@@ -362,3 +521,35 @@ func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.Downgrade
 
 	return ams.maintenanceServer.Downgrade(ctx, r)
 }
+
+func (ams *authMaintenanceServer) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.Drain(ctx, r)
+}
+
+func (ams *authMaintenanceServer) ConfigSet(ctx context.Context, r *pb.ConfigSetRequest) (*pb.ConfigSetResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.ConfigSet(ctx, r)
+}
+
+func (ams *authMaintenanceServer) LogLevelSet(ctx context.Context, r *pb.LogLevelSetRequest) (*pb.LogLevelSetResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.LogLevelSet(ctx, r)
+}
+
+func (ams *authMaintenanceServer) FeatureGates(ctx context.Context, r *pb.FeatureGatesRequest) (*pb.FeatureGatesResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.FeatureGates(ctx, r)
+}
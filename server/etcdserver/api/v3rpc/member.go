@@ -16,6 +16,7 @@ package v3rpc
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -105,6 +106,29 @@ func (cs *ClusterServer) MemberPromote(ctx context.Context, r *pb.MemberPromoteR
 	return &pb.MemberPromoteResponse{Header: cs.header(), Members: membersToProtoMembers(membs)}, nil
 }
 
+func (cs *ClusterServer) ClusterMetadataPut(ctx context.Context, r *pb.ClusterMetadataPutRequest) (*pb.ClusterMetadataPutResponse, error) {
+	if err := cs.server.PutClusterMetadata(ctx, r.Key, r.Value); err != nil {
+		return nil, togRPCError(err)
+	}
+	return &pb.ClusterMetadataPutResponse{Header: cs.header()}, nil
+}
+
+func (cs *ClusterServer) ClusterMetadataDelete(ctx context.Context, r *pb.ClusterMetadataDeleteRequest) (*pb.ClusterMetadataDeleteResponse, error) {
+	if err := cs.server.DeleteClusterMetadata(ctx, r.Key); err != nil {
+		return nil, togRPCError(err)
+	}
+	return &pb.ClusterMetadataDeleteResponse{Header: cs.header()}, nil
+}
+
+func (cs *ClusterServer) ClusterMetadataList(ctx context.Context, r *pb.ClusterMetadataListRequest) (*pb.ClusterMetadataListResponse, error) {
+	if r.Linearizable {
+		if err := cs.server.LinearizableReadNotify(ctx); err != nil {
+			return nil, togRPCError(err)
+		}
+	}
+	return &pb.ClusterMetadataListResponse{Header: cs.header(), Entries: clusterMetadataToProto(cs.server.ClusterMetadata())}, nil
+}
+
 func (cs *ClusterServer) header() *pb.ResponseHeader {
 	return &pb.ResponseHeader{ClusterId: uint64(cs.cluster.ID()), MemberId: uint64(cs.server.MemberID()), RaftTerm: cs.server.Term()}
 }
@@ -122,3 +146,14 @@ func membersToProtoMembers(membs []*membership.Member) []*pb.Member {
 	}
 	return protoMembs
 }
+
+// clusterMetadataToProto returns the entries of the cluster metadata sorted by
+// key.
+func clusterMetadataToProto(md map[string]string) []*pb.ClusterMetadataEntry {
+	entries := make([]*pb.ClusterMetadataEntry, 0, len(md))
+	for k, v := range md {
+		entries = append(entries, &pb.ClusterMetadataEntry{Key: k, Value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
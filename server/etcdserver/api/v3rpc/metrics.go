@@ -52,6 +52,31 @@ var (
 		},
 		[]string{"type", "client_api_version"},
 	)
+
+	watchPacingDelayedResponses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "watch_pacing_delayed_responses_total",
+		Help:      "The total number of watch responses delayed by the bandwidth limit of their watcher.",
+	})
+
+	watchPacingDelaySec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "watch_pacing_delay_seconds",
+		Help:      "The delay of the watch responses delayed by the bandwidth limit of their watcher.",
+
+		// lowest bucket start of upper bound 0.001 sec (1 ms) with factor 2
+		// highest bucket start of 0.001 sec * 2^16 == 65.536 sec
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 17),
+	})
+
+	watchPacingCanceledWatchers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "watch_pacing_canceled_watchers_total",
+		Help:      "The total number of watchers canceled for lagging too far behind their bandwidth limit.",
+	})
 )
 
 func init() {
@@ -59,4 +84,7 @@ func init() {
 	prometheus.MustRegister(receivedBytes)
 	prometheus.MustRegister(streamFailures)
 	prometheus.MustRegister(clientRequests)
+	prometheus.MustRegister(watchPacingDelayedResponses)
+	prometheus.MustRegister(watchPacingDelaySec)
+	prometheus.MustRegister(watchPacingCanceledWatchers)
 }
@@ -26,7 +26,9 @@ import (
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
 	"go.etcd.io/etcd/server/v3/etcdserver/version"
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
@@ -41,6 +43,15 @@ var toGRPCErrorMap = map[error]error{
 	membership.ErrTooManyLearners:     rpctypes.ErrGRPCTooManyLearners,
 	errors.ErrNotEnoughStartedMembers: rpctypes.ErrMemberNotEnoughStarted,
 	errors.ErrLearnerNotReady:         rpctypes.ErrGRPCLearnerNotReady,
+	errors.ErrMemberIsReadReplica:     rpctypes.ErrGRPCMemberIsReadReplica,
+	errors.ErrClusterMetadataKeyEmpty: rpctypes.ErrGRPCClusterMetadataKeyEmpty,
+	errors.ErrClusterMetadataTooLarge: rpctypes.ErrGRPCClusterMetadataTooLarge,
+	errors.ErrSnapshotRevisionApplied: rpctypes.ErrGRPCSnapshotRevisionApplied,
+
+	v3valuepolicy.ErrInvalidValuePolicy: rpctypes.ErrGRPCInvalidValuePolicy,
+	v3valuepolicy.ErrValueTooLarge:      rpctypes.ErrGRPCValuePolicyValueTooLarge,
+	v3valuepolicy.ErrBadContent:         rpctypes.ErrGRPCValuePolicyBadContent,
+	v3valuepolicy.ErrKeyMismatch:        rpctypes.ErrGRPCValuePolicyKeyMismatch,
 
 	mvcc.ErrCompacted:         rpctypes.ErrGRPCCompacted,
 	mvcc.ErrFutureRev:         rpctypes.ErrGRPCFutureRev,
@@ -60,6 +71,7 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 	errors.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
 	errors.ErrBadLeaderTransferee:        rpctypes.ErrGRPCBadLeaderTransferee,
+	errors.ErrReadReplicaTooStale:        rpctypes.ErrGRPCReadReplicaTooStale,
 
 	errors.ErrClusterVersionUnavailable:      rpctypes.ErrGRPCClusterVersionUnavailable,
 	errors.ErrWrongDowngradeVersionFormat:    rpctypes.ErrGRPCWrongDowngradeVersionFormat,
@@ -67,9 +79,10 @@ var toGRPCErrorMap = map[error]error{
 	version.ErrDowngradeInProcess:            rpctypes.ErrGRPCDowngradeInProcess,
 	version.ErrNoInflightDowngrade:           rpctypes.ErrGRPCNoInflightDowngrade,
 
-	lease.ErrLeaseNotFound:    rpctypes.ErrGRPCLeaseNotFound,
-	lease.ErrLeaseExists:      rpctypes.ErrGRPCLeaseExist,
-	lease.ErrLeaseTTLTooLarge: rpctypes.ErrGRPCLeaseTTLTooLarge,
+	lease.ErrLeaseNotFound:     rpctypes.ErrGRPCLeaseNotFound,
+	lease.ErrLeaseExists:       rpctypes.ErrGRPCLeaseExist,
+	lease.ErrLeaseTTLTooLarge:  rpctypes.ErrGRPCLeaseTTLTooLarge,
+	lease.ErrLeaseLabelInvalid: rpctypes.ErrGRPCLeaseLabelInvalid,
 
 	auth.ErrRootUserNotExist:     rpctypes.ErrGRPCRootUserNotExist,
 	auth.ErrRootRoleNotExist:     rpctypes.ErrGRPCRootRoleNotExist,
@@ -139,12 +152,15 @@ func isClientCtxErr(ctxErr error, err error) bool {
 }
 
 // in v3.4, learner is allowed to serve serializable read and endpoint status
-func isRPCSupportedForLearner(req any) bool {
+func isRPCSupportedForLearner(req any, readReplica bool) bool {
 	switch r := req.(type) {
 	case *pb.StatusRequest:
 		return true
 	case *pb.RangeRequest:
 		return r.Serializable
+	case *pb.TxnRequest:
+		// read replicas also serve serializable read-only txns
+		return readReplica && txn.IsTxnReadonly(r) && txn.IsTxnSerializable(r)
 	default:
 		return false
 	}
@@ -32,6 +32,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -43,11 +44,22 @@ type watchServer struct {
 	clusterID int64
 	memberID  int64
 
-	maxRequestBytes uint
+	maxRequestBytes          uint
+	maxWatchers              uint
+	maxWatcherBytesPerSecond uint
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
+	lessor    lease.Lessor
 	ag        AuthGetter
+	uwl       UserWatcherLimiter
+}
+
+// UserWatcherLimiter limits the number of watchers of each authenticated user
+// across all watch streams.
+type UserWatcherLimiter interface {
+	AcquireUserWatcher(user string) bool
+	ReleaseUserWatcher(user string)
 }
 
 // NewWatchServer returns a new watch server.
@@ -58,11 +70,15 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 		clusterID: int64(s.Cluster().ID()),
 		memberID:  int64(s.MemberID()),
 
-		maxRequestBytes: s.Cfg.MaxRequestBytesWithOverhead(),
+		maxRequestBytes:          s.Cfg.MaxRequestBytesWithOverhead(),
+		maxWatchers:              s.Cfg.MaxWatchersPerStream,
+		maxWatcherBytesPerSecond: s.Cfg.MaxWatcherBytesPerSecond,
 
 		sg:        s,
 		watchable: s.Watchable(),
+		lessor:    s.Lessor(),
 		ag:        s,
+		uwl:       s,
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
@@ -126,17 +142,21 @@ type serverWatchStream struct {
 	clusterID int64
 	memberID  int64
 
-	maxRequestBytes uint
+	maxRequestBytes          uint
+	maxWatchers              uint
+	maxWatcherBytesPerSecond uint
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
+	lessor    lease.Lessor
 	ag        AuthGetter
+	uwl       UserWatcherLimiter
 
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
 
-	// mu protects progress, prevKV, fragment
+	// mu protects progress, prevKV, fragment, limits, leases, watchers
 	mu sync.RWMutex
 	// tracks the watchID that stream might need to send progress to
 	// TODO: combine progress and prevKV into a single struct?
@@ -145,6 +165,13 @@ type serverWatchStream struct {
 	prevKV map[mvcc.WatchID]bool
 	// records fragmented watch IDs
 	fragment map[mvcc.WatchID]bool
+	// records the response limits requested by watch IDs
+	limits map[mvcc.WatchID]watchLimits
+	// records the lease filter of the watch IDs restricted to the keys of a lease
+	leases map[mvcc.WatchID]*leaseWatch
+	// records the user of the active watch IDs, which is empty for
+	// unauthenticated clients
+	watchers map[mvcc.WatchID]string
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -160,11 +187,15 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		clusterID: ws.clusterID,
 		memberID:  ws.memberID,
 
-		maxRequestBytes: ws.maxRequestBytes,
+		maxRequestBytes:          ws.maxRequestBytes,
+		maxWatchers:              ws.maxWatchers,
+		maxWatcherBytesPerSecond: ws.maxWatcherBytesPerSecond,
 
 		sg:        ws.sg,
 		watchable: ws.watchable,
+		lessor:    ws.lessor,
 		ag:        ws.ag,
+		uwl:       ws.uwl,
 
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
@@ -174,6 +205,9 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		progress: make(map[mvcc.WatchID]bool),
 		prevKV:   make(map[mvcc.WatchID]bool),
 		fragment: make(map[mvcc.WatchID]bool),
+		limits:   make(map[mvcc.WatchID]watchLimits),
+		leases:   make(map[mvcc.WatchID]*leaseWatch),
+		watchers: make(map[mvcc.WatchID]string),
 
 		closec: make(chan struct{}),
 	}
@@ -227,16 +261,16 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 	return err
 }
 
-func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) error {
+func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) (*auth.AuthInfo, error) {
 	authInfo, err := sws.ag.AuthInfoFromCtx(sws.gRPCStream.Context())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if authInfo == nil {
 		// if auth is enabled, IsRangePermitted() can cause an error
 		authInfo = &auth.AuthInfo{}
 	}
-	return sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
+	return authInfo, sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
 }
 
 func (sws *serverWatchStream) recvLoop() error {
@@ -270,7 +304,7 @@ func (sws *serverWatchStream) recvLoop() error {
 				creq.RangeEnd = []byte{}
 			}
 
-			err := sws.isWatchPermitted(creq)
+			authInfo, err := sws.isWatchPermitted(creq)
 			if err != nil {
 				var cancelReason string
 				switch {
@@ -303,6 +337,24 @@ func (sws *serverWatchStream) recvLoop() error {
 				}
 			}
 
+			user := authInfo.Username
+			if !sws.acquireWatcher(user) {
+				wr := &pb.WatchResponse{
+					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+					WatchId:      clientv3.InvalidWatchID,
+					Canceled:     true,
+					Created:      true,
+					CancelReason: rpctypes.ErrGRPCTooManyWatchers.Error(),
+				}
+
+				select {
+				case sws.ctrlStream <- wr:
+					continue
+				case <-sws.closec:
+					return nil
+				}
+			}
+
 			filters := FiltersFromRequest(creq)
 
 			wsrev := sws.watchStream.Rev()
@@ -313,6 +365,7 @@ func (sws *serverWatchStream) recvLoop() error {
 			id, err := sws.watchStream.Watch(mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, rev, filters...)
 			if err == nil {
 				sws.mu.Lock()
+				sws.watchers[id] = user
 				if creq.ProgressNotify {
 					sws.progress[id] = true
 				}
@@ -322,8 +375,18 @@ func (sws *serverWatchStream) recvLoop() error {
 				if creq.Fragment {
 					sws.fragment[id] = true
 				}
+				if creq.MaxEvents > 0 || creq.MaxBytes > 0 {
+					sws.limits[id] = watchLimits{maxEvents: int(creq.MaxEvents), maxBytes: int(creq.MaxBytes)}
+				}
+				if creq.Lease != 0 {
+					sws.leases[id] = newLeaseWatch(sws.lessor, sws.watchable, creq.Lease, creq.Key, creq.RangeEnd)
+				} else {
+					// drop the filter of a canceled watcher of the same ID
+					delete(sws.leases, id)
+				}
 				sws.mu.Unlock()
 			} else {
+				sws.uwl.ReleaseUserWatcher(user)
 				id = clientv3.InvalidWatchID
 			}
 
@@ -358,11 +421,7 @@ func (sws *serverWatchStream) recvLoop() error {
 						return nil
 					}
 
-					sws.mu.Lock()
-					delete(sws.progress, mvcc.WatchID(id))
-					delete(sws.prevKV, mvcc.WatchID(id))
-					delete(sws.fragment, mvcc.WatchID(id))
-					sws.mu.Unlock()
+					sws.forgetWatcher(mvcc.WatchID(id))
 				}
 			}
 		case *pb.WatchRequest_ProgressRequest:
@@ -390,8 +449,20 @@ func (sws *serverWatchStream) sendLoop() {
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
+	// the watch responses exceeding the bandwidth of their watcher are
+	// delayed, and so is the progress of the whole stream after them
+	var pacing *watchPacing
+	var progressAll *pb.WatchResponse
+	var wakeAt time.Time
+	pacingTimer := time.NewTimer(time.Hour)
+	pacingTimer.Stop()
+	if sws.maxWatcherBytesPerSecond > 0 {
+		pacing = newWatchPacing(sws.maxWatcherBytesPerSecond)
+	}
+
 	defer func() {
 		progressTicker.Stop()
+		pacingTimer.Stop()
 		// drain the chan to clean up pending events
 		for ws := range sws.watchStream.Chan() {
 			mvcc.ReportEventReceived(len(ws.Events))
@@ -404,6 +475,25 @@ func (sws *serverWatchStream) sendLoop() {
 	}()
 
 	for {
+		if pacing != nil {
+			if progressAll != nil && !pacing.backlogged() {
+				if err := sws.gRPCStream.Send(progressAll); err != nil {
+					sws.logSendError("failed to send watch response to gRPC stream", err)
+					return
+				}
+				progressAll = nil
+			}
+			next, ok := pacing.wake()
+			if !next.Equal(wakeAt) {
+				wakeAt = next
+				if ok {
+					pacingTimer.Reset(time.Until(next))
+				} else {
+					pacingTimer.Stop()
+				}
+			}
+		}
+
 		select {
 		case wresp, ok := <-sws.watchStream.Chan():
 			if !ok {
@@ -417,10 +507,16 @@ func (sws *serverWatchStream) sendLoop() {
 			events := make([]*mvccpb.Event, len(evs))
 			sws.mu.RLock()
 			needPrevKV := sws.prevKV[wresp.WatchID]
+			lw := sws.leases[wresp.WatchID]
 			sws.mu.RUnlock()
+			if lw != nil && lw.canceled {
+				mvcc.ReportEventReceived(len(evs))
+				continue
+			}
 			for i := range evs {
 				events[i] = &evs[i]
-				if needPrevKV && !IsCreateEvent(evs[i]) {
+				// the deleted keys of a lazy range delete are not looked up.
+				if needPrevKV && !IsCreateEvent(evs[i]) && evs[i].RangeEnd == nil {
 					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1}
 					r, err := sws.watchable.Range(context.TODO(), evs[i].Kv.Key, nil, opt)
 					if err == nil && len(r.KVs) != 0 {
@@ -428,14 +524,38 @@ func (sws *serverWatchStream) sendLoop() {
 					}
 				}
 			}
+			var cancelReason string
+			if lw != nil {
+				var err error
+				events, err = lw.filter(sws.watchable, events)
+				if err != nil {
+					// the keys attached to the lease are unknown, so the
+					// watcher cannot go on without missing events.
+					lw.canceled = true
+					sws.watchStream.Cancel(wresp.WatchID)
+					sws.releaseWatcher(wresp.WatchID)
+					cancelReason = err.Error()
+					events = nil
+				}
+				// the events of other leases are never sent.
+				mvcc.ReportEventReceived(len(evs) - len(events))
+				if len(evs) > 0 && len(events) == 0 && wresp.CompactRevision == 0 && cancelReason == "" {
+					continue
+				}
+			}
 
-			canceled := wresp.CompactRevision != 0
+			canceled := wresp.CompactRevision != 0 || cancelReason != ""
+			if wresp.CompactRevision != 0 {
+				// the watcher is removed from the watch stream on compaction.
+				sws.releaseWatcher(wresp.WatchID)
+			}
 			wr := &pb.WatchResponse{
 				Header:          sws.newResponseHeader(wresp.Revision),
 				WatchId:         int64(wresp.WatchID),
 				Events:          events,
 				CompactRevision: wresp.CompactRevision,
 				Canceled:        canceled,
+				CancelReason:    cancelReason,
 			}
 
 			// Progress notifications can have WatchID -1
@@ -449,37 +569,17 @@ func (sws *serverWatchStream) sendLoop() {
 				}
 			}
 
-			mvcc.ReportEventReceived(len(evs))
-
-			sws.mu.RLock()
-			fragmented, ok := sws.fragment[wresp.WatchID]
-			sws.mu.RUnlock()
+			mvcc.ReportEventReceived(len(events))
 
-			var serr error
-			// gofail: var beforeSendWatchResponse struct{}
-			if !fragmented && !ok {
-				serr = sws.gRPCStream.Send(wr)
-			} else {
-				serr = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+			if wresp.WatchID == clientv3.InvalidWatchID && pacing != nil && pacing.backlogged() {
+				progressAll = wr
+				continue
 			}
-
-			if serr != nil {
-				if isClientCtxErr(sws.gRPCStream.Context().Err(), serr) {
-					sws.lg.Debug("failed to send watch response to gRPC stream", zap.Error(serr))
-				} else {
-					sws.lg.Warn("failed to send watch response to gRPC stream", zap.Error(serr))
-					streamFailures.WithLabelValues("send", "watch").Inc()
-				}
+			if serr := sws.sendWatchResponse(wr, pacing, ids); serr != nil {
+				sws.logSendError("failed to send watch response to gRPC stream", serr)
 				return
 			}
 
-			sws.mu.Lock()
-			if len(evs) > 0 && sws.progress[wresp.WatchID] {
-				// elide next progress update if sent a key update
-				sws.progress[wresp.WatchID] = false
-			}
-			sws.mu.Unlock()
-
 		case c, ok := <-sws.ctrlStream:
 			if !ok {
 				return
@@ -502,6 +602,9 @@ func (sws *serverWatchStream) sendLoop() {
 
 			if c.Canceled && wid != clientv3.InvalidWatchID {
 				delete(ids, wid)
+				if pacing != nil {
+					pacing.remove(wid)
+				}
 				continue
 			}
 			if c.Created {
@@ -509,19 +612,27 @@ func (sws *serverWatchStream) sendLoop() {
 				ids[wid] = struct{}{}
 				for _, v := range pending[wid] {
 					mvcc.ReportEventReceived(len(v.Events))
-					if err := sws.gRPCStream.Send(v); err != nil {
-						if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
-							sws.lg.Debug("failed to send pending watch response to gRPC stream", zap.Error(err))
-						} else {
-							sws.lg.Warn("failed to send pending watch response to gRPC stream", zap.Error(err))
-							streamFailures.WithLabelValues("send", "watch").Inc()
-						}
+					if _, ok := ids[wid]; !ok {
+						// canceled while flushing
+						continue
+					}
+					if err := sws.sendWatchResponse(v, pacing, ids); err != nil {
+						sws.logSendError("failed to send pending watch response to gRPC stream", err)
 						return
 					}
 				}
 				delete(pending, wid)
 			}
 
+		case <-pacingTimer.C:
+			wakeAt = time.Time{}
+			for _, wr := range pacing.ready(time.Now()) {
+				if err := sws.sendPart(wr); err != nil {
+					sws.logSendError("failed to send delayed watch response to gRPC stream", err)
+					return
+				}
+			}
+
 		case <-progressTicker.C:
 			sws.mu.Lock()
 			for id, ok := range sws.progress {
@@ -538,6 +649,100 @@ func (sws *serverWatchStream) sendLoop() {
 	}
 }
 
+// sendWatchResponse sends the watch response of a watcher split to the limits
+// requested by the watcher, delaying the parts exceeding its bandwidth.
+func (sws *serverWatchStream) sendWatchResponse(wr *pb.WatchResponse, pacing *watchPacing, ids map[mvcc.WatchID]struct{}) error {
+	id := mvcc.WatchID(wr.WatchId)
+	sws.mu.RLock()
+	limits, limited := sws.limits[id]
+	fragment := sws.fragment[id]
+	sws.mu.RUnlock()
+
+	paced := pacing != nil && id != clientv3.InvalidWatchID
+	maxBytes := limits.maxBytes
+	if paced && (maxBytes <= 0 || maxBytes > pacing.bytesPerSecond) {
+		// keep the pacing smooth by sending a second of bandwidth at most at once
+		maxBytes = pacing.bytesPerSecond
+	}
+	for _, part := range splitWatchResponse(wr, limits.maxEvents, maxBytes, fragment || limited) {
+		if paced {
+			send, tooSlow := pacing.admit(part, time.Now())
+			if tooSlow {
+				return sws.cancelSlowWatcher(id, pacing, ids)
+			}
+			if !send {
+				continue
+			}
+		}
+		if err := sws.sendPart(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendPart sends a watch response, fragmented to the maximum request size if
+// requested by the watcher.
+func (sws *serverWatchStream) sendPart(wr *pb.WatchResponse) error {
+	id := mvcc.WatchID(wr.WatchId)
+	sws.mu.RLock()
+	fragmented := sws.fragment[id]
+	sws.mu.RUnlock()
+
+	var err error
+	// gofail: var beforeSendWatchResponse struct{}
+	if !fragmented {
+		err = sws.gRPCStream.Send(wr)
+	} else {
+		err = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+	}
+	if err != nil {
+		return err
+	}
+
+	sws.mu.Lock()
+	if len(wr.Events) > 0 && sws.progress[id] {
+		// elide next progress update if sent a key update
+		sws.progress[id] = false
+	}
+	sws.mu.Unlock()
+	return nil
+}
+
+// cancelSlowWatcher cancels a watcher lagging too far behind its bandwidth and
+// drops its delayed responses.
+func (sws *serverWatchStream) cancelSlowWatcher(id mvcc.WatchID, pacing *watchPacing, ids map[mvcc.WatchID]struct{}) error {
+	pacing.remove(id)
+	if err := sws.watchStream.Cancel(id); err != nil {
+		// already canceled by the client
+		return nil
+	}
+	delete(ids, id)
+	sws.forgetWatcher(id)
+	watchPacingCanceledWatchers.Inc()
+	sws.lg.Warn(
+		"canceled watcher exceeding its bandwidth",
+		zap.Int64("watch-id", int64(id)),
+		zap.Uint("max-watcher-bytes-per-second", sws.maxWatcherBytesPerSecond),
+		zap.Duration("max-pacing-delay", maxWatchPacingDelay),
+	)
+	return sws.gRPCStream.Send(&pb.WatchResponse{
+		Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:      int64(id),
+		Canceled:     true,
+		CancelReason: rpctypes.ErrGRPCWatcherTooSlow.Error(),
+	})
+}
+
+func (sws *serverWatchStream) logSendError(msg string, err error) {
+	if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+		sws.lg.Debug(msg, zap.Error(err))
+	} else {
+		sws.lg.Warn(msg, zap.Error(err))
+		streamFailures.WithLabelValues("send", "watch").Inc()
+	}
+}
+
 func IsCreateEvent(e mvccpb.Event) bool {
 	return e.Type == mvccpb.PUT && e.Kv.CreateRevision == e.Kv.ModRevision
 }
@@ -569,13 +774,14 @@ func sendFragments(
 			idx++
 		}
 		if idx == len(wr.Events) {
-			// last response has no more fragment
-			cur.Fragment = false
+			// last response has no more fragment, unless the response
+			// is itself a fragment of a revision
+			cur.Fragment = wr.Fragment
 		}
 		if err := sendFunc(&cur); err != nil {
 			return err
 		}
-		if !cur.Fragment {
+		if idx == len(wr.Events) {
 			break
 		}
 	}
@@ -586,6 +792,49 @@ func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
 	close(sws.closec)
 	sws.wg.Wait()
+
+	sws.mu.Lock()
+	defer sws.mu.Unlock()
+	for id, user := range sws.watchers {
+		sws.uwl.ReleaseUserWatcher(user)
+		delete(sws.watchers, id)
+	}
+}
+
+// acquireWatcher checks that a new watcher does not exceed the maximum
+// number of watchers of the stream and of its user, and counts it as a
+// watcher of the user.
+func (sws *serverWatchStream) acquireWatcher(user string) bool {
+	sws.mu.RLock()
+	n := uint(len(sws.watchers))
+	sws.mu.RUnlock()
+	if sws.maxWatchers > 0 && n >= sws.maxWatchers {
+		return false
+	}
+	return sws.uwl.AcquireUserWatcher(user)
+}
+
+// forgetWatcher drops the state of a canceled watcher and uncounts it.
+func (sws *serverWatchStream) forgetWatcher(id mvcc.WatchID) {
+	sws.mu.Lock()
+	delete(sws.progress, id)
+	delete(sws.prevKV, id)
+	delete(sws.fragment, id)
+	delete(sws.limits, id)
+	delete(sws.leases, id)
+	sws.mu.Unlock()
+	sws.releaseWatcher(id)
+}
+
+// releaseWatcher uncounts the watcher, if active.
+func (sws *serverWatchStream) releaseWatcher(id mvcc.WatchID) {
+	sws.mu.Lock()
+	user, ok := sws.watchers[id]
+	delete(sws.watchers, id)
+	sws.mu.Unlock()
+	if ok {
+		sws.uwl.ReleaseUserWatcher(user)
+	}
 }
 
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
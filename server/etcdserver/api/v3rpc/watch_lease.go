@@ -0,0 +1,171 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"bytes"
+	"context"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// leaseWatch restricts the events of a watcher to the keys attached to a
+// lease: the puts attaching a key to the lease, and the puts and deletions
+// of the keys attached to the lease before, including the deletions of the
+// lease expiring or being revoked.
+//
+// The keys attached to the lease are tracked from the lessor index, which
+// reflects a revision between the start of the watcher and seedRev. The
+// events up to seedRev are thus filtered by looking up the previous revision
+// of their keys, while applying them brings the tracked keys up to date
+// with seedRev, so that the later events are filtered by the tracked keys
+// alone.
+type leaseWatch struct {
+	id int64
+	// keys are the watched keys attached to the lease.
+	keys    map[string]struct{}
+	seedRev int64
+	// canceled is set once the watcher is canceled, as its previous events
+	// could not be looked up, to drop its events still queued.
+	canceled bool
+}
+
+// newLeaseWatch returns the lease filter of a watcher of the range [key, end),
+// which must have started before calling it.
+func newLeaseWatch(le lease.Lessor, kv mvcc.KV, id int64, key, end []byte) *leaseWatch {
+	lw := &leaseWatch{
+		id:   id,
+		keys: make(map[string]struct{}),
+	}
+	if l := le.Lookup(lease.LeaseID(id)); l != nil {
+		for _, k := range l.Keys() {
+			if inWatchRange([]byte(k), key, end) {
+				lw.keys[k] = struct{}{}
+			}
+		}
+	}
+	lw.seedRev = kv.Rev()
+	return lw
+}
+
+// filter returns the events of the keys attached to the lease. It fails if
+// the previous revision of an event is compacted.
+func (lw *leaseWatch) filter(kv mvcc.KV, events []*mvccpb.Event) ([]*mvccpb.Event, error) {
+	kept := events[:0]
+	for _, ev := range events {
+		keep := ev.Type == mvccpb.PUT && ev.Kv.Lease == lw.id
+		if !keep {
+			var err error
+			if ev.Kv.ModRevision <= lw.seedRev {
+				keep, err = lw.attachedBefore(kv, ev)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				keep = lw.attached(ev)
+			}
+		}
+		lw.apply(ev)
+		if keep {
+			kept = append(kept, ev)
+		}
+	}
+	return kept, nil
+}
+
+// attachedBefore returns whether a key of the event was attached to the
+// lease before the event, looking up the previous revision.
+func (lw *leaseWatch) attachedBefore(kv mvcc.KV, ev *mvccpb.Event) (bool, error) {
+	if ev.PrevKv != nil {
+		return ev.PrevKv.Lease == lw.id, nil
+	}
+	if IsCreateEvent(*ev) {
+		return false, nil
+	}
+	opt := mvcc.RangeOptions{Rev: ev.Kv.ModRevision - 1}
+	r, err := kv.Range(context.TODO(), ev.Kv.Key, eventRangeEnd(ev), opt)
+	if err != nil {
+		return false, err
+	}
+	for i := range r.KVs {
+		if r.KVs[i].Lease == lw.id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// attached returns whether a key of the event is a tracked key of the lease.
+func (lw *leaseWatch) attached(ev *mvccpb.Event) bool {
+	end := eventRangeEnd(ev)
+	if end == nil {
+		_, ok := lw.keys[string(ev.Kv.Key)]
+		return ok
+	}
+	for k := range lw.keys {
+		if inWatchRange([]byte(k), ev.Kv.Key, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply updates the tracked keys of the lease to the event.
+func (lw *leaseWatch) apply(ev *mvccpb.Event) {
+	if ev.Type == mvccpb.PUT {
+		if ev.Kv.Lease == lw.id {
+			lw.keys[string(ev.Kv.Key)] = struct{}{}
+		} else {
+			delete(lw.keys, string(ev.Kv.Key))
+		}
+		return
+	}
+	end := eventRangeEnd(ev)
+	if end == nil {
+		delete(lw.keys, string(ev.Kv.Key))
+		return
+	}
+	for k := range lw.keys {
+		if inWatchRange([]byte(k), ev.Kv.Key, end) {
+			delete(lw.keys, k)
+		}
+	}
+}
+
+// eventRangeEnd returns the end of the range of a lazy range delete, nil for
+// the events of a single key.
+func eventRangeEnd(ev *mvccpb.Event) []byte {
+	end := ev.RangeEnd
+	if len(end) == 1 && end[0] == 0 {
+		// "\x00" ranges over all keys from the key on.
+		end = []byte{}
+	}
+	return end
+}
+
+// inWatchRange returns whether k is in the range [key, end), which is the
+// single key if end is nil, and all keys from key on if end is empty or
+// "\x00".
+func inWatchRange(k, key, end []byte) bool {
+	if end == nil {
+		return bytes.Equal(k, key)
+	}
+	if bytes.Compare(k, key) < 0 {
+		return false
+	}
+	return len(end) == 0 || (len(end) == 1 && end[0] == 0) || bytes.Compare(k, end) < 0
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+func eventKeys(events []*mvccpb.Event) []string {
+	var keys []string
+	for _, ev := range events {
+		keys = append(keys, ev.Type.String()+" "+string(ev.Kv.Key))
+	}
+	return keys
+}
+
+func TestLeaseWatchTrackedKeys(t *testing.T) {
+	lw := &leaseWatch{id: 1, keys: map[string]struct{}{"a": {}}}
+	events := []*mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("b"), Lease: 1, ModRevision: 2}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("c"), Lease: 2, ModRevision: 3}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 4}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 5}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("b"), ModRevision: 6}, RangeEnd: []byte("d")},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("c"), ModRevision: 7}},
+	}
+	kept, err := lw.filter(nil, events)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PUT b", "PUT a", "DELETE b"}, eventKeys(kept))
+	assert.Empty(t, lw.keys)
+}
+
+func TestLeaseWatchLookupBeforeSeed(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+	s := mvcc.New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer s.Close()
+
+	s.Put([]byte("a"), []byte("v"), 1)             // rev 2
+	s.Put([]byte("a"), []byte("v"), lease.NoLease) // rev 3
+	s.Put([]byte("b"), []byte("v"), 1)             // rev 4
+
+	lw := newLeaseWatch(&lease.FakeLessor{}, s, 1, []byte("a"), []byte("c"))
+	assert.Equal(t, int64(4), lw.seedRev)
+	events := []*mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), Lease: 1, CreateRevision: 2, ModRevision: 2}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), CreateRevision: 2, ModRevision: 3}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("b"), Lease: 1, CreateRevision: 4, ModRevision: 4}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("b"), ModRevision: 5}},
+	}
+	kept, err := lw.filter(s, events)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PUT a", "PUT a", "PUT b", "DELETE b"}, eventKeys(kept))
+
+	_, err = s.Compact(traceutil.TODO(), 3)
+	require.NoError(t, err)
+	lw = &leaseWatch{id: 1, keys: map[string]struct{}{}, seedRev: 4}
+	_, err = lw.filter(s, []*mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), CreateRevision: 2, ModRevision: 3}},
+	})
+	require.ErrorIs(t, err, mvcc.ErrCompacted)
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// maxWatchPacingDelay is the longest a paced watcher may lag behind. A
+// watcher whose backlog would take longer to send is canceled, so a watcher
+// that cannot keep up with its events does not buffer them without bound.
+const maxWatchPacingDelay = time.Minute
+
+// watchLimits are the limits of the watch responses requested by a watcher.
+type watchLimits struct {
+	maxEvents int
+	maxBytes  int
+}
+
+// splitWatchResponse splits the events of a watch response into responses of
+// at most maxEvents events and maxBytes bytes of events, zero meaning no
+// limit. A single event larger than maxBytes is sent on its own. If fragment
+// is false, responses are only split between revisions, so a revision larger
+// than the limits is sent whole. Otherwise a revision split across responses
+// is sent as fragments.
+func splitWatchResponse(wr *pb.WatchResponse, maxEvents, maxBytes int, fragment bool) []*pb.WatchResponse {
+	if maxEvents <= 0 && maxBytes <= 0 {
+		return []*pb.WatchResponse{wr}
+	}
+
+	var wrs []*pb.WatchResponse
+	split := func(start, end int, fragment bool) {
+		cur := *wr
+		cur.Events = wr.Events[start:end]
+		cur.Fragment = fragment
+		wrs = append(wrs, &cur)
+	}
+	// start is the first event of the current response, and revStart the
+	// first event of the revision of the current event, preceded by revSize
+	// bytes of events in the current response
+	start, size := 0, 0
+	revStart, revSize := 0, 0
+	for i, ev := range wr.Events {
+		if i > start && !sameRevision(wr.Events[i-1], ev) {
+			revStart, revSize = i, size
+		}
+		evSize := ev.Size()
+		full := (maxEvents > 0 && i-start >= maxEvents) || (maxBytes > 0 && size+evSize > maxBytes)
+		if i > start && full {
+			switch {
+			case fragment || revStart == i:
+				split(start, i, sameRevision(wr.Events[i-1], ev))
+				start, size = i, 0
+			case revStart > start:
+				split(start, revStart, false)
+				start, size = revStart, size-revSize
+			}
+		}
+		size += evSize
+	}
+	if start == 0 {
+		return []*pb.WatchResponse{wr}
+	}
+	split(start, len(wr.Events), false)
+	return wrs
+}
+
+func sameRevision(a, b *mvccpb.Event) bool {
+	return a.Kv.ModRevision == b.Kv.ModRevision
+}
+
+// watchPacing paces the responses of each watcher of a stream to a maximum
+// number of bytes per second, so a watcher receiving a burst of events does
+// not monopolize the stream. Responses of a watcher are delayed, in order,
+// until the bytes it sent before are paid off.
+type watchPacing struct {
+	bytesPerSecond int
+	watchers       map[mvcc.WatchID]*watchPacer
+	// backlog holds the watchers with delayed responses
+	backlog map[mvcc.WatchID]*watchPacer
+}
+
+type watchPacer struct {
+	// next is when the watcher may send its next response.
+	next time.Time
+	// queue holds the responses delayed by the pacing.
+	queue []pacedResponse
+	// queued is the size of the responses in queue.
+	queued int
+}
+
+type pacedResponse struct {
+	wr     *pb.WatchResponse
+	queued time.Time
+}
+
+func newWatchPacing(bytesPerSecond uint) *watchPacing {
+	return &watchPacing{
+		bytesPerSecond: int(bytesPerSecond),
+		watchers:       make(map[mvcc.WatchID]*watchPacer),
+		backlog:        make(map[mvcc.WatchID]*watchPacer),
+	}
+}
+
+// admit returns whether the watch response can be sent now, in which case it
+// is accounted for. Otherwise the response is queued, unless the backlog of
+// the watcher would exceed maxWatchPacingDelay, in which case admit reports
+// that the watcher is too slow and should be canceled.
+func (wp *watchPacing) admit(wr *pb.WatchResponse, now time.Time) (send bool, tooSlow bool) {
+	id := mvcc.WatchID(wr.WatchId)
+	p, ok := wp.watchers[id]
+	if !ok {
+		p = &watchPacer{}
+		wp.watchers[id] = p
+	}
+	size := wr.Size()
+	if len(p.queue) == 0 && !p.next.After(now) {
+		wp.sent(id, p, wr, now)
+		return true, false
+	}
+	if p.next.Sub(now)+wp.duration(p.queued+size) > maxWatchPacingDelay {
+		return false, true
+	}
+	p.queue = append(p.queue, pacedResponse{wr: wr, queued: now})
+	p.queued += size
+	wp.backlog[id] = p
+	watchPacingDelayedResponses.Inc()
+	return false, false
+}
+
+// ready returns the delayed watch responses that can be sent now, and
+// accounts for them.
+func (wp *watchPacing) ready(now time.Time) []*pb.WatchResponse {
+	var wrs []*pb.WatchResponse
+	for id, p := range wp.backlog {
+		for len(p.queue) > 0 && !p.next.After(now) {
+			pr := p.queue[0]
+			p.queue = p.queue[1:]
+			p.queued -= pr.wr.Size()
+			wp.sent(id, p, pr.wr, now)
+			watchPacingDelaySec.Observe(now.Sub(pr.queued).Seconds())
+			wrs = append(wrs, pr.wr)
+		}
+		if len(p.queue) == 0 {
+			delete(wp.backlog, id)
+		}
+	}
+	return wrs
+}
+
+func (wp *watchPacing) sent(id mvcc.WatchID, p *watchPacer, wr *pb.WatchResponse, now time.Time) {
+	if wr.Canceled {
+		// a canceled watcher sends no more responses
+		delete(wp.watchers, id)
+		return
+	}
+	p.next = now.Add(wp.duration(wr.Size()))
+}
+
+// wake returns when the next delayed watch response can be sent, if any.
+func (wp *watchPacing) wake() (time.Time, bool) {
+	var next time.Time
+	for _, p := range wp.backlog {
+		if next.IsZero() || p.next.Before(next) {
+			next = p.next
+		}
+	}
+	return next, len(wp.backlog) > 0
+}
+
+// backlogged returns whether any watch response is delayed.
+func (wp *watchPacing) backlogged() bool {
+	return len(wp.backlog) > 0
+}
+
+// remove drops the watcher and its delayed watch responses.
+func (wp *watchPacing) remove(id mvcc.WatchID) {
+	delete(wp.watchers, id)
+	delete(wp.backlog, id)
+}
+
+func (wp *watchPacing) duration(size int) time.Duration {
+	return time.Duration(int64(size) * int64(time.Second) / int64(wp.bytesPerSecond))
+}
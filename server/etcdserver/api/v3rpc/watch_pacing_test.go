@@ -0,0 +1,173 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestSplitWatchResponse(t *testing.T) {
+	tcs := []struct {
+		name      string
+		revs      []int64
+		maxEvents int
+		maxBytes  int
+		fragment  bool
+		// wantEvents is the number of events of each response
+		wantEvents []int
+		// wantFragment is the fragment flag of each response
+		wantFragment []bool
+	}{
+		{
+			name:         "no limits",
+			revs:         []int64{1, 2, 3},
+			wantEvents:   []int{3},
+			wantFragment: []bool{false},
+		},
+		{
+			name:         "max events between revisions",
+			revs:         []int64{1, 2, 3, 4, 5},
+			maxEvents:    2,
+			wantEvents:   []int{2, 2, 1},
+			wantFragment: []bool{false, false, false},
+		},
+		{
+			name:         "max events fragmenting a revision",
+			revs:         []int64{1, 2, 2, 2, 3},
+			maxEvents:    2,
+			fragment:     true,
+			wantEvents:   []int{2, 2, 1},
+			wantFragment: []bool{true, false, false},
+		},
+		{
+			name:         "max events keeping revisions whole",
+			revs:         []int64{1, 2, 2, 2, 3},
+			maxEvents:    2,
+			wantEvents:   []int{1, 3, 1},
+			wantFragment: []bool{false, false, false},
+		},
+		{
+			name:         "max bytes",
+			revs:         []int64{1, 2, 3, 4},
+			maxBytes:     2 * testEventSize,
+			wantEvents:   []int{2, 2},
+			wantFragment: []bool{false, false},
+		},
+		{
+			name:         "max bytes smaller than an event",
+			revs:         []int64{1, 1, 2},
+			maxBytes:     1,
+			fragment:     true,
+			wantEvents:   []int{1, 1, 1},
+			wantFragment: []bool{true, false, false},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			wr := &pb.WatchResponse{WatchId: 1}
+			for _, rev := range tc.revs {
+				wr.Events = append(wr.Events, testEvent(rev))
+			}
+
+			wrs := splitWatchResponse(wr, tc.maxEvents, tc.maxBytes, tc.fragment)
+
+			var events []int
+			var fragments []bool
+			var revs []int64
+			for _, part := range wrs {
+				events = append(events, len(part.Events))
+				fragments = append(fragments, part.Fragment)
+				for _, ev := range part.Events {
+					revs = append(revs, ev.Kv.ModRevision)
+				}
+			}
+			assert.Equal(t, tc.wantEvents, events)
+			assert.Equal(t, tc.wantFragment, fragments)
+			assert.Equal(t, tc.revs, revs)
+		})
+	}
+}
+
+func TestSendFragmentOfFragment(t *testing.T) {
+	wr := createResponse(11, 5)
+	wr.Fragment = true
+
+	var wrs []*pb.WatchResponse
+	err := sendFragments(wr, 20, func(wr *pb.WatchResponse) error {
+		wrs = append(wrs, wr)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, wrs, 5)
+	for _, wr := range wrs {
+		assert.True(t, wr.Fragment)
+	}
+}
+
+func TestWatchPacing(t *testing.T) {
+	now := time.Now()
+	wp := newWatchPacing(uint(10 * testEventSize))
+	wr := func(id int64) *pb.WatchResponse {
+		return &pb.WatchResponse{WatchId: id, Events: []*mvccpb.Event{testEvent(1)}}
+	}
+	size := wr(1).Size()
+	perResponse := wp.duration(size)
+
+	send, tooSlow := wp.admit(wr(1), now)
+	require.True(t, send)
+	require.False(t, tooSlow)
+	assert.False(t, wp.backlogged())
+
+	// the second response of the watcher waits for the first to be paid off,
+	// while other watchers are not delayed
+	send, _ = wp.admit(wr(1), now)
+	require.False(t, send)
+	send, _ = wp.admit(wr(2), now)
+	require.True(t, send)
+	assert.True(t, wp.backlogged())
+
+	next, ok := wp.wake()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(perResponse), next)
+	assert.Empty(t, wp.ready(next.Add(-time.Nanosecond)))
+	assert.Len(t, wp.ready(next), 1)
+	assert.False(t, wp.backlogged())
+
+	// a backlog exceeding the maximum delay asks to cancel the watcher
+	for {
+		send, tooSlow = wp.admit(wr(1), now)
+		require.False(t, send)
+		if tooSlow {
+			break
+		}
+	}
+	wp.remove(1)
+	assert.False(t, wp.backlogged())
+	send, _ = wp.admit(wr(1), now)
+	assert.True(t, send)
+}
+
+var testEventSize = testEvent(1).Size()
+
+func testEvent(rev int64) *mvccpb.Event {
+	return &mvccpb.Event{Kv: &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), ModRevision: rev}}
+}
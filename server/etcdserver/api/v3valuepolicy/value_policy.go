@@ -0,0 +1,192 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v3valuepolicy manages the policies restricting the keys and values
+// written under key prefixes.
+package v3valuepolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	ErrInvalidValuePolicy = errors.New("etcdserver: invalid value policy")
+	ErrValueTooLarge      = errors.New("etcdserver: value exceeds the size limit of the value policy")
+	ErrBadContent         = errors.New("etcdserver: value does not match the content type of the value policy")
+	ErrKeyMismatch        = errors.New("etcdserver: key does not match the key pattern of the value policy")
+)
+
+type policy struct {
+	*pb.ValuePolicy
+	keyPattern *regexp.Regexp
+}
+
+// ValuePolicyStore persists value policies to the backend and checks the
+// writes against them.
+type ValuePolicyStore struct {
+	lg       *zap.Logger
+	mu       sync.RWMutex
+	policies map[string]*policy
+
+	be schema.ValuePolicyBackend
+}
+
+func NewValuePolicyStore(lg *zap.Logger, be schema.ValuePolicyBackend) (*ValuePolicyStore, error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	ret := &ValuePolicyStore{lg: lg, policies: make(map[string]*policy), be: be}
+	err := ret.restore()
+	return ret, err
+}
+
+// Validate checks that the policy is well-formed.
+func Validate(p *pb.ValuePolicy) error {
+	_, err := compile(p)
+	return err
+}
+
+func compile(p *pb.ValuePolicy) (*policy, error) {
+	if p == nil || p.MaxValueSize < 0 {
+		return nil, ErrInvalidValuePolicy
+	}
+	if _, ok := pb.ValuePolicy_ContentType_name[int32(p.ContentType)]; !ok {
+		return nil, ErrInvalidValuePolicy
+	}
+	ret := &policy{ValuePolicy: p}
+	if p.KeyPattern != "" {
+		re, err := regexp.Compile(p.KeyPattern)
+		if err != nil {
+			return nil, ErrInvalidValuePolicy
+		}
+		ret.keyPattern = re
+	}
+	return ret, nil
+}
+
+// Put sets the policy, replacing the policy of the same prefix.
+func (s *ValuePolicyStore) Put(p *pb.ValuePolicy) error {
+	cp, err := compile(p)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies[string(p.Prefix)] = cp
+	s.be.MustPutValuePolicy(p)
+	return nil
+}
+
+// Delete deletes the policy of the prefix and returns it, if any.
+func (s *ValuePolicyStore) Delete(prefix []byte) *pb.ValuePolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.policies[string(prefix)]
+	if !ok {
+		return nil
+	}
+	delete(s.policies, string(prefix))
+	s.be.MustDeleteValuePolicy(prefix)
+	return p.ValuePolicy
+}
+
+// List returns all policies, sorted by prefix.
+func (s *ValuePolicyStore) List() []*pb.ValuePolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ret := make([]*pb.ValuePolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		ret = append(ret, p.ValuePolicy)
+	}
+	sort.Slice(ret, func(i, j int) bool { return bytes.Compare(ret[i].Prefix, ret[j].Prefix) < 0 })
+	return ret
+}
+
+// Check checks the put of the key against the policy with the longest
+// prefix of the key. If ignoreValue is set, the value is left unchanged
+// by the put and is not checked.
+func (s *ValuePolicyStore) Check(key, value []byte, ignoreValue bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.policies) == 0 {
+		return nil
+	}
+	var match *policy
+	for _, p := range s.policies {
+		if bytes.HasPrefix(key, p.Prefix) && (match == nil || len(p.Prefix) > len(match.Prefix)) {
+			match = p
+		}
+	}
+	if match == nil {
+		return nil
+	}
+	if match.keyPattern != nil && !match.keyPattern.Match(key) {
+		return ErrKeyMismatch
+	}
+	if ignoreValue {
+		return nil
+	}
+	if match.MaxValueSize > 0 && int64(len(value)) > match.MaxValueSize {
+		return ErrValueTooLarge
+	}
+	if !validContent(match.ContentType, value) {
+		return ErrBadContent
+	}
+	return nil
+}
+
+func validContent(ct pb.ValuePolicy_ContentType, value []byte) bool {
+	switch ct {
+	case pb.ValuePolicy_JSON:
+		return json.Valid(value)
+	case pb.ValuePolicy_PROTOBUF:
+		for len(value) > 0 {
+			_, _, n := protowire.ConsumeField(value)
+			if n < 0 {
+				return false
+			}
+			value = value[n:]
+		}
+	}
+	return true
+}
+
+func (s *ValuePolicyStore) restore() error {
+	ps, err := s.be.GetAllValuePolicies()
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		cp, err := compile(p)
+		if err != nil {
+			s.lg.Warn("ignoring invalid value policy", zap.ByteString("prefix", p.Prefix), zap.Error(err))
+			continue
+		}
+		s.policies[string(p.Prefix)] = cp
+	}
+	s.be.ForceCommit()
+	return nil
+}
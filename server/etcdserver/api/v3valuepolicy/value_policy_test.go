@@ -0,0 +1,82 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3valuepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestValuePolicyStoreCheck(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s, err := NewValuePolicyStore(lg, schema.NewValuePolicyBackend(lg, be))
+	require.NoError(t, err)
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/a/"), ContentType: pb.ValuePolicy_JSON, MaxValueSize: 8}))
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/a/raw/"), KeyPattern: `^/a/raw/[0-9]+$`}))
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/pb/"), ContentType: pb.ValuePolicy_PROTOBUF}))
+	require.ErrorIs(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/bad/"), KeyPattern: "("}), ErrInvalidValuePolicy)
+
+	tests := []struct {
+		key, value  string
+		ignoreValue bool
+		wantErr     error
+	}{
+		{key: "/b", value: "{"},
+		{key: "/a/x", value: `{"a":1}`},
+		{key: "/a/x", value: "{", wantErr: ErrBadContent},
+		{key: "/a/x", value: `"123456789"`, wantErr: ErrValueTooLarge},
+		{key: "/a/x", value: "{", ignoreValue: true},
+		// the longest prefix wins
+		{key: "/a/raw/1", value: "{"},
+		{key: "/a/raw/x", value: "1", wantErr: ErrKeyMismatch},
+		{key: "/a/raw/x", ignoreValue: true, wantErr: ErrKeyMismatch},
+		{key: "/pb/x", value: "\x08\x96\x01"},
+		{key: "/pb/x", value: "\x08", wantErr: ErrBadContent},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.wantErr, s.Check([]byte(tt.key), []byte(tt.value), tt.ignoreValue), "Check(%q, %q)", tt.key, tt.value)
+	}
+}
+
+func TestValuePolicyStoreRestore(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s, err := NewValuePolicyStore(lg, schema.NewValuePolicyBackend(lg, be))
+	require.NoError(t, err)
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/b/"), MaxValueSize: 1}))
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte(""), ContentType: pb.ValuePolicy_JSON}))
+	require.NoError(t, s.Put(&pb.ValuePolicy{Prefix: []byte("/a/"), MaxValueSize: 2}))
+	assert.Equal(t, &pb.ValuePolicy{Prefix: []byte("/a/"), MaxValueSize: 2}, s.Delete([]byte("/a/")))
+	assert.Nil(t, s.Delete([]byte("/a/")))
+
+	restored, err := NewValuePolicyStore(lg, schema.NewValuePolicyBackend(lg, be))
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.ValuePolicy{
+		{ContentType: pb.ValuePolicy_JSON},
+		{Prefix: []byte("/b/"), MaxValueSize: 1},
+	}, restored.List())
+}
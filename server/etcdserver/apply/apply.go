@@ -29,6 +29,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	mvcctxn "go.etcd.io/etcd/server/v3/etcdserver/txn"
@@ -83,6 +84,8 @@ type applierV3 interface {
 
 	Alarm(*pb.AlarmRequest) (*pb.AlarmResponse, error)
 
+	ValuePolicy(*pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error)
+
 	Authenticate(r *pb.InternalAuthenticateRequest) (*pb.AuthenticateResponse, error)
 
 	AuthEnable() (*pb.AuthEnableResponse, error)
@@ -109,15 +112,16 @@ type SnapshotServer interface {
 }
 
 type applierV3backend struct {
-	lg              *zap.Logger
-	kv              mvcc.KV
-	alarmStore      *v3alarm.AlarmStore
-	authStore       auth.AuthStore
-	lessor          lease.Lessor
-	cluster         *membership.RaftCluster
-	raftStatus      RaftStatusGetter
-	snapshotServer  SnapshotServer
-	consistentIndex cindex.ConsistentIndexer
+	lg               *zap.Logger
+	kv               mvcc.KV
+	alarmStore       *v3alarm.AlarmStore
+	valuePolicyStore *v3valuepolicy.ValuePolicyStore
+	authStore        auth.AuthStore
+	lessor           lease.Lessor
+	cluster          *membership.RaftCluster
+	raftStatus       RaftStatusGetter
+	snapshotServer   SnapshotServer
+	consistentIndex  cindex.ConsistentIndexer
 
 	txnModeWriteWithSharedBuffer bool
 }
@@ -126,6 +130,7 @@ func newApplierV3Backend(
 	lg *zap.Logger,
 	kv mvcc.KV,
 	alarmStore *v3alarm.AlarmStore,
+	valuePolicyStore *v3valuepolicy.ValuePolicyStore,
 	authStore auth.AuthStore,
 	lessor lease.Lessor,
 	cluster *membership.RaftCluster,
@@ -138,6 +143,7 @@ func newApplierV3Backend(
 		lg:                           lg,
 		kv:                           kv,
 		alarmStore:                   alarmStore,
+		valuePolicyStore:             valuePolicyStore,
 		authStore:                    authStore,
 		lessor:                       lessor,
 		cluster:                      cluster,
@@ -176,7 +182,11 @@ func (a *applierV3backend) Compaction(compaction *pb.CompactionRequest) (*pb.Com
 		traceutil.Field{Key: "revision", Value: compaction.Revision},
 	)
 
-	ch, err := a.kv.Compact(trace, compaction.Revision)
+	retentions := make([]mvcc.Retention, 0, len(compaction.Retentions))
+	for _, r := range compaction.Retentions {
+		retentions = append(retentions, mvcc.Retention{Prefix: r.Prefix, Revision: r.Revision})
+	}
+	ch, err := a.kv.Compact(trace, compaction.Revision, retentions...)
 	if err != nil {
 		return nil, ch, nil, err
 	}
@@ -187,7 +197,7 @@ func (a *applierV3backend) Compaction(compaction *pb.CompactionRequest) (*pb.Com
 }
 
 func (a *applierV3backend) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
-	l, err := a.lessor.Grant(lease.LeaseID(lc.ID), lc.TTL)
+	l, err := a.lessor.GrantWithLabels(lease.LeaseID(lc.ID), lc.TTL, lc.Labels)
 	resp := &pb.LeaseGrantResponse{}
 	if err == nil {
 		resp.ID = int64(l.ID)
@@ -241,6 +251,24 @@ func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 	return resp, nil
 }
 
+func (a *applierV3backend) ValuePolicy(r *pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error) {
+	switch r.Action {
+	case pb.ValuePolicyRequest_GET:
+	case pb.ValuePolicyRequest_PUT:
+		if err := a.valuePolicyStore.Put(r.Policy); err != nil {
+			return nil, err
+		}
+	case pb.ValuePolicyRequest_DELETE:
+		if r.Policy == nil {
+			return nil, v3valuepolicy.ErrInvalidValuePolicy
+		}
+		a.valuePolicyStore.Delete(r.Policy.Prefix)
+	default:
+		return nil, v3valuepolicy.ErrInvalidValuePolicy
+	}
+	return &pb.ValuePolicyResponse{Header: a.newHeader(), Policies: a.valuePolicyStore.List()}, nil
+}
+
 type applierV3Capped struct {
 	applierV3
 	q serverstorage.BackendQuota
@@ -432,8 +460,10 @@ func (a *ApplierMembership) ClusterMemberAttrSet(r *membershippb.ClusterMemberAt
 	a.cluster.UpdateAttributes(
 		types.ID(r.Member_ID),
 		membership.Attributes{
-			Name:       r.MemberAttributes.Name,
-			ClientURLs: r.MemberAttributes.ClientUrls,
+			Name:               r.MemberAttributes.Name,
+			ClientURLs:         r.MemberAttributes.ClientUrls,
+			LeadershipPriority: r.MemberAttributes.LeadershipPriority,
+			ReadReplica:        r.MemberAttributes.ReadReplica,
 		},
 		shouldApplyV3,
 	)
@@ -447,6 +477,30 @@ func (a *ApplierMembership) DowngradeInfoSet(r *membershippb.DowngradeInfoSetReq
 	a.cluster.SetDowngradeInfo(&d, shouldApplyV3)
 }
 
+// MaxClusterMetadataBytes is the maximum total size of the keys and values of
+// the cluster metadata.
+const MaxClusterMetadataBytes = 64 * 1024
+
+// ClusterMetadataSet applies a change of the cluster metadata. Setting an
+// entry fails if the cluster metadata would exceed MaxClusterMetadataBytes,
+// as concurrent requests may each pass the check when proposed.
+func (a *ApplierMembership) ClusterMetadataSet(r *membershippb.ClusterMetadataSetRequest, shouldApplyV3 membership.ShouldApplyV3) error {
+	if !shouldApplyV3 {
+		// the cluster metadata recovered from the backend already reflects
+		// the change, whose size check may not be replayed on the final state.
+		return nil
+	}
+	if r.Delete {
+		a.cluster.DeleteClusterMetadata(r.Key, shouldApplyV3)
+		return nil
+	}
+	if a.cluster.ClusterMetadataSizeWith(r.Key, r.Value) > MaxClusterMetadataBytes {
+		return errors.ErrClusterMetadataTooLarge
+	}
+	a.cluster.SetClusterMetadata(r.Key, r.Value, shouldApplyV3)
+	return nil
+}
+
 type quotaApplierV3 struct {
 	applierV3
 	q serverstorage.Quota
@@ -195,6 +195,8 @@ func needAdminPermission(r *pb.InternalRaftRequest) bool {
 		return true
 	case r.AuthRoleList != nil:
 		return true
+	case r.ValuePolicy != nil:
+		return true
 	default:
 		return false
 	}
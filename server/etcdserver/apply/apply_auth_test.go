@@ -102,6 +102,7 @@ func defaultAuthApplierV3(t *testing.T) *authApplierV3 {
 			lg,
 			kv,
 			alarmStore,
+			nil,
 			authStore,
 			lessor,
 			cluster,
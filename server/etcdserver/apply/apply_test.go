@@ -0,0 +1,49 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/membershippb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+func TestApplierMembershipClusterMetadataSizeLimit(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	cluster := membership.NewCluster(lg)
+	a := NewApplierMembership(lg, cluster, nil)
+
+	half := strings.Repeat("v", MaxClusterMetadataBytes/2)
+	require.NoError(t, a.ClusterMetadataSet(&membershippb.ClusterMetadataSetRequest{Key: "a", Value: half}, membership.ApplyBoth))
+	// both proposed while the cluster metadata was empty.
+	err := a.ClusterMetadataSet(&membershippb.ClusterMetadataSetRequest{Key: "b", Value: half}, membership.ApplyBoth)
+	require.ErrorIs(t, err, errors.ErrClusterMetadataTooLarge)
+	assert.Equal(t, map[string]string{"a": half}, cluster.ClusterMetadata())
+
+	// overwriting an entry only counts its new value.
+	require.NoError(t, a.ClusterMetadataSet(&membershippb.ClusterMetadataSetRequest{Key: "a", Value: half + "v"}, membership.ApplyBoth))
+
+	// replayed entries are already reflected by the cluster metadata recovered from the backend.
+	require.NoError(t, a.ClusterMetadataSet(&membershippb.ClusterMetadataSetRequest{Key: "b", Value: half}, membership.ApplyV2storeOnly))
+	require.NoError(t, a.ClusterMetadataSet(&membershippb.ClusterMetadataSetRequest{Key: "a", Delete: true}, membership.ApplyV2storeOnly))
+	assert.Equal(t, map[string]string{"a": half + "v"}, cluster.ClusterMetadata())
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
+)
+
+// valuePolicyApplierV3 fails the puts violating the value policies of their
+// keys.
+type valuePolicyApplierV3 struct {
+	applierV3
+	ps *v3valuepolicy.ValuePolicyStore
+}
+
+func newValuePolicyApplierV3(ps *v3valuepolicy.ValuePolicyStore, base applierV3) applierV3 {
+	return &valuePolicyApplierV3{applierV3: base, ps: ps}
+}
+
+func (a *valuePolicyApplierV3) Put(p *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
+	if err := a.ps.Check(p.Key, p.Value, p.IgnoreValue); err != nil {
+		return nil, nil, err
+	}
+	return a.applierV3.Put(p)
+}
+
+// Txn checks the puts of both branches of the transaction, like the
+// permission checks of auth, so the outcome does not depend on the compares.
+func (a *valuePolicyApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
+	if err := a.checkTxn(rt); err != nil {
+		return nil, nil, err
+	}
+	return a.applierV3.Txn(rt)
+}
+
+func (a *valuePolicyApplierV3) checkTxn(rt *pb.TxnRequest) error {
+	for _, reqs := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, requ := range reqs {
+			switch tv := requ.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				if tv.RequestPut == nil {
+					continue
+				}
+				if err := a.ps.Check(tv.RequestPut.Key, tv.RequestPut.Value, tv.RequestPut.IgnoreValue); err != nil {
+					return err
+				}
+			case *pb.RequestOp_RequestTxn:
+				if tv.RequestTxn == nil {
+					continue
+				}
+				if err := a.checkTxn(tv.RequestTxn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/txn"
 	"go.etcd.io/etcd/server/v3/lease"
@@ -38,8 +39,10 @@ type UberApplier interface {
 type uberApplier struct {
 	lg *zap.Logger
 
-	alarmStore           *v3alarm.AlarmStore
-	warningApplyDuration time.Duration
+	alarmStore *v3alarm.AlarmStore
+	// warningApplyDuration returns the current duration after which
+	// applying a request logs a warning.
+	warningApplyDuration func() time.Duration
 
 	// This is the applier that is taking in consideration current alarms
 	applyV3 applierV3
@@ -53,17 +56,18 @@ func NewUberApplier(
 	be backend.Backend,
 	kv mvcc.KV,
 	alarmStore *v3alarm.AlarmStore,
+	valuePolicyStore *v3valuepolicy.ValuePolicyStore,
 	authStore auth.AuthStore,
 	lessor lease.Lessor,
 	cluster *membership.RaftCluster,
 	raftStatus RaftStatusGetter,
 	snapshotServer SnapshotServer,
 	consistentIndex cindex.ConsistentIndexer,
-	warningApplyDuration time.Duration,
+	warningApplyDuration func() time.Duration,
 	txnModeWriteWithSharedBuffer bool,
 	quotaBackendBytesCfg int64,
 ) UberApplier {
-	applyV3base := newApplierV3(lg, be, kv, alarmStore, authStore, lessor, cluster, raftStatus, snapshotServer, consistentIndex, txnModeWriteWithSharedBuffer, quotaBackendBytesCfg)
+	applyV3base := newApplierV3(lg, be, kv, alarmStore, valuePolicyStore, authStore, lessor, cluster, raftStatus, snapshotServer, consistentIndex, txnModeWriteWithSharedBuffer, quotaBackendBytesCfg)
 
 	ua := &uberApplier{
 		lg:                   lg,
@@ -81,6 +85,7 @@ func newApplierV3(
 	be backend.Backend,
 	kv mvcc.KV,
 	alarmStore *v3alarm.AlarmStore,
+	valuePolicyStore *v3valuepolicy.ValuePolicyStore,
 	authStore auth.AuthStore,
 	lessor lease.Lessor,
 	cluster *membership.RaftCluster,
@@ -90,10 +95,10 @@ func newApplierV3(
 	txnModeWriteWithSharedBuffer bool,
 	quotaBackendBytesCfg int64,
 ) applierV3 {
-	applierBackend := newApplierV3Backend(lg, kv, alarmStore, authStore, lessor, cluster, raftStatus, snapshotServer, consistentIndex, txnModeWriteWithSharedBuffer)
+	applierBackend := newApplierV3Backend(lg, kv, alarmStore, valuePolicyStore, authStore, lessor, cluster, raftStatus, snapshotServer, consistentIndex, txnModeWriteWithSharedBuffer)
 	return newAuthApplierV3(
 		authStore,
-		newQuotaApplierV3(lg, quotaBackendBytesCfg, be, applierBackend),
+		newValuePolicyApplierV3(valuePolicyStore, newQuotaApplierV3(lg, quotaBackendBytesCfg, be, applierBackend)),
 		lessor,
 	)
 }
@@ -112,7 +117,7 @@ func (a *uberApplier) restoreAlarms() {
 
 func (a *uberApplier) Apply(r *pb.InternalRaftRequest) *Result {
 	// We first execute chain of Apply() calls down the hierarchy:
-	// (i.e. CorruptApplier -> CappedApplier -> Auth -> Quota -> Backend),
+	// (i.e. CorruptApplier -> CappedApplier -> Auth -> ValuePolicy -> Quota -> Backend),
 	// then dispatch() unpacks the request to a specific method (like Put),
 	// that gets executed down the hierarchy again:
 	// i.e. CorruptApplier.Put(CappedApplier.Put(...(BackendApplier.Put(...)))).
@@ -127,7 +132,7 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest) *Result {
 	defer func(start time.Time) {
 		success := ar.Err == nil || errors.Is(ar.Err, mvcc.ErrCompacted)
 		txn.ApplySecObserve(v3Version, op, success, time.Since(start))
-		txn.WarnOfExpensiveRequest(a.lg, a.warningApplyDuration, start, &pb.InternalRaftStringer{Request: r}, ar.Resp, ar.Err)
+		txn.WarnOfExpensiveRequest(a.lg, a.warningApplyDuration(), start, &pb.InternalRaftStringer{Request: r}, ar.Resp, ar.Err)
 		if !success {
 			txn.WarnOfFailedRequest(a.lg, start, &pb.InternalRaftStringer{Request: r}, ar.Resp, ar.Err)
 		}
@@ -161,6 +166,9 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest) *Result {
 	case r.Alarm != nil:
 		op = "Alarm"
 		ar.Resp, ar.Err = a.Alarm(r.Alarm)
+	case r.ValuePolicy != nil:
+		op = "ValuePolicy"
+		ar.Resp, ar.Err = a.applyV3.ValuePolicy(r.ValuePolicy)
 	case r.Authenticate != nil:
 		op = "Authenticate"
 		ar.Resp, ar.Err = a.applyV3.Authenticate(r.Authenticate)
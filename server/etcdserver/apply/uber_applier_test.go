@@ -27,6 +27,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/lease"
@@ -50,6 +51,8 @@ func defaultUberApplier(t *testing.T) UberApplier {
 	kv := mvcc.NewStore(lg, be, lessor, mvcc.StoreConfig{})
 	alarmStore, err := v3alarm.NewAlarmStore(lg, schema.NewAlarmBackend(lg, be))
 	require.NoError(t, err)
+	valuePolicyStore, err := v3valuepolicy.NewValuePolicyStore(lg, schema.NewValuePolicyBackend(lg, be))
+	require.NoError(t, err)
 
 	tp, err := auth.NewTokenProvider(lg, "simple", dummyIndexWaiter, 300*time.Second)
 	require.NoError(t, err)
@@ -65,13 +68,14 @@ func defaultUberApplier(t *testing.T) UberApplier {
 		be,
 		kv,
 		alarmStore,
+		valuePolicyStore,
 		authStore,
 		lessor,
 		cluster,
 		&fakeRaftStatusGetter{},
 		&fakeSnapshotServer{},
 		consistentIndex,
-		1*time.Hour,
+		func() time.Duration { return time.Hour },
 		false,
 		16*1024*1024, // 16MB
 	)
@@ -277,3 +281,67 @@ func TestUberApplier_Alarm_Deactivate(t *testing.T) {
 	require.NotNil(t, result)
 	assert.NoError(t, result.Err)
 }
+
+// TestUberApplier_ValuePolicy tests the applier fails the puts violating the value policy of their keys
+func TestUberApplier_ValuePolicy(t *testing.T) {
+	ua := defaultUberApplier(t)
+	result := ua.Apply(&pb.InternalRaftRequest{
+		Header: &pb.RequestHeader{},
+		ValuePolicy: &pb.ValuePolicyRequest{
+			Action: pb.ValuePolicyRequest_PUT,
+			Policy: &pb.ValuePolicy{
+				Prefix:       []byte("/config/"),
+				MaxValueSize: 16,
+				ContentType:  pb.ValuePolicy_JSON,
+				KeyPattern:   `^/config/[a-z]+$`,
+			},
+		},
+	})
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	tcs := []struct {
+		name        string
+		request     *pb.InternalRaftRequest
+		expectError error
+	}{
+		{
+			name:    "Put of a key without policy succeeds",
+			request: &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/other"), Value: []byte("{")}},
+		},
+		{
+			name:    "Put of a valid value succeeds",
+			request: &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/config/a"), Value: []byte(`{"a":1}`)}},
+		},
+		{
+			name:        "Put of an invalid JSON value fails",
+			request:     &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/config/a"), Value: []byte("{")}},
+			expectError: v3valuepolicy.ErrBadContent,
+		},
+		{
+			name:        "Put of a too large value fails",
+			request:     &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/config/a"), Value: []byte(`"0123456789abcdef"`)}},
+			expectError: v3valuepolicy.ErrValueTooLarge,
+		},
+		{
+			name:        "Put of a mismatching key fails",
+			request:     &pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("/config/A"), Value: []byte("1")}},
+			expectError: v3valuepolicy.ErrKeyMismatch,
+		},
+		{
+			name: "Txn with an invalid put in the failure branch fails",
+			request: &pb.InternalRaftRequest{Txn: &pb.TxnRequest{
+				Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/config/a"), Value: []byte("1")}}}},
+				Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/config/b"), Value: []byte("{")}}}},
+			}},
+			expectError: v3valuepolicy.ErrBadContent,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			result = ua.Apply(tc.request)
+			require.NotNil(t, result)
+			require.Equalf(t, tc.expectError, result.Err, "Apply: got %v, expect: %v", result.Err, tc.expectError)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/dustin/go-humanize"
+	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -42,6 +43,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	servererrors "go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/features"
 	serverstorage "go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -76,6 +78,12 @@ func bootstrap(cfg config.ServerConfig) (b *bootstrappedServer, err error) {
 	}
 
 	haveWAL := wal.Exist(cfg.WALDir())
+	if !haveWAL && !cfg.NewCluster && cfg.BootstrapFromPeer {
+		if err = bootstrapFromPeer(cfg, prt, ss); err != nil {
+			return nil, err
+		}
+		haveWAL = true
+	}
 	st := v2store.New(StoreClusterPrefix, StoreKeysPrefix)
 	backend, err := bootstrapBackend(cfg, haveWAL, st, ss)
 	if err != nil {
@@ -159,6 +167,10 @@ type bootstrappedCluster struct {
 type bootstrappedRaft struct {
 	lg        *zap.Logger
 	heartbeat time.Duration
+	clock     clockwork.Clock
+
+	groupCommitDelay   time.Duration
+	groupCommitEntries int
 
 	peers   []raft.Peer
 	config  *raft.Config
@@ -499,9 +511,13 @@ func bootstrapRaftFromCluster(cfg config.ServerConfig, cl *membership.RaftCluste
 	return &bootstrappedRaft{
 		lg:        cfg.Logger,
 		heartbeat: time.Duration(cfg.TickMs) * time.Millisecond,
+		clock:     cfg.Clock,
 		config:    raftConfig(cfg, uint64(member.ID), s),
 		peers:     peers,
 		storage:   s,
+
+		groupCommitDelay:   cfg.WALGroupCommitDelay,
+		groupCommitEntries: cfg.WALGroupCommitEntries,
 	}
 }
 
@@ -510,12 +526,20 @@ func bootstrapRaftFromWAL(cfg config.ServerConfig, bwal *bootstrappedWAL) *boots
 	return &bootstrappedRaft{
 		lg:        cfg.Logger,
 		heartbeat: time.Duration(cfg.TickMs) * time.Millisecond,
+		clock:     cfg.Clock,
 		config:    raftConfig(cfg, uint64(bwal.meta.nodeID), s),
 		storage:   s,
+
+		groupCommitDelay:   cfg.WALGroupCommitDelay,
+		groupCommitEntries: cfg.WALGroupCommitEntries,
 	}
 }
 
 func raftConfig(cfg config.ServerConfig, id uint64, s *raft.MemoryStorage) *raft.Config {
+	readOnlyOption := raft.ReadOnlySafe
+	if cfg.ServerFeatureGate.Enabled(features.LeaseBasedReads) {
+		readOnlyOption = raft.ReadOnlyLeaseBased
+	}
 	return &raft.Config{
 		ID:              id,
 		ElectionTick:    cfg.ElectionTicks,
@@ -525,7 +549,8 @@ func raftConfig(cfg config.ServerConfig, id uint64, s *raft.MemoryStorage) *raft
 		MaxInflightMsgs: maxInflightMsgs,
 		CheckQuorum:     true,
 		PreVote:         cfg.PreVote,
-		Logger:          NewRaftLoggerZap(cfg.Logger.Named("raft")),
+		ReadOnlyOption:  readOnlyOption,
+		Logger:          NewRaftLoggerZap(subsystemLogger(cfg, LogSubsystemRaft).Named("raft")),
 	}
 }
 
@@ -545,8 +570,12 @@ func (b *bootstrappedRaft) newRaftNode(ss *snap.Snapshotter, wal *wal.WAL, cl *m
 			isIDRemoved: func(id uint64) bool { return cl.IsIDRemoved(types.ID(id)) },
 			Node:        n,
 			heartbeat:   b.heartbeat,
+			clock:       b.clock,
 			raftStorage: b.storage,
 			storage:     serverstorage.NewStorage(b.lg, wal, ss),
+
+			groupCommitMaxDelay:   b.groupCommitDelay,
+			groupCommitMinEntries: b.groupCommitEntries,
 		},
 	)
 }
@@ -0,0 +1,633 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/pkg/v3/pbutil"
+	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+	"go.etcd.io/raft/v3"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+const (
+	// PeerBootstrapSnapshotPath serves the snapshots members joining the
+	// cluster bootstrap from. A POST creates a snapshot, whose backend is then
+	// fetched, in ranges if resumed, with a GET of the path followed by the ID
+	// of the snapshot, and released with a DELETE of the same path.
+	PeerBootstrapSnapshotPath = "/members/bootstrap/snapshot"
+
+	// bootstrapSnapshotTTL is how long a bootstrap snapshot is kept once it
+	// is not fetched anymore.
+	bootstrapSnapshotTTL = 5 * time.Minute
+	// maxBootstrapSnapshots is the maximum number of bootstrap snapshots a
+	// member keeps at once.
+	maxBootstrapSnapshots = 2
+	// bootstrapSnapshotInterval is the minimum interval between creating
+	// bootstrap snapshots beyond maxBootstrapSnapshots in a row, as each one
+	// writes the whole backend.
+	bootstrapSnapshotInterval = 30 * time.Second
+
+	// bootstrapSnapshotRetries is the number of times fetching the backend
+	// of a bootstrap snapshot is resumed after it fails.
+	bootstrapSnapshotRetries       = 5
+	bootstrapSnapshotRetryInterval = time.Second
+)
+
+var errBootstrapSnapshotBehind = errors.New("raft log is behind the backend, retry later")
+
+// peerSnapshot is a snapshot a member joining the cluster bootstraps
+// from. It holds the backend of the serving member, fetched separately, along
+// with the last raft snapshot of the member and the committed entries after
+// it, which cover the backend. The joining member starts from them as a
+// member restarting from its own snapshot and WAL would.
+type peerSnapshot struct {
+	ID string `json:"id"`
+	// Size and SHA256 are the size and the hex encoded sha256 of the backend.
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+
+	Snapshot  raftpb.Snapshot  `json:"snapshot"`
+	Entries   []raftpb.Entry   `json:"entries"`
+	HardState raftpb.HardState `json:"hard-state"`
+}
+
+// createBootstrapSnapshot writes the backend to a temporary file in the
+// snapshot directory, and returns the path of the file with the bootstrap
+// snapshot describing it.
+func (s *EtcdServer) createBootstrapSnapshot() (*peerSnapshot, string, error) {
+	// the raft snapshot is taken first, so that the backend holds at least
+	// its index.
+	rs, err := s.r.raftStorage.Snapshot()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Snapshotter.cleanupSnapdir cleans up orphaned "db.tmp" files during startup.
+	f, err := os.CreateTemp(s.Cfg.SnapDir(), "db.tmp.bootstrap.*")
+	if err != nil {
+		return nil, "", err
+	}
+	path := f.Name()
+	h := sha256.New()
+	dbsnap := s.be.Snapshot()
+	size, err := dbsnap.WriteTo(io.MultiWriter(f, h))
+	if cerr := dbsnap.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, "", err
+	}
+
+	// the entries up to the consistent index of the backend are needed, so
+	// the joining member does not fall behind its own backend.
+	ci := s.consistIndex.ConsistentIndex()
+	last, err := s.r.raftStorage.LastIndex()
+	if err != nil {
+		os.Remove(path)
+		return nil, "", err
+	}
+	last = min(last, s.getCommittedIndex())
+	if last < ci {
+		os.Remove(path)
+		return nil, "", errBootstrapSnapshotBehind
+	}
+	bs := &peerSnapshot{
+		Size:      size,
+		SHA256:    hex.EncodeToString(h.Sum(nil)),
+		Snapshot:  rs,
+		HardState: raftpb.HardState{Term: rs.Metadata.Term, Commit: last},
+	}
+	if last > rs.Metadata.Index {
+		bs.Entries, err = s.r.raftStorage.Entries(rs.Metadata.Index+1, last+1, math.MaxUint64)
+		if err != nil {
+			// raft compacted the log past the snapshot taken above
+			os.Remove(path)
+			return nil, "", fmt.Errorf("%w, retry later", err)
+		}
+		bs.HardState.Term = bs.Entries[len(bs.Entries)-1].Term
+	}
+	bs.ID = strings.TrimPrefix(filepath.Base(path), "db.tmp.bootstrap.")
+	return bs, path, nil
+}
+
+type bootstrapSnapshotHandler struct {
+	lg      *zap.Logger
+	cluster *membership.RaftCluster
+	// createSnapshot creates a bootstrap snapshot, returning the path of its
+	// backend.
+	createSnapshot func() (*peerSnapshot, string, error)
+	limiter        *rate.Limiter
+
+	// createMu serializes creating bootstrap snapshots.
+	createMu sync.Mutex
+	mu       sync.Mutex
+	// snapshots holds the backend files of the bootstrap snapshots by ID.
+	snapshots map[string]*bootstrapSnapshotFile
+}
+
+type bootstrapSnapshotFile struct {
+	// member is the member the snapshot was created for.
+	member   types.ID
+	snapshot *peerSnapshot
+	path     string
+	expire   *time.Timer
+}
+
+func (s *EtcdServer) BootstrapSnapshotHandler() http.Handler {
+	return newBootstrapSnapshotHandler(s.Logger(), s.cluster, s.createBootstrapSnapshot)
+}
+
+func newBootstrapSnapshotHandler(lg *zap.Logger, cl *membership.RaftCluster, createSnapshot func() (*peerSnapshot, string, error)) *bootstrapSnapshotHandler {
+	return &bootstrapSnapshotHandler{
+		lg:             lg,
+		cluster:        cl,
+		createSnapshot: createSnapshot,
+		limiter:        rate.NewLimiter(rate.Every(bootstrapSnapshotInterval), maxBootstrapSnapshots),
+		snapshots:      make(map[string]*bootstrapSnapshotFile),
+	}
+}
+
+func (h *bootstrapSnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if gcid := r.Header.Get("X-Etcd-Cluster-ID"); gcid != h.cluster.ID().String() {
+		http.Error(w, rafthttp.ErrClusterIDMismatch.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	w.Header().Set("X-Etcd-Cluster-ID", h.cluster.ID().String())
+
+	// only a member added to the cluster that has not started yet bootstraps
+	// from a snapshot.
+	from, err := types.IDFromString(r.Header.Get("X-Server-From"))
+	if err != nil {
+		http.Error(w, "invalid X-Server-From header", http.StatusBadRequest)
+		return
+	}
+	if m := h.cluster.Member(from); m == nil || m.IsStarted() {
+		http.Error(w, fmt.Sprintf("member %s is not a member waiting to start", from), http.StatusForbidden)
+		return
+	}
+
+	if r.URL.Path == PeerBootstrapSnapshotPath {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.create(w, from)
+		return
+	}
+	id, ok := strings.CutPrefix(r.URL.Path, PeerBootstrapSnapshotPath+"/")
+	if !ok {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.serve(w, r, from, id)
+	case http.MethodDelete:
+		h.remove(from, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodDelete)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// create creates a bootstrap snapshot for the member, or returns the one
+// created for it before, which it has not released yet.
+func (h *bootstrapSnapshotHandler) create(w http.ResponseWriter, member types.ID) {
+	h.createMu.Lock()
+	defer h.createMu.Unlock()
+
+	h.mu.Lock()
+	var bs *peerSnapshot
+	for _, sf := range h.snapshots {
+		if sf.member == member {
+			bs = sf.snapshot
+		}
+	}
+	n := len(h.snapshots)
+	h.mu.Unlock()
+
+	if bs == nil {
+		if n >= maxBootstrapSnapshots {
+			http.Error(w, "too many bootstrap snapshots in progress", http.StatusServiceUnavailable)
+			return
+		}
+		if !h.limiter.Allow() {
+			w.Header().Set("Retry-After", strconv.Itoa(int(bootstrapSnapshotInterval.Seconds())))
+			http.Error(w, "too many bootstrap snapshots created recently", http.StatusTooManyRequests)
+			return
+		}
+		var path string
+		var err error
+		bs, path, err = h.createSnapshot()
+		if err != nil {
+			h.lg.Warn("failed to create bootstrap snapshot", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		h.mu.Lock()
+		h.snapshots[bs.ID] = &bootstrapSnapshotFile{
+			member:   member,
+			snapshot: bs,
+			path:     path,
+			expire:   time.AfterFunc(bootstrapSnapshotTTL, func() { h.remove(member, bs.ID) }),
+		}
+		h.mu.Unlock()
+		h.lg.Info(
+			"created bootstrap snapshot",
+			zap.String("id", bs.ID),
+			zap.String("member-id", member.String()),
+			zap.String("path", path),
+			zap.String("size", humanize.Bytes(uint64(bs.Size))),
+			zap.Uint64("snapshot-index", bs.Snapshot.Metadata.Index),
+			zap.Uint64("commit-index", bs.HardState.Commit),
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bs); err != nil {
+		h.lg.Warn("failed to encode bootstrap snapshot", zap.Error(err))
+	}
+}
+
+// serve serves the backend of the bootstrap snapshot, honoring the range of
+// the request so that fetching the backend can be resumed.
+func (h *bootstrapSnapshotHandler) serve(w http.ResponseWriter, r *http.Request, member types.ID, id string) {
+	h.mu.Lock()
+	sf, ok := h.snapshots[id]
+	ok = ok && sf.member == member
+	if ok {
+		sf.expire.Stop()
+	}
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("bootstrap snapshot %s not found", id), http.StatusNotFound)
+		return
+	}
+	// the snapshot expires once it is not fetched anymore
+	defer sf.expire.Reset(bootstrapSnapshotTTL)
+
+	f, err := os.Open(sf.path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
+
+func (h *bootstrapSnapshotHandler) remove(member types.ID, id string) {
+	h.mu.Lock()
+	sf, ok := h.snapshots[id]
+	ok = ok && sf.member == member
+	if ok {
+		delete(h.snapshots, id)
+	}
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+	sf.expire.Stop()
+	if err := os.Remove(sf.path); err != nil {
+		h.lg.Warn("failed to remove bootstrap snapshot", zap.String("path", sf.path), zap.Error(err))
+		return
+	}
+	h.lg.Info("removed bootstrap snapshot", zap.String("id", id))
+}
+
+// bootstrapFromPeer bootstraps a member joining an existing cluster, which
+// has no WAL yet, from a bootstrap snapshot of a voting member. It writes the
+// backend, the raft snapshot and a WAL holding the committed entries after
+// it, so the member then starts as if it restarted from them.
+func bootstrapFromPeer(cfg config.ServerConfig, prt http.RoundTripper, ss *snap.Snapshotter) error {
+	lg := cfg.Logger
+	cl, err := bootstrapExistingClusterNoWAL(cfg, prt)
+	if err != nil {
+		return err
+	}
+	// unlike the round tripper of the peers, the transport does not time out
+	// reads, as creating and fetching snapshots can take a while.
+	tr, err := transport.NewTransport(cfg.PeerTLSInfo, cfg.PeerDialTimeout())
+	if err != nil {
+		return err
+	}
+	defer tr.CloseIdleConnections()
+	cc := &http.Client{Transport: &bootstrapRoundTripper{rt: tr, cid: cl.cl.ID(), id: cl.nodeID}}
+
+	var errs []error
+	for _, m := range cl.remotes {
+		if m.ID == cl.nodeID || m.IsLearner {
+			continue
+		}
+		for _, u := range m.PeerURLs {
+			lg.Info("bootstrapping from peer", zap.String("remote-peer-id", m.ID.String()), zap.String("remote-peer-url", u))
+			err = fetchBootstrapSnapshot(cfg, cc, u, cl.cl.ID(), cl.nodeID, ss)
+			if err == nil {
+				return nil
+			}
+			lg.Warn("failed to bootstrap from peer", zap.String("remote-peer-url", u), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", u, err))
+		}
+	}
+	return fmt.Errorf("cannot bootstrap from peers: %w", errors.Join(errs...))
+}
+
+func fetchBootstrapSnapshot(cfg config.ServerConfig, cc *http.Client, peerURL string, cid, id types.ID, ss *snap.Snapshotter) error {
+	lg := cfg.Logger
+	ctx := context.Background()
+	bs, err := requestBootstrapSnapshot(ctx, cc, peerURL)
+	if err != nil {
+		return err
+	}
+	snapURL := peerURL + PeerBootstrapSnapshotPath + "/" + bs.ID
+	defer func() {
+		req, rerr := http.NewRequestWithContext(ctx, http.MethodDelete, snapURL, nil)
+		if rerr == nil {
+			var resp *http.Response
+			if resp, rerr = cc.Do(req); rerr == nil {
+				resp.Body.Close()
+			}
+		}
+		if rerr != nil {
+			lg.Warn("failed to release bootstrap snapshot", zap.String("url", snapURL), zap.Error(rerr))
+		}
+	}()
+	if err = verifyBootstrapSnapshot(bs); err != nil {
+		return err
+	}
+
+	// Snapshotter.cleanupSnapdir cleans up orphaned "db.tmp" files during startup.
+	f, err := os.CreateTemp(cfg.SnapDir(), "db.tmp.bootstrap.*")
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	h := sha256.New()
+	start := time.Now()
+	err = downloadBootstrapSnapshot(ctx, lg, cc, snapURL, f, h, cfg.BootstrapFromPeerBytesPerSecond)
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != bs.SHA256 {
+		return fmt.Errorf("sha256 mismatch of bootstrap snapshot (expected %s, got %s)", bs.SHA256, sum)
+	}
+	if err = os.Rename(path, cfg.BackendPath()); err != nil {
+		return err
+	}
+	lg.Info(
+		"fetched backend of bootstrap snapshot",
+		zap.String("path", cfg.BackendPath()),
+		zap.String("size", humanize.Bytes(uint64(bs.Size))),
+		zap.Duration("took", time.Since(start)),
+	)
+
+	if !raft.IsEmptySnap(bs.Snapshot) {
+		if err = ss.SaveSnap(bs.Snapshot); err != nil {
+			return err
+		}
+	}
+	if err = createBootstrapWAL(cfg, bs, cid, id); err != nil {
+		return err
+	}
+	lg.Info(
+		"bootstrapped from peer",
+		zap.String("remote-peer-url", peerURL),
+		zap.Uint64("snapshot-index", bs.Snapshot.Metadata.Index),
+		zap.Uint64("commit-index", bs.HardState.Commit),
+	)
+	return nil
+}
+
+func requestBootstrapSnapshot(ctx context.Context, cc *http.Client, peerURL string) (*peerSnapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peerURL+PeerBootstrapSnapshotPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create bootstrap snapshot: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	var bs peerSnapshot
+	if err = json.NewDecoder(resp.Body).Decode(&bs); err != nil {
+		return nil, fmt.Errorf("failed to decode bootstrap snapshot: %w", err)
+	}
+	return &bs, nil
+}
+
+// bootstrapRoundTripper identifies the cluster and the member bootstrapping
+// from a peer in its requests.
+type bootstrapRoundTripper struct {
+	rt      http.RoundTripper
+	cid, id types.ID
+}
+
+func (rt *bootstrapRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Etcd-Cluster-ID", rt.cid.String())
+	req.Header.Set("X-Server-From", rt.id.String())
+	return rt.rt.RoundTrip(req)
+}
+
+// verifyBootstrapSnapshot checks that the entries of the bootstrap snapshot
+// follow its raft snapshot up to the commit index.
+func verifyBootstrapSnapshot(bs *peerSnapshot) error {
+	next := bs.Snapshot.Metadata.Index + 1
+	for _, e := range bs.Entries {
+		if e.Index != next {
+			return fmt.Errorf("bootstrap snapshot entry index %d does not follow index %d", e.Index, next-1)
+		}
+		next++
+	}
+	if next-1 != bs.HardState.Commit {
+		return fmt.Errorf("bootstrap snapshot entries end at index %d, expected commit index %d", next-1, bs.HardState.Commit)
+	}
+	return nil
+}
+
+// downloadBootstrapSnapshot fetches the backend of a bootstrap snapshot into
+// w, at most bytesPerSecond bytes per second if not zero. A failed fetch is
+// resumed from the bytes already written, up to bootstrapSnapshotRetries
+// times.
+func downloadBootstrapSnapshot(ctx context.Context, lg *zap.Logger, cc *http.Client, url string, w io.Writer, h hash.Hash, bytesPerSecond uint) error {
+	var limiter *rate.Limiter
+	if bytesPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+	}
+	w = io.MultiWriter(w, h)
+	var offset int64
+	for retries := 0; ; retries++ {
+		n, err := downloadBootstrapSnapshotRange(ctx, cc, url, w, offset, limiter)
+		offset += n
+		if err == nil {
+			return nil
+		}
+		if retries >= bootstrapSnapshotRetries {
+			return err
+		}
+		lg.Warn("failed to fetch bootstrap snapshot, resuming", zap.Int64("offset", offset), zap.Error(err))
+		select {
+		case <-time.After(bootstrapSnapshotRetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func downloadBootstrapSnapshotRange(ctx context.Context, cc *http.Client, url string, w io.Writer, offset int64, limiter *rate.Limiter) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	want := http.StatusOK
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		want = http.StatusPartialContent
+	}
+	resp, err := cc.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != want {
+		b, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("failed to fetch bootstrap snapshot: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	var r io.Reader = resp.Body
+	if limiter != nil {
+		r = &rateLimitedReader{ctx: ctx, r: r, limiter: limiter}
+	}
+	return io.Copy(w, r)
+}
+
+// rateLimitedReader limits the rate at which bytes are read from r.
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (rr *rateLimitedReader) Read(p []byte) (int, error) {
+	// a read may not be larger than the burst of the limiter.
+	if burst := rr.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := rr.r.Read(p)
+	if n > 0 {
+		if werr := rr.limiter.WaitN(rr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// createBootstrapWAL creates the WAL of the member from the bootstrap
+// snapshot. The WAL is written to a temporary directory renamed once
+// complete, so that the member bootstraps again if it crashes before.
+func createBootstrapWAL(cfg config.ServerConfig, bs *peerSnapshot, cid, id types.ID) error {
+	metadata := pbutil.MustMarshal(
+		&etcdserverpb.Metadata{
+			NodeID:    uint64(id),
+			ClusterID: uint64(cid),
+		},
+	)
+	tmpdir := filepath.Clean(cfg.WALDir()) + ".bootstrap"
+	if err := os.RemoveAll(tmpdir); err != nil {
+		return err
+	}
+	w, err := wal.Create(cfg.Logger, tmpdir, metadata)
+	if err != nil {
+		return err
+	}
+	walsnap := walpb.Snapshot{
+		Index:     bs.Snapshot.Metadata.Index,
+		Term:      bs.Snapshot.Metadata.Term,
+		ConfState: &bs.Snapshot.Metadata.ConfState,
+	}
+	err = w.SaveSnapshot(walsnap)
+	if err == nil {
+		err = w.Save(bs.HardState, bs.Entries)
+	}
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.RemoveAll(tmpdir)
+		return err
+	}
+	// the WAL directory may exist without WAL files
+	if err = os.Remove(cfg.WALDir()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err = os.Rename(tmpdir, cfg.WALDir()); err != nil {
+		return err
+	}
+	df, err := fileutil.OpenDir(filepath.Dir(cfg.WALDir()))
+	if err != nil {
+		return err
+	}
+	defer df.Close()
+	return fileutil.Fsync(df)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestDownloadBootstrapSnapshotResumes(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// fail the first request half way
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.Write(data[:len(data)/2])
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	h := sha256.New()
+	err := downloadBootstrapSnapshot(context.Background(), zaptest.NewLogger(t), srv.Client(), srv.URL, &buf, h, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, data, buf.Bytes())
+	sum := sha256.Sum256(data)
+	assert.Equal(t, sum[:], h.Sum(nil))
+}
+
+func TestDownloadBootstrapSnapshotRateLimit(t *testing.T) {
+	data := make([]byte, 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	start := time.Now()
+	// the burst covers the first 32KiB, the rest takes a second
+	err := downloadBootstrapSnapshot(context.Background(), zaptest.NewLogger(t), srv.Client(), srv.URL, &buf, sha256.New(), 32*1024)
+	require.NoError(t, err)
+	assert.Len(t, buf.Bytes(), len(data))
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestVerifyBootstrapSnapshot(t *testing.T) {
+	snap := raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 10, Term: 2}}
+	tcs := []struct {
+		name    string
+		entries []raftpb.Entry
+		commit  uint64
+		wantErr bool
+	}{
+		{name: "NoEntries", commit: 10},
+		{name: "Entries", entries: []raftpb.Entry{{Index: 11}, {Index: 12}}, commit: 12},
+		{name: "Gap", entries: []raftpb.Entry{{Index: 11}, {Index: 13}}, commit: 13, wantErr: true},
+		{name: "NotAfterSnapshot", entries: []raftpb.Entry{{Index: 10}, {Index: 11}}, commit: 11, wantErr: true},
+		{name: "CommitMismatch", entries: []raftpb.Entry{{Index: 11}}, commit: 12, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyBootstrapSnapshot(&peerSnapshot{Snapshot: snap, Entries: tc.entries, HardState: raftpb.HardState{Commit: tc.commit}})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBootstrapSnapshotHandler(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	started := &membership.Member{ID: 1, Attributes: membership.Attributes{Name: "started"}}
+	cl := membership.NewClusterFromMembers(lg, types.ID(100), []*membership.Member{
+		started, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5},
+	})
+	var created int
+	h := newBootstrapSnapshotHandler(lg, cl, func() (*peerSnapshot, string, error) {
+		created++
+		path := filepath.Join(t.TempDir(), "db")
+		require.NoError(t, os.WriteFile(path, []byte("backend"), 0o600))
+		return &peerSnapshot{ID: fmt.Sprint(created), Size: 7}, path, nil
+	})
+	do := func(method, path string, cid string, from types.ID) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if cid != "" {
+			req.Header.Set("X-Etcd-Cluster-ID", cid)
+		}
+		if from != 0 {
+			req.Header.Set("X-Server-From", from.String())
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	cid := types.ID(100).String()
+
+	assert.Equal(t, http.StatusPreconditionFailed, do(http.MethodPost, PeerBootstrapSnapshotPath, "", 2).Code, "missing cluster ID")
+	assert.Equal(t, http.StatusPreconditionFailed, do(http.MethodPost, PeerBootstrapSnapshotPath, types.ID(101).String(), 2).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 0).Code, "missing member ID")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 1).Code, "started member")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 6).Code, "unknown member")
+
+	rec := do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 2)
+	require.Equal(t, http.StatusOK, rec.Code)
+	// a member requesting a snapshot again gets the one created for it.
+	require.Equal(t, http.StatusOK, do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 2).Code)
+	assert.Equal(t, 1, created)
+
+	// the snapshot is only served to the member it was created for.
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, PeerBootstrapSnapshotPath+"/1", cid, 3).Code)
+	do(http.MethodDelete, PeerBootstrapSnapshotPath+"/1", cid, 3)
+	rec = do(http.MethodGet, PeerBootstrapSnapshotPath+"/1", cid, 2)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "backend", rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, PeerBootstrapSnapshotPath+"/1", cid, 2).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, PeerBootstrapSnapshotPath+"/1", cid, 2).Code)
+
+	// creating snapshots is rate limited.
+	require.Equal(t, http.StatusOK, do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 3).Code)
+	h.remove(3, "2")
+	rec = do(http.MethodPost, PeerBootstrapSnapshotPath, cid, 4)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	assert.Equal(t, 2, created)
+}
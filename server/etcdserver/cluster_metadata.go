@@ -0,0 +1,64 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/membershippb"
+	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// MaxClusterMetadataBytes is the maximum total size of the keys and values of
+// the cluster metadata.
+const MaxClusterMetadataBytes = apply.MaxClusterMetadataBytes
+
+// ClusterMetadata returns the cluster metadata, the operational annotations
+// of the cluster, as applied by the local member.
+func (s *EtcdServer) ClusterMetadata() map[string]string {
+	return s.cluster.ClusterMetadata()
+}
+
+// PutClusterMetadata sets the entry of the key of the cluster metadata. Like
+// membership changes, it requires the root role when auth is enabled.
+func (s *EtcdServer) PutClusterMetadata(ctx context.Context, key, value string) error {
+	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+		return err
+	}
+	if key == "" {
+		return errors.ErrClusterMetadataKeyEmpty
+	}
+	// fail fast, the limit is enforced when applied as well.
+	if s.cluster.ClusterMetadataSizeWith(key, value) > MaxClusterMetadataBytes {
+		return errors.ErrClusterMetadataTooLarge
+	}
+	_, err := s.raftRequest(ctx, pb.InternalRaftRequest{ClusterMetadataSet: &membershippb.ClusterMetadataSetRequest{Key: key, Value: value}})
+	return err
+}
+
+// DeleteClusterMetadata deletes the entry of the key of the cluster metadata,
+// if any.
+func (s *EtcdServer) DeleteClusterMetadata(ctx context.Context, key string) error {
+	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+		return err
+	}
+	if key == "" {
+		return errors.ErrClusterMetadataKeyEmpty
+	}
+	_, err := s.raftRequest(ctx, pb.InternalRaftRequest{ClusterMetadataSet: &membershippb.ClusterMetadataSetRequest{Key: key, Delete: true}})
+	return err
+}
@@ -38,10 +38,17 @@ var (
 	ErrTooManyRequests             = errors.New("etcdserver: too many requests")
 	ErrUnhealthy                   = errors.New("etcdserver: unhealthy cluster")
 	ErrCorrupt                     = errors.New("etcdserver: corrupt cluster")
+	ErrInvalidRuntimeConfig        = errors.New("etcdserver: invalid runtime config")
+	ErrInvalidLogLevel             = errors.New("etcdserver: invalid log level")
 	ErrBadLeaderTransferee         = errors.New("etcdserver: bad leader transferee")
 	ErrClusterVersionUnavailable   = errors.New("etcdserver: cluster version not found during downgrade")
 	ErrWrongDowngradeVersionFormat = errors.New("etcdserver: wrong downgrade target version format")
 	ErrKeyNotFound                 = errors.New("etcdserver: key not found")
+	ErrMemberIsReadReplica         = errors.New("etcdserver: can not promote a read replica member")
+	ErrReadReplicaTooStale         = errors.New("etcdserver: read replica exceeds max staleness")
+	ErrClusterMetadataKeyEmpty     = errors.New("etcdserver: cluster metadata key is not provided")
+	ErrClusterMetadataTooLarge     = errors.New("etcdserver: cluster metadata exceeds the size limit")
+	ErrSnapshotRevisionApplied     = errors.New("etcdserver: snapshot revision is already applied")
 )
 
 type DiscoveryError struct {
@@ -0,0 +1,47 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceExemplar returns the exemplar linking a metric observed while
+// serving the request of ctx to the trace of the request, or nil if the
+// request is not sampled.
+func TraceExemplar(ctx context.Context) prometheus.Labels {
+	return spanContextExemplar(trace.SpanContextFromContext(ctx))
+}
+
+func spanContextExemplar(sc trace.SpanContext) prometheus.Labels {
+	if !sc.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{"trace_id": sc.TraceID().String()}
+}
+
+// linkCommitToTrace links the backend commit of the request with the given ID
+// to the trace of the request, if the member proposed the request and it is
+// sampled.
+func (s *EtcdServer) linkCommitToTrace(id uint64) {
+	v, ok := s.applyTraces.Load(id)
+	if !ok {
+		return
+	}
+	s.Backend().BatchTx().SetCommitExemplar(spanContextExemplar(v.(trace.SpanContext)))
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"slices"
+	"strings"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/featuregate"
+	"go.etcd.io/etcd/server/v3/features"
+)
+
+// FeatureGates returns the feature gates of the member, sorted by name.
+func (s *EtcdServer) FeatureGates() []*pb.FeatureGate {
+	fg, ok := s.Cfg.ServerFeatureGate.(featuregate.MutableFeatureGate)
+	if !ok {
+		return nil
+	}
+	flags := make(map[featuregate.Feature][]string)
+	for flag, f := range features.ExperimentalFlagToFeatureMap {
+		flags[f] = append(flags[f], flag)
+	}
+
+	var gates []*pb.FeatureGate
+	for f, spec := range fg.GetAll() {
+		gate := &pb.FeatureGate{
+			Name:              string(f),
+			Stage:             featureGateStage(spec),
+			Enabled:           fg.Enabled(f),
+			DefaultEnabled:    spec.Default,
+			Locked:            spec.LockToDefault,
+			Runtime:           spec.Runtime,
+			ExperimentalFlags: flags[f],
+		}
+		slices.Sort(gate.ExperimentalFlags)
+		gates = append(gates, gate)
+	}
+	slices.SortFunc(gates, func(a, b *pb.FeatureGate) int { return strings.Compare(a.Name, b.Name) })
+	return gates
+}
+
+func featureGateStage(spec featuregate.FeatureSpec) pb.FeatureGate_Stage {
+	switch spec.PreRelease {
+	case featuregate.Alpha:
+		return pb.FeatureGate_ALPHA
+	case featuregate.Beta:
+		return pb.FeatureGate_BETA
+	case featuregate.Deprecated:
+		return pb.FeatureGate_DEPRECATED
+	default:
+		return pb.FeatureGate_GA
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// followerLease tracks the follower lease granted to the local member by the
+// leader. The leader grants a lease from a heartbeat round the follower
+// acknowledged, and assumes it is held from the time it granted it. The
+// follower holds the lease from the earlier time it sent its acknowledgement,
+// so that it never holds the lease longer than the leader assumes.
+type followerLease struct {
+	mu sync.Mutex
+	// acked maps the contexts of the recent heartbeat rounds acknowledged by
+	// the local member to the time it sent the acknowledgement.
+	acked map[string]time.Time
+	// lead is the leader that granted the lease held until expiry.
+	lead   types.ID
+	expiry time.Time
+}
+
+// observeAcksSent records the time the local member acknowledges the
+// heartbeat rounds among the messages it is about to send. Rounds
+// acknowledged longer than the lease duration ago are forgotten, as a lease
+// granted from them has already expired.
+func (l *followerLease) observeAcksSent(ms []raftpb.Message, now time.Time, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range ms {
+		if ms[i].Type != raftpb.MsgHeartbeatResp {
+			continue
+		}
+		round, _, ok := parseLeaderLeaseContext(ms[i].Context)
+		if !ok {
+			continue
+		}
+		if l.acked == nil {
+			l.acked = make(map[string]time.Time)
+		}
+		// the lease is held from the first acknowledgement of a round, in
+		// case the leader granted it upon receiving that one.
+		ctx := string(leaderLeaseContext(round))
+		if _, ok := l.acked[ctx]; !ok {
+			l.acked[ctx] = now
+		}
+	}
+	for c, t := range l.acked {
+		if now.Sub(t) >= duration {
+			delete(l.acked, c)
+		}
+	}
+}
+
+// observeGrant records the lease granted by the given leader along with a
+// heartbeat carrying the given context, if any.
+func (l *followerLease) observeGrant(lead types.ID, ctx []byte, duration time.Duration) {
+	_, granted, ok := parseLeaderLeaseContext(ctx)
+	if !ok || granted == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.acked[string(leaderLeaseContext(granted))]
+	if !ok {
+		return
+	}
+	if expiry := t.Add(duration); lead != l.lead || expiry.After(l.expiry) {
+		l.lead, l.expiry = lead, expiry
+	}
+}
+
+// valid returns true if the local member holds a lease granted by the given
+// leader.
+func (l *followerLease) valid(now time.Time, lead types.ID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return lead == l.lead && now.Before(l.expiry)
+}
+
+// followerLeaseReadIndex returns the index a linearizable read of a follower
+// holding a lease waits to apply: its last log index, which is not before any
+// entry exposed to clients. It returns false if the read index has to be
+// requested from the leader.
+func (s *EtcdServer) followerLeaseReadIndex() (uint64, bool) {
+	if !s.FeatureEnabled(features.LeaseBasedReads) || s.isLeader() {
+		return 0, false
+	}
+	if !s.followerLease.valid(time.Now(), types.ID(s.getLead())) {
+		return 0, false
+	}
+	index, err := s.r.raftStorage.LastIndex()
+	if err != nil {
+		return 0, false
+	}
+	followerLeaseReads.Inc()
+	return index, true
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// groupCommit holds back the raft Ready that follows a leader save of
+// entries until enough entries have been proposed meanwhile or the group
+// commit delay expires, so that they are saved to the WAL with one fsync.
+// It is only accessed by the raft loop, except for propose.
+type groupCommit struct {
+	maxDelay   time.Duration
+	minEntries uint64
+
+	proposed atomic.Uint64
+	// proposedc wakes up the raft loop waiting on the group commit.
+	proposedc chan struct{}
+
+	// base is the number of proposals at the last leader save of entries
+	// at savedAt, zero if the next Ready is not held back.
+	base    uint64
+	savedAt time.Time
+}
+
+func newGroupCommit(maxDelay time.Duration, minEntries int) *groupCommit {
+	if maxDelay <= 0 {
+		return nil
+	}
+	return &groupCommit{
+		maxDelay:   maxDelay,
+		minEntries: uint64(minEntries),
+		proposedc:  make(chan struct{}, 1),
+	}
+}
+
+// propose records a proposal to the raft node.
+func (g *groupCommit) propose() {
+	if g == nil {
+		return
+	}
+	g.proposed.Add(1)
+	select {
+	case g.proposedc <- struct{}{}:
+	default:
+	}
+}
+
+// saved records the save of n entries, only holding back the next Ready
+// if the save was by the leader.
+func (g *groupCommit) saved(islead bool, n int, now time.Time) {
+	if g == nil {
+		return
+	}
+	if !islead || n == 0 {
+		g.savedAt = time.Time{}
+		return
+	}
+	g.base = g.proposed.Load()
+	g.savedAt = now
+}
+
+// wait returns how long the next Ready is still to be held back.
+func (g *groupCommit) wait(now time.Time) time.Duration {
+	if g == nil || g.savedAt.IsZero() {
+		return 0
+	}
+	if g.proposed.Load()-g.base >= g.minEntries {
+		g.savedAt = time.Time{}
+		return 0
+	}
+	d := g.savedAt.Add(g.maxDelay).Sub(now)
+	if d <= 0 {
+		g.savedAt = time.Time{}
+		return 0
+	}
+	return d
+}
+
+// wakeup returns the channel that is signaled on proposals.
+func (g *groupCommit) wakeup() <-chan struct{} {
+	if g == nil {
+		return nil
+	}
+	return g.proposedc
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupCommit(t *testing.T) {
+	assert.Nil(t, newGroupCommit(0, 10), "zero delay disables group commit")
+
+	g := newGroupCommit(10*time.Millisecond, 3)
+	now := time.Now()
+	assert.Zero(t, g.wait(now), "nothing saved yet")
+
+	g.saved(false, 5, now)
+	assert.Zero(t, g.wait(now), "followers do not hold back Ready")
+
+	g.saved(true, 0, now)
+	assert.Zero(t, g.wait(now), "saves without entries do not hold back Ready")
+
+	g.saved(true, 5, now)
+	assert.Equal(t, 10*time.Millisecond, g.wait(now))
+	g.propose()
+	g.propose()
+	assert.Equal(t, 6*time.Millisecond, g.wait(now.Add(4*time.Millisecond)))
+	g.propose()
+	assert.Zero(t, g.wait(now.Add(5*time.Millisecond)), "enough proposals to group")
+	assert.Zero(t, g.wait(now.Add(5*time.Millisecond)), "Ready is released until the next save")
+
+	g.saved(true, 5, now)
+	assert.Zero(t, g.wait(now.Add(10*time.Millisecond)), "delay expired")
+
+	select {
+	case <-g.wakeup():
+	default:
+		t.Fatal("expected proposals to wake up the raft loop")
+	}
+}
@@ -0,0 +1,519 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// leaderLease tracks when the leader last heard from each follower. With
+// CheckQuorum enabled, a follower does not vote for another candidate within
+// an election timeout after hearing from the leader, so the leader keeps its
+// leadership while a quorum of voters acknowledged it recently enough.
+//
+// A follower may receive a heartbeat any time after the leader sent it, so an
+// acknowledgement only extends the lease from the time the acknowledged
+// heartbeat was sent. The heartbeats of each round are tagged with a unique
+// context, which followers echo in their responses.
+//
+// The leader also grants follower leases along with its heartbeats, so that
+// followers serve linearizable reads without asking the leader for a read
+// index. The entries a follower holding a lease did not append yet are held
+// back: the leader neither applies them nor tells the other followers they are
+// committed. Every entry exposed to clients is then in the log of the
+// follower, which serves a read once it applied its last log index.
+type leaderLease struct {
+	mu sync.Mutex
+	// round numbers the heartbeat rounds sent by the leader.
+	round uint64
+	// sent maps the contexts of the recent heartbeat rounds to their send time.
+	sent map[string]time.Time
+	// acks maps followers to the send time of the latest round they acknowledged.
+	acks map[types.ID]time.Time
+	// ackRounds maps followers to the latest round they acknowledged.
+	ackRounds map[types.ID]uint64
+	// match maps followers to the latest log index they acknowledged appending.
+	match map[types.ID]uint64
+	// grants maps followers to the time until which they may hold the
+	// follower lease granted by the local member.
+	grants map[types.ID]time.Time
+	// committedInTerm is true once the leader applied an entry of its term.
+	// Followers caught up with its commit index hold no entries of previous
+	// terms it is about to overwrite.
+	committedInTerm bool
+	// transferUntil suspends the lease while the leadership is transferred,
+	// as the transferee is elected without waiting for the lease to expire.
+	transferUntil time.Time
+	// heldUntil holds back the entries after heldIndex, as followers may hold
+	// leases the local member does not know of, after it became leader or
+	// restarted.
+	heldUntil time.Time
+	heldIndex uint64
+	// voteAfter is the time before which the local member does not vote, as
+	// it forgot the leader it acknowledged before it restarted.
+	voteAfter time.Time
+	// exposedc is closed once more entries may be exposed.
+	exposedc chan struct{}
+}
+
+// leaseGrant is what the leader needs to grant follower leases.
+type leaseGrant struct {
+	// duration is how long the leader assumes a granted lease is held, or
+	// zero if it grants no leases.
+	duration time.Duration
+	// commit is the commit index a follower must have appended to be granted
+	// a lease.
+	commit uint64
+	voters []types.ID
+}
+
+// observeSent tags the heartbeats among the messages the leader is about to
+// send with the context of a new round sent at the given time. Rounds sent
+// longer than the lease duration ago are forgotten, as their
+// acknowledgements can no longer extend the lease.
+//
+// The heartbeats to the followers eligible for a follower lease also carry
+// the latest round they acknowledged, from which they hold the lease. The
+// commit index of the messages is capped to the entries that may be exposed.
+func (l *leaderLease) observeSent(ms []raftpb.Message, now time.Time, duration time.Duration, grant leaseGrant) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	exposable := l.exposableLocked(now)
+	var ctx []byte
+	for i := range ms {
+		if ms[i].Type == raftpb.MsgApp || ms[i].Type == raftpb.MsgHeartbeat {
+			ms[i].Commit = min(ms[i].Commit, exposable)
+		}
+		// heartbeats carrying the context of a read index request are left
+		// alone; there are none when raft serves read index from its lease.
+		if ms[i].Type != raftpb.MsgHeartbeat || ms[i].To == 0 || len(ms[i].Context) != 0 {
+			continue
+		}
+		if ctx == nil {
+			l.round++
+			ctx = leaderLeaseContext(l.round)
+			if l.sent == nil {
+				l.sent = make(map[string]time.Time)
+			}
+			l.sent[string(ctx)] = now
+		}
+		ms[i].Context = ctx
+		to := types.ID(ms[i].To)
+		if l.grantable(to, grant) {
+			ms[i].Context = binary.BigEndian.AppendUint64(bytes.Clone(ctx), l.ackRounds[to])
+			if l.grants == nil {
+				l.grants = make(map[types.ID]time.Time)
+			}
+			if until := now.Add(grant.duration); until.After(l.grants[to]) {
+				l.grants[to] = until
+			}
+		}
+	}
+	for c, t := range l.sent {
+		if now.Sub(t) >= duration {
+			delete(l.sent, c)
+		}
+	}
+	for id, until := range l.grants {
+		if !now.Before(until) {
+			delete(l.grants, id)
+		}
+	}
+}
+
+// grantable returns true if the given follower is granted a follower lease:
+// it is a voter that acknowledged a recent round and appended the entries the
+// leader committed in its term.
+func (l *leaderLease) grantable(id types.ID, grant leaseGrant) bool {
+	if grant.duration <= 0 || !l.committedInTerm || !slices.Contains(grant.voters, id) {
+		return false
+	}
+	if _, ok := l.sent[string(leaderLeaseContext(l.ackRounds[id]))]; !ok {
+		return false
+	}
+	return l.match[id] >= grant.commit
+}
+
+// observeAck records that the given follower responded to the heartbeat
+// round with the given context.
+func (l *leaderLease) observeAck(id types.ID, ctx []byte) {
+	round, _, ok := parseLeaderLeaseContext(ctx)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.sent[string(leaderLeaseContext(round))]
+	if !ok {
+		return
+	}
+	if l.acks == nil {
+		l.acks = make(map[types.ID]time.Time)
+		l.ackRounds = make(map[types.ID]uint64)
+	}
+	if t.After(l.acks[id]) {
+		l.acks[id] = t
+		l.ackRounds[id] = round
+	}
+}
+
+// observeMatch records that the given follower appended the entries up to
+// the given index.
+func (l *leaderLease) observeMatch(id types.ID, index uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index <= l.match[id] {
+		return
+	}
+	if l.match == nil {
+		l.match = make(map[types.ID]uint64)
+	}
+	l.match[id] = index
+	if l.exposedc != nil {
+		close(l.exposedc)
+		l.exposedc = nil
+	}
+}
+
+// commitInTerm records that the leader applied an entry of its term.
+func (l *leaderLease) commitInTerm() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.committedInTerm = true
+}
+
+// transfer suspends the lease until the given time, while the leadership is
+// transferred.
+func (l *leaderLease) transfer(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.transferUntil = until
+}
+
+// hold holds back the entries after the given index until the given time.
+func (l *leaderLease) hold(now, until time.Time, index uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Before(l.heldUntil) {
+		index = min(index, l.heldIndex)
+		until = later(until, l.heldUntil)
+	}
+	l.heldUntil, l.heldIndex = until, index
+}
+
+// exposableLocked returns the index up to which entries may be exposed to
+// clients, as every follower holding a lease appended them.
+func (l *leaderLease) exposableLocked(now time.Time) uint64 {
+	index := uint64(math.MaxUint64)
+	if now.Before(l.heldUntil) {
+		index = l.heldIndex
+	}
+	for id, until := range l.grants {
+		if now.Before(until) {
+			index = min(index, l.match[id])
+		}
+	}
+	return index
+}
+
+// waitExposable blocks until the entry at the given index may be exposed to
+// clients, and returns the index up to which entries may be exposed.
+func (l *leaderLease) waitExposable(index uint64, stopc <-chan struct{}) uint64 {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		exposable := l.exposableLocked(now)
+		if exposable >= index {
+			l.mu.Unlock()
+			return exposable
+		}
+		if l.exposedc == nil {
+			l.exposedc = make(chan struct{})
+		}
+		exposedc := l.exposedc
+		// the entry is exposed at the latest once the hold and the leases
+		// holding it back expire.
+		until := l.heldUntil
+		for _, u := range l.grants {
+			until = later(until, u)
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(until.Sub(now))
+		select {
+		case <-exposedc:
+		case <-timer.C:
+		case <-stopc:
+			timer.Stop()
+			return index
+		}
+		timer.Stop()
+	}
+}
+
+// reset forgets all heartbeat rounds and acknowledgements, e.g. on leader
+// change. The follower leases granted are kept until they expire, along with
+// the entries the followers appended.
+func (l *leaderLease) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sent = nil
+	l.acks = nil
+	l.ackRounds = nil
+	l.committedInTerm = false
+	l.transferUntil = time.Time{}
+	for id := range l.match {
+		if _, ok := l.grants[id]; !ok {
+			delete(l.match, id)
+		}
+	}
+}
+
+// valid returns true if a quorum of the given voters, counting the local
+// member, acknowledged the leader within the lease duration.
+func (l *leaderLease) valid(now time.Time, self types.ID, voters []types.ID, duration time.Duration) bool {
+	if duration <= 0 {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Before(l.transferUntil) {
+		return false
+	}
+	acked := 0
+	for _, id := range voters {
+		if id == self {
+			acked++
+			continue
+		}
+		if t, ok := l.acks[id]; ok && now.Sub(t) < duration {
+			acked++
+		}
+	}
+	return acked >= len(voters)/2+1
+}
+
+// restart makes the local member wait out a lease it may have acknowledged
+// before it restarted: it neither votes before voteAfter nor exposes the
+// entries after index before holdUntil.
+func (l *leaderLease) restart(voteAfter, holdUntil time.Time, index uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.voteAfter = voteAfter
+	l.heldUntil, l.heldIndex = holdUntil, index
+}
+
+// canVote returns false if the local member must not vote yet.
+func (l *leaderLease) canVote(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !now.Before(l.voteAfter)
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// leaderLeaseContextPrefix prefixes the contexts of the heartbeat rounds
+// tagged by the leader lease.
+const leaderLeaseContextPrefix = "leader-lease/"
+
+// leaderLeaseContext returns the context of the given heartbeat round.
+func leaderLeaseContext(round uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte(leaderLeaseContextPrefix), round)
+}
+
+// parseLeaderLeaseContext returns the heartbeat round of the given context,
+// and the acknowledged round a follower lease is granted from, if any.
+func parseLeaderLeaseContext(ctx []byte) (round, granted uint64, ok bool) {
+	rest, ok := bytes.CutPrefix(ctx, []byte(leaderLeaseContextPrefix))
+	if !ok {
+		return 0, 0, false
+	}
+	switch len(rest) {
+	case 8:
+		return binary.BigEndian.Uint64(rest), 0, true
+	case 16:
+		return binary.BigEndian.Uint64(rest), binary.BigEndian.Uint64(rest[8:]), true
+	default:
+		return 0, 0, false
+	}
+}
+
+// leaderLeaseDuration is how long an acknowledgement from a follower extends
+// the leader lease, reduced by the configured clock drift bound. It is also
+// how long a follower holds a follower lease from its acknowledgement.
+func (s *EtcdServer) leaderLeaseDuration() time.Duration {
+	return s.Cfg.ElectionTimeout() - s.Cfg.LeaderLeaseMaxClockDrift
+}
+
+// followerLeaseGrantDuration is how long the leader assumes a follower
+// lease it granted is held. It is not shorter than a follower holds it
+// by its own clock.
+func (s *EtcdServer) followerLeaseGrantDuration() time.Duration {
+	return s.Cfg.ElectionTimeout() + s.Cfg.LeaderLeaseMaxClockDrift
+}
+
+// leaderLeaseValid returns true if the local member holds a valid leader lease.
+func (s *EtcdServer) leaderLeaseValid() bool {
+	return s.isLeader() && s.leaderLease.valid(time.Now(), s.MemberID(), s.cluster.VotingMemberIDs(), s.leaderLeaseDuration())
+}
+
+// observeLeaderLease updates the leader and follower leases with the given
+// raft message and returns false if the message must be dropped. Read index
+// requests forwarded by followers are dropped while the lease is not valid;
+// followers retry them. Votes are dropped until a restarted member waited out
+// the lease it may have acknowledged before.
+func (s *EtcdServer) observeLeaderLease(m raftpb.Message) bool {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return true
+	}
+	switch m.Type {
+	case raftpb.MsgHeartbeat:
+		s.followerLease.observeGrant(types.ID(m.From), m.Context, s.leaderLeaseDuration())
+	case raftpb.MsgHeartbeatResp:
+		s.leaderLease.observeAck(types.ID(m.From), m.Context)
+	case raftpb.MsgAppResp:
+		if !m.Reject {
+			s.leaderLease.observeMatch(types.ID(m.From), m.Index)
+		}
+	case raftpb.MsgReadIndex:
+		if s.isLeader() && !s.leaderLeaseValid() {
+			leaderLeaseReadIndexDelayed.Inc()
+			return false
+		}
+	case raftpb.MsgVote, raftpb.MsgPreVote:
+		return s.leaderLease.canVote(time.Now())
+	}
+	return true
+}
+
+// observeSend tags the heartbeats the leader is about to send, so that their
+// acknowledgements extend the leader lease from the time they were sent, and
+// grant follower leases. It records when the local member acknowledges the
+// heartbeats of the leader, from which the follower leases are held.
+func (s *EtcdServer) observeSend(ms []raftpb.Message) {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return
+	}
+	now := time.Now()
+	var grant leaseGrant
+	if s.leaderLeaseValid() {
+		grant = leaseGrant{
+			duration: s.followerLeaseGrantDuration(),
+			commit:   s.getCommittedIndex(),
+			voters:   s.cluster.VotingMemberIDs(),
+		}
+	}
+	s.leaderLease.observeSent(ms, now, s.leaderLeaseDuration(), grant)
+	s.followerLease.observeAcksSent(ms, now, s.leaderLeaseDuration())
+}
+
+// resetLeaderLease forgets the heartbeat rounds on leader change. A new
+// leader holds back the entries it did not apply yet until the follower
+// leases granted by the previous leader expired.
+func (s *EtcdServer) resetLeaderLease() {
+	s.leaderLease.reset()
+	if s.FeatureEnabled(features.LeaseBasedReads) && s.isLeader() {
+		now := time.Now()
+		s.leaderLease.hold(now, now.Add(s.followerLeaseGrantDuration()), s.getAppliedIndex())
+	}
+}
+
+// restartLeaderLease makes a member restarting with an existing log wait out
+// the leases it may have taken part in before it restarted. It does not vote
+// within an election timeout, as it forgot the leader it acknowledged, and
+// holds back the entries it did not apply yet, as it forgot the follower
+// leases it granted.
+func (s *EtcdServer) restartLeaderLease() {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return
+	}
+	index := s.consistIndex.ConsistentIndex()
+	if index == 0 {
+		return
+	}
+	now := time.Now()
+	s.leaderLease.restart(now.Add(s.Cfg.ElectionTimeout()), now.Add(s.followerLeaseGrantDuration()), index)
+}
+
+// transferLeaderLease suspends the leader lease and stops granting follower
+// leases while the leadership is transferred.
+func (s *EtcdServer) transferLeaderLease() {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return
+	}
+	s.leaderLease.transfer(time.Now().Add(s.Cfg.ElectionTimeout()))
+}
+
+// waitFollowerLeases blocks applying the entry at the given index until every
+// follower holding a lease appended it. It returns the index up to which
+// entries may be applied.
+func (s *EtcdServer) waitFollowerLeases(index uint64) uint64 {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return math.MaxUint64
+	}
+	return s.leaderLease.waitExposable(index, s.stopping)
+}
+
+// waitLeaderLease blocks a read index request of the leader until it holds a
+// valid leader lease. Read index requests of followers return immediately, as
+// they are confirmed by the leader.
+func (s *EtcdServer) waitLeaderLease() error {
+	if !s.FeatureEnabled(features.LeaseBasedReads) {
+		return nil
+	}
+	if !s.isLeader() || s.leaderLeaseValid() {
+		return nil
+	}
+	leaderLeaseReadIndexDelayed.Inc()
+
+	ticker := time.NewTicker(time.Duration(s.Cfg.TickMs) * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.NewTimer(s.Cfg.ReqTimeout())
+	defer timeout.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.isLeader() || s.leaderLeaseValid() {
+				return nil
+			}
+		case <-timeout.C:
+			s.Logger().Warn(
+				"timed out waiting for leader lease",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.Duration("timeout", s.Cfg.ReqTimeout()),
+			)
+			return errors.ErrTimeout
+		case <-s.stopping:
+			return errors.ErrStopped
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestLeaderLease(t *testing.T) {
+	now := time.Now()
+	voters := []types.ID{1, 2, 3, 4, 5}
+	duration := 800 * time.Millisecond
+
+	var lease leaderLease
+	assert.False(t, lease.valid(now, 1, voters, duration), "no acknowledgements")
+	assert.True(t, lease.valid(now, 1, []types.ID{1}, duration), "single member cluster")
+
+	old := heartbeats(2, 3, 4, 5)
+	lease.observeSent(old, now.Add(-700*time.Millisecond), duration, leaseGrant{})
+	recent := heartbeats(2, 3, 4, 5)
+	lease.observeSent(recent, now.Add(-100*time.Millisecond), duration, leaseGrant{})
+	require.NotEqual(t, old[0].Context, recent[0].Context, "rounds should be tagged with distinct contexts")
+
+	lease.observeAck(2, recent[0].Context)
+	assert.False(t, lease.valid(now, 1, voters, duration), "no quorum")
+
+	// the lease is extended from the time the acknowledged round was sent,
+	// however late the acknowledgement is received.
+	lease.observeAck(3, old[1].Context)
+	assert.True(t, lease.valid(now, 1, voters, duration))
+	assert.False(t, lease.valid(now.Add(100*time.Millisecond), 1, voters, duration), "acknowledgement of member 3 expired")
+	assert.False(t, lease.valid(now, 1, voters, 0), "drift exceeds election timeout")
+
+	// an older round does not shorten the lease.
+	lease.observeAck(2, old[0].Context)
+	lease.observeAck(3, recent[1].Context)
+	assert.True(t, lease.valid(now.Add(600*time.Millisecond), 1, voters, duration))
+
+	lease.observeAck(4, []byte("unknown"))
+	lease.observeAck(4, nil)
+	assert.NotContains(t, lease.acks, types.ID(4), "acknowledgements of unknown rounds are ignored")
+
+	lease.reset()
+	assert.False(t, lease.valid(now, 1, voters, duration))
+	lease.observeAck(2, recent[0].Context)
+	assert.Empty(t, lease.acks, "rounds are forgotten on reset")
+}
+
+func TestLeaderLeaseObserveSent(t *testing.T) {
+	now := time.Now()
+	duration := 800 * time.Millisecond
+	var lease leaderLease
+
+	ms := []raftpb.Message{
+		{Type: raftpb.MsgHeartbeat, To: 2},
+		{Type: raftpb.MsgApp, To: 2},
+		{Type: raftpb.MsgHeartbeat, To: 3, Context: []byte("read-index")},
+		{Type: raftpb.MsgHeartbeat, To: 0},
+	}
+	lease.observeSent(ms, now, duration, leaseGrant{})
+	assert.NotEmpty(t, ms[0].Context)
+	assert.Empty(t, ms[1].Context)
+	assert.Equal(t, []byte("read-index"), ms[2].Context, "read index contexts are kept")
+	assert.Empty(t, ms[3].Context, "dropped messages are not tagged")
+	assert.Len(t, lease.sent, 1)
+
+	// rounds older than the lease duration are forgotten.
+	lease.observeSent(heartbeats(2), now.Add(duration), duration, leaseGrant{})
+	assert.Len(t, lease.sent, 1)
+	assert.NotContains(t, lease.sent, string(ms[0].Context))
+}
+
+func heartbeats(to ...uint64) []raftpb.Message {
+	var ms []raftpb.Message
+	for _, id := range to {
+		ms = append(ms, raftpb.Message{Type: raftpb.MsgHeartbeat, To: id})
+	}
+	return ms
+}
+
+func TestFollowerLeaseGrant(t *testing.T) {
+	now := time.Now()
+	duration := 800 * time.Millisecond
+	grant := leaseGrant{duration: time.Second, commit: 10, voters: []types.ID{1, 2, 3}}
+	var lease leaderLease
+	var follower followerLease
+
+	// the follower acknowledges a round, but the leader did not commit an
+	// entry of its term yet.
+	round := heartbeats(2, 3)
+	lease.observeSent(round, now, duration, grant)
+	acks := []raftpb.Message{{Type: raftpb.MsgHeartbeatResp, To: 1, Context: round[0].Context}}
+	follower.observeAcksSent(acks, now.Add(10*time.Millisecond), duration)
+	lease.observeAck(2, round[0].Context)
+	lease.observeMatch(2, 10)
+	ms := heartbeats(2)
+	lease.observeSent(ms, now.Add(100*time.Millisecond), duration, grant)
+	follower.observeGrant(1, ms[0].Context, duration)
+	assert.False(t, follower.valid(now.Add(100*time.Millisecond), 1))
+
+	lease.commitInTerm()
+	lease.observeMatch(3, 9)
+	ms = heartbeats(2, 3)
+	lease.observeSent(ms, now.Add(200*time.Millisecond), duration, grant)
+	assert.NotEqual(t, ms[0].Context, ms[1].Context, "only the follower caught up with the commit index is granted a lease")
+	assert.NotContains(t, lease.grants, types.ID(3))
+	follower.observeGrant(1, ms[0].Context, duration)
+
+	// the lease is held from the time the acknowledgement was sent.
+	assert.True(t, follower.valid(now.Add(200*time.Millisecond), 1))
+	assert.False(t, follower.valid(now.Add(200*time.Millisecond), 3), "granted by another leader")
+	assert.False(t, follower.valid(now.Add(810*time.Millisecond), 1))
+	assert.Equal(t, now.Add(1200*time.Millisecond), lease.grants[2], "the leader assumes the lease is held from the time it granted it")
+
+	// entries the follower did not append are held back until the lease expires.
+	ms = []raftpb.Message{{Type: raftpb.MsgApp, To: 3, Commit: 12}}
+	lease.observeSent(ms, now.Add(300*time.Millisecond), duration, leaseGrant{})
+	assert.Equal(t, uint64(10), ms[0].Commit)
+	lease.observeMatch(2, 12)
+	lease.observeMatch(2, 11)
+	lease.mu.Lock()
+	assert.Equal(t, uint64(12), lease.exposableLocked(now.Add(300*time.Millisecond)), "the match index does not go back")
+	lease.mu.Unlock()
+
+	// the granted leases outlive a leader change.
+	lease.reset()
+	lease.mu.Lock()
+	assert.Equal(t, uint64(12), lease.exposableLocked(now.Add(300*time.Millisecond)))
+	assert.Equal(t, uint64(math.MaxUint64), lease.exposableLocked(now.Add(1200*time.Millisecond)))
+	lease.mu.Unlock()
+}
+
+func TestLeaderLeaseHold(t *testing.T) {
+	now := time.Now()
+	var lease leaderLease
+	lease.hold(now, now.Add(time.Second), 10)
+	lease.hold(now, now.Add(500*time.Millisecond), 20)
+	lease.mu.Lock()
+	assert.Equal(t, uint64(10), lease.exposableLocked(now.Add(900*time.Millisecond)), "a hold does not shorten a previous one")
+	assert.Equal(t, uint64(math.MaxUint64), lease.exposableLocked(now.Add(time.Second)))
+	lease.mu.Unlock()
+
+	// a held back entry is exposed once the hold expires.
+	until := time.Now().Add(200 * time.Millisecond)
+	lease.hold(time.Now(), until, 10)
+	assert.Equal(t, uint64(math.MaxUint64), lease.waitExposable(11, nil))
+	assert.False(t, time.Now().Before(until))
+
+	// or once the follower holding a lease appended it.
+	lease.grants = map[types.ID]time.Time{2: time.Now().Add(time.Minute)}
+	exposed := make(chan uint64)
+	go func() { exposed <- lease.waitExposable(11, nil) }()
+	lease.observeMatch(2, 10)
+	select {
+	case <-exposed:
+		t.Fatal("entry 11 exposed before the follower appended it")
+	case <-time.After(100 * time.Millisecond):
+	}
+	lease.observeMatch(2, 11)
+	assert.Equal(t, uint64(11), <-exposed)
+}
+
+func TestLeaderLeaseRestart(t *testing.T) {
+	now := time.Now()
+	var lease leaderLease
+	assert.True(t, lease.canVote(now))
+	lease.restart(now.Add(time.Second), now.Add(2*time.Second), 5)
+	assert.False(t, lease.canVote(now))
+	assert.True(t, lease.canVote(now.Add(time.Second)))
+	lease.mu.Lock()
+	assert.Equal(t, uint64(5), lease.exposableLocked(now.Add(time.Second)))
+	lease.mu.Unlock()
+}
+
+func TestLeaderLeaseContext(t *testing.T) {
+	round, granted, ok := parseLeaderLeaseContext(leaderLeaseContext(7))
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), round)
+	assert.Zero(t, granted)
+
+	_, _, ok = parseLeaderLeaseContext([]byte("read-index"))
+	assert.False(t, ok)
+	_, _, ok = parseLeaderLeaseContext(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/features"
+)
+
+// learnerAutoPromoteCheckInterval is the interval at which the leader
+// evaluates the progress of learners for automatic promotion.
+var learnerAutoPromoteCheckInterval = time.Second
+
+// learnerPromotionTracker keeps track of how long each learner has been
+// caught up with the leader.
+type learnerPromotionTracker struct {
+	// maxLag is the maximum number of raft entries a learner may lag
+	// behind the leader to be considered caught up.
+	maxLag uint64
+	// duration is how long a learner needs to stay caught up before
+	// it is ready to be promoted.
+	duration time.Duration
+
+	caughtUpSince map[types.ID]time.Time
+}
+
+func newLearnerPromotionTracker(maxLag uint64, duration time.Duration) *learnerPromotionTracker {
+	return &learnerPromotionTracker{
+		maxLag:        maxLag,
+		duration:      duration,
+		caughtUpSince: make(map[types.ID]time.Time),
+	}
+}
+
+// update records the match index of the given learners against the leader's
+// match index, and returns the learners which have stayed caught up for at
+// least the configured duration, sorted by ID. Learners not present in
+// learnerMatch are forgotten.
+func (t *learnerPromotionTracker) update(now time.Time, leaderMatch uint64, learnerMatch map[types.ID]uint64) []types.ID {
+	for id := range t.caughtUpSince {
+		if _, ok := learnerMatch[id]; !ok {
+			delete(t.caughtUpSince, id)
+		}
+	}
+
+	var ready []types.ID
+	for id, match := range learnerMatch {
+		if match+t.maxLag < leaderMatch {
+			delete(t.caughtUpSince, id)
+			continue
+		}
+		since, ok := t.caughtUpSince[id]
+		if !ok {
+			t.caughtUpSince[id] = now
+			since = now
+		}
+		if now.Sub(since) >= t.duration {
+			ready = append(ready, id)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i] < ready[j] })
+	return ready
+}
+
+// reset forgets the progress of all learners.
+func (t *learnerPromotionTracker) reset() {
+	t.caughtUpSince = make(map[types.ID]time.Time)
+}
+
+// monitorLearnerAutoPromotion every learnerAutoPromoteCheckInterval checks if it's
+// the leader and promotes learners which have been caught up with the leader for
+// a sustained period.
+func (s *EtcdServer) monitorLearnerAutoPromotion() {
+	if !s.FeatureEnabled(features.LearnerAutoPromote) {
+		return
+	}
+	lg := s.Logger()
+	lg.Info(
+		"enabled learner auto promotion",
+		zap.String("local-member-id", s.MemberID().String()),
+		zap.Uint64("max-lag", s.Cfg.LearnerAutoPromoteMaxLag),
+		zap.Duration("duration", s.Cfg.LearnerAutoPromoteDuration),
+	)
+	tracker := newLearnerPromotionTracker(s.Cfg.LearnerAutoPromoteMaxLag, s.Cfg.LearnerAutoPromoteDuration)
+	for {
+		select {
+		case <-time.After(learnerAutoPromoteCheckInterval):
+		case <-s.stopping:
+			lg.Info("server has stopped; stopping learner auto promotion's monitor")
+			return
+		}
+
+		if !s.isLeader() {
+			tracker.reset()
+			continue
+		}
+		rs := s.raftStatus()
+		if rs.Progress == nil {
+			tracker.reset()
+			continue
+		}
+
+		learnerMatch := make(map[types.ID]uint64)
+		for _, m := range s.cluster.Members() {
+			if !m.IsLearner || m.ReadReplica || !m.IsStarted() {
+				continue
+			}
+			if pr, ok := rs.Progress[uint64(m.ID)]; ok {
+				learnerMatch[m.ID] = pr.Match
+			}
+		}
+		for _, id := range tracker.update(time.Now(), rs.Progress[rs.ID].Match, learnerMatch) {
+			s.autoPromoteLearner(id)
+		}
+	}
+}
+
+func (s *EtcdServer) autoPromoteLearner(id types.ID) {
+	lg := s.Logger()
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	if _, err := s.proposePromoteMember(ctx, uint64(id)); err != nil {
+		learnerAutoPromotions.WithLabelValues("failure").Inc()
+		lg.Warn(
+			"failed to automatically promote learner",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("learner-member-id", id.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	learnerAutoPromotions.WithLabelValues("success").Inc()
+	lg.Info(
+		"automatically promoted learner to voting member",
+		zap.String("local-member-id", s.MemberID().String()),
+		zap.String("promoted-member-id", id.String()),
+	)
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+func TestLearnerPromotionTracker(t *testing.T) {
+	start := time.Now()
+	tracker := newLearnerPromotionTracker(10, 30*time.Second)
+
+	// learner 2 is caught up, learner 3 lags behind.
+	ready := tracker.update(start, 100, map[types.ID]uint64{2: 95, 3: 50})
+	assert.Empty(t, ready)
+
+	// learner 3 catches up later than learner 2.
+	ready = tracker.update(start.Add(10*time.Second), 200, map[types.ID]uint64{2: 195, 3: 190})
+	assert.Empty(t, ready)
+
+	ready = tracker.update(start.Add(30*time.Second), 300, map[types.ID]uint64{2: 300, 3: 295})
+	assert.Equal(t, []types.ID{2}, ready)
+
+	// learner 2 falls behind and needs to catch up for the full duration again.
+	ready = tracker.update(start.Add(40*time.Second), 400, map[types.ID]uint64{2: 300, 3: 400})
+	assert.Equal(t, []types.ID{3}, ready)
+
+	ready = tracker.update(start.Add(50*time.Second), 500, map[types.ID]uint64{2: 500, 3: 500})
+	assert.Equal(t, []types.ID{3}, ready)
+
+	// learner 3 is gone (e.g. promoted), learner 2 becomes ready.
+	ready = tracker.update(start.Add(80*time.Second), 600, map[types.ID]uint64{2: 600})
+	assert.Equal(t, []types.ID{2}, ready)
+	assert.NotContains(t, tracker.caughtUpSince, types.ID(3))
+
+	tracker.reset()
+	ready = tracker.update(start.Add(90*time.Second), 700, map[types.ID]uint64{2: 700})
+	assert.Empty(t, ready)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// Subsystems whose log level can be overridden at runtime with LogLevelSet.
+const (
+	LogSubsystemRaft = "raft"
+	LogSubsystemMVCC = "mvcc"
+	LogSubsystemAuth = "auth"
+	LogSubsystemGRPC = "grpc"
+)
+
+// LogSubsystems lists the subsystems whose log level can be overridden.
+var LogSubsystems = []string{LogSubsystemRaft, LogSubsystemMVCC, LogSubsystemAuth, LogSubsystemGRPC}
+
+// subsystemLogger returns the logger of the given subsystem, which honors
+// the log level overridden for the subsystem.
+func subsystemLogger(cfg config.ServerConfig, subsystem string) *zap.Logger {
+	return cfg.LogLevels.Logger(cfg.Logger, subsystem)
+}
+
+// SetLogLevels overrides the log level of the given subsystems. Either all
+// levels are set, or none if any of them is invalid. It returns the current
+// log level of all subsystems.
+func (s *EtcdServer) SetLogLevels(settings []*pb.LogLevelSetting) ([]*pb.LogLevelSetting, error) {
+	if s.Cfg.LogLevels == nil {
+		return nil, fmt.Errorf("%w: log levels are not adjustable at runtime", errors.ErrInvalidLogLevel)
+	}
+	for _, setting := range settings {
+		if !slices.Contains(LogSubsystems, setting.Subsystem) {
+			return nil, fmt.Errorf("%w: unknown subsystem %q", errors.ErrInvalidLogLevel, setting.Subsystem)
+		}
+		if setting.Level != "" {
+			var lvl zapcore.Level
+			if err := lvl.Set(setting.Level); err != nil {
+				return nil, fmt.Errorf("%w: %s=%q", errors.ErrInvalidLogLevel, setting.Subsystem, setting.Level)
+			}
+		}
+		if setting.Ttl < 0 {
+			return nil, fmt.Errorf("%w: negative ttl %d", errors.ErrInvalidLogLevel, setting.Ttl)
+		}
+	}
+
+	for _, setting := range settings {
+		ttl := time.Duration(setting.Ttl) * time.Second
+		if err := s.Cfg.LogLevels.Set(setting.Subsystem, setting.Level, ttl); err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrInvalidLogLevel, err)
+		}
+		s.lg.Info(
+			"changed log level",
+			zap.String("subsystem", setting.Subsystem),
+			zap.String("level", setting.Level),
+			zap.Duration("ttl", ttl),
+		)
+	}
+
+	var levels []*pb.LogLevelSetting
+	for _, l := range s.Cfg.LogLevels.Levels() {
+		levels = append(levels, &pb.LogLevelSetting{
+			Subsystem: l.Subsystem,
+			Level:     l.Level.String(),
+			Ttl:       int64(l.TTL.Round(time.Second) / time.Second),
+		})
+	}
+	return levels, nil
+}
@@ -59,6 +59,60 @@ var (
 		Name:      "learner_promote_successes",
 		Help:      "The total number of successful learner promotions while this member is leader.",
 	})
+	learnerAutoPromotions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "server",
+			Name:      "learner_auto_promotions_total",
+			Help:      "The total number of automatic learner promotions attempted while this member is leader.",
+		},
+		[]string{"result"},
+	)
+	readReplicaStaleness = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "read_replica_staleness_seconds",
+		Help:      "How far the data of this read replica lags behind the leader in seconds.",
+	})
+	leaderLeaseReadIndexDelayed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "leader_lease_read_index_delayed_total",
+		Help:      "The total number of read index requests delayed or dropped by the leader because it did not hold a valid leader lease.",
+	})
+	followerLeaseReads = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "follower_lease_reads_total",
+		Help:      "The total number of batches of linearizable reads served by a follower based on its follower lease, without requesting a read index from the leader.",
+	})
+	leadershipPriorityTransfers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "server",
+			Name:      "leadership_priority_transfers_total",
+			Help:      "The total number of leadership transfers to members with higher leadership priority initiated by this member.",
+		},
+		[]string{"result"},
+	)
+	scrubFindings = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "scrub_findings_total",
+		Help:      "The total number of problems found by the scrubs of the backend.",
+	})
+	scrubBytesRead = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "scrub_read_bytes_total",
+		Help:      "The total number of bytes read by the scrubs of the backend.",
+	})
+	scrubLastFinished = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "scrub_last_finished_timestamp_seconds",
+		Help:      "The time the last scrub of the backend that was not canceled finished, in seconds since the Unix epoch.",
+	})
 	heartbeatSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "server",
@@ -89,6 +143,16 @@ var (
 		Name:      "proposals_pending",
 		Help:      "The current number of pending proposals to commit.",
 	})
+	walGroupCommitDelaySec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "wal_group_commit_delay_seconds",
+		Help:      "The distributions of the time the leader held back raft Ready to group WAL saves.",
+
+		// lowest bucket start of upper bound 0.0001 sec (0.1 ms) with factor 2
+		// highest bucket start of 0.0001 sec * 2^9 == 0.0512 sec
+		Buckets: prometheus.ExponentialBuckets(0.0001, 2, 10),
+	})
 	proposalsFailed = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "server",
@@ -107,6 +171,16 @@ var (
 		Name:      "read_indexes_failed_total",
 		Help:      "The total number of failed read indexes seen.",
 	})
+	readIndexBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "read_index_batch_size",
+		Help:      "The number of linearizable reads served by a single read index request.",
+
+		// lowest bucket start of upper bound 1 with factor 2
+		// highest bucket start of 1 * 2^12 == 4096
+		Buckets: prometheus.ExponentialBuckets(1, 2, 13),
+	})
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
@@ -163,15 +237,25 @@ func init() {
 	prometheus.MustRegister(proposalsCommitted)
 	prometheus.MustRegister(proposalsApplied)
 	prometheus.MustRegister(proposalsPending)
+	prometheus.MustRegister(walGroupCommitDelaySec)
 	prometheus.MustRegister(proposalsFailed)
 	prometheus.MustRegister(slowReadIndex)
 	prometheus.MustRegister(readIndexFailed)
+	prometheus.MustRegister(readIndexBatchSize)
 	prometheus.MustRegister(leaseExpired)
 	prometheus.MustRegister(currentVersion)
 	prometheus.MustRegister(currentGoVersion)
 	prometheus.MustRegister(serverID)
 	prometheus.MustRegister(learnerPromoteSucceed)
 	prometheus.MustRegister(learnerPromoteFailed)
+	prometheus.MustRegister(learnerAutoPromotions)
+	prometheus.MustRegister(leadershipPriorityTransfers)
+	prometheus.MustRegister(readReplicaStaleness)
+	prometheus.MustRegister(leaderLeaseReadIndexDelayed)
+	prometheus.MustRegister(followerLeaseReads)
+	prometheus.MustRegister(scrubFindings)
+	prometheus.MustRegister(scrubBytesRead)
+	prometheus.MustRegister(scrubLastFinished)
 	prometheus.MustRegister(fdUsed)
 	prometheus.MustRegister(fdLimit)
 
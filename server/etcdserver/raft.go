@@ -15,12 +15,15 @@
 package etcdserver
 
 import (
+	"context"
 	"expvar"
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
@@ -95,9 +98,14 @@ type raftNode struct {
 	readStateC chan raft.ReadState
 
 	// utility
-	ticker *time.Ticker
+	ticker clockwork.Ticker
 	// contention detectors for raft heartbeat message
 	td *contention.TimeoutDetector
+	// saveStart is the start time in unix nanoseconds of the save of the raft
+	// hard state and entries in progress, zero if none.
+	saveStart *atomic.Int64
+	// groupCommit holds back raft Ready to group WAL saves, nil if disabled.
+	groupCommit *groupCommit
 
 	stopped chan struct{}
 	done    chan struct{}
@@ -112,11 +120,19 @@ type raftNodeConfig struct {
 	raftStorage *raft.MemoryStorage
 	storage     serverstorage.Storage
 	heartbeat   time.Duration // for logging
+	// clock drives the raft ticks, the real clock if nil.
+	clock clockwork.Clock
 	// transport specifies the transport to send and receive msgs to members.
 	// Sending messages MUST NOT block. It is okay to drop messages, since
 	// clients should timeout and reissue their messages.
 	// If transport is nil, server will panic.
 	transport rafthttp.Transporter
+	// groupCommitMaxDelay is how long the leader holds back raft Ready
+	// after saving entries to group the entries proposed meanwhile into one
+	// WAL save, at most until groupCommitMinEntries are proposed. Zero
+	// disables group commit.
+	groupCommitMaxDelay   time.Duration
+	groupCommitMinEntries int
 }
 
 func newRaftNode(cfg raftNodeConfig) *raftNode {
@@ -135,6 +151,8 @@ func newRaftNode(cfg raftNodeConfig) *raftNode {
 	r := &raftNode{
 		lg:             cfg.lg,
 		tickMu:         new(sync.RWMutex),
+		saveStart:      new(atomic.Int64),
+		groupCommit:    newGroupCommit(cfg.groupCommitMaxDelay, cfg.groupCommitMinEntries),
 		raftNodeConfig: cfg,
 		latestTickTs:   time.Now(),
 		// set up contention detectors for raft heartbeat message.
@@ -146,10 +164,14 @@ func newRaftNode(cfg raftNodeConfig) *raftNode {
 		stopped:    make(chan struct{}),
 		done:       make(chan struct{}),
 	}
+	if r.clock == nil {
+		r.clock = clockwork.NewRealClock()
+	}
 	if r.heartbeat == 0 {
-		r.ticker = &time.Ticker{}
+		// a ticker of a clock that is never advanced never fires.
+		r.ticker = clockwork.NewFakeClock().NewTicker(time.Hour)
 	} else {
-		r.ticker = time.NewTicker(r.heartbeat)
+		r.ticker = r.clock.NewTicker(r.heartbeat)
 	}
 	return r
 }
@@ -168,6 +190,22 @@ func (r *raftNode) getLatestTickTs() time.Time {
 	return r.latestTickTs
 }
 
+// Propose proposes data be appended to the log, counting it for group commit.
+func (r *raftNode) Propose(ctx context.Context, data []byte) error {
+	r.groupCommit.propose()
+	return r.Node.Propose(ctx, data)
+}
+
+// saveInProgress returns how long the save of the raft hard state and
+// entries in progress has been running, or zero if no save is in progress.
+func (r *raftNode) saveInProgress() time.Duration {
+	start := r.saveStart.Load()
+	if start == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, start))
+}
+
 // start prepares and starts raftNode in a new goroutine. It is no longer safe
 // to modify the fields after it has been started.
 func (r *raftNode) start(rh *raftReadyHandler) {
@@ -176,12 +214,28 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 	go func() {
 		defer r.onStop()
 		islead := false
+		var heldSince time.Time
 
 		for {
+			readyc := r.Ready()
+			var groupCommitc <-chan time.Time
+			if wait := r.groupCommit.wait(time.Now()); wait > 0 {
+				if heldSince.IsZero() {
+					heldSince = time.Now()
+				}
+				readyc = nil
+				groupCommitc = time.After(wait)
+			} else if !heldSince.IsZero() {
+				walGroupCommitDelaySec.Observe(time.Since(heldSince).Seconds())
+				heldSince = time.Time{}
+			}
+
 			select {
-			case <-r.ticker.C:
+			case <-r.ticker.Chan():
 				r.tick()
-			case rd := <-r.Ready():
+			case <-groupCommitc:
+			case <-r.groupCommit.wakeup():
+			case rd := <-readyc:
 				if rd.SoftState != nil {
 					newLeader := rd.SoftState.Lead != raft.None && rh.getLead() != rd.SoftState.Lead
 					if newLeader {
@@ -236,8 +290,12 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 				// writing to their disks.
 				// For more details, check raft thesis 10.2.1
 				if islead {
+					msgs := r.processMessages(rd.Messages)
+					if rh.beforeSend != nil {
+						rh.beforeSend(msgs)
+					}
 					// gofail: var raftBeforeLeaderSend struct{}
-					r.transport.Send(r.processMessages(rd.Messages))
+					r.transport.Send(msgs)
 				}
 
 				// Must save the snapshot file and WAL snapshot entry before saving any other entries or hardstate to
@@ -251,9 +309,12 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 				}
 
 				// gofail: var raftBeforeSave struct{}
+				r.saveStart.Store(time.Now().UnixNano())
 				if err := r.storage.Save(rd.HardState, rd.Entries); err != nil {
 					r.lg.Fatal("failed to save Raft hard state and entries", zap.Error(err))
 				}
+				r.saveStart.Store(0)
+				r.groupCommit.saved(islead, len(rd.Entries), time.Now())
 				if !raft.IsEmptyHardState(rd.HardState) {
 					proposalsCommitted.Set(float64(rd.HardState.Commit))
 				}
@@ -318,6 +379,9 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 						}
 					}
 
+					if rh.beforeSend != nil {
+						rh.beforeSend(msgs)
+					}
 					// gofail: var raftBeforeFollowerSend struct{}
 					r.transport.Send(msgs)
 				} else {
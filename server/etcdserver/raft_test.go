@@ -26,7 +26,9 @@ import (
 
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/pkg/v3/pbutil"
+	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/mock/mockstorage"
 	serverstorage "go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/raft/v3"
@@ -185,7 +187,7 @@ func TestStopRaftWhenWaitingForApplyDone(t *testing.T) {
 		transport:   newNopTransporter(),
 	})
 	srv := &EtcdServer{lgMu: new(sync.RWMutex), lg: zaptest.NewLogger(t), r: *r}
-	srv.r.start(nil)
+	srv.r.start(&raftReadyHandler{})
 	n.readyc <- raft.Ready{}
 
 	stop := func() {
@@ -278,6 +280,7 @@ func TestProcessDuplicatedAppRespMessage(t *testing.T) {
 	})
 
 	s := &EtcdServer{
+		Cfg:        config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:       new(sync.RWMutex),
 		lg:         zaptest.NewLogger(t),
 		r:          *r,
@@ -0,0 +1,212 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+const (
+	// stalenessTrackerGranularity is the minimum time between two pending
+	// leader observations tracked by the staleness tracker. Observations
+	// received within the granularity are merged.
+	stalenessTrackerGranularity = 100 * time.Millisecond
+	// maxPendingStalenessObservations bounds the memory used by the staleness
+	// tracker while the local member is catching up with the leader.
+	maxPendingStalenessObservations = 1024
+)
+
+// readReplicaStalenessCheckInterval is the interval at which a read replica
+// refreshes its staleness metric.
+var readReplicaStalenessCheckInterval = time.Second
+
+type stalenessObservation struct {
+	at     time.Time
+	commit uint64
+}
+
+// stalenessTracker tracks how far the local data lags behind the leader in
+// time. The local data is considered fresh as of the last time the leader
+// was heard from with a commit index that has since been applied locally.
+type stalenessTracker struct {
+	mu sync.Mutex
+	// applied is the latest applied index.
+	applied uint64
+	// freshAt is the latest time at which the local data was known to be
+	// up to date with the leader.
+	freshAt time.Time
+	// pending are leader observations whose commit index has not been applied
+	// yet, ordered by time.
+	pending []stalenessObservation
+}
+
+// observeLeader records that the leader reported the given commit index at the given time.
+func (t *stalenessTracker) observeLeader(now time.Time, commit uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if commit <= t.applied {
+		if now.After(t.freshAt) {
+			t.freshAt = now
+		}
+		// later observations are still pending on the local member
+		i := 0
+		for ; i < len(t.pending) && !t.pending[i].at.After(now); i++ {
+		}
+		t.pending = t.pending[i:]
+		return
+	}
+	if n := len(t.pending); n > 0 && now.Sub(t.pending[n-1].at) < stalenessTrackerGranularity {
+		// merging keeps the earlier time with the higher commit index, which
+		// can only overestimate the staleness.
+		if commit > t.pending[n-1].commit {
+			t.pending[n-1].commit = commit
+		}
+		return
+	}
+	if len(t.pending) >= maxPendingStalenessObservations {
+		t.pending = t.pending[1:]
+	}
+	t.pending = append(t.pending, stalenessObservation{at: now, commit: commit})
+}
+
+// observeApplied records that entries up to the given index have been applied.
+func (t *stalenessTracker) observeApplied(applied uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if applied <= t.applied {
+		return
+	}
+	t.applied = applied
+	i := 0
+	for ; i < len(t.pending) && t.pending[i].commit <= applied; i++ {
+		t.freshAt = t.pending[i].at
+	}
+	t.pending = t.pending[i:]
+}
+
+// staleness returns how far the local data lags behind the leader.
+// It returns false if the local data has never been up to date.
+func (t *stalenessTracker) staleness(now time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.freshAt.IsZero() {
+		return 0, false
+	}
+	if now.Before(t.freshAt) {
+		return 0, true
+	}
+	return now.Sub(t.freshAt), true
+}
+
+// observeRaftMessage updates the staleness tracker with the commit index
+// carried by append messages from the leader. Heartbeats are ignored, as the
+// leader sends the minimum of the match index of the member and its commit
+// index in them, which does not tell how far a lagging member is behind.
+func (s *EtcdServer) observeRaftMessage(m raftpb.Message) {
+	if !s.Cfg.ReadReplica {
+		return
+	}
+	if m.Type != raftpb.MsgApp {
+		return
+	}
+	s.replicaStaleness.observeLeader(time.Now(), m.Commit)
+}
+
+// probeReadReplicaStaleness confirms with a read index round trip that the
+// local data is up to date with the leader as of the start of the probe.
+// It keeps the staleness of a read replica bounded while the leader has no
+// entries to append.
+func (s *EtcdServer) probeReadReplicaStaleness() {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	if err := s.linearizableReadNotify(ctx); err != nil {
+		s.Logger().Debug("failed to probe read replica staleness", zap.Error(err))
+		return
+	}
+	s.replicaStaleness.observeLeader(start, s.getAppliedIndex())
+}
+
+// IsReadReplica returns true if the local member runs as a read replica.
+func (s *EtcdServer) IsReadReplica() bool {
+	return s.Cfg.ReadReplica && s.IsLearner()
+}
+
+// ReadReplicaStaleness returns how far the local member lags behind the
+// leader. It returns false if the local member is not a read replica.
+// A read replica which has never caught up with the leader reports
+// math.MaxInt64 as its staleness.
+func (s *EtcdServer) ReadReplicaStaleness() (time.Duration, bool) {
+	if !s.IsReadReplica() {
+		return 0, false
+	}
+	d, ok := s.replicaStaleness.staleness(time.Now())
+	if !ok {
+		return math.MaxInt64, true
+	}
+	return d, true
+}
+
+// checkReadReplicaStaleness returns an error if the local member is a read
+// replica whose data exceeds the configured max staleness.
+func (s *EtcdServer) checkReadReplicaStaleness() error {
+	d, ok := s.ReadReplicaStaleness()
+	if !ok {
+		return nil
+	}
+	if d > s.Cfg.ReadReplicaMaxStaleness {
+		return errors.ErrReadReplicaTooStale
+	}
+	return nil
+}
+
+// monitorReadReplicaStaleness every readReplicaStalenessCheckInterval
+// probes and refreshes the staleness metric of a read replica.
+func (s *EtcdServer) monitorReadReplicaStaleness() {
+	if !s.Cfg.ReadReplica {
+		return
+	}
+	lg := s.Logger()
+	lg.Info(
+		"enabled read replica",
+		zap.String("local-member-id", s.MemberID().String()),
+		zap.Duration("max-staleness", s.Cfg.ReadReplicaMaxStaleness),
+	)
+	for {
+		select {
+		case <-time.After(readReplicaStalenessCheckInterval):
+		case <-s.stopping:
+			return
+		}
+
+		if !s.IsReadReplica() {
+			continue
+		}
+		s.probeReadReplicaStaleness()
+		if d, ok := s.replicaStaleness.staleness(time.Now()); ok {
+			readReplicaStaleness.Set(d.Seconds())
+		} else {
+			readReplicaStaleness.Set(math.Inf(1))
+		}
+	}
+}
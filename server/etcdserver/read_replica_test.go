@@ -0,0 +1,95 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestStalenessTracker(t *testing.T) {
+	start := time.Now()
+	var tracker stalenessTracker
+
+	_, ok := tracker.staleness(start)
+	assert.False(t, ok, "never caught up with the leader")
+
+	tracker.observeApplied(10)
+	tracker.observeLeader(start, 10)
+	d, ok := tracker.staleness(start.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+
+	// the leader moves ahead; the local member is fresh as of the last observation it applied.
+	tracker.observeLeader(start.Add(2*time.Second), 20)
+	tracker.observeLeader(start.Add(3*time.Second), 30)
+	d, _ = tracker.staleness(start.Add(4 * time.Second))
+	assert.Equal(t, 4*time.Second, d)
+
+	tracker.observeApplied(25)
+	d, _ = tracker.staleness(start.Add(4 * time.Second))
+	assert.Equal(t, 2*time.Second, d)
+
+	tracker.observeApplied(30)
+	d, _ = tracker.staleness(start.Add(4 * time.Second))
+	assert.Equal(t, time.Second, d)
+	assert.Empty(t, tracker.pending)
+
+	// observations within the granularity are merged into the earlier one.
+	tracker.observeLeader(start.Add(5*time.Second), 40)
+	tracker.observeLeader(start.Add(5*time.Second+stalenessTrackerGranularity/2), 50)
+	assert.Len(t, tracker.pending, 1)
+	tracker.observeApplied(45)
+	d, _ = tracker.staleness(start.Add(6 * time.Second))
+	assert.Equal(t, 3*time.Second, d)
+	tracker.observeApplied(50)
+	d, _ = tracker.staleness(start.Add(6 * time.Second))
+	assert.Equal(t, time.Second, d)
+}
+
+func TestStalenessTrackerFreshAtMonotonic(t *testing.T) {
+	start := time.Now()
+	var tracker stalenessTracker
+	tracker.observeApplied(10)
+	tracker.observeLeader(start.Add(2*time.Second), 10)
+	// a read index probe started before the latest observation completes.
+	tracker.observeLeader(start, 10)
+	d, _ := tracker.staleness(start.Add(3 * time.Second))
+	assert.Equal(t, time.Second, d)
+}
+
+func TestObserveRaftMessageIgnoresHeartbeats(t *testing.T) {
+	s := &EtcdServer{Cfg: config.ServerConfig{ReadReplica: true}}
+	s.replicaStaleness.observeApplied(10)
+
+	// the leader sends min(match, committed) in heartbeats, which a lagging
+	// member has always applied.
+	s.observeRaftMessage(raftpb.Message{Type: raftpb.MsgHeartbeat, Commit: 10})
+	_, ok := s.replicaStaleness.staleness(time.Now())
+	assert.False(t, ok)
+
+	s.observeRaftMessage(raftpb.Message{Type: raftpb.MsgApp, Commit: 20})
+	_, ok = s.replicaStaleness.staleness(time.Now())
+	assert.False(t, ok)
+
+	s.observeRaftMessage(raftpb.Message{Type: raftpb.MsgApp, Commit: 10})
+	_, ok = s.replicaStaleness.staleness(time.Now())
+	assert.True(t, ok)
+}
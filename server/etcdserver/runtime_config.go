@@ -0,0 +1,203 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// Names of the settings which can be changed at runtime with ConfigSet. They
+// match the server flags.
+const (
+	RuntimeConfigCompactionBatchLimit        = "compaction-batch-limit"
+	RuntimeConfigCompactionSleepInterval     = "compaction-sleep-interval"
+	RuntimeConfigWarningApplyDuration        = "warning-apply-duration"
+	RuntimeConfigMaxConcurrentStreams        = "max-concurrent-streams"
+	RuntimeConfigWatchProgressNotifyInterval = "watch-progress-notify-interval"
+	RuntimeConfigMaxRequestBytes             = "max-request-bytes"
+	RuntimeConfigBackendBatchInterval        = "backend-batch-interval"
+	RuntimeConfigBackendBatchLimit           = "backend-batch-limit"
+	RuntimeConfigBackendBatchLimitMax        = "backend-batch-limit-max"
+)
+
+// runtimeConfig holds the current value of the settings which can be changed
+// at runtime. Compaction settings are held by the mvcc store, and batch
+// settings by the backend.
+type runtimeConfig struct {
+	warningApplyDuration        time.Duration
+	maxConcurrentStreams        uint32
+	watchProgressNotifyInterval time.Duration
+	maxRequestBytes             uint
+}
+
+// currentRuntimeConfig returns the current runtime adjustable settings, which
+// default to the configuration the member started with.
+func (s *EtcdServer) currentRuntimeConfig() runtimeConfig {
+	s.runtimeCfgMu.RLock()
+	defer s.runtimeCfgMu.RUnlock()
+	return s.runtimeConfigLocked()
+}
+
+func (s *EtcdServer) runtimeConfigLocked() runtimeConfig {
+	if s.runtimeCfg != nil {
+		return *s.runtimeCfg
+	}
+	return runtimeConfig{
+		warningApplyDuration:        s.Cfg.WarningApplyDuration,
+		maxConcurrentStreams:        s.Cfg.MaxConcurrentStreams,
+		watchProgressNotifyInterval: s.Cfg.WatchProgressNotifyInterval,
+		maxRequestBytes:             s.Cfg.MaxRequestBytes,
+	}
+}
+
+// WarningApplyDuration returns the current duration after which applying a
+// request logs a warning.
+func (s *EtcdServer) WarningApplyDuration() time.Duration {
+	return s.currentRuntimeConfig().warningApplyDuration
+}
+
+// MaxConcurrentStreams returns the current maximum number of concurrent
+// streams of a client connection, zero meaning no limit. It never exceeds
+// the value the member started with, which is the limit negotiated with
+// HTTP/2 clients.
+func (s *EtcdServer) MaxConcurrentStreams() uint32 {
+	return s.currentRuntimeConfig().maxConcurrentStreams
+}
+
+// WatchProgressNotifyInterval returns the current interval of watch progress
+// notifications.
+func (s *EtcdServer) WatchProgressNotifyInterval() time.Duration {
+	return s.currentRuntimeConfig().watchProgressNotifyInterval
+}
+
+// MaxRequestBytes returns the current maximum size of a request sent over
+// raft. It never exceeds the value the member started with, which bounds
+// the size of the gRPC messages the member receives.
+func (s *EtcdServer) MaxRequestBytes() uint {
+	return s.currentRuntimeConfig().maxRequestBytes
+}
+
+// SetRuntimeConfig changes the given settings of the local member. Either all
+// settings are changed, or none if any of them is invalid. The changes are
+// not persisted across restarts. It returns the current value of all
+// runtime adjustable settings.
+func (s *EtcdServer) SetRuntimeConfig(settings []*pb.ConfigSetting) ([]*pb.ConfigSetting, error) {
+	s.runtimeCfgMu.Lock()
+	defer s.runtimeCfgMu.Unlock()
+
+	next := s.runtimeConfigLocked()
+	compaction := s.kv.CompactionConfig()
+	be := s.Backend()
+	batch := be.BatchConfig()
+	for _, setting := range settings {
+		if err := s.parseRuntimeSetting(&next, &compaction, &batch, setting); err != nil {
+			return nil, err
+		}
+	}
+
+	s.kv.SetCompactionConfig(compaction.CompactionBatchLimit, compaction.CompactionSleepInterval)
+	be.SetBatchConfig(batch)
+	s.runtimeCfg = &next
+	for _, setting := range settings {
+		s.lg.Info(
+			"changed runtime config",
+			zap.String("name", setting.Name),
+			zap.String("value", setting.Value),
+		)
+	}
+
+	return []*pb.ConfigSetting{
+		{Name: RuntimeConfigCompactionBatchLimit, Value: strconv.Itoa(compaction.CompactionBatchLimit)},
+		{Name: RuntimeConfigCompactionSleepInterval, Value: compaction.CompactionSleepInterval.String()},
+		{Name: RuntimeConfigWarningApplyDuration, Value: next.warningApplyDuration.String()},
+		{Name: RuntimeConfigMaxConcurrentStreams, Value: strconv.FormatUint(uint64(next.maxConcurrentStreams), 10)},
+		{Name: RuntimeConfigWatchProgressNotifyInterval, Value: next.watchProgressNotifyInterval.String()},
+		{Name: RuntimeConfigMaxRequestBytes, Value: strconv.FormatUint(uint64(next.maxRequestBytes), 10)},
+		{Name: RuntimeConfigBackendBatchInterval, Value: batch.Interval.String()},
+		{Name: RuntimeConfigBackendBatchLimit, Value: strconv.Itoa(batch.Limit)},
+		{Name: RuntimeConfigBackendBatchLimitMax, Value: strconv.Itoa(batch.LimitMax)},
+	}, nil
+}
+
+func (s *EtcdServer) parseRuntimeSetting(rc *runtimeConfig, compaction *mvcc.StoreConfig, batch *backend.BatchConfig, setting *pb.ConfigSetting) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s=%q %s", errors.ErrInvalidRuntimeConfig, setting.Name, setting.Value, reason)
+	}
+	switch setting.Name {
+	case RuntimeConfigCompactionBatchLimit, RuntimeConfigBackendBatchLimit:
+		n, err := strconv.Atoi(setting.Value)
+		if err != nil || n <= 0 {
+			return invalid("must be a positive integer")
+		}
+		if setting.Name == RuntimeConfigBackendBatchLimit {
+			batch.Limit = n
+		} else {
+			compaction.CompactionBatchLimit = n
+		}
+	case RuntimeConfigBackendBatchLimitMax:
+		// zero disables the adaptive batch limit.
+		n, err := strconv.Atoi(setting.Value)
+		if err != nil || n < 0 {
+			return invalid("must be a non-negative integer")
+		}
+		batch.LimitMax = n
+	case RuntimeConfigCompactionSleepInterval, RuntimeConfigWarningApplyDuration, RuntimeConfigWatchProgressNotifyInterval, RuntimeConfigBackendBatchInterval:
+		d, err := time.ParseDuration(setting.Value)
+		if err != nil || d <= 0 {
+			return invalid("must be a positive duration")
+		}
+		switch setting.Name {
+		case RuntimeConfigCompactionSleepInterval:
+			compaction.CompactionSleepInterval = d
+		case RuntimeConfigWarningApplyDuration:
+			rc.warningApplyDuration = d
+		case RuntimeConfigBackendBatchInterval:
+			batch.Interval = d
+		default:
+			rc.watchProgressNotifyInterval = d
+		}
+	case RuntimeConfigMaxConcurrentStreams:
+		n, err := strconv.ParseUint(setting.Value, 10, 32)
+		if err != nil || n == 0 {
+			return invalid("must be a positive integer")
+		}
+		// zero means no limit, as for gRPC.
+		if s.Cfg.MaxConcurrentStreams > 0 && uint32(n) > s.Cfg.MaxConcurrentStreams {
+			return invalid(fmt.Sprintf("must not exceed the startup value %d", s.Cfg.MaxConcurrentStreams))
+		}
+		rc.maxConcurrentStreams = uint32(n)
+	case RuntimeConfigMaxRequestBytes:
+		n, err := strconv.ParseUint(setting.Value, 10, 0)
+		if err != nil || n == 0 {
+			return invalid("must be a positive integer")
+		}
+		if uint(n) > s.Cfg.MaxRequestBytes {
+			return invalid(fmt.Sprintf("must not exceed the startup value %d", s.Cfg.MaxRequestBytes))
+		}
+		rc.maxRequestBytes = uint(n)
+	default:
+		return invalid("is not adjustable at runtime")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	errorspkg "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+const (
+	// defaultScrubRateBytes is the number of bytes per second a scrub reads
+	// from the backend if the request does not set it.
+	defaultScrubRateBytes = 10 * 1024 * 1024
+	// maxScrubFindings is the number of problems kept in the scrub status.
+	maxScrubFindings = 100
+)
+
+// scrubber keeps the status of the last scrub of the backend.
+type scrubber struct {
+	mu sync.Mutex
+	// cancel cancels the running scrub, or is nil if no scrub is running.
+	cancel context.CancelFunc
+	status pb.ScrubResponse
+}
+
+// Scrub starts, cancels, or reports the scrub of the backend of the local
+// member. Only one scrub runs at a time; starting a scrub while one is
+// running reports the running scrub.
+func (s *EtcdServer) Scrub(ctx context.Context, r *pb.ScrubRequest) (*pb.ScrubResponse, error) {
+	sc := &s.scrubber
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	switch r.Action {
+	case pb.ScrubRequest_START:
+		if sc.cancel != nil {
+			break
+		}
+		select {
+		case <-s.stopping:
+			return nil, errors.ErrStopped
+		default:
+		}
+		rateBytes := r.RateBytes
+		if rateBytes <= 0 {
+			rateBytes = defaultScrubRateBytes
+		}
+		var sctx context.Context
+		sctx, sc.cancel = context.WithCancel(s.ctx)
+		sc.status = pb.ScrubResponse{
+			State:     pb.ScrubResponse_RUNNING,
+			StartTime: time.Now().Unix(),
+		}
+		s.GoAttach(func() { s.scrub(sctx, rateBytes, r.CheckPages) })
+	case pb.ScrubRequest_CANCEL:
+		if sc.cancel != nil {
+			sc.cancel()
+		}
+	}
+	resp := sc.status
+	resp.Findings = append([]string(nil), sc.status.Findings...)
+	return &resp, nil
+}
+
+func (s *EtcdServer) scrub(ctx context.Context, rateBytes int64, checkPages bool) {
+	lg := s.Logger()
+	sc := &s.scrubber
+	lg.Info("scrub started", zap.Int64("rate-bytes", rateBytes), zap.Bool("check-pages", checkPages))
+
+	found := func(err error) {
+		scrubFindings.Inc()
+		sc.mu.Lock()
+		defer sc.mu.Unlock()
+		sc.status.FindingsCount++
+		if len(sc.status.Findings) < maxScrubFindings {
+			sc.status.Findings = append(sc.status.Findings, err.Error())
+			lg.Warn("scrub found a problem", zap.Error(err))
+		}
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(rateBytes), int(rateBytes))
+	read := func(ctx context.Context, bytes, revisions int64) error {
+		scrubBytesRead.Add(float64(bytes))
+		sc.mu.Lock()
+		sc.status.BytesRead += bytes
+		sc.status.RevisionsChecked += revisions
+		sc.mu.Unlock()
+		// a batch may be larger than the burst of the limiter.
+		for n := bytes; n > 0; n -= rateBytes {
+			if err := limiter.WaitN(ctx, int(min(n, rateBytes))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if checkPages {
+		var errs []error
+		errs, err = s.Backend().CheckPages(ctx, func(ctx context.Context, n int64) error {
+			return read(ctx, n, 0)
+		})
+		for _, perr := range errs {
+			found(fmt.Errorf("page check: %w", perr))
+		}
+	}
+	if err == nil {
+		_, err = s.KV().Scrub(ctx, func(ctx context.Context, batch mvcc.ScrubStats) error {
+			return read(ctx, batch.Bytes, batch.Revisions)
+		}, found)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.cancel()
+	sc.cancel = nil
+	sc.status.FinishTime = time.Now().Unix()
+	switch {
+	case err == nil:
+		sc.status.State = pb.ScrubResponse_FINISHED
+		scrubLastFinished.Set(float64(sc.status.FinishTime))
+	case errorspkg.Is(err, context.Canceled):
+		sc.status.State = pb.ScrubResponse_CANCELED
+	default:
+		sc.status.State = pb.ScrubResponse_FAILED
+		sc.status.Error = err.Error()
+	}
+	lg.Info(
+		"scrub stopped",
+		zap.Stringer("state", sc.status.State),
+		zap.Int64("bytes-read", sc.status.BytesRead),
+		zap.Int64("revisions-checked", sc.status.RevisionsChecked),
+		zap.Int64("findings", sc.status.FindingsCount),
+		zap.Error(err),
+	)
+}
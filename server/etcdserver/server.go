@@ -59,6 +59,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v2store"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
@@ -119,6 +120,10 @@ var (
 	// (since it will timeout).
 	monitorVersionInterval = rafthttp.ConnWriteTimeout - time.Second
 
+	// monitorLeadershipPriorityInterval is the interval at which the leader
+	// checks whether a member with a higher leadership priority is available.
+	monitorLeadershipPriorityInterval = 5 * time.Second
+
 	recommendedMaxRequestBytesString = humanize.Bytes(uint64(recommendedMaxRequestBytes))
 	storeMemberAttributeRegexp       = regexp.MustCompile(path.Join(membership.StoreMembersPrefix, "[[:xdigit:]]{1,16}", "attributes"))
 )
@@ -221,6 +226,11 @@ type EtcdServer struct {
 	readych chan struct{}
 	Cfg     config.ServerConfig
 
+	// drainc is closed when the server starts draining for maintenance.
+	drainc    chan struct{}
+	drainOnce sync.Once
+	draining  atomic.Bool
+
 	lgMu *sync.RWMutex
 	lg   *zap.Logger
 
@@ -233,6 +243,8 @@ type EtcdServer struct {
 	// readNotifier is used to notify the read routine that it can process the request
 	// when there is no error
 	readNotifier *notifier
+	// readNotifierWaiters is the number of linearizable reads waiting on readNotifier.
+	readNotifierWaiters atomic.Int64
 
 	// stop signals the run goroutine should shutdown.
 	stop chan struct{}
@@ -256,13 +268,30 @@ type EtcdServer struct {
 
 	applyWait wait.WaitTime
 
-	kv         mvcc.WatchableKV
-	lessor     lease.Lessor
-	bemu       sync.RWMutex
-	be         backend.Backend
-	beHooks    *serverstorage.BackendHooks
-	authStore  auth.AuthStore
-	alarmStore *v3alarm.AlarmStore
+	kv mvcc.WatchableKV
+	// runtimeCfgMu protects runtimeCfg, which overrides the settings of Cfg
+	// changed at runtime.
+	runtimeCfgMu sync.RWMutex
+	runtimeCfg   *runtimeConfig
+	lessor       lease.Lessor
+	bemu         sync.RWMutex
+	be           backend.Backend
+	beHooks      *serverstorage.BackendHooks
+	authStore    auth.AuthStore
+	alarmStore   *v3alarm.AlarmStore
+	// valuePolicyStore holds the value policies of key prefixes.
+	valuePolicyStore *v3valuepolicy.ValuePolicyStore
+
+	// applyTraces maps the IDs of sampled requests proposed by the member to
+	// the trace of the request, linking the backend commit to the trace.
+	applyTraces sync.Map
+
+	// userWatchers counts the watchers of the authenticated users, enforcing
+	// MaxWatchersPerUser.
+	userWatchers userWatchers
+
+	// revisionSnapshots are the pending requests for snapshots at a revision.
+	revisionSnapshots revisionSnapshots
 
 	stats  *stats.ServerStats
 	lstats *stats.LeaderStats
@@ -292,6 +321,20 @@ type EtcdServer struct {
 	firstCommitInTerm     *notify.Notifier
 	clusterVersionChanged *notify.Notifier
 
+	// replicaStaleness tracks how far the local data lags behind the leader
+	// when the local member runs as a read replica.
+	replicaStaleness stalenessTracker
+
+	// leaderLease tracks follower acknowledgements while the local member is
+	// leader and lease based reads are enabled, and the follower leases it
+	// granted.
+	leaderLease leaderLease
+	// followerLease is the follower lease granted to the local member.
+	followerLease followerLease
+
+	// scrubber keeps the status of the last scrub of the backend.
+	scrubber scrubber
+
 	*AccessController
 	// forceDiskSnapshot can force snapshot be triggered after apply, independent of the snapshotCount.
 	// Should only be set within apply code path. Used to force snapshot after cluster version downgrade.
@@ -322,6 +365,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	heartbeat := time.Duration(cfg.TickMs) * time.Millisecond
 	srv = &EtcdServer{
 		readych:               make(chan struct{}),
+		drainc:                make(chan struct{}),
 		Cfg:                   cfg,
 		lgMu:                  new(sync.RWMutex),
 		lg:                    cfg.Logger,
@@ -330,7 +374,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		snapshotter:           b.ss,
 		r:                     *b.raft.newRaftNode(b.ss, b.storage.wal.w, b.cluster.cl),
 		memberID:              b.cluster.nodeID,
-		attributes:            membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice()},
+		attributes:            membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice(), LeadershipPriority: cfg.LeadershipPriority, ReadReplica: cfg.ReadReplica},
 		cluster:               b.cluster.cl,
 		stats:                 sstats,
 		lstats:                lstats,
@@ -356,6 +400,8 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		CheckpointInterval:         cfg.LeaseCheckpointInterval,
 		CheckpointPersist:          cfg.ServerFeatureGate.Enabled(features.LeaseCheckpointPersist),
 		ExpiredLeasesRetryInterval: srv.Cfg.ReqTimeout(),
+		GracePeriod:                cfg.LeaseGracePeriod,
+		Clock:                      cfg.Clock,
 	})
 
 	tp, err := auth.NewTokenProvider(cfg.Logger, cfg.AuthToken,
@@ -372,11 +418,14 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	mvccStoreConfig := mvcc.StoreConfig{
 		CompactionBatchLimit:    cfg.CompactionBatchLimit,
 		CompactionSleepInterval: cfg.CompactionSleepInterval,
+		MonitoredKeyPrefixes:    cfg.MetricsKeyPrefixes,
+		MonitoredKeySizeBuckets: cfg.MetricsKeyPrefixSizeBuckets,
 	}
-	srv.kv = mvcc.New(srv.Logger(), srv.be, srv.lessor, mvccStoreConfig)
+	srv.kv = mvcc.New(subsystemLogger(cfg, LogSubsystemMVCC), srv.be, srv.lessor, mvccStoreConfig)
 	srv.corruptionChecker = newCorruptionChecker(cfg.Logger, srv, srv.kv.HashStorage())
 
-	srv.authStore = auth.NewAuthStore(srv.Logger(), schema.NewAuthBackend(srv.Logger(), srv.be), tp, int(cfg.BcryptCost))
+	authLogger := subsystemLogger(cfg, LogSubsystemAuth)
+	srv.authStore = auth.NewAuthStore(authLogger, schema.NewAuthBackend(authLogger, srv.be), tp, int(cfg.BcryptCost))
 
 	newSrv := srv // since srv == nil in defer if srv is returned as nil
 	defer func() {
@@ -387,7 +436,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		}
 	}()
 	if num := cfg.AutoCompactionRetention; num != 0 {
-		srv.compactor, err = v3compactor.New(cfg.Logger, cfg.AutoCompactionMode, num, srv.kv, srv)
+		srv.compactor, err = v3compactor.New(cfg.Logger, cfg.Clock, cfg.AutoCompactionMode, num, cfg.AutoCompactionPrefixRetentions, srv.kv, srv)
 		if err != nil {
 			return nil, err
 		}
@@ -397,6 +446,9 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	if err = srv.restoreAlarms(); err != nil {
 		return nil, err
 	}
+	if err = srv.restoreValuePolicies(); err != nil {
+		return nil, err
+	}
 	srv.uberApply = srv.NewUberApplier()
 
 	if srv.FeatureEnabled(features.LeaseCheckpoint) {
@@ -493,6 +545,12 @@ func (s *EtcdServer) adjustTicks() {
 		lg.Info("skipping initial election tick advance", zap.Int("election-ticks", s.Cfg.ElectionTicks))
 		return
 	}
+	// a restarted member must not campaign before it waited out the leader
+	// lease it may have acknowledged.
+	if s.FeatureEnabled(features.LeaseBasedReads) {
+		lg.Info("skipping initial election tick advance; lease based reads are enabled", zap.Int("election-ticks", s.Cfg.ElectionTicks))
+		return
+	}
 	lg.Info("starting initial election tick advance", zap.Int("election-ticks", s.Cfg.ElectionTicks))
 
 	// retry up to "rafthttp.ConnReadTimeout", which is 5-sec
@@ -548,6 +606,9 @@ func (s *EtcdServer) Start() {
 	s.GoAttach(s.monitorKVHash)
 	s.GoAttach(s.monitorCompactHash)
 	s.GoAttach(s.monitorDowngrade)
+	s.GoAttach(s.monitorLeadershipPriority)
+	s.GoAttach(s.monitorLearnerAutoPromotion)
+	s.GoAttach(s.monitorReadReplicaStaleness)
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -582,6 +643,7 @@ func (s *EtcdServer) start() {
 	s.readwaitc = make(chan struct{}, 1)
 	s.readNotifier = newNotifier()
 	s.leaderChanged = notify.NewNotifier()
+	s.restartLeaderLease()
 	if s.ClusterVersion() != nil {
 		lg.Info(
 			"starting etcd server",
@@ -661,6 +723,7 @@ type ServerPeerV2 interface {
 	ServerPeer
 	HashKVHandler() http.Handler
 	DowngradeEnabledHandler() http.Handler
+	BootstrapSnapshotHandler() http.Handler
 }
 
 func (s *EtcdServer) DowngradeInfo() *serverversion.DowngradeInfo { return s.cluster.DowngradeInfo() }
@@ -730,6 +793,10 @@ func (s *EtcdServer) Process(ctx context.Context, m raftpb.Message) error {
 	if m.Type == raftpb.MsgApp {
 		s.stats.RecvAppendReq(types.ID(m.From).String(), m.Size())
 	}
+	s.observeRaftMessage(m)
+	if !s.observeLeaderLease(m) {
+		return nil
+	}
 	return s.r.Step(ctx, m)
 }
 
@@ -759,6 +826,8 @@ type raftReadyHandler struct {
 	updateLead           func(lead uint64)
 	updateLeadership     func(newLeader bool)
 	updateCommittedIndex func(uint64)
+	// beforeSend is called with the messages the local member is about to send.
+	beforeSend func([]raftpb.Message)
 }
 
 func (s *EtcdServer) run() {
@@ -795,6 +864,7 @@ func (s *EtcdServer) run() {
 				}
 			}
 			if newLeader {
+				s.resetLeaderLease()
 				s.leaderChanged.Notify()
 			}
 			// TODO: remove the nil checking
@@ -809,6 +879,7 @@ func (s *EtcdServer) run() {
 				s.setCommittedIndex(ci)
 			}
 		},
+		beforeSend: s.observeSend,
 	}
 	s.r.start(rh)
 
@@ -978,6 +1049,7 @@ func (s *EtcdServer) applyAll(ep *etcdProgress, apply *toApply) {
 
 	proposalsApplied.Set(float64(ep.appliedi))
 	s.applyWait.Trigger(ep.appliedi)
+	s.replicaStaleness.observeApplied(ep.appliedi)
 
 	// wait for the raft routine to finish the disk writes before triggering a
 	// snapshot. or applied index might be greater than the last index in raft
@@ -1071,6 +1143,8 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	// We do not want to wait on closing the old backend.
 	s.bemu.Lock()
 	oldbe := s.be
+	// keep the batch config changed at runtime.
+	newbe.SetBatchConfig(oldbe.BatchConfig())
 	go func() {
 		lg.Info("closing old backend file")
 		defer func() {
@@ -1092,6 +1166,14 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 
 	lg.Info("restored alarm store")
 
+	lg.Info("restoring value policy store")
+
+	if err := s.restoreValuePolicies(); err != nil {
+		lg.Panic("failed to restore value policy store", zap.Error(err))
+	}
+
+	lg.Info("restored value policy store")
+
 	if s.authStore != nil {
 		lg.Info("restoring auth store")
 
@@ -1147,8 +1229,8 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 }
 
 func (s *EtcdServer) NewUberApplier() apply.UberApplier {
-	return apply.NewUberApplier(s.lg, s.be, s.KV(), s.alarmStore, s.authStore, s.lessor, s.cluster, s, s, s.consistIndex,
-		s.Cfg.WarningApplyDuration, s.Cfg.ServerFeatureGate.Enabled(features.TxnModeWriteWithSharedBuffer), s.Cfg.QuotaBackendBytes)
+	return apply.NewUberApplier(s.lg, s.be, s.KV(), s.alarmStore, s.valuePolicyStore, s.authStore, s.lessor, s.cluster, s, s, s.consistIndex,
+		s.WarningApplyDuration, s.Cfg.ServerFeatureGate.Enabled(features.TxnModeWriteWithSharedBuffer), s.Cfg.QuotaBackendBytes)
 }
 
 func verifySnapshotIndex(snapshot raftpb.Snapshot, cindex uint64) {
@@ -1242,6 +1324,7 @@ func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) er
 		zap.String("transferee-member-id", types.ID(transferee).String()),
 	)
 
+	s.transferLeaderLease()
 	s.r.TransferLeadership(ctx, lead, transferee)
 	for s.Lead() != transferee {
 		select {
@@ -1264,6 +1347,12 @@ func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) er
 
 // TryTransferLeadershipOnShutdown transfers the leader to the chosen transferee. It is only used in server graceful shutdown.
 func (s *EtcdServer) TryTransferLeadershipOnShutdown() error {
+	return s.tryTransferLeadership(s.ctx)
+}
+
+// tryTransferLeadership transfers the leader to the chosen transferee within
+// the request timeout, unless the given context is done first.
+func (s *EtcdServer) tryTransferLeadership(ctx context.Context) error {
 	lg := s.Logger()
 	if !s.isLeader() {
 		lg.Info(
@@ -1289,7 +1378,7 @@ func (s *EtcdServer) TryTransferLeadershipOnShutdown() error {
 	}
 
 	tm := s.Cfg.ReqTimeout()
-	ctx, cancel := context.WithTimeout(s.ctx, tm)
+	ctx, cancel := context.WithTimeout(ctx, tm)
 	err := s.MoveLeader(ctx, s.Lead(), uint64(transferee))
 	cancel()
 	return err
@@ -1319,6 +1408,37 @@ func (s *EtcdServer) Stop() {
 	s.HardStop()
 }
 
+// Drain puts the server into maintenance mode before shutdown. The server
+// stops accepting new client connections and closes its client streams, which
+// is signaled through DrainNotify, and transfers its leadership if it is the
+// leader, unless ctx is done first. It returns true if the leadership was
+// transferred.
+// Draining cannot be undone; the server is expected to be stopped afterwards.
+func (s *EtcdServer) Drain(ctx context.Context) (bool, error) {
+	lg := s.Logger()
+	s.drainOnce.Do(func() {
+		lg.Info("draining server", zap.String("local-member-id", s.MemberID().String()))
+		s.draining.Store(true)
+		if s.drainc != nil {
+			close(s.drainc)
+		}
+	})
+
+	if !s.isLeader() {
+		return false, nil
+	}
+	if err := s.tryTransferLeadership(ctx); err != nil {
+		return false, err
+	}
+	return !s.isLeader(), nil
+}
+
+// DrainNotify returns a channel that is closed when the server starts draining.
+func (s *EtcdServer) DrainNotify() <-chan struct{} { return s.drainc }
+
+// IsDraining returns true if the server is draining for maintenance.
+func (s *EtcdServer) IsDraining() bool { return s.draining.Load() }
+
 // ReadyNotify returns a channel that will be closed when the server
 // is ready to serve client requests
 func (s *EtcdServer) ReadyNotify() <-chan struct{} { return s.readych }
@@ -1490,7 +1610,13 @@ func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) ([]*membershi
 	if err := s.checkMembershipOperationPermission(ctx); err != nil {
 		return nil, err
 	}
+	return s.proposePromoteMember(ctx, id)
+}
 
+// proposePromoteMember checks whether the to-be-promoted learner node is ready and
+// proposes the promote confChange to raft, without checking the permission of the
+// requester.
+func (s *EtcdServer) proposePromoteMember(ctx context.Context, id uint64) ([]*membership.Member, error) {
 	// check if we can promote this learner.
 	if err := s.mayPromoteMember(types.ID(id)); err != nil {
 		return nil, err
@@ -1520,6 +1646,14 @@ func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) ([]*membershi
 
 func (s *EtcdServer) mayPromoteMember(id types.ID) error {
 	lg := s.Logger()
+	if m := s.cluster.Member(id); m != nil && m.ReadReplica {
+		lg.Warn(
+			"rejecting member promote request; member is a read replica",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("requested-member-promote-id", id.String()),
+		)
+		return errors.ErrMemberIsReadReplica
+	}
 	if err := s.isLearnerReady(lg, uint64(id)); err != nil {
 		return err
 	}
@@ -1772,8 +1906,10 @@ func (s *EtcdServer) publishV3(timeout time.Duration) {
 	req := &membershippb.ClusterMemberAttrSetRequest{
 		Member_ID: uint64(s.MemberID()),
 		MemberAttributes: &membershippb.Attributes{
-			Name:       s.attributes.Name,
-			ClientUrls: s.attributes.ClientURLs,
+			Name:               s.attributes.Name,
+			ClientUrls:         s.attributes.ClientURLs,
+			LeadershipPriority: s.attributes.LeadershipPriority,
+			ReadReplica:        s.attributes.ReadReplica,
 		},
 	}
 	// gofail: var beforePublishing struct{}
@@ -1868,8 +2004,12 @@ func (s *EtcdServer) apply(
 	raftAdvancedC <-chan struct{},
 ) (appliedt uint64, appliedi uint64, shouldStop bool) {
 	s.lg.Debug("Applying entries", zap.Int("num-entries", len(es)))
+	var exposable uint64
 	for i := range es {
 		e := es[i]
+		if e.Index > exposable {
+			exposable = s.waitFollowerLeases(e.Index)
+		}
 		index := s.consistIndex.ConsistentIndex()
 		s.lg.Debug("Applying entry",
 			zap.Uint64("consistent-index", index),
@@ -1891,6 +2031,7 @@ func (s *EtcdServer) apply(
 			s.applyEntryNormal(&e, shouldApplyV3)
 			s.setAppliedIndex(e.Index)
 			s.setTerm(e.Term)
+			s.takeRevisionSnapshots()
 
 		case raftpb.EntryConfChange:
 			// gofail: var beforeApplyOneConfChange struct{}
@@ -1932,6 +2073,9 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 	// skip it in advance to avoid some potential bug in the future
 	if len(e.Data) == 0 {
 		s.firstCommitInTerm.Notify()
+		if s.isLeader() && e.Term == s.r.Status().Term {
+			s.leaderLease.commitInTerm()
+		}
 
 		// promote lessor when the local member is leader and finished
 		// applying all entries from the last term.
@@ -1969,6 +2113,9 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 		if !needResult && raftReq.Txn != nil {
 			removeNeedlessRangeReqs(raftReq.Txn)
 		}
+		if needResult {
+			s.linkCommitToTrace(id)
+		}
 		ar = s.applyInternalRaftRequest(&raftReq, shouldApplyV3)
 	}
 
@@ -1980,6 +2127,9 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 	if ar == nil {
 		return
 	}
+	for _, hook := range s.Cfg.PostApplyHooks {
+		hook(e.Index, &raftReq, ar.Resp, ar.Err)
+	}
 
 	if !errorspkg.Is(ar.Err, errors.ErrNoSpace) || len(s.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.w.Trigger(id, ar)
@@ -2006,7 +2156,7 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 }
 
 func (s *EtcdServer) applyInternalRaftRequest(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3) *apply.Result {
-	if r.ClusterVersionSet == nil && r.ClusterMemberAttrSet == nil && r.DowngradeInfoSet == nil && r.DowngradeVersionTest == nil {
+	if r.ClusterVersionSet == nil && r.ClusterMemberAttrSet == nil && r.DowngradeInfoSet == nil && r.ClusterMetadataSet == nil && r.DowngradeVersionTest == nil {
 		if !shouldApplyV3 {
 			return nil
 		}
@@ -2016,7 +2166,7 @@ func (s *EtcdServer) applyInternalRaftRequest(r *pb.InternalRaftRequest, shouldA
 	op := "unknown"
 	defer func(start time.Time) {
 		txn.ApplySecObserve("v3", op, true, time.Since(start))
-		txn.WarnOfExpensiveRequest(s.lg, s.Cfg.WarningApplyDuration, start, &pb.InternalRaftStringer{Request: r}, nil, nil)
+		txn.WarnOfExpensiveRequest(s.lg, s.WarningApplyDuration(), start, &pb.InternalRaftStringer{Request: r}, nil, nil)
 	}(time.Now())
 	switch {
 	case r.ClusterVersionSet != nil:
@@ -2029,6 +2179,11 @@ func (s *EtcdServer) applyInternalRaftRequest(r *pb.InternalRaftRequest, shouldA
 	case r.DowngradeInfoSet != nil:
 		op = "DowngradeInfoSet" // Implemented in 3.5.x
 		membershipApplier.DowngradeInfoSet(r.DowngradeInfoSet, shouldApplyV3)
+	case r.ClusterMetadataSet != nil:
+		op = "ClusterMetadataSet" // Implemented in 3.6.x
+		if err := membershipApplier.ClusterMetadataSet(r.ClusterMetadataSet, shouldApplyV3); err != nil {
+			return &apply.Result{Err: err}
+		}
 	case r.DowngradeVersionTest != nil:
 		op = "DowngradeVersionTest" // Implemented in 3.6 for test only
 		// do nothing, we are just to ensure etcdserver don't panic in case
@@ -2354,6 +2509,56 @@ func (s *EtcdServer) monitorCompactHash() {
 	}
 }
 
+// monitorLeadershipPriority every monitorLeadershipPriorityInterval checks if it's
+// the leader and transfers leadership to a healthy voting member with a higher
+// leadership priority, if any.
+func (s *EtcdServer) monitorLeadershipPriority() {
+	if !s.FeatureEnabled(features.LeadershipPriority) {
+		return
+	}
+	lg := s.Logger()
+	for {
+		select {
+		case <-time.After(monitorLeadershipPriorityInterval):
+		case <-s.stopping:
+			lg.Info("server has stopped; stopping leadership priority's monitor")
+			return
+		}
+
+		if !s.isLeader() || !s.hasMultipleVotingMembers() {
+			continue
+		}
+		rs := s.raftStatus()
+		if rs.Progress == nil || rs.LeadTransferee != raft.None {
+			continue
+		}
+		transferee, ok := preferredLeader(s.r.transport, time.Now().Add(-HealthInterval), s.MemberID(), s.cluster.VotingMembers(), rs.Progress)
+		if !ok {
+			continue
+		}
+
+		lg.Info(
+			"transferring leadership to member with higher leadership priority",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("transferee-member-id", transferee.String()),
+		)
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		err := s.MoveLeader(ctx, s.Lead(), uint64(transferee))
+		cancel()
+		if err != nil {
+			leadershipPriorityTransfers.WithLabelValues("failure").Inc()
+			lg.Warn(
+				"failed to transfer leadership to member with higher leadership priority",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.String("transferee-member-id", transferee.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		leadershipPriorityTransfers.WithLabelValues("success").Inc()
+	}
+}
+
 func (s *EtcdServer) updateClusterVersionV3(ver string) {
 	lg := s.Logger()
 
@@ -2453,6 +2658,12 @@ func (s *EtcdServer) Backend() backend.Backend {
 
 func (s *EtcdServer) AuthStore() auth.AuthStore { return s.authStore }
 
+func (s *EtcdServer) Lessor() lease.Lessor { return s.lessor }
+
+// WALSaveInProgress returns how long the save of raft entries to the WAL in
+// progress has been running, or zero if no save is in progress.
+func (s *EtcdServer) WALSaveInProgress() time.Duration { return s.r.saveInProgress() }
+
 func (s *EtcdServer) restoreAlarms() error {
 	as, err := v3alarm.NewAlarmStore(s.lg, schema.NewAlarmBackend(s.lg, s.be))
 	if err != nil {
@@ -2462,6 +2673,15 @@ func (s *EtcdServer) restoreAlarms() error {
 	return nil
 }
 
+func (s *EtcdServer) restoreValuePolicies() error {
+	ps, err := v3valuepolicy.NewValuePolicyStore(s.lg, schema.NewValuePolicyBackend(s.lg, s.be))
+	if err != nil {
+		return err
+	}
+	s.valuePolicyStore = ps
+	return nil
+}
+
 // GoAttach creates a goroutine on a given function and tracks it using
 // the etcdserver waitgroup.
 // The passed function should interrupt on s.StoppingNotify().
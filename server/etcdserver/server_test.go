@@ -94,6 +94,7 @@ func TestApplyRepeat(t *testing.T) {
 		transport:   newNopTransporter(),
 	})
 	s := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           zaptest.NewLogger(t),
 		r:            *r,
@@ -336,6 +337,7 @@ func newServer(t *testing.T, recorder *nodeRecorder) *EtcdServer {
 		betesting.Close(t, be)
 	})
 	srv := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           zaptest.NewLogger(t),
 		r:            *newRaftNode(raftNodeConfig{lg: lg, Node: recorder}),
@@ -510,6 +512,7 @@ func TestApplyConfigChangeUpdatesConsistIndex(t *testing.T) {
 
 	ci := cindex.NewConsistentIndex(be)
 	srv := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           lg,
 		memberID:     1,
@@ -604,6 +607,7 @@ func TestApplyMultiConfChangeShouldStop(t *testing.T) {
 	})
 	ci := cindex.NewFakeConsistentIndex(0)
 	srv := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           lg,
 		memberID:     2,
@@ -970,6 +974,7 @@ func TestAddMember(t *testing.T) {
 		transport:   newNopTransporter(),
 	})
 	s := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           lg,
 		r:            *r,
@@ -1077,6 +1082,7 @@ func TestRemoveMember(t *testing.T) {
 		transport:   newNopTransporter(),
 	})
 	s := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           zaptest.NewLogger(t),
 		r:            *r,
@@ -1126,6 +1132,7 @@ func TestUpdateMember(t *testing.T) {
 		transport:   newNopTransporter(),
 	})
 	s := &EtcdServer{
+		Cfg:          config.ServerConfig{ServerFeatureGate: features.NewDefaultServerFeatureGate("test", nil)},
 		lgMu:         new(sync.RWMutex),
 		lg:           lg,
 		r:            *r,
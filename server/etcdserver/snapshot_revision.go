@@ -0,0 +1,146 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// SnapshotAtRevision returns a snapshot of the backend taken right after
+// applying the entry that brings the key-value store to the given revision.
+// As all members apply the same entries, the snapshots of all members at a
+// revision hold the same key-value store, leases, authentication and
+// membership. The revision must not be applied yet; the member waits until
+// it applies the revision. It returns ErrSnapshotRevisionApplied if the
+// member already applied the revision, or passed it by applying a raft
+// snapshot.
+func (s *EtcdServer) SnapshotAtRevision(ctx context.Context, rev int64) (backend.Snapshot, error) {
+	ch, err := s.revisionSnapshots.register(s.KV().Rev, rev)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case snap := <-ch:
+		if snap == nil {
+			return nil, errors.ErrSnapshotRevisionApplied
+		}
+		s.Logger().Info("created database snapshot at revision",
+			zap.Int64("revision", rev),
+			zap.Int64("bytes", snap.Size()),
+		)
+		return snap, nil
+	case <-ctx.Done():
+		s.revisionSnapshots.unregister(rev, ch)
+		return nil, ctx.Err()
+	case <-s.stopping:
+		s.revisionSnapshots.unregister(rev, ch)
+		return nil, errors.ErrStopped
+	}
+}
+
+// takeRevisionSnapshots is called by the apply loop after applying an entry
+// to take the snapshots requested at the current revision.
+func (s *EtcdServer) takeRevisionSnapshots() {
+	if s.revisionSnapshots.pending.Load() == 0 {
+		return
+	}
+	s.revisionSnapshots.take(s.KV().Rev(), s.Backend().Snapshot)
+}
+
+// revisionSnapshots are the pending requests for snapshots at a revision,
+// taken by the apply loop.
+type revisionSnapshots struct {
+	// pending is the number of pending requests, so that the apply loop
+	// only takes mu while there are any.
+	pending atomic.Int64
+
+	mu sync.Mutex
+	// waiters receive the snapshot at their revision, or nil once the
+	// revision was passed without taking it.
+	waiters map[int64][]chan backend.Snapshot
+}
+
+// register adds a request for a snapshot at rev, which must be after the
+// current revision.
+func (rs *revisionSnapshots) register(currentRev func() int64, rev int64) (chan backend.Snapshot, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	// counted before reading the current revision, so that the apply loop
+	// sees the request once it applies the revision.
+	rs.pending.Add(1)
+	if currentRev() >= rev {
+		rs.pending.Add(-1)
+		return nil, errors.ErrSnapshotRevisionApplied
+	}
+	if rs.waiters == nil {
+		rs.waiters = make(map[int64][]chan backend.Snapshot)
+	}
+	ch := make(chan backend.Snapshot, 1)
+	rs.waiters[rev] = append(rs.waiters[rev], ch)
+	return ch, nil
+}
+
+// unregister removes a request, closing its snapshot if it was taken meanwhile.
+func (rs *revisionSnapshots) unregister(rev int64, ch chan backend.Snapshot) {
+	rs.mu.Lock()
+	chs := rs.waiters[rev]
+	for i := range chs {
+		if chs[i] == ch {
+			rs.waiters[rev] = append(chs[:i:i], chs[i+1:]...)
+			if len(rs.waiters[rev]) == 0 {
+				delete(rs.waiters, rev)
+			}
+			rs.pending.Add(-1)
+			break
+		}
+	}
+	rs.mu.Unlock()
+
+	select {
+	case snap := <-ch:
+		if snap != nil {
+			snap.Close()
+		}
+	default:
+	}
+}
+
+// take takes the snapshots requested at the current revision rev, and fails
+// the requests at the revisions before it.
+func (rs *revisionSnapshots) take(rev int64, snapshot func() backend.Snapshot) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for r, chs := range rs.waiters {
+		if r > rev {
+			continue
+		}
+		for _, ch := range chs {
+			if r == rev {
+				ch <- snapshot()
+			} else {
+				ch <- nil
+			}
+		}
+		rs.pending.Add(-int64(len(chs)))
+		delete(rs.waiters, r)
+	}
+}
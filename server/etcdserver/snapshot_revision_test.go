@@ -0,0 +1,79 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestRevisionSnapshots(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	var rs revisionSnapshots
+	currentRev := int64(5)
+	rev := func() int64 { return currentRev }
+
+	_, err := rs.register(rev, 5)
+	require.ErrorIs(t, err, errors.ErrSnapshotRevisionApplied, "the current revision is already applied")
+
+	at6, err := rs.register(rev, 6)
+	require.NoError(t, err)
+	at7, err := rs.register(rev, 7)
+	require.NoError(t, err)
+	canceled, err := rs.register(rev, 7)
+	require.NoError(t, err)
+	rs.unregister(7, canceled)
+	assert.Equal(t, int64(2), rs.pending.Load())
+
+	currentRev = 6
+	rs.take(currentRev, be.Snapshot)
+	snap := <-at6
+	require.NotNil(t, snap)
+	require.NoError(t, snap.Close())
+	assert.Empty(t, at7, "revision 7 is not applied yet")
+
+	// revision 7 is passed by applying a raft snapshot.
+	currentRev = 8
+	rs.take(currentRev, be.Snapshot)
+	assert.Nil(t, <-at7)
+	assert.Zero(t, rs.pending.Load())
+	assert.Empty(t, rs.waiters)
+
+	// a snapshot taken while the request is unregistered is closed.
+	at9, err := rs.register(rev, 9)
+	require.NoError(t, err)
+	taken := &closeCountingSnapshot{Snapshot: be.Snapshot()}
+	rs.take(9, func() backend.Snapshot { return taken })
+	rs.unregister(9, at9)
+	assert.Equal(t, 1, taken.closed)
+}
+
+type closeCountingSnapshot struct {
+	backend.Snapshot
+	closed int
+}
+
+func (s *closeCountingSnapshot) Close() error {
+	s.closed++
+	return s.Snapshot.Close()
+}
@@ -129,6 +129,10 @@ func deleteRange(ctx context.Context, txnWrite mvcc.TxnWrite, dr *pb.DeleteRange
 		}
 	}
 
+	if dr.Lazy {
+		resp.Deleted, resp.Header.Revision = txnWrite.LazyDeleteRange(dr.Key, end)
+		return resp, nil
+	}
 	resp.Deleted, resp.Header.Revision = txnWrite.DeleteRange(dr.Key, end)
 	return resp, nil
 }
@@ -435,7 +439,7 @@ func checkRange(rv mvcc.ReadView, req *pb.RangeRequest) error {
 		return nil
 	case req.Revision > rv.Rev():
 		return mvcc.ErrFutureRev
-	case req.Revision < rv.FirstRev():
+	case req.Revision < rv.FirstRevOf(req.Key, req.RangeEnd):
 		return mvcc.ErrCompacted
 	}
 	return nil
@@ -21,6 +21,7 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
+	"go.etcd.io/raft/v3/tracker"
 )
 
 // isConnectedToQuorumSince checks whether the local member is connected to the
@@ -81,6 +82,54 @@ func longestConnected(tp rafthttp.Transporter, membs []types.ID) (types.ID, bool
 	return longest, true
 }
 
+// preferredLeader chooses the voting member with the highest leadership
+// priority, if that priority is higher than the one of the local member.
+// A candidate must have been connected to the local member since the given
+// time and its log must have caught up with the local log. Ties are broken
+// in favor of the candidate with the highest match index.
+// It returns false, if no member is preferred over the local member.
+func preferredLeader(tp rafthttp.Transporter, since time.Time, self types.ID, members []*membership.Member, progress map[uint64]tracker.Progress) (types.ID, bool) {
+	var (
+		selfPriority int32
+		found        bool
+	)
+	for _, m := range members {
+		if m.ID == self {
+			selfPriority = m.LeadershipPriority
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, false
+	}
+
+	selfMatch := progress[uint64(self)].Match
+	var (
+		preferred      types.ID
+		bestPriority   int32
+		bestMatch      uint64
+		foundPreferred bool
+	)
+	for _, m := range members {
+		if m.ID == self || m.IsLearner || m.LeadershipPriority <= selfPriority {
+			continue
+		}
+		if !isConnectedSince(tp, since, m.ID) {
+			continue
+		}
+		pr, ok := progress[uint64(m.ID)]
+		if !ok || float64(pr.Match) < float64(selfMatch)*readyPercentThreshold {
+			continue
+		}
+		if !foundPreferred || m.LeadershipPriority > bestPriority ||
+			(m.LeadershipPriority == bestPriority && pr.Match > bestMatch) {
+			preferred, bestPriority, bestMatch, foundPreferred = m.ID, m.LeadershipPriority, pr.Match, true
+		}
+	}
+	return preferred, foundPreferred
+}
+
 type notifier struct {
 	c   chan struct{}
 	err error
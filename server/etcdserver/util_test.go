@@ -26,6 +26,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
 	"go.etcd.io/raft/v3/raftpb"
+	"go.etcd.io/raft/v3/tracker"
 )
 
 func TestLongestConnected(t *testing.T) {
@@ -61,6 +62,104 @@ func TestLongestConnected(t *testing.T) {
 	}
 }
 
+func TestPreferredLeader(t *testing.T) {
+	now := time.Now()
+	member := func(id uint64, priority int32, isLearner bool) *membership.Member {
+		return &membership.Member{
+			ID:             types.ID(id),
+			RaftAttributes: membership.RaftAttributes{IsLearner: isLearner},
+			Attributes:     membership.Attributes{LeadershipPriority: priority},
+		}
+	}
+	connected := &nopTransporterWithActiveTime{activeMap: map[types.ID]time.Time{
+		1: now.Add(-time.Minute),
+		2: now.Add(-time.Minute),
+		3: now.Add(-time.Minute),
+		4: now.Add(-time.Minute),
+	}}
+	progress := func(matches ...uint64) map[uint64]tracker.Progress {
+		pr := make(map[uint64]tracker.Progress)
+		for i, m := range matches {
+			pr[uint64(i+1)] = tracker.Progress{Match: m}
+		}
+		return pr
+	}
+
+	tcs := []struct {
+		name          string
+		transport     rafthttp.Transporter
+		members       []*membership.Member
+		progress      map[uint64]tracker.Progress
+		wantPreferred bool
+		wantID        types.ID
+	}{
+		{
+			name:      "equal priorities",
+			transport: connected,
+			members:   []*membership.Member{member(1, 0, false), member(2, 0, false), member(3, 0, false)},
+			progress:  progress(100, 100, 100),
+		},
+		{
+			name:          "highest priority wins",
+			transport:     connected,
+			members:       []*membership.Member{member(1, 0, false), member(2, 5, false), member(3, 10, false)},
+			progress:      progress(100, 100, 100),
+			wantPreferred: true,
+			wantID:        3,
+		},
+		{
+			name:          "tie is broken by match index",
+			transport:     connected,
+			members:       []*membership.Member{member(1, 0, false), member(2, 10, false), member(3, 10, false)},
+			progress:      progress(100, 100, 99),
+			wantPreferred: true,
+			wantID:        2,
+		},
+		{
+			name:          "lagging member is skipped",
+			transport:     connected,
+			members:       []*membership.Member{member(1, 0, false), member(2, 5, false), member(3, 10, false)},
+			progress:      progress(100, 100, 10),
+			wantPreferred: true,
+			wantID:        2,
+		},
+		{
+			name:      "local member already has highest priority",
+			transport: connected,
+			members:   []*membership.Member{member(1, 10, false), member(2, 5, false), member(3, 5, false)},
+			progress:  progress(100, 100, 100),
+		},
+		{
+			name:      "learner is skipped",
+			transport: connected,
+			members:   []*membership.Member{member(1, 0, false), member(2, 0, false), member(4, 10, true)},
+			progress:  map[uint64]tracker.Progress{1: {Match: 100}, 2: {Match: 100}, 4: {Match: 100}},
+		},
+		{
+			name: "recently connected member is skipped",
+			transport: &nopTransporterWithActiveTime{activeMap: map[types.ID]time.Time{
+				2: now.Add(-time.Minute),
+				3: now,
+			}},
+			members:       []*membership.Member{member(1, 0, false), member(2, 5, false), member(3, 10, false)},
+			progress:      progress(100, 100, 100),
+			wantPreferred: true,
+			wantID:        2,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			id, ok := preferredLeader(tc.transport, now.Add(-HealthInterval), 1, tc.members, tc.progress)
+			if ok != tc.wantPreferred {
+				t.Fatalf("expected preferred %v, got %v", tc.wantPreferred, ok)
+			}
+			if id != tc.wantID {
+				t.Errorf("expected preferred leader %s, got %s", tc.wantID, id)
+			}
+		})
+	}
+}
+
 type nopTransporterWithActiveTime struct {
 	activeMap map[types.ID]time.Time
 }
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 
@@ -32,6 +34,7 @@ import (
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3valuepolicy"
 	apply2 "go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/etcdserver/txn"
@@ -112,7 +115,7 @@ func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRe
 	var resp *pb.RangeResponse
 	var err error
 	defer func(start time.Time) {
-		txn.WarnOfExpensiveReadOnlyRangeRequest(s.Logger(), s.Cfg.WarningApplyDuration, start, r, resp, err)
+		txn.WarnOfExpensiveReadOnlyRangeRequest(s.Logger(), s.WarningApplyDuration(), start, r, resp, err)
 		if resp != nil {
 			trace.AddField(
 				traceutil.Field{Key: "response_count", Value: len(resp.Kvs)},
@@ -128,6 +131,8 @@ func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRe
 		if err != nil {
 			return nil, err
 		}
+	} else if err = s.checkReadReplicaStaleness(); err != nil {
+		return nil, err
 	}
 	chk := func(ai *auth.AuthInfo) error {
 		return s.authStore.IsRangePermitted(ai, r.Key, r.RangeEnd)
@@ -171,6 +176,8 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 			if err != nil {
 				return nil, err
 			}
+		} else if err := s.checkReadReplicaStaleness(); err != nil {
+			return nil, err
 		}
 		var resp *pb.TxnResponse
 		var err error
@@ -179,7 +186,7 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 		}
 
 		defer func(start time.Time) {
-			txn.WarnOfExpensiveReadOnlyTxnRequest(s.Logger(), s.Cfg.WarningApplyDuration, start, r, resp, err)
+			txn.WarnOfExpensiveReadOnlyTxnRequest(s.Logger(), s.WarningApplyDuration(), start, r, resp, err)
 			trace.LogIfLong(traceThreshold)
 		}(time.Now())
 
@@ -450,7 +457,10 @@ func (s *EtcdServer) newHeader() *pb.ResponseHeader {
 }
 
 // LeaseLeases is really ListLeases !???
-func (s *EtcdServer) LeaseLeases(_ context.Context, _ *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+func (s *EtcdServer) LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	if r.Details {
+		return s.leaseLeasesDetails(ctx)
+	}
 	ls := s.lessor.Leases()
 	lss := make([]*pb.LeaseStatus, len(ls))
 	for i := range ls {
@@ -459,6 +469,52 @@ func (s *EtcdServer) LeaseLeases(_ context.Context, _ *pb.LeaseLeasesRequest) (*
 	return &pb.LeaseLeasesResponse{Header: s.newHeader(), Leases: lss}, nil
 }
 
+// leaseLeasesDetails lists all leases with their details, which are only
+// known to the primary lessor of the leader.
+func (s *EtcdServer) leaseLeasesDetails(ctx context.Context) (*pb.LeaseLeasesResponse, error) {
+	if s.isLeader() {
+		if err := s.waitAppliedIndex(); err != nil {
+			return nil, err
+		}
+		ls := s.lessor.Leases()
+		lss := make([]*pb.LeaseStatus, len(ls))
+		for i, l := range ls {
+			// The leasor could be demoted if leader changed while listing.
+			if l.Demoted() {
+				return nil, errors.ErrLeaderChanged
+			}
+			lss[i] = l.Status()
+		}
+		return &pb.LeaseLeasesResponse{Header: s.newHeader(), Leases: lss}, nil
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+
+	// forward to leader
+	for cctx.Err() == nil {
+		leader, err := s.waitLeader(cctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, url := range leader.PeerURLs {
+			lurl := url + leasehttp.LeaseListInternalPrefix
+			resp, err := leasehttp.LeasesHTTP(cctx, lurl, s.peerRt)
+			if err == nil {
+				resp.Header = s.newHeader()
+				return resp, nil
+			}
+		}
+		// Throttle in case of e.g. connection problems.
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if errorspkg.Is(cctx.Err(), context.DeadlineExceeded) {
+		return nil, errors.ErrTimeout
+	}
+	return nil, errors.ErrCanceled
+}
+
 func (s *EtcdServer) waitLeader(ctx context.Context) (*membership.Member, error) {
 	leader := s.cluster.Member(s.Leader())
 	for leader == nil {
@@ -487,6 +543,21 @@ func (s *EtcdServer) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.AlarmRe
 	return resp.(*pb.AlarmResponse), nil
 }
 
+// ValuePolicy sets, deletes, or lists the value policies. Policies are
+// validated before they are proposed.
+func (s *EtcdServer) ValuePolicy(ctx context.Context, r *pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error) {
+	if r.Action == pb.ValuePolicyRequest_PUT {
+		if err := v3valuepolicy.Validate(r.Policy); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{ValuePolicy: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ValuePolicyResponse), nil
+}
+
 func (s *EtcdServer) AuthEnable(ctx context.Context, r *pb.AuthEnableRequest) (*pb.AuthEnableResponse, error) {
 	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{AuthEnable: r})
 	if err != nil {
@@ -764,7 +835,7 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 		return nil, err
 	}
 
-	if len(data) > int(s.Cfg.MaxRequestBytes) {
+	if len(data) > int(s.MaxRequestBytes()) {
 		return nil, errors.ErrRequestTooLarge
 	}
 
@@ -777,6 +848,15 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
 	defer cancel()
 
+	// record the proposal and its apply in the trace of the request, which
+	// may have started in the client.
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("raft proposal", trace.WithAttributes(attribute.Int64("etcd.request.id", int64(id))))
+	if span.SpanContext().IsSampled() {
+		s.applyTraces.Store(id, span.SpanContext())
+		defer s.applyTraces.Delete(id)
+	}
+
 	start := time.Now()
 	err = s.r.Propose(cctx, data)
 	if err != nil {
@@ -789,6 +869,7 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 
 	select {
 	case x := <-ch:
+		span.AddEvent("applied")
 		return x.(*apply2.Result), nil
 	case <-cctx.Done():
 		proposalsFailed.Inc()
@@ -814,6 +895,16 @@ func (s *EtcdServer) linearizableReadLoop() {
 			return
 		}
 
+		// wait for the batching window to let more linearizable reads share
+		// a single read index request.
+		if s.Cfg.ReadIndexBatchingWindow > 0 {
+			select {
+			case <-time.After(s.Cfg.ReadIndexBatchingWindow):
+			case <-s.stopping:
+				return
+			}
+		}
+
 		// as a single loop is can unlock multiple reads, it is not very useful
 		// to propagate the trace from Txn or Range.
 		trace := traceutil.New("linearizableReadLoop", s.Logger())
@@ -822,15 +913,22 @@ func (s *EtcdServer) linearizableReadLoop() {
 		s.readMu.Lock()
 		nr := s.readNotifier
 		s.readNotifier = nextnr
+		batchSize := s.readNotifierWaiters.Swap(0)
 		s.readMu.Unlock()
-
-		confirmedIndex, err := s.requestCurrentIndex(leaderChangedNotifier, requestID)
-		if isStopped(err) {
-			return
-		}
-		if err != nil {
-			nr.notify(err)
-			continue
+		readIndexBatchSize.Observe(float64(batchSize))
+
+		// a follower holding a lease does not need to ask the leader.
+		confirmedIndex, ok := s.followerLeaseReadIndex()
+		if !ok {
+			var err error
+			confirmedIndex, err = s.requestCurrentIndex(leaderChangedNotifier, requestID)
+			if isStopped(err) {
+				return
+			}
+			if err != nil {
+				nr.notify(err)
+				continue
+			}
 		}
 
 		trace.Step("read index received")
@@ -939,6 +1037,9 @@ func uint64ToBigEndianBytes(number uint64) []byte {
 }
 
 func (s *EtcdServer) sendReadIndex(requestIndex uint64) error {
+	if err := s.waitLeaderLease(); err != nil {
+		return err
+	}
 	ctxToSend := uint64ToBigEndianBytes(requestIndex)
 
 	cctx, cancel := context.WithTimeout(context.Background(), s.Cfg.ReqTimeout())
@@ -963,6 +1064,7 @@ func (s *EtcdServer) LinearizableReadNotify(ctx context.Context) error {
 func (s *EtcdServer) linearizableReadNotify(ctx context.Context) error {
 	s.readMu.RLock()
 	nc := s.readNotifier
+	s.readNotifierWaiters.Add(1)
 	s.readMu.RUnlock()
 
 	// signal linearizable loop for current notify if it hasn't been already
@@ -0,0 +1,60 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import "sync"
+
+// userWatchers counts the watchers of each authenticated user across the
+// watch streams of all client listeners of the member.
+type userWatchers struct {
+	mu     sync.Mutex
+	counts map[string]uint
+}
+
+// AcquireUserWatcher counts a new watcher of the user, unless the user
+// reached the maximum number of watchers. Watchers of unauthenticated clients
+// are not limited.
+func (s *EtcdServer) AcquireUserWatcher(user string) bool {
+	limit := s.Cfg.MaxWatchersPerUser
+	if limit == 0 || user == "" {
+		return true
+	}
+	uw := &s.userWatchers
+	uw.mu.Lock()
+	defer uw.mu.Unlock()
+	if uw.counts[user] >= limit {
+		return false
+	}
+	if uw.counts == nil {
+		uw.counts = make(map[string]uint)
+	}
+	uw.counts[user]++
+	return true
+}
+
+// ReleaseUserWatcher uncounts a watcher of the user counted by
+// AcquireUserWatcher.
+func (s *EtcdServer) ReleaseUserWatcher(user string) {
+	uw := &s.userWatchers
+	uw.mu.Lock()
+	defer uw.mu.Unlock()
+	if n, ok := uw.counts[user]; ok {
+		if n <= 1 {
+			delete(uw.counts, user)
+		} else {
+			uw.counts[user] = n - 1
+		}
+	}
+}
@@ -79,6 +79,30 @@ const (
 	// alpha: v3.6
 	// main PR: https://github.com/etcd-io/etcd/pull/17661
 	SetMemberLocalAddr featuregate.Feature = "SetMemberLocalAddr"
+	// LeadershipPriority enables leader to transfer leadership to a healthy voting member
+	// that publishes a higher leadership priority than the current leader.
+	// alpha: v3.6
+	LeadershipPriority featuregate.Feature = "LeadershipPriority"
+	// LearnerAutoPromote enables leader to automatically promote learners which have been
+	// caught up with the leader for a sustained period.
+	// alpha: v3.6
+	LearnerAutoPromote featuregate.Feature = "LearnerAutoPromote"
+	// LeaseBasedReads enables the leader to serve read index requests, including the
+	// ones forwarded by followers, based on its leader lease instead of confirming its
+	// leadership with a quorum of heartbeats for every batch of linearizable reads.
+	// The leader also grants follower leases, with which followers serve linearizable
+	// reads without asking the leader for a read index. Entries are only applied once
+	// every follower holding a lease appended them, so a slow or stopped follower holds
+	// back writes until its lease expires. A restarted member does not vote within an
+	// election timeout.
+	// Safety depends on clock drift between members staying within --leader-lease-max-clock-drift.
+	// alpha: v3.6
+	LeaseBasedReads featuregate.Feature = "LeaseBasedReads"
+	// LazyDeleteRange enables clients to delete a range lazily, marking the whole range
+	// deleted at once with a single range tombstone and watch event, while the compactions
+	// remove its keys.
+	// alpha: v3.6
+	LazyDeleteRange featuregate.Feature = "LazyDeleteRange"
 )
 
 var (
@@ -91,6 +115,10 @@ var (
 		LeaseCheckpoint:              {Default: false, PreRelease: featuregate.Alpha},
 		LeaseCheckpointPersist:       {Default: false, PreRelease: featuregate.Alpha},
 		SetMemberLocalAddr:           {Default: false, PreRelease: featuregate.Alpha},
+		LeadershipPriority:           {Default: false, PreRelease: featuregate.Alpha},
+		LearnerAutoPromote:           {Default: false, PreRelease: featuregate.Alpha},
+		LeaseBasedReads:              {Default: false, PreRelease: featuregate.Alpha},
+		LazyDeleteRange:              {Default: false, PreRelease: featuregate.Alpha},
 	}
 	// ExperimentalFlagToFeatureMap is the map from the cmd line flags of experimental features
 	// to their corresponding feature gates.
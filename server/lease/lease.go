@@ -19,6 +19,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jonboulle/clockwork"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -32,6 +35,10 @@ type Lease struct {
 	expiryMu sync.RWMutex
 	// expiry is time when lease should expire. no expiration when expiry.IsZero() is true
 	expiry time.Time
+	// clock computes the expiry, the real clock is used if nil.
+	clock clockwork.Clock
+	// labels describe the owner of the lease, they never change.
+	labels []*pb.LeaseLabel
 
 	// mu protects concurrent accesses to itemSet
 	mu      sync.RWMutex
@@ -53,7 +60,7 @@ func (l *Lease) expired() bool {
 }
 
 func (l *Lease) persistTo(b backend.Backend) {
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL}
+	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL, Labels: l.labels}
 	tx := b.BatchTx()
 	tx.LockInsideApply()
 	defer tx.Unlock()
@@ -65,6 +72,12 @@ func (l *Lease) TTL() int64 {
 	return l.ttl
 }
 
+// Labels returns the labels the lease was granted with. They must not be
+// modified.
+func (l *Lease) Labels() []*pb.LeaseLabel {
+	return l.labels
+}
+
 // SetLeaseItem sets the given lease item, this func is thread-safe
 func (l *Lease) SetLeaseItem(item LeaseItem) {
 	l.mu.Lock()
@@ -82,12 +95,33 @@ func (l *Lease) getRemainingTTL() int64 {
 
 // refresh refreshes the expiry of the lease.
 func (l *Lease) refresh(extend time.Duration) {
-	newExpiry := time.Now().Add(extend + time.Duration(l.getRemainingTTL())*time.Second)
+	newExpiry := l.now().Add(extend + time.Duration(l.getRemainingTTL())*time.Second)
 	l.expiryMu.Lock()
 	defer l.expiryMu.Unlock()
 	l.expiry = newExpiry
 }
 
+// postpone postpones the expiry of the lease to t if it is earlier, and
+// returns whether it did.
+func (l *Lease) postpone(t time.Time) bool {
+	l.expiryMu.Lock()
+	defer l.expiryMu.Unlock()
+	if l.expiry == forever || !l.expiry.Before(t) {
+		return false
+	}
+	l.expiry = t
+	return true
+}
+
+// delay delays the expiry of the lease by d.
+func (l *Lease) delay(d time.Duration) {
+	l.expiryMu.Lock()
+	defer l.expiryMu.Unlock()
+	if l.expiry != forever {
+		l.expiry = l.expiry.Add(d)
+	}
+}
+
 // forever sets the expiry of lease to be forever.
 func (l *Lease) forever() {
 	l.expiryMu.Lock()
@@ -113,6 +147,22 @@ func (l *Lease) Keys() []string {
 	return keys
 }
 
+// Status returns the remaining TTL, the granted TTL, the number of attached
+// keys and the labels of the lease. The remaining TTL is only meaningful on
+// the primary lessor.
+func (l *Lease) Status() *pb.LeaseStatus {
+	l.mu.RLock()
+	keys := len(l.itemSet)
+	l.mu.RUnlock()
+	return &pb.LeaseStatus{
+		ID:         int64(l.ID),
+		TTL:        int64(l.Remaining().Seconds()),
+		GrantedTTL: l.ttl,
+		Keys:       int64(keys),
+		Labels:     l.labels,
+	}
+}
+
 // Remaining returns the remaining time of the lease.
 func (l *Lease) Remaining() time.Duration {
 	l.expiryMu.RLock()
@@ -120,7 +170,14 @@ func (l *Lease) Remaining() time.Duration {
 	if l.expiry.IsZero() {
 		return time.Duration(math.MaxInt64)
 	}
-	return time.Until(l.expiry)
+	return l.expiry.Sub(l.now())
+}
+
+func (l *Lease) now() time.Time {
+	if l.clock == nil {
+		return time.Now()
+	}
+	return l.clock.Now()
 }
 
 type LeaseItem struct {
@@ -17,6 +17,8 @@ package lease
 import (
 	"testing"
 	"time"
+
+	"github.com/jonboulle/clockwork"
 )
 
 func TestLeaseQueue(t *testing.T) {
@@ -25,6 +27,7 @@ func TestLeaseQueue(t *testing.T) {
 		leaseExpiredNotifier:      newLeaseExpiredNotifier(),
 		leaseMap:                  make(map[LeaseID]*Lease),
 		expiredLeaseRetryInterval: expiredRetryInterval,
+		clock:                     clockwork.NewRealClock(),
 	}
 	le.leaseExpiredNotifier.Init()
 
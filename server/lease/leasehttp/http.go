@@ -30,10 +30,11 @@ import (
 )
 
 var (
-	LeasePrefix         = "/leases"
-	LeaseInternalPrefix = "/leases/internal"
-	applyTimeout        = time.Second
-	ErrLeaseHTTPTimeout = errors.New("waiting for node to catch up its applied index has timed out")
+	LeasePrefix             = "/leases"
+	LeaseInternalPrefix     = "/leases/internal"
+	LeaseListInternalPrefix = "/leases/internal/list"
+	applyTimeout            = time.Second
+	ErrLeaseHTTPTimeout     = errors.New("waiting for node to catch up its applied index has timed out")
 )
 
 // NewHandler returns an http Handler for lease renewals
@@ -143,6 +144,37 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+	case LeaseListInternalPrefix:
+		lreq := pb.LeaseLeasesRequest{}
+		if lerr := lreq.Unmarshal(b); lerr != nil {
+			http.Error(w, "error unmarshalling request", http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-h.waitch():
+		case <-time.After(applyTimeout):
+			http.Error(w, ErrLeaseHTTPTimeout.Error(), http.StatusRequestTimeout)
+			return
+		}
+
+		ls := h.l.Leases()
+		// TODO: fill out ResponseHeader
+		resp := &pb.LeaseLeasesResponse{Header: &pb.ResponseHeader{}, Leases: make([]*pb.LeaseStatus, len(ls))}
+		for i, l := range ls {
+			// The leasor could be demoted if leader changed while listing.
+			if l.Demoted() {
+				http.Error(w, lease.ErrNotPrimary.Error(), http.StatusInternalServerError)
+				return
+			}
+			resp.Leases[i] = l.Status()
+		}
+
+		v, err = resp.Marshal()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 	default:
 		http.Error(w, fmt.Sprintf("unknown request path %q", r.URL.Path), http.StatusBadRequest)
 		return
@@ -262,6 +294,49 @@ func TimeToLiveHTTP(ctx context.Context, id lease.LeaseID, keys bool, url string
 	return lresp, nil
 }
 
+// LeasesHTTP retrieves the details of all leases from the given primary server.
+func LeasesHTTP(ctx context.Context, url string, rt http.RoundTripper) (*pb.LeaseLeasesResponse, error) {
+	lreq, err := (&pb.LeaseLeasesRequest{Details: true}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(lreq))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/protobuf")
+
+	req = req.WithContext(ctx)
+
+	cc := &http.Client{
+		Transport: rt,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := cc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusRequestTimeout {
+		return nil, ErrLeaseHTTPTimeout
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lease: unknown error(%s)", string(b))
+	}
+
+	lresp := &pb.LeaseLeasesResponse{}
+	if err := lresp.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf(`lease: %w. data = "%s"`, err, string(b))
+	}
+	return lresp, nil
+}
+
 func readResponse(resp *http.Response) (b []byte, err error) {
 	b, err = io.ReadAll(resp.Body)
 	httputil.GracefulClose(resp)
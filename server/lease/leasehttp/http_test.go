@@ -18,11 +18,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
 	"go.uber.org/zap/zaptest"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/server/v3/lease"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 )
@@ -78,6 +80,41 @@ func TestTimeToLiveHTTP(t *testing.T) {
 	}
 }
 
+func TestLeasesHTTP(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, be)
+
+	le := lease.NewLessor(lg, be, nil, lease.LessorConfig{MinLeaseTTL: int64(5)})
+	le.Promote(time.Second)
+	labels := []*pb.LeaseLabel{{Key: "host", Value: "a"}}
+	l, err := le.GrantWithLabels(1, int64(5), labels)
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	if err = le.Attach(l.ID, []lease.LeaseItem{{Key: "foo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le, waitReady))
+	defer ts.Close()
+
+	resp, err := LeasesHTTP(context.TODO(), ts.URL+LeaseListInternalPrefix, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Leases) != 1 {
+		t.Fatalf("leases expected 1, got %d", len(resp.Leases))
+	}
+	ls := resp.Leases[0]
+	if ls.ID != 1 || ls.GrantedTTL != 5 || ls.Keys != 1 {
+		t.Fatalf("lease status expected ID 1, granted TTL 5 and 1 key, got %v", ls)
+	}
+	if !reflect.DeepEqual(ls.Labels, labels) {
+		t.Fatalf("labels expected %v, got %v", labels, ls.Labels)
+	}
+}
+
 func TestRenewHTTPTimeout(t *testing.T) {
 	testApplyTimeout(t, func(l *lease.Lease, serverURL string) error {
 		_, err := RenewHTTP(context.TODO(), l.ID, serverURL+LeasePrefix, http.DefaultTransport)
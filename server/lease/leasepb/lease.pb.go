@@ -26,12 +26,13 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Lease struct {
-	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	TTL                  int64    `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	RemainingTTL         int64    `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ID                   int64                      `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TTL                  int64                      `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	RemainingTTL         int64                      `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	Labels               []*etcdserverpb.LeaseLabel `protobuf:"bytes,4,rep,name=Labels,proto3" json:"Labels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *Lease) Reset()         { *m = Lease{} }
@@ -156,25 +157,26 @@ func init() {
 func init() { proto.RegisterFile("lease.proto", fileDescriptor_3dd57e402472b33a) }
 
 var fileDescriptor_3dd57e402472b33a = []byte{
-	// 283 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xce, 0x49, 0x4d, 0x2c,
-	0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x07, 0x73, 0x0a, 0x92, 0xa4, 0x44, 0xd2,
-	0xf3, 0xd3, 0xf3, 0xc1, 0x62, 0xfa, 0x20, 0x16, 0x44, 0x5a, 0x4a, 0x3e, 0xb5, 0x24, 0x39, 0x45,
-	0x3f, 0xb1, 0x20, 0x53, 0x1f, 0xc4, 0x28, 0x4e, 0x2d, 0x2a, 0x4b, 0x2d, 0x2a, 0x48, 0xd2, 0x2f,
-	0x2a, 0x48, 0x86, 0x28, 0x50, 0xf2, 0xe5, 0x62, 0xf5, 0x01, 0x99, 0x20, 0xc4, 0xc7, 0xc5, 0xe4,
-	0xe9, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x1c, 0xc4, 0xe4, 0xe9, 0x22, 0x24, 0xc0, 0xc5, 0x1c,
-	0x12, 0xe2, 0x23, 0xc1, 0x04, 0x16, 0x00, 0x31, 0x85, 0x94, 0xb8, 0x78, 0x82, 0x52, 0x73, 0x13,
-	0x33, 0xf3, 0x32, 0xf3, 0xd2, 0x41, 0x52, 0xcc, 0x60, 0x29, 0x14, 0x31, 0xa5, 0x12, 0x2e, 0x11,
-	0xb0, 0x71, 0x9e, 0x79, 0x25, 0xa9, 0x45, 0x79, 0x89, 0x39, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5,
-	0x25, 0x42, 0x31, 0x5c, 0x62, 0x60, 0xf1, 0x90, 0xcc, 0xdc, 0xd4, 0x90, 0x7c, 0x9f, 0xcc, 0xb2,
-	0x54, 0xa8, 0x0c, 0xd8, 0x46, 0x6e, 0x23, 0x15, 0x3d, 0x64, 0xf7, 0xe9, 0x61, 0x57, 0x1b, 0x84,
-	0xc3, 0x0c, 0xa5, 0x0a, 0x2e, 0x51, 0x34, 0x5b, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xe2,
-	0xb9, 0xc4, 0x31, 0xb4, 0x40, 0xa4, 0xa0, 0xf6, 0xaa, 0x12, 0xb0, 0x17, 0xa2, 0x38, 0x08, 0x97,
-	0x29, 0x4e, 0x9e, 0x27, 0x1e, 0xca, 0x31, 0x5c, 0x78, 0x28, 0xc7, 0x70, 0xe2, 0x91, 0x1c, 0xe3,
-	0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x9f, 0x9e,
-	0x0f, 0x36, 0x5b, 0x2f, 0x33, 0x1f, 0x1c, 0xf6, 0xfa, 0x10, 0x4b, 0xf4, 0xcb, 0x8c, 0xf5, 0xc1,
-	0x51, 0xa6, 0x0f, 0x8d, 0x38, 0x6b, 0x28, 0x9d, 0xc4, 0x06, 0x8e, 0x10, 0x63, 0x40, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x0e, 0x16, 0x3b, 0xc4, 0xdf, 0x01, 0x00, 0x00,
+	// 304 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0x41, 0x4b, 0xc3, 0x30,
+	0x18, 0x5d, 0x5b, 0x9d, 0x90, 0x8a, 0x48, 0x98, 0x5a, 0x76, 0x88, 0xa3, 0x28, 0xec, 0xd4, 0xc8,
+	0x76, 0xf4, 0x26, 0xbb, 0x14, 0x7a, 0x0a, 0x3d, 0x89, 0x20, 0xed, 0xfc, 0x28, 0x81, 0xae, 0x89,
+	0x4d, 0x2d, 0xde, 0xfc, 0x1b, 0xfe, 0xa4, 0x1d, 0xf7, 0x13, 0x5c, 0xfd, 0x23, 0xd2, 0xaf, 0x3d,
+	0xa8, 0x73, 0x78, 0xca, 0xcb, 0x7b, 0x2f, 0xef, 0x05, 0x1e, 0x71, 0x73, 0x48, 0x0c, 0x04, 0xba,
+	0x54, 0x95, 0xa2, 0x47, 0x78, 0xd1, 0xe9, 0x78, 0x94, 0xa9, 0x4c, 0x21, 0xc7, 0x5b, 0xd4, 0xc9,
+	0xe3, 0x4b, 0xa8, 0x96, 0x4f, 0x3c, 0xd1, 0x92, 0xb7, 0xc0, 0x40, 0x59, 0x43, 0xa9, 0x53, 0x5e,
+	0xea, 0x65, 0x67, 0xf0, 0xdf, 0xc8, 0x61, 0xd4, 0x26, 0xd0, 0x13, 0x62, 0x87, 0x0b, 0xcf, 0x9a,
+	0x58, 0x53, 0x47, 0xd8, 0xe1, 0x82, 0x9e, 0x12, 0x27, 0x8e, 0x23, 0xcf, 0x46, 0xa2, 0x85, 0xd4,
+	0x27, 0xc7, 0x02, 0x56, 0x89, 0x2c, 0x64, 0x91, 0xb5, 0x92, 0x83, 0xd2, 0x0f, 0x8e, 0xde, 0x90,
+	0x61, 0x94, 0xa4, 0x90, 0x1b, 0xef, 0x60, 0xe2, 0x4c, 0xdd, 0x99, 0x17, 0x7c, 0xef, 0x0d, 0xb0,
+	0x0a, 0x0d, 0xa2, 0xf7, 0xf9, 0x15, 0x19, 0x21, 0x1b, 0x16, 0x15, 0x94, 0x45, 0x92, 0x0b, 0x78,
+	0x7e, 0x01, 0x53, 0xd1, 0x07, 0x72, 0x8e, 0x7c, 0x2c, 0x57, 0x10, 0xab, 0x48, 0xd6, 0xd0, 0x2b,
+	0xf8, 0x47, 0x77, 0x76, 0xf5, 0x47, 0xf2, 0x8e, 0x57, 0xec, 0xc9, 0xf0, 0x5f, 0xc9, 0xd9, 0xaf,
+	0x56, 0xa3, 0x55, 0x61, 0x80, 0x3e, 0x92, 0x8b, 0x9d, 0x27, 0x9d, 0xd4, 0xf7, 0x5e, 0xff, 0xd3,
+	0xdb, 0x99, 0xc5, 0xbe, 0x94, 0xbb, 0x70, 0xbd, 0x65, 0x83, 0xcd, 0x96, 0x0d, 0xd6, 0x0d, 0xb3,
+	0x36, 0x0d, 0xb3, 0x3e, 0x1a, 0x66, 0xbd, 0x7f, 0xb2, 0xc1, 0x3d, 0xcf, 0x14, 0x66, 0x07, 0x52,
+	0xe1, 0x5a, 0xbc, 0x2b, 0xe1, 0xf5, 0x9c, 0xe3, 0xc8, 0xbc, 0x9f, 0xfa, 0xb6, 0x3f, 0xd3, 0x21,
+	0x4e, 0x38, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x28, 0x52, 0x26, 0x92, 0x11, 0x02, 0x00, 0x00,
 }
 
 func (m *Lease) Marshal() (dAtA []byte, err error) {
@@ -201,6 +203,20 @@ func (m *Lease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Labels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLease(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if m.RemainingTTL != 0 {
 		i = encodeVarintLease(dAtA, i, uint64(m.RemainingTTL))
 		i--
@@ -323,6 +339,12 @@ func (m *Lease) Size() (n int) {
 	if m.RemainingTTL != 0 {
 		n += 1 + sovLease(uint64(m.RemainingTTL))
 	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovLease(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -453,6 +475,40 @@ func (m *Lease) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLease
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, &etcdserverpb.LeaseLabel{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLease(dAtA[iNdEx:])
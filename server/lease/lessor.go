@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -57,10 +58,11 @@ var (
 	// the default interval to check if the expired lease is revoked
 	defaultExpiredleaseRetryInterval = 3 * time.Second
 
-	ErrNotPrimary       = errors.New("not a primary lessor")
-	ErrLeaseNotFound    = errors.New("lease not found")
-	ErrLeaseExists      = errors.New("lease already exists")
-	ErrLeaseTTLTooLarge = errors.New("too large lease TTL")
+	ErrNotPrimary        = errors.New("not a primary lessor")
+	ErrLeaseNotFound     = errors.New("lease not found")
+	ErrLeaseExists       = errors.New("lease already exists")
+	ErrLeaseTTLTooLarge  = errors.New("too large lease TTL")
+	ErrLeaseLabelInvalid = errors.New("invalid lease label")
 )
 
 // TxnDelete is a TxnWrite that only permits deletes. Defined here
@@ -90,6 +92,9 @@ type Lessor interface {
 
 	// Grant grants a lease that expires at least after TTL seconds.
 	Grant(id LeaseID, ttl int64) (*Lease, error)
+	// GrantWithLabels grants a lease like Grant, labeled with the given
+	// labels describing its owner.
+	GrantWithLabels(id LeaseID, ttl int64, labels []*pb.LeaseLabel) (*Lease, error)
 	// Revoke revokes a lease with given ID. The item attached to the
 	// given lease will be removed. If the ID does not exist, an error
 	// will be returned.
@@ -141,7 +146,6 @@ type Lessor interface {
 }
 
 // lessor implements Lessor interface.
-// TODO: use clockwork for testability.
 type lessor struct {
 	mu sync.RWMutex
 
@@ -187,8 +191,12 @@ type lessor struct {
 	expiredLeaseRetryInterval time.Duration
 	// whether lessor should always persist remaining TTL (always enabled in v3.6).
 	checkpointPersist bool
+	// the minimum time the leases live after the lessor is promoted
+	gracePeriod time.Duration
 	// cluster is used to adapt lessor logic based on cluster version
 	cluster cluster
+
+	clock clockwork.Clock
 }
 
 type cluster interface {
@@ -201,6 +209,13 @@ type LessorConfig struct {
 	CheckpointInterval         time.Duration
 	ExpiredLeasesRetryInterval time.Duration
 	CheckpointPersist          bool
+	// GracePeriod is the minimum time the leases live after the lessor is
+	// promoted, so that the leases that could not be renewed while the
+	// cluster had no leader are not expired right away.
+	GracePeriod time.Duration
+	// Clock drives the expiry and checkpoints of leases, the real clock by
+	// default. Tests inject a fake clock to expire leases without waiting.
+	Clock clockwork.Clock
 
 	leaseRevokeRate int
 }
@@ -222,6 +237,10 @@ func newLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorCon
 	if leaseRevokeRate == 0 {
 		leaseRevokeRate = defaultLeaseRevokeRate
 	}
+	clock := cfg.Clock
+	if clock == nil {
+		clock = clockwork.NewRealClock()
+	}
 	l := &lessor{
 		leaseMap:                  make(map[LeaseID]*Lease),
 		itemMap:                   make(map[LeaseItem]LeaseID),
@@ -233,12 +252,14 @@ func newLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorCon
 		checkpointInterval:        checkpointInterval,
 		expiredLeaseRetryInterval: expiredLeaseRetryInterval,
 		checkpointPersist:         cfg.CheckpointPersist,
+		gracePeriod:               cfg.GracePeriod,
 		// expiredC is a small buffered chan to avoid unnecessary blocking.
 		expiredC: make(chan []*Lease, 16),
 		stopC:    make(chan struct{}),
 		doneC:    make(chan struct{}),
 		lg:       lg,
 		cluster:  cluster,
+		clock:    clock,
 	}
 	l.initAndRecover()
 
@@ -279,6 +300,10 @@ func (le *lessor) SetCheckpointer(cp Checkpointer) {
 }
 
 func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
+	return le.GrantWithLabels(id, ttl, nil)
+}
+
+func (le *lessor) GrantWithLabels(id LeaseID, ttl int64, labels []*pb.LeaseLabel) (*Lease, error) {
 	if id == NoLease {
 		return nil, ErrLeaseNotFound
 	}
@@ -287,9 +312,15 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 		return nil, ErrLeaseTTLTooLarge
 	}
 
+	if err := validateLabels(labels); err != nil {
+		return nil, err
+	}
+
 	// TODO: when lessor is under high load, it should give out lease
 	// with longer TTL to reduce renew load.
 	l := NewLease(id, ttl)
+	l.clock = le.clock
+	l.labels = labels
 
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -323,6 +354,21 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	return l, nil
 }
 
+// validateLabels checks the keys of the labels are not empty and unique.
+func validateLabels(labels []*pb.LeaseLabel) error {
+	keys := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		if label.Key == "" {
+			return ErrLeaseLabelInvalid
+		}
+		if _, ok := keys[label.Key]; ok {
+			return ErrLeaseLabelInvalid
+		}
+		keys[label.Key] = struct{}{}
+	}
+	return nil
+}
+
 func (le *lessor) Revoke(id LeaseID) error {
 	le.mu.Lock()
 
@@ -476,12 +522,26 @@ func (le *lessor) Promote(extend time.Duration) {
 	le.demotec = make(chan struct{})
 
 	// refresh the expiries of all leases.
+	graceEnd := le.clock.Now().Add(le.gracePeriod)
+	graced := 0
 	for _, l := range le.leaseMap {
 		l.refresh(extend)
+		if le.gracePeriod > 0 && l.postpone(graceEnd) {
+			graced++
+		}
 		item := &LeaseWithTime{id: l.ID, time: l.expiry}
 		le.leaseExpiredNotifier.RegisterOrUpdate(item)
 		le.scheduleCheckpointIfNeeded(l)
 	}
+	if le.gracePeriod > 0 {
+		leaseGraced.Add(float64(graced))
+		le.lg.Info(
+			"postponed lease expiries to the end of the grace period",
+			zap.Duration("grace-period", le.gracePeriod),
+			zap.Int("leases", len(le.leaseMap)),
+			zap.Int("postponed-leases", graced),
+		)
+	}
 
 	if len(le.leaseMap) < le.leaseRevokeRate {
 		// no possibility of lease pile-up
@@ -516,7 +576,9 @@ func (le *lessor) Promote(extend time.Duration) {
 		rateDelay -= float64(remaining - baseWindow)
 		delay := time.Duration(rateDelay)
 		nextWindow = baseWindow + delay
-		l.refresh(delay + extend)
+		// delay the expiry set above, so that the leases postponed to the end
+		// of the grace period are spread after it.
+		l.delay(delay)
 		item := &LeaseWithTime{id: l.ID, time: l.expiry}
 		le.leaseExpiredNotifier.RegisterOrUpdate(item)
 		le.scheduleCheckpointIfNeeded(l)
@@ -612,7 +674,7 @@ func (le *lessor) Stop() {
 func (le *lessor) runLoop() {
 	defer close(le.doneC)
 
-	delayTicker := time.NewTicker(500 * time.Millisecond)
+	delayTicker := le.clock.NewTicker(500 * time.Millisecond)
 	defer delayTicker.Stop()
 
 	for {
@@ -620,7 +682,7 @@ func (le *lessor) runLoop() {
 		le.checkpointScheduledLeases()
 
 		select {
-		case <-delayTicker.C:
+		case <-delayTicker.Chan():
 		case <-le.stopC:
 			return
 		}
@@ -702,7 +764,7 @@ func (le *lessor) expireExists() (l *Lease, next bool) {
 		le.leaseExpiredNotifier.Unregister() // O(log N)
 		return nil, true
 	}
-	now := time.Now()
+	now := le.clock.Now()
 	if now.Before(item.time) /* item.time: expiration time */ {
 		// Candidate expirations are caught up, reinsert this item
 		// and no need to revoke (nothing is expiry)
@@ -756,7 +818,7 @@ func (le *lessor) scheduleCheckpointIfNeeded(lease *Lease) {
 		}
 		heap.Push(&le.leaseCheckpointHeap, &LeaseWithTime{
 			id:   lease.ID,
-			time: time.Now().Add(le.checkpointInterval),
+			time: le.clock.Now().Add(le.checkpointInterval),
 		})
 	}
 }
@@ -766,7 +828,7 @@ func (le *lessor) findDueScheduledCheckpoints(checkpointLimit int) []*pb.LeaseCh
 		return nil
 	}
 
-	now := time.Now()
+	now := le.clock.Now()
 	var cps []*pb.LeaseCheckpoint
 	for le.leaseCheckpointHeap.Len() > 0 && len(cps) < checkpointLimit {
 		lt := le.leaseCheckpointHeap[0]
@@ -818,6 +880,8 @@ func (le *lessor) initAndRecover() {
 			expiry:       forever,
 			revokec:      make(chan struct{}),
 			remainingTTL: lpb.RemainingTTL,
+			clock:        le.clock,
+			labels:       lpb.Labels,
 		}
 	}
 	le.leaseExpiredNotifier.Init()
@@ -841,6 +905,10 @@ func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	return nil, nil
 }
 
+func (fl *FakeLessor) GrantWithLabels(id LeaseID, ttl int64, labels []*pb.LeaseLabel) (*Lease, error) {
+	return fl.Grant(id, ttl)
+}
+
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
 func (fl *FakeLessor) Checkpoint(id LeaseID, remainingTTL int64) error { return nil }
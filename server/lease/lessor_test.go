@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
@@ -101,7 +102,7 @@ func TestLessorGrant(t *testing.T) {
 	defer tx.Unlock()
 	lpb := schema.MustUnsafeGetLease(tx, int64(l.ID))
 	if lpb == nil {
-		t.Errorf("lpb = %d, want not nil", lpb)
+		t.Errorf("lpb = %v, want not nil", lpb)
 	}
 }
 
@@ -205,7 +206,7 @@ func TestLessorRevoke(t *testing.T) {
 	defer tx.Unlock()
 	lpb := schema.MustUnsafeGetLease(tx, int64(l.ID))
 	if lpb != nil {
-		t.Errorf("lpb = %d, want nil", lpb)
+		t.Errorf("lpb = %v, want nil", lpb)
 	}
 }
 
@@ -426,6 +427,42 @@ func TestLessorRecover(t *testing.T) {
 	}
 }
 
+func TestLessorGrantWithLabels(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+
+	for _, labels := range [][]*pb.LeaseLabel{
+		{{Key: "", Value: "a"}},
+		{{Key: "host", Value: "a"}, {Key: "host", Value: "b"}},
+	} {
+		if _, err := le.GrantWithLabels(1, 10, labels); !errors.Is(err, ErrLeaseLabelInvalid) {
+			t.Errorf("grant with labels %v error = %v, want %v", labels, err, ErrLeaseLabelInvalid)
+		}
+	}
+
+	labels := []*pb.LeaseLabel{{Key: "host", Value: "a"}, {Key: "pid", Value: "1"}}
+	l, err := le.GrantWithLabels(1, 10, labels)
+	if err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	if !reflect.DeepEqual(l.Labels(), labels) {
+		t.Errorf("labels = %v, want %v", l.Labels(), labels)
+	}
+
+	// the labels are recovered from the backend.
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	nl := nle.Lookup(l.ID)
+	if nl == nil || !reflect.DeepEqual(nl.Labels(), labels) {
+		t.Errorf("recovered lease = %v, want labels %v", nl, labels)
+	}
+}
+
 func TestLessorExpire(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
@@ -479,6 +516,125 @@ func TestLessorExpire(t *testing.T) {
 	}
 }
 
+func TestLessorExpireWithFakeClock(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	clock := clockwork.NewFakeClock()
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, Clock: clock})
+	defer le.Stop()
+
+	le.Promote(0)
+	l, err := le.Grant(1, 10)
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// wait for the run loop to wait for its ticker.
+	if err = clock.BlockUntilContext(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(9 * time.Second)
+	if remaining := l.Remaining(); remaining != time.Second {
+		t.Fatalf("remaining = %v, want %v", remaining, time.Second)
+	}
+	select {
+	case <-le.ExpiredLeasesC():
+		t.Fatalf("lease expired before its TTL")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err = clock.BlockUntilContext(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	select {
+	case el := <-le.ExpiredLeasesC():
+		if el[0].ID != l.ID {
+			t.Fatalf("expired id = %x, want %x", el[0].ID, l.ID)
+		}
+	case <-ctx.Done():
+		t.Fatalf("failed to receive expired lease")
+	}
+}
+
+// TestLessorPromoteGracePeriod ensures that the leases expiring before the end
+// of the grace period are postponed to it on promotion.
+func TestLessorPromoteGracePeriod(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	clock := clockwork.NewFakeClock()
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, GracePeriod: 30 * time.Second, Clock: clock})
+	defer le.Stop()
+
+	short, err := le.Grant(1, 5)
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	long, err := le.Grant(2, 60)
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	le.Promote(time.Second)
+
+	if remaining := short.Remaining(); remaining != 30*time.Second {
+		t.Fatalf("short lease remaining = %v, want %v", remaining, 30*time.Second)
+	}
+	if remaining := long.Remaining(); remaining != 61*time.Second {
+		t.Fatalf("long lease remaining = %v, want %v", remaining, 61*time.Second)
+	}
+
+	// the grace period only applies on promotion.
+	if _, err = le.Renew(short.ID); err != nil {
+		t.Fatal(err)
+	}
+	if remaining := short.Remaining(); remaining != 5*time.Second {
+		t.Fatalf("renewed short lease remaining = %v, want %v", remaining, 5*time.Second)
+	}
+}
+
+// TestLessorPromoteGracePeriodPileup ensures that the leases postponed to the
+// end of the grace period are spread after it instead of expiring together.
+func TestLessorPromoteGracePeriodPileup(t *testing.T) {
+	leaseRevokeRate := 10
+	gracePeriod := 30 * time.Second
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	clock := clockwork.NewFakeClock()
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, GracePeriod: gracePeriod, Clock: clock, leaseRevokeRate: leaseRevokeRate})
+	defer le.Stop()
+	for i := 1; i <= leaseRevokeRate*5; i++ {
+		if _, err := le.Grant(LeaseID(i), 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	le.Promote(0)
+
+	windowCounts := make(map[int64]int)
+	for _, l := range le.leaseMap {
+		remaining := l.Remaining()
+		if remaining < gracePeriod {
+			t.Fatalf("lease %d remaining = %v, want at least the grace period %v", l.ID, remaining, gracePeriod)
+		}
+		windowCounts[int64(remaining.Seconds())]++
+	}
+	for s, c := range windowCounts {
+		if c > leaseRevokeRate {
+			t.Errorf("expected at most %d expiring at %ds, got %d", leaseRevokeRate, s, c)
+		}
+	}
+}
+
 func TestLessorExpireAndDemote(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
@@ -40,6 +40,13 @@ var (
 		Help:      "The number of renewed leases seen by the leader.",
 	})
 
+	leaseGraced = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "grace_postponed_total",
+		Help:      "The number of lease expiries postponed to the end of the grace period after a leader change.",
+	})
+
 	leaseTotalTTLs = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -56,5 +63,6 @@ func init() {
 	prometheus.MustRegister(leaseGranted)
 	prometheus.MustRegister(leaseRevoked)
 	prometheus.MustRegister(leaseRenewed)
+	prometheus.MustRegister(leaseGraced)
 	prometheus.MustRegister(leaseTotalTTLs)
 }
@@ -47,3 +47,15 @@ func (s *cls2clc) MemberRemove(ctx context.Context, r *pb.MemberRemoveRequest, o
 func (s *cls2clc) MemberPromote(ctx context.Context, r *pb.MemberPromoteRequest, opts ...grpc.CallOption) (*pb.MemberPromoteResponse, error) {
 	return s.cls.MemberPromote(ctx, r)
 }
+
+func (s *cls2clc) ClusterMetadataPut(ctx context.Context, r *pb.ClusterMetadataPutRequest, opts ...grpc.CallOption) (*pb.ClusterMetadataPutResponse, error) {
+	return s.cls.ClusterMetadataPut(ctx, r)
+}
+
+func (s *cls2clc) ClusterMetadataDelete(ctx context.Context, r *pb.ClusterMetadataDeleteRequest, opts ...grpc.CallOption) (*pb.ClusterMetadataDeleteResponse, error) {
+	return s.cls.ClusterMetadataDelete(ctx, r)
+}
+
+func (s *cls2clc) ClusterMetadataList(ctx context.Context, r *pb.ClusterMetadataListRequest, opts ...grpc.CallOption) (*pb.ClusterMetadataListResponse, error) {
+	return s.cls.ClusterMetadataList(ctx, r)
+}
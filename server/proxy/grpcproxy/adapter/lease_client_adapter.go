@@ -45,6 +45,10 @@ func (c *ls2lc) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (pb
 	return &ls2lcClientStream{cs}, nil
 }
 
+func (c *ls2lc) LeaseRenew(ctx context.Context, in *pb.LeaseRenewRequest, opts ...grpc.CallOption) (*pb.LeaseRenewResponse, error) {
+	return c.leaseServer.LeaseRenew(ctx, in)
+}
+
 func (c *ls2lc) LeaseTimeToLive(ctx context.Context, in *pb.LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*pb.LeaseTimeToLiveResponse, error) {
 	return c.leaseServer.LeaseTimeToLive(ctx, in)
 }
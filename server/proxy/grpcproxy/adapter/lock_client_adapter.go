@@ -35,3 +35,11 @@ func (s *ls2lsc) Lock(ctx context.Context, r *v3lockpb.LockRequest, opts ...grpc
 func (s *ls2lsc) Unlock(ctx context.Context, r *v3lockpb.UnlockRequest, opts ...grpc.CallOption) (*v3lockpb.UnlockResponse, error) {
 	return s.ls.Unlock(ctx, r)
 }
+
+func (s *ls2lsc) LockStatus(ctx context.Context, r *v3lockpb.LockStatusRequest, opts ...grpc.CallOption) (*v3lockpb.LockStatusResponse, error) {
+	return s.ls.LockStatus(ctx, r)
+}
+
+func (s *ls2lsc) ForceUnlock(ctx context.Context, r *v3lockpb.ForceUnlockRequest, opts ...grpc.CallOption) (*v3lockpb.ForceUnlockResponse, error) {
+	return s.ls.ForceUnlock(ctx, r)
+}
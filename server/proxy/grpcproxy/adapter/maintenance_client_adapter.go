@@ -32,6 +32,10 @@ func (s *mts2mtc) Alarm(ctx context.Context, r *pb.AlarmRequest, opts ...grpc.Ca
 	return s.mts.Alarm(ctx, r)
 }
 
+func (s *mts2mtc) ValuePolicy(ctx context.Context, r *pb.ValuePolicyRequest, opts ...grpc.CallOption) (*pb.ValuePolicyResponse, error) {
+	return s.mts.ValuePolicy(ctx, r)
+}
+
 func (s *mts2mtc) Status(ctx context.Context, r *pb.StatusRequest, opts ...grpc.CallOption) (*pb.StatusResponse, error) {
 	return s.mts.Status(ctx, r)
 }
@@ -40,6 +44,10 @@ func (s *mts2mtc) Defragment(ctx context.Context, dr *pb.DefragmentRequest, opts
 	return s.mts.Defragment(ctx, dr)
 }
 
+func (s *mts2mtc) Scrub(ctx context.Context, r *pb.ScrubRequest, opts ...grpc.CallOption) (*pb.ScrubResponse, error) {
+	return s.mts.Scrub(ctx, r)
+}
+
 func (s *mts2mtc) Hash(ctx context.Context, r *pb.HashRequest, opts ...grpc.CallOption) (*pb.HashResponse, error) {
 	return s.mts.Hash(ctx, r)
 }
@@ -56,6 +64,22 @@ func (s *mts2mtc) Downgrade(ctx context.Context, r *pb.DowngradeRequest, opts ..
 	return s.mts.Downgrade(ctx, r)
 }
 
+func (s *mts2mtc) Drain(ctx context.Context, r *pb.DrainRequest, opts ...grpc.CallOption) (*pb.DrainResponse, error) {
+	return s.mts.Drain(ctx, r)
+}
+
+func (s *mts2mtc) ConfigSet(ctx context.Context, r *pb.ConfigSetRequest, opts ...grpc.CallOption) (*pb.ConfigSetResponse, error) {
+	return s.mts.ConfigSet(ctx, r)
+}
+
+func (s *mts2mtc) LogLevelSet(ctx context.Context, r *pb.LogLevelSetRequest, opts ...grpc.CallOption) (*pb.LogLevelSetResponse, error) {
+	return s.mts.LogLevelSet(ctx, r)
+}
+
+func (s *mts2mtc) FeatureGates(ctx context.Context, r *pb.FeatureGatesRequest, opts ...grpc.CallOption) (*pb.FeatureGatesResponse, error) {
+	return s.mts.FeatureGates(ctx, r)
+}
+
 func (s *mts2mtc) Snapshot(ctx context.Context, in *pb.SnapshotRequest, opts ...grpc.CallOption) (pb.Maintenance_SnapshotClient, error) {
 	cs := newPipeStream(ctx, func(ss chanServerStream) error {
 		return s.mts.Snapshot(in, &ss2scServerStream{ss})
@@ -134,6 +134,18 @@ func (cp *clusterProxy) MemberUpdate(ctx context.Context, r *pb.MemberUpdateRequ
 	return cp.clus.MemberUpdate(ctx, r)
 }
 
+func (cp *clusterProxy) ClusterMetadataPut(ctx context.Context, r *pb.ClusterMetadataPutRequest) (*pb.ClusterMetadataPutResponse, error) {
+	return cp.clus.ClusterMetadataPut(ctx, r)
+}
+
+func (cp *clusterProxy) ClusterMetadataDelete(ctx context.Context, r *pb.ClusterMetadataDeleteRequest) (*pb.ClusterMetadataDeleteResponse, error) {
+	return cp.clus.ClusterMetadataDelete(ctx, r)
+}
+
+func (cp *clusterProxy) ClusterMetadataList(ctx context.Context, r *pb.ClusterMetadataListRequest) (*pb.ClusterMetadataListResponse, error) {
+	return cp.clus.ClusterMetadataList(ctx, r)
+}
+
 func (cp *clusterProxy) membersFromUpdates() ([]*pb.Member, error) {
 	cp.umu.RLock()
 	defer cp.umu.RUnlock()
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -116,13 +117,32 @@ func (lp *leaseProxy) LeaseTimeToLive(ctx context.Context, rr *pb.LeaseTimeToLiv
 }
 
 func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
-	r, err := lp.lessor.Leases(ctx)
+	var (
+		r   *clientv3.LeaseLeasesResponse
+		err error
+	)
+	if rr.Details {
+		r, err = lp.lessor.Leases(ctx, clientv3.WithLeaseDetails())
+	} else {
+		r, err = lp.lessor.Leases(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
 	leases := make([]*pb.LeaseStatus, len(r.Leases))
-	for i := range r.Leases {
-		leases[i] = &pb.LeaseStatus{ID: int64(r.Leases[i].ID)}
+	for i, ls := range r.Leases {
+		var labels []*pb.LeaseLabel
+		for k, v := range ls.Labels {
+			labels = append(labels, &pb.LeaseLabel{Key: k, Value: v})
+		}
+		sort.Slice(labels, func(a, b int) bool { return labels[a].Key < labels[b].Key })
+		leases[i] = &pb.LeaseStatus{
+			ID:         int64(ls.ID),
+			TTL:        ls.TTL,
+			GrantedTTL: ls.GrantedTTL,
+			Keys:       ls.Keys,
+			Labels:     labels,
+		}
 	}
 	rp := &pb.LeaseLeasesResponse{
 		Header: r.ResponseHeader,
@@ -131,6 +151,15 @@ func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest
 	return rp, err
 }
 
+func (lp *leaseProxy) LeaseRenew(ctx context.Context, rr *pb.LeaseRenewRequest) (*pb.LeaseRenewResponse, error) {
+	rp, err := lp.leaseClient.LeaseRenew(ctx, rr)
+	if err != nil {
+		return nil, err
+	}
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
 func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
 	lp.mu.Lock()
 	select {
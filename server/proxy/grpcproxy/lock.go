@@ -36,3 +36,11 @@ func (lp *lockProxy) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3lo
 func (lp *lockProxy) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (*v3lockpb.UnlockResponse, error) {
 	return lp.lockClient.Unlock(ctx, req)
 }
+
+func (lp *lockProxy) LockStatus(ctx context.Context, req *v3lockpb.LockStatusRequest) (*v3lockpb.LockStatusResponse, error) {
+	return lp.lockClient.LockStatus(ctx, req)
+}
+
+func (lp *lockProxy) ForceUnlock(ctx context.Context, req *v3lockpb.ForceUnlockRequest) (*v3lockpb.ForceUnlockResponse, error) {
+	return lp.lockClient.ForceUnlock(ctx, req)
+}
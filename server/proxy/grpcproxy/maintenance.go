@@ -75,6 +75,14 @@ func (mp *maintenanceProxy) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.
 	return mp.maintenanceClient.Alarm(ctx, r)
 }
 
+func (mp *maintenanceProxy) ValuePolicy(ctx context.Context, r *pb.ValuePolicyRequest) (*pb.ValuePolicyResponse, error) {
+	return mp.maintenanceClient.ValuePolicy(ctx, r)
+}
+
+func (mp *maintenanceProxy) Scrub(ctx context.Context, r *pb.ScrubRequest) (*pb.ScrubResponse, error) {
+	return mp.maintenanceClient.Scrub(ctx, r)
+}
+
 func (mp *maintenanceProxy) Status(ctx context.Context, r *pb.StatusRequest) (*pb.StatusResponse, error) {
 	return mp.maintenanceClient.Status(ctx, r)
 }
@@ -86,3 +94,19 @@ func (mp *maintenanceProxy) MoveLeader(ctx context.Context, r *pb.MoveLeaderRequ
 func (mp *maintenanceProxy) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
 	return mp.maintenanceClient.Downgrade(ctx, r)
 }
+
+func (mp *maintenanceProxy) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	return mp.maintenanceClient.Drain(ctx, r)
+}
+
+func (mp *maintenanceProxy) ConfigSet(ctx context.Context, r *pb.ConfigSetRequest) (*pb.ConfigSetResponse, error) {
+	return mp.maintenanceClient.ConfigSet(ctx, r)
+}
+
+func (mp *maintenanceProxy) LogLevelSet(ctx context.Context, r *pb.LogLevelSetRequest) (*pb.LogLevelSetResponse, error) {
+	return mp.maintenanceClient.LogLevelSet(ctx, r)
+}
+
+func (mp *maintenanceProxy) FeatureGates(ctx context.Context, r *pb.FeatureGatesRequest) (*pb.FeatureGatesResponse, error) {
+	return mp.maintenanceClient.FeatureGates(ctx, r)
+}
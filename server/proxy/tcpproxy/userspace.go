@@ -19,6 +19,7 @@ import (
 	"io"
 	"math/rand"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 type remote struct {
 	mu       sync.Mutex
 	srv      *net.SRV
+	network  string
 	addr     string
 	inactive bool
 }
@@ -39,7 +41,7 @@ func (r *remote) inactivate() {
 }
 
 func (r *remote) tryReactivate() error {
-	conn, err := net.Dial("tcp", r.addr)
+	conn, err := net.Dial(r.network, r.addr)
 	if err != nil {
 		return err
 	}
@@ -57,8 +59,10 @@ func (r *remote) isActive() bool {
 }
 
 type TCPProxy struct {
-	Logger          *zap.Logger
-	Listener        net.Listener
+	Logger   *zap.Logger
+	Listener net.Listener
+	// Endpoints are the proxied endpoints. An endpoint with an absolute
+	// path as target and port 0 is a unix socket.
 	Endpoints       []*net.SRV
 	MonitorInterval time.Duration
 
@@ -77,8 +81,11 @@ func (tp *TCPProxy) Run() error {
 
 	var eps []string // for logging
 	for _, srv := range tp.Endpoints {
-		addr := net.JoinHostPort(srv.Target, fmt.Sprintf("%d", srv.Port))
-		tp.remotes = append(tp.remotes, &remote{srv: srv, addr: addr})
+		network, addr := "tcp", net.JoinHostPort(srv.Target, fmt.Sprintf("%d", srv.Port))
+		if srv.Port == 0 && strings.HasPrefix(srv.Target, "/") {
+			network, addr = "unix", srv.Target
+		}
+		tp.remotes = append(tp.remotes, &remote{srv: srv, network: network, addr: addr})
 		eps = append(eps, addr)
 	}
 	if tp.Logger != nil {
@@ -167,7 +174,7 @@ func (tp *TCPProxy) serve(in net.Conn) {
 			break
 		}
 		// TODO: add timeout
-		out, err = net.Dial("tcp", remote.addr)
+		out, err = net.Dial(remote.network, remote.addr)
 		if err == nil {
 			break
 		}
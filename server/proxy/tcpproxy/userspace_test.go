@@ -21,6 +21,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -68,6 +70,55 @@ func TestUserspaceProxy(t *testing.T) {
 	}
 }
 
+func TestUserspaceProxyUnix(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// keep the socket path short enough for sockaddr_un.
+	dir, err := os.MkdirTemp("", "tcpproxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "etcd.sock")
+	ul, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "hello unix proxy"
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, want)
+	}))
+	ts.Listener = ul
+	ts.Start()
+	defer ts.Close()
+
+	p := TCPProxy{
+		Listener:  l,
+		Endpoints: []*net.SRV{{Target: sock}},
+	}
+	go p.Run()
+	defer p.Stop()
+
+	res, err := http.Get("http://" + l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, gerr := io.ReadAll(res.Body)
+	res.Body.Close()
+	if gerr != nil {
+		t.Fatal(gerr)
+	}
+
+	if string(got) != want {
+		t.Errorf("got = %s, want %s", got, want)
+	}
+}
+
 func TestUserspaceProxyPriority(t *testing.T) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -44,6 +44,12 @@ func newBackend(cfg config.ServerConfig, hooks backend.Hooks) backend.Backend {
 			cfg.Logger.Info("setting backend batch interval", zap.Duration("batch interval", cfg.BackendBatchInterval))
 		}
 	}
+	if cfg.BackendBatchLimitMax > bcfg.BatchLimit {
+		bcfg.BatchLimitMax = cfg.BackendBatchLimitMax
+		if cfg.Logger != nil {
+			cfg.Logger.Info("setting backend batch limit max", zap.Int("batch limit max", cfg.BackendBatchLimitMax))
+		}
+	}
 	bcfg.BackendFreelistType = cfg.BackendFreelistType
 	bcfg.Logger = cfg.Logger
 	if cfg.QuotaBackendBytes > 0 && cfg.QuotaBackendBytes != DefaultQuotaBytes {
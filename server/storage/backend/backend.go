@@ -15,6 +15,7 @@
 package backend
 
 import (
+	"context"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -55,6 +56,11 @@ type Backend interface {
 
 	Snapshot() Snapshot
 	Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error)
+	// CheckPages checks the consistency of the pages of the database file,
+	// returning the problems found. It checks a subtree of the pages of a
+	// bucket per read transaction, calling wait with the bytes checked before
+	// checking the next one.
+	CheckPages(ctx context.Context, wait func(ctx context.Context, n int64) error) ([]error, error)
 	// Size returns the current size of the backend physically allocated.
 	// The backend can hold DB space that is not utilized at the moment,
 	// since it can conduct pre-allocation or spare unused space for recycling.
@@ -72,6 +78,29 @@ type Backend interface {
 
 	// SetTxPostLockInsideApplyHook sets a txPostLockInsideApplyHook.
 	SetTxPostLockInsideApplyHook(func())
+
+	// BatchConfig returns the current configuration of the batch tx.
+	BatchConfig() BatchConfig
+	// SetBatchConfig changes the configuration of the batch tx.
+	SetBatchConfig(cfg BatchConfig)
+
+	// CommitInProgress returns how long the commit of the batch tx in
+	// progress has been running, or zero if no commit is in progress.
+	CommitInProgress() time.Duration
+}
+
+// BatchConfig configures when the batch tx is committed.
+type BatchConfig struct {
+	// Interval is the maximum time before committing the batch tx.
+	Interval time.Duration
+	// Limit is the maximum number of pending operations before committing
+	// the batch tx.
+	Limit int
+	// LimitMax is the maximum the limit grows to while the operations fill
+	// the batch tx before the interval expires. The limit is halved back
+	// towards Limit once they no longer do. The limit is fixed if LimitMax is
+	// not greater than Limit.
+	LimitMax int
 }
 
 type Snapshot interface {
@@ -108,9 +137,22 @@ type backend struct {
 	bopts *bolt.Options
 	db    *bolt.DB
 
-	batchInterval time.Duration
-	batchLimit    int
-	batchTx       *batchTxBuffered
+	// batchInterval is the maximum time before committing the batch tx.
+	batchInterval atomic.Int64
+	// batchIntervalc notifies the run loop the batch interval changed.
+	batchIntervalc chan struct{}
+	// batchCfg is the configured batch limit, and batchLimit the current
+	// one, which grows under load up to batchCfg.LimitMax. They are
+	// protected by the batch tx lock.
+	batchCfg   BatchConfig
+	batchLimit int
+	// batchLimitReached is whether the batch limit was reached since the
+	// batch interval last expired.
+	batchLimitReached bool
+	batchTx           *batchTxBuffered
+	// commitStart is the start time in unix nanoseconds of the commit in
+	// progress, zero if none.
+	commitStart atomic.Int64
 
 	readTx *readTx
 	// txReadBufferCache mirrors "txReadBuffer" within "readTx" -- readTx.baseReadTx.buf.
@@ -137,6 +179,9 @@ type BackendConfig struct {
 	BatchInterval time.Duration
 	// BatchLimit is the maximum puts before flushing the BatchTx.
 	BatchLimit int
+	// BatchLimitMax is the maximum the batch limit grows to under load. The
+	// batch limit is fixed if it is not greater than BatchLimit.
+	BatchLimitMax int
 	// BackendFreelistType is the backend boltdb's freelist type.
 	BackendFreelistType bolt.FreelistType
 	// MmapSize is the number of bytes to mmap for the backend.
@@ -211,9 +256,9 @@ func newBackend(bcfg BackendConfig) *backend {
 		bopts: bopts,
 		db:    db,
 
-		batchInterval: bcfg.BatchInterval,
-		batchLimit:    bcfg.BatchLimit,
-		mlock:         bcfg.Mlock,
+		batchCfg:   BatchConfig{Interval: bcfg.BatchInterval, Limit: bcfg.BatchLimit, LimitMax: bcfg.BatchLimitMax},
+		batchLimit: bcfg.BatchLimit,
+		mlock:      bcfg.Mlock,
 
 		readTx: &readTx{
 			baseReadTx: baseReadTx{
@@ -232,11 +277,15 @@ func newBackend(bcfg BackendConfig) *backend {
 			buf:        nil,
 		},
 
+		batchIntervalc: make(chan struct{}, 1),
+
 		stopc: make(chan struct{}),
 		donec: make(chan struct{}),
 
 		lg: bcfg.Logger,
 	}
+	b.batchInterval.Store(int64(bcfg.BatchInterval))
+	batchLimitGauge.Set(float64(b.batchLimit))
 
 	b.batchTx = newBatchTxBuffered(b)
 	// We set it after newBatchTxBuffered to skip the 'empty' commit.
@@ -430,19 +479,44 @@ func (b *backend) SizeInUse() int64 {
 
 func (b *backend) run() {
 	defer close(b.donec)
-	t := time.NewTimer(b.batchInterval)
+	t := time.NewTimer(time.Duration(b.batchInterval.Load()))
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
+		case <-b.batchIntervalc:
+			// the batch interval changed.
+			t.Reset(time.Duration(b.batchInterval.Load()))
+			continue
 		case <-b.stopc:
 			b.batchTx.CommitAndStop()
 			return
 		}
+		b.batchTx.adaptBatchLimit()
 		if b.batchTx.safePending() != 0 {
 			b.batchTx.Commit()
 		}
-		t.Reset(b.batchInterval)
+		t.Reset(time.Duration(b.batchInterval.Load()))
+	}
+}
+
+func (b *backend) BatchConfig() BatchConfig {
+	b.batchTx.Mutex.Lock()
+	defer b.batchTx.Mutex.Unlock()
+	return b.batchCfg
+}
+
+func (b *backend) SetBatchConfig(cfg BatchConfig) {
+	b.batchTx.Mutex.Lock()
+	defer b.batchTx.Mutex.Unlock()
+	b.batchCfg = cfg
+	b.batchLimit = cfg.Limit
+	batchLimitGauge.Set(float64(b.batchLimit))
+	if time.Duration(b.batchInterval.Swap(int64(cfg.Interval))) != cfg.Interval {
+		select {
+		case b.batchIntervalc <- struct{}{}:
+		default:
+		}
 	}
 }
 
@@ -459,6 +533,14 @@ func (b *backend) Commits() int64 {
 	return atomic.LoadInt64(&b.commits)
 }
 
+func (b *backend) CommitInProgress() time.Duration {
+	start := b.commitStart.Load()
+	if start == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, start))
+}
+
 func (b *backend) Defrag() error {
 	return b.defrag()
 }
@@ -15,6 +15,7 @@
 package backend_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -124,6 +125,50 @@ func TestBackendBatchIntervalCommit(t *testing.T) {
 	}))
 }
 
+func TestBackendAdaptiveBatchLimit(t *testing.T) {
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.BatchInterval, bcfg.BatchLimit, bcfg.BatchLimitMax = time.Hour, 2, 8
+	b, _ := betesting.NewTmpBackendFromCfg(t, bcfg)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.Unlock()
+	put := func(n int) {
+		for i := 0; i < n; i++ {
+			tx.Lock()
+			tx.UnsafePut(schema.Test, []byte(fmt.Sprintf("foo%d", i)), []byte("bar"))
+			tx.Unlock()
+		}
+	}
+
+	// the limit doubles every time the puts fill the batch.
+	put(2)
+	assert.Equal(t, 4, backend.BatchLimitForTest(b))
+	put(4)
+	assert.Equal(t, 8, backend.BatchLimitForTest(b))
+	put(8)
+	assert.Equal(t, 8, backend.BatchLimitForTest(b))
+
+	// a smaller limit resets the current one.
+	b.SetBatchConfig(backend.BatchConfig{Interval: time.Hour, Limit: 2, LimitMax: 4})
+	assert.Equal(t, backend.BatchConfig{Interval: time.Hour, Limit: 2, LimitMax: 4}, b.BatchConfig())
+	assert.Equal(t, 2, backend.BatchLimitForTest(b))
+	put(2)
+	assert.Equal(t, 4, backend.BatchLimitForTest(b))
+
+	// the limit is halved once the batch interval expires without the puts
+	// filling the batch.
+	b.SetBatchConfig(backend.BatchConfig{Interval: 50 * time.Millisecond, Limit: 1, LimitMax: 1024})
+	put(1)
+	put(2)
+	assert.Equal(t, 4, backend.BatchLimitForTest(b))
+	assert.Eventually(t, func() bool {
+		return backend.BatchLimitForTest(b) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestBackendDefrag(t *testing.T) {
 	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
 	// Make sure we change BackendFreelistType
@@ -349,3 +394,39 @@ func TestBackendWritebackForEach(t *testing.T) {
 		t.Fatalf("expected %q, got %q", seq, partialSeq)
 	}
 }
+
+func TestBackendCheckPages(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	for i := 0; i < 20000; i++ {
+		tx.UnsafePut(schema.Test, []byte(fmt.Sprintf("foo_%05d", i)), make([]byte, 100))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+
+	backend.SetCheckPagesChunkPagesForTest(t, 16)
+	var waits int
+	var bytes int64
+	errs, err := b.CheckPages(context.Background(), func(ctx context.Context, n int64) error {
+		waits++
+		bytes += n
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Greater(t, waits, 16, "expected the pages to be checked in chunks")
+	assert.Positive(t, bytes)
+
+	// the check stops once waiting fails, e.g. on cancellation.
+	waits = 0
+	_, err = b.CheckPages(context.Background(), func(ctx context.Context, n int64) error {
+		waits++
+		return context.Canceled
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, waits)
+}
@@ -22,6 +22,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	bolt "go.etcd.io/bbolt"
@@ -54,6 +55,9 @@ type BatchTx interface {
 	CommitAndStop()
 	LockInsideApply()
 	LockOutsideApply()
+	// SetCommitExemplar attaches the exemplar to the commit duration of the
+	// next commit, linking it to the trace of a request it includes.
+	SetCommitExemplar(exemplar prometheus.Labels)
 	UnsafeReadWriter
 }
 
@@ -76,6 +80,8 @@ type batchTx struct {
 	backend *backend
 
 	pending int
+	// exemplar is observed with the duration of the next commit.
+	exemplar atomic.Pointer[prometheus.Labels]
 }
 
 // Lock is supposed to be called only by the unit test.
@@ -252,6 +258,13 @@ func (t *batchTx) CommitAndStop() {
 	t.Unlock()
 }
 
+func (t *batchTx) SetCommitExemplar(exemplar prometheus.Labels) {
+	if exemplar == nil {
+		return
+	}
+	t.exemplar.Store(&exemplar)
+}
+
 func (t *batchTx) safePending() int {
 	t.Mutex.Lock()
 	defer t.Mutex.Unlock()
@@ -266,15 +279,22 @@ func (t *batchTx) commit(stop bool) {
 		}
 
 		start := time.Now()
+		t.backend.commitStart.Store(start.UnixNano())
 
 		// gofail: var beforeCommit struct{}
 		err := t.tx.Commit()
 		// gofail: var afterCommit struct{}
+		t.backend.commitStart.Store(0)
 
+		commitOps.Observe(float64(t.pending))
 		rebalanceSec.Observe(t.tx.Stats().RebalanceTime.Seconds())
 		spillSec.Observe(t.tx.Stats().SpillTime.Seconds())
 		writeSec.Observe(t.tx.Stats().WriteTime.Seconds())
-		commitSec.Observe(time.Since(start).Seconds())
+		if exemplar := t.exemplar.Swap(nil); exemplar != nil {
+			commitSec.(prometheus.ExemplarObserver).ObserveWithExemplar(time.Since(start).Seconds(), *exemplar)
+		} else {
+			commitSec.Observe(time.Since(start).Seconds())
+		}
 		atomic.AddInt64(&t.backend.commits, 1)
 
 		t.pending = 0
@@ -332,13 +352,43 @@ func (t *batchTxBuffered) Unlock() {
 		//
 		// Please also refer to
 		// https://github.com/etcd-io/etcd/pull/17119#issuecomment-1857547158
-		if t.pending >= t.backend.batchLimit || t.pendingDeleteOperations > 0 {
+		if t.pending >= t.backend.batchLimit {
+			t.growBatchLimit()
+			t.commit(false)
+		} else if t.pendingDeleteOperations > 0 {
 			t.commit(false)
 		}
 	}
 	t.batchTx.Unlock()
 }
 
+// growBatchLimit doubles the batch limit, up to the configured maximum, as
+// the operations filled the batch before the batch interval expired.
+func (t *batchTxBuffered) growBatchLimit() {
+	b := t.backend
+	b.batchLimitReached = true
+	batchLimitCommits.Inc()
+	if b.batchLimit >= b.batchCfg.LimitMax {
+		return
+	}
+	b.batchLimit = min(2*b.batchLimit, b.batchCfg.LimitMax)
+	batchLimitGauge.Set(float64(b.batchLimit))
+}
+
+// adaptBatchLimit halves the batch limit, down to the configured limit, if
+// the operations did not fill the batch since the batch interval last
+// expired.
+func (t *batchTxBuffered) adaptBatchLimit() {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	b := t.backend
+	if !b.batchLimitReached && b.batchLimit > b.batchCfg.Limit {
+		b.batchLimit = max(b.batchLimit/2, b.batchCfg.Limit)
+		batchLimitGauge.Set(float64(b.batchLimit))
+	}
+	b.batchLimitReached = false
+}
+
 func (t *batchTxBuffered) Commit() {
 	t.lock()
 	t.commit(false)
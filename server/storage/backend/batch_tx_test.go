@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
 
 	bolt "go.etcd.io/bbolt"
 	"go.etcd.io/etcd/server/v3/storage/backend"
@@ -182,6 +184,34 @@ func TestBatchTxCommit(t *testing.T) {
 	})
 }
 
+func TestBatchTxCommitExemplar(t *testing.T) {
+	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, b)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+	tx.Unlock()
+	tx.SetCommitExemplar(prometheus.Labels{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"})
+	tx.Commit()
+
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var traceIDs []string
+	for _, mf := range mfs {
+		if mf.GetName() != "etcd_disk_backend_commit_duration_seconds" {
+			continue
+		}
+		for _, bucket := range mf.GetMetric()[0].GetHistogram().GetBucket() {
+			for _, l := range bucket.GetExemplar().GetLabel() {
+				traceIDs = append(traceIDs, l.GetValue())
+			}
+		}
+	}
+	require.Equal(t, []string{"4bf92f3577b34da6a3ce929d0e0e4736"}, traceIDs)
+}
+
 func TestBatchTxBatchLimitCommit(t *testing.T) {
 	// start backend with batch limit 1 so one write can
 	// trigger a commit
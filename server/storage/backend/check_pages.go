@@ -0,0 +1,200 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// checkPagesChunkPages is the number of pages of the database file the pages
+// of a bucket are split into subtrees of, each checked in one read transaction.
+var checkPagesChunkPages int64 = 1024
+
+const (
+	// the layout of the branch pages of bbolt: a page header followed by the
+	// elements pointing at the child pages.
+	boltPageHeaderSize          = 16
+	boltBranchElementSize       = 16
+	boltBranchElementPgidOffset = 8
+)
+
+// CheckPages checks the consistency of the pages of the buckets of the
+// database file, returning the problems found. Rather than holding one read
+// transaction, which keeps the pages freed meanwhile from being reused, for
+// as long as the whole file is read, the pages of a bucket are split into
+// subtrees of about checkPagesChunkPages pages, each checked in its own read
+// transaction after waiting for the bytes checked before. The subtrees are
+// found again from the bucket in every transaction, so the check follows the
+// changes of the bucket meanwhile, but does not check the pages across the
+// subtrees, like every page being reachable or freed.
+func (b *backend) CheckPages(ctx context.Context, wait func(ctx context.Context, n int64) error) ([]error, error) {
+	var names [][]byte
+	var chunks int64
+	err := b.viewPages(func(tx *bolt.Tx, _ *os.File) error {
+		chunks = max(tx.Size()/int64(tx.DB().Info().PageSize)/checkPagesChunkPages, 1)
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			names = append(names, append([]byte(nil), name...))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	for _, name := range names {
+		var paths [][]int
+		err = b.viewPages(func(tx *bolt.Tx, f *os.File) (err error) {
+			paths, err = subtreePaths(tx, f, name, chunks)
+			return err
+		})
+		if err != nil {
+			return errs, err
+		}
+		for _, path := range paths {
+			var n int64
+			err = b.viewPages(func(tx *bolt.Tx, f *os.File) error {
+				pgid, ok, err := resolveSubtree(tx, f, name, path)
+				if err != nil || !ok {
+					return err
+				}
+				for cerr := range tx.Check(bolt.WithPageId(pgid)) {
+					// the problems of the freelist are found for every subtree.
+					if !seen[cerr.Error()] {
+						seen[cerr.Error()] = true
+						errs = append(errs, fmt.Errorf("bucket %q: %w", name, cerr))
+					}
+				}
+				n = tx.Size() / chunks
+				if info, _ := tx.Page(int(pgid)); info != nil && info.Type == "leaf" {
+					n = int64(info.OverflowCount+1) * int64(tx.DB().Info().PageSize)
+				}
+				return nil
+			})
+			if err != nil {
+				return errs, err
+			}
+			if err = wait(ctx, n); err != nil {
+				return errs, err
+			}
+		}
+	}
+	return errs, nil
+}
+
+// viewPages calls fn in a read transaction with the database file open to read
+// the pages the transaction sees.
+func (b *backend) viewPages(fn func(tx *bolt.Tx, f *os.File) error) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	f, err := os.Open(b.db.Path())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(tx, f)
+	})
+}
+
+// subtreePaths returns the paths of child indexes from the root page of the
+// bucket to the roots of its subtrees, descending the branch pages until
+// there are as many subtrees as chunks.
+func subtreePaths(tx *bolt.Tx, f *os.File, name []byte, chunks int64) ([][]int, error) {
+	type subtree struct {
+		path []int
+		pgid uint64
+	}
+	bkt := tx.Bucket(name)
+	if bkt == nil || bkt.RootPage() == 0 {
+		// inline buckets are stored in the page of the root bucket.
+		return nil, nil
+	}
+	level := []subtree{{pgid: uint64(bkt.RootPage())}}
+	for int64(len(level)) < chunks {
+		var next []subtree
+		for _, st := range level {
+			info, err := tx.Page(int(st.pgid))
+			if err != nil {
+				return nil, err
+			}
+			if info == nil || info.Type != "branch" {
+				next = append(next, st)
+				continue
+			}
+			children, err := branchChildren(f, tx.DB().Info().PageSize, st.pgid, info.Count)
+			if err != nil {
+				return nil, err
+			}
+			for i, child := range children {
+				next = append(next, subtree{path: append(append([]int(nil), st.path...), i), pgid: child})
+			}
+		}
+		if len(next) == len(level) {
+			break
+		}
+		level = next
+	}
+	paths := make([][]int, len(level))
+	for i, st := range level {
+		paths[i] = st.path
+	}
+	return paths, nil
+}
+
+// resolveSubtree returns the page the path leads to from the root page of the
+// bucket, or false if the path no longer exists.
+func resolveSubtree(tx *bolt.Tx, f *os.File, name []byte, path []int) (uint64, bool, error) {
+	bkt := tx.Bucket(name)
+	if bkt == nil || bkt.RootPage() == 0 {
+		return 0, false, nil
+	}
+	pgid := uint64(bkt.RootPage())
+	for _, i := range path {
+		info, err := tx.Page(int(pgid))
+		if err != nil {
+			return 0, false, err
+		}
+		if info == nil || info.Type != "branch" || i >= info.Count {
+			return 0, false, nil
+		}
+		buf := make([]byte, 8)
+		off := int64(pgid)*int64(tx.DB().Info().PageSize) + boltPageHeaderSize + int64(i)*boltBranchElementSize + boltBranchElementPgidOffset
+		if _, err = f.ReadAt(buf, off); err != nil {
+			return 0, false, err
+		}
+		pgid = binary.NativeEndian.Uint64(buf)
+	}
+	return pgid, true, nil
+}
+
+// branchChildren returns the child pages of a branch page with count elements.
+func branchChildren(f *os.File, pageSize int, pgid uint64, count int) ([]uint64, error) {
+	buf := make([]byte, count*boltBranchElementSize)
+	if _, err := f.ReadAt(buf, int64(pgid)*int64(pageSize)+boltPageHeaderSize); err != nil {
+		return nil, err
+	}
+	children := make([]uint64, count)
+	for i := range children {
+		children[i] = binary.NativeEndian.Uint64(buf[i*boltBranchElementSize+boltBranchElementPgidOffset:])
+	}
+	return children, nil
+}
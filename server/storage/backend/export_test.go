@@ -14,7 +14,11 @@
 
 package backend
 
-import bolt "go.etcd.io/bbolt"
+import (
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
 
 func DbFromBackendForTest(b Backend) *bolt.DB {
 	return b.(*backend).db
@@ -27,3 +31,16 @@ func DefragLimitForTest() int {
 func CommitsForTest(b Backend) int64 {
 	return b.(*backend).Commits()
 }
+
+func BatchLimitForTest(b Backend) int {
+	bb := b.(*backend)
+	bb.batchTx.Mutex.Lock()
+	defer bb.batchTx.Mutex.Unlock()
+	return bb.batchLimit
+}
+
+func SetCheckPagesChunkPagesForTest(t testing.TB, n int64) {
+	old := checkPagesChunkPages
+	checkPagesChunkPages = n
+	t.Cleanup(func() { checkPagesChunkPages = old })
+}
@@ -84,6 +84,31 @@ var (
 		Buckets: prometheus.ExponentialBuckets(.01, 2, 17),
 	})
 
+	commitOps = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "disk",
+		Name:      "backend_commit_operations",
+		Help:      "The distribution of the number of operations committed by each commit called by backend.",
+
+		// lowest bucket start of upper bound 1 with factor 2
+		// highest bucket start of 2^16 == 65536
+		Buckets: prometheus.ExponentialBuckets(1, 2, 17),
+	})
+
+	batchLimitCommits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "disk",
+		Name:      "backend_batch_limit_commits_total",
+		Help:      "The number of backend commits triggered by the number of pending operations reaching the batch limit.",
+	})
+
+	batchLimitGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "disk",
+		Name:      "backend_batch_limit",
+		Help:      "The current number of pending operations before the backend commits, which grows under load with an adaptive batch limit.",
+	})
+
 	isDefragActive = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "etcd",
 		Subsystem: "disk",
@@ -100,4 +125,7 @@ func init() {
 	prometheus.MustRegister(defragSec)
 	prometheus.MustRegister(snapshotTransferSec)
 	prometheus.MustRegister(isDefragActive)
+	prometheus.MustRegister(commitOps)
+	prometheus.MustRegister(batchLimitCommits)
+	prometheus.MustRegister(batchLimitGauge)
 }
@@ -0,0 +1,173 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// Retention keeps the history of the keys with the prefix from the revision
+// on, when the store is compacted at a later revision. The retention with the
+// longest prefix of a key applies to the key.
+type Retention struct {
+	Prefix   []byte
+	Revision int64
+}
+
+// contains reports whether the range [key, end) is under the prefix. A nil
+// end is the single key, and an empty end is every key from key on.
+func (r Retention) contains(key, end []byte) bool {
+	if !bytes.HasPrefix(key, r.Prefix) {
+		return false
+	}
+	if end == nil {
+		return true
+	}
+	pend := prefixEnd(r.Prefix)
+	return pend == nil || (len(end) > 0 && bytes.Compare(end, pend) <= 0)
+}
+
+// intersects reports whether the range [key, end) has keys under the prefix.
+func (r Retention) intersects(key, end []byte) bool {
+	if end == nil {
+		return bytes.HasPrefix(key, r.Prefix)
+	}
+	if pend := prefixEnd(r.Prefix); pend != nil && bytes.Compare(key, pend) >= 0 {
+		return false
+	}
+	return len(end) == 0 || bytes.Compare(r.Prefix, end) < 0
+}
+
+// prefixEnd returns the end of the range of the keys with the prefix, or nil
+// if there is no end.
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			end := make([]byte, i+1)
+			copy(end, prefix)
+			end[i]++
+			return end
+		}
+	}
+	return nil
+}
+
+// compactRevOf returns the compaction revision of the range [key, end), the
+// revision below which the history of some key in the range may be compacted.
+// rs are the retentions of a compaction at rev.
+func compactRevOf(rev int64, rs []Retention, key, end []byte) int64 {
+	if len(rs) == 0 {
+		return rev
+	}
+	ret, n := rev, -1
+	for _, r := range rs {
+		if len(r.Prefix) > n && r.contains(key, end) {
+			ret, n = r.Revision, len(r.Prefix)
+		}
+	}
+	if end == nil {
+		return ret
+	}
+	for _, r := range rs {
+		if r.Revision > ret && !r.contains(key, end) && r.intersects(key, end) {
+			ret = r.Revision
+		}
+	}
+	return ret
+}
+
+// globalCompactRevOf returns the compaction revision of the keys under no
+// other retention than the one of the empty prefix, if any. rs are the
+// retentions of a compaction at rev.
+func globalCompactRevOf(rev int64, rs []Retention) int64 {
+	return compactRevOf(rev, rs, nil, nil)
+}
+
+// mergeRetentions returns the retentions of a compaction at rev requesting
+// the retentions req, after a compaction at prevRev with the retentions prev.
+//
+// The compaction revision of a key never goes backwards: a retention keeps
+// at most the history the previous compaction kept. The retentions left from
+// the previous compaction but not requested again follow their parent
+// prefix, or rev, as far as that history allows. The retentions that do not
+// change the compaction revision of their keys are dropped, so the result
+// is the same for any members applying the same compactions.
+func mergeRetentions(prevRev int64, prev []Retention, rev int64, req []Retention) []Retention {
+	want := make(map[string]int64, len(req))
+	for _, r := range req {
+		want[string(r.Prefix)] = max(min(r.Revision, rev), 0)
+	}
+	prefixes := make([]string, 0, len(want)+len(prev))
+	for p := range want {
+		prefixes = append(prefixes, p)
+	}
+	for _, r := range prev {
+		if _, ok := want[string(r.Prefix)]; !ok {
+			prefixes = append(prefixes, string(r.Prefix))
+		}
+	}
+	// parents go before the prefixes under them.
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) < len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	var ret []Retention
+	for _, p := range prefixes {
+		inherited := compactRevOf(rev, ret, []byte(p), nil)
+		w, ok := want[p]
+		if !ok {
+			w = inherited
+		}
+		w = max(w, compactRevOf(prevRev, prev, []byte(p), nil))
+		if w != inherited {
+			ret = append(ret, Retention{Prefix: []byte(p), Revision: w})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return bytes.Compare(ret[i].Prefix, ret[j].Prefix) < 0 })
+	return ret
+}
+
+func encodeRetentions(rs []Retention) []byte {
+	var b []byte
+	for _, r := range rs {
+		b = binary.AppendUvarint(b, uint64(len(r.Prefix)))
+		b = append(b, r.Prefix...)
+		b = binary.BigEndian.AppendUint64(b, uint64(r.Revision))
+	}
+	return b
+}
+
+func decodeRetentions(b []byte) ([]Retention, error) {
+	var rs []Retention
+	for len(b) > 0 {
+		n, l := binary.Uvarint(b)
+		if l <= 0 || uint64(len(b)-l) < n+8 {
+			return nil, fmt.Errorf("invalid compaction retentions")
+		}
+		b = b[l:]
+		rs = append(rs, Retention{
+			Prefix:   bytes.Clone(b[:n]),
+			Revision: int64(binary.BigEndian.Uint64(b[n : n+8])),
+		})
+		b = b[n+8:]
+	}
+	return rs, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactRevOf(t *testing.T) {
+	rs := []Retention{
+		{Prefix: []byte("/a/"), Revision: 5},
+		{Prefix: []byte("/a/b/"), Revision: 8},
+		{Prefix: []byte("/c/"), Revision: 2},
+	}
+	tests := []struct {
+		key, end string
+		single   bool
+		want     int64
+	}{
+		{key: "/x", single: true, want: 10},
+		{key: "/a/x", single: true, want: 5},
+		{key: "/a/b/x", single: true, want: 8},
+		{key: "/c/x", single: true, want: 2},
+		// ranges under a prefix
+		{key: "/a/", end: "/a0", want: 8},
+		{key: "/a/b/", end: "/a/b0", want: 8},
+		{key: "/c/", end: "/c0", want: 2},
+		{key: "/c/a", end: "/c/b", want: 2},
+		// ranges over several prefixes, or keys without retention
+		{key: "/a/", end: "/c0", want: 10},
+		{key: "/c/", want: 10},
+		{key: "/b", end: "/c/x", want: 10},
+	}
+	for _, tt := range tests {
+		var end []byte
+		if !tt.single {
+			end = []byte(tt.end)
+		}
+		assert.Equalf(t, tt.want, compactRevOf(10, rs, []byte(tt.key), end), "compactRevOf(%q, %q)", tt.key, tt.end)
+	}
+	assert.Equal(t, int64(3), compactRevOf(10, []Retention{{Revision: 3}}, []byte("/x"), []byte{}))
+}
+
+func TestMergeRetentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		prevRev int64
+		prev    []Retention
+		rev     int64
+		req     []Retention
+		want    []Retention
+	}{
+		{
+			name:    "retention is bounded by the previous compaction",
+			prevRev: 4, rev: 10,
+			req:  []Retention{{Prefix: []byte("/a/"), Revision: 2}, {Prefix: []byte("/b/"), Revision: 6}},
+			want: []Retention{{Prefix: []byte("/a/"), Revision: 4}, {Prefix: []byte("/b/"), Revision: 6}},
+		},
+		{
+			name:    "retention not requested again follows the compaction",
+			prevRev: 10, prev: []Retention{{Prefix: []byte("/a/"), Revision: 4}},
+			rev: 20,
+		},
+		{
+			name:    "retention keeps at most the previous history",
+			prevRev: 10, prev: []Retention{{Prefix: []byte("/a/"), Revision: 4}},
+			rev:  20,
+			req:  []Retention{{Prefix: []byte("/a/"), Revision: 1}, {Prefix: []byte("/b/"), Revision: 25}},
+			want: []Retention{{Prefix: []byte("/a/"), Revision: 4}},
+		},
+		{
+			name:    "nested retention does not go back below its parent",
+			prevRev: 10, prev: []Retention{{Prefix: []byte("/a/"), Revision: 4}},
+			rev:  20,
+			req:  []Retention{{Prefix: []byte("/a/"), Revision: 8}, {Prefix: []byte("/a/b/"), Revision: 2}},
+			want: []Retention{{Prefix: []byte("/a/"), Revision: 8}, {Prefix: []byte("/a/b/"), Revision: 4}},
+		},
+		{
+			name:    "nested retention not requested again follows its parent",
+			prevRev: 10,
+			prev:    []Retention{{Prefix: []byte("/a/"), Revision: 4}, {Prefix: []byte("/a/b/"), Revision: 6}},
+			rev:     20,
+			req:     []Retention{{Prefix: []byte("/a/"), Revision: 2}},
+			want:    []Retention{{Prefix: []byte("/a/"), Revision: 4}, {Prefix: []byte("/a/b/"), Revision: 6}},
+		},
+		{
+			name:    "default retention",
+			prevRev: 10,
+			rev:     20,
+			req:     []Retention{{Revision: 15}, {Prefix: []byte("/cache/"), Revision: 20}},
+			want:    []Retention{{Prefix: []byte{}, Revision: 15}, {Prefix: []byte("/cache/"), Revision: 20}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeRetentions(tt.prevRev, tt.prev, tt.rev, tt.req)
+			assert.Equal(t, tt.want, got)
+			// resuming the compaction gives the same retentions.
+			assert.Equal(t, got, mergeRetentions(tt.prevRev, got, tt.rev, got))
+		})
+	}
+}
+
+func TestRetentionsEncoding(t *testing.T) {
+	rs := []Retention{{Prefix: []byte{}, Revision: 1}, {Prefix: []byte("/a/"), Revision: 1 << 40}}
+	got, err := decodeRetentions(encodeRetentions(rs))
+	require.NoError(t, err)
+	assert.Equal(t, rs, got)
+
+	_, err = decodeRetentions([]byte{3, 'a'})
+	require.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/google/btree"
@@ -23,12 +24,14 @@ import (
 
 type index interface {
 	Get(key []byte, atRev int64) (rev, created Revision, ver int64, err error)
+	// Has reports whether the revision is in the history of the key.
+	Has(key []byte, rev Revision) bool
 	Range(key, end []byte, atRev int64) ([][]byte, []Revision)
 	Revisions(key, end []byte, atRev int64, limit int) ([]Revision, int)
 	CountRevisions(key, end []byte, atRev int64) int
 	Put(key []byte, rev Revision)
 	Tombstone(key []byte, rev Revision) error
-	Compact(rev int64) map[Revision]struct{}
+	Compact(rev int64, retentions ...Retention) map[Revision]struct{}
 	Keep(rev int64) map[Revision]struct{}
 	Equal(b index) bool
 
@@ -36,6 +39,23 @@ type index interface {
 	KeyIndex(ki *keyIndex) *keyIndex
 }
 
+// keyIndexLookups holds the keyIndexes used to search the tree for a key,
+// as the key given to the tree escapes to the heap.
+var keyIndexLookups = sync.Pool{New: func() any { return new(keyIndex) }}
+
+// lookupKeyIndex returns a keyIndex of the given key to search the tree with.
+// It must be released with releaseLookupKeyIndex once the search is done.
+func lookupKeyIndex(key []byte) *keyIndex {
+	ki := keyIndexLookups.Get().(*keyIndex)
+	ki.key = key
+	return ki
+}
+
+func releaseLookupKeyIndex(ki *keyIndex) {
+	ki.key = nil
+	keyIndexLookups.Put(ki)
+}
+
 type treeIndex struct {
 	sync.RWMutex
 	tree *btree.BTreeG[*keyIndex]
@@ -52,12 +72,14 @@ func newTreeIndex(lg *zap.Logger) index {
 }
 
 func (ti *treeIndex) Put(key []byte, rev Revision) {
-	keyi := &keyIndex{key: key}
+	lookup := lookupKeyIndex(key)
+	defer releaseLookupKeyIndex(lookup)
 
 	ti.Lock()
 	defer ti.Unlock()
-	okeyi, ok := ti.tree.Get(keyi)
+	okeyi, ok := ti.tree.Get(lookup)
 	if !ok {
+		keyi := newKeyIndex(key)
 		keyi.put(ti.lg, rev.Main, rev.Sub)
 		ti.tree.ReplaceOrInsert(keyi)
 		return
@@ -72,13 +94,25 @@ func (ti *treeIndex) Get(key []byte, atRev int64) (modified, created Revision, v
 }
 
 func (ti *treeIndex) unsafeGet(key []byte, atRev int64) (modified, created Revision, ver int64, err error) {
-	keyi := &keyIndex{key: key}
-	if keyi = ti.keyIndex(keyi); keyi == nil {
+	lookup := lookupKeyIndex(key)
+	keyi := ti.keyIndex(lookup)
+	releaseLookupKeyIndex(lookup)
+	if keyi == nil {
 		return Revision{}, Revision{}, 0, ErrRevisionNotFound
 	}
 	return keyi.get(ti.lg, atRev)
 }
 
+func (ti *treeIndex) Has(key []byte, rev Revision) bool {
+	lookup := lookupKeyIndex(key)
+	defer releaseLookupKeyIndex(lookup)
+
+	ti.RLock()
+	defer ti.RUnlock()
+	keyi := ti.keyIndex(lookup)
+	return keyi != nil && keyi.has(rev)
+}
+
 func (ti *treeIndex) KeyIndex(keyi *keyIndex) *keyIndex {
 	ti.RLock()
 	defer ti.RUnlock()
@@ -93,10 +127,11 @@ func (ti *treeIndex) keyIndex(keyi *keyIndex) *keyIndex {
 }
 
 func (ti *treeIndex) unsafeVisit(key, end []byte, f func(ki *keyIndex) bool) {
-	keyi, endi := &keyIndex{key: key}, &keyIndex{key: end}
+	lookup := lookupKeyIndex(key)
+	defer releaseLookupKeyIndex(lookup)
 
-	ti.tree.AscendGreaterOrEqual(keyi, func(item *keyIndex) bool {
-		if len(endi.key) > 0 && !item.Less(endi) {
+	ti.tree.AscendGreaterOrEqual(lookup, func(item *keyIndex) bool {
+		if len(end) > 0 && bytes.Compare(item.key, end) >= 0 {
 			return false
 		}
 		if !f(item) {
@@ -177,11 +212,12 @@ func (ti *treeIndex) Range(key, end []byte, atRev int64) (keys [][]byte, revs []
 }
 
 func (ti *treeIndex) Tombstone(key []byte, rev Revision) error {
-	keyi := &keyIndex{key: key}
+	lookup := lookupKeyIndex(key)
+	defer releaseLookupKeyIndex(lookup)
 
 	ti.Lock()
 	defer ti.Unlock()
-	ki, ok := ti.tree.Get(keyi)
+	ki, ok := ti.tree.Get(lookup)
 	if !ok {
 		return ErrRevisionNotFound
 	}
@@ -189,7 +225,9 @@ func (ti *treeIndex) Tombstone(key []byte, rev Revision) error {
 	return ki.tombstone(ti.lg, rev.Main, rev.Sub)
 }
 
-func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
+// Compact compacts the keys at the given rev, or at the revisions of their
+// retentions, and returns the revisions up to rev to be kept.
+func (ti *treeIndex) Compact(rev int64, retentions ...Retention) map[Revision]struct{} {
 	available := make(map[Revision]struct{})
 	ti.lg.Info("compact tree index", zap.Int64("revision", rev))
 	ti.Lock()
@@ -200,7 +238,11 @@ func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
 		// Lock is needed here to prevent modification to the keyIndex while
 		// compaction is going on or revision added to empty before deletion
 		ti.Lock()
-		keyi.compact(ti.lg, rev, available)
+		atRev := compactRevOf(rev, retentions, keyi.key, nil)
+		keyi.compact(ti.lg, atRev, available)
+		if atRev < rev {
+			keyi.retain(atRev, rev, available)
+		}
 		if keyi.isEmpty() {
 			_, ok := ti.tree.Delete(keyi)
 			if !ok {
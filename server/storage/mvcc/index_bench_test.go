@@ -15,9 +15,12 @@
 package mvcc
 
 import (
+	"fmt"
 	"testing"
 
 	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
 )
 
 func BenchmarkIndexCompact1(b *testing.B)       { benchmarkIndexCompact(b, 1) }
@@ -47,6 +50,7 @@ func BenchmarkIndexPut(b *testing.B) {
 
 	bytesN := 64
 	keys := createBytesSlice(bytesN, b.N)
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
 		kvindex.Put(keys[i], Revision{Main: int64(i), Sub: int64(i)})
@@ -62,8 +66,65 @@ func BenchmarkIndexGet(b *testing.B) {
 	for i := 1; i < b.N; i++ {
 		kvindex.Put(keys[i], Revision{Main: int64(i), Sub: int64(i)})
 	}
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
 		kvindex.Get(keys[i], int64(i))
 	}
 }
+
+func BenchmarkIndexPutExisting(b *testing.B) {
+	log := zap.NewNop()
+	kvindex := newTreeIndex(log)
+
+	bytesN := 64
+	keys := createBytesSlice(bytesN, 1000)
+	for i, key := range keys {
+		kvindex.Put(key, Revision{Main: int64(i + 1)})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		kvindex.Put(keys[i%len(keys)], Revision{Main: int64(len(keys) + i + 1)})
+	}
+}
+
+func BenchmarkIndexRange(b *testing.B) {
+	log := zap.NewNop()
+	kvindex := newTreeIndex(log)
+
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("foo%05d", i))
+		kvindex.Put(keys[i], Revision{Main: int64(i + 1)})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := i % (len(keys) - 100)
+		kvindex.Range(keys[start], keys[start+100], int64(len(keys)))
+	}
+}
+
+func BenchmarkIndexRestore(b *testing.B) {
+	log := zap.NewNop()
+
+	bytesN := 64
+	keys := createBytesSlice(bytesN, b.N)
+	rkvs := make([]revKeyValue, b.N)
+	for i := range rkvs {
+		rkvs[i] = revKeyValue{
+			key:  RevToBytes(Revision{Main: int64(i + 1)}, NewRevBytes()),
+			kv:   mvccpb.KeyValue{Key: keys[i], CreateRevision: int64(i + 1), Version: 1},
+			kstr: string(keys[i]),
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	rkvc, revc := restoreIntoIndex(log, newTreeIndex(log))
+	for _, rkv := range rkvs {
+		rkvc <- rkv
+	}
+	close(rkvc)
+	<-revc
+}
@@ -76,6 +76,48 @@ type keyIndex struct {
 	generations []generation
 }
 
+// keyIndexAlloc is a keyIndex allocated along with the storage of its first
+// generation and revision, as most keys are never deleted and some are never
+// updated. Allocating them together saves two allocations for every key.
+type keyIndexAlloc struct {
+	ki  keyIndex
+	gen [1]generation
+	rev [1]Revision
+}
+
+// init returns the keyIndex of the given key with an empty generation, backed
+// by the storage of the allocation.
+func (a *keyIndexAlloc) init(key []byte) *keyIndex {
+	a.gen[0].revs = a.rev[:0]
+	a.ki = keyIndex{key: key, generations: a.gen[:1]}
+	return &a.ki
+}
+
+// newKeyIndex returns the keyIndex of a new key.
+func newKeyIndex(key []byte) *keyIndex {
+	return new(keyIndexAlloc).init(key)
+}
+
+// keyIndexArenaChunk is the number of keyIndexes allocated at once by a
+// keyIndexArena.
+const keyIndexArenaChunk = 1024
+
+// keyIndexArena allocates keyIndexes in chunks, to restore the many keys of
+// a backend at once without allocating each of them. A chunk is only freed
+// once all its keys are compacted, so it is not used for the keys put later.
+type keyIndexArena struct {
+	chunk []keyIndexAlloc
+}
+
+func (a *keyIndexArena) newKeyIndex(key []byte) *keyIndex {
+	if len(a.chunk) == 0 {
+		a.chunk = make([]keyIndexAlloc, keyIndexArenaChunk)
+	}
+	ki := a.chunk[0].init(key)
+	a.chunk = a.chunk[1:]
+	return ki
+}
+
 // put puts a revision to the keyIndex.
 func (ki *keyIndex) put(lg *zap.Logger, main int64, sub int64) {
 	rev := Revision{Main: main, Sub: sub}
@@ -103,7 +145,7 @@ func (ki *keyIndex) put(lg *zap.Logger, main int64, sub int64) {
 }
 
 func (ki *keyIndex) restore(lg *zap.Logger, created, modified Revision, ver int64) {
-	if len(ki.generations) != 0 {
+	if len(ki.generations) != 0 && !ki.isEmpty() {
 		lg.Panic(
 			"'restore' got an unexpected non-empty generations",
 			zap.Int("generations-size", len(ki.generations)),
@@ -111,8 +153,11 @@ func (ki *keyIndex) restore(lg *zap.Logger, created, modified Revision, ver int6
 	}
 
 	ki.modified = modified
-	g := generation{created: created, ver: ver, revs: []Revision{modified}}
-	ki.generations = append(ki.generations, g)
+	if len(ki.generations) == 0 {
+		ki.generations = append(ki.generations, generation{})
+	}
+	g := &ki.generations[0]
+	g.created, g.ver, g.revs = created, ver, append(g.revs, modified)
 	keysGauge.Inc()
 }
 
@@ -255,6 +300,17 @@ func (ki *keyIndex) keep(atRev int64, available map[Revision]struct{}) {
 	}
 }
 
+// retain adds the revisions in (fromRev, toRev] to the available map.
+func (ki *keyIndex) retain(fromRev, toRev int64, available map[Revision]struct{}) {
+	for _, g := range ki.generations {
+		for _, rev := range g.revs {
+			if rev.Main > fromRev && rev.Main <= toRev {
+				available[rev] = struct{}{}
+			}
+		}
+	}
+}
+
 func (ki *keyIndex) doCompact(atRev int64, available map[Revision]struct{}) (genIdx int, revIndex int) {
 	// walk until reaching the first revision smaller or equal to "atRev",
 	// and add the revision to the available map
@@ -285,6 +341,18 @@ func (ki *keyIndex) isEmpty() bool {
 	return len(ki.generations) == 1 && ki.generations[0].isEmpty()
 }
 
+// has reports whether the revision is in any generation of the keyIndex.
+func (ki *keyIndex) has(rev Revision) bool {
+	for _, g := range ki.generations {
+		for _, r := range g.revs {
+			if r == rev {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // findGeneration finds out the generation of the keyIndex that the
 // given rev belongs to. If the given rev is at the gap of two generations,
 // which means that the key does not exist at the given rev, it returns nil.
@@ -202,6 +202,42 @@ func TestKeyIndexPut(t *testing.T) {
 	}
 }
 
+// TestKeyIndexAlloc ensures the keyIndexes sharing an allocation chunk do not
+// share the storage of their revisions.
+func TestKeyIndexAlloc(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	var arena keyIndexArena
+	a, b := arena.newKeyIndex([]byte("a")), arena.newKeyIndex([]byte("b"))
+	a.restore(lg, Revision{Main: 1}, Revision{Main: 2}, 2)
+	b.restoreTombstone(lg, 3, 0)
+	c := newKeyIndex([]byte("c"))
+	c.put(lg, 4, 0)
+	for i := int64(5); i < 8; i++ {
+		a.put(lg, i, 0)
+		b.put(lg, i, 0)
+		if i < 7 {
+			require.NoError(t, b.tombstone(lg, i, 1))
+		}
+		c.put(lg, i, 0)
+	}
+
+	wa := &keyIndex{key: []byte("a"), modified: Revision{Main: 7}, generations: []generation{
+		{created: Revision{Main: 1}, ver: 5, revs: []Revision{{Main: 2}, {Main: 5}, {Main: 6}, {Main: 7}}},
+	}}
+	assert.True(t, a.equal(wa), "a = %v, want %v", a, wa)
+	wc := &keyIndex{key: []byte("c"), modified: Revision{Main: 7}, generations: []generation{
+		{created: Revision{Main: 4}, ver: 4, revs: []Revision{{Main: 4}, {Main: 5}, {Main: 6}, {Main: 7}}},
+	}}
+	assert.True(t, c.equal(wc), "c = %v, want %v", c, wc)
+	_, _, _, err := b.get(lg, 6)
+	require.ErrorIs(t, err, ErrRevisionNotFound)
+	modified, created, ver, err := b.get(lg, 7)
+	require.NoError(t, err)
+	assert.Equal(t, Revision{Main: 7}, modified)
+	assert.Equal(t, Revision{Main: 7}, created)
+	assert.Equal(t, int64(1), ver)
+}
+
 func TestKeyIndexRestore(t *testing.T) {
 	ki := &keyIndex{key: []byte("foo")}
 	ki.restore(zaptest.NewLogger(t), Revision{Main: 5}, Revision{Main: 7}, 2)
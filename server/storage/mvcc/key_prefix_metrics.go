@@ -0,0 +1,167 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// defaultKeyPrefixSizeBuckets are the default buckets of the put size
+// histogram of the monitored key prefixes, from 64B to 1MiB.
+var defaultKeyPrefixSizeBuckets = prometheus.ExponentialBuckets(64, 4, 8)
+
+// keyPrefixMetrics exports the request and storage metrics of the keys under
+// the monitored key prefixes, labeled by prefix. A key is accounted to every
+// monitored prefix it has, and a range to every monitored prefix it overlaps.
+// A nil *keyPrefixMetrics monitors no prefix.
+type keyPrefixMetrics struct {
+	prefixes []string
+	putSize  *prometheus.HistogramVec
+}
+
+func newKeyPrefixMetrics(lg *zap.Logger, prefixes []string, sizeBuckets []float64) *keyPrefixMetrics {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	m := &keyPrefixMetrics{}
+	for _, p := range prefixes {
+		if p != "" {
+			m.prefixes = append(m.prefixes, p)
+		}
+	}
+	if len(sizeBuckets) == 0 {
+		sizeBuckets = defaultKeyPrefixSizeBuckets
+	}
+	m.putSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "prefix_put_size_bytes",
+			Help:      "The size distribution of put kv pairs of the monitored key prefixes seen by this member.",
+			Buckets:   sizeBuckets,
+		},
+		[]string{"prefix"},
+	)
+	// the histogram is registered by the first store of the process; the
+	// stores created afterwards, e.g. on snapshot recovery, share it.
+	if err := prometheus.Register(m.putSize); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			m.putSize = are.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			lg.Warn("failed to register key prefix put size histogram", zap.Error(err))
+		}
+	}
+	return m
+}
+
+// each calls f with every monitored prefix of key.
+func (m *keyPrefixMetrics) each(key []byte, f func(prefix string)) {
+	if m == nil {
+		return
+	}
+	for _, p := range m.prefixes {
+		if bytes.HasPrefix(key, []byte(p)) {
+			f(p)
+		}
+	}
+}
+
+// eachOverlap calls f with every monitored prefix whose keys overlap the
+// range [key, end). As for ranges, an empty end is the single key and an end
+// of "\x00" is the end of the keyspace.
+func (m *keyPrefixMetrics) eachOverlap(key, end []byte, f func(prefix string)) {
+	if len(end) == 0 {
+		m.each(key, f)
+		return
+	}
+	if m == nil {
+		return
+	}
+	toEnd := len(end) == 1 && end[0] == 0
+	for _, p := range m.prefixes {
+		pEnd := prefixRangeEnd([]byte(p))
+		pToEnd := len(pEnd) == 1 && pEnd[0] == 0
+		if (pToEnd || bytes.Compare(key, pEnd) < 0) && (toEnd || bytes.Compare([]byte(p), end) < 0) {
+			f(p)
+		}
+	}
+}
+
+// observeRequest counts a range or delete request of the range [key, end).
+func (m *keyPrefixMetrics) observeRequest(key, end []byte, typ string) {
+	m.eachOverlap(key, end, func(p string) {
+		prefixRequestCounter.WithLabelValues(p, typ).Inc()
+	})
+}
+
+func (m *keyPrefixMetrics) observePut(key, value []byte) {
+	m.each(key, func(p string) {
+		prefixRequestCounter.WithLabelValues(p, "put").Inc()
+		prefixPutBytesCounter.WithLabelValues(p).Add(float64(len(key) + len(value)))
+		m.putSize.WithLabelValues(p).Observe(float64(len(key) + len(value)))
+	})
+}
+
+// observeEvents counts the events sent to a watcher.
+func (m *keyPrefixMetrics) observeEvents(evs []mvccpb.Event) {
+	if m == nil {
+		return
+	}
+	for _, ev := range evs {
+		m.each(ev.Kv.Key, func(p string) {
+			prefixWatchEventsCounter.WithLabelValues(p).Inc()
+		})
+	}
+}
+
+// addKeys adjusts the number of keys under the prefixes of key by delta.
+func (m *keyPrefixMetrics) addKeys(key []byte, delta float64) {
+	m.each(key, func(p string) {
+		prefixKeysGauge.WithLabelValues(p).Add(delta)
+	})
+}
+
+// resetKeys sets the number of keys under each prefix to the number of keys
+// of the index at the given revision.
+func (m *keyPrefixMetrics) resetKeys(idx index, rev int64) {
+	if m == nil {
+		return
+	}
+	for _, p := range m.prefixes {
+		prefixKeysGauge.WithLabelValues(p).Set(float64(idx.CountRevisions([]byte(p), prefixRangeEnd([]byte(p)), rev)))
+	}
+}
+
+// prefixRangeEnd returns the end of the range of the keys with the given
+// prefix.
+func prefixRangeEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// the prefix is all 0xff, so the range ends at the last key.
+	return []byte{0}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestKeyPrefixMetrics(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	cfg := StoreConfig{MonitoredKeyPrefixes: []string{"/metrics/app1/", "/metrics/"}}
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, cfg)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	w.Watch(0, []byte("/metrics/app1/"), prefixRangeEnd([]byte("/metrics/app1/")), 0)
+
+	s.Put([]byte("/metrics/app1/a"), []byte("1"), lease.NoLease)
+	s.Put([]byte("/metrics/app1/a"), []byte("22"), lease.NoLease)
+	s.Put([]byte("/metrics/app1/b"), []byte("3"), lease.NoLease)
+	s.Put([]byte("/metrics/app2/a"), []byte("4"), lease.NoLease)
+	s.Put([]byte("/other"), []byte("5"), lease.NoLease)
+	s.DeleteRange([]byte("/metrics/app1/b"), nil)
+	_, err := s.Range(context.TODO(), []byte("/metrics/app1/"), prefixRangeEnd([]byte("/metrics/app1/")), RangeOptions{})
+	assert.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		<-w.Chan()
+	}
+
+	assert.InDelta(t, 3, testutil.ToFloat64(prefixRequestCounter.WithLabelValues("/metrics/app1/", "put")), 0)
+	assert.InDelta(t, 4, testutil.ToFloat64(prefixRequestCounter.WithLabelValues("/metrics/", "put")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(prefixRequestCounter.WithLabelValues("/metrics/app1/", "delete")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(prefixRequestCounter.WithLabelValues("/metrics/app1/", "range")), 0)
+	assert.InDelta(t, 16+17+16, testutil.ToFloat64(prefixPutBytesCounter.WithLabelValues("/metrics/app1/")), 0)
+	assert.InDelta(t, 4, testutil.ToFloat64(prefixWatchEventsCounter.WithLabelValues("/metrics/app1/")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(prefixKeysGauge.WithLabelValues("/metrics/app1/")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(prefixKeysGauge.WithLabelValues("/metrics/")), 0)
+	var m dto.Metric
+	require.NoError(t, s.(*watchableStore).keyPrefixes.putSize.WithLabelValues("/metrics/app1/").(prometheus.Histogram).Write(&m))
+	assert.Equal(t, uint64(3), m.GetHistogram().GetSampleCount())
+	assert.InDelta(t, 16+17+16, m.GetHistogram().GetSampleSum(), 0)
+
+	// the key counts are recovered from the backend.
+	prefixKeysGauge.Reset()
+	s.Close()
+	s = New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, cfg)
+	defer cleanup(s, b)
+	assert.InDelta(t, 1, testutil.ToFloat64(prefixKeysGauge.WithLabelValues("/metrics/app1/")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(prefixKeysGauge.WithLabelValues("/metrics/")), 0)
+}
+
+func TestKeyPrefixMetricsRangeOverlap(t *testing.T) {
+	m := newKeyPrefixMetrics(zaptest.NewLogger(t), []string{"/a/", "/b/", "/c/", "\xff"}, nil)
+	tcs := []struct {
+		name     string
+		key, end string
+		want     []string
+	}{
+		{name: "Key", key: "/b/x", want: []string{"/b/"}},
+		{name: "Prefix", key: "/a/", end: "/a0", want: []string{"/a/"}},
+		{name: "SpanningPrefixes", key: "/a/", end: "/c/", want: []string{"/a/", "/b/"}},
+		{name: "PartialOverlap", key: "/a/z", end: "/b/0", want: []string{"/a/", "/b/"}},
+		{name: "Within", key: "/b/0", end: "/b/1", want: []string{"/b/"}},
+		{name: "FromKey", key: "/b/x", end: "\x00", want: []string{"/b/", "/c/", "\xff"}},
+		{name: "All", key: "\x00", end: "\x00", want: []string{"/a/", "/b/", "/c/", "\xff"}},
+		{name: "None", key: "/d", end: "/e", want: nil},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var end []byte
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			var got []string
+			m.eachOverlap([]byte(tc.key), end, func(p string) { got = append(got, p) })
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	assert.Equal(t, []byte("/b"), prefixRangeEnd([]byte("/a")))
+	assert.Equal(t, []byte("/b"), prefixRangeEnd([]byte("/a\xff")))
+	assert.Equal(t, []byte{0}, prefixRangeEnd([]byte("\xff\xff")))
+}
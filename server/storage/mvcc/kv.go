@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"context"
+	"time"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
@@ -38,9 +39,15 @@ type RangeResult struct {
 type ReadView interface {
 	// FirstRev returns the first KV revision at the time of opening the txn.
 	// After a compaction, the first revision increases to the compaction
-	// revision.
+	// revision, the revision the keys under no compaction retention are
+	// compacted at.
 	FirstRev() int64
 
+	// FirstRevOf returns the first revision of the history of the keys in the
+	// range [key, end) at the time of opening the txn, which follows the
+	// compaction retentions of the range.
+	FirstRevOf(key, end []byte) int64
+
 	// Rev returns the revision of the KV at the time of opening the txn.
 	Rev() int64
 
@@ -73,6 +80,14 @@ type WriteView interface {
 	// if the `end` is not nil, deleteRange deletes the keys in range [key, range_end).
 	DeleteRange(key, end []byte) (n, rev int64)
 
+	// LazyDeleteRange deletes the given range from the store like DeleteRange,
+	// but only writes a single range tombstone to the backend instead of a
+	// tombstone for every key, and generates a single event in the event
+	// history for the whole range. The revisions of the deleted keys are
+	// removed from the backend by the compactions.
+	// if the `end` is nil, LazyDeleteRange deletes the key like DeleteRange.
+	LazyDeleteRange(key, end []byte) (n, rev int64)
+
 	// Put puts the given key, value into the store. Put also takes additional argument lease to
 	// attach a lease to a key-value pair as meta-data. KV implementation does not validate the lease
 	// id.
@@ -93,6 +108,9 @@ type TxnWrite interface {
 type txnReadWrite struct{ TxnRead }
 
 func (trw *txnReadWrite) DeleteRange(key, end []byte) (n, rev int64) { panic("unexpected DeleteRange") }
+func (trw *txnReadWrite) LazyDeleteRange(key, end []byte) (n, rev int64) {
+	panic("unexpected LazyDeleteRange")
+}
 func (trw *txnReadWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	panic("unexpected Put")
 }
@@ -122,8 +140,20 @@ type KV interface {
 	// HashStorage returns HashStorage interface for KV storage.
 	HashStorage() HashStorage
 
-	// Compact frees all superseded keys with revisions less than rev.
-	Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
+	// Compact frees all superseded keys with revisions less than rev, except
+	// the history kept by the retentions.
+	Compact(trace *traceutil.Trace, rev int64, retentions ...Retention) (<-chan struct{}, error)
+
+	// SetCompactionConfig changes the batch limit and sleep interval of the
+	// following compactions. Zero values keep the current setting.
+	SetCompactionConfig(batchLimit int, sleepInterval time.Duration)
+
+	// CompactionConfig returns the current compaction settings.
+	CompactionConfig() StoreConfig
+
+	// Scrub checks the revisions in the backend against the index. See
+	// store.Scrub.
+	Scrub(ctx context.Context, progress func(ctx context.Context, batch ScrubStats) error, found func(err error)) (ScrubStats, error)
 
 	// Commit commits outstanding txns into the underlying backend.
 	Commit()
@@ -34,6 +34,7 @@ import (
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
 // Functional tests for features implemented in v3 store. It treats v3 store
@@ -74,6 +75,9 @@ var (
 		defer txn.End()
 		return txn.DeleteRange(key, end)
 	}
+	lazyDeleteRangeFunc = func(kv KV, key, end []byte) (n, rev int64) {
+		return kv.LazyDeleteRange(key, end)
+	}
 )
 
 func TestKVRange(t *testing.T)    { testKVRange(t, normalRangeFunc) }
@@ -339,6 +343,78 @@ func testKVDeleteRange(t *testing.T, f deleteRangeFunc) {
 	}
 }
 
+func TestKVLazyDeleteRange(t *testing.T) { testKVDeleteRange(t, lazyDeleteRangeFunc) }
+
+// TestKVLazyDeleteRangeTxn ensures the keys lazily deleted in a txn are
+// deleted at the revision of the txn, together with the keys put before in the
+// same txn, and that only a single range tombstone is written to the backend.
+func TestKVLazyDeleteRangeTxn(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+
+	txn := s.Write(traceutil.TODO())
+	txn.Put([]byte("foo2"), []byte("bar2"), lease.NoLease)
+	n, rev := txn.LazyDeleteRange([]byte("foo"), []byte("fop"))
+	txn.Put([]byte("foo3"), []byte("bar3"), lease.NoLease)
+	txn.End()
+	if n != 3 || rev != 4 {
+		t.Fatalf("n = %d, rev = %d, want (3, 4)", n, rev)
+	}
+
+	r, err := s.Range(context.TODO(), []byte("foo"), []byte("fop"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wkvs := []mvccpb.KeyValue{
+		{Key: []byte("foo3"), Value: []byte("bar3"), CreateRevision: 4, ModRevision: 4, Version: 1},
+	}
+	if !reflect.DeepEqual(r.KVs, wkvs) {
+		t.Errorf("kvs = %+v, want %+v", r.KVs, wkvs)
+	}
+	r, err = s.Range(context.TODO(), []byte("foo"), []byte("fop"), RangeOptions{Rev: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 2 {
+		t.Errorf("len(kvs) at rev 3 = %d, want 2", len(r.KVs))
+	}
+
+	tx := b.BatchTx()
+	tx.Lock()
+	keys, _ := tx.UnsafeRange(schema.Key, RevToBytes(Revision{Main: 4}, NewRevBytes()), RevToBytes(Revision{Main: 5}, NewRevBytes()), 0)
+	tx.Unlock()
+	var tombstones int
+	for _, k := range keys {
+		if isTombstone(k) {
+			t.Errorf("unexpected tombstone %x", k)
+		}
+		if isRangeTombstone(k) {
+			tombstones++
+		}
+	}
+	if tombstones != 1 {
+		t.Errorf("range tombstones = %d, want 1", tombstones)
+	}
+
+	// the compaction removes the revisions of the deleted keys but keeps the
+	// range tombstone and the key put after it.
+	ch, err := s.Compact(traceutil.TODO(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	tx.Lock()
+	keys, _ = tx.UnsafeRange(schema.Key, RevToBytes(Revision{Main: 1}, NewRevBytes()), RevToBytes(Revision{Main: 5}, NewRevBytes()), 0)
+	tx.Unlock()
+	if len(keys) != 2 || !isRangeTombstone(keys[0]) {
+		t.Errorf("revisions after compaction = %x, want the range tombstone and foo3", keys)
+	}
+}
+
 func TestKVDeleteMultipleTimes(t *testing.T)    { testKVDeleteMultipleTimes(t, normalDeleteRangeFunc) }
 func TestKVTxnDeleteMultipleTimes(t *testing.T) { testKVDeleteMultipleTimes(t, txnDeleteRangeFunc) }
 
@@ -690,6 +766,24 @@ func TestKVRestore(t *testing.T) {
 			ch, _ := kv.Compact(traceutil.TODO(), delAtRev)
 			<-ch
 		},
+		func(kv KV) {
+			kv.Put([]byte("foo"), []byte("bar0"), 1)
+			kv.Put([]byte("foo1"), []byte("bar1"), 2)
+			kv.Put([]byte("foo2"), []byte("bar2"), 0)
+			kv.LazyDeleteRange([]byte("foo"), []byte("foo2"))
+			kv.Put([]byte("foo1"), []byte("bar3"), 3)
+			kv.LazyDeleteRange([]byte("foo1"), []byte{})
+		},
+		func(kv KV) { // after compaction, only the range tombstones are left
+			kv.Put([]byte("foo1"), []byte("bar1"), 0)
+			kv.Put([]byte("foo2"), []byte("bar2"), 0)
+			kv.Put([]byte("foo3"), []byte("bar3"), 0)
+			_, delAtRev := kv.LazyDeleteRange([]byte("foo1"), []byte("foo3"))
+			kv.Put([]byte("foo2"), []byte("bar4"), 0)
+
+			ch, _ := kv.Compact(traceutil.TODO(), delAtRev)
+			<-ch
+		},
 	}
 	for i, tt := range tests {
 		b, _ := betesting.NewDefaultTmpBackend(t)
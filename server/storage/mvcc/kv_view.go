@@ -29,6 +29,12 @@ func (rv *readView) FirstRev() int64 {
 	return tr.FirstRev()
 }
 
+func (rv *readView) FirstRevOf(key, end []byte) int64 {
+	tr := rv.kv.Read(ConcurrentReadTxMode, traceutil.TODO())
+	defer tr.End()
+	return tr.FirstRevOf(key, end)
+}
+
 func (rv *readView) Rev() int64 {
 	tr := rv.kv.Read(ConcurrentReadTxMode, traceutil.TODO())
 	defer tr.End()
@@ -49,6 +55,12 @@ func (wv *writeView) DeleteRange(key, end []byte) (n, rev int64) {
 	return tw.DeleteRange(key, end)
 }
 
+func (wv *writeView) LazyDeleteRange(key, end []byte) (n, rev int64) {
+	tw := wv.kv.Write(traceutil.TODO())
+	defer tw.End()
+	return tw.LazyDeleteRange(key, end)
+}
+
 func (wv *writeView) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	tw := wv.kv.Write(traceutil.TODO())
 	defer tw.End()
@@ -47,13 +47,21 @@ var (
 type StoreConfig struct {
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
+	// MonitoredKeyPrefixes are the key prefixes whose requests, watch
+	// events and keys are exported as metrics labeled by prefix.
+	MonitoredKeyPrefixes []string
+	// MonitoredKeySizeBuckets are the buckets of the put size histogram of
+	// the monitored key prefixes, default buckets if empty.
+	MonitoredKeySizeBuckets []float64
 }
 
 type store struct {
 	ReadView
 	WriteView
 
-	cfg StoreConfig
+	// cfgMu protects cfg, which can be changed at runtime.
+	cfgMu sync.RWMutex
+	cfg   StoreConfig
 
 	// mu read locks for txns and write locks for non-txn store changes.
 	mu sync.RWMutex
@@ -69,8 +77,12 @@ type store struct {
 	revMu sync.RWMutex
 	// currentRev is the revision of the last completed transaction.
 	currentRev int64
-	// compactMainRev is the main revision of the last compaction.
+	// compactMainRev is the main revision of the last compaction, the latest
+	// revision any key is compacted at.
 	compactMainRev int64
+	// compactRetentions are the retentions of the last compaction, sorted by
+	// prefix. The keys under no retention are compacted at compactMainRev.
+	compactRetentions []Retention
 
 	fifoSched schedule.Scheduler
 
@@ -78,6 +90,8 @@ type store struct {
 
 	lg     *zap.Logger
 	hashes HashStorage
+
+	keyPrefixes *keyPrefixMetrics
 }
 
 // NewStore returns a new store. It is useful to create a store inside
@@ -108,6 +122,8 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 		stopc: make(chan struct{}),
 
 		lg: lg,
+
+		keyPrefixes: newKeyPrefixMetrics(lg, cfg.MonitoredKeyPrefixes, cfg.MonitoredKeySizeBuckets),
 	}
 	s.hashes = NewHashStorage(lg, s)
 	s.ReadView = &readView{s}
@@ -169,7 +185,7 @@ func (s *store) hashByRev(rev int64) (hash KeyValueHash, currentRev int64, err e
 
 	s.mu.RLock()
 	s.revMu.RLock()
-	compactRev, currentRev = s.compactMainRev, s.currentRev
+	compactRev, currentRev = globalCompactRevOf(s.compactMainRev, s.compactRetentions), s.currentRev
 	s.revMu.RUnlock()
 
 	if rev > 0 && rev < compactRev {
@@ -193,31 +209,54 @@ func (s *store) hashByRev(rev int64) (hash KeyValueHash, currentRev int64, err e
 	return hash, currentRev, err
 }
 
-func (s *store) updateCompactRev(rev int64) (<-chan struct{}, int64, error) {
+// updateCompactRev updates the compaction of the store to the compaction at
+// rev with the retentions, and returns the revision the store is compacted
+// at along with its retentions. The retentions with a revision after rev
+// compact their keys further, in which case the store is compacted at their
+// latest revision and the other keys are retained from rev on.
+func (s *store) updateCompactRev(rev int64, retentions []Retention) (<-chan struct{}, int64, []Retention, int64, error) {
 	s.revMu.Lock()
-	if rev <= s.compactMainRev {
+	if rev <= globalCompactRevOf(s.compactMainRev, s.compactRetentions) {
 		ch := make(chan struct{})
 		f := schedule.NewJob("kvstore_updateCompactRev_compactBarrier", func(ctx context.Context) { s.compactBarrier(ctx, ch) })
 		s.fifoSched.Schedule(f)
 		s.revMu.Unlock()
-		return ch, 0, ErrCompacted
+		return ch, 0, nil, 0, ErrCompacted
+	}
+	mainRev, global := max(rev, s.compactMainRev), false
+	for _, r := range retentions {
+		mainRev = max(mainRev, r.Revision)
+		global = global || len(r.Prefix) == 0
 	}
-	if rev > s.currentRev {
+	if mainRev > s.currentRev {
 		s.revMu.Unlock()
-		return nil, 0, ErrFutureRev
+		return nil, 0, nil, 0, ErrFutureRev
 	}
+	if mainRev > rev && !global {
+		retentions = append(retentions, Retention{Prefix: []byte{}, Revision: rev})
+	}
+	rev = mainRev
 	compactMainRev := s.compactMainRev
+	prevRetentions := s.compactRetentions
 	s.compactMainRev = rev
+	s.compactRetentions = mergeRetentions(compactMainRev, prevRetentions, rev, retentions)
 
-	SetScheduledCompact(s.b.BatchTx(), rev)
+	tx := s.b.BatchTx()
+	tx.LockInsideApply()
+	UnsafeSetScheduledCompact(tx, rev)
+	if len(s.compactRetentions) > 0 || len(prevRetentions) > 0 {
+		UnsafeSetCompactRetentions(tx, s.compactRetentions)
+	}
+	tx.Unlock()
 	// ensure that desired compaction is persisted
 	// gofail: var compactBeforeCommitScheduledCompact struct{}
 	s.b.ForceCommit()
 	// gofail: var compactAfterCommitScheduledCompact struct{}
 
+	retentions = s.compactRetentions
 	s.revMu.Unlock()
 
-	return nil, compactMainRev, nil
+	return nil, rev, retentions, compactMainRev, nil
 }
 
 // checkPrevCompactionCompleted checks whether the previous scheduled compaction is completed.
@@ -230,14 +269,14 @@ func (s *store) checkPrevCompactionCompleted() bool {
 	return scheduledCompact == finishedCompact && scheduledCompactFound == finishedCompactFound
 }
 
-func (s *store) compact(trace *traceutil.Trace, rev, prevCompactRev int64, prevCompactionCompleted bool) <-chan struct{} {
+func (s *store) compact(trace *traceutil.Trace, rev int64, retentions []Retention, prevCompactRev int64, prevCompactionCompleted bool) <-chan struct{} {
 	ch := make(chan struct{})
 	j := schedule.NewJob("kvstore_compact", func(ctx context.Context) {
 		if ctx.Err() != nil {
 			s.compactBarrier(ctx, ch)
 			return
 		}
-		hash, err := s.scheduleCompaction(rev, prevCompactRev)
+		hash, err := s.scheduleCompaction(rev, retentions, prevCompactRev)
 		if err != nil {
 			s.lg.Warn("Failed compaction", zap.Error(err))
 			s.compactBarrier(context.TODO(), ch)
@@ -258,20 +297,37 @@ func (s *store) compact(trace *traceutil.Trace, rev, prevCompactRev int64, prevC
 	return ch
 }
 
-func (s *store) compactLockfree(rev int64) (<-chan struct{}, error) {
+func (s *store) compactLockfree(rev int64, retentions []Retention) (<-chan struct{}, error) {
 	prevCompactionCompleted := s.checkPrevCompactionCompleted()
-	ch, prevCompactRev, err := s.updateCompactRev(rev)
+	ch, rev, retentions, prevCompactRev, err := s.updateCompactRev(rev, retentions)
 	if err != nil {
 		return ch, err
 	}
 
-	return s.compact(traceutil.TODO(), rev, prevCompactRev, prevCompactionCompleted), nil
+	return s.compact(traceutil.TODO(), rev, retentions, prevCompactRev, prevCompactionCompleted), nil
+}
+
+func (s *store) SetCompactionConfig(batchLimit int, sleepInterval time.Duration) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+	if batchLimit > 0 {
+		s.cfg.CompactionBatchLimit = batchLimit
+	}
+	if sleepInterval > 0 {
+		s.cfg.CompactionSleepInterval = sleepInterval
+	}
 }
 
-func (s *store) Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error) {
+func (s *store) CompactionConfig() StoreConfig {
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
+	return s.cfg
+}
+
+func (s *store) Compact(trace *traceutil.Trace, rev int64, retentions ...Retention) (<-chan struct{}, error) {
 	s.mu.Lock()
 	prevCompactionCompleted := s.checkPrevCompactionCompleted()
-	ch, prevCompactRev, err := s.updateCompactRev(rev)
+	ch, rev, retentions, prevCompactRev, err := s.updateCompactRev(rev, retentions)
 	trace.Step("check and update compact revision")
 	if err != nil {
 		s.mu.Unlock()
@@ -279,7 +335,7 @@ func (s *store) Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, err
 	}
 	s.mu.Unlock()
 
-	return s.compact(trace, rev, prevCompactRev, prevCompactionCompleted), nil
+	return s.compact(trace, rev, retentions, prevCompactRev, prevCompactionCompleted), nil
 }
 
 func (s *store) Commit() {
@@ -303,6 +359,7 @@ func (s *store) Restore(b backend.Backend) error {
 		s.revMu.Lock()
 		s.currentRev = 1
 		s.compactMainRev = -1
+		s.compactRetentions = nil
 		s.revMu.Unlock()
 	}
 
@@ -312,7 +369,6 @@ func (s *store) Restore(b backend.Backend) error {
 	return s.restore()
 }
 
-//nolint:unparam
 func (s *store) restore() error {
 	s.setupMetricsReporter()
 
@@ -339,6 +395,14 @@ func (s *store) restore() error {
 		s.revMu.Unlock()
 	}
 	scheduledCompact, _ := UnsafeReadScheduledCompact(tx)
+	retentions, err := UnsafeReadCompactRetentions(tx)
+	if err != nil {
+		tx.RUnlock()
+		return err
+	}
+	s.revMu.Lock()
+	s.compactRetentions = retentions
+	s.revMu.Unlock()
 	// index keys concurrently as they're loaded in from tx
 	keysGauge.Set(0)
 	rkvc, revc := restoreIntoIndex(s.lg, s.kvindex)
@@ -384,6 +448,7 @@ func (s *store) restore() error {
 		}
 		s.revMu.Unlock()
 	}
+	s.keyPrefixes.resetKeys(s.kvindex, s.currentRev)
 
 	if scheduledCompact <= s.compactMainRev {
 		scheduledCompact = 0
@@ -408,7 +473,7 @@ func (s *store) restore() error {
 	s.lg.Info("kvstore restored", zap.Int64("current-rev", s.currentRev))
 
 	if scheduledCompact != 0 {
-		if _, err := s.compactLockfree(scheduledCompact); err != nil {
+		if _, err := s.compactLockfree(scheduledCompact, retentions); err != nil {
 			s.lg.Warn("compaction encountered error",
 				zap.Int64("scheduled-compact-revision", scheduledCompact),
 				zap.Error(err),
@@ -437,7 +502,19 @@ func restoreIntoIndex(lg *zap.Logger, idx index) (chan<- revKeyValue, <-chan int
 		defer func() { revc <- currentRev }()
 		// restore the tree index from streaming the unordered index.
 		kiCache := make(map[string]*keyIndex, restoreChunkKeys)
+		var arena keyIndexArena
 		for rkv := range rkvc {
+			if isRangeTombstone(rkv.key) {
+				rev := BytesToRev(rkv.key)
+				currentRev = rev.Main
+				keys, _ := idx.Range(rkv.kv.Key, rangeEndFromTombstone(rkv.kv.Value), rev.Main)
+				for _, key := range keys {
+					if err := idx.Tombstone(key, rev); err != nil {
+						lg.Warn("tombstone encountered error", zap.Error(err))
+					}
+				}
+				continue
+			}
 			ki, ok := kiCache[rkv.kstr]
 			// purge kiCache if many keys but still missing in the cache
 			if !ok && len(kiCache) >= restoreChunkKeys {
@@ -451,11 +528,12 @@ func restoreIntoIndex(lg *zap.Logger, idx index) (chan<- revKeyValue, <-chan int
 			}
 			// cache miss, fetch from tree index if there
 			if !ok {
-				ki = &keyIndex{key: rkv.kv.Key}
-				if idxKey := idx.KeyIndex(ki); idxKey != nil {
+				lookup := lookupKeyIndex(rkv.kv.Key)
+				if idxKey := idx.KeyIndex(lookup); idxKey != nil {
 					kiCache[rkv.kstr], ki = idxKey, idxKey
 					ok = true
 				}
+				releaseLookupKeyIndex(lookup)
 			}
 
 			rev := BytesToRev(rkv.key)
@@ -475,6 +553,7 @@ func restoreIntoIndex(lg *zap.Logger, idx index) (chan<- revKeyValue, <-chan int
 				}
 				ki.put(lg, rev.Main, rev.Sub)
 			} else {
+				ki = arena.newKeyIndex(rkv.kv.Key)
 				if isTombstone(rkv.key) {
 					ki.restoreTombstone(lg, rev.Main, rev.Sub)
 				} else {
@@ -495,7 +574,14 @@ func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, k
 			lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
 		}
 		rkv.kstr = string(rkv.kv.Key)
-		if isTombstone(key) {
+		if isRangeTombstone(key) {
+			end := string(rangeEndFromTombstone(rkv.kv.Value))
+			for k := range keyToLease {
+				if k >= rkv.kstr && (end == "" || k < end) {
+					delete(keyToLease, k)
+				}
+			}
+		} else if isTombstone(key) {
 			delete(keyToLease, rkv.kstr)
 		} else if lid := lease.LeaseID(rkv.kv.Lease); lid != lease.NoLease {
 			keyToLease[rkv.kstr] = lid
@@ -534,7 +620,7 @@ func (s *store) setupMetricsReporter() {
 	reportCompactRev = func() float64 {
 		s.revMu.RLock()
 		defer s.revMu.RUnlock()
-		return float64(s.compactMainRev)
+		return float64(globalCompactRevOf(s.compactMainRev, s.compactRetentions))
 	}
 	reportCompactRevMu.Unlock()
 }
@@ -25,9 +25,9 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
-func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyValueHash, error) {
+func (s *store) scheduleCompaction(compactMainRev int64, retentions []Retention, prevCompactRev int64) (KeyValueHash, error) {
 	totalStart := time.Now()
-	keep := s.kvindex.Compact(compactMainRev)
+	keep := s.kvindex.Compact(compactMainRev, retentions...)
 	indexCompactionPauseMs.Observe(float64(time.Since(totalStart) / time.Millisecond))
 
 	totalStart = time.Now()
@@ -39,7 +39,8 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 	end := make([]byte, 8)
 	binary.BigEndian.PutUint64(end, uint64(compactMainRev+1))
 
-	batchNum := s.cfg.CompactionBatchLimit
+	cfg := s.CompactionConfig()
+	batchNum := cfg.CompactionBatchLimit
 	h := newKVHasher(prevCompactRev, compactMainRev, keep)
 	last := make([]byte, 8+1+8)
 	for {
@@ -89,7 +90,7 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 		dbCompactionPauseMs.Observe(float64(time.Since(start) / time.Millisecond))
 
 		select {
-		case <-time.After(s.cfg.CompactionSleepInterval):
+		case <-time.After(cfg.CompactionSleepInterval):
 		case <-s.stopc:
 			return KeyValueHash{}, fmt.Errorf("interrupted due to stop signal")
 		}
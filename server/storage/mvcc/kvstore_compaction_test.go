@@ -83,7 +83,7 @@ func TestScheduleCompaction(t *testing.T) {
 		}
 		tx.Unlock()
 
-		_, err := s.scheduleCompaction(tt.rev, 0)
+		_, err := s.scheduleCompaction(tt.rev, nil, 0)
 		if err != nil {
 			t.Error(err)
 		}
@@ -148,3 +148,134 @@ func TestCompactAllAndRestore(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSetCompactionConfig(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.SetCompactionConfig(10, time.Second)
+	cfg := s.CompactionConfig()
+	if cfg.CompactionBatchLimit != 10 || cfg.CompactionSleepInterval != time.Second {
+		t.Fatalf("compaction config = %+v, want batch limit 10 and sleep interval 1s", cfg)
+	}
+
+	// non-positive values leave the setting unchanged.
+	s.SetCompactionConfig(0, 0)
+	if got := s.CompactionConfig(); !reflect.DeepEqual(got, cfg) {
+		t.Errorf("compaction config = %+v, want %+v", got, cfg)
+	}
+}
+
+func TestCompactRetentionsAndRestore(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s0 := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	for i := 0; i < 3; i++ {
+		s0.Put([]byte("/audit/a"), []byte("v"), lease.NoLease)
+		s0.Put([]byte("/b"), []byte("v"), lease.NoLease)
+	}
+	rev := s0.Rev()
+	done, err := s0.Compact(traceutil.TODO(), rev, Retention{Prefix: []byte("/audit/"), Revision: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for compaction to finish")
+	}
+
+	check := func(s *store) {
+		tests := []struct {
+			key, end []byte
+			rev      int64
+			wcount   int
+			werr     error
+		}{
+			{key: []byte("/audit/a"), rev: 2, wcount: 1},
+			{key: []byte("/audit/"), end: []byte("/audit0"), rev: 3, wcount: 1},
+			{key: []byte("/audit/a"), rev: 1, werr: ErrCompacted},
+			{key: []byte("/b"), rev: 2, werr: ErrCompacted},
+			{key: []byte("/"), end: []byte{}, rev: 2, werr: ErrCompacted},
+		}
+		for i, tt := range tests {
+			r, err := s.Range(context.TODO(), tt.key, tt.end, RangeOptions{Rev: tt.rev})
+			if err != tt.werr {
+				t.Fatalf("#%d: range error = %v, want %v", i, err, tt.werr)
+			}
+			if err == nil && len(r.KVs) != tt.wcount {
+				t.Errorf("#%d: range count = %d, want %d", i, len(r.KVs), tt.wcount)
+			}
+		}
+	}
+	check(s0)
+	if err = s0.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s1 := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer s1.Close()
+	check(s1)
+
+	// the retention of /audit/ follows the next compaction if not requested
+	// again.
+	s1.Put([]byte("/b"), []byte("v"), lease.NoLease)
+	if _, err = s1.Compact(traceutil.TODO(), s1.Rev()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s1.Range(context.TODO(), []byte("/audit/a"), nil, RangeOptions{Rev: rev}); err != ErrCompacted {
+		t.Errorf("range error = %v, want %v", err, ErrCompacted)
+	}
+}
+
+func TestCompactRetentionAfterRevision(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	for i := 0; i < 3; i++ {
+		s.Put([]byte("/cache/a"), []byte("v"), lease.NoLease)
+		s.Put([]byte("/b"), []byte("v"), lease.NoLease)
+	}
+	rev := s.Rev()
+	done, err := s.Compact(traceutil.TODO(), 2, Retention{Prefix: []byte("/cache/"), Revision: rev})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+
+	tr := s.Read(ConcurrentReadTxMode, traceutil.TODO())
+	if r := tr.FirstRev(); r != 2 {
+		t.Errorf("first rev = %d, want 2", r)
+	}
+	if r := tr.FirstRevOf([]byte("/cache/a"), nil); r != rev {
+		t.Errorf("first rev of /cache/a = %d, want %d", r, rev)
+	}
+	if r := tr.FirstRevOf([]byte("/b"), nil); r != 2 {
+		t.Errorf("first rev of /b = %d, want 2", r)
+	}
+	tr.End()
+
+	if _, err = s.Range(context.TODO(), []byte("/b"), nil, RangeOptions{Rev: 2}); err != nil {
+		t.Errorf("range error = %v, want nil", err)
+	}
+	if _, err = s.Range(context.TODO(), []byte("/cache/a"), nil, RangeOptions{Rev: 2}); err != ErrCompacted {
+		t.Errorf("range error = %v, want %v", err, ErrCompacted)
+	}
+	if _, _, err = s.hashByRev(3); err != nil {
+		t.Errorf("hash error = %v, want nil", err)
+	}
+
+	// the compaction revision of the other keys stays at 2.
+	if _, err = s.Compact(traceutil.TODO(), 2, Retention{Prefix: []byte("/cache/"), Revision: rev}); err != ErrCompacted {
+		t.Errorf("compact error = %v, want %v", err, ErrCompacted)
+	}
+	if _, err = s.Compact(traceutil.TODO(), 3, Retention{Prefix: []byte("/cache/"), Revision: rev + 1}); err != ErrFutureRev {
+		t.Errorf("compact error = %v, want %v", err, ErrFutureRev)
+	}
+	if _, err = s.Compact(traceutil.TODO(), 3, Retention{Prefix: []byte("/cache/"), Revision: rev}); err != nil {
+		t.Errorf("compact error = %v, want nil", err)
+	}
+}
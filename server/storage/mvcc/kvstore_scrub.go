@@ -0,0 +1,177 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var scrubBatchKeys = 1000 // non-const for testing
+
+// ScrubStats counts what a scrub of the store, or a batch of it, read.
+type ScrubStats struct {
+	// Bytes is the number of bytes of the revisions read.
+	Bytes int64
+	// Revisions is the number of revisions checked.
+	Revisions int64
+}
+
+// Scrub checks the revisions in the backend up to the current revision, in
+// batches of short read transactions. It checks that the revisions are well
+// formed, and that the revisions above the compaction revision of their key
+// are in the index. found is called with each problem found. progress is
+// called with the stats of each batch before reading the next one, which
+// lets the caller bound the rate of the scrub; the scrub stops with the error
+// progress returns.
+func (s *store) Scrub(ctx context.Context, progress func(ctx context.Context, batch ScrubStats) error, found func(err error)) (ScrubStats, error) {
+	var stats ScrubStats
+
+	s.revMu.RLock()
+	end := s.currentRev + 1
+	s.revMu.RUnlock()
+	// the read buffer goes before the db in the ranges of a read tx, so the
+	// revisions to scrub are committed first to range them in order.
+	s.b.ForceCommit()
+
+	min, max := NewRevBytes(), NewRevBytes()
+	max = RevToBytes(Revision{Main: end}, max)
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		batch, last := s.scrubBatch(min, max, found)
+		stats.Bytes += batch.Bytes
+		stats.Revisions += batch.Revisions
+		if err := progress(ctx, batch); err != nil {
+			return stats, err
+		}
+		if last == nil {
+			return stats, nil
+		}
+		// next batch begins after where this one ended
+		min = append(last, 0)
+	}
+}
+
+// scrubBatch checks a batch of revisions from min. It returns the stats of the
+// batch and the last revision checked, or nil if no revision is left.
+func (s *store) scrubBatch(min, max []byte, found func(err error)) (ScrubStats, []byte) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// the read buffer of a concurrent read tx may be a stale copy holding
+	// revisions already committed, which would be ranged twice.
+	tx := s.b.ReadTx()
+	tx.RLock()
+	keys, vals := tx.UnsafeRange(schema.Key, min, max, int64(scrubBatchKeys))
+	batch := ScrubStats{Revisions: int64(len(keys))}
+	var missing []scrubMissing
+	for i, key := range keys {
+		batch.Bytes += int64(len(key) + len(vals[i]))
+		m, err := s.scrubRevision(key, vals[i])
+		if err != nil {
+			found(err)
+		}
+		if m != nil {
+			missing = append(missing, *m)
+		}
+	}
+	var last []byte
+	if len(keys) == scrubBatchKeys {
+		last = append([]byte(nil), keys[len(keys)-1]...)
+	}
+	tx.RUnlock()
+
+	// the index drops the revisions of a compaction after the compaction
+	// revision is updated, so reading it after the index tells whether the
+	// missing revisions may have been compacted. It is read out of the tx as
+	// the end of write txns holds revMu while writing back to the tx.
+	s.revMu.RLock()
+	compactMainRev, retentions := s.compactMainRev, s.compactRetentions
+	s.revMu.RUnlock()
+	for _, m := range missing {
+		if m.rev.Main > compactRevOf(compactMainRev, retentions, m.key, nil) {
+			found(fmt.Errorf("revision %d_%d: key %q is missing from the index", m.rev.Main, m.rev.Sub, m.key))
+		}
+	}
+	return batch, last
+}
+
+// scrubMissing is a revision missing from the index.
+type scrubMissing struct {
+	key []byte
+	rev Revision
+}
+
+// scrubRevision checks the revision, returning the problem found, or the
+// revision if it is missing from the index.
+func (s *store) scrubRevision(key, val []byte) (*scrubMissing, error) {
+	if err := validRevisionBytes(key); err != nil {
+		return nil, fmt.Errorf("revision %x: %w", key, err)
+	}
+	rev := BytesToRev(key)
+	id := fmt.Sprintf("%d_%d", rev.Main, rev.Sub)
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(val); err != nil {
+		return nil, fmt.Errorf("revision %s: invalid key value: %w", id, err)
+	}
+	if len(kv.Key) == 0 {
+		return nil, fmt.Errorf("revision %s: empty key", id)
+	}
+	switch {
+	case isRangeTombstone(key):
+		// the index tombstones the keys of the range, not the range.
+		if len(kv.Value) == 0 {
+			return nil, fmt.Errorf("revision %s: range tombstone without range end", id)
+		}
+		return nil, nil
+	case isTombstone(key):
+	default:
+		switch {
+		case kv.ModRevision != rev.Main:
+			return nil, fmt.Errorf("revision %s: key %q has mod revision %d", id, kv.Key, kv.ModRevision)
+		case kv.CreateRevision <= 0 || kv.CreateRevision > kv.ModRevision:
+			return nil, fmt.Errorf("revision %s: key %q has create revision %d", id, kv.Key, kv.CreateRevision)
+		case kv.Version <= 0:
+			return nil, fmt.Errorf("revision %s: key %q has version %d", id, kv.Key, kv.Version)
+		}
+	}
+	if s.kvindex.Has(kv.Key, rev) {
+		return nil, nil
+	}
+	return &scrubMissing{key: bytes.Clone(kv.Key), rev: rev}, nil
+}
+
+// validRevisionBytes checks the revision bytes like BytesToBucketKey,
+// without panicking.
+func validRevisionBytes(b []byte) error {
+	switch {
+	case len(b) != revBytesLen && len(b) != markedRevBytesLen:
+		return fmt.Errorf("invalid revision length %d", len(b))
+	case b[8] != '_':
+		return fmt.Errorf("invalid separator %q", b[8])
+	case int64(binary.BigEndian.Uint64(b[0:8])) < 0 || int64(binary.BigEndian.Uint64(b[9:17])) < 0:
+		return fmt.Errorf("negative revision")
+	case len(b) == markedRevBytesLen && b[markBytePosition] != markTombstone && b[markBytePosition] != markRangeTombstone:
+		return fmt.Errorf("invalid mark %q", b[markBytePosition])
+	}
+	return nil
+}
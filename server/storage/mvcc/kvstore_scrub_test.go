@@ -0,0 +1,102 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestStoreScrub(t *testing.T) {
+	oldBatchKeys := scrubBatchKeys
+	defer func() { scrubBatchKeys = oldBatchKeys }()
+	scrubBatchKeys = 2
+
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	for _, k := range []string{"/a", "/b", "/c", "/a"} {
+		s.Put([]byte(k), []byte("v"), lease.NoLease)
+	}
+	s.DeleteRange([]byte("/b"), nil)
+	txn := s.Write(traceutil.TODO())
+	txn.LazyDeleteRange([]byte("/a"), []byte("/b"))
+	txn.End()
+	done, err := s.Compact(traceutil.TODO(), 3)
+	require.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for compaction to finish")
+	}
+
+	scrub := func() (ScrubStats, []error) {
+		var (
+			findings []error
+			progress ScrubStats
+		)
+		stats, err := s.Scrub(context.Background(), func(ctx context.Context, batch ScrubStats) error {
+			assert.LessOrEqual(t, batch.Revisions, int64(scrubBatchKeys))
+			progress.Bytes += batch.Bytes
+			progress.Revisions += batch.Revisions
+			return nil
+		}, func(err error) { findings = append(findings, err) })
+		require.NoError(t, err)
+		assert.Equal(t, stats, progress)
+		return stats, findings
+	}
+
+	// the compaction at 3 leaves /a at 2 and 5, /b at 3, /c at 4, the
+	// tombstone of /b at 6 and the range tombstone at 7.
+	stats, findings := scrub()
+	assert.Empty(t, findings)
+	assert.Equal(t, int64(6), stats.Revisions)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	rev := s.Rev()
+	tx.UnsafePut(schema.Key, RevToBytes(Revision{Main: rev, Sub: 1}, NewRevBytes()), []byte("not a key value"))
+	kv := mvccpb.KeyValue{Key: []byte("/missing"), Value: []byte("v"), CreateRevision: rev, ModRevision: rev, Version: 1}
+	d, err := kv.Marshal()
+	require.NoError(t, err)
+	tx.UnsafePut(schema.Key, RevToBytes(Revision{Main: rev, Sub: 2}, NewRevBytes()), d)
+	kv = mvccpb.KeyValue{Key: []byte("/c"), Value: []byte("v"), CreateRevision: rev, ModRevision: rev - 1, Version: 1}
+	d, err = kv.Marshal()
+	require.NoError(t, err)
+	tx.UnsafePut(schema.Key, RevToBytes(Revision{Main: rev, Sub: 3}, NewRevBytes()), d)
+	badMark := append(RevToBytes(Revision{Main: rev, Sub: 4}, NewRevBytes()), 'x')
+	tx.UnsafePut(schema.Key, badMark, d)
+	tx.Unlock()
+
+	stats, findings = scrub()
+	assert.Equal(t, int64(10), stats.Revisions)
+	require.Len(t, findings, 4)
+	assert.ErrorContains(t, findings[0], "invalid key value")
+	assert.ErrorContains(t, findings[1], `key "/missing" is missing from the index`)
+	assert.ErrorContains(t, findings[2], `key "/c" has mod revision 6`)
+	assert.ErrorContains(t, findings[3], "invalid mark 'x'")
+}
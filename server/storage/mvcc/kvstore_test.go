@@ -30,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
@@ -398,6 +399,7 @@ func TestStoreRestore(t *testing.T) {
 	}
 	b.tx.rangeRespc <- rangeResp{[][]byte{schema.FinishedCompactKeyName}, [][]byte{newTestRevBytes(Revision{Main: 3})}}
 	b.tx.rangeRespc <- rangeResp{[][]byte{schema.ScheduledCompactKeyName}, [][]byte{newTestRevBytes(Revision{Main: 3})}}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
 
 	b.tx.rangeRespc <- rangeResp{[][]byte{putkey, delkey}, [][]byte{putkvb, delkvb}}
 	b.tx.rangeRespc <- rangeResp{nil, nil}
@@ -413,6 +415,7 @@ func TestStoreRestore(t *testing.T) {
 	wact := []testutil.Action{
 		{Name: "range", Params: []any{schema.Meta, schema.FinishedCompactKeyName, []byte(nil), int64(0)}},
 		{Name: "range", Params: []any{schema.Meta, schema.ScheduledCompactKeyName, []byte(nil), int64(0)}},
+		{Name: "range", Params: []any{schema.Meta, schema.ScheduledCompactRetentionsKeyName, []byte(nil), int64(0)}},
 		{Name: "range", Params: []any{schema.Key, newTestRevBytes(Revision{Main: 1}), newTestRevBytes(Revision{Main: math.MaxInt64, Sub: math.MaxInt64}), int64(restoreChunkKeys)}},
 	}
 	if g := b.tx.Action(); !reflect.DeepEqual(g, wact) {
@@ -425,7 +428,7 @@ func TestStoreRestore(t *testing.T) {
 	}
 	ki := &keyIndex{key: []byte("foo"), modified: Revision{Main: 5}, generations: gens}
 	wact = []testutil.Action{
-		{Name: "keyIndex", Params: []any{ki}},
+		{Name: "keyIndex", Params: []any{[]byte("foo")}},
 		{Name: "insert", Params: []any{ki}},
 	}
 	if g := fi.Action(); !reflect.DeepEqual(g, wact) {
@@ -957,6 +960,7 @@ type fakeBatchTx struct {
 
 func (b *fakeBatchTx) LockInsideApply()                         {}
 func (b *fakeBatchTx) LockOutsideApply()                        {}
+func (b *fakeBatchTx) SetCommitExemplar(prometheus.Labels)      {}
 func (b *fakeBatchTx) Lock()                                    {}
 func (b *fakeBatchTx) Unlock()                                  {}
 func (b *fakeBatchTx) RLock()                                   {}
@@ -995,14 +999,20 @@ func (b *fakeBackend) BatchTx() backend.BatchTx
 func (b *fakeBackend) ReadTx() backend.ReadTx                                     { return b.tx }
 func (b *fakeBackend) ConcurrentReadTx() backend.ReadTx                           { return b.tx }
 func (b *fakeBackend) Hash(func(bucketName, keyName []byte) bool) (uint32, error) { return 0, nil }
-func (b *fakeBackend) Size() int64                                                { return 0 }
-func (b *fakeBackend) SizeInUse() int64                                           { return 0 }
-func (b *fakeBackend) OpenReadTxN() int64                                         { return 0 }
-func (b *fakeBackend) Snapshot() backend.Snapshot                                 { return nil }
-func (b *fakeBackend) ForceCommit()                                               {}
-func (b *fakeBackend) Defrag() error                                              { return nil }
-func (b *fakeBackend) Close() error                                               { return nil }
-func (b *fakeBackend) SetTxPostLockInsideApplyHook(func())                        {}
+func (b *fakeBackend) CheckPages(context.Context, func(context.Context, int64) error) ([]error, error) {
+	return nil, nil
+}
+func (b *fakeBackend) Size() int64                         { return 0 }
+func (b *fakeBackend) SizeInUse() int64                    { return 0 }
+func (b *fakeBackend) OpenReadTxN() int64                  { return 0 }
+func (b *fakeBackend) Snapshot() backend.Snapshot          { return nil }
+func (b *fakeBackend) ForceCommit()                        {}
+func (b *fakeBackend) Defrag() error                       { return nil }
+func (b *fakeBackend) Close() error                        { return nil }
+func (b *fakeBackend) SetTxPostLockInsideApplyHook(func()) {}
+func (b *fakeBackend) BatchConfig() backend.BatchConfig    { return backend.BatchConfig{} }
+func (b *fakeBackend) SetBatchConfig(backend.BatchConfig)  {}
+func (b *fakeBackend) CommitInProgress() time.Duration     { return 0 }
 
 type indexGetResp struct {
 	rev     Revision
@@ -1047,6 +1057,11 @@ func (i *fakeIndex) Get(key []byte, atRev int64) (rev, created Revision, ver int
 	return r.rev, r.created, r.ver, r.err
 }
 
+func (i *fakeIndex) Has(key []byte, rev Revision) bool {
+	i.Recorder.Record(testutil.Action{Name: "has", Params: []any{key, rev}})
+	return true
+}
+
 func (i *fakeIndex) Range(key, end []byte, atRev int64) ([][]byte, []Revision) {
 	i.Recorder.Record(testutil.Action{Name: "range", Params: []any{key, end, atRev}})
 	r := <-i.indexRangeRespc
@@ -1068,7 +1083,7 @@ func (i *fakeIndex) RangeSince(key, end []byte, rev int64) []Revision {
 	return r.revs
 }
 
-func (i *fakeIndex) Compact(rev int64) map[Revision]struct{} {
+func (i *fakeIndex) Compact(rev int64, _ ...Retention) map[Revision]struct{} {
 	i.Recorder.Record(testutil.Action{Name: "compact", Params: []any{rev}})
 	return <-i.indexCompactRespc
 }
@@ -1084,7 +1099,8 @@ func (i *fakeIndex) Insert(ki *keyIndex) {
 }
 
 func (i *fakeIndex) KeyIndex(ki *keyIndex) *keyIndex {
-	i.Recorder.Record(testutil.Action{Name: "keyIndex", Params: []any{ki}})
+	// the keyIndex searched for is reused once the search is done.
+	i.Recorder.Record(testutil.Action{Name: "keyIndex", Params: []any{ki.key}})
 	return nil
 }
 
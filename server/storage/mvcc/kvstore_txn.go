@@ -36,8 +36,11 @@ type storeTxnCommon struct {
 	s  *store
 	tx backend.UnsafeReader
 
-	firstRev int64
-	rev      int64
+	// compactRev and retentions are the compaction of the store at the time
+	// of opening the txn.
+	compactRev int64
+	retentions []Retention
+	rev        int64
 
 	trace *traceutil.Trace
 }
@@ -57,13 +60,16 @@ func (s *store) Read(mode ReadTxMode, trace *traceutil.Trace) TxnRead {
 	}
 
 	tx.RLock() // RLock is no-op. concurrentReadTx does not need to be locked after it is created.
-	firstRev, rev := s.compactMainRev, s.currentRev
+	compactRev, retentions, rev := s.compactMainRev, s.compactRetentions, s.currentRev
 	s.revMu.RUnlock()
-	return newMetricsTxnRead(&storeTxnRead{storeTxnCommon{s, tx, firstRev, rev, trace}, tx})
+	return newMetricsTxnRead(&storeTxnRead{storeTxnCommon{s, tx, compactRev, retentions, rev, trace}, tx}, s.keyPrefixes)
 }
 
-func (tr *storeTxnCommon) FirstRev() int64 { return tr.firstRev }
-func (tr *storeTxnCommon) Rev() int64      { return tr.rev }
+func (tr *storeTxnCommon) FirstRev() int64 { return globalCompactRevOf(tr.compactRev, tr.retentions) }
+func (tr *storeTxnCommon) FirstRevOf(key, end []byte) int64 {
+	return compactRevOf(tr.compactRev, tr.retentions, key, end)
+}
+func (tr *storeTxnCommon) Rev() int64 { return tr.rev }
 
 func (tr *storeTxnCommon) Range(ctx context.Context, key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	return tr.rangeKeys(ctx, key, end, tr.Rev(), ro)
@@ -77,7 +83,7 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 	if rev <= 0 {
 		rev = curRev
 	}
-	if rev < tr.s.compactMainRev {
+	if rev < compactRevOf(tr.s.compactMainRev, tr.s.compactRetentions, key, end) {
 		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
 	}
 	if ro.Count {
@@ -149,12 +155,12 @@ func (s *store) Write(trace *traceutil.Trace) TxnWrite {
 	tx := s.b.BatchTx()
 	tx.LockInsideApply()
 	tw := &storeTxnWrite{
-		storeTxnCommon: storeTxnCommon{s, tx, 0, 0, trace},
+		storeTxnCommon: storeTxnCommon{s, tx, 0, nil, 0, trace},
 		tx:             tx,
 		beginRev:       s.currentRev,
 		changes:        make([]mvccpb.KeyValue, 0, 4),
 	}
-	return newMetricsTxnWrite(tw)
+	return newMetricsTxnWrite(tw, s.keyPrefixes)
 }
 
 func (tw *storeTxnWrite) Rev() int64 { return tw.beginRev }
@@ -174,6 +180,13 @@ func (tw *storeTxnWrite) DeleteRange(key, end []byte) (int64, int64) {
 	return 0, tw.beginRev
 }
 
+func (tw *storeTxnWrite) LazyDeleteRange(key, end []byte) (int64, int64) {
+	if n := tw.lazyDeleteRange(key, end); n != 0 || len(tw.changes) > 0 {
+		return n, tw.beginRev + 1
+	}
+	return 0, tw.beginRev
+}
+
 func (tw *storeTxnWrite) Put(key, value []byte, lease lease.LeaseID) int64 {
 	tw.put(key, value, lease)
 	return tw.beginRev + 1
@@ -205,6 +218,8 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		c = created.Main
 		oldLease = tw.s.le.GetLease(lease.LeaseItem{Key: string(key)})
 		tw.trace.Step("get key's previous created_revision and leaseID")
+	} else {
+		tw.s.keyPrefixes.addKeys(key, 1)
 	}
 	ibytes := NewRevBytes()
 	idxRev := Revision{Main: rev, Sub: int64(len(tw.changes))}
@@ -295,6 +310,7 @@ func (tw *storeTxnWrite) delete(key []byte) {
 
 	tw.tx.UnsafeSeqPut(schema.Key, ibytes, d)
 	err = tw.s.kvindex.Tombstone(key, idxRev.Revision)
+	tw.s.keyPrefixes.addKeys(key, -1)
 	if err != nil {
 		tw.storeTxnCommon.s.lg.Fatal(
 			"failed to tombstone an existing key",
@@ -303,12 +319,59 @@ func (tw *storeTxnWrite) delete(key []byte) {
 		)
 	}
 	tw.changes = append(tw.changes, kv)
+	tw.detachLease(key)
+}
+
+// lazyDeleteRange deletes the keys of the range with a single range tombstone
+// in the backend. The keys are tombstoned in the index at the revision of the
+// range tombstone, and their revisions are removed from the backend by the
+// compactions like the ones of the keys deleted by deleteRange.
+func (tw *storeTxnWrite) lazyDeleteRange(key, end []byte) int64 {
+	if end == nil {
+		return tw.deleteRange(key, end)
+	}
+	rrev := tw.beginRev
+	if len(tw.changes) > 0 {
+		rrev++
+	}
+	keys, _ := tw.s.kvindex.Range(key, end, rrev)
+	if len(keys) == 0 {
+		return 0
+	}
+
+	idxRev := Revision{Main: tw.beginRev + 1, Sub: int64(len(tw.changes))}
+	ibytes := append(RevToBytes(idxRev, NewRevBytes()), markRangeTombstone)
+	kv := mvccpb.KeyValue{Key: key, Value: rangeTombstoneEnd(end)}
+	d, err := kv.Marshal()
+	if err != nil {
+		tw.storeTxnCommon.s.lg.Fatal(
+			"failed to marshal mvccpb.KeyValue",
+			zap.Error(err),
+		)
+	}
+	tw.tx.UnsafeSeqPut(schema.Key, ibytes, d)
+	for _, key := range keys {
+		if err = tw.s.kvindex.Tombstone(key, idxRev); err != nil {
+			tw.storeTxnCommon.s.lg.Fatal(
+				"failed to tombstone an existing key",
+				zap.String("key", string(key)),
+				zap.Error(err),
+			)
+		}
+		tw.s.keyPrefixes.addKeys(key, -1)
+		tw.detachLease(key)
+	}
+	tw.trace.Step("tombstone range in bolt db", traceutil.Field{Key: "keys", Value: len(keys)})
+	tw.changes = append(tw.changes, mvccpb.KeyValue{Key: key})
+	return int64(len(keys))
+}
 
+func (tw *storeTxnWrite) detachLease(key []byte) {
 	item := lease.LeaseItem{Key: string(key)}
 	leaseID := tw.s.le.GetLease(item)
 
 	if leaseID != lease.NoLease {
-		err = tw.s.le.Detach(leaseID, []lease.LeaseItem{item})
+		err := tw.s.le.Detach(leaseID, []lease.LeaseItem{item})
 		if err != nil {
 			tw.storeTxnCommon.s.lg.Error(
 				"failed to detach old lease from a key",
@@ -284,6 +284,46 @@ var (
 			Name:      "total_put_size_in_bytes",
 			Help:      "The total size of put kv pairs seen by this member.",
 		})
+
+	prefixRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "prefix_requests_total",
+			Help:      "Total number of ranges, puts and deletes of the monitored key prefixes seen by this member.",
+		},
+		[]string{"prefix", "type"},
+	)
+
+	prefixPutBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "prefix_put_bytes_total",
+			Help:      "Total size of put kv pairs of the monitored key prefixes seen by this member.",
+		},
+		[]string{"prefix"},
+	)
+
+	prefixWatchEventsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "prefix_watch_events_total",
+			Help:      "Total number of events of the monitored key prefixes sent to watchers by this member.",
+		},
+		[]string{"prefix"},
+	)
+
+	prefixKeysGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "prefix_keys",
+			Help:      "Number of keys of the monitored key prefixes.",
+		},
+		[]string{"prefix"},
+	)
 )
 
 func init() {
@@ -310,6 +350,10 @@ func init() {
 	prometheus.MustRegister(currentRev)
 	prometheus.MustRegister(compactRev)
 	prometheus.MustRegister(totalPutSizeGauge)
+	prometheus.MustRegister(prefixRequestCounter)
+	prometheus.MustRegister(prefixPutBytesCounter)
+	prometheus.MustRegister(prefixWatchEventsCounter)
+	prometheus.MustRegister(prefixKeysGauge)
 }
 
 // ReportEventReceived reports that an event is received.
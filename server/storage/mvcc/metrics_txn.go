@@ -26,30 +26,41 @@ type metricsTxnWrite struct {
 	puts    uint
 	deletes uint
 	putSize int64
+
+	prefixes *keyPrefixMetrics
 }
 
-func newMetricsTxnRead(tr TxnRead) TxnRead {
-	return &metricsTxnWrite{&txnReadWrite{tr}, 0, 0, 0, 0}
+func newMetricsTxnRead(tr TxnRead, prefixes *keyPrefixMetrics) TxnRead {
+	return &metricsTxnWrite{&txnReadWrite{tr}, 0, 0, 0, 0, prefixes}
 }
 
-func newMetricsTxnWrite(tw TxnWrite) TxnWrite {
-	return &metricsTxnWrite{tw, 0, 0, 0, 0}
+func newMetricsTxnWrite(tw TxnWrite, prefixes *keyPrefixMetrics) TxnWrite {
+	return &metricsTxnWrite{tw, 0, 0, 0, 0, prefixes}
 }
 
 func (tw *metricsTxnWrite) Range(ctx context.Context, key, end []byte, ro RangeOptions) (*RangeResult, error) {
 	tw.ranges++
+	tw.prefixes.observeRequest(key, end, "range")
 	return tw.TxnWrite.Range(ctx, key, end, ro)
 }
 
 func (tw *metricsTxnWrite) DeleteRange(key, end []byte) (n, rev int64) {
 	tw.deletes++
+	tw.prefixes.observeRequest(key, end, "delete")
 	return tw.TxnWrite.DeleteRange(key, end)
 }
 
+func (tw *metricsTxnWrite) LazyDeleteRange(key, end []byte) (n, rev int64) {
+	tw.deletes++
+	tw.prefixes.observeRequest(key, end, "delete")
+	return tw.TxnWrite.LazyDeleteRange(key, end)
+}
+
 func (tw *metricsTxnWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	tw.puts++
 	size := int64(len(key) + len(value))
 	tw.putSize += size
+	tw.prefixes.observePut(key, value)
 	return tw.TxnWrite.Put(key, value, lease)
 }
 
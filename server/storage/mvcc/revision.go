@@ -30,6 +30,9 @@ const (
 	markedRevBytesLen      = revBytesLen + 1
 	markBytePosition       = markedRevBytesLen - 1
 	markTombstone     byte = 't'
+	// markRangeTombstone marks the revision of a lazily deleted range. Its
+	// value holds the first key and the end of the range.
+	markRangeTombstone byte = 'r'
 )
 
 type Revision struct {
@@ -112,7 +115,7 @@ func BytesToBucketKey(bytes []byte) BucketKey {
 			Main: main,
 			Sub:  sub,
 		},
-		tombstone: isTombstone(bytes),
+		tombstone: isTombstone(bytes) || isRangeTombstone(bytes),
 	}
 }
 
@@ -124,3 +127,31 @@ func isTombstone(b []byte) bool {
 func IsTombstone(b []byte) bool {
 	return isTombstone(b)
 }
+
+// isRangeTombstone checks whether the revision bytes is the tombstone of a
+// lazily deleted range.
+func isRangeTombstone(b []byte) bool {
+	return len(b) == markedRevBytesLen && b[markBytePosition] == markRangeTombstone
+}
+
+func IsRangeTombstone(b []byte) bool {
+	return isRangeTombstone(b)
+}
+
+// rangeTombstoneEnd returns the range end of the API, which is '\0' for the
+// keys greater than or equal to the first key, for the given range end.
+func rangeTombstoneEnd(end []byte) []byte {
+	if len(end) == 0 {
+		return []byte{0}
+	}
+	return end
+}
+
+// rangeEndFromTombstone returns the range end of the store for the range end
+// of a range tombstone.
+func rangeEndFromTombstone(end []byte) []byte {
+	if len(end) == 1 && end[0] == 0 {
+		return []byte{}
+	}
+	return end
+}
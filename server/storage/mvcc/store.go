@@ -58,3 +58,17 @@ func UnsafeSetFinishedCompact(tx backend.UnsafeWriter, value int64) {
 	rbytes = RevToBytes(Revision{Main: value}, rbytes)
 	tx.UnsafePut(schema.Meta, schema.FinishedCompactKeyName, rbytes)
 }
+
+// UnsafeReadCompactRetentions returns the retentions of the scheduled
+// compaction.
+func UnsafeReadCompactRetentions(tx backend.UnsafeReader) ([]Retention, error) {
+	_, vs := tx.UnsafeRange(schema.Meta, schema.ScheduledCompactRetentionsKeyName, nil, 0)
+	if len(vs) == 0 {
+		return nil, nil
+	}
+	return decodeRetentions(vs[0])
+}
+
+func UnsafeSetCompactRetentions(tx backend.UnsafeWriter, rs []Retention) {
+	tx.UnsafePut(schema.Meta, schema.ScheduledCompactRetentionsKeyName, encodeRetentions(rs))
+}
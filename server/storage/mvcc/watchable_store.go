@@ -298,6 +298,7 @@ func (s *watchableStore) moveVictims() (moved int) {
 				continue
 			}
 			pendingEventsGauge.Add(float64(len(eb.evs)))
+			s.keyPrefixes.observeEvents(eb.evs)
 			moved++
 		}
 
@@ -354,15 +355,17 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 	// find min revision index, and these revisions can be used to
 	// query the backend store of key-value pairs
 	curRev := s.store.currentRev
-	compactionRev := s.store.compactMainRev
+	compactRev := func(w *watcher) int64 {
+		return compactRevOf(s.store.compactMainRev, s.store.compactRetentions, w.key, w.end)
+	}
 
-	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactionRev)
+	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactRev)
 	evs = rangeEventsWithReuse(s.store.lg, s.store.b, evs, minRev, curRev+1)
 
 	victims := make(watcherBatch)
 	wb := newWatcherBatch(wg, evs)
 	for w := range wg.watchers {
-		if w.minRev < compactionRev {
+		if w.minRev < compactRev(w) {
 			// Skip the watcher that failed to send compacted watch response due to w.ch is full.
 			// Next retry of syncWatchers would try to resend the compacted watch response to w.ch
 			continue
@@ -383,6 +386,7 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 
 		if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: curRev}) {
 			pendingEventsGauge.Add(float64(len(eb.evs)))
+			s.keyPrefixes.observeEvents(eb.evs)
 		} else {
 			w.victim = true
 		}
@@ -469,12 +473,18 @@ func kvsToEvents(lg *zap.Logger, revs, vals [][]byte) (evs []mvccpb.Event) {
 		}
 
 		ty := mvccpb.PUT
-		if isTombstone(revs[i]) {
+		var rangeEnd []byte
+		switch {
+		case isTombstone(revs[i]):
 			ty = mvccpb.DELETE
 			// patch in mod revision so watchers won't skip
 			kv.ModRevision = BytesToRev(revs[i]).Main
+		case isRangeTombstone(revs[i]):
+			ty = mvccpb.DELETE
+			kv.ModRevision = BytesToRev(revs[i]).Main
+			rangeEnd, kv.Value = kv.Value, nil
 		}
-		evs = append(evs, mvccpb.Event{Kv: &kv, Type: ty})
+		evs = append(evs, mvccpb.Event{Kv: &kv, Type: ty, RangeEnd: rangeEnd})
 	}
 	return evs
 }
@@ -492,6 +502,7 @@ func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
 		}
 		if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: rev}) {
 			pendingEventsGauge.Add(float64(len(eb.evs)))
+			s.keyPrefixes.observeEvents(eb.evs)
 		} else {
 			// move slow watcher to victims
 			w.victim = true
@@ -268,6 +268,69 @@ func TestRangeEvents(t *testing.T) {
 	}
 }
 
+// TestWatchLazyDeleteRange ensures a lazy range delete notifies a single
+// event with the range end to the synced and unsynced watchers of any key in
+// the range.
+func TestWatchLazyDeleteRange(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.Put([]byte("foo1"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo2"), []byte("bar"), lease.NoLease)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	watches := []struct {
+		key, end []byte
+		startRev int64
+		notified bool
+	}{
+		{[]byte("foo1"), nil, 0, true},
+		{[]byte("foo2"), []byte("foo3"), 0, true},
+		{[]byte("fo"), []byte{}, 0, true},
+		// the event does not tell which keys of the range existed.
+		{[]byte("foo3"), nil, 0, true},
+		{[]byte("fop"), nil, 0, false},
+		{[]byte("zoo"), []byte("zop"), 0, false},
+		// unsynced watchers replay the range tombstone from the backend.
+		{[]byte("foo2"), nil, 4, true},
+		{[]byte("zoo"), []byte{}, 4, false},
+	}
+	want := map[WatchID]bool{}
+	for _, wt := range watches {
+		id, err := w.Watch(0, wt.key, wt.end, wt.startRev)
+		require.NoError(t, err)
+		want[id] = wt.notified
+	}
+
+	n, rev := s.LazyDeleteRange([]byte("foo"), []byte("fop"))
+	require.Equal(t, int64(2), n)
+	require.Equal(t, int64(4), rev)
+
+	wev := mvccpb.Event{
+		Type:     mvccpb.DELETE,
+		Kv:       &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: 4},
+		RangeEnd: []byte("fop"),
+	}
+	got := map[WatchID]bool{}
+	for len(got) < 5 {
+		select {
+		case resp := <-w.Chan():
+			require.Equal(t, []mvccpb.Event{wev}, resp.Events)
+			require.Truef(t, want[resp.WatchID], "unexpected event for watch %d", resp.WatchID)
+			got[resp.WatchID] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got events for %d watches, want 5", len(got))
+		}
+	}
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected response %+v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestWatchCompacted tests a watcher that watches on a compacted revision.
 func TestWatchCompacted(t *testing.T) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
@@ -304,6 +367,41 @@ func TestWatchCompacted(t *testing.T) {
 	}
 }
 
+func TestWatchCompactRetention(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	for i := 0; i < 5; i++ {
+		s.Put([]byte("/audit/a"), []byte("v"), lease.NoLease)
+		s.Put([]byte("/b"), []byte("v"), lease.NoLease)
+	}
+	_, err := s.Compact(traceutil.TODO(), s.Rev(), Retention{Prefix: []byte("/audit/"), Revision: 2})
+	require.NoError(t, err)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	wt, _ := w.Watch(0, []byte("/audit/"), []byte("/audit0"), 2)
+	select {
+	case resp := <-w.Chan():
+		require.Equal(t, wt, resp.WatchID)
+		require.Zero(t, resp.CompactRevision)
+		require.Len(t, resp.Events, 5)
+	case <-time.After(1 * time.Second):
+		t.Fatalf("failed to receive response (timeout)")
+	}
+
+	wt, _ = w.Watch(0, []byte("/b"), nil, 2)
+	select {
+	case resp := <-w.Chan():
+		require.Equal(t, wt, resp.WatchID)
+		require.Equal(t, s.Rev(), resp.CompactRevision)
+	case <-time.After(1 * time.Second):
+		t.Fatalf("failed to receive response (timeout)")
+	}
+}
+
 func TestWatchNoEventLossOnCompact(t *testing.T) {
 	oldChanBufLen, oldMaxWatchersPerSync := chanBufLen, maxWatchersPerSync
 
@@ -33,6 +33,7 @@ func (tw *watchableStoreTxnWrite) End() {
 		if change.CreateRevision == 0 {
 			evs[i].Type = mvccpb.DELETE
 			evs[i].Kv.ModRevision = rev
+			evs[i].RangeEnd = tw.rangeEnds[i]
 		} else {
 			evs[i].Type = mvccpb.PUT
 		}
@@ -49,8 +50,23 @@ func (tw *watchableStoreTxnWrite) End() {
 type watchableStoreTxnWrite struct {
 	TxnWrite
 	s *watchableStore
+	// rangeEnds are the range ends of the changes of the lazy range deletes,
+	// by the index of the change.
+	rangeEnds map[int][]byte
 }
 
 func (s *watchableStore) Write(trace *traceutil.Trace) TxnWrite {
-	return &watchableStoreTxnWrite{s.store.Write(trace), s}
+	return &watchableStoreTxnWrite{TxnWrite: s.store.Write(trace), s: s}
+}
+
+func (tw *watchableStoreTxnWrite) LazyDeleteRange(key, end []byte) (n, rev int64) {
+	i := len(tw.Changes())
+	n, rev = tw.TxnWrite.LazyDeleteRange(key, end)
+	if end != nil && n != 0 {
+		if tw.rangeEnds == nil {
+			tw.rangeEnds = make(map[int][]byte)
+		}
+		tw.rangeEnds[i] = rangeTombstoneEnd(end)
+	}
+	return n, rev
 }
@@ -83,7 +83,11 @@ func newWatcherBatch(wg *watcherGroup, evs []mvccpb.Event) watcherBatch {
 
 	wb := make(watcherBatch)
 	for _, ev := range evs {
-		for w := range wg.watcherSetByKey(string(ev.Kv.Key)) {
+		ws := wg.watcherSetByKey(string(ev.Kv.Key))
+		if ev.RangeEnd != nil {
+			ws = wg.watcherSetByRange(string(ev.Kv.Key), string(rangeEndFromTombstone(ev.RangeEnd)))
+		}
+		for w := range ws {
 			if ev.Kv.ModRevision >= w.minRev {
 				// don't double notify
 				wb.add(w, ev)
@@ -219,7 +223,7 @@ func (wg *watcherGroup) delete(wa *watcher) bool {
 }
 
 // choose selects watchers from the watcher group to update
-func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watcherGroup, int64) {
+func (wg *watcherGroup) choose(maxWatchers int, curRev int64, compactRev func(*watcher) int64) (*watcherGroup, int64) {
 	if len(wg.watchers) < maxWatchers {
 		return wg, wg.chooseAll(curRev, compactRev)
 	}
@@ -234,7 +238,7 @@ func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watc
 	return &ret, ret.chooseAll(curRev, compactRev)
 }
 
-func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
+func (wg *watcherGroup) chooseAll(curRev int64, compactRev func(*watcher) int64) int64 {
 	minRev := int64(math.MaxInt64)
 	for w := range wg.watchers {
 		if w.minRev > curRev {
@@ -248,9 +252,9 @@ func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
 			// mark 'restore' done, since it's chosen
 			w.restore = false
 		}
-		if w.minRev < compactRev {
+		if crev := compactRev(w); w.minRev < crev {
 			select {
-			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: compactRev}:
+			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: crev}:
 				w.compacted = true
 				wg.delete(w)
 			default:
@@ -289,3 +293,19 @@ func (wg *watcherGroup) watcherSetByKey(key string) watcherSet {
 	}
 	return ret
 }
+
+// watcherSetByRange gets the set of watchers that receive events on any key
+// in the range [key, end), or greater than or equal to key if end is empty.
+func (wg *watcherGroup) watcherSetByRange(key, end string) watcherSet {
+	ret := make(watcherSet)
+	for k, ws := range wg.keyWatchers {
+		if k >= key && (end == "" || k < end) {
+			ret.union(ws)
+		}
+	}
+	wg.ranges.Visit(adt.NewStringAffineInterval(key, end), func(iv *adt.IntervalValue) bool {
+		ret.union(iv.Val.(watcherSet))
+		return true
+	})
+	return ret
+}
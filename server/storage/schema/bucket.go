@@ -27,7 +27,10 @@ var (
 	leaseBucketName = []byte("lease")
 	alarmBucketName = []byte("alarm")
 
-	clusterBucketName = []byte("cluster")
+	valuePolicyBucketName = []byte("valuePolicy")
+
+	clusterBucketName         = []byte("cluster")
+	clusterMetadataBucketName = []byte("clusterMetadata")
 
 	membersBucketName        = []byte("members")
 	membersRemovedBucketName = []byte("members_removed")
@@ -45,6 +48,10 @@ var (
 	Lease   = backend.Bucket(bucket{id: 3, name: leaseBucketName, safeRangeBucket: false})
 	Alarm   = backend.Bucket(bucket{id: 4, name: alarmBucketName, safeRangeBucket: false})
 	Cluster = backend.Bucket(bucket{id: 5, name: clusterBucketName, safeRangeBucket: false})
+	// ClusterMetadata holds the operational annotations of the cluster. It is
+	// kept apart from Cluster so that it survives restoring a snapshot.
+	ClusterMetadata = backend.Bucket(bucket{id: 6, name: clusterMetadataBucketName, safeRangeBucket: false})
+	ValuePolicy     = backend.Bucket(bucket{id: 7, name: valuePolicyBucketName, safeRangeBucket: false})
 
 	Members        = backend.Bucket(bucket{id: 10, name: membersBucketName, safeRangeBucket: false})
 	MembersRemoved = backend.Bucket(bucket{id: 11, name: membersRemovedBucketName, safeRangeBucket: false})
@@ -55,7 +62,7 @@ var (
 
 	Test = backend.Bucket(bucket{id: 100, name: testBucketName, safeRangeBucket: false})
 
-	AllBuckets = []backend.Bucket{Key, Meta, Lease, Alarm, Cluster, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
+	AllBuckets = []backend.Bucket{Key, Meta, Lease, Alarm, Cluster, ClusterMetadata, ValuePolicy, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
 )
 
 type bucket struct {
@@ -82,7 +89,8 @@ var (
 	ClusterClusterVersionKeyName = []byte("clusterVersion")
 	ClusterDowngradeKeyName      = []byte("downgrade")
 	// Since v3.6
-	MetaStorageVersionName = []byte("storageVersion")
+	MetaStorageVersionName            = []byte("storageVersion")
+	ScheduledCompactRetentionsKeyName = []byte("scheduledCompactRetentions")
 	// Before adding new meta key please update server/etcdserver/version
 )
 
@@ -174,6 +174,45 @@ func (s *membershipBackend) MustCreateBackendBuckets() {
 	tx.UnsafeCreateBucket(Cluster)
 }
 
+// MustSaveClusterMetadataToBackend saves an entry of the cluster metadata to
+// backend. The field is populated since etcd v3.6.
+func (s *membershipBackend) MustSaveClusterMetadataToBackend(key, value string) {
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	// the bucket is only created once used, leaving the backend of clusters
+	// without cluster metadata as it was before.
+	tx.UnsafeCreateBucket(ClusterMetadata)
+	tx.UnsafePut(ClusterMetadata, []byte(key), []byte(value))
+}
+
+// MustDeleteClusterMetadataFromBackend deletes an entry of the cluster
+// metadata from backend.
+func (s *membershipBackend) MustDeleteClusterMetadataFromBackend(key string) {
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	tx.UnsafeCreateBucket(ClusterMetadata)
+	tx.UnsafeDelete(ClusterMetadata, []byte(key))
+}
+
+// ClusterMetadataFromBackend reads the cluster metadata from backend.
+// The field is populated since etcd v3.6.
+func (s *membershipBackend) ClusterMetadataFromBackend() map[string]string {
+	md := make(map[string]string)
+	tx := s.be.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	err := tx.UnsafeForEach(ClusterMetadata, func(k, v []byte) error {
+		md[string(k)] = string(v)
+		return nil
+	})
+	if err != nil {
+		s.lg.Panic("failed to read cluster metadata from backend", zap.Error(err))
+	}
+	return md
+}
+
 func mustParseMemberIDFromBytes(lg *zap.Logger, key []byte) types.ID {
 	id, err := types.IDFromString(string(key))
 	if err != nil {
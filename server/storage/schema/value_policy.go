@@ -0,0 +1,93 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+type ValuePolicyBackend interface {
+	MustPutValuePolicy(policy *etcdserverpb.ValuePolicy)
+	MustDeleteValuePolicy(prefix []byte)
+	GetAllValuePolicies() ([]*etcdserverpb.ValuePolicy, error)
+	ForceCommit()
+}
+
+type valuePolicyBackend struct {
+	lg *zap.Logger
+	be backend.Backend
+}
+
+func NewValuePolicyBackend(lg *zap.Logger, be backend.Backend) ValuePolicyBackend {
+	return &valuePolicyBackend{
+		lg: lg,
+		be: be,
+	}
+}
+
+// MustPutValuePolicy saves the policy keyed by its prefix, replacing the
+// policy of the same prefix.
+func (s *valuePolicyBackend) MustPutValuePolicy(policy *etcdserverpb.ValuePolicy) {
+	v, err := policy.Marshal()
+	if err != nil {
+		s.lg.Panic("failed to marshal value policy", zap.Error(err))
+	}
+
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	// the bucket is only created once used, leaving the backend of clusters
+	// without value policies as it was before.
+	tx.UnsafeCreateBucket(ValuePolicy)
+	tx.UnsafePut(ValuePolicy, valuePolicyBackendKey(policy.Prefix), v)
+}
+
+func (s *valuePolicyBackend) MustDeleteValuePolicy(prefix []byte) {
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	tx.UnsafeCreateBucket(ValuePolicy)
+	tx.UnsafeDelete(ValuePolicy, valuePolicyBackendKey(prefix))
+}
+
+func (s *valuePolicyBackend) GetAllValuePolicies() ([]*etcdserverpb.ValuePolicy, error) {
+	tx := s.be.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	var ps []*etcdserverpb.ValuePolicy
+	err := tx.UnsafeForEach(ValuePolicy, func(k, v []byte) error {
+		var p etcdserverpb.ValuePolicy
+		if err := p.Unmarshal(v); err != nil {
+			return err
+		}
+		ps = append(ps, &p)
+		return nil
+	})
+	return ps, err
+}
+
+func (s *valuePolicyBackend) ForceCommit() {
+	s.be.ForceCommit()
+}
+
+// valuePolicyBackendKey returns the backend key of the policy of the prefix.
+// The key is prefixed by a fixed byte, since the empty prefix, which covers
+// the whole keyspace, cannot be stored as an empty key.
+func valuePolicyBackendKey(prefix []byte) []byte {
+	return append([]byte{'/'}, prefix...)
+}
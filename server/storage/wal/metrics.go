@@ -43,10 +43,21 @@ var (
 		Name:      "wal_write_bytes_total",
 		Help:      "Total number of bytes written in WAL.",
 	})
+
+	walSaveEntries = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "disk",
+		Name:      "wal_save_entries",
+		Help:      "The distributions of the number of entries committed to WAL together in a save.",
+
+		// 1 entry to 2^14 entries.
+		Buckets: prometheus.ExponentialBuckets(1, 2, 15),
+	})
 )
 
 func init() {
 	prometheus.MustRegister(walFsyncSec)
 	prometheus.MustRegister(walWriteSec)
 	prometheus.MustRegister(walWriteBytes)
+	prometheus.MustRegister(walSaveEntries)
 }
@@ -962,6 +962,9 @@ func (w *WAL) Save(st raftpb.HardState, ents []raftpb.Entry) error {
 	}
 
 	mustSync := raft.MustSync(st, w.state, len(ents))
+	if len(ents) > 0 {
+		walSaveEntries.Observe(float64(len(ents)))
+	}
 
 	// TODO(xiangli): no more reference operator
 	for i := range ents {
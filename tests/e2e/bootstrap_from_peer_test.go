@@ -0,0 +1,71 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestBootstrapFromPeer(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	epc, err := e2e.NewEtcdProcessCluster(ctx, t,
+		e2e.WithClusterSize(1),
+		e2e.WithSnapshotCount(10),
+	)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, epc.Close())
+	}()
+
+	t.Log("Writing 20 keys to the cluster (more than SnapshotCount entries to trigger at least a snapshot)")
+	writeKVs(t, epc.Etcdctl(), 0, 20)
+
+	t.Log("Starting a new member bootstrapping from the existing member")
+	newCfg := *epc.Cfg
+	newCfg.ServerConfig.BootstrapFromPeer = true
+	newCfg.ServerConfig.BootstrapFromPeerBytesPerSecond = 1 << 20
+	id, err := epc.StartNewProc(ctx, &newCfg, t, true /* addAsLearner */)
+	require.NoError(t, err)
+	member := epc.Procs[len(epc.Procs)-1]
+	_, err = member.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "bootstrapped from peer"})
+	require.NoError(t, err)
+	_, err = epc.Procs[0].Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "removed bootstrap snapshot"})
+	require.NoError(t, err)
+
+	t.Log("Promoting the new member once it caught up")
+	require.Eventually(t, func() bool {
+		_, err = epc.Etcdctl().MemberPromote(ctx, id)
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "failed to promote member: %v", err)
+	writeKVs(t, epc.Etcdctl(), 20, 30)
+	assertKVHash(t, epc)
+
+	t.Log("Restarting the new member from its WAL")
+	require.NoError(t, member.Restart(ctx))
+	resp, err := member.Etcdctl().Get(ctx, "key-", config.GetOptions{Prefix: true, Serializable: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 30)
+}
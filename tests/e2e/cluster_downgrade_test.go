@@ -27,7 +27,6 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/version"
-	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/etcdserver"
@@ -95,16 +94,16 @@ func TestDowngradeCancellationAfterDowngrading2InClusterOf3(t *testing.T) {
 func testDowngradeUpgrade(t *testing.T, numberOfMembersToDowngrade int, clusterSize int, triggerSnapshot bool, triggerCancellation CancellationState) {
 	currentEtcdBinary := e2e.BinPath.Etcd
 	lastReleaseBinary := e2e.BinPath.EtcdLastRelease
-	if !fileutil.Exist(lastReleaseBinary) {
+	if !e2e.LastReleaseAvailable() {
 		t.Skipf("%q does not exist", lastReleaseBinary)
 	}
 
-	currentVersion, err := e2e.GetVersionFromBinary(currentEtcdBinary)
+	currentVersion, err := e2e.BinaryVersion(e2e.DefaultRunner(), currentEtcdBinary)
 	require.NoError(t, err)
 	// wipe any pre-release suffix like -alpha.0 we see commonly in builds
 	currentVersion.PreRelease = ""
 
-	lastVersion, err := e2e.GetVersionFromBinary(lastReleaseBinary)
+	lastVersion, err := e2e.BinaryVersion(e2e.DefaultRunner(), lastReleaseBinary)
 	require.NoError(t, err)
 
 	require.Equalf(t, lastVersion.Minor, currentVersion.Minor-1, "unexpected minor version difference")
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"go.etcd.io/etcd/pkg/v3/expect"
 	"go.etcd.io/etcd/tests/v3/framework/e2e"
 )
 
@@ -57,3 +58,35 @@ func testShellCompletion(t *testing.T, binPath, shellName string) {
 	shellCmd := exec.Command(shellName, "-c", "source "+filename)
 	require.NoError(t, shellCmd.Run())
 }
+
+func TestCtlV3CompletionDynamic(t *testing.T) {
+	testCtl(t, completionDynamicTest, withCfg(*e2e.NewConfig(e2e.WithClusterSize(1))))
+}
+
+func completionDynamicTest(cx ctlCtx) {
+	for _, key := range []string{"foo1", "foo2", "bar"} {
+		require.NoError(cx.t, ctlV3Put(cx, key, "value", ""))
+	}
+	require.NoError(cx.t, ctlV3Role(cx, []string{"add", "completion-role"}, "Role completion-role created"))
+	require.NoError(cx.t, ctlV3User(cx, []string{"add", "completion-user", "--no-password"}, "User completion-user created", nil))
+
+	tests := []struct {
+		args   []string
+		expect []string
+	}{
+		{args: []string{"get", "fo"}, expect: []string{"foo1", "foo2"}},
+		{args: []string{"del", "foo1", "b"}, expect: []string{"bar"}},
+		{args: []string{"member", "remove", ""}, expect: []string{cx.epc.Procs[0].Config().Name}},
+		{args: []string{"user", "get", "completion-"}, expect: []string{"completion-user"}},
+		{args: []string{"user", "grant-role", "completion-user", ""}, expect: []string{"completion-role"}},
+	}
+	for _, tc := range tests {
+		cmdArgs := append(cx.PrefixArgs(), "__complete")
+		cmdArgs = append(cmdArgs, tc.args...)
+		var expects []expect.ExpectedResponse
+		for _, e := range tc.expect {
+			expects = append(expects, expect.ExpectedResponse{Value: e})
+		}
+		require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expects...), "completion of %v", tc.args)
+	}
+}
@@ -92,6 +92,14 @@ func testElect(cx ctlCtx) {
 			cx.t.Fatalf("expected different elect name, got l1=%q, l2=%q", l1, l2)
 		}
 	}
+
+	// the history lists the leaders, oldest first.
+	require.NoError(cx.t, e2e.SpawnWithExpects(append(cx.PrefixArgs(), "elect", "history", name), cx.envMap,
+		expect.ExpectedResponse{Value: "epoch"},
+		expect.ExpectedResponse{Value: "p1"},
+		expect.ExpectedResponse{Value: "epoch"},
+		expect.ExpectedResponse{Value: "p2"},
+	))
 }
 
 // ctlV3Elect creates a elect process with a channel listening for when it wins the election.
@@ -0,0 +1,42 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3EndpointStatusConsistencyCheck(t *testing.T) {
+	testCtl(t, endpointStatusConsistencyCheckTest, withQuorum())
+}
+
+func endpointStatusConsistencyCheckTest(cx ctlCtx) {
+	for i := 0; i < 5; i++ {
+		require.NoError(cx.t, ctlV3Put(cx, fmt.Sprintf("key%d", i), "value", ""))
+	}
+	require.NoError(cx.t, e2e.SpawnWithExpects(append(cx.PrefixArgs(), "compact", "3"), cx.envMap, expect.ExpectedResponse{Value: "compacted revision 3"}))
+
+	cmdArgs := append(cx.PrefixArgs(), "endpoint", "status", "--cluster", "--consistency-check")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "all endpoints are consistent at revision"}))
+
+	cmdArgs = append(cx.PrefixArgs(), "--write-out", "json", "endpoint", "status", "--cluster", "--consistency-check")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: `"compact_revision":3`}))
+}
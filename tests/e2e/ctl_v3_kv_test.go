@@ -45,6 +45,11 @@ func TestCtlV3GetCountOnly(t *testing.T)          { testCtl(t, getCountOnlyTest)
 
 func TestCtlV3DelTimeout(t *testing.T) { testCtl(t, delTest, withDefaultDialTimeout()) }
 
+func TestCtlV3Namespace(t *testing.T) { testCtl(t, namespaceTest) }
+
+func TestCtlV3CAS(t *testing.T)  { testCtl(t, casTest) }
+func TestCtlV3Incr(t *testing.T) { testCtl(t, incrTest) }
+
 func TestCtlV3GetRevokedCRL(t *testing.T) {
 	cfg := e2e.NewConfig(
 		e2e.WithClusterSize(1),
@@ -322,6 +327,53 @@ func delTest(cx ctlCtx) {
 	}
 }
 
+func namespaceTest(cx ctlCtx) {
+	require.NoError(cx.t, ctlV3Put(cx, "a", "v1", "", "--namespace", "/team-a/"))
+	require.NoError(cx.t, ctlV3Put(cx, "a", "v2", "", "--namespace", "/team-b/"))
+	require.NoError(cx.t, ctlV3Put(cx, "/team-ab", "v3", ""))
+
+	require.NoError(cx.t, ctlV3Get(cx, []string{"/team-a/a"}, kv{"/team-a/a", "v1"}))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"a", "--namespace", "/team-b/"}, kv{"a", "v2"}))
+	// the keys of other namespaces are out of the prefix of the namespace.
+	require.NoError(cx.t, ctlV3Get(cx, []string{"", "--prefix", "--namespace", "/team-a"}, kv{"/a", "v1"}, kv{"b", "v3"}))
+
+	require.NoError(cx.t, ctlV3Del(cx, []string{"", "--from-key", "--namespace", "/team-a/"}, 1))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"/team", "--prefix"}, kv{"/team-ab", "v3"}, kv{"/team-b/a", "v2"}))
+}
+
+func casTest(cx ctlCtx) {
+	cas := func(args ...string) []string {
+		return append(cx.PrefixArgs(), append([]string{"cas", "foo"}, args...)...)
+	}
+
+	require.NoError(cx.t, e2e.SpawnWithExpects(cas("--expect-mod-rev", "0", "--new-value", "bar"), cx.envMap,
+		expect.ExpectedResponse{Value: "OK"}, expect.ExpectedResponse{Value: "bar"}, expect.ExpectedResponse{Value: "2"}))
+	require.ErrorContains(cx.t, e2e.SpawnWithExpects(cas("--expect-value", "baz", "--new-value", "qux"), cx.envMap,
+		expect.ExpectedResponse{Value: "FAILED"}, expect.ExpectedResponse{Value: "bar"}, expect.ExpectedResponse{Value: "2"}),
+		"does not hold the expected value")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cas("--expect-value", "bar", "--new-value", "qux"), cx.envMap,
+		expect.ExpectedResponse{Value: "OK"}, expect.ExpectedResponse{Value: "qux"}, expect.ExpectedResponse{Value: "3"}))
+	require.NoError(cx.t, e2e.SpawnWithExpects(cas("--expect-mod-rev", "3", "--new-value", "quux"), cx.envMap,
+		expect.ExpectedResponse{Value: "OK"}, expect.ExpectedResponse{Value: "quux"}, expect.ExpectedResponse{Value: "4"}))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"foo"}, kv{"foo", "quux"}))
+}
+
+func incrTest(cx ctlCtx) {
+	incr := func(args ...string) []string {
+		return append(cx.PrefixArgs(), append([]string{"incr", "counter"}, args...)...)
+	}
+
+	require.NoError(cx.t, e2e.SpawnWithExpects(incr(), cx.envMap,
+		expect.ExpectedResponse{Value: "1"}, expect.ExpectedResponse{Value: "2"}))
+	require.NoError(cx.t, e2e.SpawnWithExpects(incr("--by", "10"), cx.envMap,
+		expect.ExpectedResponse{Value: "11"}, expect.ExpectedResponse{Value: "3"}))
+	require.NoError(cx.t, e2e.SpawnWithExpects(incr("--by", "-12"), cx.envMap,
+		expect.ExpectedResponse{Value: "-1"}, expect.ExpectedResponse{Value: "4"}))
+
+	require.NoError(cx.t, ctlV3Put(cx, "counter", "x", ""))
+	require.ErrorContains(cx.t, e2e.SpawnWithExpects(incr(), cx.envMap, expect.ExpectedResponse{Value: "5"}), "not an integer")
+}
+
 func ctlV3Put(cx ctlCtx, key, value, leaseID string, flags ...string) error {
 	skipValue := false
 	skipLease := false
@@ -41,6 +41,8 @@ func TestCtlV3LeaseKeepAlivePeerTLS(t *testing.T) {
 	testCtl(t, leaseTestKeepAlive, withCfg(*e2e.NewConfigPeerTLS()))
 }
 
+func TestCtlV3LeaseListDetails(t *testing.T) { testCtl(t, leaseTestListDetails) }
+
 func leaseTestKeepAlive(cx ctlCtx) {
 	// put with TTL 10 seconds and keep-alive
 	leaseID, err := ctlV3LeaseGrant(cx, 10)
@@ -58,8 +60,27 @@ func leaseTestKeepAlive(cx ctlCtx) {
 	}
 }
 
-func ctlV3LeaseGrant(cx ctlCtx, ttl int) (string, error) {
+func leaseTestListDetails(cx ctlCtx) {
+	leaseID, err := ctlV3LeaseGrant(cx, 10, "--label", "host=a", "--label", "pid=1")
+	if err != nil {
+		cx.t.Fatalf("leaseTestListDetails: ctlV3LeaseGrant error (%v)", err)
+	}
+	if err = ctlV3Put(cx, "key", "val", leaseID); err != nil {
+		cx.t.Fatalf("leaseTestListDetails: ctlV3Put error (%v)", err)
+	}
+	cmdArgs := append(cx.PrefixArgs(), "lease", "list")
+	if err = e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: "found 1 leases"},
+		expect.ExpectedResponse{Value: fmt.Sprintf("%s granted with TTL(10s), remaining(", leaseID)},
+		expect.ExpectedResponse{Value: "attached keys(1), labels(host=a,pid=1)"},
+	); err != nil {
+		cx.t.Fatalf("leaseTestListDetails: lease list error (%v)", err)
+	}
+}
+
+func ctlV3LeaseGrant(cx ctlCtx, ttl int, args ...string) (string, error) {
 	cmdArgs := append(cx.PrefixArgs(), "lease", "grant", strconv.Itoa(ttl))
+	cmdArgs = append(cmdArgs, args...)
 	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
 	if err != nil {
 		return "", err
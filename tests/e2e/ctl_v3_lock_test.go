@@ -36,6 +36,46 @@ func TestCtlV3LockWithCmd(t *testing.T) {
 	testCtl(t, testLockWithCmd)
 }
 
+// TestCtlV3LockPartition ensures a member cut off from the quorum can't
+// acquire a lock, and acquires it once the partition heals.
+func TestCtlV3LockPartition(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithPeerNetworkFaults())
+	require.NoError(t, err)
+	defer clus.Close()
+
+	leader := clus.WaitLeader(t)
+	isolated := clus.Procs[(leader+1)%len(clus.Procs)]
+	require.NoError(t, clus.Partition([]e2e.EtcdProcess{isolated}))
+
+	lock := func(member e2e.EtcdProcess) *expect.ExpectProcess {
+		proc, lerr := e2e.SpawnCmd([]string{e2e.BinPath.Etcdctl, "--endpoints", member.EndpointsGRPC()[0], "lock", "a"}, nil)
+		require.NoError(t, lerr)
+		return proc
+	}
+	isolatedLocker := lock(isolated)
+	defer isolatedLocker.Stop()
+	_, err = isolatedLocker.ExpectWithContext(ctx, expect.ExpectedResponse{Value: "a/", Timeout: 3 * time.Second})
+	require.ErrorIs(t, err, context.DeadlineExceeded, "isolated member should not acquire the lock")
+
+	holder := lock(clus.Procs[leader])
+	_, err = holder.ExpectWithContext(ctx, expect.ExpectedResponse{Value: "a/", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	require.NoError(t, clus.HealNetwork())
+	require.NoError(t, holder.Signal(os.Interrupt))
+	require.NoError(t, e2e.CloseWithTimeout(holder, 2*time.Second))
+	_, err = isolatedLocker.ExpectWithContext(ctx, expect.ExpectedResponse{Value: "a/", Timeout: 10 * time.Second})
+	require.NoError(t, err)
+}
+
+func TestCtlV3LockStatusBreak(t *testing.T) {
+	testCtl(t, testLockStatusBreak)
+}
+
 func testLock(cx ctlCtx) {
 	name := "a"
 
@@ -99,6 +139,42 @@ func testLock(cx ctlCtx) {
 	}
 }
 
+func testLockStatusBreak(cx ctlCtx) {
+	name := "a"
+	require.NoError(cx.t, ctlV3LockStatus(cx, name, expect.ExpectedResponse{Value: `lock "a" is not held`}))
+	require.ErrorContains(cx.t, ctlV3LockBreak(cx, name, expect.ExpectedResponse{Value: "lock is not held"}), "lock is not held")
+
+	holder, ch, err := ctlV3Lock(cx, name)
+	require.NoError(cx.t, err)
+	defer holder.Stop()
+	var l1 string
+	select {
+	case <-time.After(2 * time.Second):
+		cx.t.Fatalf("timed out locking")
+	case l := <-ch:
+		l1 = strings.TrimSpace(l)
+	}
+	waiter, ch, err := ctlV3Lock(cx, name)
+	require.NoError(cx.t, err)
+	defer waiter.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(cx.t, ctlV3LockStatus(cx, name,
+		expect.ExpectedResponse{Value: fmt.Sprintf(`lock "a" held by %s`, l1)},
+		expect.ExpectedResponse{Value: "waiter 1: a/"},
+	))
+	require.NoError(cx.t, ctlV3LockBreak(cx, name, expect.ExpectedResponse{Value: fmt.Sprintf(`lock "a" released from %s`, l1)}))
+
+	// the waiter acquires the lock while the holder still runs.
+	select {
+	case <-time.After(2 * time.Second):
+		cx.t.Fatalf("waiter did not acquire the broken lock")
+	case l2 := <-ch:
+		require.NotEqual(cx.t, l1, strings.TrimSpace(l2))
+		require.True(cx.t, strings.HasPrefix(l2, name))
+	}
+}
+
 func testLockWithCmd(cx ctlCtx) {
 	// exec command with zero exit code
 	echoCmd := []string{"echo"}
@@ -142,3 +218,13 @@ func ctlV3LockWithCmd(cx ctlCtx, execCmd []string, as ...expect.ExpectedResponse
 	defer cancel()
 	return e2e.SpawnWithExpectsContext(ctx, cmdArgs, cx.envMap, as...)
 }
+
+func ctlV3LockStatus(cx ctlCtx, name string, as ...expect.ExpectedResponse) error {
+	cmdArgs := append(cx.PrefixArgs(), "lock", "status", name)
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, as...)
+}
+
+func ctlV3LockBreak(cx ctlCtx, name string, as ...expect.ExpectedResponse) error {
+	cmdArgs := append(cx.PrefixArgs(), "lock", "break", name)
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, as...)
+}
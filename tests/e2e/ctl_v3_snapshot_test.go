@@ -70,6 +70,40 @@ func snapshotTest(cx ctlCtx) {
 	}
 }
 
+func TestCtlV3SnapshotSaveCluster(t *testing.T) {
+	testCtl(t, snapshotSaveClusterTest, withCfg(*e2e.NewConfig(e2e.WithClusterSize(3))), withQuorum())
+}
+
+func snapshotSaveClusterTest(cx ctlCtx) {
+	maintenanceInitKeys(cx)
+
+	dir := filepath.Join(cx.t.TempDir(), "snapshots")
+	cmdArgs := append(cx.PrefixArgs(), "snapshot", "save", "--cluster", dir)
+	var expected []expect.ExpectedResponse
+	for i := 0; i < cx.cfg.ClusterSize; i++ {
+		expected = append(expected, expect.ExpectedResponse{Value: "Snapshot of member"})
+	}
+	lines, err := e2e.SpawnWithExpectLines(context.TODO(), cmdArgs, cx.envMap, expected...)
+	require.NoError(cx.t, err)
+
+	var rev int64
+	_, err = fmt.Sscanf(lines[0][strings.Index(lines[0], "at revision"):], "at revision %d", &rev)
+	require.NoError(cx.t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	require.NoError(cx.t, err)
+	require.Len(cx.t, files, cx.cfg.ClusterSize)
+	var totalKey int
+	for _, fpath := range files {
+		st, err := getSnapshotStatus(cx, fpath)
+		require.NoError(cx.t, err)
+		assert.Equal(cx.t, rev, st.Revision)
+		if totalKey == 0 {
+			totalKey = st.TotalKey
+		}
+		assert.Equal(cx.t, totalKey, st.TotalKey)
+	}
+}
+
 func TestCtlV3SnapshotCorrupt(t *testing.T) { testCtl(t, snapshotCorruptTest) }
 
 func snapshotCorruptTest(cx ctlCtx) {
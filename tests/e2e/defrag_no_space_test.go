@@ -16,7 +16,6 @@ package e2e
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
@@ -57,7 +56,7 @@ func TestDefragNoSpace(t *testing.T) {
 
 			member := clus.Procs[0]
 
-			require.NoError(t, member.Failpoints().SetupHTTP(context.Background(), tc.failpoint, fmt.Sprintf(`return("%s")`, tc.err)))
+			require.NoError(t, clus.EnableFailpoint(context.Background(), tc.failpoint, e2e.FailpointReturn(tc.err), member))
 			require.ErrorContains(t, member.Etcdctl().Defragment(context.Background(), config.DefragOption{Timeout: time.Minute}), tc.err)
 
 			// Make sure etcd continues to run even after the failed defrag attempt
@@ -23,7 +23,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 	"go.etcd.io/etcd/tests/v3/framework/config"
 	"go.etcd.io/etcd/tests/v3/framework/e2e"
 )
@@ -40,7 +39,7 @@ func clusterTestCases(size int) []clusterTestCase {
 			config: e2e.NewConfig(e2e.WithClusterSize(size)),
 		},
 	}
-	if !fileutil.Exist(e2e.BinPath.EtcdLastRelease) {
+	if !e2e.LastReleaseAvailable() {
 		return tcs
 	}
 
@@ -84,7 +83,7 @@ func TestMixVersionsSnapshotByAddingMember(t *testing.T) {
 func mixVersionsSnapshotTestByAddingMember(t *testing.T, cfg *e2e.EtcdProcessClusterConfig, newInstanceVersion e2e.ClusterVersion) {
 	e2e.BeforeTest(t)
 
-	if !fileutil.Exist(e2e.BinPath.EtcdLastRelease) {
+	if !e2e.LastReleaseAvailable() {
 		t.Skipf("%q does not exist", e2e.BinPath.EtcdLastRelease)
 	}
 
@@ -126,7 +125,7 @@ func TestMixVersionsSnapshotByMockingPartition(t *testing.T) {
 func mixVersionsSnapshotTestByMockPartition(t *testing.T, cfg *e2e.EtcdProcessClusterConfig, mockPartitionNodeIndex int) {
 	e2e.BeforeTest(t)
 
-	if !fileutil.Exist(e2e.BinPath.EtcdLastRelease) {
+	if !e2e.LastReleaseAvailable() {
 		t.Skipf("%q does not exist", e2e.BinPath.EtcdLastRelease)
 	}
 
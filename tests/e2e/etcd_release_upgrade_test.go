@@ -25,7 +25,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.etcd.io/etcd/api/v3/version"
-	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 	"go.etcd.io/etcd/pkg/v3/expect"
 	"go.etcd.io/etcd/tests/v3/framework/e2e"
 )
@@ -33,7 +32,7 @@ import (
 // TestReleaseUpgrade ensures that changes to master branch does not affect
 // upgrade from latest etcd releases.
 func TestReleaseUpgrade(t *testing.T) {
-	if !fileutil.Exist(e2e.BinPath.EtcdLastRelease) {
+	if !e2e.LastReleaseAvailable() {
 		t.Skipf("%q does not exist", e2e.BinPath.EtcdLastRelease)
 	}
 
@@ -113,7 +112,7 @@ func TestReleaseUpgrade(t *testing.T) {
 }
 
 func TestReleaseUpgradeWithRestart(t *testing.T) {
-	if !fileutil.Exist(e2e.BinPath.EtcdLastRelease) {
+	if !e2e.LastReleaseAvailable() {
 		t.Skipf("%q does not exist", e2e.BinPath.EtcdLastRelease)
 	}
 
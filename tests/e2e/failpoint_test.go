@@ -0,0 +1,69 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestFailpointOrchestration(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithGoFailEnabled(true))
+	require.NoError(t, err)
+	defer clus.Close()
+
+	const failpoint = "beforeApplyOneEntryNormal"
+	fps, err := clus.Procs[0].Failpoints().List()
+	require.NoError(t, err)
+	require.Contains(t, fps, failpoint)
+	require.Empty(t, fps[failpoint])
+
+	term := e2e.FailpointSleep(time.Millisecond).Times(2)
+	require.NoError(t, clus.EnableFailpoint(ctx, failpoint, term, clus.Procs[0]))
+	fps, err = clus.Procs[0].Failpoints().List()
+	require.NoError(t, err)
+	require.Equal(t, string(term), fps[failpoint])
+
+	fired, err := clus.FailpointFired(ctx, failpoint)
+	require.NoError(t, err)
+	require.False(t, fired)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, clus.Etcdctl().Put(ctx, "foo", "bar", config.PutOptions{}))
+	}
+	require.NoError(t, clus.Procs[0].Failpoints().WaitFired(ctx, failpoint))
+	counts, err := clus.FailpointCounts(ctx, failpoint)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		clus.Procs[0].Config().Name: 2,
+		clus.Procs[1].Config().Name: 0,
+		clus.Procs[2].Config().Name: 0,
+	}, counts)
+
+	require.NoError(t, clus.DisableFailpoint(ctx, failpoint, clus.Procs[0]))
+	fps, err = clus.Procs[0].Failpoints().List()
+	require.NoError(t, err)
+	require.Empty(t, fps[failpoint])
+}
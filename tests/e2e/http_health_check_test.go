@@ -184,9 +184,15 @@ var defaultHealthCheckConfigs = []healthCheckConfig{
 		expectedRespSubStrings: []string{`ok`},
 	},
 	{
-		url:                    "/livez?verbose=true",
-		expectedStatusCode:     http.StatusOK,
-		expectedRespSubStrings: []string{`[+]serializable_read ok`},
+		url:                "/livez?verbose=true",
+		expectedStatusCode: http.StatusOK,
+		expectedRespSubStrings: []string{
+			`[+]serializable_read ok`,
+			`[+]wal_fsync ok`,
+			`[+]backend_commit ok`,
+			`[+]lessor ok`,
+			`[+]auth_store ok`,
+		},
 	},
 	{
 		url:                "/readyz?verbose=true",
@@ -194,8 +200,14 @@ var defaultHealthCheckConfigs = []healthCheckConfig{
 		expectedRespSubStrings: []string{
 			`[+]serializable_read ok`,
 			`[+]data_corruption ok`,
+			`[+]leader_present ok`,
 		},
 	},
+	{
+		url:                    "/readyz?format=json",
+		expectedStatusCode:     http.StatusOK,
+		expectedRespSubStrings: []string{`"status":"success"`, `{"name":"leader_present","status":"success"}`},
+	},
 }
 
 func TestHTTPLivezReadyzHandler(t *testing.T) {
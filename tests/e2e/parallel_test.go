@@ -0,0 +1,54 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+// TestParallelClusters ensures clusters with reserved ports can run
+// concurrently.
+func TestParallelClusters(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		t.Run(fmt.Sprintf("cluster-%d", i), func(t *testing.T) {
+			e2e.BeforeParallelTest(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			clus, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(3), e2e.WithReservedPorts())
+			require.NoError(t, err)
+			defer clus.Close()
+
+			cc := clus.Etcdctl()
+			require.NoError(t, cc.Put(ctx, "cluster", t.Name(), config.PutOptions{}))
+			resp, err := cc.Get(ctx, "cluster", config.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			require.Equal(t, t.Name(), string(resp.Kvs[0].Value))
+
+			members, err := cc.MemberList(ctx, false)
+			require.NoError(t, err)
+			require.Len(t, members.Members, 3)
+		})
+	}
+}
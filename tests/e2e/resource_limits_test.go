@@ -0,0 +1,71 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestResourceLimitsOOM(t *testing.T) {
+	e2e.BeforeTest(t)
+	requireCgroupV2(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	clus, err := e2e.NewEtcdProcessCluster(ctx, t,
+		e2e.WithClusterSize(1),
+		e2e.WithQuotaBackendBytes(8*1024*1024*1024),
+		e2e.WithResourceLimits(e2e.ResourceLimits{MemoryMax: 128 * 1024 * 1024}),
+	)
+	require.NoError(t, err)
+	defer clus.Close()
+	member := clus.Procs[0]
+
+	value := strings.Repeat("a", 1024*1024)
+	for i := 0; i < 1000 && member.IsRunning(); i++ {
+		if err = member.Etcdctl().Put(ctx, fmt.Sprintf("key-%d", i), value, config.PutOptions{}); err != nil {
+			break
+		}
+	}
+	require.Error(t, err, "expected the member to be OOM killed")
+	require.NoError(t, member.Wait(ctx))
+	kills, err := member.Cgroup().OOMKills()
+	require.NoError(t, err)
+	require.Positive(t, kills)
+}
+
+func requireCgroupV2(t *testing.T) {
+	root := os.Getenv(e2e.CgroupRootEnv)
+	if root == "" {
+		root = "/sys/fs/cgroup"
+	}
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		t.Skipf("cgroup v2 hierarchy not found at %q", root)
+	}
+	if os.Geteuid() != 0 {
+		t.Skip("managing cgroups requires root")
+	}
+}
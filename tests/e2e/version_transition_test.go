@@ -0,0 +1,46 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/config"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestVersionTransitionKV(t *testing.T) {
+	for _, tc := range e2e.VersionTransitions {
+		t.Run(tc.Name, func(t *testing.T) {
+			e2e.BeforeTest(t)
+			var puts int
+			tc.Run(t, func(t *testing.T, epc *e2e.EtcdProcessCluster) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				cc := epc.Etcdctl()
+				require.NoError(t, cc.Put(ctx, fmt.Sprintf("key-%d", puts), "value", config.PutOptions{}))
+				puts++
+				resp, err := cc.Get(ctx, "key-", config.GetOptions{Prefix: true, CountOnly: true})
+				require.NoError(t, err)
+				require.Equal(t, int64(puts), resp.Count)
+			}, e2e.WithClusterSize(3), e2e.WithSnapshotCount(10))
+		})
+	}
+}
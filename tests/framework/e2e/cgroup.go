@@ -0,0 +1,184 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+// CgroupRootEnv overrides the cgroup v2 hierarchy under which the cgroups of
+// the members are created, by default the root of /sys/fs/cgroup.
+const CgroupRootEnv = "ETCD_E2E_CGROUP_ROOT"
+
+// ResourceLimits are the limits of the cgroup a member runs in. Zero values
+// are unlimited.
+type ResourceLimits struct {
+	// MemoryMax is the memory, in bytes, above which the member is OOM killed.
+	// The member is not allowed to swap.
+	MemoryMax int64
+	// CPUMax is the number of CPUs the member can use, e.g. 0.5 for half a CPU.
+	CPUMax float64
+	// IOReadBPS and IOWriteBPS limit the throughput, in bytes per second, of
+	// the device holding the data dir of the member.
+	IOReadBPS  int64
+	IOWriteBPS int64
+	// IOReadIOPS and IOWriteIOPS limit the operations per second on the device
+	// holding the data dir of the member.
+	IOReadIOPS  int64
+	IOWriteIOPS int64
+}
+
+func (l ResourceLimits) files(dataDir string) (map[string]string, error) {
+	files := make(map[string]string)
+	if l.MemoryMax > 0 {
+		files["memory.max"] = strconv.FormatInt(l.MemoryMax, 10)
+		files["memory.swap.max"] = "0"
+	}
+	if l.CPUMax > 0 {
+		const period = 100000
+		files["cpu.max"] = fmt.Sprintf("%d %d", int64(l.CPUMax*period), period)
+	}
+	var io []string
+	for _, lim := range []struct {
+		key string
+		v   int64
+	}{{"rbps", l.IOReadBPS}, {"wbps", l.IOWriteBPS}, {"riops", l.IOReadIOPS}, {"wiops", l.IOWriteIOPS}} {
+		if lim.v > 0 {
+			io = append(io, fmt.Sprintf("%s=%d", lim.key, lim.v))
+		}
+	}
+	if len(io) > 0 {
+		var st unix.Stat_t
+		if err := unix.Stat(dataDir, &st); err != nil {
+			return nil, err
+		}
+		dev := uint64(st.Dev) //nolint:unconvert // the type of Dev depends on the platform
+		files["io.max"] = fmt.Sprintf("%d:%d %s", unix.Major(dev), unix.Minor(dev), strings.Join(io, " "))
+	}
+	return files, nil
+}
+
+// cgroupName returns the name of the cgroup of the member, unique to the test
+// process and to the cluster of the member through its peer port, which the
+// port registry keeps unique across the clusters running in parallel.
+func cgroupName(cfg *EtcdServerProcessConfig) string {
+	name := fmt.Sprintf("%d-%s-%s", os.Getpid(), cfg.PeerURL.Port(), cfg.Name)
+	return cgroupNameCleanRegex.ReplaceAllString(name, "_")
+}
+
+var cgroupNameCleanRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+func newCgroup(lg *zap.Logger, name, dataDir string, limits ResourceLimits) *Cgroup {
+	root := os.Getenv(CgroupRootEnv)
+	if root == "" {
+		root = "/sys/fs/cgroup"
+	}
+	return &Cgroup{
+		lg:      lg,
+		Path:    filepath.Join(root, "etcd-e2e-"+name),
+		DataDir: dataDir,
+		Limits:  limits,
+	}
+}
+
+// Cgroup is a cgroup v2 limiting the resources of a member, so that OOM and
+// slow disk scenarios can be reproduced. Managing cgroups requires root.
+type Cgroup struct {
+	lg *zap.Logger
+
+	Path    string
+	DataDir string
+	Limits  ResourceLimits
+}
+
+// Create creates the cgroup and applies its limits.
+func (cg *Cgroup) Create() error {
+	files, err := cg.Limits.files(cg.DataDir)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(filepath.Join(filepath.Dir(cg.Path), "cgroup.controllers")); err != nil {
+		return fmt.Errorf("cgroup v2 hierarchy not found at %q: %w", filepath.Dir(cg.Path), err)
+	}
+	// the controllers of the limits must be enabled in the parent.
+	controllers := make(map[string]bool)
+	for file := range files {
+		controllers[strings.Split(file, ".")[0]] = true
+	}
+	for controller := range controllers {
+		err = os.WriteFile(filepath.Join(filepath.Dir(cg.Path), "cgroup.subtree_control"), []byte("+"+controller), 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to enable the %s controller: %w", controller, err)
+		}
+	}
+	if err = os.Mkdir(cg.Path, 0o755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	cg.lg.Info("created cgroup", zap.String("path", cg.Path), zap.Any("limits", files))
+	for file, value := range files {
+		err = os.WriteFile(filepath.Join(cg.Path, file), []byte(value), 0o644)
+		// swap accounting is optional.
+		if file == "memory.swap.max" && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set %s to %q: %w", file, value, err)
+		}
+	}
+	return nil
+}
+
+// Wrap returns the command running args inside the cgroup. The process joins
+// the cgroup before executing args, so none of its allocations escape the
+// limits.
+func (cg *Cgroup) Wrap(args []string) []string {
+	return append([]string{"/bin/sh", "-c", `echo $$ > "$0" && exec "$@"`, filepath.Join(cg.Path, "cgroup.procs")}, args...)
+}
+
+// OOMKills returns the number of processes of the cgroup killed by the OOM
+// killer.
+func (cg *Cgroup) OOMKills() (int, error) {
+	b, err := os.ReadFile(filepath.Join(cg.Path, "memory.events"))
+	if err != nil {
+		return 0, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		if v, ok := strings.CutPrefix(s.Text(), "oom_kill "); ok {
+			return strconv.Atoi(v)
+		}
+	}
+	return 0, s.Err()
+}
+
+// Remove removes the cgroup, once its processes exited.
+func (cg *Cgroup) Remove() error {
+	err := os.Remove(cg.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestResourceLimitsFiles(t *testing.T) {
+	files, err := ResourceLimits{}.files(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	files, err = ResourceLimits{
+		MemoryMax:  64 << 20,
+		CPUMax:     0.5,
+		IOWriteBPS: 1 << 20,
+		IOReadIOPS: 100,
+	}.files(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "67108864", files["memory.max"])
+	assert.Equal(t, "0", files["memory.swap.max"])
+	assert.Equal(t, "50000 100000", files["cpu.max"])
+	assert.Regexp(t, regexp.MustCompile(`^\d+:\d+ wbps=1048576 riops=100$`), files["io.max"])
+}
+
+func TestCgroupCreate(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(CgroupRootEnv, root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu io memory"), 0o644))
+
+	cg := newCgroup(zaptest.NewLogger(t), "member-0", t.TempDir(), ResourceLimits{CPUMax: 2})
+	require.NoError(t, cg.Create())
+	b, err := os.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	require.NoError(t, err)
+	assert.Equal(t, "+cpu", string(b))
+	b, err = os.ReadFile(filepath.Join(root, "etcd-e2e-member-0", "cpu.max"))
+	require.NoError(t, err)
+	assert.Equal(t, "200000 100000", string(b))
+	assert.Equal(t, []string{"/bin/sh", "-c", `echo $$ > "$0" && exec "$@"`, filepath.Join(cg.Path, "cgroup.procs"), "etcd", "--name=m0"},
+		cg.Wrap([]string{"etcd", "--name=m0"}))
+
+	require.NoError(t, os.Remove(filepath.Join(cg.Path, "cpu.max")))
+	require.NoError(t, cg.Remove())
+	assert.NoDirExists(t, cg.Path)
+}
+
+func TestCgroupName(t *testing.T) {
+	cfg := &EtcdServerProcessConfig{Name: "TestFoo/bar-test-0", PeerURL: url.URL{Scheme: "http", Host: "localhost:20001"}}
+	assert.Equal(t, fmt.Sprintf("%d-20001-TestFoo_bar-test-0", os.Getpid()), cgroupName(cfg))
+	other := &EtcdServerProcessConfig{Name: cfg.Name, PeerURL: url.URL{Scheme: "http", Host: "localhost:20101"}}
+	assert.NotEqual(t, cgroupName(cfg), cgroupName(other), "members of parallel clusters should not share cgroups")
+}
+
+func TestCgroupCreateWithoutCgroupV2(t *testing.T) {
+	t.Setenv(CgroupRootEnv, t.TempDir())
+	cg := newCgroup(zaptest.NewLogger(t), "member-0", t.TempDir(), ResourceLimits{MemoryMax: 1 << 30})
+	require.ErrorContains(t, cg.Create(), "cgroup v2 hierarchy not found")
+}
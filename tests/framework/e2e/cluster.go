@@ -146,6 +146,16 @@ type EtcdProcessClusterConfig struct {
 	GoFailClientTimeout time.Duration
 	LazyFSEnabled       bool
 	PeerProxy           bool
+	// peerNetwork holds the network faults injected between the members,
+	// see WithPeerNetworkFaults.
+	peerNetwork *peerNetwork
+	// ResourceLimits runs the members in cgroups with the given limits.
+	ResourceLimits *ResourceLimits
+	// Runner runs the binaries of the members, DefaultRunner if nil.
+	Runner ProcessRunner
+	// ReservePorts reserves the ports of the members from Ports instead of
+	// using BasePort, so that clusters can be started concurrently.
+	ReservePorts bool
 
 	// Process config
 
@@ -351,6 +361,24 @@ func WithLazyFSEnabled(enabled bool) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.LazyFSEnabled = enabled }
 }
 
+// WithReservedPorts reserves the ports of the members from Ports, so that the
+// cluster can run concurrently with other clusters, e.g. in parallel tests.
+func WithReservedPorts() EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.ReservePorts = true }
+}
+
+// WithResourceLimits runs the members in cgroups limiting their memory, CPU
+// and disk IO. It requires root and a cgroup v2 hierarchy.
+func WithResourceLimits(limits ResourceLimits) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.ResourceLimits = &limits }
+}
+
+// WithRunner runs the binaries of the members with the given runner, e.g. a
+// ContainerRunner running them in containers.
+func WithRunner(r ProcessRunner) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.Runner = r }
+}
+
 func WithWarningUnaryRequestDuration(time time.Duration) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.WarningUnaryRequestDuration = time }
 }
@@ -443,6 +471,15 @@ func InitEtcdProcessCluster(t testing.TB, cfg *EtcdProcessClusterConfig) (*EtcdP
 	if cfg.Logger == nil {
 		cfg.Logger = zaptest.NewLogger(t)
 	}
+	if cfg.ReservePorts {
+		n := (cfg.ClusterSize + reservedSpareMembers) * reservedPortsPerMember
+		base, err := Ports.Reserve(n)
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(func() { Ports.Release(base, n) })
+		cfg.BasePort = base
+	}
 	if cfg.BasePort == 0 {
 		cfg.BasePort = EtcdProcessBasePort
 	}
@@ -452,10 +489,10 @@ func InitEtcdProcessCluster(t testing.TB, cfg *EtcdProcessClusterConfig) (*EtcdP
 
 	// validate SnapshotCatchUpEntries could be set for at least one member
 	if cfg.ServerConfig.SnapshotCatchUpEntries != etcdserver.DefaultSnapshotCatchUpEntries {
-		if !CouldSetSnapshotCatchupEntries(BinPath.Etcd) {
+		if !couldSetSnapshotCatchupEntries(BinaryVersion(cfg.runner(), BinPath.Etcd)) {
 			return nil, fmt.Errorf("cannot set SnapshotCatchUpEntries for current etcd version: %s", BinPath.Etcd)
 		}
-		if cfg.Version == LastVersion && !CouldSetSnapshotCatchupEntries(BinPath.EtcdLastRelease) {
+		if cfg.Version == LastVersion && !couldSetSnapshotCatchupEntries(BinaryVersion(cfg.runner(), BinPath.EtcdLastRelease)) {
 			return nil, fmt.Errorf("cannot set SnapshotCatchUpEntries for last etcd version: %s", BinPath.EtcdLastRelease)
 		}
 	}
@@ -547,7 +584,11 @@ func (cfg *EtcdProcessClusterConfig) SetInitialOrDiscovery(serverCfg *EtcdServer
 func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i int) *EtcdServerProcessConfig {
 	var curls []string
 	var curl string
-	port := cfg.BasePort + 5*i
+	portsPerMember := 5
+	if cfg.ReservePorts {
+		portsPerMember = reservedPortsPerMember
+	}
+	port := cfg.BasePort + portsPerMember*i
 	clientPort := port
 	peerPort := port + 1
 	metricsPort := port + 2
@@ -576,6 +617,14 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 			From:   peerAdvertiseURL,
 		}
 	}
+	var peerProxyCfg *peerProxyConfig
+	if cfg.peerNetwork != nil {
+		if cfg.PeerProxy || cfg.IsPeerTLS {
+			panic("Can't use peer network faults with the peer proxy or peer TLS as the members are identified from their plaintext requests")
+		}
+		peerAdvertiseURL.Host = fmt.Sprintf("localhost:%d", peer2Port)
+		peerProxyCfg = &peerProxyConfig{network: cfg.peerNetwork, from: peerAdvertiseURL, to: peerListenURL}
+	}
 
 	name := fmt.Sprintf("%s-test-%d", testNameCleanRegex.ReplaceAllString(tb.Name(), ""), i)
 
@@ -637,9 +686,10 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 	}
 
 	execPath := cfg.binaryPath(i)
+	runner := cfg.runner()
 
 	if cfg.ServerConfig.SnapshotCatchUpEntries != etcdserver.DefaultSnapshotCatchUpEntries {
-		if !IsSnapshotCatchupEntriesFlagAvailable(execPath) {
+		if !isSnapshotCatchupEntriesFlagAvailable(BinaryVersion(runner, execPath)) {
 			cfg.ServerConfig.ExperimentalSnapshotCatchUpEntries = cfg.ServerConfig.SnapshotCatchUpEntries
 			cfg.ServerConfig.SnapshotCatchUpEntries = etcdserver.DefaultSnapshotCatchUpEntries
 		}
@@ -651,7 +701,7 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 		if defaultValue := defaultValues[flag]; value == "" || value == defaultValue {
 			continue
 		}
-		if strings.HasSuffix(flag, "snapshot-catchup-entries") && !CouldSetSnapshotCatchupEntries(execPath) {
+		if strings.HasSuffix(flag, "snapshot-catchup-entries") && !couldSetSnapshotCatchupEntries(BinaryVersion(runner, execPath)) {
 			continue
 		}
 		args = append(args, fmt.Sprintf("--%s=%s", flag, value))
@@ -661,6 +711,9 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 	var gofailPort int
 	if cfg.GoFailEnabled {
 		gofailPort = (i+1)*10000 + 2381
+		if cfg.ReservePorts {
+			gofailPort = cfg.BasePort + reservedPortsPerMember*i + 5
+		}
 		envVars["GOFAIL_HTTP"] = fmt.Sprintf("127.0.0.1:%d", gofailPort)
 	}
 
@@ -682,8 +735,18 @@ func (cfg *EtcdProcessClusterConfig) EtcdServerProcessConfig(tb testing.TB, i in
 		GoFailPort:          gofailPort,
 		GoFailClientTimeout: cfg.GoFailClientTimeout,
 		Proxy:               proxyCfg,
+		peerProxy:           peerProxyCfg,
 		LazyFSEnabled:       cfg.LazyFSEnabled,
+		ResourceLimits:      cfg.ResourceLimits,
+		Runner:              runner,
+	}
+}
+
+func (cfg *EtcdProcessClusterConfig) runner() ProcessRunner {
+	if cfg.Runner != nil {
+		return cfg.Runner
 	}
+	return DefaultRunner()
 }
 
 func (cfg *EtcdProcessClusterConfig) binaryPath(i int) string {
@@ -715,7 +778,7 @@ func (cfg *EtcdProcessClusterConfig) binaryPath(i int) string {
 func (epc *EtcdProcessCluster) MinServerVersion() (*semver.Version, error) {
 	var minVersion *semver.Version
 	for _, member := range epc.Procs {
-		ver, err := GetVersionFromBinary(member.Config().ExecPath)
+		ver, err := BinaryVersion(member.Config().Runner, member.Config().ExecPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get version from member %s binary: %w", member.Config().Name, err)
 		}
@@ -57,6 +57,7 @@ type EtcdProcess interface {
 	PeerProxy() proxy.Server
 	Failpoints() *BinaryFailpoints
 	LazyFS() *LazyFS
+	Cgroup() *Cgroup
 	Logs() LogsExpect
 	Kill() error
 }
@@ -71,7 +72,9 @@ type EtcdServerProcess struct {
 	cfg        *EtcdServerProcessConfig
 	proc       *expect.ExpectProcess
 	proxy      proxy.Server
+	peerProxy  *peerProxy
 	lazyfs     *LazyFS
+	cgroup     *Cgroup
 	failpoints *BinaryFailpoints
 	donec      chan struct{} // closed when Interact() terminates
 }
@@ -99,14 +102,21 @@ type EtcdServerProcessConfig struct {
 	GoFailPort          int
 	GoFailClientTimeout time.Duration
 
-	LazyFSEnabled bool
-	Proxy         *proxy.ServerConfig
+	LazyFSEnabled  bool
+	ResourceLimits *ResourceLimits
+	Proxy          *proxy.ServerConfig
+	peerProxy      *peerProxyConfig
+	// Runner runs the binary of the member, on the host if nil.
+	Runner ProcessRunner
 }
 
 func NewEtcdServerProcess(t testing.TB, cfg *EtcdServerProcessConfig) (*EtcdServerProcess, error) {
-	if !fileutil.Exist(cfg.ExecPath) {
+	if cfg.Runner == nil && !fileutil.Exist(cfg.ExecPath) {
 		return nil, fmt.Errorf("could not find etcd binary: %s", cfg.ExecPath)
 	}
+	if cfg.Runner != nil && (cfg.LazyFSEnabled || cfg.ResourceLimits != nil) {
+		return nil, errors.New("lazyfs and resource limits are not supported with a process runner")
+	}
 	if !cfg.KeepDataDir {
 		if err := os.RemoveAll(cfg.DataDirPath); err != nil {
 			return nil, err
@@ -125,6 +135,14 @@ func NewEtcdServerProcess(t testing.TB, cfg *EtcdServerProcessConfig) (*EtcdServ
 	if cfg.LazyFSEnabled {
 		ep.lazyfs = newLazyFS(cfg.lg, cfg.DataDirPath, t)
 	}
+	if cfg.ResourceLimits != nil {
+		ep.cgroup = newCgroup(cfg.lg, cgroupName(cfg), cfg.DataDirPath, *cfg.ResourceLimits)
+		t.Cleanup(func() {
+			if err := ep.cgroup.Remove(); err != nil {
+				t.Logf("failed to remove cgroup %s: %v", ep.cgroup.Path, err)
+			}
+		})
+	}
 	return ep, nil
 }
 
@@ -159,6 +177,13 @@ func (ep *EtcdServerProcess) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if ep.cfg.peerProxy != nil && ep.peerProxy == nil {
+		ep.cfg.lg.Info("starting peer network proxy...", zap.String("name", ep.cfg.Name), zap.String("from", ep.cfg.peerProxy.from.String()), zap.String("to", ep.cfg.peerProxy.to.String()))
+		var err error
+		if ep.peerProxy, err = startPeerProxy(ep.cfg.lg, ep.cfg.Name, ep.cfg.peerProxy); err != nil {
+			return err
+		}
+	}
 	if ep.lazyfs != nil {
 		ep.cfg.lg.Info("starting lazyfs...", zap.String("name", ep.cfg.Name))
 		err := ep.lazyfs.Start(ctx)
@@ -167,8 +192,20 @@ func (ep *EtcdServerProcess) Start(ctx context.Context) error {
 		}
 	}
 
+	args := append([]string{ep.cfg.ExecPath}, ep.cfg.Args...)
+	if ep.cfg.Runner != nil {
+		args = ep.cfg.Runner.Command(ep.cfg, ep.cfg.Args)
+	}
+	if ep.cgroup != nil {
+		ep.cfg.lg.Info("creating cgroup...", zap.String("name", ep.cfg.Name))
+		if err := ep.cgroup.Create(); err != nil {
+			return err
+		}
+		args = ep.cgroup.Wrap(args)
+	}
+
 	ep.cfg.lg.Info("starting server...", zap.String("name", ep.cfg.Name))
-	proc, err := SpawnCmdWithLogger(ep.cfg.lg, append([]string{ep.cfg.ExecPath}, ep.cfg.Args...), ep.cfg.EnvVars, ep.cfg.Name)
+	proc, err := SpawnCmdWithLogger(ep.cfg.lg, args, ep.cfg.EnvVars, ep.cfg.Name)
 	if err != nil {
 		return err
 	}
@@ -228,6 +265,14 @@ func (ep *EtcdServerProcess) Stop() (err error) {
 			return err
 		}
 	}
+	if ep.peerProxy != nil {
+		ep.cfg.lg.Info("stopping peer network proxy...", zap.String("name", ep.cfg.Name))
+		err = ep.peerProxy.Close()
+		ep.peerProxy = nil
+		if err != nil {
+			return err
+		}
+	}
 	if ep.lazyfs != nil {
 		ep.cfg.lg.Info("stopping lazyfs...", zap.String("name", ep.cfg.Name))
 		err = ep.lazyfs.Stop()
@@ -244,6 +289,12 @@ func (ep *EtcdServerProcess) Close() error {
 	if err := ep.Stop(); err != nil {
 		return err
 	}
+	if ep.cgroup != nil {
+		ep.cfg.lg.Info("removing cgroup", zap.String("path", ep.cgroup.Path))
+		if err := ep.cgroup.Remove(); err != nil {
+			return err
+		}
+	}
 
 	if !ep.cfg.KeepDataDir {
 		ep.cfg.lg.Info("removing directory", zap.String("data-dir", ep.cfg.DataDirPath))
@@ -272,6 +323,11 @@ func (ep *EtcdServerProcess) Logs() LogsExpect {
 
 func (ep *EtcdServerProcess) Kill() error {
 	ep.cfg.lg.Info("killing server...", zap.String("name", ep.cfg.Name))
+	if ep.cfg.Runner != nil {
+		if err := ep.cfg.Runner.Kill(ep.cfg); err != nil {
+			return err
+		}
+	}
 	return ep.proc.Signal(syscall.SIGKILL)
 }
 
@@ -337,6 +393,10 @@ func (ep *EtcdServerProcess) LazyFS() *LazyFS {
 	return ep.lazyfs
 }
 
+func (ep *EtcdServerProcess) Cgroup() *Cgroup {
+	return ep.cgroup
+}
+
 func (ep *EtcdServerProcess) Failpoints() *BinaryFailpoints {
 	return ep.failpoints
 }
@@ -356,47 +416,34 @@ func (f *BinaryFailpoints) SetupEnv(failpoint, payload string) error {
 }
 
 func (f *BinaryFailpoints) SetupHTTP(ctx context.Context, failpoint, payload string) error {
-	host := fmt.Sprintf("127.0.0.1:%d", f.member.Config().GoFailPort)
-	failpointURL := url.URL{
-		Scheme: "http",
-		Host:   host,
-		Path:   failpoint,
-	}
-	r, err := http.NewRequestWithContext(ctx, http.MethodPut, failpointURL.String(), bytes.NewBuffer([]byte(payload)))
+	resp, err := f.do(ctx, http.MethodPut, failpoint, bytes.NewBuffer([]byte(payload)))
 	if err != nil {
 		return err
 	}
-	httpClient := http.Client{
-		Timeout: 1 * time.Second,
-	}
-	if f.clientTimeout != 0 {
-		httpClient.Timeout = f.clientTimeout
-	}
-	resp, err := httpClient.Do(r)
+	defer resp.Body.Close()
+	return checkFailpointStatus(resp, http.StatusNoContent)
+}
+
+func (f *BinaryFailpoints) DeactivateHTTP(ctx context.Context, failpoint string) error {
+	resp, err := f.do(ctx, http.MethodDelete, failpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusNoContent {
-		errMsg, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("bad status code: %d, err: %w", resp.StatusCode, err)
-		}
-		return fmt.Errorf("bad status code: %d, err: %s", resp.StatusCode, errMsg)
-	}
-	return nil
+	return checkFailpointStatus(resp, http.StatusNoContent)
 }
 
-func (f *BinaryFailpoints) DeactivateHTTP(ctx context.Context, failpoint string) error {
+// do sends a request to the gofail HTTP endpoint of the member.
+func (f *BinaryFailpoints) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	host := fmt.Sprintf("127.0.0.1:%d", f.member.Config().GoFailPort)
 	failpointURL := url.URL{
 		Scheme: "http",
 		Host:   host,
-		Path:   failpoint,
+		Path:   path,
 	}
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, failpointURL.String(), nil)
+	r, err := http.NewRequestWithContext(ctx, method, failpointURL.String(), body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	httpClient := http.Client{
 		Timeout: time.Second,
@@ -404,12 +451,11 @@ func (f *BinaryFailpoints) DeactivateHTTP(ctx context.Context, failpoint string)
 	if f.clientTimeout != 0 {
 		httpClient.Timeout = f.clientTimeout
 	}
-	resp, err := httpClient.Do(r)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusNoContent {
+	return httpClient.Do(r)
+}
+
+func checkFailpointStatus(resp *http.Response, expected int) error {
+	if resp.StatusCode != expected {
 		errMsg, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return fmt.Errorf("bad status code: %d, err: %w", resp.StatusCode, err)
@@ -495,7 +541,12 @@ var GetVersionFromBinary = func(binaryPath string) (*semver.Version, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not find binary version from %s, err: %w", binaryPath, err)
 	}
+	return parseVersion(binaryPath, lines)
+}
 
+// parseVersion parses the version from the "--version" output of an etcd
+// binary.
+func parseVersion(binaryPath string, lines []string) (*semver.Version, error) {
 	for _, line := range lines {
 		if strings.HasPrefix(line, "etcd Version:") {
 			versionString := strings.TrimSpace(strings.SplitAfter(line, ":")[1])
@@ -524,7 +575,10 @@ func setGetVersionFromBinary(tb testing.TB, f func(binaryPath string) (*semver.V
 }
 
 func CouldSetSnapshotCatchupEntries(execPath string) bool {
-	v, err := GetVersionFromBinary(execPath)
+	return couldSetSnapshotCatchupEntries(GetVersionFromBinary(execPath))
+}
+
+func couldSetSnapshotCatchupEntries(v *semver.Version, err error) bool {
 	if err != nil {
 		return false
 	}
@@ -534,7 +588,10 @@ func CouldSetSnapshotCatchupEntries(execPath string) bool {
 }
 
 func IsSnapshotCatchupEntriesFlagAvailable(execPath string) bool {
-	v, err := GetVersionFromBinary(execPath)
+	return isSnapshotCatchupEntriesFlagAvailable(GetVersionFromBinary(execPath))
+}
+
+func isSnapshotCatchupEntriesFlagAvailable(v *semver.Version, err error) bool {
 	if err != nil {
 		return false
 	}
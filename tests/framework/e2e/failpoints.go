@@ -0,0 +1,181 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FailpointTerm is a gofail term expression, describing what an enabled
+// failpoint does when executed.
+type FailpointTerm string
+
+// FailpointOff is the term of a failpoint doing nothing.
+const FailpointOff FailpointTerm = "off"
+
+// FailpointPanic returns the term of a failpoint panicking.
+func FailpointPanic() FailpointTerm { return "panic" }
+
+// FailpointSleep returns the term of a failpoint sleeping for d.
+func FailpointSleep(d time.Duration) FailpointTerm {
+	return FailpointTerm(fmt.Sprintf("sleep(%q)", d.String()))
+}
+
+// FailpointReturn returns the term of a failpoint returning v, a string,
+// an integer or a boolean.
+func FailpointReturn(v any) FailpointTerm {
+	if s, ok := v.(string); ok {
+		return FailpointTerm(fmt.Sprintf("return(%q)", s))
+	}
+	return FailpointTerm(fmt.Sprintf("return(%v)", v))
+}
+
+// Times limits the term to its first n executions.
+func (t FailpointTerm) Times(n int) FailpointTerm {
+	return FailpointTerm(fmt.Sprintf("%d*%s", n, t))
+}
+
+// Probability makes the term apply to the given percentage of executions.
+func (t FailpointTerm) Probability(percent float64) FailpointTerm {
+	return FailpointTerm(strconv.FormatFloat(percent, 'f', -1, 64) + "%" + string(t))
+}
+
+// Then chains the next term, which applies once the term is exhausted.
+func (t FailpointTerm) Then(next FailpointTerm) FailpointTerm {
+	return t + "->" + next
+}
+
+// List returns the terms of the failpoints of the member, by name. The term
+// of the failpoints that are not enabled is empty.
+func (f *BinaryFailpoints) List() (map[string]string, error) {
+	fps, err := failpoints(f.member)
+	if err != nil {
+		return nil, err
+	}
+	delete(fps, "")
+	return fps, nil
+}
+
+// Enable enables the failpoint with the given term.
+func (f *BinaryFailpoints) Enable(ctx context.Context, failpoint string, term FailpointTerm) error {
+	return f.SetupHTTP(ctx, failpoint, string(term))
+}
+
+// Disable disables the failpoint.
+func (f *BinaryFailpoints) Disable(ctx context.Context, failpoint string) error {
+	return f.DeactivateHTTP(ctx, failpoint)
+}
+
+// Count returns the number of times the failpoint was executed since it was
+// enabled, or 0 if it is not enabled.
+func (f *BinaryFailpoints) Count(ctx context.Context, failpoint string) (int, error) {
+	resp, err := f.do(ctx, http.MethodGet, failpoint+"/count", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// gofail has no counter for disabled failpoints.
+		if strings.Contains(string(b), "failpoint is disabled") {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("bad status code: %d, err: %s", resp.StatusCode, b)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
+
+// WaitFired waits until the failpoint is executed.
+func (f *BinaryFailpoints) WaitFired(ctx context.Context, failpoint string) error {
+	for {
+		count, err := f.Count(ctx, failpoint)
+		// the process may be restarting after a panic failpoint.
+		if err == nil && count > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failpoint %q not fired: %w (last error: %v)", failpoint, ctx.Err(), err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// EnableFailpoint enables the failpoint with the given term on the given
+// members, or on all members if none is given.
+func (epc *EtcdProcessCluster) EnableFailpoint(ctx context.Context, failpoint string, term FailpointTerm, members ...EtcdProcess) error {
+	return forEachFailpoints(epc, members, func(m EtcdProcess) error {
+		return m.Failpoints().Enable(ctx, failpoint, term)
+	})
+}
+
+// DisableFailpoint disables the failpoint on the given members, or on all
+// members if none is given.
+func (epc *EtcdProcessCluster) DisableFailpoint(ctx context.Context, failpoint string, members ...EtcdProcess) error {
+	return forEachFailpoints(epc, members, func(m EtcdProcess) error {
+		return m.Failpoints().Disable(ctx, failpoint)
+	})
+}
+
+// FailpointCounts returns the number of times the failpoint was executed on
+// the given members, or on all members if none is given, by member name.
+func (epc *EtcdProcessCluster) FailpointCounts(ctx context.Context, failpoint string, members ...EtcdProcess) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := forEachFailpoints(epc, members, func(m EtcdProcess) error {
+		count, err := m.Failpoints().Count(ctx, failpoint)
+		counts[m.Config().Name] = count
+		return err
+	})
+	return counts, err
+}
+
+// FailpointFired returns whether the failpoint was executed on any of the
+// given members, or of all members if none is given.
+func (epc *EtcdProcessCluster) FailpointFired(ctx context.Context, failpoint string, members ...EtcdProcess) (bool, error) {
+	counts, err := epc.FailpointCounts(ctx, failpoint, members...)
+	if err != nil {
+		return false, err
+	}
+	for _, count := range counts {
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func forEachFailpoints(epc *EtcdProcessCluster, members []EtcdProcess, f func(EtcdProcess) error) error {
+	if len(members) == 0 {
+		members = epc.Procs
+	}
+	for _, m := range members {
+		if m.Failpoints() == nil {
+			return fmt.Errorf("member %q has no failpoints, start the cluster with WithGoFailEnabled", m.Config().Name)
+		}
+		if err := f(m); err != nil {
+			return fmt.Errorf("member %q: %w", m.Config().Name, err)
+		}
+	}
+	return nil
+}
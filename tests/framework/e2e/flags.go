@@ -72,6 +72,7 @@ func InitFlags() {
 	binLastRelease := flag.String("bin-last-release", "", "The path for the last release etcd binary.")
 
 	flag.StringVar(&CertDir, "cert-dir", certDirDef, "The directory for store certificate files.")
+	flag.StringVar(&LastReleaseImage, "image-last-release", "", "The container image run in place of the last release etcd binary if it does not exist.")
 	flag.Parse()
 
 	BinPath = binPath{
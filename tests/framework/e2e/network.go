@@ -0,0 +1,434 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithPeerNetworkFaults routes the peer traffic of the members through
+// proxies telling which member each peer connection comes from, so that
+// network faults can be injected between members with Partition,
+// InjectPeerLatency and InjectPeerConnectionLoss. The proxies identify the
+// members from the headers of their peer requests, which requires plaintext
+// peer traffic.
+func WithPeerNetworkFaults() EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) {
+		c.peerNetwork = newPeerNetwork()
+		c.IsPeerTLS = false
+	}
+}
+
+// Partition cuts the peer traffic between the given groups of members in
+// both directions, until HealNetwork is called. The members of a group keep
+// communicating with each other. A single group is cut off from all the other
+// members of the cluster.
+func (epc *EtcdProcessCluster) Partition(groups ...[]EtcdProcess) error {
+	network, err := epc.peerNetwork()
+	if err != nil {
+		return err
+	}
+	if len(groups) == 1 {
+		var rest []EtcdProcess
+		for _, p := range epc.Procs {
+			if !slices.Contains(groups[0], p) {
+				rest = append(rest, p)
+			}
+		}
+		groups = append(groups, rest)
+	}
+	var names [][]string
+	for _, group := range groups {
+		names = append(names, memberNames(group))
+	}
+	network.partition(names...)
+	return nil
+}
+
+// InjectPeerLatency delays the peer traffic from and to the given members by
+// latency, randomized by up to jitter.
+func (epc *EtcdProcessCluster) InjectPeerLatency(latency, jitter time.Duration, members ...EtcdProcess) error {
+	network, err := epc.peerNetwork()
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return errors.New("no member given")
+	}
+	network.injectLatency(latency, jitter, memberNames(members))
+	return nil
+}
+
+// InjectPeerConnectionLoss drops the given percentage of the peer connections
+// from and to the given members. Whole connections are dropped, as dropping
+// some of the packets of a TCP stream corrupts it: the established
+// connections are closed, and the given percentage of the connections the
+// members then open is closed once accepted.
+func (epc *EtcdProcessCluster) InjectPeerConnectionLoss(percent int, members ...EtcdProcess) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("invalid connection loss percentage %d", percent)
+	}
+	network, err := epc.peerNetwork()
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return errors.New("no member given")
+	}
+	network.injectConnectionLoss(percent, memberNames(members))
+	return nil
+}
+
+// HealNetwork removes the network faults injected in the peer traffic of the
+// given members, or of all members if none is given.
+func (epc *EtcdProcessCluster) HealNetwork(members ...EtcdProcess) error {
+	network, err := epc.peerNetwork()
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		members = epc.Procs
+	}
+	network.heal(memberNames(members))
+	return nil
+}
+
+func (epc *EtcdProcessCluster) peerNetwork() (*peerNetwork, error) {
+	if epc.Cfg.peerNetwork == nil {
+		return nil, errors.New("the cluster has no peer network proxies, start it with WithPeerNetworkFaults")
+	}
+	return epc.Cfg.peerNetwork, nil
+}
+
+func memberNames(members []EtcdProcess) []string {
+	names := make([]string, 0, len(members))
+	for _, m := range members {
+		names = append(names, m.Config().Name)
+	}
+	return names
+}
+
+// peerNetwork holds the network faults injected between the members, and the
+// peer connections they apply to.
+type peerNetwork struct {
+	mu sync.Mutex
+	// members maps the advertised peer URLs of the members to their names.
+	members map[string]string
+	// blocked holds the pairs of members whose traffic is cut.
+	blocked map[peerPair]struct{}
+	latency map[string]peerLatency
+	// connLoss maps the members to the percentage of their connections dropped.
+	connLoss map[string]int
+	conns    map[*peerConn]struct{}
+}
+
+// peerPair is an unordered pair of members, the traffic between them flowing
+// on connections opened by either of them.
+type peerPair struct{ a, b string }
+
+func newPeerPair(a, b string) peerPair {
+	if a > b {
+		a, b = b, a
+	}
+	return peerPair{a: a, b: b}
+}
+
+type peerLatency struct {
+	latency, jitter time.Duration
+}
+
+// peerConn is a peer connection opened by member from to member to. from is
+// empty for the requests that don't identify their sender, e.g. lease renewals
+// forwarded to the leader.
+type peerConn struct {
+	from, to string
+	in, out  net.Conn
+}
+
+func newPeerNetwork() *peerNetwork {
+	return &peerNetwork{
+		members:  make(map[string]string),
+		blocked:  make(map[peerPair]struct{}),
+		latency:  make(map[string]peerLatency),
+		connLoss: make(map[string]int),
+		conns:    make(map[*peerConn]struct{}),
+	}
+}
+
+func (n *peerNetwork) register(peerURL url.URL, name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.members[peerURL.String()] = name
+}
+
+// sender returns the name of the member whose advertised peer URLs are given,
+// or an empty string if they are not known.
+func (n *peerNetwork) sender(peerURLs string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, u := range strings.Split(peerURLs, ",") {
+		if name, ok := n.members[u]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func (n *peerNetwork) partition(groups ...[]string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := range groups {
+		for j := i + 1; j < len(groups); j++ {
+			for _, a := range groups[i] {
+				for _, b := range groups[j] {
+					n.blocked[newPeerPair(a, b)] = struct{}{}
+				}
+			}
+		}
+	}
+	n.closeConnsLocked(func(c *peerConn) bool { return n.isBlockedLocked(c) })
+}
+
+func (n *peerNetwork) injectLatency(latency, jitter time.Duration, members []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, m := range members {
+		n.latency[m] = peerLatency{latency: latency, jitter: jitter}
+	}
+}
+
+func (n *peerNetwork) injectConnectionLoss(percent int, members []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, m := range members {
+		n.connLoss[m] = percent
+	}
+	n.closeConnsLocked(func(c *peerConn) bool {
+		return slices.Contains(members, c.from) || slices.Contains(members, c.to)
+	})
+}
+
+func (n *peerNetwork) heal(members []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for p := range n.blocked {
+		if slices.Contains(members, p.a) || slices.Contains(members, p.b) {
+			delete(n.blocked, p)
+		}
+	}
+	for _, m := range members {
+		delete(n.latency, m)
+		delete(n.connLoss, m)
+	}
+}
+
+// admit registers the connection, unless it has to be dropped.
+func (n *peerNetwork) admit(c *peerConn) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.isBlockedLocked(c) {
+		return false
+	}
+	if loss := max(n.connLoss[c.from], n.connLoss[c.to]); loss > 0 && rand.Intn(100) < loss {
+		return false
+	}
+	n.conns[c] = struct{}{}
+	return true
+}
+
+func (n *peerNetwork) release(c *peerConn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.conns, c)
+}
+
+// isBlockedLocked returns true if the traffic of the connection is cut. The
+// connections of unknown senders are cut while their receiver is partitioned
+// from any member, as they may come from across the partition.
+func (n *peerNetwork) isBlockedLocked(c *peerConn) bool {
+	if c.from != "" {
+		_, ok := n.blocked[newPeerPair(c.from, c.to)]
+		return ok
+	}
+	for p := range n.blocked {
+		if p.a == c.to || p.b == c.to {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *peerNetwork) closeConnsLocked(match func(*peerConn) bool) {
+	for c := range n.conns {
+		if match(c) {
+			c.in.Close()
+			c.out.Close()
+			delete(n.conns, c)
+		}
+	}
+}
+
+// delay returns the latency of the traffic of the connection.
+func (n *peerNetwork) delay(c *peerConn) time.Duration {
+	n.mu.Lock()
+	from, to := n.latency[c.from], n.latency[c.to]
+	n.mu.Unlock()
+	l := from
+	if to.latency+to.jitter > l.latency+l.jitter {
+		l = to
+	}
+	if l.latency <= 0 {
+		return 0
+	}
+	d := l.latency
+	if l.jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(2*l.jitter))) - l.jitter
+	}
+	return max(d, 0)
+}
+
+// peerProxy forwards the peer connections of a member, dropping or delaying
+// them according to the faults injected in the peer network.
+type peerProxy struct {
+	lg      *zap.Logger
+	network *peerNetwork
+	name    string
+	to      url.URL
+	ln      net.Listener
+	wg      sync.WaitGroup
+
+	mu sync.Mutex
+	// accepted are the connections accepted and not closed yet.
+	accepted map[net.Conn]struct{}
+}
+
+// peerProxyConfig configures the peer proxy of a member, listening on its
+// advertised peer URL and forwarding to its peer listener.
+type peerProxyConfig struct {
+	network  *peerNetwork
+	from, to url.URL
+}
+
+func startPeerProxy(lg *zap.Logger, name string, cfg *peerProxyConfig) (*peerProxy, error) {
+	ln, err := net.Listen("tcp", cfg.from.Host)
+	if err != nil {
+		return nil, err
+	}
+	cfg.network.register(cfg.from, name)
+	p := &peerProxy{lg: lg, network: cfg.network, name: name, to: cfg.to, ln: ln, accepted: make(map[net.Conn]struct{})}
+	p.wg.Add(1)
+	go p.serve()
+	return p, nil
+}
+
+func (p *peerProxy) serve() {
+	defer p.wg.Done()
+	for {
+		in, err := p.ln.Accept()
+		if err != nil {
+			return
+		}
+		p.mu.Lock()
+		p.accepted[in] = struct{}{}
+		p.mu.Unlock()
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.handle(in)
+			p.mu.Lock()
+			delete(p.accepted, in)
+			p.mu.Unlock()
+		}()
+	}
+}
+
+func (p *peerProxy) handle(in net.Conn) {
+	defer in.Close()
+	// peer requests identify their sender with its advertised peer URLs.
+	// The bytes read to parse the first request are forwarded as is, and a
+	// connection only carries the requests of a single member.
+	var head bytes.Buffer
+	req, err := http.ReadRequest(bufio.NewReader(io.TeeReader(in, &head)))
+	if err != nil {
+		return
+	}
+	out, err := net.DialTimeout("tcp", p.to.Host, time.Second)
+	if err != nil {
+		p.lg.Debug("failed to dial peer listener", zap.String("name", p.name), zap.Error(err))
+		return
+	}
+	defer out.Close()
+	c := &peerConn{from: p.network.sender(req.Header.Get("X-PeerURLs")), to: p.name, in: in, out: out}
+	if !p.network.admit(c) {
+		return
+	}
+	defer p.network.release(c)
+	if _, err = out.Write(head.Bytes()); err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.copy(c, out, in)
+		out.Close()
+	}()
+	p.copy(c, in, out)
+	in.Close()
+	<-done
+}
+
+func (p *peerProxy) copy(c *peerConn, dst io.Writer, src io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if d := p.network.delay(c); d > 0 {
+				time.Sleep(d)
+			}
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (p *peerProxy) Close() error {
+	err := p.ln.Close()
+	p.mu.Lock()
+	for in := range p.accepted {
+		in.Close()
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPeerNetworkPartition(t *testing.T) {
+	n := newPeerNetwork()
+	admitted := func(from, to string) bool {
+		c := &peerConn{from: from, to: to}
+		ok := n.admit(c)
+		n.release(c)
+		return ok
+	}
+
+	n.partition([]string{"a"}, []string{"b", "c"})
+	assert.False(t, admitted("a", "b"))
+	assert.False(t, admitted("b", "a"), "the traffic is cut in both directions")
+	assert.False(t, admitted("c", "a"))
+	assert.True(t, admitted("b", "c"), "members of a group keep communicating")
+	assert.False(t, admitted("", "a"), "unknown senders may come from across the partition")
+	assert.False(t, admitted("", "b"))
+
+	n.heal([]string{"b"})
+	assert.True(t, admitted("a", "b"))
+	assert.False(t, admitted("a", "c"))
+	n.heal([]string{"a", "b", "c"})
+	assert.True(t, admitted("", "a"))
+
+	n.injectConnectionLoss(100, []string{"a"})
+	assert.False(t, admitted("b", "a"))
+	assert.False(t, admitted("a", "c"))
+	assert.True(t, admitted("b", "c"))
+}
+
+func TestPeerProxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	to, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	n := newPeerNetwork()
+	n.register(url.URL{Scheme: "http", Host: "localhost:1"}, "a")
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	from := url.URL{Scheme: "http", Host: l.Addr().String()}
+	require.NoError(t, l.Close())
+	p, err := startPeerProxy(zaptest.NewLogger(t), "b", &peerProxyConfig{network: n, from: from, to: *to})
+	require.NoError(t, err)
+	defer p.Close()
+
+	get := func() error {
+		req, err := http.NewRequest(http.MethodGet, from.String(), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-PeerURLs", "http://localhost:1")
+		// each request opens a new connection.
+		req.Close = true
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+		return err
+	}
+	require.NoError(t, get())
+	n.partition([]string{"a"}, []string{"b"})
+	require.Error(t, get())
+	n.heal([]string{"a"})
+	require.NoError(t, get())
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+const (
+	// reservedPortsPerMember are the client, peer, metrics, peer proxy, client
+	// HTTP and gofail ports of a member of a cluster with reserved ports.
+	reservedPortsPerMember = 6
+	// reservedSpareMembers are the members a cluster with reserved ports can
+	// add beyond its initial size.
+	reservedSpareMembers = 3
+)
+
+// Ports is the registry of the ports reserved by the clusters of the test
+// process. Its range does not overlap the ports of the clusters with a fixed
+// base port, e.g. EtcdProcessBasePort.
+var Ports = NewPortRegistry(23000, 32000)
+
+// PortRegistry reserves blocks of consecutive ports, so that clusters started
+// concurrently do not collide.
+type PortRegistry struct {
+	mu       sync.Mutex
+	min, max int
+	next     int
+	reserved map[int]bool
+}
+
+// NewPortRegistry returns a registry of the ports in [min, max).
+func NewPortRegistry(min, max int) *PortRegistry {
+	return &PortRegistry{min: min, max: max, next: min, reserved: make(map[int]bool)}
+}
+
+// Reserve reserves n consecutive ports and returns the first one. Ports that
+// are already in use on localhost, e.g. by another test process, are skipped.
+func (r *PortRegistry) Reserve(n int) (int, error) {
+	if n <= 0 || n > r.max-r.min {
+		return 0, fmt.Errorf("cannot reserve %d ports in [%d, %d)", n, r.min, r.max)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// search from the last reservation, so that the ports of the clusters
+	// that were just closed are not reused right away.
+	for scanned := 0; scanned < r.max-r.min; {
+		base := r.next
+		if base+n > r.max {
+			scanned += r.max - base
+			r.next = r.min
+			continue
+		}
+		if i := r.unavailable(base, n); i >= 0 {
+			scanned += i - base + 1
+			r.next = i + 1
+			continue
+		}
+		for p := base; p < base+n; p++ {
+			r.reserved[p] = true
+		}
+		r.next = base + n
+		return base, nil
+	}
+	return 0, fmt.Errorf("no %d consecutive ports available in [%d, %d)", n, r.min, r.max)
+}
+
+// unavailable returns the last port of [base, base+n) that is reserved or in
+// use, or -1 if all are available.
+func (r *PortRegistry) unavailable(base, n int) int {
+	for p := base + n - 1; p >= base; p-- {
+		if r.reserved[p] || !portFree(p) {
+			return p
+		}
+	}
+	return -1
+}
+
+// Release releases the n ports starting at base.
+func (r *PortRegistry) Release(base, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for p := base; p < base+n; p++ {
+		delete(r.reserved, p)
+	}
+}
+
+func portFree(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortRegistryReserve(t *testing.T) {
+	r := NewPortRegistry(41000, 41010)
+	a, err := r.Reserve(4)
+	require.NoError(t, err)
+	b, err := r.Reserve(4)
+	require.NoError(t, err)
+	assert.Equal(t, 41000, a)
+	assert.Equal(t, 41004, b)
+
+	_, err = r.Reserve(4)
+	require.ErrorContains(t, err, "no 4 consecutive ports available")
+
+	r.Release(a, 4)
+	c, err := r.Reserve(4)
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+
+	_, err = r.Reserve(11)
+	require.Error(t, err)
+}
+
+func TestPortRegistrySkipsPortsInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:41101")
+	require.NoError(t, err)
+	defer l.Close()
+
+	r := NewPortRegistry(41100, 41110)
+	base, err := r.Reserve(3)
+	require.NoError(t, err)
+	assert.Equal(t, 41102, base)
+}
+
+func TestPortRegistryConcurrentReserve(t *testing.T) {
+	r := NewPortRegistry(41200, 41300)
+	var mu sync.Mutex
+	taken := make(map[int]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			base, err := r.Reserve(5)
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for p := base; p < base+5; p++ {
+				assert.False(t, taken[p], fmt.Sprintf("port %d reserved twice", p))
+				taken[p] = true
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, taken, 50)
+}
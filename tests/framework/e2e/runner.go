@@ -0,0 +1,184 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+// ProcessRunner runs the etcd binaries of the members. A nil ProcessRunner
+// runs them as processes on the host.
+type ProcessRunner interface {
+	// Command returns the command running the binary of the member with the
+	// given arguments.
+	Command(cfg *EtcdServerProcessConfig, args []string) []string
+	// Kill forcibly stops the member, before its command is killed.
+	Kill(cfg *EtcdServerProcessConfig) error
+	// Version returns the version of the etcd binary at execPath as run by
+	// the runner.
+	Version(execPath string) (*semver.Version, error)
+}
+
+// BinaryVersion returns the version of the etcd binary at execPath as run by
+// the runner, on the host if the runner is nil.
+func BinaryVersion(r ProcessRunner, execPath string) (*semver.Version, error) {
+	if r == nil {
+		return GetVersionFromBinary(execPath)
+	}
+	return r.Version(execPath)
+}
+
+// LastReleaseImage is the container image run in place of the last release
+// binary when the binary does not exist, e.g. a published release image.
+var LastReleaseImage string
+
+// DefaultRunner returns the runner of the clusters that do not set one. It is
+// nil, unless the last release binary does not exist and LastReleaseImage is
+// set, in which case the last release runs in containers of the image.
+func DefaultRunner() ProcessRunner {
+	if LastReleaseImage == "" || fileutil.Exist(BinPath.EtcdLastRelease) {
+		return nil
+	}
+	return &ContainerRunner{Images: map[string]string{BinPath.EtcdLastRelease: LastReleaseImage}}
+}
+
+// LastReleaseAvailable returns whether the last release can be run, either
+// from its binary or from LastReleaseImage.
+func LastReleaseAvailable() bool {
+	return fileutil.Exist(BinPath.EtcdLastRelease) || LastReleaseImage != ""
+}
+
+// containerEtcdPath is the path of the etcd binary in release images.
+const containerEtcdPath = "/usr/local/bin/etcd"
+
+var containerNameCleanRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// ContainerRunner runs members in containers with docker or podman, so that
+// tests can run released images, other OS bases, or members constrained by
+// the container engine. The members keep their data dirs, certificates and
+// working directory from the host, mounted at the same paths.
+type ContainerRunner struct {
+	// Engine is the container engine command, "docker" by default.
+	Engine string
+	// Images maps etcd binaries, such as BinPath.EtcdLastRelease, to the
+	// images run in their place, e.g. "gcr.io/etcd-development/etcd:v3.5.17".
+	Images map[string]string
+	// BaseImage runs the binaries missing from Images, mounted into a
+	// container of the image, e.g. another OS base. If empty, these binaries
+	// run on the host.
+	BaseImage string
+	// Network is the network of the containers, "host" by default. Members
+	// advertise localhost URLs, so other networks need the URLs of the
+	// cluster to be reachable from them.
+	Network string
+	// Args are extra arguments of the run command, e.g. "--memory=512m" or
+	// "--cpus=0.5" to constrain the members.
+	Args []string
+}
+
+func (r *ContainerRunner) engine() string {
+	if r.Engine == "" {
+		return "docker"
+	}
+	return r.Engine
+}
+
+// image returns the image running the binary, and whether the binary is
+// mounted into it. An empty image runs the binary on the host.
+func (r *ContainerRunner) image(execPath string) (image string, mount bool) {
+	if image, ok := r.Images[execPath]; ok {
+		return image, false
+	}
+	return r.BaseImage, r.BaseImage != ""
+}
+
+func (r *ContainerRunner) Command(cfg *EtcdServerProcessConfig, args []string) []string {
+	image, mount := r.image(cfg.ExecPath)
+	if image == "" {
+		return append([]string{cfg.ExecPath}, args...)
+	}
+	network := r.Network
+	if network == "" {
+		network = "host"
+	}
+	cmd := []string{r.engine(), "run", "--rm",
+		"--name", containerName(cfg.Name),
+		"--network", network,
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--volume", cfg.DataDirPath + ":" + cfg.DataDirPath,
+		"--volume", CertDir + ":" + CertDir + ":ro",
+	}
+	// unix sockets and relative paths of the members are in the working
+	// directory
+	if wd, err := os.Getwd(); err == nil {
+		cmd = append(cmd, "--volume", wd+":"+wd, "--workdir", wd)
+	}
+	if mount {
+		cmd = append(cmd, "--volume", cfg.ExecPath+":"+containerEtcdPath+":ro")
+	}
+	for _, k := range slices.Sorted(maps.Keys(cfg.EnvVars)) {
+		cmd = append(cmd, "--env", k+"="+cfg.EnvVars[k])
+	}
+	cmd = append(cmd, r.Args...)
+	cmd = append(cmd, "--entrypoint", containerEtcdPath, image)
+	return append(cmd, args...)
+}
+
+func (r *ContainerRunner) Kill(cfg *EtcdServerProcessConfig) error {
+	if image, _ := r.image(cfg.ExecPath); image == "" {
+		return nil
+	}
+	out, err := exec.Command(r.engine(), "rm", "--force", containerName(cfg.Name)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to remove container of %s: %w, output: %s", cfg.Name, err, out)
+	}
+	return nil
+}
+
+func (r *ContainerRunner) Version(execPath string) (*semver.Version, error) {
+	image, mount := r.image(execPath)
+	if image == "" {
+		return GetVersionFromBinary(execPath)
+	}
+	cmd := []string{r.engine(), "run", "--rm"}
+	if mount {
+		if !fileutil.Exist(execPath) {
+			return nil, fmt.Errorf("binary path does not exist: %s", execPath)
+		}
+		cmd = append(cmd, "--volume", execPath+":"+containerEtcdPath+":ro")
+	}
+	cmd = append(cmd, "--entrypoint", containerEtcdPath, image, "--version")
+	lines, err := RunUtilCompletion(cmd, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not find binary version from image %s, err: %w", image, err)
+	}
+	return parseVersion(image, lines)
+}
+
+// containerName returns the name of the container of the member, unique to
+// the test process.
+func containerName(name string) string {
+	return fmt.Sprintf("etcd-e2e-%d-%s", os.Getpid(), containerNameCleanRegex.ReplaceAllString(strings.TrimSpace(name), "_"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRunnerCommand(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	cfg := &EtcdServerProcessConfig{
+		ExecPath:    "/bin/etcd-last-release",
+		Name:        "TestFoo bar-test-0",
+		DataDirPath: "/tmp/data",
+		EnvVars:     map[string]string{"GOFAIL_HTTP": "127.0.0.1:12381", "A": "b"},
+	}
+	name := fmt.Sprintf("etcd-e2e-%d-TestFoo_bar-test-0", os.Getpid())
+	prefix := []string{
+		"podman", "run", "--rm",
+		"--name", name,
+		"--network", "host",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--volume", "/tmp/data:/tmp/data",
+		"--volume", CertDir + ":" + CertDir + ":ro",
+		"--volume", wd + ":" + wd, "--workdir", wd,
+	}
+	env := []string{"--env", "A=b", "--env", "GOFAIL_HTTP=127.0.0.1:12381"}
+
+	tcs := []struct {
+		name   string
+		runner *ContainerRunner
+		want   []string
+	}{
+		{
+			name:   "Image",
+			runner: &ContainerRunner{Engine: "podman", Images: map[string]string{cfg.ExecPath: "etcd:v3.5.17"}, Args: []string{"--memory=512m"}},
+			want:   slices.Concat(prefix, env, []string{"--memory=512m", "--entrypoint", containerEtcdPath, "etcd:v3.5.17", "--name", "m0"}),
+		},
+		{
+			name:   "BaseImage",
+			runner: &ContainerRunner{Engine: "podman", BaseImage: "fedora:41"},
+			want:   slices.Concat(prefix, []string{"--volume", cfg.ExecPath + ":" + containerEtcdPath + ":ro"}, env, []string{"--entrypoint", containerEtcdPath, "fedora:41", "--name", "m0"}),
+		},
+		{
+			name:   "Host",
+			runner: &ContainerRunner{Images: map[string]string{"/bin/etcd": "etcd:v3.6.0"}},
+			want:   []string{cfg.ExecPath, "--name", "m0"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.runner.Command(cfg, []string{"--name", "m0"}))
+		})
+	}
+}
@@ -24,3 +24,12 @@ func BeforeTest(t testing.TB) {
 	SkipInShortMode(t)
 	testutil.BeforeTest(t)
 }
+
+// BeforeParallelTest marks the test as parallel. Unlike BeforeTest, it keeps
+// the working directory, which is shared by all tests, and does not check for
+// leaked goroutines, which belong to the tests running concurrently. The
+// clusters of parallel tests must be started with WithReservedPorts.
+func BeforeParallelTest(t *testing.T) {
+	SkipInShortMode(t)
+	t.Parallel()
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/tests/v3/framework/config"
+)
+
+// VersionTransition is a sequence of rolling replacements of the binary of
+// all the members of a cluster.
+type VersionTransition struct {
+	Name string
+	// Start is the version the cluster is created with, either CurrentVersion
+	// or LastVersion.
+	Start ClusterVersion
+	// Steps are the versions the members are replaced with, in order.
+	Steps []ClusterVersion
+}
+
+// VersionTransitions are the transitions between the current and the last
+// release that tests can be parameterized over.
+var VersionTransitions = []VersionTransition{
+	{Name: "Upgrade", Start: LastVersion, Steps: []ClusterVersion{CurrentVersion}},
+	{Name: "UpgradeDowngrade", Start: LastVersion, Steps: []ClusterVersion{CurrentVersion, LastVersion}},
+	{Name: "DowngradeUpgrade", Start: CurrentVersion, Steps: []ClusterVersion{LastVersion, CurrentVersion}},
+}
+
+// VersionTransitionCheck is called once the cluster is created and after each
+// member is replaced, to verify the behavior under test in every mixed
+// version state of the cluster.
+type VersionTransitionCheck func(t *testing.T, epc *EtcdProcessCluster)
+
+// Run creates a cluster with the given options on the start version, then
+// replaces the members one by one for each step. Between replacements, it
+// verifies that the cluster is healthy and still holds the keys and members
+// it had before the replacement, and calls check if not nil. The test is
+// skipped if the last release can not be run.
+func (vt VersionTransition) Run(t *testing.T, check VersionTransitionCheck, opts ...EPClusterOption) {
+	runner := NewConfig(opts...).runner()
+	if runner == nil && !fileutil.Exist(BinPath.EtcdLastRelease) {
+		t.Skipf("%q does not exist", BinPath.EtcdLastRelease)
+	}
+	currentVersion, err := BinaryVersion(runner, BinPath.Etcd)
+	require.NoError(t, err)
+	currentVersion.PreRelease = ""
+	lastVersion, err := BinaryVersion(runner, BinPath.EtcdLastRelease)
+	require.NoError(t, err)
+	lastVersion = &semver.Version{Major: lastVersion.Major, Minor: lastVersion.Minor}
+
+	epc, err := NewEtcdProcessCluster(context.TODO(), t, append(opts, WithVersion(vt.Start), WithKeepDataDir(true))...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, epc.Close())
+	})
+	if check != nil {
+		check(t, epc)
+	}
+
+	from := vt.Start
+	for _, to := range vt.Steps {
+		if from == to {
+			continue
+		}
+		fromVersion, toVersion := lastVersion, currentVersion
+		if to == LastVersion {
+			fromVersion, toVersion = currentVersion, lastVersion
+			DowngradeEnable(t, epc, lastVersion)
+		}
+		t.Logf("Replacing members from %s to %s", fromVersion, toVersion)
+		for i := range epc.Procs {
+			before := clusterState(t, epc)
+			// a downgrade completes once all members are replaced, so none is
+			// enabled when upgrading.
+			require.NoError(t, DowngradeUpgradeMembersByID(t, nil, epc, []int{i}, false, fromVersion, toVersion))
+			require.NoError(t, epc.Etcdctl().Health(context.TODO()), "cluster unhealthy after replacing member %d", i)
+			require.Equal(t, before, clusterState(t, epc), "cluster state changed after replacing member %d", i)
+			if check != nil {
+				check(t, epc)
+			}
+		}
+		from = to
+	}
+}
+
+// clusterState returns the keys and the members of the cluster, as a string
+// comparable between replacements.
+func clusterState(t *testing.T, epc *EtcdProcessCluster) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cc := epc.Etcdctl()
+	kvs, err := cc.Get(ctx, "", config.GetOptions{Prefix: true})
+	require.NoError(t, err)
+	members, err := cc.MemberList(ctx, false)
+	require.NoError(t, err)
+	return fmt.Sprintf("kvs: %v, members: %v", kvs.Kvs, members.Members)
+}
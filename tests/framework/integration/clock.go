@@ -0,0 +1,64 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"time"
+
+	framecfg "go.etcd.io/etcd/tests/v3/framework/config"
+)
+
+// clockStep is the time AdvanceClock advances the fake clock by at once. The
+// members see at most one raft tick per step, as a ticker drops the ticks
+// its receiver is not ready for.
+const clockStep = time.Second
+
+// AdvanceClock advances the fake clock of the cluster by d, one step at a
+// time with a pause in between, so that the members handle the timers of each
+// step, e.g. exchange heartbeats and revoke expired leases. Since raft only
+// ticks once per step, no election times out while the virtual time passes.
+// It panics if the cluster has no fake clock.
+func (c *Cluster) AdvanceClock(d time.Duration) {
+	if c.Cfg.Clock == nil {
+		panic("cluster has no fake clock")
+	}
+	for ; d > 0; d -= clockStep {
+		c.Cfg.Clock.Advance(min(d, clockStep))
+		time.Sleep(framecfg.TickDuration)
+	}
+}
+
+// tickClock advances the fake clock of the cluster in real time, until the
+// returned function is called.
+func (c *Cluster) tickClock() (stop func()) {
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		ticker := time.NewTicker(framecfg.TickDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Cfg.Clock.Advance(framecfg.TickDuration)
+			case <-stopc:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+	}
+}
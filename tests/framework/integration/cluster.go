@@ -36,6 +36,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jonboulle/clockwork"
 	"github.com/soheilhy/cmux"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -45,6 +46,7 @@ import (
 	"google.golang.org/grpc/keepalive"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	"go.etcd.io/etcd/client/pkg/v3/testutil"
 	"go.etcd.io/etcd/client/pkg/v3/tlsutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
@@ -171,12 +173,23 @@ type ClusterConfig struct {
 	EnableLeaseCheckpoint   bool
 	LeaseCheckpointInterval time.Duration
 	LeaseCheckpointPersist  bool
+	LeaseBasedReads         bool
+	LazyDeleteRange         bool
 
 	WatchProgressNotifyInterval time.Duration
+	MaxStreamsPerConnection     uint
+	MaxWatchersPerStream        uint
+	MaxWatchersPerUser          uint
+	MaxWatcherBytesPerSecond    uint
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+
+	// Clock, if set, drives the raft ticks, the expiry of leases and the auto
+	// compaction of the members. Time only passes when advanced by the test,
+	// see AdvanceClock.
+	Clock *clockwork.FakeClock
 }
 
 type Cluster struct {
@@ -221,6 +234,11 @@ func (c *Cluster) fillClusterForMembers() error {
 
 func (c *Cluster) Launch(t testutil.TB) {
 	t.Logf("Launching new cluster...")
+	if c.Cfg.Clock != nil {
+		// the members need raft ticks to elect a leader.
+		stop := c.tickClock()
+		defer stop()
+	}
 	errc := make(chan error)
 	for _, m := range c.Members {
 		// Members are launched in separate goroutines because if they boot
@@ -290,11 +308,18 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			EnableLeaseCheckpoint:       c.Cfg.EnableLeaseCheckpoint,
 			LeaseCheckpointInterval:     c.Cfg.LeaseCheckpointInterval,
 			LeaseCheckpointPersist:      c.Cfg.LeaseCheckpointPersist,
+			LeaseBasedReads:             c.Cfg.LeaseBasedReads,
+			LazyDeleteRange:             c.Cfg.LazyDeleteRange,
 			WatchProgressNotifyInterval: c.Cfg.WatchProgressNotifyInterval,
+			MaxStreamsPerConnection:     c.Cfg.MaxStreamsPerConnection,
+			MaxWatchersPerStream:        c.Cfg.MaxWatchersPerStream,
+			MaxWatchersPerUser:          c.Cfg.MaxWatchersPerUser,
+			MaxWatcherBytesPerSecond:    c.Cfg.MaxWatcherBytesPerSecond,
 			MaxLearners:                 c.Cfg.MaxLearners,
 			DisableStrictReconfigCheck:  c.Cfg.DisableStrictReconfigCheck,
 			CorruptCheckTime:            c.Cfg.CorruptCheckTime,
 			Metrics:                     c.Cfg.Metrics,
+			Clock:                       c.Cfg.Clock,
 		})
 	m.DiscoveryURL = c.Cfg.DiscoveryURL
 	return m
@@ -616,11 +641,18 @@ type MemberConfig struct {
 	EnableLeaseCheckpoint       bool
 	LeaseCheckpointInterval     time.Duration
 	LeaseCheckpointPersist      bool
+	LeaseBasedReads             bool
+	LazyDeleteRange             bool
 	WatchProgressNotifyInterval time.Duration
+	MaxStreamsPerConnection     uint
+	MaxWatchersPerStream        uint
+	MaxWatchersPerUser          uint
+	MaxWatcherBytesPerSecond    uint
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+	Clock                       *clockwork.FakeClock
 }
 
 // MustNewMember return an inited member with the given name. If peerTLS is
@@ -722,6 +754,10 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.LeaseCheckpointInterval = mcfg.LeaseCheckpointInterval
 
 	m.WatchProgressNotifyInterval = mcfg.WatchProgressNotifyInterval
+	m.MaxStreamsPerConnection = mcfg.MaxStreamsPerConnection
+	m.MaxWatchersPerStream = mcfg.MaxWatchersPerStream
+	m.MaxWatchersPerUser = mcfg.MaxWatchersPerUser
+	m.MaxWatcherBytesPerSecond = mcfg.MaxWatcherBytesPerSecond
 
 	m.InitialCorruptCheck = true
 	if mcfg.CorruptCheckTime > time.Duration(0) {
@@ -734,12 +770,16 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 		m.MaxLearners = mcfg.MaxLearners
 	}
 	m.Metrics = mcfg.Metrics
+	if mcfg.Clock != nil {
+		m.Clock = mcfg.Clock
+	}
 	m.V2Deprecation = config.V2_DEPR_DEFAULT
 	m.GRPCServerRecorder = &grpctesting.GRPCRecorder{}
 
 	m.Logger, m.LogObserver = memberLogger(t, mcfg.Name)
+	m.LogLevels = logutil.NewSubsystemLevels(zapcore.InfoLevel, etcdserver.LogSubsystems...)
 	m.ServerFeatureGate = features.NewDefaultServerFeatureGate(m.Name, m.Logger)
-	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist)
+	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v,LeaseBasedReads=%v,LazyDeleteRange=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist, mcfg.LeaseBasedReads, mcfg.LazyDeleteRange)
 	if err := m.ServerFeatureGate.(featuregate.MutableFeatureGate).Set(featureGates); err != nil {
 		t.Fatalf("Set FeatureGate FAILED: %v", err)
 	}
@@ -951,9 +991,11 @@ func (m *Member) Clone(t testutil.TB) *Member {
 	}
 	mm.InitialClusterToken = m.InitialClusterToken
 	mm.ElectionTicks = m.ElectionTicks
+	mm.Clock = m.Clock
 	mm.PeerTLSInfo = m.PeerTLSInfo
 	mm.ClientTLSInfo = m.ClientTLSInfo
 	mm.Logger, mm.LogObserver = memberLogger(t, mm.Name+"c")
+	mm.LogLevels = logutil.NewSubsystemLevels(zapcore.InfoLevel, etcdserver.LogSubsystems...)
 	return mm
 }
 
@@ -993,7 +1035,7 @@ func (m *Member) Launch() error {
 		m.ServerClient = v3client.New(m.Server)
 		lockpb.RegisterLockServer(m.GRPCServer, v3lock.NewLockServer(m.ServerClient))
 		epb.RegisterElectionServer(m.GRPCServer, v3election.NewElectionServer(m.ServerClient))
-		go m.GRPCServer.Serve(m.GRPCListener)
+		go m.GRPCServer.Serve(v3rpc.NewDrainListener(m.GRPCListener, m.Server))
 	}
 
 	m.RaftHandler = &testutil.PauseableHandler{Next: etcdhttp.NewPeerHandler(m.Logger, m.Server)}
@@ -1587,8 +1629,22 @@ func (c *Cluster) GetLearnerMembers() ([]*pb.Member, error) {
 // AddAndLaunchLearnerMember creates a learner member, adds it to Cluster
 // via v3 MemberAdd API, and then launches the new member.
 func (c *Cluster) AddAndLaunchLearnerMember(t testutil.TB) {
+	c.addAndLaunchLearnerMember(t, func(*Member) {})
+}
+
+// AddAndLaunchReadReplicaMember adds a learner member serving reads as a read
+// replica at most maxStaleness behind the leader.
+func (c *Cluster) AddAndLaunchReadReplicaMember(t testutil.TB, maxStaleness time.Duration) {
+	c.addAndLaunchLearnerMember(t, func(m *Member) {
+		m.ReadReplica = true
+		m.ReadReplicaMaxStaleness = maxStaleness
+	})
+}
+
+func (c *Cluster) addAndLaunchLearnerMember(t testutil.TB, configure func(m *Member)) {
 	m := c.MustNewMember(t)
 	m.IsLearner = true
+	configure(m)
 
 	scheme := SchemeFromTLSInfo(c.Cfg.PeerTLS)
 	peerURLs := []string{scheme + "://" + m.PeerListeners[0].Addr().String()}
@@ -25,7 +25,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -429,3 +432,52 @@ func TestMaxLearnerInCluster(t *testing.T) {
 		t.Errorf("failed to add member %v", err)
 	}
 }
+
+func TestClusterMetadata(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx := context.Background()
+	_, err := clus.Client(0).ClusterMetadataPut(ctx, "environment", "production")
+	require.NoError(t, err)
+	_, err = clus.Client(0).ClusterMetadataPut(ctx, "owner", "storage")
+	require.NoError(t, err)
+
+	// the metadata is kept apart from the keyspace and survives compaction
+	presp, err := clus.Client(0).Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	_, err = clus.Client(0).Compact(ctx, presp.Header.Revision, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	want := []*etcdserverpb.ClusterMetadataEntry{{Key: "environment", Value: "production"}, {Key: "owner", Value: "storage"}}
+	for i := range clus.Members {
+		resp, err := clus.Client(i).ClusterMetadataList(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, resp.Entries)
+
+		sresp, err := clus.Client(i).Status(ctx, clus.Members[i].GRPCURL)
+		require.NoError(t, err)
+		require.Equal(t, want, sresp.ClusterMetadata)
+	}
+
+	_, err = clus.Client(1).ClusterMetadataDelete(ctx, "owner")
+	require.NoError(t, err)
+	// deleting a missing entry is not an error
+	_, err = clus.Client(1).ClusterMetadataDelete(ctx, "owner")
+	require.NoError(t, err)
+
+	// the metadata is persisted in the backend
+	clus.Members[2].Stop(t)
+	require.NoError(t, clus.Members[2].Restart(t))
+	clus.WaitLeader(t)
+	resp, err := clus.Client(2).ClusterMetadataList(ctx, clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.Equal(t, want[:1], resp.Entries)
+
+	_, err = clus.Client(0).ClusterMetadataPut(ctx, "", "value")
+	require.ErrorIs(t, err, rpctypes.ErrClusterMetadataKeyEmpty)
+	_, err = clus.Client(0).ClusterMetadataPut(ctx, "large", strings.Repeat("a", 64*1024))
+	require.ErrorIs(t, err, rpctypes.ErrClusterMetadataTooLarge)
+}
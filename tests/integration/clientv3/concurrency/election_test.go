@@ -111,3 +111,83 @@ func TestResumeElection(t *testing.T) {
 		t.Errorf("expected new leader to be 'candidate1' got %q", string(kv.Value))
 	}
 }
+
+func TestElectionHistory(t *testing.T) {
+	const prefix = "/election-history"
+
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	changes, _, err := concurrency.ElectionHistory(ctx, cli, prefix, 10)
+	if err != nil {
+		t.Fatalf("ElectionHistory() returned non nil err: %s", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no leadership change, got %v", changes)
+	}
+
+	var revs []int64
+	for _, v := range []string{"candidate1", "candidate2", "candidate3"} {
+		s, err := concurrency.NewSession(cli)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := concurrency.NewElection(s, prefix)
+		if err = e.Campaign(ctx, v); err != nil {
+			t.Fatalf("Campaign() returned non nil err: %s", err)
+		}
+		revs = append(revs, e.Rev())
+		if v != "candidate3" {
+			if err = e.Resign(ctx); err != nil {
+				t.Fatalf("Resign() returned non nil err: %s", err)
+			}
+		}
+		defer s.Close()
+	}
+
+	checkChanges := func() {
+		changes, _, err = concurrency.ElectionHistory(ctx, cli, prefix, 2)
+		if err != nil {
+			t.Fatalf("ElectionHistory() returned non nil err: %s", err)
+		}
+		if len(changes) != 2 || string(changes[0].Kv.Value) != "candidate2" || string(changes[1].Kv.Value) != "candidate3" {
+			t.Fatalf("expected the changes to candidate2 and candidate3, got %v", changes)
+		}
+		for i, c := range changes {
+			if c.Epoch() != revs[i+1] || c.Revision != revs[i+1] {
+				t.Errorf("expected change %d at epoch and revision %d, got %d and %d", i, revs[i+1], c.Epoch(), c.Revision)
+			}
+		}
+	}
+	checkChanges()
+
+	// the changes older than the first replayed revisions are still found.
+	for i := 0; i < 1500; i++ {
+		if _, err = cli.Put(ctx, "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkChanges()
+
+	// the changes before the compacted revision are lost.
+	resp, err := cli.Put(ctx, "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Compact(ctx, resp.Header.Revision); err != nil {
+		t.Fatal(err)
+	}
+	changes, _, err = concurrency.ElectionHistory(ctx, cli, prefix, 10)
+	if err != nil {
+		t.Fatalf("ElectionHistory() returned non nil err: %s", err)
+	}
+	if len(changes) != 1 || string(changes[0].Kv.Value) != "candidate3" || changes[0].Revision != resp.Header.Revision {
+		t.Fatalf("expected the change to candidate3 at the compacted revision, got %v", changes)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
@@ -377,6 +378,117 @@ func TestKVDeleteRange(t *testing.T) {
 	}
 }
 
+func TestKVLazyDeleteRange(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, LazyDeleteRange: true})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	for _, key := range []string{"a", "b", "b/1", "b/2", "c"} {
+		if _, err := kv.Put(ctx, key, ""); err != nil {
+			t.Fatalf("couldn't put %q (%v)", key, err)
+		}
+	}
+	wch := kv.Watch(ctx, "b/1", clientv3.WithRev(1))
+
+	dresp, err := kv.Delete(ctx, "b", clientv3.WithPrefix(), clientv3.WithLazyDelete())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), dresp.Deleted)
+
+	resp, err := kv.Get(ctx, "a", clientv3.WithFromKey())
+	require.NoError(t, err)
+	var keys []string
+	for _, kv := range resp.Kvs {
+		keys = append(keys, string(kv.Key))
+	}
+	require.Equal(t, []string{"a", "c"}, keys)
+
+	// the watcher gets the put of its key, then the delete of the whole range.
+	var evs []*clientv3.Event
+	for len(evs) < 2 {
+		wresp := <-wch
+		require.NoError(t, wresp.Err())
+		evs = append(evs, wresp.Events...)
+	}
+	require.Len(t, evs, 2)
+	require.True(t, evs[1].IsRangeDelete())
+	require.Equal(t, "b", string(evs[1].Kv.Key))
+	require.Equal(t, "c", string(evs[1].RangeEnd))
+	require.Equal(t, dresp.Header.Revision, evs[1].Kv.ModRevision)
+
+	_, err = integration2.ToGRPC(kv).KV.DeleteRange(ctx, &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte{0}, Lazy: true, PrevKv: true})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCLazyDeletePrevKV)
+}
+
+func TestKVLazyDeleteRangeDisabled(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	_, err := kv.Delete(ctx, "a", clientv3.WithPrefix(), clientv3.WithLazyDelete())
+	require.ErrorIs(t, err, rpctypes.ErrLazyDeleteDisabled)
+	then := clientv3.OpTxn(nil, []clientv3.Op{clientv3.OpDelete("a", clientv3.WithPrefix(), clientv3.WithLazyDelete())}, nil)
+	_, err = kv.Txn(ctx).Else(then).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrLazyDeleteDisabled)
+
+	_, err = kv.Delete(ctx, "a", clientv3.WithPrefix())
+	require.NoError(t, err)
+}
+
+func TestKVDoBatch(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	_, err := kv.Put(ctx, "b", "1")
+	require.NoError(t, err)
+
+	resps, err := clientv3.DoBatch(ctx, kv,
+		clientv3.OpPut("a", "1"),
+		clientv3.OpGet("a"),
+		clientv3.OpDelete("b"),
+		clientv3.OpGet("", clientv3.WithPrefix(), clientv3.WithCountOnly()),
+	)
+	require.NoError(t, err)
+	require.Len(t, resps, 4)
+	rev := resps[0].Put().Header.Revision
+	require.Equal(t, "1", string(resps[1].Get().Kvs[0].Value))
+	require.Equal(t, int64(1), resps[2].Del().Deleted)
+	require.Equal(t, int64(1), resps[3].Get().Count)
+	for _, resp := range resps[1:] {
+		var h *pb.ResponseHeader
+		switch {
+		case resp.Get() != nil:
+			h = resp.Get().Header
+		case resp.Del() != nil:
+			h = resp.Del().Header
+		}
+		require.Equal(t, rev, h.Revision)
+	}
+
+	// a key written twice fails the whole batch.
+	_, err = clientv3.DoBatch(ctx, kv, clientv3.OpPut("c", "1"), clientv3.OpPut("c", "2"))
+	require.ErrorIs(t, err, rpctypes.ErrDuplicateKey)
+	gresp, err := kv.Get(ctx, "c")
+	require.NoError(t, err)
+	require.Empty(t, gresp.Kvs)
+
+	resps, err = clientv3.DoBatch(ctx, kv)
+	require.NoError(t, err)
+	require.Empty(t, resps)
+}
+
 func TestKVCompactError(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -461,6 +573,65 @@ func TestKVCompact(t *testing.T) {
 	}
 }
 
+func TestKVCompactRetention(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	for i := 0; i < 5; i++ {
+		_, err := kv.Put(ctx, "/audit/foo", "bar")
+		require.NoError(t, err)
+		_, err = kv.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+	}
+	// revision 2 is the first put of /audit/foo
+	_, err := kv.Compact(ctx, 11, clientv3.WithCompactRetention("/audit/", 2), clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	for i := range clus.Members {
+		c := clus.Client(i)
+		resp, err := c.Get(ctx, "/audit/", clientv3.WithPrefix(), clientv3.WithRev(2))
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+
+		_, err = c.Get(ctx, "foo", clientv3.WithRev(3))
+		require.ErrorIs(t, err, rpctypes.ErrCompacted)
+
+		tresp, err := c.Txn(ctx).Then(clientv3.OpGet("/audit/foo", clientv3.WithRev(2))).Commit()
+		require.NoError(t, err)
+		require.Len(t, tresp.Responses[0].GetResponseRange().Kvs, 1)
+
+		_, err = c.Txn(ctx).Then(clientv3.OpGet("foo", clientv3.WithRev(3))).Commit()
+		require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	}
+
+	wchan := kv.Watch(ctx, "/audit/foo", clientv3.WithRev(2))
+	wr := <-wchan
+	require.NoError(t, wr.Err())
+	require.Len(t, wr.Events, 5)
+
+	wchan = kv.Watch(ctx, "foo", clientv3.WithRev(3))
+	wr = <-wchan
+	require.Equal(t, int64(11), wr.CompactRevision)
+
+	// a retention later than the compaction revision compacts its prefix
+	// further, keeping the compaction revision of the other keys.
+	_, err = kv.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	_, err = kv.Compact(ctx, 11, clientv3.WithCompactRetention("/audit/", 12), clientv3.WithCompactPhysical())
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	_, err = kv.Compact(ctx, 12, clientv3.WithCompactRetention("/audit/", 12), clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+	_, err = kv.Get(ctx, "/audit/foo", clientv3.WithRev(10))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	_, err = kv.Get(ctx, "foo", clientv3.WithRev(12))
+	require.NoError(t, err)
+}
+
 // TestKVGetRetry ensures get will retry on disconnect.
 func TestKVGetRetry(t *testing.T) {
 	integration2.BeforeTest(t)
@@ -829,6 +1000,47 @@ func TestKVForLearner(t *testing.T) {
 	}
 }
 
+// TestKVForReadReplica ensures that a read replica serves serializable
+// read-only txns, while a learner does not.
+func TestKVForReadReplica(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	_, err := clus.Client(0).Put(context.TODO(), "foo", "bar")
+	require.NoError(t, err)
+
+	clus.AddAndLaunchReadReplicaMember(t, time.Minute)
+	replica := clus.Members[3]
+	<-replica.ReadyNotify()
+	cli, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:   []string{replica.GRPCURL},
+		DialTimeout: 5 * time.Second,
+		DialOptions: []grpc.DialOption{grpc.WithBlock()},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	cmp := clientv3.Compare(clientv3.Value("foo"), "=", "bar")
+	get := clientv3.OpGet("foo", clientv3.WithSerializable())
+	// the replica serves reads once it caught up with the leader.
+	var resp *clientv3.TxnResponse
+	require.Eventually(t, func() bool {
+		resp, err = cli.Txn(context.TODO()).If(cmp).Then(get).Commit()
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "last error: %v", err)
+	require.True(t, resp.Succeeded)
+	require.Len(t, resp.Responses[0].GetResponseRange().Kvs, 1)
+	require.Equal(t, "bar", string(resp.Responses[0].GetResponseRange().Kvs[0].Value))
+
+	for _, op := range []clientv3.Op{clientv3.OpGet("foo"), clientv3.OpPut("foo", "baz")} {
+		_, err = cli.Txn(context.TODO()).If(cmp).Then(op).Commit()
+		require.Error(t, err)
+		require.Equal(t, rpctypes.ErrorDesc(rpctypes.ErrGRPCNotSupportedForLearner), rpctypes.ErrorDesc(err))
+	}
+}
+
 // TestBalancerSupportLearner verifies that balancer's retry and failover mechanism supports cluster with learner member
 func TestBalancerSupportLearner(t *testing.T) {
 	integration2.BeforeTest(t)
@@ -0,0 +1,146 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/ephemeral"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestEphemeralPutCancel(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	k1, err := ephemeral.Put(ctx1, cli, "foo", "bar")
+	require.NoError(t, err)
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	k2, err := ephemeral.Put(ctx2, cli, "abc", "123")
+	require.NoError(t, err)
+	require.Equal(t, k1.Lease(), k2.Lease(), "keys of the same client and TTL should share a lease")
+
+	cancel1()
+	<-k1.Done()
+	require.ErrorIs(t, k1.Err(), context.Canceled)
+	require.NoError(t, k2.Err())
+	resp, err := cli.Get(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Empty(t, resp.Kvs)
+	ttl, err := cli.TimeToLive(context.Background(), k2.Lease())
+	require.NoError(t, err)
+	assert.Positive(t, ttl.TTL, "lease should be kept while a key is attached")
+
+	cancel2()
+	<-k2.Done()
+	resp, err = cli.Get(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Empty(t, resp.Kvs)
+	ttl, err = cli.TimeToLive(context.Background(), k2.Lease())
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), ttl.TTL, "lease should be revoked once no key is attached")
+}
+
+func TestEphemeralKeepAlive(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	k, err := ephemeral.Put(ctx, cli, "foo", "bar", ephemeral.WithTTL(2))
+	require.NoError(t, err)
+
+	time.Sleep(4 * time.Second)
+	require.NoError(t, k.Err())
+	resp, err := cli.Get(context.Background(), "foo")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, int64(k.Lease()), resp.Kvs[0].Lease)
+
+	cancel()
+	<-k.Done()
+}
+
+func TestEphemeralLeaseLost(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lostc := make(chan string, 1)
+	k, err := ephemeral.Put(ctx, cli, "foo", "bar", ephemeral.WithTTL(3), ephemeral.WithOnLost(func(key string, err error) {
+		assert.ErrorIs(t, err, ephemeral.ErrLeaseLost)
+		lostc <- key
+	}))
+	require.NoError(t, err)
+
+	_, err = cli.Revoke(context.Background(), k.Lease())
+	require.NoError(t, err)
+	select {
+	case key := <-lostc:
+		assert.Equal(t, "foo", key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("loss callback was not called")
+	}
+	<-k.Done()
+	require.ErrorIs(t, k.Err(), ephemeral.ErrLeaseLost)
+
+	k2, err := ephemeral.Put(ctx, cli, "foo", "bar", ephemeral.WithTTL(3))
+	require.NoError(t, err)
+	assert.NotEqual(t, k.Lease(), k2.Lease(), "keys put after a loss should use a new lease")
+	cancel()
+	<-k2.Done()
+}
+
+func TestEphemeralClientClose(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	k, err := ephemeral.Put(context.Background(), cli, "foo", "bar", ephemeral.WithTTL(2))
+	require.NoError(t, err)
+
+	// a closed client neither deletes its keys nor revokes their lease, as a
+	// process that dies
+	require.NoError(t, cli.Close())
+	<-k.Done()
+	require.ErrorIs(t, k.Err(), ephemeral.ErrLeaseLost)
+
+	require.Eventually(t, func() bool {
+		resp, err := clus.RandClient().Get(context.Background(), "foo")
+		return err == nil && len(resp.Kvs) == 0
+	}, 5*time.Second, 100*time.Millisecond, "key should expire with its lease")
+}
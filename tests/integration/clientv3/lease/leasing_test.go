@@ -1116,6 +1116,41 @@ func TestLeasingDeleteRangeBounds(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLeasingLazyDeleteRange(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, LazyDeleteRange: true})
+	defer clus.Terminate(t)
+
+	lkv, closeLKV, err := leasing.NewKV(clus.Client(0), "0/")
+	require.NoError(t, err)
+	defer closeLKV()
+
+	for _, k := range []string{"key/a", "key/b", "other"} {
+		_, err = clus.Client(0).Put(context.TODO(), k, "123")
+		require.NoError(t, err)
+		_, err = lkv.Get(context.TODO(), k)
+		require.NoError(t, err)
+	}
+
+	dresp, err := lkv.Delete(context.TODO(), "key/", clientv3.WithPrefix(), clientv3.WithLazyDelete())
+	require.NoError(t, err)
+	wresp := <-clus.Client(0).Watch(context.TODO(), "key/a", clientv3.WithRev(dresp.Header.Revision))
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	require.True(t, wresp.Events[0].IsRangeDelete())
+
+	// the cached keys of the range are deleted, from the cache too.
+	clus.Members[0].Stop(t)
+	for _, k := range []string{"key/a", "key/b"} {
+		resp, err := lkv.Get(context.TODO(), k)
+		require.NoError(t, err)
+		require.Emptyf(t, resp.Kvs, "expected %q deleted", k)
+	}
+	resp, err := lkv.Get(context.TODO(), "other")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+}
+
 func TestLeasingDeleteRangeContendTxn(t *testing.T) {
 	then := []clientv3.Op{clientv3.OpDelete("key/", clientv3.WithPrefix())}
 	testLeasingDeleteRangeContend(t, clientv3.OpTxn(nil, then, nil))
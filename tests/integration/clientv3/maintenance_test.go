@@ -24,6 +24,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -40,6 +42,7 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/mvcc/testutil"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -332,6 +335,82 @@ func TestMaintenanceSnapshotWithVersionVersion(t *testing.T) {
 	}
 }
 
+func TestMaintenanceSnapshotAtRevision(t *testing.T) {
+	integration2.BeforeTest(t)
+	lg := zaptest.NewLogger(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	resp, err := cli.Put(context.Background(), "foo", "0")
+	require.NoError(t, err)
+	rev := resp.Header.Revision + 2
+
+	// the revision is applied by the members already.
+	_, err = cli.SnapshotAtRevision(context.Background(), clus.Members[0].GRPCURL, resp.Header.Revision)
+	require.ErrorIs(t, err, rpctypes.ErrGRPCSnapshotRevisionApplied)
+
+	type snapshot struct {
+		resp *clientv3.SnapshotResponse
+		err  error
+	}
+	snapc := make(chan snapshot, len(clus.Members))
+	for _, m := range clus.Members {
+		go func() {
+			resp, err := cli.SnapshotAtRevision(context.Background(), m.GRPCURL, rev)
+			snapc <- snapshot{resp, err}
+		}()
+	}
+	// the snapshots are only taken once the members apply the revision.
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, snapc)
+
+	_, err = cli.Put(context.Background(), "foo", "1")
+	require.NoError(t, err)
+	// a lease granted before the revision is in the snapshots, while a lease
+	// granted after it is not, though granting a lease does not change the
+	// revision.
+	before, err := cli.Grant(context.Background(), 100)
+	require.NoError(t, err)
+	_, err = cli.Put(context.Background(), "bar", "2", clientv3.WithLease(before.ID))
+	require.NoError(t, err)
+	after, err := cli.Grant(context.Background(), 100)
+	require.NoError(t, err)
+	require.NotEqual(t, before.ID, after.ID)
+
+	for range clus.Members {
+		snap := <-snapc
+		require.NoError(t, snap.err)
+		require.Equal(t, rev, snap.resp.Header.Revision)
+		dpath := filepath.Join(t.TempDir(), "snapshot.db")
+		f, err := os.Create(dpath)
+		require.NoError(t, err)
+		size, err := io.Copy(f, snap.resp.Snapshot)
+		snap.resp.Snapshot.Close()
+		require.NoError(t, err)
+		// drop the sha256 checksum to open the snapshot as a database
+		require.NoError(t, f.Truncate(size-sha256.Size))
+		require.NoError(t, f.Close())
+
+		b := backend.NewDefaultBackend(lg, dpath)
+		s := mvcc.NewStore(lg, b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+		assert.Equal(t, rev, s.Rev())
+		r, err := s.Range(context.Background(), []byte("foo"), nil, mvcc.RangeOptions{})
+		require.NoError(t, err)
+		require.Len(t, r.KVs, 1)
+		assert.Equal(t, "1", string(r.KVs[0].Value))
+		tx := b.ReadTx()
+		tx.RLock()
+		leases := schema.MustUnsafeGetAllLeases(tx)
+		tx.RUnlock()
+		require.Len(t, leases, 1)
+		assert.Equal(t, int64(before.ID), leases[0].ID)
+		s.Close()
+		b.Close()
+	}
+}
+
 func TestMaintenanceSnapshotContentDigest(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -425,3 +504,130 @@ func TestMaintenanceStatus(t *testing.T) {
 		t.Fatal("no leader found")
 	}
 }
+
+func TestMaintenanceValuePolicy(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx := context.Background()
+	policy := &clientv3.ValuePolicy{
+		Prefix:       []byte("/config/"),
+		MaxValueSize: 32,
+		ContentType:  etcdserverpb.ValuePolicy_JSON,
+		KeyPattern:   `^/config/[a-z]+$`,
+	}
+	_, err := clus.Client(0).ValuePolicyPut(ctx, policy)
+	require.NoError(t, err)
+	_, err = clus.Client(0).ValuePolicyPut(ctx, &clientv3.ValuePolicy{Prefix: []byte("/bad/"), KeyPattern: "("})
+	require.ErrorIs(t, err, rpctypes.ErrInvalidValuePolicy)
+
+	kv := clus.Client(1)
+	_, err = kv.Put(ctx, "/config/a", `{"a":1}`)
+	require.NoError(t, err)
+	_, err = kv.Put(ctx, "/config/a", "{")
+	require.ErrorIs(t, err, rpctypes.ErrValuePolicyBadContent)
+	_, err = kv.Put(ctx, "/config/a", `"`+strings.Repeat("a", 32)+`"`)
+	require.ErrorIs(t, err, rpctypes.ErrValuePolicyValueTooLarge)
+	_, err = kv.Put(ctx, "/config/A", "1")
+	require.ErrorIs(t, err, rpctypes.ErrValuePolicyKeyMismatch)
+	_, err = kv.Txn(ctx).Then(clientv3.OpPut("/other", "{"), clientv3.OpPut("/config/b", "{")).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrValuePolicyBadContent)
+	gresp, err := kv.Get(ctx, "/other")
+	require.NoError(t, err)
+	require.Empty(t, gresp.Kvs)
+
+	// the policies are persisted in the backend
+	clus.Members[2].Stop(t)
+	require.NoError(t, clus.Members[2].Restart(t))
+	clus.WaitLeader(t)
+	resp, err := clus.Client(2).ValuePolicyList(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*etcdserverpb.ValuePolicy{(*etcdserverpb.ValuePolicy)(policy)}, resp.Policies)
+
+	_, err = clus.Client(2).ValuePolicyDelete(ctx, "/config/")
+	require.NoError(t, err)
+	_, err = kv.Put(ctx, "/config/a", "{")
+	require.NoError(t, err)
+}
+
+func TestMaintenanceScrub(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx := context.Background()
+	cli := clus.RandClient()
+	ep := clus.Members[0].GRPCURL
+	for i := 0; i < 100; i++ {
+		_, err := cli.Put(ctx, fmt.Sprintf("/k%d", i), "v")
+		require.NoError(t, err)
+	}
+	_, err := cli.Delete(ctx, "/k", clientv3.WithPrefix())
+	require.NoError(t, err)
+
+	waitScrub := func(state etcdserverpb.ScrubResponse_ScrubState) *clientv3.ScrubResponse {
+		var resp *clientv3.ScrubResponse
+		require.Eventually(t, func() bool {
+			resp, err = cli.ScrubStatus(ctx, ep)
+			require.NoError(t, err)
+			return resp.State == state
+		}, 10*time.Second, 10*time.Millisecond)
+		return resp
+	}
+
+	resp, err := cli.ScrubStatus(ctx, ep)
+	require.NoError(t, err)
+	require.Equal(t, etcdserverpb.ScrubResponse_IDLE, resp.State)
+
+	// a scrub reading a byte per second runs until it is canceled.
+	resp, err = cli.ScrubStart(ctx, ep, 1, false)
+	require.NoError(t, err)
+	require.Equal(t, etcdserverpb.ScrubResponse_RUNNING, resp.State)
+	resp, err = cli.ScrubStart(ctx, ep, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, etcdserverpb.ScrubResponse_RUNNING, resp.State)
+	_, err = cli.ScrubCancel(ctx, ep)
+	require.NoError(t, err)
+	waitScrub(etcdserverpb.ScrubResponse_CANCELED)
+
+	_, err = cli.ScrubStart(ctx, ep, 0, true)
+	require.NoError(t, err)
+	resp = waitScrub(etcdserverpb.ScrubResponse_FINISHED)
+	assert.Zero(t, resp.FindingsCount)
+	assert.Empty(t, resp.Findings)
+	assert.Equal(t, int64(200), resp.RevisionsChecked)
+	assert.Positive(t, resp.BytesRead)
+	assert.NotZero(t, resp.FinishTime)
+}
+
+func TestMaintenanceFeatureGates(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, EnableLeaseCheckpoint: true})
+	defer clus.Terminate(t)
+
+	resp, err := clus.RandClient().FeatureGates(context.Background(), clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	gates := make(map[string]*etcdserverpb.FeatureGate)
+	var names []string
+	for _, f := range resp.Features {
+		gates[f.Name] = f
+		names = append(names, f.Name)
+	}
+	require.IsIncreasing(t, names)
+
+	require.Contains(t, gates, "LeaseCheckpoint")
+	assert.Equal(t, &etcdserverpb.FeatureGate{
+		Name:              "LeaseCheckpoint",
+		Stage:             etcdserverpb.FeatureGate_ALPHA,
+		Enabled:           true,
+		ExperimentalFlags: []string{"experimental-enable-lease-checkpoint"},
+	}, gates["LeaseCheckpoint"])
+	require.Contains(t, gates, "TxnModeWriteWithSharedBuffer")
+	assert.Equal(t, etcdserverpb.FeatureGate_BETA, gates["TxnModeWriteWithSharedBuffer"].Stage)
+	assert.True(t, gates["TxnModeWriteWithSharedBuffer"].DefaultEnabled)
+	assert.False(t, gates["TxnModeWriteWithSharedBuffer"].Runtime)
+}
@@ -79,3 +79,37 @@ func TestNamespaceWatch(t *testing.T) {
 	// let client close teardown namespace watch
 	c.Watcher = nsWatcher
 }
+
+func TestNamespaceWatchLazyDeleteRange(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, LazyDeleteRange: true})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsWatcher := namespace.NewWatcher(c.Watcher, "foo/")
+	defer nsWatcher.Close()
+
+	nsWch := nsWatcher.Watch(context.TODO(), "b", clientv3.WithFromKey(), clientv3.WithFilterPut())
+	for _, rng := range [][2]string{{"foo/b", "foo/c"}, {"f", "g"}} {
+		_, err := c.Put(context.TODO(), "foo/b1", "bar")
+		require.NoError(t, err)
+		_, err = c.Delete(context.TODO(), rng[0], clientv3.WithRange(rng[1]), clientv3.WithLazyDelete())
+		require.NoError(t, err)
+	}
+
+	// the ranges are translated into the namespace, clamped to its keys.
+	var evs []*clientv3.Event
+	for len(evs) < 2 {
+		wr := <-nsWch
+		require.NoError(t, wr.Err())
+		evs = append(evs, wr.Events...)
+	}
+	require.Len(t, evs, 2)
+	require.True(t, evs[0].IsRangeDelete())
+	require.Equal(t, "b", string(evs[0].Kv.Key))
+	require.Equal(t, "c", string(evs[0].RangeEnd))
+	require.True(t, evs[1].IsRangeDelete())
+	require.Empty(t, evs[1].Kv.Key)
+	require.Equal(t, []byte{0}, evs[1].RangeEnd)
+}
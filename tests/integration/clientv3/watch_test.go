@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -907,6 +908,182 @@ func TestWatchWithFilter(t *testing.T) {
 	}
 }
 
+// TestWatchWithLease checks that a watch with WithLease only gets the events
+// of the keys attached to the lease, including the puts moving them off the
+// lease and their deletion on revoke.
+func TestWatchWithLease(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	cluster := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, LazyDeleteRange: true})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := context.Background()
+
+	l1, err := client.Grant(ctx, 60)
+	require.NoError(t, err)
+	l2, err := client.Grant(ctx, 60)
+	require.NoError(t, err)
+
+	// attached before the watch starts
+	_, err = client.Put(ctx, "e", "1", clientv3.WithLease(l1.ID))
+	require.NoError(t, err)
+
+	wch := client.Watch(ctx, "", clientv3.WithPrefix(), clientv3.WithLease(l1.ID))
+
+	ops := []clientv3.Op{
+		clientv3.OpPut("a", "1", clientv3.WithLease(l1.ID)),
+		clientv3.OpPut("b", "1"),
+		clientv3.OpPut("c", "1", clientv3.WithLease(l2.ID)),
+		clientv3.OpPut("d", "1", clientv3.WithLease(l1.ID)),
+		clientv3.OpPut("d", "2"),
+		clientv3.OpDelete("d"),
+		clientv3.OpDelete("e"),
+		clientv3.OpDelete("b"),
+		clientv3.OpPut("x1", "1", clientv3.WithLease(l1.ID)),
+		clientv3.OpDelete("x", clientv3.WithPrefix(), clientv3.WithLazyDelete()),
+		clientv3.OpPut("c", "2", clientv3.WithLease(l1.ID)),
+	}
+	for _, op := range ops {
+		_, err = client.Do(ctx, op)
+		require.NoError(t, err)
+	}
+	_, err = client.Revoke(ctx, l1.ID)
+	require.NoError(t, err)
+
+	type event struct {
+		typ mvccpb.Event_EventType
+		key string
+	}
+	expected := []event{
+		{mvccpb.PUT, "a"},
+		{mvccpb.PUT, "d"},
+		{mvccpb.PUT, "d"},
+		{mvccpb.DELETE, "e"},
+		{mvccpb.PUT, "x1"},
+		{mvccpb.DELETE, "x"},
+		{mvccpb.PUT, "c"},
+		{mvccpb.DELETE, "a"},
+		{mvccpb.DELETE, "c"},
+	}
+	var got []event
+	for len(got) < len(expected) {
+		select {
+		case resp := <-wch:
+			require.NoError(t, resp.Err())
+			for _, ev := range resp.Events {
+				got = append(got, event{ev.Type, string(ev.Kv.Key)})
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for lease events, got %+v", got)
+		}
+	}
+	require.Equal(t, expected, got)
+
+	select {
+	case resp := <-wch:
+		t.Fatalf("unexpected watch response (%+v)", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestWatchWithMaxEvents checks that watch responses are split to the
+// requested number of events while revisions are received whole.
+func TestWatchWithMaxEvents(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	cluster := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := context.Background()
+
+	var ops []clientv3.Op
+	for i := 0; i < 5; i++ {
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf("txn%d", i), "1"))
+	}
+	resp, err := client.Txn(ctx).Then(ops...).Commit()
+	require.NoError(t, err)
+	txnRev := resp.Header.Revision
+	for i := 0; i < 5; i++ {
+		_, err = client.Put(ctx, fmt.Sprintf("put%d", i), "1")
+		require.NoError(t, err)
+	}
+
+	wch := client.Watch(ctx, "", clientv3.WithPrefix(), clientv3.WithRev(txnRev), clientv3.WithMaxEvents(2))
+	events := 0
+	for events < 10 {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			txnEvents := 0
+			for _, ev := range wresp.Events {
+				if ev.Kv.ModRevision == txnRev {
+					txnEvents++
+				}
+			}
+			if txnEvents == 0 {
+				require.LessOrEqual(t, len(wresp.Events), 2)
+			} else {
+				require.Equal(t, 5, txnEvents, "expected the transaction events in a single response")
+			}
+			events += len(wresp.Events)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %d", events)
+		}
+	}
+}
+
+// TestWatchPacing checks that a watcher receiving a burst of events is paced
+// to the bandwidth limit of watchers without delaying the other watchers of
+// the stream.
+func TestWatchPacing(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	cluster := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, MaxWatcherBytesPerSecond: 2000})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := context.Background()
+
+	value := strings.Repeat("x", 1000)
+	var firstRev int64
+	for i := 0; i < 8; i++ {
+		resp, err := client.Put(ctx, "a", value)
+		require.NoError(t, err)
+		if firstRev == 0 {
+			firstRev = resp.Header.Revision
+		}
+	}
+
+	start := time.Now()
+	wcha := client.Watch(ctx, "a", clientv3.WithRev(firstRev))
+	wchb := client.Watch(ctx, "b")
+	_, err := client.Put(ctx, "b", "1")
+	require.NoError(t, err)
+
+	select {
+	case wresp := <-wchb:
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+	case <-time.After(time.Second):
+		t.Fatal("expected the other watcher not to be delayed")
+	}
+
+	events := 0
+	for events < 8 {
+		select {
+		case wresp := <-wcha:
+			require.NoError(t, wresp.Err())
+			events += len(wresp.Events)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for paced events, got %d", events)
+		}
+	}
+	// the 8KB of events are sent at 2KB per second, the first 2KB at once
+	require.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+}
+
 // TestWatchWithCreatedNotification checks that WithCreatedNotify returns a
 // Created watch response.
 func TestWatchWithCreatedNotification(t *testing.T) {
@@ -26,16 +26,24 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 
 	"go.etcd.io/etcd/client/pkg/v3/testutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 	"go.etcd.io/etcd/tests/v3/framework/testutils"
 )
@@ -178,6 +186,132 @@ func testEmbedEtcdGracefulStop(t *testing.T, secure bool) {
 	require.NoError(t, err)
 }
 
+func TestEmbedEtcdHooks(t *testing.T) {
+	testutil.SkipTestIfShortMode(t, "Cannot start embedded cluster in --short tests")
+
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+
+	var mu sync.Mutex
+	var unaryMethods, streamMethods []string
+	var appliedKeys []string
+	cfg.GRPCUnaryInterceptors = []grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			mu.Lock()
+			unaryMethods = append(unaryMethods, info.FullMethod)
+			mu.Unlock()
+			return handler(ctx, req)
+		},
+	}
+	cfg.GRPCStreamInterceptors = []grpc.StreamServerInterceptor{
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			mu.Lock()
+			streamMethods = append(streamMethods, info.FullMethod)
+			mu.Unlock()
+			return handler(srv, ss)
+		},
+	}
+	cfg.PostApplyHooks = []config.PostApplyHook{
+		func(index uint64, req *etcdserverpb.InternalRaftRequest, resp proto.Message, err error) {
+			if req.Put == nil {
+				return
+			}
+			assert.NoError(t, err)
+			assert.IsType(t, &etcdserverpb.PutResponse{}, resp)
+			mu.Lock()
+			appliedKeys = append(appliedKeys, string(req.Put.Key))
+			mu.Unlock()
+		},
+	}
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{urls[0].String()}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.Put(context.Background(), "foo", "bar")
+	require.NoError(t, err)
+	wctx, wcancel := context.WithCancel(context.Background())
+	defer wcancel()
+	wch := cli.Watch(wctx, "foo", clientv3.WithRev(1))
+	<-wch
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, unaryMethods, "/etcdserverpb.KV/Put")
+	assert.Contains(t, streamMethods, "/etcdserverpb.Watch/Watch")
+	assert.Equal(t, []string{"foo"}, appliedKeys)
+}
+
+// rangePlugin serves "/test.Plugin/Range" from the plugin store.
+type rangePlugin struct{}
+
+func (rangePlugin) Register(gs *grpc.Server, store embed.PluginStore) {
+	gs.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Plugin",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Range",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := &etcdserverpb.RangeRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if err := store.LinearizableRead(ctx); err != nil {
+					return nil, err
+				}
+				rr, err := store.Range(ctx, req.Key, req.RangeEnd, mvcc.RangeOptions{Rev: req.Revision})
+				if err != nil {
+					return nil, err
+				}
+				resp := &etcdserverpb.RangeResponse{Header: &etcdserverpb.ResponseHeader{Revision: rr.Rev}, Count: int64(rr.Count)}
+				for i := range rr.KVs {
+					resp.Kvs = append(resp.Kvs, &rr.KVs[i])
+				}
+				return resp, nil
+			},
+		}},
+	}, struct{}{})
+}
+
+func TestEmbedEtcdPlugin(t *testing.T) {
+	testutil.SkipTestIfShortMode(t, "Cannot start embedded cluster in --short tests")
+
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+	cfg.Plugins = []embed.Plugin{rangePlugin{}}
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{urls[0].String()}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	first, err := cli.Put(context.Background(), "foo", "v1")
+	require.NoError(t, err)
+	_, err = cli.Put(context.Background(), "foo", "v2")
+	require.NoError(t, err)
+
+	for rev, want := range map[int64]string{0: "v2", first.Header.Revision: "v1"} {
+		resp := &etcdserverpb.RangeResponse{}
+		err = cli.ActiveConnection().Invoke(context.Background(), "/test.Plugin/Range", &etcdserverpb.RangeRequest{Key: []byte("foo"), Revision: rev}, resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		assert.Equal(t, want, string(resp.Kvs[0].Value))
+	}
+}
+
 func newEmbedURLs(secure bool, n int) (urls []url.URL) {
 	scheme := "unix"
 	if secure {
@@ -20,11 +20,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	traceservice "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	"google.golang.org/grpc"
 
@@ -114,6 +116,160 @@ func TestTracing(t *testing.T) {
 	}
 }
 
+// TestTracingPropagation ensures that the server joins the trace of a client
+// which does not record spans itself, as etcdctl without a tracing endpoint,
+// and records the apply of the request.
+func TestTracingPropagation(t *testing.T) {
+	testutil.SkipTestIfShortMode(t,
+		"Wal creation tests are depending on embedded etcd server so are integration-level tests.")
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	traceFound := make(chan struct{}, 1)
+	srv := grpc.NewServer()
+	traceservice.RegisterTraceServiceServer(srv, &traceServer{
+		traceFound: traceFound,
+		filterFunc: func(req *traceservice.ExportTraceServiceRequest) bool {
+			for _, resourceSpans := range req.GetResourceSpans() {
+				for _, scoped := range resourceSpans.GetScopeSpans() {
+					for _, span := range scoped.GetSpans() {
+						if span.GetName() != "etcdserverpb.KV/Put" || trace.TraceID(span.GetTraceId()) != traceID {
+							continue
+						}
+						for _, event := range span.GetEvents() {
+							if event.GetName() == "applied" {
+								return true
+							}
+						}
+					}
+				}
+			}
+			return false
+		},
+	})
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	cfg := integration.NewEmbedConfig(t, "default")
+	cfg.EnableDistributedTracing = true
+	cfg.DistributedTracingAddress = listener.Addr().String()
+	cfg.DistributedTracingServiceName = "integration-test-tracing"
+	// only traces sampled by the client are recorded.
+	cfg.DistributedTracingSamplingRatePerMillion = 0
+
+	etcdSrv, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer etcdSrv.Close()
+	select {
+	case <-etcdSrv.Server.ReadyNotify():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to start embed.Etcd for test")
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(noop.NewTracerProvider()),
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)),
+	}
+	cli, err := integration.NewClient(t, clientv3.Config{DialOptions: dialOptions, Endpoints: []string{cfg.AdvertiseClientUrls[0].String()}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	_, err = cli.Put(ctx, "key", "value")
+	require.NoError(t, err)
+
+	select {
+	case <-traceFound:
+	case <-time.After(30 * time.Second):
+		t.Fatal("Timed out waiting for trace")
+	}
+}
+
+// TestTracingExemplars ensures that the backend commit latency of a sampled
+// request links to the trace of the request through an exemplar.
+func TestTracingExemplars(t *testing.T) {
+	testutil.SkipTestIfShortMode(t,
+		"Wal creation tests are depending on embedded etcd server so are integration-level tests.")
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	traceservice.RegisterTraceServiceServer(srv, &traceServer{
+		traceFound: make(chan struct{}, 1),
+		filterFunc: func(*traceservice.ExportTraceServiceRequest) bool { return false },
+	})
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	cfg := integration.NewEmbedConfig(t, "default")
+	cfg.EnableDistributedTracing = true
+	cfg.DistributedTracingAddress = listener.Addr().String()
+	cfg.DistributedTracingServiceName = "integration-test-tracing"
+	cfg.DistributedTracingSamplingRatePerMillion = 0
+
+	etcdSrv, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer etcdSrv.Close()
+	select {
+	case <-etcdSrv.Server.ReadyNotify():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to start embed.Etcd for test")
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(noop.NewTracerProvider()),
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)),
+	}
+	cli, err := integration.NewClient(t, clientv3.Config{DialOptions: dialOptions, Endpoints: []string{cfg.AdvertiseClientUrls[0].String()}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("b7ad6b7169203331")
+	require.NoError(t, err)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	_, err = cli.Put(ctx, "key", "value")
+	require.NoError(t, err)
+
+	// the backend commits periodically, after the request returned.
+	require.Eventually(t, func() bool {
+		mfs, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, mf := range mfs {
+			if mf.GetName() != "etcd_disk_backend_commit_duration_seconds" {
+				continue
+			}
+			for _, bucket := range mf.GetMetric()[0].GetHistogram().GetBucket() {
+				for _, l := range bucket.GetExemplar().GetLabel() {
+					if l.GetName() == "trace_id" && l.GetValue() == traceID.String() {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func containsNodeListSpan(req *traceservice.ExportTraceServiceRequest) bool {
 	for _, resourceSpans := range req.GetResourceSpans() {
 		for _, attr := range resourceSpans.GetResource().GetAttributes() {
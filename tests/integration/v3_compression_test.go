@@ -0,0 +1,117 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/compression"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3Compression ensures that the responses to clients compressing their
+// requests are compressed with the same algorithm.
+func TestV3Compression(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	value := strings.Repeat("compressible ", 64*1024)
+	for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			sh := &payloadStatsHandler{}
+			cli, err := integration.NewClient(t, clientv3.Config{
+				Endpoints:            clus.Client(0).Endpoints(),
+				CompressionAlgorithm: algorithm,
+				DialOptions:          []grpc.DialOption{grpc.WithStatsHandler(sh)},
+			})
+			require.NoError(t, err)
+			defer cli.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			_, err = cli.Put(ctx, "foo", value)
+			require.NoError(t, err)
+			resp, err := cli.Get(ctx, "foo")
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			assert.Equal(t, value, string(resp.Kvs[0].Value))
+			length, wireLength := sh.received()
+			assert.Less(t, wireLength, length/10, "range response is not compressed")
+
+			rc, err := cli.Snapshot(ctx)
+			require.NoError(t, err)
+			defer rc.Close()
+			var snap bytes.Buffer
+			_, err = io.Copy(&snap, rc)
+			require.NoError(t, err)
+			assert.Positive(t, snap.Len())
+			snapLength, snapWireLength := sh.received()
+			assert.Less(t, snapWireLength-wireLength, snapLength-length, "snapshot is not compressed")
+		})
+	}
+
+	_, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:            clus.Client(0).Endpoints(),
+		CompressionAlgorithm: "snappy",
+	})
+	require.Error(t, err)
+}
+
+// payloadStatsHandler sums the length of the received messages before and
+// after decompression.
+type payloadStatsHandler struct {
+	mu         sync.Mutex
+	length     int
+	wireLength int
+}
+
+func (h *payloadStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.length += in.Length
+		h.wireLength += in.CompressedLength
+	}
+}
+
+func (h *payloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *payloadStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+func (h *payloadStatsHandler) received() (length, wireLength int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.length, h.wireLength
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3ConfigSet ensures runtime adjustable settings are changed atomically
+// and take effect without restarting the member.
+func TestV3ConfigSet(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	srv := clus.Members[0].Server
+	mc := integration.ToGRPC(clus.Client(0)).Maintenance
+	kvc := integration.ToGRPC(clus.Client(0)).KV
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	configSet := func(settings ...string) (map[string]string, error) {
+		req := &pb.ConfigSetRequest{}
+		for _, s := range settings {
+			name, value, _ := strings.Cut(s, "=")
+			req.Settings = append(req.Settings, &pb.ConfigSetting{Name: name, Value: value})
+		}
+		resp, err := mc.ConfigSet(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		current := make(map[string]string)
+		for _, s := range resp.Settings {
+			current[s.Name] = s.Value
+		}
+		return current, nil
+	}
+
+	current, err := configSet()
+	require.NoError(t, err)
+	require.Equal(t, srv.Cfg.WarningApplyDuration.String(), current["warning-apply-duration"])
+
+	current, err = configSet("warning-apply-duration=1s", "max-request-bytes=1024", "compaction-batch-limit=10")
+	require.NoError(t, err)
+	require.Equal(t, "1s", current["warning-apply-duration"])
+	require.Equal(t, "1024", current["max-request-bytes"])
+	require.Equal(t, "10", current["compaction-batch-limit"])
+	require.Equal(t, time.Second, srv.WarningApplyDuration())
+	require.Equal(t, 10, srv.KV().CompactionConfig().CompactionBatchLimit)
+
+	current, err = configSet("backend-batch-interval=10ms", "backend-batch-limit=100", "backend-batch-limit-max=1000")
+	require.NoError(t, err)
+	require.Equal(t, "10ms", current["backend-batch-interval"])
+	require.Equal(t, "100", current["backend-batch-limit"])
+	require.Equal(t, "1000", current["backend-batch-limit-max"])
+	require.Equal(t, backend.BatchConfig{Interval: 10 * time.Millisecond, Limit: 100, LimitMax: 1000}, srv.Backend().BatchConfig())
+
+	_, err = kvc.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: make([]byte, 2048)})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCRequestTooLarge)
+
+	for _, settings := range [][]string{
+		{"warning-apply-duration=2s", "unknown=1"},
+		{"warning-apply-duration=2s", "max-request-bytes=1073741824"},
+		{"warning-apply-duration=-1s"},
+		{"watch-progress-notify-interval=1ms"},
+		{"backend-batch-limit=0"},
+		{"backend-batch-limit-max=-1"},
+	} {
+		_, err = configSet(settings...)
+		require.Equalf(t, codes.InvalidArgument, status.Code(err), "settings %v: %v", settings, err)
+	}
+	// an invalid setting leaves all settings unchanged.
+	require.Equal(t, time.Second, srv.WarningApplyDuration())
+
+	_, err = configSet("max-concurrent-streams=1")
+	require.NoError(t, err)
+	first, err := integration.ToGRPC(clus.Client(0)).Watch.Watch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, first.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")},
+	}}))
+	wresp, err := first.Recv()
+	require.NoError(t, err)
+	require.True(t, wresp.Created)
+	second, err := integration.ToGRPC(clus.Client(0)).Watch.Watch(ctx)
+	require.NoError(t, err)
+	_, err = second.Recv()
+	require.ErrorIs(t, err, rpctypes.ErrGRPCTooManyStreams)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3DrainLeader ensures a drained leader transfers its leadership, closes
+// its client streams and refuses new client connections, while it keeps
+// serving the existing ones.
+func TestV3DrainLeader(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	leadIdx := clus.WaitLeader(t)
+	lead := integration.ToGRPC(clus.Client(leadIdx))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	wStream, err := lead.Watch.Watch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")},
+	}}))
+	wresp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, wresp.Created)
+
+	dresp, err := lead.Maintenance.Drain(ctx, &pb.DrainRequest{})
+	require.NoError(t, err)
+	require.True(t, dresp.LeaderTransferred)
+	require.NotEqual(t, clus.Members[leadIdx].Server.MemberID(), clus.Members[leadIdx].Server.Leader())
+	require.True(t, clus.Members[leadIdx].Server.IsDraining())
+
+	_, err = wStream.Recv()
+	require.ErrorIs(t, err, rpctypes.ErrGRPCDraining)
+
+	_, err = lead.KV.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	require.NoError(t, err)
+	_, err = integration.NewClientV3(clus.Members[leadIdx])
+	require.Error(t, err, "a new client connection to the drained member should be refused")
+
+	_, err = lead.Maintenance.Status(ctx, &pb.StatusRequest{})
+	require.NoError(t, err)
+
+	follower := integration.ToGRPC(clus.Client((leadIdx + 1) % 3))
+	_, err = follower.KV.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	require.NoError(t, err)
+}
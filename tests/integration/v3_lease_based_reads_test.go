@@ -0,0 +1,77 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3LeaseBasedReadsFollower ensures followers serve linearizable reads
+// based on their follower lease, and observe every write completed before the
+// read, whichever member the write went through.
+func TestV3LeaseBasedReadsFollower(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, LeaseBasedReads: true})
+	defer clus.Terminate(t)
+
+	leadIdx := clus.WaitLeader(t)
+	followerIdx := (leadIdx + 1) % 3
+	otherIdx := (leadIdx + 2) % 3
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for i := 0; i < 30; i++ {
+		writer := clus.Client([]int{leadIdx, otherIdx}[i%2])
+		val := fmt.Sprintf("v%d", i)
+		_, err := writer.Put(ctx, "foo", val)
+		require.NoError(t, err)
+
+		resp, err := clus.Client(followerIdx).Get(ctx, "foo")
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		require.Equal(t, val, string(resp.Kvs[0].Value))
+	}
+
+	require.Eventually(t, func() bool {
+		if _, err := clus.Client(followerIdx).Get(ctx, "foo"); err != nil {
+			return false
+		}
+		reads, err := clus.Members[followerIdx].Metric("etcd_server_follower_lease_reads_total")
+		if err != nil {
+			return false
+		}
+		n, err := strconv.Atoi(reads)
+		return err == nil && n > 0
+	}, 10*time.Second, 10*time.Millisecond, "the follower should serve reads based on its lease")
+
+	// the writes are held back only until the lease of a stopped follower
+	// expires.
+	clus.Members[otherIdx].Stop(t)
+	_, err := clus.Client(leadIdx).Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	resp, err := clus.Client(followerIdx).Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(resp.Kvs[0].Value))
+}
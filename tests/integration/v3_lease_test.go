@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -281,6 +282,37 @@ func TestV3LeaseExpire(t *testing.T) {
 	})
 }
 
+// TestV3LeaseExpireWithFakeClock ensures a lease expires once its TTL passed
+// on the clock of the cluster, without waiting in real time.
+func TestV3LeaseExpireWithFakeClock(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, Clock: clockwork.NewFakeClock()})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	lc := integration.ToGRPC(clus.RandClient()).Lease
+	lresp, err := lc.LeaseGrant(ctx, &pb.LeaseGrantRequest{TTL: 60})
+	require.NoError(t, err)
+	_, err = kvc.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: lresp.ID})
+	require.NoError(t, err)
+
+	clus.AdvanceClock(50 * time.Second)
+	ttl, err := lc.LeaseTimeToLive(ctx, &pb.LeaseTimeToLiveRequest{ID: lresp.ID})
+	require.NoError(t, err)
+	require.LessOrEqual(t, ttl.TTL, int64(10))
+	rresp, err := kvc.Range(ctx, &pb.RangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+	require.Len(t, rresp.Kvs, 1)
+
+	clus.AdvanceClock(10 * time.Second)
+	require.Eventually(t, func() bool {
+		rresp, err = kvc.Range(ctx, &pb.RangeRequest{Key: []byte("foo")})
+		return err == nil && len(rresp.Kvs) == 0
+	}, 5*time.Second, 10*time.Millisecond, "leased key not deleted")
+}
+
 // TestV3LeaseKeepAlive ensures keepalive keeps the lease alive.
 func TestV3LeaseKeepAlive(t *testing.T) {
 	integration.BeforeTest(t)
@@ -314,6 +346,39 @@ func TestV3LeaseKeepAlive(t *testing.T) {
 	})
 }
 
+func TestV3LeaseRenew(t *testing.T) {
+	integration.BeforeTest(t)
+	testLeaseRemoveLeasedKey(t, func(clus *integration.Cluster, leaseID int64) error {
+		lc := integration.ToGRPC(clus.RandClient()).Lease
+
+		// renew long enough so lease would've expired otherwise
+		for i := 0; i < 3; i++ {
+			lresp, err := lc.LeaseRenew(context.TODO(), &pb.LeaseRenewRequest{ID: leaseID})
+			if err != nil {
+				return err
+			}
+			if lresp.ID != leaseID {
+				return fmt.Errorf("expected lease ID %v, got %v", leaseID, lresp.ID)
+			}
+			time.Sleep(time.Duration(lresp.TTL/2) * time.Second)
+		}
+		_, err := lc.LeaseRevoke(context.TODO(), &pb.LeaseRevokeRequest{ID: leaseID})
+		if err != nil {
+			return err
+		}
+
+		// a revoked lease is renewed with a zero TTL, as by keep alive requests.
+		lresp, err := lc.LeaseRenew(context.TODO(), &pb.LeaseRenewRequest{ID: leaseID})
+		if err != nil {
+			return err
+		}
+		if lresp.TTL != 0 {
+			return fmt.Errorf("expected TTL 0 for revoked lease, got %v", lresp.TTL)
+		}
+		return nil
+	})
+}
+
 // TestV3LeaseCheckpoint ensures a lease checkpoint results in a remaining TTL being persisted
 // across leader elections.
 func TestV3LeaseCheckpoint(t *testing.T) {
@@ -472,6 +537,39 @@ func TestV3LeaseLeases(t *testing.T) {
 	}
 }
 
+// TestV3LeaseLeasesDetails ensures the details of the leases are listed from
+// the leader, whichever member serves the request.
+func TestV3LeaseLeasesDetails(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	labels := []*pb.LeaseLabel{{Key: "host", Value: "a"}, {Key: "pid", Value: "1"}}
+	lresp, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(ctx, &pb.LeaseGrantRequest{TTL: 30, Labels: labels})
+	require.NoError(t, err)
+	_, err = integration.ToGRPC(clus.RandClient()).KV.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: lresp.ID})
+	require.NoError(t, err)
+
+	_, err = integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(ctx, &pb.LeaseGrantRequest{TTL: 30, Labels: []*pb.LeaseLabel{{Key: "host"}, {Key: "host"}}})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCLeaseLabelInvalid)
+
+	for i := range clus.Members {
+		resp, err := integration.ToGRPC(clus.Client(i)).Lease.LeaseLeases(ctx, &pb.LeaseLeasesRequest{Details: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Leases, 1)
+		ls := resp.Leases[0]
+		require.Equal(t, lresp.ID, ls.ID)
+		require.Equal(t, int64(30), ls.GrantedTTL)
+		require.Positive(t, ls.TTL)
+		require.LessOrEqual(t, ls.TTL, int64(30))
+		require.Equal(t, int64(1), ls.Keys)
+		require.Equal(t, labels, ls.Labels)
+	}
+}
+
 // TestV3LeaseRenewStress keeps creating lease and renewing it immediately to ensure the renewal goes through.
 // it was oberserved that the immediate lease renewal after granting a lease from follower resulted lease not found.
 // related issue https://github.com/etcd-io/etcd/issues/6978
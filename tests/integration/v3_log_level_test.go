@@ -0,0 +1,85 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3LogLevelSet ensures the log level of a subsystem can be lowered at
+// runtime without affecting other subsystems, and reverts after its TTL.
+func TestV3LogLevelSet(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	m := clus.Members[0]
+	gc := integration.ToGRPC(clus.Client(0))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	levels := func(resp *pb.LogLevelSetResponse) map[string]string {
+		current := make(map[string]string)
+		for _, l := range resp.Levels {
+			current[l.Subsystem] = l.Level
+		}
+		return current
+	}
+
+	resp, err := gc.Maintenance.LogLevelSet(ctx, &pb.LogLevelSetRequest{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"auth": "info", "grpc": "info", "mvcc": "info", "raft": "info"}, levels(resp))
+
+	for _, settings := range [][]*pb.LogLevelSetting{
+		{{Subsystem: "auth", Level: "debug"}, {Subsystem: "unknown", Level: "debug"}},
+		{{Subsystem: "auth", Level: "verbose"}},
+		{{Subsystem: "auth", Level: "debug", Ttl: -1}},
+	} {
+		_, err = gc.Maintenance.LogLevelSet(ctx, &pb.LogLevelSetRequest{Settings: settings})
+		require.Equalf(t, codes.InvalidArgument, status.Code(err), "settings %v: %v", settings, err)
+	}
+
+	resp, err = gc.Maintenance.LogLevelSet(ctx, &pb.LogLevelSetRequest{Settings: []*pb.LogLevelSetting{
+		{Subsystem: "auth", Level: "debug", Ttl: 2},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"auth": "debug", "grpc": "info", "mvcc": "info", "raft": "info"}, levels(resp))
+
+	_, err = gc.Auth.UserAdd(ctx, &pb.AuthUserAddRequest{Name: "root", Password: "123"})
+	require.NoError(t, err)
+	_, err = gc.Auth.UserGrantRole(ctx, &pb.AuthUserGrantRoleRequest{User: "root", Role: "root"})
+	require.NoError(t, err)
+	_, err = gc.Auth.AuthEnable(ctx, &pb.AuthEnableRequest{})
+	require.NoError(t, err)
+	_, err = gc.Auth.Authenticate(ctx, &pb.AuthenticateRequest{Name: "root", Password: "123"})
+	require.NoError(t, err)
+	_, err = m.LogObserver.Expect(ctx, "authenticated a user", 1)
+	require.NoError(t, err)
+
+	// levels are sorted by subsystem, auth first.
+	require.Eventually(t, func() bool {
+		return m.Server.Cfg.LogLevels.Levels()[0].Level.String() == "info"
+	}, 10*time.Second, 100*time.Millisecond)
+}
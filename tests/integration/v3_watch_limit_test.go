@@ -0,0 +1,142 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3WatchLimitPerStream ensures watchers exceeding the maximum number of
+// watchers of a stream are canceled with a typed error, and that canceled
+// watchers are no longer counted.
+func TestV3WatchLimitPerStream(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxWatchersPerStream: 2})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// watchers with the same context share the same stream.
+	wctx, wcancel := context.WithCancel(ctx)
+	defer wcancel()
+	w1 := cli.Watch(wctx, "a", clientv3.WithCreatedNotify())
+	requireWatchCreated(t, w1)
+	cctx, ccancel := context.WithCancel(wctx)
+	w2 := cli.Watch(cctx, "b", clientv3.WithCreatedNotify())
+	requireWatchCreated(t, w2)
+
+	w3 := cli.Watch(wctx, "c", clientv3.WithCreatedNotify())
+	requireWatchCanceled(t, w3, rpctypes.ErrGRPCTooManyWatchers)
+
+	// another stream has its own limit.
+	w4 := cli.Watch(metadata.AppendToOutgoingContext(ctx, "stream", "other"), "d", clientv3.WithCreatedNotify())
+	requireWatchCreated(t, w4)
+
+	ccancel()
+	require.Eventually(t, func() bool {
+		w := cli.Watch(wctx, "e", clientv3.WithCreatedNotify())
+		resp := <-w
+		return resp.Created && resp.Err() == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+// TestV3WatchLimitPerUser ensures the watchers of an authenticated user are
+// limited across watch streams.
+func TestV3WatchLimitPerUser(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxWatchersPerUser: 2})
+	defer clus.Terminate(t)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, err)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// each watcher has its own stream.
+	for i := 0; i < 2; i++ {
+		w := cli.Watch(metadata.AppendToOutgoingContext(ctx, "stream", fmt.Sprint(i)), "a", clientv3.WithCreatedNotify())
+		requireWatchCreated(t, w)
+	}
+	w := cli.Watch(metadata.AppendToOutgoingContext(ctx, "stream", "2"), "a", clientv3.WithCreatedNotify())
+	requireWatchCanceled(t, w, rpctypes.ErrGRPCTooManyWatchers)
+}
+
+// TestV3StreamLimitPerConnection ensures streams exceeding the maximum number
+// of streams of a client connection fail with a typed error, and that closed
+// streams are no longer counted.
+func TestV3StreamLimitPerConnection(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxStreamsPerConnection: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	wc := integration.ToGRPC(clus.Client(0)).Watch
+	watch := func(ctx context.Context) error {
+		stream, err := wc.Watch(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+			CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")},
+		}}))
+		_, err = stream.Recv()
+		return err
+	}
+	wctx, wcancel := context.WithCancel(ctx)
+	require.NoError(t, watch(wctx))
+	require.ErrorIs(t, watch(ctx), rpctypes.ErrGRPCTooManyStreams)
+
+	wcancel()
+	require.Eventually(t, func() bool {
+		return watch(ctx) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func requireWatchCreated(t *testing.T, w clientv3.WatchChan) {
+	t.Helper()
+	select {
+	case resp := <-w:
+		require.NoError(t, resp.Err())
+		require.True(t, resp.Created)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch creation")
+	}
+}
+
+func requireWatchCanceled(t *testing.T, w clientv3.WatchChan, want error) {
+	t.Helper()
+	select {
+	case resp := <-w:
+		require.True(t, resp.Canceled)
+		require.ErrorContains(t, resp.Err(), rpctypes.ErrorDesc(want))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch cancellation")
+	}
+}
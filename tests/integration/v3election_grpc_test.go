@@ -163,3 +163,55 @@ func TestV3ElectionObserve(t *testing.T) {
 
 	<-leader2c
 }
+
+// TestV3ElectionObserveHistory checks that an Observe stream replays the last
+// leadership changes with increasing epochs before the new proclamations.
+func TestV3ElectionObserveHistory(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Election
+	var leader *epb.LeaderKey
+	for _, v := range []string{"a", "b", "c"} {
+		if leader != nil {
+			_, err := lc.Resign(context.TODO(), &epb.ResignRequest{Leader: leader})
+			require.NoError(t, err)
+		}
+		lease, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 30})
+		require.NoError(t, err)
+		c, err := lc.Campaign(context.TODO(), &epb.CampaignRequest{Name: []byte("foo"), Lease: lease.ID, Value: []byte(v)})
+		require.NoError(t, err)
+		leader = c.Leader
+	}
+	// a proclamation is not a leadership change.
+	_, err := lc.Proclaim(context.TODO(), &epb.ProclaimRequest{Leader: leader, Value: []byte("c1")})
+	require.NoError(t, err)
+
+	lresp, err := lc.Leader(context.TODO(), &epb.LeaderRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Equal(t, leader.Rev, lresp.Epoch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := lc.Observe(ctx, &epb.LeaderRequest{Name: []byte("foo"), History: 2})
+	require.NoError(t, err)
+	var epochs []int64
+	for _, v := range []string{"b", "c", "c1"} {
+		resp, err := s.Recv()
+		require.NoError(t, err)
+		require.Equal(t, v, string(resp.Kv.Value))
+		require.Equal(t, resp.Kv.CreateRevision, resp.Epoch)
+		require.GreaterOrEqual(t, resp.Header.Revision, resp.Kv.ModRevision)
+		epochs = append(epochs, resp.Epoch)
+	}
+	require.Less(t, epochs[0], epochs[1])
+	require.Equal(t, leader.Rev, epochs[2])
+
+	_, err = lc.Proclaim(context.TODO(), &epb.ProclaimRequest{Leader: leader, Value: []byte("c2")})
+	require.NoError(t, err)
+	resp, err := s.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "c2", string(resp.Kv.Value))
+	require.Equal(t, leader.Rev, resp.Epoch)
+}
@@ -69,3 +69,54 @@ func TestV3LockLockWaiter(t *testing.T) {
 	case <-lockc:
 	}
 }
+
+// TestV3LockStatusForceUnlock tests that the holder and the waiters of a lock
+// are listed, and that breaking the lock hands it over to the first waiter.
+func TestV3LockStatusForceUnlock(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Lock
+	status, err := lc.LockStatus(context.TODO(), &lockpb.LockStatusRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Nil(t, status.Holder)
+	_, err = lc.ForceUnlock(context.TODO(), &lockpb.ForceUnlockRequest{Name: []byte("foo")})
+	require.ErrorContains(t, err, "lock is not held")
+
+	// the server grants the lease of the holder.
+	l1, err := lc.Lock(context.TODO(), &lockpb.LockRequest{Name: []byte("foo"), Ttl: 30})
+	require.NoError(t, err)
+	require.NotZero(t, l1.Lease)
+
+	lease2, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 30})
+	require.NoError(t, err)
+	lockc := make(chan *lockpb.LockResponse, 1)
+	go func() {
+		l2, lerr := lc.Lock(context.TODO(), &lockpb.LockRequest{Name: []byte("foo"), Lease: lease2.ID})
+		if lerr != nil {
+			t.Error(lerr)
+		}
+		lockc <- l2
+	}()
+	require.Eventually(t, func() bool {
+		status, err = lc.LockStatus(context.TODO(), &lockpb.LockStatusRequest{Name: []byte("foo")})
+		require.NoError(t, err)
+		return len(status.Waiters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, l1.Key, status.Holder.Key)
+	require.Equal(t, l1.Lease, status.Holder.Lease)
+	require.Positive(t, status.Holder.Ttl)
+	require.Equal(t, lease2.ID, status.Waiters[0].Lease)
+	require.Greater(t, status.Waiters[0].CreateRevision, status.Holder.CreateRevision)
+
+	broken, err := lc.ForceUnlock(context.TODO(), &lockpb.ForceUnlockRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Equal(t, l1.Key, broken.Holder.Key)
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("waiter did not lock after force unlock")
+	case l2 := <-lockc:
+		require.Equal(t, status.Waiters[0].Key, l2.Key)
+	}
+}
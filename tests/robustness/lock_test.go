@@ -0,0 +1,240 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3election/v3electionpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/failpoint"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+)
+
+const (
+	lockPrefix          = "/robustness/lock/"
+	lockNames           = 2
+	lockClientsPerName  = 3
+	lockTrafficDuration = 10 * time.Second
+	// lockSessionTTL is short so that the locks of abandoned sessions expire
+	// during the test.
+	lockSessionTTL = 2
+	// lockAbandonPercent is the percentage of sessions a client abandons
+	// while holding the lock, as if it crashed.
+	lockAbandonPercent = 20
+)
+
+// lockService acquires name for the lease, uses it, then releases it unless
+// abandon is set. It returns the observations of the ownership of name
+// implied by the responses.
+type lockService func(ctx context.Context, c *clientv3.Client, clientID int, name string, lease clientv3.LeaseID, abandon bool) []model.LockObservation
+
+func TestRobustnessLock(t *testing.T) {
+	testRunner.BeforeTest(t)
+	for _, svc := range []struct {
+		name    string
+		service lockService
+	}{
+		{name: "Lock", service: lockAndWrite},
+		{name: "Election", service: campaignAndProclaim},
+	} {
+		for _, fp := range []failpoint.Failpoint{failpoint.KillFailpoint, failpoint.BlackholePeerNetwork} {
+			t.Run(fmt.Sprintf("%s/%s", svc.name, fp.Name()), func(t *testing.T) {
+				lg := zaptest.NewLogger(t)
+				ctx := context.Background()
+				clus, err := e2e.NewEtcdProcessCluster(ctx, t,
+					e2e.WithClusterSize(3),
+					e2e.WithIsPeerTLS(true),
+					e2e.WithPeerProxy(true),
+				)
+				require.NoError(t, err)
+				defer forcestopCluster(clus)
+
+				observations := runLockTraffic(ctx, t, lg, clus, fp, svc.service)
+				history := lockHistory(ctx, t, clus)
+				lg.Info("Validating lock observations", zap.Int("observations", len(observations)), zap.Int("events", len(history)))
+				require.NotEmpty(t, observations)
+				require.NoError(t, model.ValidateLockObservations(model.NewLockHistory(history), observations))
+			})
+		}
+	}
+}
+
+// runLockTraffic runs clients contending for the locks while the failpoint is
+// repeatedly injected, and returns their observations.
+func runLockTraffic(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, fp failpoint.Failpoint, service lockService) []model.LockObservation {
+	trafficCtx, cancel := context.WithTimeout(ctx, lockTrafficDuration)
+	defer cancel()
+	g := errgroup.Group{}
+	g.Go(func() error {
+		ids := identity.NewIDProvider()
+		for trafficCtx.Err() == nil {
+			time.Sleep(WaitBeforeFailpoint)
+			// the failpoint is not interrupted by the end of the traffic, so
+			// that the cluster is healthy once it returns.
+			if _, err := failpoint.Inject(ctx, t, lg, clus, fp, time.Now(), ids); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var mu sync.Mutex
+	var observations []model.LockObservation
+	for i := 0; i < lockNames*lockClientsPerName; i++ {
+		clientID, name := i, fmt.Sprintf("%slock-%d", lockPrefix, i%lockNames)
+		g.Go(func() error {
+			c, err := clientv3.New(clientv3.Config{
+				Endpoints:            clus.EndpointsGRPC(),
+				Logger:               zap.NewNop(),
+				DialKeepAliveTime:    10 * time.Second,
+				DialKeepAliveTimeout: 100 * time.Millisecond,
+			})
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			for trafficCtx.Err() == nil {
+				obs := runLockSession(trafficCtx, c, clientID, name, service)
+				mu.Lock()
+				observations = append(observations, obs...)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	return observations
+}
+
+func runLockSession(ctx context.Context, c *clientv3.Client, clientID int, name string, service lockService) []model.LockObservation {
+	lease, err := c.Grant(ctx, lockSessionTTL)
+	if err != nil {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}
+	keepAliveCtx, stopKeepAlive := context.WithCancel(ctx)
+	defer stopKeepAlive()
+	if _, err = c.KeepAlive(keepAliveCtx, lease.ID); err != nil {
+		return nil
+	}
+	serviceCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	abandon := rand.Intn(100) < lockAbandonPercent
+	obs := service(serviceCtx, c, clientID, name, lease.ID, abandon)
+	if !abandon {
+		c.Revoke(serviceCtx, lease.ID)
+	}
+	return obs
+}
+
+// lockAndWrite acquires the lock, then writes a resource guarded by the
+// existence of the lock key.
+func lockAndWrite(ctx context.Context, c *clientv3.Client, clientID int, name string, lease clientv3.LeaseID, abandon bool) []model.LockObservation {
+	lc := v3lockpb.NewLockClient(c.ActiveConnection())
+	resp, err := lc.Lock(ctx, &v3lockpb.LockRequest{Name: []byte(name), Lease: int64(lease)})
+	if err != nil {
+		return nil
+	}
+	key := string(resp.Key)
+	obs := []model.LockObservation{{ClientID: clientID, Operation: "lock", Name: name, Key: key, Revision: resp.Header.Revision}}
+	for i := 0; i < 3; i++ {
+		tresp, err := c.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), ">", 0)).
+			Then(clientv3.OpPut(name+"-resource", key)).
+			Commit()
+		if err != nil || !tresp.Succeeded {
+			break
+		}
+		// the lock is owned right before the guarded write.
+		obs = append(obs, model.LockObservation{ClientID: clientID, Operation: "guarded put", Name: name, Key: key, Revision: tresp.Header.Revision - 1})
+	}
+	if !abandon {
+		lc.Unlock(ctx, &v3lockpb.UnlockRequest{Key: resp.Key})
+	}
+	return obs
+}
+
+// campaignAndProclaim campaigns to be the leader, then proclaims values and
+// reads the leader. The header of the campaign response is the one of the
+// request creating the campaign key, before the campaigner became the leader,
+// so it does not imply the ownership of the election.
+func campaignAndProclaim(ctx context.Context, c *clientv3.Client, clientID int, name string, lease clientv3.LeaseID, abandon bool) []model.LockObservation {
+	ec := v3electionpb.NewElectionClient(c.ActiveConnection())
+	resp, err := ec.Campaign(ctx, &v3electionpb.CampaignRequest{Name: []byte(name), Lease: int64(lease), Value: []byte("campaign")})
+	if err != nil {
+		return nil
+	}
+	key := string(resp.Leader.Key)
+	var obs []model.LockObservation
+	for i := 0; i < 3; i++ {
+		presp, err := ec.Proclaim(ctx, &v3electionpb.ProclaimRequest{Leader: resp.Leader, Value: []byte(fmt.Sprintf("proclaim-%d", i))})
+		if err != nil {
+			break
+		}
+		// the leader key is updated by the proclamation, so the election is
+		// owned right before it.
+		obs = append(obs, model.LockObservation{ClientID: clientID, Operation: "proclaim", Name: name, Key: key, Revision: presp.Header.Revision - 1})
+	}
+	if lresp, err := ec.Leader(ctx, &v3electionpb.LeaderRequest{Name: []byte(name)}); err == nil {
+		obs = append(obs, model.LockObservation{ClientID: clientID, Operation: "leader", Name: name, Key: string(lresp.Kv.Key), Revision: lresp.Header.Revision})
+	}
+	if !abandon {
+		ec.Resign(ctx, &v3electionpb.ResignRequest{Leader: resp.Leader})
+	}
+	return obs
+}
+
+// lockHistory returns the changes of the keys of the locks, from the first
+// revision.
+func lockHistory(ctx context.Context, t *testing.T, clus *e2e.EtcdProcessCluster) []model.LockEvent {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	c, err := clientv3.New(clientv3.Config{Endpoints: clus.EndpointsGRPC(), Logger: zap.NewNop()})
+	require.NoError(t, err)
+	defer c.Close()
+	// the watch is complete once it returns the write of a key outside of
+	// the locks, made after the traffic.
+	resp, err := c.Put(ctx, lockPrefix+"end", "")
+	require.NoError(t, err)
+	lastRevision := resp.Header.Revision
+
+	var events []model.LockEvent
+	for wresp := range c.Watch(ctx, lockPrefix, clientv3.WithPrefix(), clientv3.WithRev(1)) {
+		require.NoError(t, wresp.Err())
+		for _, e := range wresp.Events {
+			if e.Kv.ModRevision >= lastRevision {
+				return events
+			}
+			events = append(events, model.LockEvent{Key: string(e.Kv.Key), Revision: e.Kv.ModRevision, Delete: e.Type == mvccpb.DELETE})
+		}
+	}
+	require.NoError(t, ctx.Err())
+	return events
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LockEvent is a change of a key of a lock or an election, as observed by a
+// watch from the first revision.
+type LockEvent struct {
+	Key      string
+	Revision int64
+	Delete   bool
+}
+
+// LockHistory is the history of the keys of locks and elections. Both the
+// lock and the election services order the contenders of a name by the create
+// revision of their key under the name + "/" prefix: the owner of a lock, or
+// the leader of an election, is the oldest existing key.
+type LockHistory struct {
+	events []LockEvent
+}
+
+// NewLockHistory returns the history of the given events.
+func NewLockHistory(events []LockEvent) *LockHistory {
+	events = append([]LockEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Revision < events[j].Revision })
+	return &LockHistory{events: events}
+}
+
+// Owner returns the key owning the lock, or leading the election, of the given
+// name at the revision, or an empty string if there is none.
+func (h *LockHistory) Owner(name string, revision int64) string {
+	prefix := name + "/"
+	createRevisions := make(map[string]int64)
+	for _, e := range h.events {
+		if e.Revision > revision {
+			break
+		}
+		if !strings.HasPrefix(e.Key, prefix) {
+			continue
+		}
+		if e.Delete {
+			delete(createRevisions, e.Key)
+			continue
+		}
+		if _, ok := createRevisions[e.Key]; !ok {
+			createRevisions[e.Key] = e.Revision
+		}
+	}
+	owner, ownerRevision := "", int64(0)
+	for key, rev := range createRevisions {
+		if owner == "" || rev < ownerRevision {
+			owner, ownerRevision = key, rev
+		}
+	}
+	return owner
+}
+
+// LockObservation is a response of a client of the lock or election services
+// implying that Key owned the lock, or led the election, of Name at Revision.
+type LockObservation struct {
+	ClientID int
+	// Operation describes the request the response was received for.
+	Operation string
+	Name      string
+	Key       string
+	Revision  int64
+}
+
+// ValidateLockObservations checks that the observations are consistent with
+// the history, i.e. that no two clients owned a lock at the same revision.
+func ValidateLockObservations(h *LockHistory, observations []LockObservation) error {
+	var errs []error
+	for _, o := range observations {
+		if owner := h.Owner(o.Name, o.Revision); owner != o.Key {
+			errs = append(errs, fmt.Errorf("client %d %s: %q owns %q at revision %d, history has owner %q",
+				o.ClientID, o.Operation, o.Key, o.Name, o.Revision, owner))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockHistoryOwner(t *testing.T) {
+	h := NewLockHistory([]LockEvent{
+		{Key: "lock/b", Revision: 3},
+		{Key: "lock/a", Revision: 2},
+		{Key: "lock-resource", Revision: 4},
+		// a put on an existing key does not change its create revision.
+		{Key: "lock/b", Revision: 5},
+		{Key: "lock/a", Revision: 6, Delete: true},
+		{Key: "lock/c", Revision: 7},
+		{Key: "lock/b", Revision: 8, Delete: true},
+		{Key: "lock/c", Revision: 9, Delete: true},
+	})
+	for rev, owner := range []string{"", "", "lock/a", "lock/a", "lock/a", "lock/a", "lock/b", "lock/b", "lock/c", ""} {
+		assert.Equalf(t, owner, h.Owner("lock", int64(rev)), "revision %d", rev)
+	}
+	assert.Empty(t, h.Owner("lock-resource", 4))
+}
+
+func TestValidateLockObservations(t *testing.T) {
+	h := NewLockHistory([]LockEvent{
+		{Key: "lock/a", Revision: 2},
+		{Key: "lock/b", Revision: 3},
+		{Key: "lock/a", Revision: 4, Delete: true},
+	})
+	require.NoError(t, ValidateLockObservations(h, []LockObservation{
+		{ClientID: 0, Operation: "lock", Name: "lock", Key: "lock/a", Revision: 2},
+		{ClientID: 0, Operation: "guarded put", Name: "lock", Key: "lock/a", Revision: 3},
+		{ClientID: 1, Operation: "lock", Name: "lock", Key: "lock/b", Revision: 4},
+	}))
+	err := ValidateLockObservations(h, []LockObservation{
+		{ClientID: 1, Operation: "lock", Name: "lock", Key: "lock/b", Revision: 3},
+		{ClientID: 0, Operation: "guarded put", Name: "lock", Key: "lock/a", Revision: 4},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `client 1 lock: "lock/b" owns "lock" at revision 3, history has owner "lock/a"`)
+	assert.Contains(t, err.Error(), `client 0 guarded put: "lock/a" owns "lock" at revision 4, history has owner "lock/b"`)
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/yaml"
+
+	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/report"
+)
+
+// scenarioCmd represents the scenario command
+var scenarioCmd = &cobra.Command{
+	Use:   "scenario",
+	Short: "Benchmark a concurrent mix of operations described in a config file",
+	Long: `Benchmarks a concurrent mix of operations described in a YAML config file,
+and reports the latency distribution of each operation type. For example:
+
+  duration: 1m           # or "total", the number of requests to send
+  rate: 1000             # arrival rate of requests per second (0 is no limit)
+  key-prefix: /bench/
+  key-space-size: 10000
+  val-size: 256
+  watchers: 100          # watchers of the key prefix
+  operations:
+  - type: range
+    weight: 70
+    serializable: true
+  - type: put
+    weight: 20
+  - type: txn
+    weight: 10
+
+Operations are picked at random in proportion to their weight. "range" gets a
+random key, or the first "limit" keys of the key prefix when limit is set; "put"
+and "delete" write a random key; "txn" creates a random key if missing, and
+otherwise reads and updates it.
+
+Requests arrive at the configured rate regardless of the latency of previous
+requests, so that the reported latency includes the time a request waited for
+a free client. The latency of watchers is the time between sending a put and
+receiving its event.`,
+	Run: scenarioFunc,
+}
+
+var scenarioConfigPath string
+
+func init() {
+	RootCmd.AddCommand(scenarioCmd)
+	scenarioCmd.Flags().StringVar(&scenarioConfigPath, "config", "", "Path to the scenario config file")
+}
+
+// Types of the operations of a scenario.
+const (
+	scenarioRange  = "range"
+	scenarioPut    = "put"
+	scenarioDelete = "delete"
+	scenarioTxn    = "txn"
+
+	// scenarioWatch names the latency report of watchers.
+	scenarioWatch = "watch"
+)
+
+type scenarioConfig struct {
+	Duration     string              `json:"duration"`
+	Total        int                 `json:"total"`
+	Rate         int                 `json:"rate"`
+	KeyPrefix    string              `json:"key-prefix"`
+	KeySpaceSize int                 `json:"key-space-size"`
+	ValSize      int                 `json:"val-size"`
+	Watchers     int                 `json:"watchers"`
+	Operations   []scenarioOperation `json:"operations"`
+
+	duration    time.Duration
+	totalWeight float64
+}
+
+type scenarioOperation struct {
+	Type         string  `json:"type"`
+	Weight       float64 `json:"weight"`
+	Serializable bool    `json:"serializable"`
+	Limit        int64   `json:"limit"`
+}
+
+type scenarioRequest struct {
+	// idx is the index of the operation in the scenario.
+	idx   int
+	start time.Time
+}
+
+func loadScenario(path string) (*scenarioConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sc := &scenarioConfig{
+		KeyPrefix:    "/benchmark/",
+		KeySpaceSize: 1000,
+		ValSize:      8,
+	}
+	if err = yaml.UnmarshalStrict(b, sc); err != nil {
+		return nil, err
+	}
+	if sc.Duration != "" {
+		if sc.duration, err = time.ParseDuration(sc.Duration); err != nil {
+			return nil, err
+		}
+	}
+	if sc.duration <= 0 && sc.Total <= 0 {
+		return nil, errors.New("either a positive duration or total is required")
+	}
+	if sc.KeySpaceSize <= 0 {
+		return nil, fmt.Errorf("expected positive key-space-size, got %d", sc.KeySpaceSize)
+	}
+	// values hold the time they were sent, to measure the latency of watchers.
+	if sc.ValSize < 8 {
+		return nil, fmt.Errorf("expected val-size of at least 8, got %d", sc.ValSize)
+	}
+	if len(sc.Operations) == 0 {
+		return nil, errors.New("no operations")
+	}
+	for _, op := range sc.Operations {
+		switch op.Type {
+		case scenarioRange, scenarioPut, scenarioDelete, scenarioTxn:
+		default:
+			return nil, fmt.Errorf("unknown operation type %q", op.Type)
+		}
+		if op.Weight <= 0 {
+			return nil, fmt.Errorf("expected positive weight of %s, got %v", op.Type, op.Weight)
+		}
+		sc.totalWeight += op.Weight
+	}
+	return sc, nil
+}
+
+// pick returns the index of a random operation in proportion to the weights.
+func (sc *scenarioConfig) pick() int {
+	w := rand.Float64() * sc.totalWeight
+	for i, op := range sc.Operations {
+		if w < op.Weight {
+			return i
+		}
+		w -= op.Weight
+	}
+	return len(sc.Operations) - 1
+}
+
+func (sc *scenarioConfig) op(idx int, val []byte) v3.Op {
+	o := sc.Operations[idx]
+	k := fmt.Sprintf("%s%d", sc.KeyPrefix, rand.Intn(sc.KeySpaceSize))
+	switch o.Type {
+	case scenarioRange:
+		var opts []v3.OpOption
+		if o.Serializable {
+			opts = append(opts, v3.WithSerializable())
+		}
+		if o.Limit > 0 {
+			k = sc.KeyPrefix
+			opts = append(opts, v3.WithPrefix(), v3.WithLimit(o.Limit))
+		}
+		return v3.OpGet(k, opts...)
+	case scenarioDelete:
+		return v3.OpDelete(k)
+	case scenarioTxn:
+		v := string(stampValue(val))
+		return v3.OpTxn(
+			[]v3.Cmp{v3.Compare(v3.CreateRevision(k), "=", 0)},
+			[]v3.Op{v3.OpPut(k, v)},
+			[]v3.Op{v3.OpGet(k), v3.OpPut(k, v)},
+		)
+	default:
+		return v3.OpPut(k, string(stampValue(val)))
+	}
+}
+
+// stampValue writes the current time at the beginning of the value.
+func stampValue(val []byte) []byte {
+	binary.BigEndian.PutUint64(val, uint64(time.Now().UnixNano()))
+	return val
+}
+
+func scenarioFunc(_ *cobra.Command, _ []string) {
+	sc, err := loadScenario(scenarioConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bad scenario config %q: %v\n", scenarioConfigPath, err)
+		os.Exit(1)
+	}
+
+	clients := mustCreateClients(totalClients, totalConns)
+
+	wctx, wcancel := context.WithCancel(context.Background())
+	watchReport := newReport()
+	watchResults := watchReport.Run()
+	var wwg sync.WaitGroup
+	for i := 0; i < sc.Watchers; i++ {
+		wch := clients[i%len(clients)].Watch(wctx, sc.KeyPrefix, v3.WithPrefix(), v3.WithCreatedNotify())
+		if resp := <-wch; resp.Err() != nil {
+			fmt.Fprintf(os.Stderr, "failed to create watcher: %v\n", resp.Err())
+			os.Exit(1)
+		}
+		wwg.Add(1)
+		go func() {
+			defer wwg.Done()
+			for resp := range wch {
+				now := time.Now()
+				for _, ev := range resp.Events {
+					if ev.Type != v3.EventTypePut || len(ev.Kv.Value) < 8 {
+						continue
+					}
+					sent := time.Unix(0, int64(binary.BigEndian.Uint64(ev.Kv.Value)))
+					watchReport.Results() <- report.Result{Start: sent, End: now}
+				}
+			}
+		}()
+	}
+
+	ctx := context.Background()
+	if sc.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sc.duration)
+		defer cancel()
+	}
+
+	reports := make([]report.Report, len(sc.Operations))
+	results := make([]<-chan string, len(sc.Operations))
+	for i := range reports {
+		reports[i] = newReport()
+		results[i] = reports[i].Run()
+	}
+
+	if sc.Total > 0 {
+		bar = pb.New(sc.Total)
+		bar.Start()
+	}
+
+	limit := rate.Inf
+	if sc.Rate > 0 {
+		limit = rate.Limit(sc.Rate)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+	requests := make(chan scenarioRequest, totalClients)
+	go func() {
+		defer close(requests)
+		for i := 0; sc.Total == 0 || i < sc.Total; i++ {
+			if limiter.Wait(ctx) != nil {
+				return
+			}
+			select {
+			case requests <- scenarioRequest{idx: sc.pick(), start: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := range clients {
+		wg.Add(1)
+		go func(c *v3.Client) {
+			defer wg.Done()
+			val := mustRandBytes(sc.ValSize)
+			for req := range requests {
+				_, err := c.Do(context.TODO(), sc.op(req.idx, val))
+				reports[req.idx].Results() <- report.Result{Err: err, Start: req.start, End: time.Now()}
+				if bar != nil {
+					bar.Increment()
+				}
+			}
+		}(clients[i])
+	}
+
+	wg.Wait()
+	for _, r := range reports {
+		close(r.Results())
+	}
+	if bar != nil {
+		bar.Finish()
+	}
+	// leave time for the watchers to receive the last events.
+	time.Sleep(time.Second)
+	wcancel()
+	wwg.Wait()
+	close(watchReport.Results())
+
+	for i, op := range sc.Operations {
+		fmt.Printf("\n%s (weight %v) summary:\n%s", op.Type, op.Weight, <-results[i])
+	}
+	if sc.Watchers > 0 {
+		fmt.Printf("\n%s (%d watchers) summary:\n%s", scenarioWatch, sc.Watchers, <-watchResults)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/report"
+)
+
+// watchFanoutCmd represents the watch fan-out command
+var watchFanoutCmd = &cobra.Command{
+	Use:   "watch-fanout",
+	Short: "Benchmark watch fan-out and event delivery latency",
+	Long: `Benchmarks the fan-out of events to many watchers. It creates
+--watchers watchers spread over --keys keys and --streams watch streams,
+puts random keys at --put-rate, and measures the latency between sending
+a put and each watcher receiving its event.
+
+After the puts, it reports the events that were never delivered, the
+watchers canceled by the server, and the slow watchers whose worst
+delivery latency exceeded --slow-threshold.`,
+	Run: watchFanoutFunc,
+}
+
+var (
+	watchFStreams       int
+	watchFWatchers      int
+	watchFKeys          int
+	watchFPutRate       int
+	watchFPutTotal      int
+	watchFValueSize     int
+	watchFSlowThreshold time.Duration
+	watchFDrainTimeout  time.Duration
+)
+
+func init() {
+	RootCmd.AddCommand(watchFanoutCmd)
+	watchFanoutCmd.Flags().IntVar(&watchFStreams, "streams", 10, "Total watch streams")
+	watchFanoutCmd.Flags().IntVar(&watchFWatchers, "watchers", 1000, "Total watchers")
+	watchFanoutCmd.Flags().IntVar(&watchFKeys, "keys", 10, "Total watched keys")
+
+	watchFanoutCmd.Flags().IntVar(&watchFPutTotal, "put-total", 1000, "Total number of put requests")
+	watchFanoutCmd.Flags().IntVar(&watchFPutRate, "put-rate", 100, "Number of keys to put per second")
+	watchFanoutCmd.Flags().IntVar(&watchFValueSize, "val-size", 32, "Value size of put requests")
+
+	watchFanoutCmd.Flags().DurationVar(&watchFSlowThreshold, "slow-threshold", 100*time.Millisecond, "Delivery latency above which a watcher is reported as slow")
+	watchFanoutCmd.Flags().DurationVar(&watchFDrainTimeout, "drain-timeout", 10*time.Second, "Time to wait for the remaining events after the last put")
+}
+
+type fanoutWatcher struct {
+	key      int
+	received atomic.Int64
+	// maxLatency is the worst delivery latency, in nanoseconds.
+	maxLatency atomic.Int64
+	canceled   atomic.Bool
+}
+
+func watchFanoutFunc(cmd *cobra.Command, _ []string) {
+	if watchFStreams <= 0 || watchFWatchers <= 0 || watchFKeys <= 0 || watchFPutRate <= 0 {
+		fmt.Fprintln(os.Stderr, cmd.Usage())
+		os.Exit(1)
+	}
+	// values hold the time they were sent.
+	if watchFValueSize < 8 {
+		watchFValueSize = 8
+	}
+
+	keys := make([]string, watchFKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("watch-fanout/%d", i)
+	}
+	clients := mustCreateClients(totalClients, totalConns)
+
+	streams := make([]clientv3.Watcher, watchFStreams)
+	for i := range streams {
+		streams[i] = clientv3.NewWatcher(clients[i%len(clients)])
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watchReport := newReport()
+	watchReportResults := watchReport.Run()
+	watchers := make([]*fanoutWatcher, watchFWatchers)
+	var wwg sync.WaitGroup
+	for i := range watchers {
+		w := &fanoutWatcher{key: i % len(keys)}
+		watchers[i] = w
+		wch := streams[i%len(streams)].Watch(ctx, keys[w.key], clientv3.WithCreatedNotify())
+		if resp := <-wch; resp.Err() != nil {
+			fmt.Fprintf(os.Stderr, "failed to create watcher: %v\n", resp.Err())
+			os.Exit(1)
+		}
+		wwg.Add(1)
+		go func() {
+			defer wwg.Done()
+			for resp := range wch {
+				if resp.Canceled {
+					w.canceled.Store(true)
+					return
+				}
+				now := time.Now()
+				for _, ev := range resp.Events {
+					sent := time.Unix(0, int64(binary.BigEndian.Uint64(ev.Kv.Value)))
+					if lat := int64(now.Sub(sent)); lat > w.maxLatency.Load() {
+						w.maxLatency.Store(lat)
+					}
+					w.received.Add(1)
+					watchReport.Results() <- report.Result{Start: sent, End: now}
+				}
+			}
+		}()
+	}
+
+	bar = pb.New(watchFPutTotal)
+	bar.Start()
+
+	putReport := newReport()
+	putReportResults := putReport.Run()
+	// puts counts the successful puts of each key.
+	puts := make([]atomic.Int64, len(keys))
+	putc := make(chan int, totalClients)
+	limiter := rate.NewLimiter(rate.Limit(watchFPutRate), 1)
+	go func() {
+		defer close(putc)
+		for i := 0; i < watchFPutTotal; i++ {
+			limiter.Wait(context.TODO())
+			putc <- rand.Intn(len(keys))
+		}
+	}()
+	for i := range clients {
+		wg.Add(1)
+		go func(c *clientv3.Client) {
+			defer wg.Done()
+			val := mustRandBytes(watchFValueSize)
+			for k := range putc {
+				st := time.Now()
+				_, err := c.Put(context.TODO(), keys[k], string(stampValue(val)))
+				if err == nil {
+					puts[k].Add(1)
+				}
+				putReport.Results() <- report.Result{Err: err, Start: st, End: time.Now()}
+				bar.Increment()
+			}
+		}(clients[i])
+	}
+	wg.Wait()
+	close(putReport.Results())
+	bar.Finish()
+
+	// wait for the watchers to receive the events of all puts.
+	var expected int64
+	for _, w := range watchers {
+		expected += puts[w.key].Load()
+	}
+	deadline := time.Now().Add(watchFDrainTimeout)
+	for time.Now().Before(deadline) {
+		var received int64
+		for _, w := range watchers {
+			received += w.received.Load()
+		}
+		if received >= expected {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	wwg.Wait()
+	close(watchReport.Results())
+
+	var received int64
+	var canceled, slow int
+	for _, w := range watchers {
+		received += w.received.Load()
+		if w.canceled.Load() {
+			canceled++
+		}
+		if time.Duration(w.maxLatency.Load()) > watchFSlowThreshold {
+			slow++
+		}
+	}
+
+	fmt.Printf("\nPut summary:\n%s", <-putReportResults)
+	fmt.Printf("\nWatch event delivery summary:\n%s", <-watchReportResults)
+	fmt.Printf("\nWatch fan-out summary:\n")
+	fmt.Printf("  Watchers:\t%d over %d keys and %d streams.\n", len(watchers), len(keys), len(streams))
+	fmt.Printf("  Events:\t%d delivered, %d expected, %d dropped.\n", received, expected, expected-received)
+	fmt.Printf("  Canceled watchers:\t%d.\n", canceled)
+	fmt.Printf("  Slow watchers (> %v):\t%d.\n", watchFSlowThreshold, slow)
+}